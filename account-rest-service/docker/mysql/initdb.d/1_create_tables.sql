@@ -15,6 +15,7 @@ CREATE TABLE medium_categories
   id INT NOT NULL AUTO_INCREMENT,
   category_name VARCHAR(10) NOT NULL,
   big_category_id INT NOT NULL,
+  is_fallback BOOLEAN NOT NULL DEFAULT FALSE,
   PRIMARY KEY(id),
   UNIQUE uq_medium_category(category_name, big_category_id),
   FOREIGN KEY fk_big_category_id(big_category_id)
@@ -22,12 +23,36 @@ CREATE TABLE medium_categories
     ON DELETE RESTRICT ON UPDATE CASCADE
 );
 
+CREATE TABLE big_category_translations
+(
+  big_category_id INT NOT NULL,
+  locale VARCHAR(5) NOT NULL,
+  category_name VARCHAR(30) NOT NULL,
+  PRIMARY KEY(big_category_id, locale),
+  FOREIGN KEY fk_big_category_id(big_category_id)
+    REFERENCES big_categories(id)
+    ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE medium_category_translations
+(
+  medium_category_id INT NOT NULL,
+  locale VARCHAR(5) NOT NULL,
+  category_name VARCHAR(30) NOT NULL,
+  PRIMARY KEY(medium_category_id, locale),
+  FOREIGN KEY fk_medium_category_id(medium_category_id)
+    REFERENCES medium_categories(id)
+    ON DELETE CASCADE ON UPDATE CASCADE
+);
+
 CREATE TABLE custom_categories
 (
   id INT NOT NULL AUTO_INCREMENT,
   category_name VARCHAR(50) NOT NULL,
   big_category_id INT NOT NULL,
   user_id VARCHAR(10) NOT NULL,
+  display_order INT NOT NULL DEFAULT 0,
+  is_archived BOOLEAN NOT NULL DEFAULT FALSE,
   PRIMARY KEY(id),
   UNIQUE uq_custom_category(category_name, big_category_id, user_id),
   FOREIGN KEY fk_big_category_id(big_category_id)
@@ -36,6 +61,20 @@ CREATE TABLE custom_categories
   INDEX idx_user_id(user_id, id)
 );
 
+CREATE TABLE shopping_list_merge_revert_failures
+(
+  id INT NOT NULL AUTO_INCREMENT,
+  user_id VARCHAR(10) NOT NULL,
+  source_custom_category_id INT NOT NULL,
+  target_custom_category_id INT NOT NULL,
+  shopping_item_id_list JSON NOT NULL,
+  regular_shopping_item_id_list JSON NOT NULL,
+  error_message VARCHAR(255) NOT NULL,
+  created_date DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(id),
+  INDEX idx_user_id(user_id, id)
+);
+
 CREATE TABLE transactions
 (
   id INT NOT NULL AUTO_INCREMENT,
@@ -50,7 +89,9 @@ CREATE TABLE transactions
   big_category_id INT NOT NULL,
   medium_category_id INT DEFAULT NULL,
   custom_category_id INT DEFAULT NULL,
+  idempotency_key VARCHAR(64) DEFAULT NULL,
   PRIMARY KEY(id),
+  UNIQUE uq_idempotency_key(user_id, idempotency_key),
   FOREIGN KEY fk_big_category_id(big_category_id)
     REFERENCES big_categories(id)
     ON DELETE RESTRICT ON UPDATE CASCADE,
@@ -68,6 +109,9 @@ CREATE TABLE standard_budgets
   user_id VARCHAR(10) NOT NULL,
   big_category_id INT NOT NULL,
   budget INT NOT NULL DEFAULT 0,
+  rollover bit(1) NOT NULL DEFAULT b'0',
+  rollover_cap INT DEFAULT NULL,
+  rollover_start_month DATE DEFAULT NULL,
   PRIMARY KEY(user_id, big_category_id),
   FOREIGN KEY fk_big_category_id(big_category_id)
     REFERENCES big_categories(id)
@@ -86,6 +130,91 @@ CREATE TABLE custom_budgets
     ON DELETE RESTRICT ON UPDATE CASCADE
 );
 
+CREATE TABLE standard_child_budgets
+(
+  id INT NOT NULL AUTO_INCREMENT,
+  user_id VARCHAR(10) NOT NULL,
+  big_category_id INT NOT NULL,
+  medium_category_id INT DEFAULT NULL,
+  custom_category_id INT DEFAULT NULL,
+  budget INT NOT NULL DEFAULT 0,
+  PRIMARY KEY(id),
+  FOREIGN KEY fk_standard_budget(user_id, big_category_id)
+    REFERENCES standard_budgets(user_id, big_category_id)
+    ON DELETE CASCADE ON UPDATE CASCADE,
+  FOREIGN KEY fk_medium_category_id(medium_category_id)
+    REFERENCES medium_categories(id)
+    ON DELETE RESTRICT ON UPDATE CASCADE,
+  FOREIGN KEY fk_custom_category_id(custom_category_id)
+    REFERENCES custom_categories(id)
+    ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE custom_child_budgets
+(
+  id INT NOT NULL AUTO_INCREMENT,
+  user_id VARCHAR(10) NOT NULL,
+  years_months DATE NOT NULL,
+  big_category_id INT NOT NULL,
+  medium_category_id INT DEFAULT NULL,
+  custom_category_id INT DEFAULT NULL,
+  budget INT NOT NULL,
+  PRIMARY KEY(id),
+  FOREIGN KEY fk_custom_budget(user_id, years_months, big_category_id)
+    REFERENCES custom_budgets(user_id, years_months, big_category_id)
+    ON DELETE CASCADE ON UPDATE CASCADE,
+  FOREIGN KEY fk_medium_category_id(medium_category_id)
+    REFERENCES medium_categories(id)
+    ON DELETE RESTRICT ON UPDATE CASCADE,
+  FOREIGN KEY fk_custom_category_id(custom_category_id)
+    REFERENCES custom_categories(id)
+    ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE budget_templates
+(
+  id INT NOT NULL AUTO_INCREMENT,
+  template_name VARCHAR(20) NOT NULL,
+  user_id VARCHAR(10) NOT NULL,
+  PRIMARY KEY(id),
+  UNIQUE uq_budget_template(template_name, user_id),
+  INDEX idx_user_id(user_id, id)
+);
+
+CREATE TABLE budget_template_budgets
+(
+  template_id INT NOT NULL,
+  big_category_id INT NOT NULL,
+  budget INT NOT NULL,
+  PRIMARY KEY(template_id, big_category_id),
+  FOREIGN KEY fk_template_id(template_id)
+    REFERENCES budget_templates(id)
+    ON DELETE CASCADE ON UPDATE CASCADE,
+  FOREIGN KEY fk_big_category_id(big_category_id)
+    REFERENCES big_categories(id)
+    ON DELETE RESTRICT ON UPDATE CASCADE
+);
+
+CREATE TABLE budget_template_child_budgets
+(
+  id INT NOT NULL AUTO_INCREMENT,
+  template_id INT NOT NULL,
+  big_category_id INT NOT NULL,
+  medium_category_id INT DEFAULT NULL,
+  custom_category_id INT DEFAULT NULL,
+  budget INT NOT NULL,
+  PRIMARY KEY(id),
+  FOREIGN KEY fk_budget_template_budget(template_id, big_category_id)
+    REFERENCES budget_template_budgets(template_id, big_category_id)
+    ON DELETE CASCADE ON UPDATE CASCADE,
+  FOREIGN KEY fk_medium_category_id(medium_category_id)
+    REFERENCES medium_categories(id)
+    ON DELETE RESTRICT ON UPDATE CASCADE,
+  FOREIGN KEY fk_custom_category_id(custom_category_id)
+    REFERENCES custom_categories(id)
+    ON DELETE CASCADE ON UPDATE CASCADE
+);
+
 CREATE TABLE group_custom_categories
 (
   id INT NOT NULL AUTO_INCREMENT,
@@ -117,7 +246,9 @@ CREATE TABLE group_transactions
   big_category_id INT NOT NULL,
   medium_category_id INT DEFAULT NULL,
   custom_category_id INT DEFAULT NULL,
+  idempotency_key VARCHAR(64) DEFAULT NULL,
   PRIMARY KEY(id),
+  UNIQUE uq_idempotency_key(group_id, idempotency_key),
   FOREIGN KEY fk_big_category_id(big_category_id)
     REFERENCES big_categories(id)
     ON DELETE RESTRICT ON UPDATE CASCADE,
@@ -135,6 +266,9 @@ CREATE TABLE group_standard_budgets
   group_id INT NOT NULL,
   big_category_id INT NOT NULL,
   budget INT NOT NULL DEFAULT 0,
+  rollover bit(1) NOT NULL DEFAULT b'0',
+  rollover_cap INT DEFAULT NULL,
+  rollover_start_month DATE DEFAULT NULL,
   PRIMARY KEY(group_id, big_category_id),
   FOREIGN KEY fk_big_category_id(big_category_id)
     REFERENCES big_categories(id)
@@ -153,6 +287,47 @@ CREATE TABLE group_custom_budgets
     ON DELETE RESTRICT ON UPDATE CASCADE
 );
 
+CREATE TABLE group_standard_child_budgets
+(
+  id INT NOT NULL AUTO_INCREMENT,
+  group_id INT NOT NULL,
+  big_category_id INT NOT NULL,
+  medium_category_id INT DEFAULT NULL,
+  custom_category_id INT DEFAULT NULL,
+  budget INT NOT NULL DEFAULT 0,
+  PRIMARY KEY(id),
+  FOREIGN KEY fk_group_standard_budget(group_id, big_category_id)
+    REFERENCES group_standard_budgets(group_id, big_category_id)
+    ON DELETE CASCADE ON UPDATE CASCADE,
+  FOREIGN KEY fk_medium_category_id(medium_category_id)
+    REFERENCES medium_categories(id)
+    ON DELETE RESTRICT ON UPDATE CASCADE,
+  FOREIGN KEY fk_custom_category_id(custom_category_id)
+    REFERENCES group_custom_categories(id)
+    ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE group_custom_child_budgets
+(
+  id INT NOT NULL AUTO_INCREMENT,
+  group_id INT NOT NULL,
+  years_months DATE NOT NULL,
+  big_category_id INT NOT NULL,
+  medium_category_id INT DEFAULT NULL,
+  custom_category_id INT DEFAULT NULL,
+  budget INT NOT NULL,
+  PRIMARY KEY(id),
+  FOREIGN KEY fk_group_custom_budget(group_id, years_months, big_category_id)
+    REFERENCES group_custom_budgets(group_id, years_months, big_category_id)
+    ON DELETE CASCADE ON UPDATE CASCADE,
+  FOREIGN KEY fk_medium_category_id(medium_category_id)
+    REFERENCES medium_categories(id)
+    ON DELETE RESTRICT ON UPDATE CASCADE,
+  FOREIGN KEY fk_custom_category_id(custom_category_id)
+    REFERENCES group_custom_categories(id)
+    ON DELETE CASCADE ON UPDATE CASCADE
+);
+
 CREATE TABLE group_accounts
 (
   id INT NOT NULL AUTO_INCREMENT,
@@ -22,104 +22,230 @@ VALUES
 
 -- medium_categories table default data
 INSERT INTO medium_categories
-  (id, category_name, big_category_id)
+  (id, category_name, big_category_id, is_fallback)
 VALUES
-  (1, "給与", 1),
-  (2, "賞与", 1),
-  (3, "一時所得", 1),
-  (4, "事業所得", 1),
-  (5, "その他収入", 1),
-  (6, "食料品", 2),
-  (7, "朝食", 2),
-  (8, "昼食", 2),
-  (9, "夕食", 2),
-  (10, "外食", 2),
-  (11, "カフェ", 2),
-  (12, "その他食費", 2),
-  (13, "消耗品", 3),
-  (14, "子育て用品", 3),
-  (15, "ペット用品", 3),
-  (16, "家具", 3),
-  (17, "家電", 3),
-  (18, "その他日用品", 3),
-  (19, "アウトドア", 4),
-  (20, "旅行", 4),
-  (21, "イベント", 4),
-  (22, "スポーツ", 4),
-  (23, "映画・動画", 4),
-  (24, "音楽", 4),
-  (25, "漫画", 4),
-  (26, "書籍", 4),
-  (27, "ゲーム", 4),
-  (28, "その他趣味・娯楽", 4),
-  (29, "飲み会", 5),
-  (30, "プレゼント", 5),
-  (31, "冠婚葬祭", 5),
-  (32, "その他交際費", 5),
-  (33, "電車", 6),
-  (34, "バス", 6),
-  (35, "タクシー", 6),
-  (36, "新幹線", 6),
-  (37, "飛行機", 6),
-  (38, "その他交通費", 6),
-  (39, "衣服", 7),
-  (40, "アクセサリー", 7),
-  (41, "クリーニング", 7),
-  (42, "美容院・理髪", 7),
-  (43, "化粧品", 7),
-  (44, "エステ・ネイル", 7),
-  (45, "その他衣服・美容", 7),
-  (46, "病院", 8),
-  (47, "薬", 8),
-  (48, "ボディケア", 8),
-  (49, "フィットネス", 8),
-  (50, "その他健康・医療", 8),
-  (51, "携帯電話", 9),
-  (52, "固定電話", 9),
-  (53, "インターネット", 9),
-  (54, "放送サービス", 9),
-  (55, "情報サービス", 9),
-  (56, "宅配・運送", 9),
-  (57, "切手・はがき", 9),
-  (58, "その他通信費", 9),
-  (59, "新聞", 10),
-  (60, "参考書", 10),
-  (61, "受験料", 10),
-  (62, "学費", 10),
-  (63, "習い事", 10),
-  (64, "塾", 10),
-  (65, "その他教養・教育", 10),
-  (66, "家賃", 11),
-  (67, "住宅ローン", 11),
-  (68, "リフォーム", 11),
-  (69, "その他住宅", 11),
-  (70, "水道", 12),
-  (71, "電気", 12),
-  (72, "ガス", 12),
-  (73, "その他水道・光熱費", 12),
-  (74, "自動車ローン", 13),
-  (75, "ガソリン", 13),
-  (76, "駐車場", 13),
-  (77, "高速料金", 13),
-  (78, "車検・整備", 13),
-  (79, "その他自動車", 13),
-  (80, "生命保険", 14),
-  (81, "医療保険", 14),
-  (82, "自動車保険", 14),
-  (83, "住宅保険", 14),
-  (84, "学資保険", 14),
-  (85, "その他保険", 14),
-  (86, "所得税", 15),
-  (87, "住民税", 15),
-  (88, "年金保険料", 15),
-  (89, "自動車税", 15),
-  (90, "その他税金・社会保険", 15),
-  (91, "現金引き出し", 16),
-  (92, "カード引き落とし", 16),
-  (93, "電子マネー", 16),
-  (94, "立替金", 16),
-  (95, "その他現金・カード", 16),
-  (96, "仕送り", 17),
-  (97, "お小遣い", 17),
-  (98, "使途不明金", 17),
-  (99, "雑費", 17);
+  (1, "給与", 1, FALSE),
+  (2, "賞与", 1, FALSE),
+  (3, "一時所得", 1, FALSE),
+  (4, "事業所得", 1, FALSE),
+  (5, "その他収入", 1, TRUE),
+  (6, "食料品", 2, FALSE),
+  (7, "朝食", 2, FALSE),
+  (8, "昼食", 2, FALSE),
+  (9, "夕食", 2, FALSE),
+  (10, "外食", 2, FALSE),
+  (11, "カフェ", 2, FALSE),
+  (12, "その他食費", 2, TRUE),
+  (13, "消耗品", 3, FALSE),
+  (14, "子育て用品", 3, FALSE),
+  (15, "ペット用品", 3, FALSE),
+  (16, "家具", 3, FALSE),
+  (17, "家電", 3, FALSE),
+  (18, "その他日用品", 3, TRUE),
+  (19, "アウトドア", 4, FALSE),
+  (20, "旅行", 4, FALSE),
+  (21, "イベント", 4, FALSE),
+  (22, "スポーツ", 4, FALSE),
+  (23, "映画・動画", 4, FALSE),
+  (24, "音楽", 4, FALSE),
+  (25, "漫画", 4, FALSE),
+  (26, "書籍", 4, FALSE),
+  (27, "ゲーム", 4, FALSE),
+  (28, "その他趣味・娯楽", 4, TRUE),
+  (29, "飲み会", 5, FALSE),
+  (30, "プレゼント", 5, FALSE),
+  (31, "冠婚葬祭", 5, FALSE),
+  (32, "その他交際費", 5, TRUE),
+  (33, "電車", 6, FALSE),
+  (34, "バス", 6, FALSE),
+  (35, "タクシー", 6, FALSE),
+  (36, "新幹線", 6, FALSE),
+  (37, "飛行機", 6, FALSE),
+  (38, "その他交通費", 6, TRUE),
+  (39, "衣服", 7, FALSE),
+  (40, "アクセサリー", 7, FALSE),
+  (41, "クリーニング", 7, FALSE),
+  (42, "美容院・理髪", 7, FALSE),
+  (43, "化粧品", 7, FALSE),
+  (44, "エステ・ネイル", 7, FALSE),
+  (45, "その他衣服・美容", 7, TRUE),
+  (46, "病院", 8, FALSE),
+  (47, "薬", 8, FALSE),
+  (48, "ボディケア", 8, FALSE),
+  (49, "フィットネス", 8, FALSE),
+  (50, "その他健康・医療", 8, TRUE),
+  (51, "携帯電話", 9, FALSE),
+  (52, "固定電話", 9, FALSE),
+  (53, "インターネット", 9, FALSE),
+  (54, "放送サービス", 9, FALSE),
+  (55, "情報サービス", 9, FALSE),
+  (56, "宅配・運送", 9, FALSE),
+  (57, "切手・はがき", 9, FALSE),
+  (58, "その他通信費", 9, TRUE),
+  (59, "新聞", 10, FALSE),
+  (60, "参考書", 10, FALSE),
+  (61, "受験料", 10, FALSE),
+  (62, "学費", 10, FALSE),
+  (63, "習い事", 10, FALSE),
+  (64, "塾", 10, FALSE),
+  (65, "その他教養・教育", 10, TRUE),
+  (66, "家賃", 11, FALSE),
+  (67, "住宅ローン", 11, FALSE),
+  (68, "リフォーム", 11, FALSE),
+  (69, "その他住宅", 11, TRUE),
+  (70, "水道", 12, FALSE),
+  (71, "電気", 12, FALSE),
+  (72, "ガス", 12, FALSE),
+  (73, "その他水道・光熱費", 12, TRUE),
+  (74, "自動車ローン", 13, FALSE),
+  (75, "ガソリン", 13, FALSE),
+  (76, "駐車場", 13, FALSE),
+  (77, "高速料金", 13, FALSE),
+  (78, "車検・整備", 13, FALSE),
+  (79, "その他自動車", 13, TRUE),
+  (80, "生命保険", 14, FALSE),
+  (81, "医療保険", 14, FALSE),
+  (82, "自動車保険", 14, FALSE),
+  (83, "住宅保険", 14, FALSE),
+  (84, "学資保険", 14, FALSE),
+  (85, "その他保険", 14, TRUE),
+  (86, "所得税", 15, FALSE),
+  (87, "住民税", 15, FALSE),
+  (88, "年金保険料", 15, FALSE),
+  (89, "自動車税", 15, FALSE),
+  (90, "その他税金・社会保険", 15, TRUE),
+  (91, "現金引き出し", 16, FALSE),
+  (92, "カード引き落とし", 16, FALSE),
+  (93, "電子マネー", 16, FALSE),
+  (94, "立替金", 16, FALSE),
+  (95, "その他現金・カード", 16, TRUE),
+  (96, "仕送り", 17, FALSE),
+  (97, "お小遣い", 17, FALSE),
+  (98, "使途不明金", 17, TRUE),
+  (99, "雑費", 17, FALSE);
+
+-- big_category_translations table default data
+INSERT INTO big_category_translations
+  (big_category_id, locale, category_name)
+VALUES
+  (1, "en", "Income"),
+  (2, "en", "Food"),
+  (3, "en", "Daily Goods"),
+  (4, "en", "Hobbies"),
+  (5, "en", "Socializing"),
+  (6, "en", "Transportation"),
+  (7, "en", "Clothing & Beauty"),
+  (8, "en", "Health & Medical"),
+  (9, "en", "Communication"),
+  (10, "en", "Education"),
+  (11, "en", "Housing"),
+  (12, "en", "Utilities"),
+  (13, "en", "Car"),
+  (14, "en", "Insurance"),
+  (15, "en", "Taxes & Social Security"),
+  (16, "en", "Cash & Card"),
+  (17, "en", "Other");
+
+-- medium_category_translations table default data
+INSERT INTO medium_category_translations
+  (medium_category_id, locale, category_name)
+VALUES
+  (1, "en", "Salary"),
+  (2, "en", "Bonus"),
+  (3, "en", "Temporary Income"),
+  (4, "en", "Business Income"),
+  (5, "en", "Other Income"),
+  (6, "en", "Groceries"),
+  (7, "en", "Breakfast"),
+  (8, "en", "Lunch"),
+  (9, "en", "Dinner"),
+  (10, "en", "Eating Out"),
+  (11, "en", "Cafe"),
+  (12, "en", "Other Food"),
+  (13, "en", "Consumables"),
+  (14, "en", "Childcare Goods"),
+  (15, "en", "Pet Supplies"),
+  (16, "en", "Furniture"),
+  (17, "en", "Home Appliances"),
+  (18, "en", "Other Daily Goods"),
+  (19, "en", "Outdoor"),
+  (20, "en", "Travel"),
+  (21, "en", "Events"),
+  (22, "en", "Sports"),
+  (23, "en", "Movies & Videos"),
+  (24, "en", "Music"),
+  (25, "en", "Comics"),
+  (26, "en", "Books"),
+  (27, "en", "Games"),
+  (28, "en", "Other Hobbies"),
+  (29, "en", "Drinking Parties"),
+  (30, "en", "Gifts"),
+  (31, "en", "Ceremonies"),
+  (32, "en", "Other Socializing"),
+  (33, "en", "Train"),
+  (34, "en", "Bus"),
+  (35, "en", "Taxi"),
+  (36, "en", "Shinkansen"),
+  (37, "en", "Airplane"),
+  (38, "en", "Other Transportation"),
+  (39, "en", "Clothes"),
+  (40, "en", "Accessories"),
+  (41, "en", "Dry Cleaning"),
+  (42, "en", "Hair Salon"),
+  (43, "en", "Cosmetics"),
+  (44, "en", "Spa & Nails"),
+  (45, "en", "Other Clothing & Beauty"),
+  (46, "en", "Hospital"),
+  (47, "en", "Medicine"),
+  (48, "en", "Body Care"),
+  (49, "en", "Fitness"),
+  (50, "en", "Other Health & Medical"),
+  (51, "en", "Mobile Phone"),
+  (52, "en", "Landline"),
+  (53, "en", "Internet"),
+  (54, "en", "Broadcasting"),
+  (55, "en", "Information Services"),
+  (56, "en", "Delivery & Shipping"),
+  (57, "en", "Stamps & Postcards"),
+  (58, "en", "Other Communication"),
+  (59, "en", "Newspaper"),
+  (60, "en", "Reference Books"),
+  (61, "en", "Exam Fees"),
+  (62, "en", "Tuition"),
+  (63, "en", "Lessons"),
+  (64, "en", "Cram School"),
+  (65, "en", "Other Education"),
+  (66, "en", "Rent"),
+  (67, "en", "Mortgage"),
+  (68, "en", "Renovation"),
+  (69, "en", "Other Housing"),
+  (70, "en", "Water"),
+  (71, "en", "Electricity"),
+  (72, "en", "Gas"),
+  (73, "en", "Other Utilities"),
+  (74, "en", "Car Loan"),
+  (75, "en", "Gasoline"),
+  (76, "en", "Parking"),
+  (77, "en", "Highway Tolls"),
+  (78, "en", "Car Inspection"),
+  (79, "en", "Other Car"),
+  (80, "en", "Life Insurance"),
+  (81, "en", "Medical Insurance"),
+  (82, "en", "Car Insurance"),
+  (83, "en", "Home Insurance"),
+  (84, "en", "Education Insurance"),
+  (85, "en", "Other Insurance"),
+  (86, "en", "Income Tax"),
+  (87, "en", "Resident Tax"),
+  (88, "en", "Pension Premiums"),
+  (89, "en", "Car Tax"),
+  (90, "en", "Other Taxes"),
+  (91, "en", "Cash Withdrawal"),
+  (92, "en", "Card Payment"),
+  (93, "en", "E-Money"),
+  (94, "en", "Advance Payment"),
+  (95, "en", "Other Cash & Card"),
+  (96, "en", "Allowance to Family"),
+  (97, "en", "Pocket Money"),
+  (98, "en", "Unknown Expenses"),
+  (99, "en", "Miscellaneous");
@@ -0,0 +1,9 @@
+ALTER TABLE standard_budgets
+  ADD rollover bit(1) NOT NULL DEFAULT b'0' AFTER budget,
+  ADD rollover_cap INT DEFAULT NULL AFTER rollover,
+  ADD rollover_start_month DATE DEFAULT NULL AFTER rollover_cap;
+
+ALTER TABLE group_standard_budgets
+  ADD rollover bit(1) NOT NULL DEFAULT b'0' AFTER budget,
+  ADD rollover_cap INT DEFAULT NULL AFTER rollover,
+  ADD rollover_start_month DATE DEFAULT NULL AFTER rollover_cap;
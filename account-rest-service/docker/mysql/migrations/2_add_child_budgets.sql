@@ -0,0 +1,81 @@
+CREATE TABLE standard_child_budgets
+(
+  id INT NOT NULL AUTO_INCREMENT,
+  user_id VARCHAR(10) NOT NULL,
+  big_category_id INT NOT NULL,
+  medium_category_id INT DEFAULT NULL,
+  custom_category_id INT DEFAULT NULL,
+  budget INT NOT NULL DEFAULT 0,
+  PRIMARY KEY(id),
+  FOREIGN KEY fk_standard_budget(user_id, big_category_id)
+    REFERENCES standard_budgets(user_id, big_category_id)
+    ON DELETE CASCADE ON UPDATE CASCADE,
+  FOREIGN KEY fk_medium_category_id(medium_category_id)
+    REFERENCES medium_categories(id)
+    ON DELETE RESTRICT ON UPDATE CASCADE,
+  FOREIGN KEY fk_custom_category_id(custom_category_id)
+    REFERENCES custom_categories(id)
+    ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE custom_child_budgets
+(
+  id INT NOT NULL AUTO_INCREMENT,
+  user_id VARCHAR(10) NOT NULL,
+  years_months DATE NOT NULL,
+  big_category_id INT NOT NULL,
+  medium_category_id INT DEFAULT NULL,
+  custom_category_id INT DEFAULT NULL,
+  budget INT NOT NULL,
+  PRIMARY KEY(id),
+  FOREIGN KEY fk_custom_budget(user_id, years_months, big_category_id)
+    REFERENCES custom_budgets(user_id, years_months, big_category_id)
+    ON DELETE CASCADE ON UPDATE CASCADE,
+  FOREIGN KEY fk_medium_category_id(medium_category_id)
+    REFERENCES medium_categories(id)
+    ON DELETE RESTRICT ON UPDATE CASCADE,
+  FOREIGN KEY fk_custom_category_id(custom_category_id)
+    REFERENCES custom_categories(id)
+    ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE group_standard_child_budgets
+(
+  id INT NOT NULL AUTO_INCREMENT,
+  group_id INT NOT NULL,
+  big_category_id INT NOT NULL,
+  medium_category_id INT DEFAULT NULL,
+  custom_category_id INT DEFAULT NULL,
+  budget INT NOT NULL DEFAULT 0,
+  PRIMARY KEY(id),
+  FOREIGN KEY fk_group_standard_budget(group_id, big_category_id)
+    REFERENCES group_standard_budgets(group_id, big_category_id)
+    ON DELETE CASCADE ON UPDATE CASCADE,
+  FOREIGN KEY fk_medium_category_id(medium_category_id)
+    REFERENCES medium_categories(id)
+    ON DELETE RESTRICT ON UPDATE CASCADE,
+  FOREIGN KEY fk_custom_category_id(custom_category_id)
+    REFERENCES group_custom_categories(id)
+    ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE group_custom_child_budgets
+(
+  id INT NOT NULL AUTO_INCREMENT,
+  group_id INT NOT NULL,
+  years_months DATE NOT NULL,
+  big_category_id INT NOT NULL,
+  medium_category_id INT DEFAULT NULL,
+  custom_category_id INT DEFAULT NULL,
+  budget INT NOT NULL,
+  PRIMARY KEY(id),
+  FOREIGN KEY fk_group_custom_budget(group_id, years_months, big_category_id)
+    REFERENCES group_custom_budgets(group_id, years_months, big_category_id)
+    ON DELETE CASCADE ON UPDATE CASCADE,
+  FOREIGN KEY fk_medium_category_id(medium_category_id)
+    REFERENCES medium_categories(id)
+    ON DELETE RESTRICT ON UPDATE CASCADE,
+  FOREIGN KEY fk_custom_category_id(custom_category_id)
+    REFERENCES group_custom_categories(id)
+    ON DELETE CASCADE ON UPDATE CASCADE
+);
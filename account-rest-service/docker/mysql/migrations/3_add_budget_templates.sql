@@ -0,0 +1,43 @@
+CREATE TABLE budget_templates
+(
+  id INT NOT NULL AUTO_INCREMENT,
+  template_name VARCHAR(20) NOT NULL,
+  user_id VARCHAR(10) NOT NULL,
+  PRIMARY KEY(id),
+  UNIQUE uq_budget_template(template_name, user_id),
+  INDEX idx_user_id(user_id, id)
+);
+
+CREATE TABLE budget_template_budgets
+(
+  template_id INT NOT NULL,
+  big_category_id INT NOT NULL,
+  budget INT NOT NULL,
+  PRIMARY KEY(template_id, big_category_id),
+  FOREIGN KEY fk_template_id(template_id)
+    REFERENCES budget_templates(id)
+    ON DELETE CASCADE ON UPDATE CASCADE,
+  FOREIGN KEY fk_big_category_id(big_category_id)
+    REFERENCES big_categories(id)
+    ON DELETE RESTRICT ON UPDATE CASCADE
+);
+
+CREATE TABLE budget_template_child_budgets
+(
+  id INT NOT NULL AUTO_INCREMENT,
+  template_id INT NOT NULL,
+  big_category_id INT NOT NULL,
+  medium_category_id INT DEFAULT NULL,
+  custom_category_id INT DEFAULT NULL,
+  budget INT NOT NULL,
+  PRIMARY KEY(id),
+  FOREIGN KEY fk_budget_template_budget(template_id, big_category_id)
+    REFERENCES budget_template_budgets(template_id, big_category_id)
+    ON DELETE CASCADE ON UPDATE CASCADE,
+  FOREIGN KEY fk_medium_category_id(medium_category_id)
+    REFERENCES medium_categories(id)
+    ON DELETE RESTRICT ON UPDATE CASCADE,
+  FOREIGN KEY fk_custom_category_id(custom_category_id)
+    REFERENCES custom_categories(id)
+    ON DELETE CASCADE ON UPDATE CASCADE
+);
@@ -0,0 +1,145 @@
+CREATE TABLE big_category_translations
+(
+  big_category_id INT NOT NULL,
+  locale VARCHAR(5) NOT NULL,
+  category_name VARCHAR(30) NOT NULL,
+  PRIMARY KEY(big_category_id, locale),
+  FOREIGN KEY fk_big_category_id(big_category_id)
+    REFERENCES big_categories(id)
+    ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE medium_category_translations
+(
+  medium_category_id INT NOT NULL,
+  locale VARCHAR(5) NOT NULL,
+  category_name VARCHAR(30) NOT NULL,
+  PRIMARY KEY(medium_category_id, locale),
+  FOREIGN KEY fk_medium_category_id(medium_category_id)
+    REFERENCES medium_categories(id)
+    ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+INSERT INTO big_category_translations
+  (big_category_id, locale, category_name)
+VALUES
+  (1, "en", "Income"),
+  (2, "en", "Food"),
+  (3, "en", "Daily Goods"),
+  (4, "en", "Hobbies"),
+  (5, "en", "Socializing"),
+  (6, "en", "Transportation"),
+  (7, "en", "Clothing & Beauty"),
+  (8, "en", "Health & Medical"),
+  (9, "en", "Communication"),
+  (10, "en", "Education"),
+  (11, "en", "Housing"),
+  (12, "en", "Utilities"),
+  (13, "en", "Car"),
+  (14, "en", "Insurance"),
+  (15, "en", "Taxes & Social Security"),
+  (16, "en", "Cash & Card"),
+  (17, "en", "Other");
+
+INSERT INTO medium_category_translations
+  (medium_category_id, locale, category_name)
+VALUES
+  (1, "en", "Salary"),
+  (2, "en", "Bonus"),
+  (3, "en", "Temporary Income"),
+  (4, "en", "Business Income"),
+  (5, "en", "Other Income"),
+  (6, "en", "Groceries"),
+  (7, "en", "Breakfast"),
+  (8, "en", "Lunch"),
+  (9, "en", "Dinner"),
+  (10, "en", "Eating Out"),
+  (11, "en", "Cafe"),
+  (12, "en", "Other Food"),
+  (13, "en", "Consumables"),
+  (14, "en", "Childcare Goods"),
+  (15, "en", "Pet Supplies"),
+  (16, "en", "Furniture"),
+  (17, "en", "Home Appliances"),
+  (18, "en", "Other Daily Goods"),
+  (19, "en", "Outdoor"),
+  (20, "en", "Travel"),
+  (21, "en", "Events"),
+  (22, "en", "Sports"),
+  (23, "en", "Movies & Videos"),
+  (24, "en", "Music"),
+  (25, "en", "Comics"),
+  (26, "en", "Books"),
+  (27, "en", "Games"),
+  (28, "en", "Other Hobbies"),
+  (29, "en", "Drinking Parties"),
+  (30, "en", "Gifts"),
+  (31, "en", "Ceremonies"),
+  (32, "en", "Other Socializing"),
+  (33, "en", "Train"),
+  (34, "en", "Bus"),
+  (35, "en", "Taxi"),
+  (36, "en", "Shinkansen"),
+  (37, "en", "Airplane"),
+  (38, "en", "Other Transportation"),
+  (39, "en", "Clothes"),
+  (40, "en", "Accessories"),
+  (41, "en", "Dry Cleaning"),
+  (42, "en", "Hair Salon"),
+  (43, "en", "Cosmetics"),
+  (44, "en", "Spa & Nails"),
+  (45, "en", "Other Clothing & Beauty"),
+  (46, "en", "Hospital"),
+  (47, "en", "Medicine"),
+  (48, "en", "Body Care"),
+  (49, "en", "Fitness"),
+  (50, "en", "Other Health & Medical"),
+  (51, "en", "Mobile Phone"),
+  (52, "en", "Landline"),
+  (53, "en", "Internet"),
+  (54, "en", "Broadcasting"),
+  (55, "en", "Information Services"),
+  (56, "en", "Delivery & Shipping"),
+  (57, "en", "Stamps & Postcards"),
+  (58, "en", "Other Communication"),
+  (59, "en", "Newspaper"),
+  (60, "en", "Reference Books"),
+  (61, "en", "Exam Fees"),
+  (62, "en", "Tuition"),
+  (63, "en", "Lessons"),
+  (64, "en", "Cram School"),
+  (65, "en", "Other Education"),
+  (66, "en", "Rent"),
+  (67, "en", "Mortgage"),
+  (68, "en", "Renovation"),
+  (69, "en", "Other Housing"),
+  (70, "en", "Water"),
+  (71, "en", "Electricity"),
+  (72, "en", "Gas"),
+  (73, "en", "Other Utilities"),
+  (74, "en", "Car Loan"),
+  (75, "en", "Gasoline"),
+  (76, "en", "Parking"),
+  (77, "en", "Highway Tolls"),
+  (78, "en", "Car Inspection"),
+  (79, "en", "Other Car"),
+  (80, "en", "Life Insurance"),
+  (81, "en", "Medical Insurance"),
+  (82, "en", "Car Insurance"),
+  (83, "en", "Home Insurance"),
+  (84, "en", "Education Insurance"),
+  (85, "en", "Other Insurance"),
+  (86, "en", "Income Tax"),
+  (87, "en", "Resident Tax"),
+  (88, "en", "Pension Premiums"),
+  (89, "en", "Car Tax"),
+  (90, "en", "Other Taxes"),
+  (91, "en", "Cash Withdrawal"),
+  (92, "en", "Card Payment"),
+  (93, "en", "E-Money"),
+  (94, "en", "Advance Payment"),
+  (95, "en", "Other Cash & Card"),
+  (96, "en", "Allowance to Family"),
+  (97, "en", "Pocket Money"),
+  (98, "en", "Unknown Expenses"),
+  (99, "en", "Miscellaneous");
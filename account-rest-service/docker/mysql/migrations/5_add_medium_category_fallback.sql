@@ -0,0 +1,9 @@
+ALTER TABLE medium_categories
+  ADD is_fallback BOOLEAN NOT NULL DEFAULT FALSE AFTER big_category_id;
+
+UPDATE
+  medium_categories
+SET
+  is_fallback = TRUE
+WHERE
+  id IN (5, 12, 18, 28, 32, 38, 45, 50, 58, 65, 69, 73, 79, 85, 90, 95, 98);
@@ -0,0 +1,3 @@
+ALTER TABLE custom_categories
+  ADD display_order INT NOT NULL DEFAULT 0 AFTER user_id,
+  ADD is_archived BOOLEAN NOT NULL DEFAULT FALSE AFTER display_order;
@@ -0,0 +1,13 @@
+CREATE TABLE shopping_list_merge_revert_failures
+(
+  id INT NOT NULL AUTO_INCREMENT,
+  user_id VARCHAR(10) NOT NULL,
+  source_custom_category_id INT NOT NULL,
+  target_custom_category_id INT NOT NULL,
+  shopping_item_id_list JSON NOT NULL,
+  regular_shopping_item_id_list JSON NOT NULL,
+  error_message VARCHAR(255) NOT NULL,
+  created_date DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(id),
+  INDEX idx_user_id(user_id, id)
+);
@@ -0,0 +1,7 @@
+ALTER TABLE transactions
+  ADD idempotency_key VARCHAR(64) DEFAULT NULL AFTER custom_category_id,
+  ADD UNIQUE uq_idempotency_key(user_id, idempotency_key);
+
+ALTER TABLE group_transactions
+  ADD idempotency_key VARCHAR(64) DEFAULT NULL AFTER custom_category_id,
+  ADD UNIQUE uq_idempotency_key(group_id, idempotency_key);
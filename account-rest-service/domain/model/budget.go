@@ -1,6 +1,7 @@
 package model
 
 import (
+	"database/sql"
 	"database/sql/driver"
 	"errors"
 	"strconv"
@@ -13,10 +14,11 @@ type StandardBudgets struct {
 }
 
 type StandardBudgetByCategory struct {
-	BigCategoryID     int    `json:"big_category_id"      db:"big_category_id"`
-	BigCategoryName   string `json:"big_category_name"    db:"big_category_name"`
-	Budget            int    `json:"budget"               db:"budget"`
-	LastMonthExpenses int    `json:"last_month_expenses"`
+	BigCategoryID     int                     `json:"big_category_id"      db:"big_category_id"`
+	BigCategoryName   string                  `json:"big_category_name"    db:"big_category_name"`
+	Budget            int                     `json:"budget"               db:"budget"`
+	LastMonthExpenses int                     `json:"last_month_expenses"`
+	ChildBudgets      []ChildBudgetByCategory `json:"child_budgets"`
 }
 
 type CustomBudgets struct {
@@ -24,10 +26,71 @@ type CustomBudgets struct {
 }
 
 type CustomBudgetByCategory struct {
-	BigCategoryID     int    `json:"big_category_id"      db:"big_category_id"`
-	BigCategoryName   string `json:"big_category_name"    db:"big_category_name"`
-	Budget            int    `json:"budget"               db:"budget"`
-	LastMonthExpenses int    `json:"last_month_expenses"`
+	BigCategoryID     int                     `json:"big_category_id"      db:"big_category_id"`
+	BigCategoryName   string                  `json:"big_category_name"    db:"big_category_name"`
+	Budget            int                     `json:"budget"               db:"budget"`
+	Rollover          int                     `json:"rollover"`
+	EffectiveBudget   int                     `json:"effective_budget"`
+	LastMonthExpenses int                     `json:"last_month_expenses"`
+	ChildBudgets      []ChildBudgetByCategory `json:"child_budgets"`
+}
+
+type ChildBudgetByCategory struct {
+	BigCategoryID     int       `json:"-"                    db:"big_category_id"`
+	MediumCategoryID  NullInt64 `json:"medium_category_id"   db:"medium_category_id"`
+	CustomCategoryID  NullInt64 `json:"custom_category_id"   db:"custom_category_id"`
+	CategoryName      string    `json:"category_name"        db:"category_name"`
+	Budget            int       `json:"budget"               db:"budget"`
+	LastMonthExpenses int       `json:"last_month_expenses"`
+}
+
+type BudgetTemplates struct {
+	BudgetTemplates []BudgetTemplate `json:"budget_templates"`
+}
+
+type BudgetTemplate struct {
+	ID                       int                        `json:"id"            db:"id"`
+	Name                     string                     `json:"template_name" db:"template_name"`
+	BudgetTemplateByCategory []BudgetTemplateByCategory `json:"budgets"`
+}
+
+type BudgetTemplateByCategory struct {
+	TemplateID      int                     `json:"-"                 db:"template_id"`
+	BigCategoryID   int                     `json:"big_category_id"   db:"big_category_id"`
+	BigCategoryName string                  `json:"big_category_name" db:"big_category_name"`
+	Budget          int                     `json:"budget"            db:"budget"`
+	ChildBudgets    []ChildBudgetByCategory `json:"child_budgets"`
+}
+
+type BudgetTemplateChildBudget struct {
+	TemplateID int `db:"template_id"`
+	ChildBudgetByCategory
+}
+
+type BudgetTargetMonths struct {
+	FirstMonth string `json:"first_month"`
+	LastMonth  string `json:"last_month"`
+}
+
+type BudgetYearMonths struct {
+	YearMonths []Months `json:"year_months"`
+}
+
+type MonthlyCustomBudgetByCategory struct {
+	Month         time.Time `db:"years_months"`
+	BigCategoryID int       `db:"big_category_id"`
+	Budget        int       `db:"budget"`
+}
+
+type BudgetRolloverSettings struct {
+	BudgetRolloverSettings []BudgetRolloverSetting `json:"rollover_settings"`
+}
+
+type BudgetRolloverSetting struct {
+	BigCategoryID      int          `json:"big_category_id" db:"big_category_id"`
+	Rollover           BitBool      `json:"rollover"        db:"rollover"`
+	RolloverCap        NullInt      `json:"rollover_cap"    db:"rollover_cap"`
+	RolloverStartMonth sql.NullTime `json:"-"               db:"rollover_start_month"`
 }
 
 type YearlyBudget struct {
@@ -37,9 +100,11 @@ type YearlyBudget struct {
 }
 
 type MonthlyBudget struct {
-	Month              Months `json:"month"                db:"years_months"`
-	BudgetType         string `json:"budget_type"`
-	MonthlyTotalBudget int    `json:"monthly_total_budget" db:"total_budget"`
+	Month                       Months `json:"month"                          db:"years_months"`
+	BudgetType                  string `json:"budget_type"`
+	MonthlyTotalBudget          int    `json:"monthly_total_budget"           db:"total_budget"`
+	MonthlyTotalRollover        int    `json:"monthly_total_rollover"`
+	MonthlyTotalEffectiveBudget int    `json:"monthly_total_effective_budget"`
 }
 
 type Months struct {
@@ -47,6 +112,57 @@ type Months struct {
 	String string
 }
 
+type BudgetStatus struct {
+	YearMonth              Months                   `json:"year_month"`
+	BudgetType             string                   `json:"budget_type"`
+	TotalBudget            int                      `json:"total_budget"`
+	TotalExpenses          int                      `json:"total_expenses"`
+	BudgetStatusByCategory []BudgetStatusByCategory `json:"budget_status_by_category"`
+}
+
+type BudgetStatusByCategory struct {
+	BigCategoryID   int    `json:"big_category_id"`
+	BigCategoryName string `json:"big_category_name"`
+	Budget          int    `json:"budget"`
+	Expenses        int    `json:"expenses"`
+	Remaining       int    `json:"remaining"`
+	UsageRate       int    `json:"usage_rate"`
+}
+
+type BudgetForecast struct {
+	YearMonth                Months                     `json:"year_month"`
+	BudgetType               string                     `json:"budget_type"`
+	ForecastMethod           string                     `json:"forecast_method"`
+	ElapsedDays              int                        `json:"elapsed_days"`
+	DaysInMonth              int                        `json:"days_in_month"`
+	TotalBudget              int                        `json:"total_budget"`
+	TotalExpenses            int                        `json:"total_expenses"`
+	TotalForecastExpenses    int                        `json:"total_forecast_expenses"`
+	TotalForecastRemaining   int                        `json:"total_forecast_remaining"`
+	BudgetForecastByCategory []BudgetForecastByCategory `json:"budget_forecast_by_category"`
+}
+
+type BudgetForecastByCategory struct {
+	BigCategoryID     int    `json:"big_category_id"`
+	BigCategoryName   string `json:"big_category_name"`
+	Budget            int    `json:"budget"`
+	Expenses          int    `json:"expenses"`
+	ForecastExpenses  int    `json:"forecast_expenses"`
+	ForecastRemaining int    `json:"forecast_remaining"`
+	ForecastUsageRate int    `json:"forecast_usage_rate"`
+	OverBudget        bool   `json:"over_budget"`
+}
+
+type BudgetThresholdEvent struct {
+	UserID          string    `json:"user_id"`
+	YearMonth       time.Time `json:"year_month"`
+	BigCategoryID   int       `json:"big_category_id"`
+	BigCategoryName string    `json:"big_category_name"`
+	Threshold       int       `json:"threshold"`
+	Budget          int       `json:"budget"`
+	Expenses        int       `json:"expenses"`
+}
+
 func NewStandardBudgets(standardBudgetByCategoryList []StandardBudgetByCategory) StandardBudgets {
 	return StandardBudgets{StandardBudgets: standardBudgetByCategoryList}
 }
@@ -55,6 +171,33 @@ func NewCustomBudgets(customBudgetByCategoryList []CustomBudgetByCategory) Custo
 	return CustomBudgets{CustomBudgets: customBudgetByCategoryList}
 }
 
+func NewBudgetTemplates(budgetTemplatesList []BudgetTemplate) BudgetTemplates {
+	return BudgetTemplates{BudgetTemplates: budgetTemplatesList}
+}
+
+func NewCustomBudgetsFromBudgetTemplate(budgetTemplate BudgetTemplate) CustomBudgets {
+	customBudgetByCategoryList := make([]CustomBudgetByCategory, len(budgetTemplate.BudgetTemplateByCategory))
+	for i, budgetTemplateByCategory := range budgetTemplate.BudgetTemplateByCategory {
+		customBudgetByCategoryList[i] = CustomBudgetByCategory{
+			BigCategoryID:   budgetTemplateByCategory.BigCategoryID,
+			BigCategoryName: budgetTemplateByCategory.BigCategoryName,
+			Budget:          budgetTemplateByCategory.Budget,
+			ChildBudgets:    budgetTemplateByCategory.ChildBudgets,
+		}
+	}
+
+	return NewCustomBudgets(customBudgetByCategoryList)
+}
+
+func NewBudgetYearMonths(yearMonthList []time.Time) BudgetYearMonths {
+	budgetYearMonths := BudgetYearMonths{YearMonths: make([]Months, len(yearMonthList))}
+	for i, yearMonth := range yearMonthList {
+		budgetYearMonths.YearMonths[i] = Months{Time: yearMonth}
+	}
+
+	return budgetYearMonths
+}
+
 func NewYearlyBudget(year time.Time) YearlyBudget {
 	return YearlyBudget{
 		Year:           year,
@@ -62,6 +205,74 @@ func NewYearlyBudget(year time.Time) YearlyBudget {
 	}
 }
 
+func NewBudgetStatus(yearMonth time.Time, budgetType string, budgetStatusByCategoryList []BudgetStatusByCategory) BudgetStatus {
+	budgetStatus := BudgetStatus{
+		YearMonth:              Months{Time: yearMonth},
+		BudgetType:             budgetType,
+		BudgetStatusByCategory: budgetStatusByCategoryList,
+	}
+
+	for _, budgetStatusByCategory := range budgetStatusByCategoryList {
+		budgetStatus.TotalBudget += budgetStatusByCategory.Budget
+		budgetStatus.TotalExpenses += budgetStatusByCategory.Expenses
+	}
+
+	return budgetStatus
+}
+
+func NewBudgetStatusByCategory(bigCategoryID int, bigCategoryName string, budget int, expenses int) BudgetStatusByCategory {
+	return BudgetStatusByCategory{
+		BigCategoryID:   bigCategoryID,
+		BigCategoryName: bigCategoryName,
+		Budget:          budget,
+		Expenses:        expenses,
+		Remaining:       budget - expenses,
+		UsageRate:       calculateUsageRate(budget, expenses),
+	}
+}
+
+func NewBudgetForecast(budgetStatus BudgetStatus, forecastMethod string, elapsedDays int, daysInMonth int, budgetForecastByCategoryList []BudgetForecastByCategory) BudgetForecast {
+	budgetForecast := BudgetForecast{
+		YearMonth:                budgetStatus.YearMonth,
+		BudgetType:               budgetStatus.BudgetType,
+		ForecastMethod:           forecastMethod,
+		ElapsedDays:              elapsedDays,
+		DaysInMonth:              daysInMonth,
+		BudgetForecastByCategory: budgetForecastByCategoryList,
+	}
+
+	for _, budgetForecastByCategory := range budgetForecastByCategoryList {
+		budgetForecast.TotalBudget += budgetForecastByCategory.Budget
+		budgetForecast.TotalExpenses += budgetForecastByCategory.Expenses
+		budgetForecast.TotalForecastExpenses += budgetForecastByCategory.ForecastExpenses
+	}
+
+	budgetForecast.TotalForecastRemaining = budgetForecast.TotalBudget - budgetForecast.TotalForecastExpenses
+
+	return budgetForecast
+}
+
+func NewBudgetForecastByCategory(bigCategoryID int, bigCategoryName string, budget int, expenses int, forecastExpenses int) BudgetForecastByCategory {
+	return BudgetForecastByCategory{
+		BigCategoryID:     bigCategoryID,
+		BigCategoryName:   bigCategoryName,
+		Budget:            budget,
+		Expenses:          expenses,
+		ForecastExpenses:  forecastExpenses,
+		ForecastRemaining: budget - forecastExpenses,
+		ForecastUsageRate: calculateUsageRate(budget, forecastExpenses),
+		OverBudget:        forecastExpenses > budget,
+	}
+}
+
+func calculateUsageRate(budget int, expenses int) int {
+	if budget <= 0 {
+		return 0
+	}
+
+	return expenses * 100 / budget
+}
+
 func (b StandardBudgets) ShowBudgetsList() []int {
 	budgetsList := make([]int, len(b.StandardBudgets))
 	for i := 0; i < len(b.StandardBudgets); i++ {
@@ -71,6 +282,24 @@ func (b StandardBudgets) ShowBudgetsList() []int {
 	return budgetsList
 }
 
+func (b StandardBudgets) ShowBigCategoryIDList() []int {
+	bigCategoryIDList := make([]int, len(b.StandardBudgets))
+	for i := 0; i < len(b.StandardBudgets); i++ {
+		bigCategoryIDList[i] = b.StandardBudgets[i].BigCategoryID
+	}
+
+	return bigCategoryIDList
+}
+
+func (b StandardBudgets) ShowChildBudgetsList() [][]ChildBudgetByCategory {
+	childBudgetsList := make([][]ChildBudgetByCategory, len(b.StandardBudgets))
+	for i := 0; i < len(b.StandardBudgets); i++ {
+		childBudgetsList[i] = b.StandardBudgets[i].ChildBudgets
+	}
+
+	return childBudgetsList
+}
+
 func (b CustomBudgets) ShowBudgetsList() []int {
 	budgetsList := make([]int, len(b.CustomBudgets))
 	for i := 0; i < len(b.CustomBudgets); i++ {
@@ -80,6 +309,87 @@ func (b CustomBudgets) ShowBudgetsList() []int {
 	return budgetsList
 }
 
+func (b CustomBudgets) ShowBigCategoryIDList() []int {
+	bigCategoryIDList := make([]int, len(b.CustomBudgets))
+	for i := 0; i < len(b.CustomBudgets); i++ {
+		bigCategoryIDList[i] = b.CustomBudgets[i].BigCategoryID
+	}
+
+	return bigCategoryIDList
+}
+
+func (b CustomBudgets) ShowChildBudgetsList() [][]ChildBudgetByCategory {
+	childBudgetsList := make([][]ChildBudgetByCategory, len(b.CustomBudgets))
+	for i := 0; i < len(b.CustomBudgets); i++ {
+		childBudgetsList[i] = b.CustomBudgets[i].ChildBudgets
+	}
+
+	return childBudgetsList
+}
+
+func (b BudgetTemplate) ShowBudgetsList() []int {
+	budgetsList := make([]int, len(b.BudgetTemplateByCategory))
+	for i := 0; i < len(b.BudgetTemplateByCategory); i++ {
+		budgetsList[i] = b.BudgetTemplateByCategory[i].Budget
+	}
+
+	return budgetsList
+}
+
+func (b BudgetTemplate) ShowBigCategoryIDList() []int {
+	bigCategoryIDList := make([]int, len(b.BudgetTemplateByCategory))
+	for i := 0; i < len(b.BudgetTemplateByCategory); i++ {
+		bigCategoryIDList[i] = b.BudgetTemplateByCategory[i].BigCategoryID
+	}
+
+	return bigCategoryIDList
+}
+
+func (b BudgetTemplate) ShowChildBudgetsList() [][]ChildBudgetByCategory {
+	childBudgetsList := make([][]ChildBudgetByCategory, len(b.BudgetTemplateByCategory))
+	for i := 0; i < len(b.BudgetTemplateByCategory); i++ {
+		childBudgetsList[i] = b.BudgetTemplateByCategory[i].ChildBudgets
+	}
+
+	return childBudgetsList
+}
+
+func (b BudgetRolloverSettings) ShowBigCategoryIDList() []int {
+	bigCategoryIDList := make([]int, len(b.BudgetRolloverSettings))
+	for i := 0; i < len(b.BudgetRolloverSettings); i++ {
+		bigCategoryIDList[i] = b.BudgetRolloverSettings[i].BigCategoryID
+	}
+
+	return bigCategoryIDList
+}
+
+func (b BudgetRolloverSettings) ShowRolloverCapList() []NullInt {
+	rolloverCapList := make([]NullInt, len(b.BudgetRolloverSettings))
+	for i := 0; i < len(b.BudgetRolloverSettings); i++ {
+		rolloverCapList[i] = b.BudgetRolloverSettings[i].RolloverCap
+	}
+
+	return rolloverCapList
+}
+
+// CarryOver returns the unused amount carried into the next month, limited by the rollover cap.
+func (s BudgetRolloverSetting) CarryOver(budget int, rollover int, expenses int) int {
+	return carryOver(budget, rollover, expenses, s.RolloverCap)
+}
+
+func carryOver(budget int, rollover int, expenses int, rolloverCap NullInt) int {
+	unusedAmount := budget + rollover - expenses
+	if unusedAmount < 0 {
+		return 0
+	}
+
+	if rolloverCap.Valid && unusedAmount > rolloverCap.Int {
+		return rolloverCap.Int
+	}
+
+	return unusedAmount
+}
+
 func (m *Months) Scan(value interface{}) error {
 	month, ok := value.(time.Time)
 	if !ok {
@@ -31,9 +31,10 @@ type BigCategory struct {
 
 type AssociatedCategory struct {
 	CategoryType  string `json:"category_type"`
-	ID            int    `json:"id"              db:"id"`
-	Name          string `json:"name"            db:"category_name"`
-	BigCategoryID int    `json:"big_category_id" db:"big_category_id"`
+	ID            int    `json:"id"                    db:"id"`
+	Name          string `json:"name"                  db:"category_name"`
+	BigCategoryID int    `json:"big_category_id"       db:"big_category_id"`
+	IsArchived    bool   `json:"is_archived,omitempty" db:"is_archived"`
 }
 
 type CustomCategory struct {
@@ -43,17 +44,114 @@ type CustomCategory struct {
 	BigCategoryID int    `json:"big_category_id" db:"big_category_id"`
 }
 
+type CustomCategoryArchive struct {
+	ID         int  `json:"id"`
+	IsArchived bool `json:"is_archived"`
+}
+
+type CustomCategoriesMerge struct {
+	TargetCustomCategoryID int `json:"target_custom_category_id"`
+}
+
+// CustomCategoryChildBudget is a child budget row of a custom category.
+// ParentKey identifies the parent budget the row belongs to, such as the month of a custom budget.
+type CustomCategoryChildBudget struct {
+	ID        int    `db:"id"`
+	ParentKey string `db:"parent_key"`
+	Budget    int    `db:"budget"`
+}
+
+// CustomCategoryChildBudgetsMerge is the change to the child budget rows needed to merge a custom category into another.
+// A source row whose parent budget already has a target row is summed into it and deleted,
+// and any other source row is moved to the target custom category.
+type CustomCategoryChildBudgetsMerge struct {
+	SummedChildBudgets []CustomCategoryChildBudget
+	DeletedIDList      []int
+	MovedIDList        []int
+}
+
+func NewCustomCategoryChildBudgetsMerge(sourceChildBudgets []CustomCategoryChildBudget, targetChildBudgets []CustomCategoryChildBudget) CustomCategoryChildBudgetsMerge {
+	targetChildBudgetsMap := make(map[string]CustomCategoryChildBudget, len(targetChildBudgets))
+	for _, targetChildBudget := range targetChildBudgets {
+		if _, ok := targetChildBudgetsMap[targetChildBudget.ParentKey]; !ok {
+			targetChildBudgetsMap[targetChildBudget.ParentKey] = targetChildBudget
+		}
+	}
+
+	var childBudgetsMerge CustomCategoryChildBudgetsMerge
+	summedIndexes := make(map[string]int)
+	for _, sourceChildBudget := range sourceChildBudgets {
+		targetChildBudget, ok := targetChildBudgetsMap[sourceChildBudget.ParentKey]
+		if !ok {
+			childBudgetsMerge.MovedIDList = append(childBudgetsMerge.MovedIDList, sourceChildBudget.ID)
+			continue
+		}
+
+		index, ok := summedIndexes[sourceChildBudget.ParentKey]
+		if !ok {
+			childBudgetsMerge.SummedChildBudgets = append(childBudgetsMerge.SummedChildBudgets, targetChildBudget)
+			index = len(childBudgetsMerge.SummedChildBudgets) - 1
+			summedIndexes[sourceChildBudget.ParentKey] = index
+		}
+
+		childBudgetsMerge.SummedChildBudgets[index].Budget += sourceChildBudget.Budget
+		childBudgetsMerge.DeletedIDList = append(childBudgetsMerge.DeletedIDList, sourceChildBudget.ID)
+	}
+
+	return childBudgetsMerge
+}
+
+// ShoppingListMergeRevertFailure records a shopping list merge which the todo service failed to revert
+// after merging the custom category failed, so that it can be reconciled later.
+type ShoppingListMergeRevertFailure struct {
+	UserID                    string
+	SourceCustomCategoryID    int
+	TargetCustomCategoryID    int
+	ShoppingItemIDList        []int
+	RegularShoppingItemIDList []int
+	ErrorMessage              string
+}
+
+type CustomCategoriesOrder struct {
+	BigCategoryID        int   `json:"big_category_id"`
+	CustomCategoryIDList []int `json:"custom_category_id_list"`
+}
+
+type CustomCategoriesCopy struct {
+	GroupCustomCategoryIDList []int `json:"group_custom_category_id_list"`
+}
+
+type CopiedCustomCategories struct {
+	CreatedCustomCategoriesList      []CustomCategory      `json:"created_custom_categories_list"`
+	SkippedGroupCustomCategoriesList []GroupCustomCategory `json:"skipped_custom_categories_list"`
+}
+
 type CategoriesID struct {
 	MediumCategoryID NullInt64 `json:"medium_category_id"`
 	CustomCategoryID NullInt64 `json:"custom_category_id"`
 }
 
 type CategoriesName struct {
+	BigCategoryID      int        `json:"-"                    db:"big_category_id"`
 	BigCategoryName    NullString `json:"big_category_name"    db:"big_category_name"`
+	MediumCategoryID   NullInt64  `json:"-"                    db:"medium_category_id"`
 	MediumCategoryName NullString `json:"medium_category_name" db:"medium_category_name"`
 	CustomCategoryName NullString `json:"custom_category_name" db:"custom_category_name"`
 }
 
+type CategoryTranslation struct {
+	CategoryType   string `db:"category_type"`
+	ID             int    `db:"id"`
+	TranslatedName string `db:"translated_name"`
+}
+
+// CategoryTranslations holds the translated names keyed by category ID,
+// so that renaming a category does not lose its translation.
+type CategoryTranslations struct {
+	BigCategoryNames    map[int]string
+	MediumCategoryNames map[int]string
+}
+
 func NewIncomeBigCategory(bigCategory *BigCategory) IncomeBigCategory {
 	return IncomeBigCategory{
 		CategoryType:             "IncomeBigCategory",
@@ -79,3 +177,58 @@ func NewCustomCategory() CustomCategory {
 		CategoryType: "CustomCategory",
 	}
 }
+
+func NewCopiedCustomCategories() CopiedCustomCategories {
+	return CopiedCustomCategories{
+		CreatedCustomCategoriesList:      make([]CustomCategory, 0),
+		SkippedGroupCustomCategoriesList: make([]GroupCustomCategory, 0),
+	}
+}
+
+func NewCategoryTranslations(categoryTranslationsList []CategoryTranslation) CategoryTranslations {
+	categoryTranslations := CategoryTranslations{
+		BigCategoryNames:    make(map[int]string),
+		MediumCategoryNames: make(map[int]string),
+	}
+
+	for _, categoryTranslation := range categoryTranslationsList {
+		switch categoryTranslation.CategoryType {
+		case "BigCategory":
+			categoryTranslations.BigCategoryNames[categoryTranslation.ID] = categoryTranslation.TranslatedName
+		case "MediumCategory":
+			categoryTranslations.MediumCategoryNames[categoryTranslation.ID] = categoryTranslation.TranslatedName
+		}
+	}
+
+	return categoryTranslations
+}
+
+// TranslateBigCategoryName returns the translated name of the big category, or the name as it is if it has no translation.
+func (t CategoryTranslations) TranslateBigCategoryName(bigCategoryID int, name string) string {
+	if translatedName, ok := t.BigCategoryNames[bigCategoryID]; ok {
+		return translatedName
+	}
+
+	return name
+}
+
+// TranslateMediumCategoryName returns the translated name of the medium category, or the name as it is if it has no translation.
+func (t CategoryTranslations) TranslateMediumCategoryName(mediumCategoryID int, name string) string {
+	if translatedName, ok := t.MediumCategoryNames[mediumCategoryID]; ok {
+		return translatedName
+	}
+
+	return name
+}
+
+// TranslateCategoriesName translates the big and medium category names in place.
+// Custom category names are left as they are since they are named by users.
+func (t CategoryTranslations) TranslateCategoriesName(categoriesName *CategoriesName) {
+	if categoriesName.BigCategoryName.Valid {
+		categoriesName.BigCategoryName.String = t.TranslateBigCategoryName(categoriesName.BigCategoryID, categoriesName.BigCategoryName.String)
+	}
+
+	if categoriesName.MediumCategoryName.Valid {
+		categoriesName.MediumCategoryName.String = t.TranslateMediumCategoryName(int(categoriesName.MediumCategoryID.Int64), categoriesName.MediumCategoryName.String)
+	}
+}
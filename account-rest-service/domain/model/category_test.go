@@ -0,0 +1,78 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewCustomCategoryChildBudgetsMerge(t *testing.T) {
+	tests := []struct {
+		name               string
+		sourceChildBudgets []CustomCategoryChildBudget
+		targetChildBudgets []CustomCategoryChildBudget
+		want               CustomCategoryChildBudgetsMerge
+	}{
+		{
+			name: "source budgets without target budgets are moved",
+			sourceChildBudgets: []CustomCategoryChildBudget{
+				{ID: 1, ParentKey: "2020-07-01", Budget: 3000},
+				{ID: 2, ParentKey: "2020-08-01", Budget: 4000},
+			},
+			want: CustomCategoryChildBudgetsMerge{
+				MovedIDList: []int{1, 2},
+			},
+		},
+		{
+			name: "source budgets are summed into target budgets of the same parent",
+			sourceChildBudgets: []CustomCategoryChildBudget{
+				{ID: 1, ParentKey: "2020-07-01", Budget: 3000},
+				{ID: 2, ParentKey: "2020-08-01", Budget: 4000},
+			},
+			targetChildBudgets: []CustomCategoryChildBudget{
+				{ID: 3, ParentKey: "2020-07-01", Budget: 5000},
+				{ID: 4, ParentKey: "2020-09-01", Budget: 6000},
+			},
+			want: CustomCategoryChildBudgetsMerge{
+				SummedChildBudgets: []CustomCategoryChildBudget{
+					{ID: 3, ParentKey: "2020-07-01", Budget: 8000},
+				},
+				DeletedIDList: []int{1},
+				MovedIDList:   []int{2},
+			},
+		},
+		{
+			name: "several source budgets of the same parent are summed into one target budget",
+			sourceChildBudgets: []CustomCategoryChildBudget{
+				{ID: 1, ParentKey: "userID1", Budget: 1000},
+				{ID: 2, ParentKey: "userID1", Budget: 2000},
+			},
+			targetChildBudgets: []CustomCategoryChildBudget{
+				{ID: 3, ParentKey: "userID1", Budget: 500},
+			},
+			want: CustomCategoryChildBudgetsMerge{
+				SummedChildBudgets: []CustomCategoryChildBudget{
+					{ID: 3, ParentKey: "userID1", Budget: 3500},
+				},
+				DeletedIDList: []int{1, 2},
+			},
+		},
+		{
+			name: "no source budgets",
+			targetChildBudgets: []CustomCategoryChildBudget{
+				{ID: 3, ParentKey: "1", Budget: 500},
+			},
+			want: CustomCategoryChildBudgetsMerge{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewCustomCategoryChildBudgetsMerge(tt.sourceChildBudgets, tt.targetChildBudgets)
+
+			if diff := cmp.Diff(tt.want, got); len(diff) != 0 {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
@@ -1,16 +1,20 @@
 package model
 
-import "time"
+import (
+	"database/sql"
+	"time"
+)
 
 type GroupStandardBudgets struct {
 	GroupStandardBudgets []GroupStandardBudgetByCategory `json:"standard_budgets"`
 }
 
 type GroupStandardBudgetByCategory struct {
-	BigCategoryID     int    `json:"big_category_id"      db:"big_category_id"`
-	BigCategoryName   string `json:"big_category_name"    db:"big_category_name"`
-	Budget            int    `json:"budget"               db:"budget"`
-	LastMonthExpenses int    `json:"last_month_expenses"`
+	BigCategoryID     int                     `json:"big_category_id"      db:"big_category_id"`
+	BigCategoryName   string                  `json:"big_category_name"    db:"big_category_name"`
+	Budget            int                     `json:"budget"               db:"budget"`
+	LastMonthExpenses int                     `json:"last_month_expenses"`
+	ChildBudgets      []ChildBudgetByCategory `json:"child_budgets"`
 }
 
 type GroupCustomBudgets struct {
@@ -18,10 +22,30 @@ type GroupCustomBudgets struct {
 }
 
 type GroupCustomBudgetByCategory struct {
-	BigCategoryID     int    `json:"big_category_id"      db:"big_category_id"`
-	BigCategoryName   string `json:"big_category_name"    db:"big_category_name"`
-	Budget            int    `json:"budget"               db:"budget"`
-	LastMonthExpenses int    `json:"last_month_expenses"`
+	BigCategoryID     int                     `json:"big_category_id"      db:"big_category_id"`
+	BigCategoryName   string                  `json:"big_category_name"    db:"big_category_name"`
+	Budget            int                     `json:"budget"               db:"budget"`
+	Rollover          int                     `json:"rollover"`
+	EffectiveBudget   int                     `json:"effective_budget"`
+	LastMonthExpenses int                     `json:"last_month_expenses"`
+	ChildBudgets      []ChildBudgetByCategory `json:"child_budgets"`
+}
+
+type MonthlyGroupCustomBudgetByCategory struct {
+	Month         time.Time `db:"years_months"`
+	BigCategoryID int       `db:"big_category_id"`
+	Budget        int       `db:"budget"`
+}
+
+type GroupBudgetRolloverSettings struct {
+	GroupBudgetRolloverSettings []GroupBudgetRolloverSetting `json:"rollover_settings"`
+}
+
+type GroupBudgetRolloverSetting struct {
+	BigCategoryID      int          `json:"big_category_id" db:"big_category_id"`
+	Rollover           BitBool      `json:"rollover"        db:"rollover"`
+	RolloverCap        NullInt      `json:"rollover_cap"    db:"rollover_cap"`
+	RolloverStartMonth sql.NullTime `json:"-"               db:"rollover_start_month"`
 }
 
 type YearlyGroupBudget struct {
@@ -31,9 +55,62 @@ type YearlyGroupBudget struct {
 }
 
 type MonthlyGroupBudget struct {
-	Month              Months `json:"month"                db:"years_months"`
-	BudgetType         string `json:"budget_type"`
-	MonthlyTotalBudget int    `json:"monthly_total_budget" db:"total_budget"`
+	Month                       Months `json:"month"                          db:"years_months"`
+	BudgetType                  string `json:"budget_type"`
+	MonthlyTotalBudget          int    `json:"monthly_total_budget"           db:"total_budget"`
+	MonthlyTotalRollover        int    `json:"monthly_total_rollover"`
+	MonthlyTotalEffectiveBudget int    `json:"monthly_total_effective_budget"`
+}
+
+type GroupBudgetStatus struct {
+	YearMonth                   Months                        `json:"year_month"`
+	BudgetType                  string                        `json:"budget_type"`
+	TotalBudget                 int                           `json:"total_budget"`
+	TotalExpenses               int                           `json:"total_expenses"`
+	GroupBudgetStatusByCategory []GroupBudgetStatusByCategory `json:"budget_status_by_category"`
+}
+
+type GroupBudgetStatusByCategory struct {
+	BigCategoryID   int    `json:"big_category_id"`
+	BigCategoryName string `json:"big_category_name"`
+	Budget          int    `json:"budget"`
+	Expenses        int    `json:"expenses"`
+	Remaining       int    `json:"remaining"`
+	UsageRate       int    `json:"usage_rate"`
+}
+
+type GroupBudgetForecast struct {
+	YearMonth                     Months                          `json:"year_month"`
+	BudgetType                    string                          `json:"budget_type"`
+	ForecastMethod                string                          `json:"forecast_method"`
+	ElapsedDays                   int                             `json:"elapsed_days"`
+	DaysInMonth                   int                             `json:"days_in_month"`
+	TotalBudget                   int                             `json:"total_budget"`
+	TotalExpenses                 int                             `json:"total_expenses"`
+	TotalForecastExpenses         int                             `json:"total_forecast_expenses"`
+	TotalForecastRemaining        int                             `json:"total_forecast_remaining"`
+	GroupBudgetForecastByCategory []GroupBudgetForecastByCategory `json:"budget_forecast_by_category"`
+}
+
+type GroupBudgetForecastByCategory struct {
+	BigCategoryID     int    `json:"big_category_id"`
+	BigCategoryName   string `json:"big_category_name"`
+	Budget            int    `json:"budget"`
+	Expenses          int    `json:"expenses"`
+	ForecastExpenses  int    `json:"forecast_expenses"`
+	ForecastRemaining int    `json:"forecast_remaining"`
+	ForecastUsageRate int    `json:"forecast_usage_rate"`
+	OverBudget        bool   `json:"over_budget"`
+}
+
+type GroupBudgetThresholdEvent struct {
+	GroupID         int       `json:"group_id"`
+	YearMonth       time.Time `json:"year_month"`
+	BigCategoryID   int       `json:"big_category_id"`
+	BigCategoryName string    `json:"big_category_name"`
+	Threshold       int       `json:"threshold"`
+	Budget          int       `json:"budget"`
+	Expenses        int       `json:"expenses"`
 }
 
 func NewGroupStandardBudgets(groupStandardBudgetByCategoryList []GroupStandardBudgetByCategory) GroupStandardBudgets {
@@ -44,6 +121,49 @@ func NewGroupCustomBudgets(groupCustomBudgetByCategoryList []GroupCustomBudgetBy
 	return GroupCustomBudgets{GroupCustomBudgets: groupCustomBudgetByCategoryList}
 }
 
+// NewGroupStandardBudgetsFromStandardBudgets copies personal standard budgets into group standard budgets.
+// Child budgets of personal custom categories are dropped, since they can't be used in a group.
+func NewGroupStandardBudgetsFromStandardBudgets(standardBudgets StandardBudgets) GroupStandardBudgets {
+	groupStandardBudgetByCategoryList := make([]GroupStandardBudgetByCategory, len(standardBudgets.StandardBudgets))
+	for i, standardBudgetByCategory := range standardBudgets.StandardBudgets {
+		groupStandardBudgetByCategoryList[i] = GroupStandardBudgetByCategory{
+			BigCategoryID:   standardBudgetByCategory.BigCategoryID,
+			BigCategoryName: standardBudgetByCategory.BigCategoryName,
+			Budget:          standardBudgetByCategory.Budget,
+			ChildBudgets:    filterMediumCategoryChildBudgets(standardBudgetByCategory.ChildBudgets),
+		}
+	}
+
+	return NewGroupStandardBudgets(groupStandardBudgetByCategoryList)
+}
+
+// NewGroupCustomBudgetsFromCustomBudgets copies personal custom budgets into group custom budgets.
+// Child budgets of personal custom categories are dropped, since they can't be used in a group.
+func NewGroupCustomBudgetsFromCustomBudgets(customBudgets CustomBudgets) GroupCustomBudgets {
+	groupCustomBudgetByCategoryList := make([]GroupCustomBudgetByCategory, len(customBudgets.CustomBudgets))
+	for i, customBudgetByCategory := range customBudgets.CustomBudgets {
+		groupCustomBudgetByCategoryList[i] = GroupCustomBudgetByCategory{
+			BigCategoryID:   customBudgetByCategory.BigCategoryID,
+			BigCategoryName: customBudgetByCategory.BigCategoryName,
+			Budget:          customBudgetByCategory.Budget,
+			ChildBudgets:    filterMediumCategoryChildBudgets(customBudgetByCategory.ChildBudgets),
+		}
+	}
+
+	return NewGroupCustomBudgets(groupCustomBudgetByCategoryList)
+}
+
+func filterMediumCategoryChildBudgets(childBudgets []ChildBudgetByCategory) []ChildBudgetByCategory {
+	mediumCategoryChildBudgets := make([]ChildBudgetByCategory, 0, len(childBudgets))
+	for _, childBudget := range childBudgets {
+		if childBudget.MediumCategoryID.Valid {
+			mediumCategoryChildBudgets = append(mediumCategoryChildBudgets, childBudget)
+		}
+	}
+
+	return mediumCategoryChildBudgets
+}
+
 func NewYearlyGroupBudget(year time.Time) YearlyGroupBudget {
 	return YearlyGroupBudget{
 		Year:                year,
@@ -51,6 +171,66 @@ func NewYearlyGroupBudget(year time.Time) YearlyGroupBudget {
 	}
 }
 
+func NewGroupBudgetStatus(yearMonth time.Time, budgetType string, groupBudgetStatusByCategoryList []GroupBudgetStatusByCategory) GroupBudgetStatus {
+	groupBudgetStatus := GroupBudgetStatus{
+		YearMonth:                   Months{Time: yearMonth},
+		BudgetType:                  budgetType,
+		GroupBudgetStatusByCategory: groupBudgetStatusByCategoryList,
+	}
+
+	for _, groupBudgetStatusByCategory := range groupBudgetStatusByCategoryList {
+		groupBudgetStatus.TotalBudget += groupBudgetStatusByCategory.Budget
+		groupBudgetStatus.TotalExpenses += groupBudgetStatusByCategory.Expenses
+	}
+
+	return groupBudgetStatus
+}
+
+func NewGroupBudgetStatusByCategory(bigCategoryID int, bigCategoryName string, budget int, expenses int) GroupBudgetStatusByCategory {
+	return GroupBudgetStatusByCategory{
+		BigCategoryID:   bigCategoryID,
+		BigCategoryName: bigCategoryName,
+		Budget:          budget,
+		Expenses:        expenses,
+		Remaining:       budget - expenses,
+		UsageRate:       calculateUsageRate(budget, expenses),
+	}
+}
+
+func NewGroupBudgetForecast(groupBudgetStatus GroupBudgetStatus, forecastMethod string, elapsedDays int, daysInMonth int, groupBudgetForecastByCategoryList []GroupBudgetForecastByCategory) GroupBudgetForecast {
+	groupBudgetForecast := GroupBudgetForecast{
+		YearMonth:                     groupBudgetStatus.YearMonth,
+		BudgetType:                    groupBudgetStatus.BudgetType,
+		ForecastMethod:                forecastMethod,
+		ElapsedDays:                   elapsedDays,
+		DaysInMonth:                   daysInMonth,
+		GroupBudgetForecastByCategory: groupBudgetForecastByCategoryList,
+	}
+
+	for _, groupBudgetForecastByCategory := range groupBudgetForecastByCategoryList {
+		groupBudgetForecast.TotalBudget += groupBudgetForecastByCategory.Budget
+		groupBudgetForecast.TotalExpenses += groupBudgetForecastByCategory.Expenses
+		groupBudgetForecast.TotalForecastExpenses += groupBudgetForecastByCategory.ForecastExpenses
+	}
+
+	groupBudgetForecast.TotalForecastRemaining = groupBudgetForecast.TotalBudget - groupBudgetForecast.TotalForecastExpenses
+
+	return groupBudgetForecast
+}
+
+func NewGroupBudgetForecastByCategory(bigCategoryID int, bigCategoryName string, budget int, expenses int, forecastExpenses int) GroupBudgetForecastByCategory {
+	return GroupBudgetForecastByCategory{
+		BigCategoryID:     bigCategoryID,
+		BigCategoryName:   bigCategoryName,
+		Budget:            budget,
+		Expenses:          expenses,
+		ForecastExpenses:  forecastExpenses,
+		ForecastRemaining: budget - forecastExpenses,
+		ForecastUsageRate: calculateUsageRate(budget, forecastExpenses),
+		OverBudget:        forecastExpenses > budget,
+	}
+}
+
 func (b GroupStandardBudgets) ShowBudgetsList() []int {
 	budgetsList := make([]int, len(b.GroupStandardBudgets))
 	for i := 0; i < len(b.GroupStandardBudgets); i++ {
@@ -60,6 +240,24 @@ func (b GroupStandardBudgets) ShowBudgetsList() []int {
 	return budgetsList
 }
 
+func (b GroupStandardBudgets) ShowBigCategoryIDList() []int {
+	bigCategoryIDList := make([]int, len(b.GroupStandardBudgets))
+	for i := 0; i < len(b.GroupStandardBudgets); i++ {
+		bigCategoryIDList[i] = b.GroupStandardBudgets[i].BigCategoryID
+	}
+
+	return bigCategoryIDList
+}
+
+func (b GroupStandardBudgets) ShowChildBudgetsList() [][]ChildBudgetByCategory {
+	childBudgetsList := make([][]ChildBudgetByCategory, len(b.GroupStandardBudgets))
+	for i := 0; i < len(b.GroupStandardBudgets); i++ {
+		childBudgetsList[i] = b.GroupStandardBudgets[i].ChildBudgets
+	}
+
+	return childBudgetsList
+}
+
 func (b GroupCustomBudgets) ShowBudgetsList() []int {
 	budgetsList := make([]int, len(b.GroupCustomBudgets))
 	for i := 0; i < len(b.GroupCustomBudgets); i++ {
@@ -68,3 +266,44 @@ func (b GroupCustomBudgets) ShowBudgetsList() []int {
 
 	return budgetsList
 }
+
+func (b GroupCustomBudgets) ShowBigCategoryIDList() []int {
+	bigCategoryIDList := make([]int, len(b.GroupCustomBudgets))
+	for i := 0; i < len(b.GroupCustomBudgets); i++ {
+		bigCategoryIDList[i] = b.GroupCustomBudgets[i].BigCategoryID
+	}
+
+	return bigCategoryIDList
+}
+
+func (b GroupCustomBudgets) ShowChildBudgetsList() [][]ChildBudgetByCategory {
+	childBudgetsList := make([][]ChildBudgetByCategory, len(b.GroupCustomBudgets))
+	for i := 0; i < len(b.GroupCustomBudgets); i++ {
+		childBudgetsList[i] = b.GroupCustomBudgets[i].ChildBudgets
+	}
+
+	return childBudgetsList
+}
+
+func (b GroupBudgetRolloverSettings) ShowBigCategoryIDList() []int {
+	bigCategoryIDList := make([]int, len(b.GroupBudgetRolloverSettings))
+	for i := 0; i < len(b.GroupBudgetRolloverSettings); i++ {
+		bigCategoryIDList[i] = b.GroupBudgetRolloverSettings[i].BigCategoryID
+	}
+
+	return bigCategoryIDList
+}
+
+func (b GroupBudgetRolloverSettings) ShowRolloverCapList() []NullInt {
+	rolloverCapList := make([]NullInt, len(b.GroupBudgetRolloverSettings))
+	for i := 0; i < len(b.GroupBudgetRolloverSettings); i++ {
+		rolloverCapList[i] = b.GroupBudgetRolloverSettings[i].RolloverCap
+	}
+
+	return rolloverCapList
+}
+
+// CarryOver returns the unused amount carried into the next month, limited by the rollover cap.
+func (s GroupBudgetRolloverSetting) CarryOver(budget int, rollover int, expenses int) int {
+	return carryOver(budget, rollover, expenses, s.RolloverCap)
+}
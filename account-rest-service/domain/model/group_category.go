@@ -43,6 +43,15 @@ type GroupCustomCategory struct {
 	BigCategoryID int    `json:"big_category_id" db:"big_category_id"`
 }
 
+type GroupCustomCategoriesCopy struct {
+	CustomCategoryIDList []int `json:"custom_category_id_list"`
+}
+
+type CopiedGroupCustomCategories struct {
+	CreatedGroupCustomCategoriesList []GroupCustomCategory `json:"created_custom_categories_list"`
+	SkippedCustomCategoriesList      []CustomCategory      `json:"skipped_custom_categories_list"`
+}
+
 func NewGroupIncomeBigCategory(groupBigCategory *GroupBigCategory) GroupIncomeBigCategory {
 	return GroupIncomeBigCategory{
 		CategoryType:                  "IncomeBigCategory",
@@ -68,3 +77,10 @@ func NewGroupCustomCategory() GroupCustomCategory {
 		CategoryType: "CustomCategory",
 	}
 }
+
+func NewCopiedGroupCustomCategories() CopiedGroupCustomCategories {
+	return CopiedGroupCustomCategories{
+		CreatedGroupCustomCategoriesList: make([]GroupCustomCategory, 0),
+		SkippedCustomCategoriesList:      make([]CustomCategory, 0),
+	}
+}
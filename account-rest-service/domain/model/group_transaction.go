@@ -46,6 +46,7 @@ type GroupTransactionReceiver struct {
 	BigCategoryID    int          `json:"big_category_id"    db:"big_category_id"    validate:"required,min=1,max=17,either_id"`
 	MediumCategoryID NullInt64    `json:"medium_category_id" db:"medium_category_id" validate:"omitempty,min=1,max=99"`
 	CustomCategoryID NullInt64    `json:"custom_category_id" db:"custom_category_id" validate:"omitempty,min=1"`
+	IdempotencyKey   NullString   `json:"-"                  db:"idempotency_key"`
 }
 
 type GroupTransactionTotalAmountByBigCategory struct {
@@ -53,6 +54,18 @@ type GroupTransactionTotalAmountByBigCategory struct {
 	TotalAmount   int `db:"total_amount"`
 }
 
+type GroupTransactionTotalAmountByMediumCategory struct {
+	MediumCategoryID NullInt64 `db:"medium_category_id"`
+	CustomCategoryID NullInt64 `db:"custom_category_id"`
+	TotalAmount      int       `db:"total_amount"`
+}
+
+type MonthlyGroupTransactionTotalAmountByBigCategory struct {
+	Month         time.Time `db:"years_months"`
+	BigCategoryID int       `db:"big_category_id"`
+	TotalAmount   int       `db:"total_amount"`
+}
+
 type YearlyAccountingStatus struct {
 	Year                   string
 	YearlyAccountingStatus [12]MonthlyAccountingStatus `json:"yearly_accounting_status"`
@@ -87,6 +100,11 @@ type GroupAccountsList struct {
 	GroupAccountsList             []GroupAccount             `json:"-"`
 }
 
+type UnsettledGroupAccountsList struct {
+	GroupID                    int            `json:"group_id"`
+	UnsettledGroupAccountsList []GroupAccount `json:"unsettled_group_accounts_list"`
+}
+
 type GroupAccountsListByPayer struct {
 	Payer             NullString     `json:"payer_user_id"`
 	GroupAccountsList []GroupAccount `json:"group_accounts_list"`
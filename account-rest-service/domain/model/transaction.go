@@ -40,6 +40,7 @@ type TransactionReceiver struct {
 	BigCategoryID    int          `json:"big_category_id"    db:"big_category_id"    validate:"required,min=1,max=17,either_id"`
 	MediumCategoryID NullInt64    `json:"medium_category_id" db:"medium_category_id" validate:"omitempty,min=1,max=99"`
 	CustomCategoryID NullInt64    `json:"custom_category_id" db:"custom_category_id" validate:"omitempty,min=1"`
+	IdempotencyKey   NullString   `json:"-"                  db:"idempotency_key"`
 }
 
 type TransactionTotalAmountByBigCategory struct {
@@ -47,6 +48,18 @@ type TransactionTotalAmountByBigCategory struct {
 	TotalAmount   int `db:"total_amount"`
 }
 
+type TransactionTotalAmountByMediumCategory struct {
+	MediumCategoryID NullInt64 `db:"medium_category_id"`
+	CustomCategoryID NullInt64 `db:"custom_category_id"`
+	TotalAmount      int       `db:"total_amount"`
+}
+
+type MonthlyTransactionTotalAmountByBigCategory struct {
+	Month         time.Time `db:"years_months"`
+	BigCategoryID int       `db:"big_category_id"`
+	TotalAmount   int       `db:"total_amount"`
+}
+
 type SenderDate struct {
 	time.Time
 }
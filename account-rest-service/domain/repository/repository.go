@@ -2,11 +2,15 @@ package repository
 
 import (
 	"database/sql"
+	"errors"
 	"time"
 
 	"github.com/hryze/kakeibo-app-api/account-rest-service/domain/model"
 )
 
+// ErrDuplicateIdempotencyKey is returned when a transaction with the same idempotency key has already been posted.
+var ErrDuplicateIdempotencyKey = errors.New("duplicate idempotency key")
+
 type HealthRepository interface {
 	PingMySQL() error
 	PingRedis() error
@@ -22,23 +26,35 @@ type CategoriesRepository interface {
 	GetCustomCategoriesList(userID string) ([]model.AssociatedCategory, error)
 	FindCustomCategory(customCategory *model.CustomCategory, userID string) error
 	PostCustomCategory(customCategory *model.CustomCategory, userID string) (sql.Result, error)
+	PostCustomCategoriesList(customCategoriesList []model.CustomCategory, userID string) error
 	PutCustomCategory(customCategory *model.CustomCategory) error
+	PutCustomCategoryArchive(customCategoryArchive *model.CustomCategoryArchive) error
+	PutCustomCategoriesOrder(customCategoriesOrder *model.CustomCategoriesOrder, userID string) error
+	MergeCustomCategory(sourceCustomCategoryID int, targetCustomCategoryID int) error
+	PostShoppingListMergeRevertFailure(shoppingListMergeRevertFailure *model.ShoppingListMergeRevertFailure) error
 	GetBigCategoryID(customCategoryID int) (int, error)
-	DeleteCustomCategory(previousCustomCategoryID int, replaceMediumCategoryID int) error
+	GetCustomCategoryBigCategoryID(customCategoryID int, userID string) (int, error)
+	GetMediumCategoryBigCategoryID(mediumCategoryID int) (int, error)
+	GetFallbackMediumCategoryID(bigCategoryID int) (int, error)
+	DeleteCustomCategory(previousCustomCategoryID int, replaceCategoriesID model.CategoriesID) error
 	GetCategoriesName(categoriesID model.CategoriesID) (*model.CategoriesName, error)
 	GetCategoriesNameList(categoriesIDList []model.CategoriesID) ([]model.CategoriesName, error)
+	GetCategoryTranslationsList(locale string) ([]model.CategoryTranslation, error)
 }
 
 type TransactionsRepository interface {
 	GetMonthlyTransactionsList(userID string, firstDay time.Time, lastDay time.Time) ([]model.TransactionSender, error)
 	Get10LatestTransactionsList(userID string) (*model.TransactionsList, error)
 	GetTransaction(transactionSender *model.TransactionSender, transactionID int) (*model.TransactionSender, error)
+	GetTransactionIDByIdempotencyKey(idempotencyKey string, userID string) (int, error)
 	PostTransaction(transaction *model.TransactionReceiver, userID string) (sql.Result, error)
 	PutTransaction(transaction *model.TransactionReceiver, transactionID int) error
 	DeleteTransaction(transactionID int) error
 	SearchTransactionsList(query string) ([]model.TransactionSender, error)
 	GetShoppingItemRelatedTransactionDataList(transactionIdList []int) ([]model.TransactionSender, error)
 	GetMonthlyTransactionTotalAmountByBigCategory(userID string, firstDay time.Time, lastDay time.Time) ([]model.TransactionTotalAmountByBigCategory, error)
+	GetMonthlyTransactionTotalAmountByMediumCategory(userID string, firstDay time.Time, lastDay time.Time) ([]model.TransactionTotalAmountByMediumCategory, error)
+	GetMonthlyTransactionTotalAmountByBigCategoryList(userID string, firstDay time.Time, lastDay time.Time) ([]model.MonthlyTransactionTotalAmountByBigCategory, error)
 }
 
 type BudgetsRepository interface {
@@ -51,6 +67,16 @@ type BudgetsRepository interface {
 	DeleteCustomBudgets(yearMonth time.Time, userID string) error
 	GetMonthlyStandardBudget(userID string) (model.MonthlyBudget, error)
 	GetMonthlyCustomBudgets(year time.Time, userID string) ([]model.MonthlyBudget, error)
+	GetMonthlyCustomBudgetByCategoryList(firstMonth time.Time, lastMonth time.Time, userID string) ([]model.MonthlyCustomBudgetByCategory, error)
+	GetBudgetRolloverSettings(userID string) (*model.BudgetRolloverSettings, error)
+	PutBudgetRolloverSettings(budgetRolloverSettings *model.BudgetRolloverSettings, userID string) error
+	ReplaceCustomBudgetsList(customBudgets *model.CustomBudgets, yearMonthList []time.Time, userID string) error
+	GetBudgetTemplatesList(userID string) ([]model.BudgetTemplate, error)
+	GetBudgetTemplate(templateID int, userID string) (*model.BudgetTemplate, error)
+	FindBudgetTemplate(budgetTemplate *model.BudgetTemplate, userID string) error
+	PostBudgetTemplate(budgetTemplate *model.BudgetTemplate, userID string) (sql.Result, error)
+	PutBudgetTemplate(budgetTemplate *model.BudgetTemplate, userID string) error
+	DeleteBudgetTemplate(templateID int, userID string) error
 }
 
 type GroupCategoriesRepository interface {
@@ -59,18 +85,22 @@ type GroupCategoriesRepository interface {
 	GetGroupCustomCategoriesList(groupID int) ([]model.GroupAssociatedCategory, error)
 	FindGroupCustomCategory(groupCustomCategory *model.GroupCustomCategory, groupID int) error
 	PostGroupCustomCategory(groupCustomCategory *model.GroupCustomCategory, groupID int) (sql.Result, error)
+	PostGroupCustomCategoriesList(groupCustomCategoriesList []model.GroupCustomCategory, groupID int) error
 	PutGroupCustomCategory(groupCustomCategory *model.GroupCustomCategory) error
 	FindGroupCustomCategoryID(groupCustomCategoryID int) error
 	GetBigCategoryID(groupCustomCategoryID int) (int, error)
-	DeleteGroupCustomCategory(previousGroupCustomCategoryID int, replaceMediumCategoryID int) error
+	GetGroupCustomCategoryBigCategoryID(groupCustomCategoryID int, groupID int) (int, error)
+	DeleteGroupCustomCategory(previousGroupCustomCategoryID int, replaceCategoriesID model.CategoriesID) error
 	GetGroupCategoriesName(categoriesID model.CategoriesID) (*model.CategoriesName, error)
 	GetGroupCategoriesNameList(categoriesIDList []model.CategoriesID) ([]model.CategoriesName, error)
+	GetGroupCategoryTranslationsList(locale string) ([]model.CategoryTranslation, error)
 }
 
 type GroupTransactionsRepository interface {
 	GetMonthlyGroupTransactionsList(groupID int, firstDay time.Time, lastDay time.Time) ([]model.GroupTransactionSender, error)
 	Get10LatestGroupTransactionsList(groupID int) (*model.GroupTransactionsList, error)
 	GetGroupTransaction(groupTransactionID int) (*model.GroupTransactionSender, error)
+	GetGroupTransactionIDByIdempotencyKey(idempotencyKey string, groupID int) (int, error)
 	PostGroupTransaction(groupTransaction *model.GroupTransactionReceiver, groupID int, postedUserID string) (sql.Result, error)
 	PutGroupTransaction(groupTransaction *model.GroupTransactionReceiver, groupTransactionID int, updatedUserID string) error
 	DeleteGroupTransaction(groupTransactionID int) error
@@ -82,8 +112,11 @@ type GroupTransactionsRepository interface {
 	PutGroupAccount(groupAccount model.GroupAccount, groupAccountID int) error
 	DeleteGroupAccountsList(yearMonth time.Time, groupID int) error
 	GetMonthlyGroupTransactionTotalAmountByBigCategory(groupID int, firstDay time.Time, lastDay time.Time) ([]model.GroupTransactionTotalAmountByBigCategory, error)
+	GetMonthlyGroupTransactionTotalAmountByMediumCategory(groupID int, firstDay time.Time, lastDay time.Time) ([]model.GroupTransactionTotalAmountByMediumCategory, error)
+	GetMonthlyGroupTransactionTotalAmountByBigCategoryList(groupID int, firstDay time.Time, lastDay time.Time) ([]model.MonthlyGroupTransactionTotalAmountByBigCategory, error)
 	YearlyGroupTransactionExistenceConfirmation(firstDayOfYear time.Time, groupID int) ([]time.Time, error)
 	GetYearlyGroupAccountsList(firstDayOfYear time.Time, groupID int) ([]model.GroupAccount, error)
+	GetUnsettledGroupAccountsList(groupID int, userID string) ([]model.GroupAccount, error)
 }
 
 type GroupBudgetsRepository interface {
@@ -96,4 +129,8 @@ type GroupBudgetsRepository interface {
 	DeleteGroupCustomBudgets(yearMonth time.Time, groupID int) error
 	GetMonthlyGroupStandardBudget(groupID int) (model.MonthlyGroupBudget, error)
 	GetMonthlyGroupCustomBudgets(year time.Time, groupID int) ([]model.MonthlyGroupBudget, error)
+	GetMonthlyGroupCustomBudgetByCategoryList(firstMonth time.Time, lastMonth time.Time, groupID int) ([]model.MonthlyGroupCustomBudgetByCategory, error)
+	GetGroupBudgetRolloverSettings(groupID int) (*model.GroupBudgetRolloverSettings, error)
+	PutGroupBudgetRolloverSettings(groupBudgetRolloverSettings *model.GroupBudgetRolloverSettings, groupID int) error
+	ReplaceGroupCustomBudgetsList(groupCustomBudgets *model.GroupCustomBudgets, yearMonthList []time.Time, groupID int) error
 }
@@ -1,9 +1,16 @@
 package handler
 
 import (
+	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
+	"log"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/garyburd/redigo/redis"
 	"github.com/gorilla/mux"
@@ -13,6 +20,13 @@ import (
 
 type Budgets interface {
 	ShowBudgetsList() []int
+	ShowBigCategoryIDList() []int
+	ShowChildBudgetsList() [][]model.ChildBudgetByCategory
+}
+
+type RolloverSettings interface {
+	ShowBigCategoryIDList() []int
+	ShowRolloverCapList() []model.NullInt
 }
 
 type UserID struct {
@@ -27,409 +41,1815 @@ func (e *BudgetValidationErrorMsg) Error() string {
 	return e.Message
 }
 
-func validateBudgets(budgets Budgets) error {
-	budgetsList := budgets.ShowBudgetsList()
-
-	for _, budget := range budgetsList {
-		if budget < 0 {
-			return &BudgetValidationErrorMsg{"予算は0以上の整数を入力してください。"}
+// budgetThresholds are the usage rates (in percent) at which budget threshold events are fired.
+var budgetThresholds = [...]int{80, 100}
+
+// forecastLookbackMonths is the number of past months referred to by the historical and recurring forecasts.
+const forecastLookbackMonths = 3
+
+const (
+	linearForecastMethod     = "linear"
+	historicalForecastMethod = "historical"
+	recurringForecastMethod  = "recurring"
+)
+
+// maxBudgetTargetMonths is the number of months a budget template or a custom budget can be written to at once.
+const maxBudgetTargetMonths = 12
+
+func validateBudgets(budgets Budgets) error {
+	budgetsList := budgets.ShowBudgetsList()
+	childBudgetsList := budgets.ShowChildBudgetsList()
+
+	for i, budget := range budgetsList {
+		if budget < 0 {
+			return &BudgetValidationErrorMsg{"予算は0以上の整数を入力してください。"}
+		}
+
+		var totalChildBudget int
+		for _, childBudget := range childBudgetsList[i] {
+			if childBudget.Budget < 0 {
+				return &BudgetValidationErrorMsg{"予算は0以上の整数を入力してください。"}
+			}
+
+			totalChildBudget += childBudget.Budget
+		}
+
+		if totalChildBudget > budget {
+			return &BudgetValidationErrorMsg{"中カテゴリーの予算の合計は大カテゴリーの予算以下にしてください。"}
+		}
+	}
+
+	return nil
+}
+
+// validateChildBudgetCategories checks that every child budget points to exactly one medium or custom category
+// which belongs to the big category of its parent budget, and that no category is budgeted twice.
+// The maps are keyed by medium or custom category ID and hold the ID of the big category it belongs to.
+func validateChildBudgetCategories(budgets Budgets, mediumCategoryBigCategoryIDs map[int]int, customCategoryBigCategoryIDs map[int]int) error {
+	bigCategoryIDList := budgets.ShowBigCategoryIDList()
+	childBudgetsList := budgets.ShowChildBudgetsList()
+
+	registeredMediumCategoryIDs := make(map[int]bool)
+	registeredCustomCategoryIDs := make(map[int]bool)
+
+	for i, childBudgets := range childBudgetsList {
+		for _, childBudget := range childBudgets {
+			var categoryID int
+			var registeredCategoryIDs map[int]bool
+			var categoryBigCategoryIDs map[int]int
+
+			switch {
+			case childBudget.MediumCategoryID.Valid && !childBudget.CustomCategoryID.Valid:
+				categoryID = int(childBudget.MediumCategoryID.Int64)
+				registeredCategoryIDs = registeredMediumCategoryIDs
+				categoryBigCategoryIDs = mediumCategoryBigCategoryIDs
+			case !childBudget.MediumCategoryID.Valid && childBudget.CustomCategoryID.Valid:
+				categoryID = int(childBudget.CustomCategoryID.Int64)
+				registeredCategoryIDs = registeredCustomCategoryIDs
+				categoryBigCategoryIDs = customCategoryBigCategoryIDs
+			default:
+				return &BudgetValidationErrorMsg{"中カテゴリーを正しく選択してください。"}
+			}
+
+			if bigCategoryID, ok := categoryBigCategoryIDs[categoryID]; !ok || bigCategoryID != bigCategoryIDList[i] {
+				return &BudgetValidationErrorMsg{"中カテゴリーを正しく選択してください。"}
+			}
+
+			if registeredCategoryIDs[categoryID] {
+				return &BudgetValidationErrorMsg{"同じ中カテゴリーの予算が重複しています。"}
+			}
+
+			registeredCategoryIDs[categoryID] = true
+		}
+	}
+
+	return nil
+}
+
+func validateRolloverSettings(rolloverSettings RolloverSettings, bigCategoriesList []model.BigCategory) error {
+	expenseBigCategoryIDs := make(map[int]bool)
+	for _, bigCategory := range bigCategoriesList {
+		if bigCategory.TransactionType == "expense" {
+			expenseBigCategoryIDs[bigCategory.ID] = false
+		}
+	}
+
+	for _, bigCategoryID := range rolloverSettings.ShowBigCategoryIDList() {
+		registered, ok := expenseBigCategoryIDs[bigCategoryID]
+		if !ok || registered {
+			return &BudgetValidationErrorMsg{"大カテゴリーを正しく指定してください。"}
+		}
+
+		expenseBigCategoryIDs[bigCategoryID] = true
+	}
+
+	rolloverCapList := rolloverSettings.ShowRolloverCapList()
+
+	for _, rolloverCap := range rolloverCapList {
+		if rolloverCap.Valid && rolloverCap.Int < 0 {
+			return &BudgetValidationErrorMsg{"繰越上限額は0以上の整数を入力してください。"}
+		}
+	}
+
+	return nil
+}
+
+func validateBudgetTemplateName(budgetTemplate *model.BudgetTemplate) error {
+	if len(budgetTemplate.Name) == 0 {
+		return &BudgetValidationErrorMsg{"テンプレート名を入力してください。"}
+	}
+
+	if strings.HasPrefix(budgetTemplate.Name, " ") || strings.HasPrefix(budgetTemplate.Name, "　") {
+		return &BudgetValidationErrorMsg{"テンプレート名の文字列先頭に空白がないか確認してください。"}
+	}
+
+	if strings.HasSuffix(budgetTemplate.Name, " ") || strings.HasSuffix(budgetTemplate.Name, "　") {
+		return &BudgetValidationErrorMsg{"テンプレート名の文字列末尾に空白がないか確認してください。"}
+	}
+
+	if utf8.RuneCountInString(budgetTemplate.Name) > 20 {
+		return &BudgetValidationErrorMsg{"テンプレート名は20文字以下で入力してください。"}
+	}
+
+	return nil
+}
+
+// validateBudgetBigCategories checks that the budgets hold exactly one budget for every expense big category,
+// since a budget template is written to custom budgets as it is.
+func validateBudgetBigCategories(budgets Budgets, bigCategoriesList []model.BigCategory) error {
+	bigCategoryIDList := budgets.ShowBigCategoryIDList()
+
+	expenseBigCategoryIDs := make(map[int]bool)
+	for _, bigCategory := range bigCategoriesList {
+		if bigCategory.TransactionType == "expense" {
+			expenseBigCategoryIDs[bigCategory.ID] = false
+		}
+	}
+
+	if len(bigCategoryIDList) != len(expenseBigCategoryIDs) {
+		return &BudgetValidationErrorMsg{"全ての大カテゴリーの予算を入力してください。"}
+	}
+
+	for _, bigCategoryID := range bigCategoryIDList {
+		registered, ok := expenseBigCategoryIDs[bigCategoryID]
+		if !ok || registered {
+			return &BudgetValidationErrorMsg{"全ての大カテゴリーの予算を入力してください。"}
+		}
+
+		expenseBigCategoryIDs[bigCategoryID] = true
+	}
+
+	return nil
+}
+
+// validateBudgetTemplate returns *BudgetValidationErrorMsg if the budget template is invalid.
+func validateBudgetTemplate(h *DBHandler, budgetTemplate *model.BudgetTemplate, userID string) error {
+	if err := validateBudgetTemplateName(budgetTemplate); err != nil {
+		return err
+	}
+
+	bigCategoriesList, err := h.CategoriesRepo.GetBigCategoriesList()
+	if err != nil {
+		return err
+	}
+
+	if err := validateBudgetBigCategories(budgetTemplate, bigCategoriesList); err != nil {
+		return err
+	}
+
+	if err := validateBudgets(budgetTemplate); err != nil {
+		return err
+	}
+
+	mediumCategoryBigCategoryIDs, customCategoryBigCategoryIDs, err := generateCategoryBigCategoryIDs(h, userID)
+	if err != nil {
+		return err
+	}
+
+	return validateChildBudgetCategories(budgetTemplate, mediumCategoryBigCategoryIDs, customCategoryBigCategoryIDs)
+}
+
+// generateBudgetTargetMonths returns every month from the first month to the last month of budgetTargetMonths.
+func generateBudgetTargetMonths(ctx context.Context, budgetTargetMonths model.BudgetTargetMonths) ([]time.Time, error) {
+	firstMonth, err := time.Parse("2006-01", budgetTargetMonths.FirstMonth)
+	if err != nil {
+		return nil, &BudgetValidationErrorMsg{"対象期間の開始年月を正しく指定してください。"}
+	}
+
+	lastMonth, err := time.Parse("2006-01", budgetTargetMonths.LastMonth)
+	if err != nil {
+		return nil, &BudgetValidationErrorMsg{"対象期間の終了年月を正しく指定してください。"}
+	}
+
+	if lastMonth.Before(firstMonth) {
+		return nil, &BudgetValidationErrorMsg{"対象期間の終了年月は開始年月以降を指定してください。"}
+	}
+
+	if !lastMonth.Before(firstMonth.AddDate(0, maxBudgetTargetMonths, 0)) {
+		return nil, &BudgetValidationErrorMsg{localizeMessagef(ctx, "対象期間は%dヶ月以内で指定してください。", maxBudgetTargetMonths)}
+	}
+
+	var targetMonthList []time.Time
+	for month := firstMonth; !month.After(lastMonth); month = month.AddDate(0, 1, 0) {
+		targetMonthList = append(targetMonthList, month)
+	}
+
+	return targetMonthList, nil
+}
+
+// generateBudgetCopyTargetMonths is the same as generateBudgetTargetMonths except that the source month is excluded.
+func generateBudgetCopyTargetMonths(ctx context.Context, budgetTargetMonths model.BudgetTargetMonths, sourceMonth time.Time) ([]time.Time, error) {
+	targetMonthList, err := generateBudgetTargetMonths(ctx, budgetTargetMonths)
+	if err != nil {
+		return nil, err
+	}
+
+	copyTargetMonthList := make([]time.Time, 0, len(targetMonthList))
+	for _, targetMonth := range targetMonthList {
+		if !targetMonth.Equal(sourceMonth) {
+			copyTargetMonthList = append(copyTargetMonthList, targetMonth)
+		}
+	}
+
+	if len(copyTargetMonthList) == 0 {
+		return nil, &BudgetValidationErrorMsg{"コピー元と異なる年月を指定してください。"}
+	}
+
+	return copyTargetMonthList, nil
+}
+
+func generateCategoryBigCategoryIDs(h *DBHandler, userID string) (map[int]int, map[int]int, error) {
+	mediumCategoriesList, err := h.CategoriesRepo.GetMediumCategoriesList()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	customCategoriesList, err := h.CategoriesRepo.GetCustomCategoriesList(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mediumCategoryBigCategoryIDs := make(map[int]int, len(mediumCategoriesList))
+	for _, mediumCategory := range mediumCategoriesList {
+		mediumCategoryBigCategoryIDs[mediumCategory.ID] = mediumCategory.BigCategoryID
+	}
+
+	customCategoryBigCategoryIDs := make(map[int]int, len(customCategoriesList))
+	for _, customCategory := range customCategoriesList {
+		customCategoryBigCategoryIDs[customCategory.ID] = customCategory.BigCategoryID
+	}
+
+	return mediumCategoryBigCategoryIDs, customCategoryBigCategoryIDs, nil
+}
+
+func setChildBudgetsLastMonthExpenses(childBudgets []model.ChildBudgetByCategory, transactionTotalAmountByMediumCategoryList []model.TransactionTotalAmountByMediumCategory) {
+	for _, transactionTotalAmountByMediumCategory := range transactionTotalAmountByMediumCategoryList {
+		for i, childBudget := range childBudgets {
+			if transactionTotalAmountByMediumCategory.MediumCategoryID == childBudget.MediumCategoryID && transactionTotalAmountByMediumCategory.CustomCategoryID == childBudget.CustomCategoryID {
+				childBudgets[i].LastMonthExpenses = transactionTotalAmountByMediumCategory.TotalAmount
+
+				break
+			}
+		}
+	}
+}
+
+func crossedBudgetThresholds(budget int, previousExpenses int, currentExpenses int) []int {
+	if budget <= 0 {
+		return nil
+	}
+
+	var thresholds []int
+	for _, threshold := range budgetThresholds {
+		thresholdAmount := budget * threshold
+		if previousExpenses*100 < thresholdAmount && currentExpenses*100 >= thresholdAmount {
+			thresholds = append(thresholds, threshold)
+		}
+	}
+
+	return thresholds
+}
+
+// generateBudgetRollovers calculates the rollover of each big category for every month from firstMonth to lastMonth,
+// which is cut off at the current month.
+// The result is keyed by "2006-01" formatted month and big category ID.
+func generateBudgetRollovers(h *DBHandler, firstMonth time.Time, lastMonth time.Time, userID string) (map[string]map[int]int, error) {
+	budgetRollovers := make(map[string]map[int]int)
+
+	// The unused amount of a month is only settled when the month ends,
+	// so nothing is carried into the months after the current one.
+	now := h.TimeManage.Now()
+	if currentMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC); lastMonth.After(currentMonth) {
+		lastMonth = currentMonth
+	}
+
+	if lastMonth.Before(firstMonth) {
+		return budgetRollovers, nil
+	}
+
+	budgetRolloverSettings, err := h.BudgetsRepo.GetBudgetRolloverSettings(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var enabledBudgetRolloverSettings []model.BudgetRolloverSetting
+	var rolloverStartMonth time.Time
+	for _, budgetRolloverSetting := range budgetRolloverSettings.BudgetRolloverSettings {
+		if !bool(budgetRolloverSetting.Rollover) || !budgetRolloverSetting.RolloverStartMonth.Valid || budgetRolloverSetting.RolloverStartMonth.Time.After(lastMonth) {
+			continue
+		}
+
+		if len(enabledBudgetRolloverSettings) == 0 || budgetRolloverSetting.RolloverStartMonth.Time.Before(rolloverStartMonth) {
+			rolloverStartMonth = budgetRolloverSetting.RolloverStartMonth.Time
+		}
+
+		enabledBudgetRolloverSettings = append(enabledBudgetRolloverSettings, budgetRolloverSetting)
+	}
+
+	if len(enabledBudgetRolloverSettings) == 0 {
+		return budgetRollovers, nil
+	}
+
+	rolloverStartMonth = time.Date(rolloverStartMonth.Year(), rolloverStartMonth.Month(), 1, 0, 0, 0, 0, time.UTC)
+	lastDayOfLastMonth := lastMonth.AddDate(0, 1, 0).Add(-1 * time.Second)
+
+	standardBudgets, err := h.BudgetsRepo.GetStandardBudgets(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	standardBudgetByBigCategory := make(map[int]int, len(standardBudgets.StandardBudgets))
+	for _, standardBudgetByCategory := range standardBudgets.StandardBudgets {
+		standardBudgetByBigCategory[standardBudgetByCategory.BigCategoryID] = standardBudgetByCategory.Budget
+	}
+
+	monthlyCustomBudgetByCategoryList, err := h.BudgetsRepo.GetMonthlyCustomBudgetByCategoryList(rolloverStartMonth, lastMonth, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	customBudgetsByMonth := make(map[string]map[int]int)
+	for _, monthlyCustomBudgetByCategory := range monthlyCustomBudgetByCategoryList {
+		month := monthlyCustomBudgetByCategory.Month.Format("2006-01")
+		if _, ok := customBudgetsByMonth[month]; !ok {
+			customBudgetsByMonth[month] = make(map[int]int)
+		}
+
+		customBudgetsByMonth[month][monthlyCustomBudgetByCategory.BigCategoryID] = monthlyCustomBudgetByCategory.Budget
+	}
+
+	monthlyTransactionTotalAmountByBigCategoryList, err := h.TransactionsRepo.GetMonthlyTransactionTotalAmountByBigCategoryList(userID, rolloverStartMonth, lastDayOfLastMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	expensesByMonth := make(map[string]map[int]int)
+	for _, monthlyTransactionTotalAmountByBigCategory := range monthlyTransactionTotalAmountByBigCategoryList {
+		month := monthlyTransactionTotalAmountByBigCategory.Month.Format("2006-01")
+		if _, ok := expensesByMonth[month]; !ok {
+			expensesByMonth[month] = make(map[int]int)
+		}
+
+		expensesByMonth[month][monthlyTransactionTotalAmountByBigCategory.BigCategoryID] = monthlyTransactionTotalAmountByBigCategory.TotalAmount
+	}
+
+	for _, budgetRolloverSetting := range enabledBudgetRolloverSettings {
+		bigCategoryID := budgetRolloverSetting.BigCategoryID
+		startMonth := time.Date(budgetRolloverSetting.RolloverStartMonth.Time.Year(), budgetRolloverSetting.RolloverStartMonth.Time.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+		rollover := 0
+		for month := startMonth; !month.After(lastMonth); month = month.AddDate(0, 1, 0) {
+			formattedMonth := month.Format("2006-01")
+
+			if !month.Before(firstMonth) {
+				if _, ok := budgetRollovers[formattedMonth]; !ok {
+					budgetRollovers[formattedMonth] = make(map[int]int)
+				}
+
+				budgetRollovers[formattedMonth][bigCategoryID] = rollover
+			}
+
+			budget := standardBudgetByBigCategory[bigCategoryID]
+			if customBudgets, ok := customBudgetsByMonth[formattedMonth]; ok {
+				budget = customBudgets[bigCategoryID]
+			}
+
+			rollover = budgetRolloverSetting.CarryOver(budget, rollover, expensesByMonth[formattedMonth][bigCategoryID])
+		}
+	}
+
+	return budgetRollovers, nil
+}
+
+func setCustomBudgetsRollover(h *DBHandler, customBudgets *model.CustomBudgets, yearMonth time.Time, userID string) error {
+	budgetRollovers, err := generateBudgetRollovers(h, yearMonth, yearMonth, userID)
+	if err != nil {
+		return err
+	}
+
+	for i, customBudgetByCategory := range customBudgets.CustomBudgets {
+		rollover := budgetRollovers[yearMonth.Format("2006-01")][customBudgetByCategory.BigCategoryID]
+
+		customBudgets.CustomBudgets[i].Rollover = rollover
+		customBudgets.CustomBudgets[i].EffectiveBudget = customBudgetByCategory.Budget + rollover
+	}
+
+	return nil
+}
+
+func generateBudgetStatus(h *DBHandler, yearMonth time.Time, userID string) (*model.BudgetStatus, error) {
+	firstDay := time.Date(yearMonth.Year(), yearMonth.Month(), 1, 0, 0, 0, 0, time.UTC)
+	lastDay := firstDay.AddDate(0, 1, 0).Add(-1 * time.Second)
+
+	transactionTotalAmountByBigCategoryList, err := h.TransactionsRepo.GetMonthlyTransactionTotalAmountByBigCategory(userID, firstDay, lastDay)
+	if err != nil {
+		return nil, err
+	}
+
+	expensesByBigCategory := make(map[int]int, len(transactionTotalAmountByBigCategoryList))
+	for _, transactionTotalAmountByBigCategory := range transactionTotalAmountByBigCategoryList {
+		expensesByBigCategory[transactionTotalAmountByBigCategory.BigCategoryID] = transactionTotalAmountByBigCategory.TotalAmount
+	}
+
+	budgetRollovers, err := generateBudgetRollovers(h, firstDay, firstDay, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rolloverByBigCategory := budgetRollovers[firstDay.Format("2006-01")]
+
+	customBudgets, err := h.BudgetsRepo.GetCustomBudgets(firstDay, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(customBudgets.CustomBudgets) != 0 {
+		budgetStatusByCategoryList := make([]model.BudgetStatusByCategory, len(customBudgets.CustomBudgets))
+		for i, customBudgetByCategory := range customBudgets.CustomBudgets {
+			budgetStatusByCategoryList[i] = model.NewBudgetStatusByCategory(customBudgetByCategory.BigCategoryID, customBudgetByCategory.BigCategoryName, customBudgetByCategory.Budget+rolloverByBigCategory[customBudgetByCategory.BigCategoryID], expensesByBigCategory[customBudgetByCategory.BigCategoryID])
+		}
+
+		budgetStatus := model.NewBudgetStatus(firstDay, "CustomBudget", budgetStatusByCategoryList)
+
+		return &budgetStatus, nil
+	}
+
+	standardBudgets, err := h.BudgetsRepo.GetStandardBudgets(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	budgetStatusByCategoryList := make([]model.BudgetStatusByCategory, len(standardBudgets.StandardBudgets))
+	for i, standardBudgetByCategory := range standardBudgets.StandardBudgets {
+		budgetStatusByCategoryList[i] = model.NewBudgetStatusByCategory(standardBudgetByCategory.BigCategoryID, standardBudgetByCategory.BigCategoryName, standardBudgetByCategory.Budget+rolloverByBigCategory[standardBudgetByCategory.BigCategoryID], expensesByBigCategory[standardBudgetByCategory.BigCategoryID])
+	}
+
+	budgetStatus := model.NewBudgetStatus(firstDay, "StandardBudget", budgetStatusByCategoryList)
+
+	return &budgetStatus, nil
+}
+
+func parseForecastMethod(r *http.Request) (string, error) {
+	forecastMethod := r.URL.Query().Get("method")
+	if len(forecastMethod) == 0 {
+		return linearForecastMethod, nil
+	}
+
+	switch forecastMethod {
+	case linearForecastMethod, historicalForecastMethod, recurringForecastMethod:
+		return forecastMethod, nil
+	}
+
+	return "", &BadRequestErrorMsg{"予測方法を正しく指定してください。"}
+}
+
+type forecastPeriod struct {
+	firstDay    time.Time
+	endOfToday  time.Time
+	elapsedDays int
+	daysInMonth int
+}
+
+func newForecastPeriod(now time.Time) forecastPeriod {
+	firstDay := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	return forecastPeriod{
+		firstDay:    firstDay,
+		endOfToday:  time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1).Add(-1 * time.Second),
+		elapsedDays: now.Day(),
+		daysInMonth: firstDay.AddDate(0, 1, -1).Day(),
+	}
+}
+
+// sameDayOfMonthPeriods returns the first day and the end of the same day of month as today for each lookback month.
+// The day is clamped to the last day of shorter months.
+func (p forecastPeriod) sameDayOfMonthPeriods() [][2]time.Time {
+	periods := make([][2]time.Time, forecastLookbackMonths)
+	for i := 0; i < forecastLookbackMonths; i++ {
+		firstDay := p.firstDay.AddDate(0, -(i + 1), 0)
+
+		day := p.elapsedDays
+		if lastDay := firstDay.AddDate(0, 1, -1).Day(); day > lastDay {
+			day = lastDay
+		}
+
+		periods[i] = [2]time.Time{firstDay, firstDay.AddDate(0, 0, day).Add(-1 * time.Second)}
+	}
+
+	return periods
+}
+
+func forecastExpensesByPace(expenses int, elapsedDays int, daysInMonth int) int {
+	return expenses * daysInMonth / elapsedDays
+}
+
+// forecastExpensesByHistory scales month-to-date expenses by the ratio of whole-month expenses to the expenses up to the same day in past months.
+// It falls back to the linear pace when there is no history.
+func forecastExpensesByHistory(expenses int, historicalExpensesToDate int, historicalExpenses int, elapsedDays int, daysInMonth int) int {
+	if historicalExpenses == 0 {
+		return forecastExpensesByPace(expenses, elapsedDays, daysInMonth)
+	}
+
+	if historicalExpensesToDate == 0 || expenses == 0 {
+		return expenses + (historicalExpenses-historicalExpensesToDate)/forecastLookbackMonths
+	}
+
+	return expenses * historicalExpenses / historicalExpensesToDate
+}
+
+// forecastExpensesByRecurringCharges projects only the non-recurring part of month-to-date expenses by the linear pace,
+// and adds every recurring charge of the month whether it has been paid or not.
+func forecastExpensesByRecurringCharges(expenses int, paidRecurringCharges int, recurringCharges int, elapsedDays int, daysInMonth int) int {
+	return forecastExpensesByPace(expenses-paidRecurringCharges, elapsedDays, daysInMonth) + recurringCharges
+}
+
+// recurringChargeKey identifies a charge that is regarded as the same one across months.
+type recurringChargeKey struct {
+	bigCategoryID    int
+	mediumCategoryID int64
+	customCategoryID int64
+	shop             string
+	amount           int
+}
+
+// recurringChargeFinder collects expense transactions and finds charges paid in every lookback month.
+type recurringChargeFinder struct {
+	monthsByCharge map[recurringChargeKey]map[string]bool
+	paidCharges    map[recurringChargeKey]bool
+}
+
+func newRecurringChargeFinder() *recurringChargeFinder {
+	return &recurringChargeFinder{
+		monthsByCharge: make(map[recurringChargeKey]map[string]bool),
+		paidCharges:    make(map[recurringChargeKey]bool),
+	}
+}
+
+func (f *recurringChargeFinder) addPastCharge(recurringCharge recurringChargeKey, transactionDate time.Time) {
+	if _, ok := f.monthsByCharge[recurringCharge]; !ok {
+		f.monthsByCharge[recurringCharge] = make(map[string]bool)
+	}
+
+	f.monthsByCharge[recurringCharge][transactionDate.Format("2006-01")] = true
+}
+
+func (f *recurringChargeFinder) addPaidCharge(recurringCharge recurringChargeKey) {
+	f.paidCharges[recurringCharge] = true
+}
+
+// recurringChargesByBigCategory returns the total of recurring charges and the total of those already paid this month by big category.
+func (f *recurringChargeFinder) recurringChargesByBigCategory() (map[int]int, map[int]int) {
+	recurringCharges := make(map[int]int)
+	paidRecurringCharges := make(map[int]int)
+	for recurringCharge, months := range f.monthsByCharge {
+		if len(months) != forecastLookbackMonths {
+			continue
+		}
+
+		recurringCharges[recurringCharge.bigCategoryID] += recurringCharge.amount
+		if f.paidCharges[recurringCharge] {
+			paidRecurringCharges[recurringCharge.bigCategoryID] += recurringCharge.amount
+		}
+	}
+
+	return recurringCharges, paidRecurringCharges
+}
+
+func newRecurringChargeKey(transactionSender model.TransactionSender) recurringChargeKey {
+	return recurringChargeKey{
+		bigCategoryID:    transactionSender.BigCategoryID,
+		mediumCategoryID: transactionSender.MediumCategoryID.Int64,
+		customCategoryID: transactionSender.CustomCategoryID.Int64,
+		shop:             transactionSender.Shop.String,
+		amount:           transactionSender.Amount,
+	}
+}
+
+func generateForecastExpenses(h *DBHandler, forecastMethod string, period forecastPeriod, expensesByBigCategory map[int]int, userID string) (map[int]int, error) {
+	forecastExpensesByBigCategory := make(map[int]int, len(expensesByBigCategory))
+
+	switch forecastMethod {
+	case historicalForecastMethod:
+		transactionTotalAmountByBigCategoryList, err := h.TransactionsRepo.GetMonthlyTransactionTotalAmountByBigCategoryList(userID, period.firstDay.AddDate(0, -forecastLookbackMonths, 0), period.firstDay.Add(-1*time.Second))
+		if err != nil {
+			return nil, err
+		}
+
+		historicalExpensesByBigCategory := make(map[int]int)
+		for _, transactionTotalAmountByBigCategory := range transactionTotalAmountByBigCategoryList {
+			historicalExpensesByBigCategory[transactionTotalAmountByBigCategory.BigCategoryID] += transactionTotalAmountByBigCategory.TotalAmount
+		}
+
+		historicalExpensesToDateByBigCategory := make(map[int]int)
+		for _, sameDayOfMonthPeriod := range period.sameDayOfMonthPeriods() {
+			transactionTotalAmountByBigCategoryList, err := h.TransactionsRepo.GetMonthlyTransactionTotalAmountByBigCategory(userID, sameDayOfMonthPeriod[0], sameDayOfMonthPeriod[1])
+			if err != nil {
+				return nil, err
+			}
+
+			for _, transactionTotalAmountByBigCategory := range transactionTotalAmountByBigCategoryList {
+				historicalExpensesToDateByBigCategory[transactionTotalAmountByBigCategory.BigCategoryID] += transactionTotalAmountByBigCategory.TotalAmount
+			}
+		}
+
+		for bigCategoryID, historicalExpenses := range historicalExpensesByBigCategory {
+			forecastExpensesByBigCategory[bigCategoryID] = forecastExpensesByHistory(expensesByBigCategory[bigCategoryID], historicalExpensesToDateByBigCategory[bigCategoryID], historicalExpenses, period.elapsedDays, period.daysInMonth)
+		}
+
+		for bigCategoryID, expenses := range expensesByBigCategory {
+			if _, ok := historicalExpensesByBigCategory[bigCategoryID]; !ok {
+				forecastExpensesByBigCategory[bigCategoryID] = forecastExpensesByPace(expenses, period.elapsedDays, period.daysInMonth)
+			}
+		}
+	case recurringForecastMethod:
+		pastTransactionsList, err := h.TransactionsRepo.GetMonthlyTransactionsList(userID, period.firstDay.AddDate(0, -forecastLookbackMonths, 0), period.firstDay.Add(-1*time.Second))
+		if err != nil {
+			return nil, err
+		}
+
+		transactionsList, err := h.TransactionsRepo.GetMonthlyTransactionsList(userID, period.firstDay, period.endOfToday)
+		if err != nil {
+			return nil, err
+		}
+
+		recurringChargeFinder := newRecurringChargeFinder()
+		for _, transaction := range pastTransactionsList {
+			if transaction.TransactionType == "expense" {
+				recurringChargeFinder.addPastCharge(newRecurringChargeKey(transaction), transaction.TransactionDate.Time)
+			}
+		}
+
+		for _, transaction := range transactionsList {
+			if transaction.TransactionType == "expense" {
+				recurringChargeFinder.addPaidCharge(newRecurringChargeKey(transaction))
+			}
+		}
+
+		recurringChargesByBigCategory, paidRecurringChargesByBigCategory := recurringChargeFinder.recurringChargesByBigCategory()
+
+		for bigCategoryID, recurringCharges := range recurringChargesByBigCategory {
+			forecastExpensesByBigCategory[bigCategoryID] = forecastExpensesByRecurringCharges(expensesByBigCategory[bigCategoryID], paidRecurringChargesByBigCategory[bigCategoryID], recurringCharges, period.elapsedDays, period.daysInMonth)
+		}
+
+		for bigCategoryID, expenses := range expensesByBigCategory {
+			if _, ok := recurringChargesByBigCategory[bigCategoryID]; !ok {
+				forecastExpensesByBigCategory[bigCategoryID] = forecastExpensesByPace(expenses, period.elapsedDays, period.daysInMonth)
+			}
+		}
+	default:
+		for bigCategoryID, expenses := range expensesByBigCategory {
+			forecastExpensesByBigCategory[bigCategoryID] = forecastExpensesByPace(expenses, period.elapsedDays, period.daysInMonth)
+		}
+	}
+
+	for bigCategoryID, expenses := range expensesByBigCategory {
+		if forecastExpensesByBigCategory[bigCategoryID] < expenses {
+			forecastExpensesByBigCategory[bigCategoryID] = expenses
+		}
+	}
+
+	return forecastExpensesByBigCategory, nil
+}
+
+// generateBudgetForecast projects month-end expenses of the current month from month-to-date transactions,
+// and compares them with the effective budget of the month.
+func generateBudgetForecast(h *DBHandler, forecastMethod string, userID string) (*model.BudgetForecast, error) {
+	period := newForecastPeriod(h.TimeManage.Now())
+
+	transactionTotalAmountByBigCategoryList, err := h.TransactionsRepo.GetMonthlyTransactionTotalAmountByBigCategory(userID, period.firstDay, period.endOfToday)
+	if err != nil {
+		return nil, err
+	}
+
+	expensesByBigCategory := make(map[int]int, len(transactionTotalAmountByBigCategoryList))
+	for _, transactionTotalAmountByBigCategory := range transactionTotalAmountByBigCategoryList {
+		expensesByBigCategory[transactionTotalAmountByBigCategory.BigCategoryID] = transactionTotalAmountByBigCategory.TotalAmount
+	}
+
+	forecastExpensesByBigCategory, err := generateForecastExpenses(h, forecastMethod, period, expensesByBigCategory, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	budgetStatus, err := generateBudgetStatus(h, period.firstDay, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	budgetForecastByCategoryList := make([]model.BudgetForecastByCategory, len(budgetStatus.BudgetStatusByCategory))
+	for i, budgetStatusByCategory := range budgetStatus.BudgetStatusByCategory {
+		budgetForecastByCategoryList[i] = model.NewBudgetForecastByCategory(budgetStatusByCategory.BigCategoryID, budgetStatusByCategory.BigCategoryName, budgetStatusByCategory.Budget, expensesByBigCategory[budgetStatusByCategory.BigCategoryID], forecastExpensesByBigCategory[budgetStatusByCategory.BigCategoryID])
+	}
+
+	budgetForecast := model.NewBudgetForecast(*budgetStatus, forecastMethod, period.elapsedDays, period.daysInMonth, budgetForecastByCategoryList)
+
+	return &budgetForecast, nil
+}
+
+// generatePreviousBudgetStatusList generates the budget status of every month touched by a transaction before it is written.
+// A month moved out of by an edit is included as well, since its budget is carried into the following months.
+// The notification is best-effort, so that a month whose budget status can't be generated is logged and skipped.
+func generatePreviousBudgetStatusList(h *DBHandler, userID string, transactionDateList ...time.Time) []*model.BudgetStatus {
+	previousBudgetStatusList := make([]*model.BudgetStatus, 0, len(transactionDateList))
+	generatedMonths := make(map[string]bool, len(transactionDateList))
+
+	for _, transactionDate := range transactionDateList {
+		if generatedMonths[transactionDate.Format("2006-01")] {
+			continue
+		}
+
+		generatedMonths[transactionDate.Format("2006-01")] = true
+
+		previousBudgetStatus, err := generateBudgetStatus(h, transactionDate, userID)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+
+		previousBudgetStatusList = append(previousBudgetStatusList, previousBudgetStatus)
+	}
+
+	return previousBudgetStatusList
+}
+
+// notifyBudgetThresholdEvents compares the budget status before a transaction was written with the current one,
+// and notifies every threshold that has been newly reached.
+func notifyBudgetThresholdEvents(h *DBHandler, previousBudgetStatus *model.BudgetStatus, userID string) error {
+	currentBudgetStatus, err := generateBudgetStatus(h, previousBudgetStatus.YearMonth.Time, userID)
+	if err != nil {
+		return err
+	}
+
+	previousExpensesByBigCategory := make(map[int]int, len(previousBudgetStatus.BudgetStatusByCategory))
+	for _, previousBudgetStatusByCategory := range previousBudgetStatus.BudgetStatusByCategory {
+		previousExpensesByBigCategory[previousBudgetStatusByCategory.BigCategoryID] = previousBudgetStatusByCategory.Expenses
+	}
+
+	for _, budgetStatusByCategory := range currentBudgetStatus.BudgetStatusByCategory {
+		previousExpenses := previousExpensesByBigCategory[budgetStatusByCategory.BigCategoryID]
+
+		for _, threshold := range crossedBudgetThresholds(budgetStatusByCategory.Budget, previousExpenses, budgetStatusByCategory.Expenses) {
+			budgetThresholdEvent := model.BudgetThresholdEvent{
+				UserID:          userID,
+				YearMonth:       currentBudgetStatus.YearMonth.Time,
+				BigCategoryID:   budgetStatusByCategory.BigCategoryID,
+				BigCategoryName: budgetStatusByCategory.BigCategoryName,
+				Threshold:       threshold,
+				Budget:          budgetStatusByCategory.Budget,
+				Expenses:        budgetStatusByCategory.Expenses,
+			}
+
+			if err := h.BudgetNotifier.NotifyBudgetThreshold(budgetThresholdEvent); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (h *DBHandler) PostInitStandardBudgets(w http.ResponseWriter, r *http.Request) {
+	var userID UserID
+	if err := json.NewDecoder(r.Body).Decode(&userID); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.BudgetsRepo.PostInitStandardBudgets(userID.UserID); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (h *DBHandler) GetStandardBudgets(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	standardBudgets, err := h.BudgetsRepo.GetStandardBudgets(userID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	now := h.TimeManage.Now()
+	firstDayOfLastMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0)
+	lastDayOfLastMonth := firstDayOfLastMonth.AddDate(0, 1, 0).Add(-1 * time.Second)
+
+	transactionTotalAmountByBigCategoryList, err := h.TransactionsRepo.GetMonthlyTransactionTotalAmountByBigCategory(userID, firstDayOfLastMonth, lastDayOfLastMonth)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	for _, transactionTotalAmountByBigCategory := range transactionTotalAmountByBigCategoryList {
+		for i, standardBudgetByCategory := range standardBudgets.StandardBudgets {
+			if transactionTotalAmountByBigCategory.BigCategoryID == standardBudgetByCategory.BigCategoryID {
+				standardBudgets.StandardBudgets[i].LastMonthExpenses = transactionTotalAmountByBigCategory.TotalAmount
+
+				break
+			}
+		}
+	}
+
+	transactionTotalAmountByMediumCategoryList, err := h.TransactionsRepo.GetMonthlyTransactionTotalAmountByMediumCategory(userID, firstDayOfLastMonth, lastDayOfLastMonth)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	for _, standardBudgetByCategory := range standardBudgets.StandardBudgets {
+		setChildBudgetsLastMonthExpenses(standardBudgetByCategory.ChildBudgets, transactionTotalAmountByMediumCategoryList)
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&standardBudgets); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) PutStandardBudgets(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	var standardBudgets model.StandardBudgets
+	if err := json.NewDecoder(r.Body).Decode(&standardBudgets); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if err := validateBudgets(standardBudgets); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
+		return
+	}
+
+	mediumCategoryBigCategoryIDs, customCategoryBigCategoryIDs, err := generateCategoryBigCategoryIDs(h, userID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if err := validateChildBudgetCategories(standardBudgets, mediumCategoryBigCategoryIDs, customCategoryBigCategoryIDs); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
+		return
+	}
+
+	if err := h.BudgetsRepo.PutStandardBudgets(&standardBudgets, userID); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	dbStandardBudgets, err := h.BudgetsRepo.GetStandardBudgets(userID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	now := h.TimeManage.Now()
+	firstDayOfLastMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0)
+	lastDayOfLastMonth := firstDayOfLastMonth.AddDate(0, 1, 0).Add(-1 * time.Second)
+
+	transactionTotalAmountByBigCategoryList, err := h.TransactionsRepo.GetMonthlyTransactionTotalAmountByBigCategory(userID, firstDayOfLastMonth, lastDayOfLastMonth)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	for _, transactionTotalAmountByBigCategory := range transactionTotalAmountByBigCategoryList {
+		for i, standardBudgetByCategory := range dbStandardBudgets.StandardBudgets {
+			if transactionTotalAmountByBigCategory.BigCategoryID == standardBudgetByCategory.BigCategoryID {
+				dbStandardBudgets.StandardBudgets[i].LastMonthExpenses = transactionTotalAmountByBigCategory.TotalAmount
+
+				break
+			}
+		}
+	}
+
+	transactionTotalAmountByMediumCategoryList, err := h.TransactionsRepo.GetMonthlyTransactionTotalAmountByMediumCategory(userID, firstDayOfLastMonth, lastDayOfLastMonth)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	for _, standardBudgetByCategory := range dbStandardBudgets.StandardBudgets {
+		setChildBudgetsLastMonthExpenses(standardBudgetByCategory.ChildBudgets, transactionTotalAmountByMediumCategoryList)
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&dbStandardBudgets); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) GetCustomBudgets(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	yearMonth, err := time.Parse("2006-01", mux.Vars(r)["year_month"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
+		return
+	}
+
+	dbCustomBudgets, err := h.BudgetsRepo.GetCustomBudgets(yearMonth, userID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if err := setCustomBudgetsRollover(h, dbCustomBudgets, yearMonth, userID); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	now := h.TimeManage.Now()
+	firstDayOfLastMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0)
+	lastDayOfLastMonth := firstDayOfLastMonth.AddDate(0, 1, 0).Add(-1 * time.Second)
+
+	transactionTotalAmountByBigCategoryList, err := h.TransactionsRepo.GetMonthlyTransactionTotalAmountByBigCategory(userID, firstDayOfLastMonth, lastDayOfLastMonth)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	for _, transactionTotalAmountByBigCategory := range transactionTotalAmountByBigCategoryList {
+		for i, standardBudgetByCategory := range dbCustomBudgets.CustomBudgets {
+			if transactionTotalAmountByBigCategory.BigCategoryID == standardBudgetByCategory.BigCategoryID {
+				dbCustomBudgets.CustomBudgets[i].LastMonthExpenses = transactionTotalAmountByBigCategory.TotalAmount
+
+				break
+			}
+		}
+	}
+
+	transactionTotalAmountByMediumCategoryList, err := h.TransactionsRepo.GetMonthlyTransactionTotalAmountByMediumCategory(userID, firstDayOfLastMonth, lastDayOfLastMonth)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	for _, customBudgetByCategory := range dbCustomBudgets.CustomBudgets {
+		setChildBudgetsLastMonthExpenses(customBudgetByCategory.ChildBudgets, transactionTotalAmountByMediumCategoryList)
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&dbCustomBudgets); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) PostCustomBudgets(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	yearMonth, err := time.Parse("2006-01", mux.Vars(r)["year_month"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
+		return
+	}
+
+	var customBudgets model.CustomBudgets
+	if err := json.NewDecoder(r.Body).Decode(&customBudgets); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if err := validateBudgets(customBudgets); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
+		return
+	}
+
+	mediumCategoryBigCategoryIDs, customCategoryBigCategoryIDs, err := generateCategoryBigCategoryIDs(h, userID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if err := validateChildBudgetCategories(customBudgets, mediumCategoryBigCategoryIDs, customCategoryBigCategoryIDs); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
+		return
+	}
+
+	if err := h.BudgetsRepo.PostCustomBudgets(&customBudgets, yearMonth, userID); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	dbCustomBudgets, err := h.BudgetsRepo.GetCustomBudgets(yearMonth, userID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if err := setCustomBudgetsRollover(h, dbCustomBudgets, yearMonth, userID); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	now := h.TimeManage.Now()
+	firstDayOfLastMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0)
+	lastDayOfLastMonth := firstDayOfLastMonth.AddDate(0, 1, 0).Add(-1 * time.Second)
+
+	transactionTotalAmountByBigCategoryList, err := h.TransactionsRepo.GetMonthlyTransactionTotalAmountByBigCategory(userID, firstDayOfLastMonth, lastDayOfLastMonth)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	for _, transactionTotalAmountByBigCategory := range transactionTotalAmountByBigCategoryList {
+		for i, standardBudgetByCategory := range dbCustomBudgets.CustomBudgets {
+			if transactionTotalAmountByBigCategory.BigCategoryID == standardBudgetByCategory.BigCategoryID {
+				dbCustomBudgets.CustomBudgets[i].LastMonthExpenses = transactionTotalAmountByBigCategory.TotalAmount
+
+				break
+			}
+		}
+	}
+
+	transactionTotalAmountByMediumCategoryList, err := h.TransactionsRepo.GetMonthlyTransactionTotalAmountByMediumCategory(userID, firstDayOfLastMonth, lastDayOfLastMonth)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	for _, customBudgetByCategory := range dbCustomBudgets.CustomBudgets {
+		setChildBudgetsLastMonthExpenses(customBudgetByCategory.ChildBudgets, transactionTotalAmountByMediumCategoryList)
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(&dbCustomBudgets); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) PutCustomBudgets(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	yearMonth, err := time.Parse("2006-01", mux.Vars(r)["year_month"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
+		return
+	}
+
+	var customBudgets model.CustomBudgets
+	if err := json.NewDecoder(r.Body).Decode(&customBudgets); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if err := validateBudgets(customBudgets); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
+		return
+	}
+
+	mediumCategoryBigCategoryIDs, customCategoryBigCategoryIDs, err := generateCategoryBigCategoryIDs(h, userID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if err := validateChildBudgetCategories(customBudgets, mediumCategoryBigCategoryIDs, customCategoryBigCategoryIDs); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
+		return
+	}
+
+	if err := h.BudgetsRepo.PutCustomBudgets(&customBudgets, yearMonth, userID); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	dbCustomBudgets, err := h.BudgetsRepo.GetCustomBudgets(yearMonth, userID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if err := setCustomBudgetsRollover(h, dbCustomBudgets, yearMonth, userID); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	now := h.TimeManage.Now()
+	firstDayOfLastMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0)
+	lastDayOfLastMonth := firstDayOfLastMonth.AddDate(0, 1, 0).Add(-1 * time.Second)
+
+	transactionTotalAmountByBigCategoryList, err := h.TransactionsRepo.GetMonthlyTransactionTotalAmountByBigCategory(userID, firstDayOfLastMonth, lastDayOfLastMonth)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	for _, transactionTotalAmountByBigCategory := range transactionTotalAmountByBigCategoryList {
+		for i, standardBudgetByCategory := range dbCustomBudgets.CustomBudgets {
+			if transactionTotalAmountByBigCategory.BigCategoryID == standardBudgetByCategory.BigCategoryID {
+				dbCustomBudgets.CustomBudgets[i].LastMonthExpenses = transactionTotalAmountByBigCategory.TotalAmount
+
+				break
+			}
+		}
+	}
+
+	transactionTotalAmountByMediumCategoryList, err := h.TransactionsRepo.GetMonthlyTransactionTotalAmountByMediumCategory(userID, firstDayOfLastMonth, lastDayOfLastMonth)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	for _, customBudgetByCategory := range dbCustomBudgets.CustomBudgets {
+		setChildBudgetsLastMonthExpenses(customBudgetByCategory.ChildBudgets, transactionTotalAmountByMediumCategoryList)
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&dbCustomBudgets); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) DeleteCustomBudgets(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	yearMonth, err := time.Parse("2006-01", mux.Vars(r)["year_month"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
+		return
+	}
+
+	if err := h.BudgetsRepo.DeleteCustomBudgets(yearMonth, userID); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&DeleteContentMsg{localizeMessage(r.Context(), "カスタム予算を削除しました。")}); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) GetYearlyBudgets(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	year, err := time.Parse("2006", mux.Vars(r)["year"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年を正しく指定してください。"}))
+		return
+	}
+
+	monthlyStandardBudget, err := h.BudgetsRepo.GetMonthlyStandardBudget(userID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	monthlyCustomBudgets, err := h.BudgetsRepo.GetMonthlyCustomBudgets(year, userID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	budgetRollovers, err := generateBudgetRollovers(h, year, year.AddDate(0, 11, 0), userID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	yearlyBudget := model.NewYearlyBudget(year)
+
+	for i, j := 0, 0; i < len(yearlyBudget.MonthlyBudgets); i++ {
+		if j < len(monthlyCustomBudgets) && time.Month(i)+1 == monthlyCustomBudgets[j].Month.Month() {
+			yearlyBudget.YearlyTotalBudget += monthlyCustomBudgets[j].MonthlyTotalBudget
+			yearlyBudget.MonthlyBudgets[i] = monthlyCustomBudgets[j]
+
+			j++
+		} else {
+			monthlyStandardBudget.Month.Time = year.AddDate(0, i, 0)
+			yearlyBudget.YearlyTotalBudget += monthlyStandardBudget.MonthlyTotalBudget
+			yearlyBudget.MonthlyBudgets[i] = monthlyStandardBudget
+		}
+
+		for _, rollover := range budgetRollovers[year.AddDate(0, i, 0).Format("2006-01")] {
+			yearlyBudget.MonthlyBudgets[i].MonthlyTotalRollover += rollover
+		}
+
+		yearlyBudget.MonthlyBudgets[i].MonthlyTotalEffectiveBudget = yearlyBudget.MonthlyBudgets[i].MonthlyTotalBudget + yearlyBudget.MonthlyBudgets[i].MonthlyTotalRollover
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(yearlyBudget); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) GetBudgetStatus(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
 		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
 	}
 
-	return nil
-}
+	yearMonth, err := time.Parse("2006-01", mux.Vars(r)["year_month"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
+		return
+	}
 
-func (h *DBHandler) PostInitStandardBudgets(w http.ResponseWriter, r *http.Request) {
-	var userID UserID
-	if err := json.NewDecoder(r.Body).Decode(&userID); err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+	budgetStatus, err := generateBudgetStatus(h, yearMonth, userID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	if err := h.BudgetsRepo.PostInitStandardBudgets(userID.UserID); err != nil {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(budgetStatus); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
-
-	w.WriteHeader(http.StatusCreated)
 }
 
-func (h *DBHandler) GetStandardBudgets(w http.ResponseWriter, r *http.Request) {
+func (h *DBHandler) GetBudgetForecast(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	standardBudgets, err := h.BudgetsRepo.GetStandardBudgets(userID)
+	forecastMethod, err := parseForecastMethod(r)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
 		return
 	}
 
-	now := h.TimeManage.Now()
-	firstDayOfLastMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0)
-	lastDayOfLastMonth := firstDayOfLastMonth.AddDate(0, 1, 0).Add(-1 * time.Second)
-
-	transactionTotalAmountByBigCategoryList, err := h.TransactionsRepo.GetMonthlyTransactionTotalAmountByBigCategory(userID, firstDayOfLastMonth, lastDayOfLastMonth)
+	budgetForecast, err := generateBudgetForecast(h, forecastMethod, userID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	for _, transactionTotalAmountByBigCategory := range transactionTotalAmountByBigCategoryList {
-		for i, standardBudgetByCategory := range standardBudgets.StandardBudgets {
-			if transactionTotalAmountByBigCategory.BigCategoryID == standardBudgetByCategory.BigCategoryID {
-				standardBudgets.StandardBudgets[i].LastMonthExpenses = transactionTotalAmountByBigCategory.TotalAmount
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(budgetForecast); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
 
-				break
-			}
+func (h *DBHandler) GetBudgetRolloverSettings(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
 		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	budgetRolloverSettings, err := h.BudgetsRepo.GetBudgetRolloverSettings(userID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(&standardBudgets); err != nil {
+	if err := json.NewEncoder(w).Encode(budgetRolloverSettings); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 }
 
-func (h *DBHandler) PutStandardBudgets(w http.ResponseWriter, r *http.Request) {
+func (h *DBHandler) PutBudgetRolloverSettings(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	var standardBudgets model.StandardBudgets
-	if err := json.NewDecoder(r.Body).Decode(&standardBudgets); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+	var budgetRolloverSettings model.BudgetRolloverSettings
+	if err := json.NewDecoder(r.Body).Decode(&budgetRolloverSettings); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	if err := validateBudgets(standardBudgets); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, err))
+	bigCategoriesList, err := h.CategoriesRepo.GetBigCategoriesList()
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	if err := h.BudgetsRepo.PutStandardBudgets(&standardBudgets, userID); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+	if err := validateRolloverSettings(budgetRolloverSettings, bigCategoriesList); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
 		return
 	}
 
-	dbStandardBudgets, err := h.BudgetsRepo.GetStandardBudgets(userID)
+	dbBudgetRolloverSettings, err := h.BudgetsRepo.GetBudgetRolloverSettings(userID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
+	// The rollover is accumulated from the month in which it was enabled, so keep the start month while it stays enabled.
 	now := h.TimeManage.Now()
-	firstDayOfLastMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0)
-	lastDayOfLastMonth := firstDayOfLastMonth.AddDate(0, 1, 0).Add(-1 * time.Second)
+	firstDayOfThisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
 
-	transactionTotalAmountByBigCategoryList, err := h.TransactionsRepo.GetMonthlyTransactionTotalAmountByBigCategory(userID, firstDayOfLastMonth, lastDayOfLastMonth)
-	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
-		return
-	}
+	for i, budgetRolloverSetting := range budgetRolloverSettings.BudgetRolloverSettings {
+		if !budgetRolloverSetting.Rollover {
+			budgetRolloverSettings.BudgetRolloverSettings[i].RolloverStartMonth = sql.NullTime{}
+			continue
+		}
 
-	for _, transactionTotalAmountByBigCategory := range transactionTotalAmountByBigCategoryList {
-		for i, standardBudgetByCategory := range dbStandardBudgets.StandardBudgets {
-			if transactionTotalAmountByBigCategory.BigCategoryID == standardBudgetByCategory.BigCategoryID {
-				dbStandardBudgets.StandardBudgets[i].LastMonthExpenses = transactionTotalAmountByBigCategory.TotalAmount
+		budgetRolloverSettings.BudgetRolloverSettings[i].RolloverStartMonth = sql.NullTime{Time: firstDayOfThisMonth, Valid: true}
+
+		for _, dbBudgetRolloverSetting := range dbBudgetRolloverSettings.BudgetRolloverSettings {
+			if dbBudgetRolloverSetting.BigCategoryID == budgetRolloverSetting.BigCategoryID && bool(dbBudgetRolloverSetting.Rollover) && dbBudgetRolloverSetting.RolloverStartMonth.Valid {
+				budgetRolloverSettings.BudgetRolloverSettings[i].RolloverStartMonth = dbBudgetRolloverSetting.RolloverStartMonth
 
 				break
 			}
 		}
 	}
 
+	if err := h.BudgetsRepo.PutBudgetRolloverSettings(&budgetRolloverSettings, userID); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	dbBudgetRolloverSettings, err = h.BudgetsRepo.GetBudgetRolloverSettings(userID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(&dbStandardBudgets); err != nil {
+	if err := json.NewEncoder(w).Encode(dbBudgetRolloverSettings); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 }
 
-func (h *DBHandler) GetCustomBudgets(w http.ResponseWriter, r *http.Request) {
+func (h *DBHandler) GetBudgetTemplatesList(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
-		return
-	}
-
-	yearMonth, err := time.Parse("2006-01", mux.Vars(r)["year_month"])
-	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	dbCustomBudgets, err := h.BudgetsRepo.GetCustomBudgets(yearMonth, userID)
+	budgetTemplatesList, err := h.BudgetsRepo.GetBudgetTemplatesList(userID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	now := h.TimeManage.Now()
-	firstDayOfLastMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0)
-	lastDayOfLastMonth := firstDayOfLastMonth.AddDate(0, 1, 0).Add(-1 * time.Second)
+	if len(budgetTemplatesList) == 0 {
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(&NoContentMsg{localizeMessage(r.Context(), "予算テンプレートは登録されていません。")}); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
 
-	transactionTotalAmountByBigCategoryList, err := h.TransactionsRepo.GetMonthlyTransactionTotalAmountByBigCategory(userID, firstDayOfLastMonth, lastDayOfLastMonth)
-	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	for _, transactionTotalAmountByBigCategory := range transactionTotalAmountByBigCategoryList {
-		for i, standardBudgetByCategory := range dbCustomBudgets.CustomBudgets {
-			if transactionTotalAmountByBigCategory.BigCategoryID == standardBudgetByCategory.BigCategoryID {
-				dbCustomBudgets.CustomBudgets[i].LastMonthExpenses = transactionTotalAmountByBigCategory.TotalAmount
-
-				break
-			}
-		}
-	}
+	budgetTemplates := model.NewBudgetTemplates(budgetTemplatesList)
 
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(&dbCustomBudgets); err != nil {
+	if err := json.NewEncoder(w).Encode(&budgetTemplates); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 }
 
-func (h *DBHandler) PostCustomBudgets(w http.ResponseWriter, r *http.Request) {
+func (h *DBHandler) PostBudgetTemplate(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	yearMonth, err := time.Parse("2006-01", mux.Vars(r)["year_month"])
-	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
+	var budgetTemplate model.BudgetTemplate
+	if err := json.NewDecoder(r.Body).Decode(&budgetTemplate); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	var customBudgets model.CustomBudgets
-	if err := json.NewDecoder(r.Body).Decode(&customBudgets); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
-		return
-	}
+	budgetTemplate.ID = 0
 
-	if err := validateBudgets(customBudgets); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, err))
+	if err := validateBudgetTemplate(h, &budgetTemplate, userID); err != nil {
+		budgetValidationErrorMsg, ok := err.(*BudgetValidationErrorMsg)
+		if !ok {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, budgetValidationErrorMsg))
 		return
 	}
 
-	if err := h.BudgetsRepo.PostCustomBudgets(&customBudgets, yearMonth, userID); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+	if err := h.BudgetsRepo.FindBudgetTemplate(&budgetTemplate, userID); err != sql.ErrNoRows {
+		if err == nil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusConflict, &ConflictErrorMsg{"予算テンプレートの登録に失敗しました。 同じテンプレート名が既に存在していないか確認してください。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	dbCustomBudgets, err := h.BudgetsRepo.GetCustomBudgets(yearMonth, userID)
+	result, err := h.BudgetsRepo.PostBudgetTemplate(&budgetTemplate, userID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	now := h.TimeManage.Now()
-	firstDayOfLastMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0)
-	lastDayOfLastMonth := firstDayOfLastMonth.AddDate(0, 1, 0).Add(-1 * time.Second)
-
-	transactionTotalAmountByBigCategoryList, err := h.TransactionsRepo.GetMonthlyTransactionTotalAmountByBigCategory(userID, firstDayOfLastMonth, lastDayOfLastMonth)
+	lastInsertId, err := result.LastInsertId()
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	for _, transactionTotalAmountByBigCategory := range transactionTotalAmountByBigCategoryList {
-		for i, standardBudgetByCategory := range dbCustomBudgets.CustomBudgets {
-			if transactionTotalAmountByBigCategory.BigCategoryID == standardBudgetByCategory.BigCategoryID {
-				dbCustomBudgets.CustomBudgets[i].LastMonthExpenses = transactionTotalAmountByBigCategory.TotalAmount
-
-				break
-			}
-		}
+	dbBudgetTemplate, err := h.BudgetsRepo.GetBudgetTemplate(int(lastInsertId), userID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusCreated)
-	if err := json.NewEncoder(w).Encode(&dbCustomBudgets); err != nil {
+	if err := json.NewEncoder(w).Encode(dbBudgetTemplate); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 }
 
-func (h *DBHandler) PutCustomBudgets(w http.ResponseWriter, r *http.Request) {
+func (h *DBHandler) PutBudgetTemplate(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	yearMonth, err := time.Parse("2006-01", mux.Vars(r)["year_month"])
+	var budgetTemplate model.BudgetTemplate
+	if err := json.NewDecoder(r.Body).Decode(&budgetTemplate); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	budgetTemplate.ID, err = strconv.Atoi(mux.Vars(r)["id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"budget template ID を正しく指定してください。"}))
 		return
 	}
 
-	var customBudgets model.CustomBudgets
-	if err := json.NewDecoder(r.Body).Decode(&customBudgets); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+	if _, err := h.BudgetsRepo.GetBudgetTemplate(budgetTemplate.ID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusNotFound, &NotFoundErrorMsg{"予算テンプレートが見つかりませんでした。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	if err := validateBudgets(customBudgets); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, err))
+	if err := validateBudgetTemplate(h, &budgetTemplate, userID); err != nil {
+		budgetValidationErrorMsg, ok := err.(*BudgetValidationErrorMsg)
+		if !ok {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, budgetValidationErrorMsg))
 		return
 	}
 
-	if err := h.BudgetsRepo.PutCustomBudgets(&customBudgets, yearMonth, userID); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+	if err := h.BudgetsRepo.FindBudgetTemplate(&budgetTemplate, userID); err != sql.ErrNoRows {
+		if err == nil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusConflict, &ConflictErrorMsg{"予算テンプレートの更新に失敗しました。 同じテンプレート名が既に存在していないか確認してください。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	dbCustomBudgets, err := h.BudgetsRepo.GetCustomBudgets(yearMonth, userID)
+	if err := h.BudgetsRepo.PutBudgetTemplate(&budgetTemplate, userID); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	dbBudgetTemplate, err := h.BudgetsRepo.GetBudgetTemplate(budgetTemplate.ID, userID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	now := h.TimeManage.Now()
-	firstDayOfLastMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0)
-	lastDayOfLastMonth := firstDayOfLastMonth.AddDate(0, 1, 0).Add(-1 * time.Second)
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(dbBudgetTemplate); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
 
-	transactionTotalAmountByBigCategoryList, err := h.TransactionsRepo.GetMonthlyTransactionTotalAmountByBigCategory(userID, firstDayOfLastMonth, lastDayOfLastMonth)
+func (h *DBHandler) DeleteBudgetTemplate(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	for _, transactionTotalAmountByBigCategory := range transactionTotalAmountByBigCategoryList {
-		for i, standardBudgetByCategory := range dbCustomBudgets.CustomBudgets {
-			if transactionTotalAmountByBigCategory.BigCategoryID == standardBudgetByCategory.BigCategoryID {
-				dbCustomBudgets.CustomBudgets[i].LastMonthExpenses = transactionTotalAmountByBigCategory.TotalAmount
+	templateID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"budget template ID を正しく指定してください。"}))
+		return
+	}
 
-				break
-			}
+	if _, err := h.BudgetsRepo.GetBudgetTemplate(templateID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusNotFound, &NotFoundErrorMsg{"予算テンプレートが見つかりませんでした。"}))
+			return
 		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if err := h.BudgetsRepo.DeleteBudgetTemplate(templateID, userID); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(&dbCustomBudgets); err != nil {
+	if err := json.NewEncoder(w).Encode(&DeleteContentMsg{localizeMessage(r.Context(), "予算テンプレートを削除しました。")}); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 }
 
-func (h *DBHandler) DeleteCustomBudgets(w http.ResponseWriter, r *http.Request) {
+func (h *DBHandler) ApplyBudgetTemplate(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	yearMonth, err := time.Parse("2006-01", mux.Vars(r)["year_month"])
+	templateID, err := strconv.Atoi(mux.Vars(r)["id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"budget template ID を正しく指定してください。"}))
 		return
 	}
 
-	if err := h.BudgetsRepo.DeleteCustomBudgets(yearMonth, userID); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+	var budgetTargetMonths model.BudgetTargetMonths
+	if err := json.NewDecoder(r.Body).Decode(&budgetTargetMonths); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	targetMonthList, err := generateBudgetTargetMonths(r.Context(), budgetTargetMonths)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
+		return
+	}
+
+	budgetTemplate, err := h.BudgetsRepo.GetBudgetTemplate(templateID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusNotFound, &NotFoundErrorMsg{"予算テンプレートが見つかりませんでした。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	customBudgets := model.NewCustomBudgetsFromBudgetTemplate(*budgetTemplate)
+
+	if err := validateBudgets(customBudgets); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
+		return
+	}
+
+	// A category budgeted by the template may have been deleted or moved to another big category since the template was saved.
+	mediumCategoryBigCategoryIDs, customCategoryBigCategoryIDs, err := generateCategoryBigCategoryIDs(h, userID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if err := validateChildBudgetCategories(customBudgets, mediumCategoryBigCategoryIDs, customCategoryBigCategoryIDs); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
+		return
+	}
+
+	if err := h.BudgetsRepo.ReplaceCustomBudgetsList(&customBudgets, targetMonthList, userID); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
+	budgetYearMonths := model.NewBudgetYearMonths(targetMonthList)
+
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(&DeleteContentMsg{"カスタム予算を削除しました。"}); err != nil {
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(&budgetYearMonths); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 }
 
-func (h *DBHandler) GetYearlyBudgets(w http.ResponseWriter, r *http.Request) {
+func (h *DBHandler) CopyCustomBudgets(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	year, err := time.Parse("2006", mux.Vars(r)["year"])
+	yearMonth, err := time.Parse("2006-01", mux.Vars(r)["year_month"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
 		return
 	}
 
-	monthlyStandardBudget, err := h.BudgetsRepo.GetMonthlyStandardBudget(userID)
-	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+	var budgetTargetMonths model.BudgetTargetMonths
+	if err := json.NewDecoder(r.Body).Decode(&budgetTargetMonths); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	monthlyCustomBudgets, err := h.BudgetsRepo.GetMonthlyCustomBudgets(year, userID)
+	targetMonthList, err := generateBudgetCopyTargetMonths(r.Context(), budgetTargetMonths, yearMonth)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
 		return
 	}
 
-	yearlyBudget := model.NewYearlyBudget(year)
+	customBudgets, err := h.BudgetsRepo.GetCustomBudgets(yearMonth, userID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
 
-	for i, j := 0, 0; i < len(yearlyBudget.MonthlyBudgets); i++ {
-		if j < len(monthlyCustomBudgets) && time.Month(i)+1 == monthlyCustomBudgets[j].Month.Month() {
-			yearlyBudget.YearlyTotalBudget += monthlyCustomBudgets[j].MonthlyTotalBudget
-			yearlyBudget.MonthlyBudgets[i] = monthlyCustomBudgets[j]
+	if len(customBudgets.CustomBudgets) == 0 {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BudgetValidationErrorMsg{"コピー元のカスタム予算が登録されていません。"}))
+		return
+	}
 
-			j++
-			continue
-		}
+	if err := validateBudgets(customBudgets); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
+		return
+	}
 
-		monthlyStandardBudget.Month.Time = year.AddDate(0, i, 0)
-		yearlyBudget.YearlyTotalBudget += monthlyStandardBudget.MonthlyTotalBudget
-		yearlyBudget.MonthlyBudgets[i] = monthlyStandardBudget
+	if err := h.BudgetsRepo.ReplaceCustomBudgetsList(customBudgets, targetMonthList, userID); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
 	}
 
+	budgetYearMonths := model.NewBudgetYearMonths(targetMonthList)
+
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(yearlyBudget); err != nil {
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(&budgetYearMonths); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
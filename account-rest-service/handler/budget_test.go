@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"database/sql"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -25,22 +26,28 @@ func (m MockBudgetsRepository) PostInitStandardBudgets(userID string) error {
 func (m MockBudgetsRepository) GetStandardBudgets(userID string) (*model.StandardBudgets, error) {
 	return &model.StandardBudgets{
 		StandardBudgets: []model.StandardBudgetByCategory{
-			{BigCategoryID: 2, BigCategoryName: "食費", Budget: 25000},
-			{BigCategoryID: 3, BigCategoryName: "日用品", Budget: 5000},
-			{BigCategoryID: 4, BigCategoryName: "趣味・娯楽", Budget: 4500},
-			{BigCategoryID: 5, BigCategoryName: "交際費", Budget: 1000},
-			{BigCategoryID: 6, BigCategoryName: "交通費", Budget: 1000},
-			{BigCategoryID: 7, BigCategoryName: "衣服・美容", Budget: 0},
-			{BigCategoryID: 8, BigCategoryName: "健康・医療", Budget: 4900},
-			{BigCategoryID: 9, BigCategoryName: "通信費", Budget: 4400},
-			{BigCategoryID: 10, BigCategoryName: "教養・教育", Budget: 10000},
-			{BigCategoryID: 11, BigCategoryName: "住宅", Budget: 15000},
-			{BigCategoryID: 12, BigCategoryName: "水道・光熱費", Budget: 3000},
-			{BigCategoryID: 13, BigCategoryName: "自動車", Budget: 0},
-			{BigCategoryID: 14, BigCategoryName: "保険", Budget: 9800},
-			{BigCategoryID: 15, BigCategoryName: "税金・社会保険", Budget: 0},
-			{BigCategoryID: 16, BigCategoryName: "現金・カード", Budget: 0},
-			{BigCategoryID: 17, BigCategoryName: "その他", Budget: 0},
+			{BigCategoryID: 2, BigCategoryName: "食費", Budget: 25000, ChildBudgets: []model.ChildBudgetByCategory{
+				{MediumCategoryID: model.NullInt64{NullInt64: sql.NullInt64{Int64: 6, Valid: true}}, CategoryName: "食料品", Budget: 15000},
+				{MediumCategoryID: model.NullInt64{NullInt64: sql.NullInt64{Int64: 10, Valid: true}}, CategoryName: "外食", Budget: 8000},
+				{CustomCategoryID: model.NullInt64{NullInt64: sql.NullInt64{Int64: 3, Valid: true}}, CategoryName: "米", Budget: 2000},
+			}},
+			{BigCategoryID: 3, BigCategoryName: "日用品", Budget: 5000, ChildBudgets: []model.ChildBudgetByCategory{
+				{MediumCategoryID: model.NullInt64{NullInt64: sql.NullInt64{Int64: 13, Valid: true}}, CategoryName: "消耗品", Budget: 3000},
+			}},
+			{BigCategoryID: 4, BigCategoryName: "趣味・娯楽", Budget: 4500, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 5, BigCategoryName: "交際費", Budget: 1000, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 6, BigCategoryName: "交通費", Budget: 1000, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 7, BigCategoryName: "衣服・美容", Budget: 0, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 8, BigCategoryName: "健康・医療", Budget: 4900, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 9, BigCategoryName: "通信費", Budget: 4400, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 10, BigCategoryName: "教養・教育", Budget: 10000, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 11, BigCategoryName: "住宅", Budget: 15000, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 12, BigCategoryName: "水道・光熱費", Budget: 3000, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 13, BigCategoryName: "自動車", Budget: 0, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 14, BigCategoryName: "保険", Budget: 9800, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 15, BigCategoryName: "税金・社会保険", Budget: 0, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 16, BigCategoryName: "現金・カード", Budget: 0, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 17, BigCategoryName: "その他", Budget: 0, ChildBudgets: []model.ChildBudgetByCategory{}},
 		},
 	}, nil
 }
@@ -52,22 +59,28 @@ func (m MockBudgetsRepository) PutStandardBudgets(standardBudgets *model.Standar
 func (m MockBudgetsRepository) GetCustomBudgets(yearMonth time.Time, userID string) (*model.CustomBudgets, error) {
 	return &model.CustomBudgets{
 		CustomBudgets: []model.CustomBudgetByCategory{
-			{BigCategoryID: 2, BigCategoryName: "食費", Budget: 30000},
-			{BigCategoryID: 3, BigCategoryName: "日用品", Budget: 5000},
-			{BigCategoryID: 4, BigCategoryName: "趣味・娯楽", Budget: 4500},
-			{BigCategoryID: 5, BigCategoryName: "交際費", Budget: 1000},
-			{BigCategoryID: 6, BigCategoryName: "交通費", Budget: 1000},
-			{BigCategoryID: 7, BigCategoryName: "衣服・美容", Budget: 0},
-			{BigCategoryID: 8, BigCategoryName: "健康・医療", Budget: 4900},
-			{BigCategoryID: 9, BigCategoryName: "通信費", Budget: 4400},
-			{BigCategoryID: 10, BigCategoryName: "教養・教育", Budget: 10000},
-			{BigCategoryID: 11, BigCategoryName: "住宅", Budget: 15000},
-			{BigCategoryID: 12, BigCategoryName: "水道・光熱費", Budget: 3000},
-			{BigCategoryID: 13, BigCategoryName: "自動車", Budget: 0},
-			{BigCategoryID: 14, BigCategoryName: "保険", Budget: 9800},
-			{BigCategoryID: 15, BigCategoryName: "税金・社会保険", Budget: 0},
-			{BigCategoryID: 16, BigCategoryName: "現金・カード", Budget: 0},
-			{BigCategoryID: 17, BigCategoryName: "その他", Budget: 0},
+			{BigCategoryID: 2, BigCategoryName: "食費", Budget: 30000, ChildBudgets: []model.ChildBudgetByCategory{
+				{MediumCategoryID: model.NullInt64{NullInt64: sql.NullInt64{Int64: 6, Valid: true}}, CategoryName: "食料品", Budget: 15000},
+				{MediumCategoryID: model.NullInt64{NullInt64: sql.NullInt64{Int64: 10, Valid: true}}, CategoryName: "外食", Budget: 8000},
+				{CustomCategoryID: model.NullInt64{NullInt64: sql.NullInt64{Int64: 3, Valid: true}}, CategoryName: "米", Budget: 2000},
+			}},
+			{BigCategoryID: 3, BigCategoryName: "日用品", Budget: 5000, ChildBudgets: []model.ChildBudgetByCategory{
+				{MediumCategoryID: model.NullInt64{NullInt64: sql.NullInt64{Int64: 13, Valid: true}}, CategoryName: "消耗品", Budget: 3000},
+			}},
+			{BigCategoryID: 4, BigCategoryName: "趣味・娯楽", Budget: 4500, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 5, BigCategoryName: "交際費", Budget: 1000, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 6, BigCategoryName: "交通費", Budget: 1000, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 7, BigCategoryName: "衣服・美容", Budget: 0, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 8, BigCategoryName: "健康・医療", Budget: 4900, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 9, BigCategoryName: "通信費", Budget: 4400, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 10, BigCategoryName: "教養・教育", Budget: 10000, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 11, BigCategoryName: "住宅", Budget: 15000, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 12, BigCategoryName: "水道・光熱費", Budget: 3000, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 13, BigCategoryName: "自動車", Budget: 0, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 14, BigCategoryName: "保険", Budget: 9800, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 15, BigCategoryName: "税金・社会保険", Budget: 0, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 16, BigCategoryName: "現金・カード", Budget: 0, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 17, BigCategoryName: "その他", Budget: 0, ChildBudgets: []model.ChildBudgetByCategory{}},
 		},
 	}, nil
 }
@@ -98,6 +111,100 @@ func (m MockBudgetsRepository) GetMonthlyCustomBudgets(year time.Time, userID st
 	}, nil
 }
 
+func (m MockBudgetsRepository) GetMonthlyCustomBudgetByCategoryList(firstMonth time.Time, lastMonth time.Time, userID string) ([]model.MonthlyCustomBudgetByCategory, error) {
+	return []model.MonthlyCustomBudgetByCategory{
+		{Month: time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC), BigCategoryID: 2, Budget: 30000},
+		{Month: time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC), BigCategoryID: 3, Budget: 5000},
+	}, nil
+}
+
+func (m MockBudgetsRepository) GetBudgetRolloverSettings(userID string) (*model.BudgetRolloverSettings, error) {
+	return &model.BudgetRolloverSettings{
+		BudgetRolloverSettings: []model.BudgetRolloverSetting{
+			{BigCategoryID: 2, Rollover: true, RolloverCap: model.NullInt{Int: 10000, Valid: true}, RolloverStartMonth: sql.NullTime{Time: time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC), Valid: true}},
+			{BigCategoryID: 3, Rollover: true, RolloverStartMonth: sql.NullTime{Time: time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC), Valid: true}},
+			{BigCategoryID: 4},
+			{BigCategoryID: 5},
+			{BigCategoryID: 6},
+			{BigCategoryID: 7},
+			{BigCategoryID: 8},
+			{BigCategoryID: 9},
+			{BigCategoryID: 10},
+			{BigCategoryID: 11},
+			{BigCategoryID: 12},
+			{BigCategoryID: 13},
+			{BigCategoryID: 14},
+			{BigCategoryID: 15},
+			{BigCategoryID: 16},
+			{BigCategoryID: 17},
+		},
+	}, nil
+}
+
+func (m MockBudgetsRepository) PutBudgetRolloverSettings(budgetRolloverSettings *model.BudgetRolloverSettings, userID string) error {
+	return nil
+}
+
+func (m MockBudgetsRepository) ReplaceCustomBudgetsList(customBudgets *model.CustomBudgets, yearMonthList []time.Time, userID string) error {
+	return nil
+}
+
+func (m MockBudgetsRepository) GetBudgetTemplatesList(userID string) ([]model.BudgetTemplate, error) {
+	budgetTemplate, err := m.GetBudgetTemplate(1, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return []model.BudgetTemplate{*budgetTemplate}, nil
+}
+
+func (m MockBudgetsRepository) GetBudgetTemplate(templateID int, userID string) (*model.BudgetTemplate, error) {
+	return &model.BudgetTemplate{
+		ID:   1,
+		Name: "帰省月",
+		BudgetTemplateByCategory: []model.BudgetTemplateByCategory{
+			{BigCategoryID: 2, BigCategoryName: "食費", Budget: 25000, ChildBudgets: []model.ChildBudgetByCategory{
+				{MediumCategoryID: model.NullInt64{NullInt64: sql.NullInt64{Int64: 6, Valid: true}}, CategoryName: "食料品", Budget: 15000},
+				{MediumCategoryID: model.NullInt64{NullInt64: sql.NullInt64{Int64: 10, Valid: true}}, CategoryName: "外食", Budget: 8000},
+				{CustomCategoryID: model.NullInt64{NullInt64: sql.NullInt64{Int64: 3, Valid: true}}, CategoryName: "米", Budget: 2000},
+			}},
+			{BigCategoryID: 3, BigCategoryName: "日用品", Budget: 5000, ChildBudgets: []model.ChildBudgetByCategory{
+				{MediumCategoryID: model.NullInt64{NullInt64: sql.NullInt64{Int64: 13, Valid: true}}, CategoryName: "消耗品", Budget: 3000},
+			}},
+			{BigCategoryID: 4, BigCategoryName: "趣味・娯楽", Budget: 4500, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 5, BigCategoryName: "交際費", Budget: 1000, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 6, BigCategoryName: "交通費", Budget: 30000, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 7, BigCategoryName: "衣服・美容", Budget: 0, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 8, BigCategoryName: "健康・医療", Budget: 4900, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 9, BigCategoryName: "通信費", Budget: 4400, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 10, BigCategoryName: "教養・教育", Budget: 10000, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 11, BigCategoryName: "住宅", Budget: 15000, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 12, BigCategoryName: "水道・光熱費", Budget: 3000, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 13, BigCategoryName: "自動車", Budget: 0, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 14, BigCategoryName: "保険", Budget: 9800, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 15, BigCategoryName: "税金・社会保険", Budget: 0, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 16, BigCategoryName: "現金・カード", Budget: 0, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 17, BigCategoryName: "その他", Budget: 0, ChildBudgets: []model.ChildBudgetByCategory{}},
+		},
+	}, nil
+}
+
+func (m MockBudgetsRepository) FindBudgetTemplate(budgetTemplate *model.BudgetTemplate, userID string) error {
+	return sql.ErrNoRows
+}
+
+func (m MockBudgetsRepository) PostBudgetTemplate(budgetTemplate *model.BudgetTemplate, userID string) (sql.Result, error) {
+	return MockSqlResult{}, nil
+}
+
+func (m MockBudgetsRepository) PutBudgetTemplate(budgetTemplate *model.BudgetTemplate, userID string) error {
+	return nil
+}
+
+func (m MockBudgetsRepository) DeleteBudgetTemplate(templateID int, userID string) error {
+	return nil
+}
+
 func TestDBHandler_PostInitStandardBudgets(t *testing.T) {
 	h := DBHandler{
 		AuthRepo:    MockAuthRepository{},
@@ -150,6 +257,7 @@ func TestDBHandler_PutStandardBudgets(t *testing.T) {
 		BudgetsRepo:      MockBudgetsRepository{},
 		TransactionsRepo: MockTransactionsRepository{},
 		TimeManage:       MockTime{},
+		CategoriesRepo:   MockCategoriesRepository{},
 	}
 
 	r := httptest.NewRequest("PUT", "/standard-budgets", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
@@ -208,6 +316,7 @@ func TestDBHandler_PostCustomBudgets(t *testing.T) {
 		BudgetsRepo:      MockBudgetsRepository{},
 		TransactionsRepo: MockTransactionsRepository{},
 		TimeManage:       MockTime{},
+		CategoriesRepo:   MockCategoriesRepository{},
 	}
 
 	r := httptest.NewRequest("POST", "/custom-budgets/2020-07", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
@@ -239,6 +348,7 @@ func TestDBHandler_PutCustomBudgets(t *testing.T) {
 		BudgetsRepo:      MockBudgetsRepository{},
 		TransactionsRepo: MockTransactionsRepository{},
 		TimeManage:       MockTime{},
+		CategoriesRepo:   MockCategoriesRepository{},
 	}
 
 	r := httptest.NewRequest("PUT", "/custom-budgets/2020-07", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
@@ -295,8 +405,10 @@ func TestDBHandler_DeleteCustomBudgets(t *testing.T) {
 
 func TestDBHandler_GetYearlyBudgets(t *testing.T) {
 	h := DBHandler{
-		AuthRepo:    MockAuthRepository{},
-		BudgetsRepo: MockBudgetsRepository{},
+		AuthRepo:         MockAuthRepository{},
+		BudgetsRepo:      MockBudgetsRepository{},
+		TransactionsRepo: MockTransactionsRepository{},
+		TimeManage:       MockTime{},
 	}
 
 	r := httptest.NewRequest("GET", "/budgets/2020", nil)
@@ -321,3 +433,902 @@ func TestDBHandler_GetYearlyBudgets(t *testing.T) {
 	testutil.AssertResponseHeader(t, res, http.StatusOK)
 	testutil.AssertResponseBody(t, res, &model.YearlyBudget{}, &model.YearlyBudget{})
 }
+
+func TestDBHandler_GetBudgetStatus(t *testing.T) {
+	h := DBHandler{
+		AuthRepo:         MockAuthRepository{},
+		BudgetsRepo:      MockBudgetsRepository{},
+		TransactionsRepo: MockTransactionsRepository{},
+		TimeManage:       MockTime{},
+	}
+
+	r := httptest.NewRequest("GET", "/budgets/2020-07/status", nil)
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"year_month": "2020-07",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.GetBudgetStatus(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &model.BudgetStatus{}, &model.BudgetStatus{})
+}
+
+func TestDBHandler_GetBudgetForecast(t *testing.T) {
+	h := DBHandler{
+		AuthRepo:         MockAuthRepository{},
+		BudgetsRepo:      MockBudgetsRepository{},
+		TransactionsRepo: MockTransactionsRepository{},
+		TimeManage:       MockTime{},
+	}
+
+	r := httptest.NewRequest("GET", "/budgets/forecast?method=linear", nil)
+	w := httptest.NewRecorder()
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.GetBudgetForecast(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &model.BudgetForecast{}, &model.BudgetForecast{})
+}
+
+func TestDBHandler_GetBudgetRolloverSettings(t *testing.T) {
+	h := DBHandler{
+		AuthRepo:    MockAuthRepository{},
+		BudgetsRepo: MockBudgetsRepository{},
+	}
+
+	r := httptest.NewRequest("GET", "/standard-budgets/rollover", nil)
+	w := httptest.NewRecorder()
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.GetBudgetRolloverSettings(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &model.BudgetRolloverSettings{}, &model.BudgetRolloverSettings{})
+}
+
+func TestDBHandler_PutBudgetRolloverSettings(t *testing.T) {
+	h := DBHandler{
+		AuthRepo:       MockAuthRepository{},
+		BudgetsRepo:    MockBudgetsRepository{},
+		CategoriesRepo: MockCategoriesRepository{},
+		TimeManage:     MockTime{},
+	}
+
+	r := httptest.NewRequest("PUT", "/standard-budgets/rollover", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
+	w := httptest.NewRecorder()
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.PutBudgetRolloverSettings(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &model.BudgetRolloverSettings{}, &model.BudgetRolloverSettings{})
+}
+
+func TestDBHandler_GetBudgetTemplatesList(t *testing.T) {
+	h := DBHandler{
+		AuthRepo:    MockAuthRepository{},
+		BudgetsRepo: MockBudgetsRepository{},
+	}
+
+	r := httptest.NewRequest("GET", "/budget-templates", nil)
+	w := httptest.NewRecorder()
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.GetBudgetTemplatesList(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &model.BudgetTemplates{}, &model.BudgetTemplates{})
+}
+
+func TestDBHandler_PostBudgetTemplate(t *testing.T) {
+	h := DBHandler{
+		AuthRepo:       MockAuthRepository{},
+		BudgetsRepo:    MockBudgetsRepository{},
+		CategoriesRepo: MockCategoriesRepository{},
+	}
+
+	r := httptest.NewRequest("POST", "/budget-templates", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
+	w := httptest.NewRecorder()
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.PostBudgetTemplate(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusCreated)
+	testutil.AssertResponseBody(t, res, &model.BudgetTemplate{}, &model.BudgetTemplate{})
+}
+
+func TestDBHandler_PutBudgetTemplate(t *testing.T) {
+	h := DBHandler{
+		AuthRepo:       MockAuthRepository{},
+		BudgetsRepo:    MockBudgetsRepository{},
+		CategoriesRepo: MockCategoriesRepository{},
+	}
+
+	r := httptest.NewRequest("PUT", "/budget-templates/1", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"id": "1",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.PutBudgetTemplate(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &model.BudgetTemplate{}, &model.BudgetTemplate{})
+}
+
+func TestDBHandler_DeleteBudgetTemplate(t *testing.T) {
+	h := DBHandler{
+		AuthRepo:    MockAuthRepository{},
+		BudgetsRepo: MockBudgetsRepository{},
+	}
+
+	r := httptest.NewRequest("DELETE", "/budget-templates/1", nil)
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"id": "1",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.DeleteBudgetTemplate(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &DeleteContentMsg{}, &DeleteContentMsg{})
+}
+
+func TestDBHandler_ApplyBudgetTemplate(t *testing.T) {
+	h := DBHandler{
+		AuthRepo:       MockAuthRepository{},
+		BudgetsRepo:    MockBudgetsRepository{},
+		CategoriesRepo: MockCategoriesRepository{},
+	}
+
+	r := httptest.NewRequest("POST", "/budget-templates/1/apply", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"id": "1",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.ApplyBudgetTemplate(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusCreated)
+	testutil.AssertResponseBody(t, res, &model.BudgetYearMonths{}, &model.BudgetYearMonths{})
+}
+
+type MockDeletedCustomCategoriesRepository struct {
+	MockCategoriesRepository
+}
+
+func (m MockDeletedCustomCategoriesRepository) GetCustomCategoriesList(userID string) ([]model.AssociatedCategory, error) {
+	return []model.AssociatedCategory{}, nil
+}
+
+func TestDBHandler_ApplyBudgetTemplateWithDeletedCustomCategory(t *testing.T) {
+	h := DBHandler{
+		AuthRepo:       MockAuthRepository{},
+		BudgetsRepo:    MockBudgetsRepository{},
+		CategoriesRepo: MockDeletedCustomCategoriesRepository{},
+	}
+
+	r := httptest.NewRequest("POST", "/budget-templates/1/apply", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"id": "1",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.ApplyBudgetTemplate(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusBadRequest)
+	testutil.AssertResponseBody(t, res, &HTTPError{ErrorMessage: &BudgetValidationErrorMsg{}}, &HTTPError{ErrorMessage: &BudgetValidationErrorMsg{}})
+}
+
+func TestDBHandler_CopyCustomBudgets(t *testing.T) {
+	h := DBHandler{
+		AuthRepo:    MockAuthRepository{},
+		BudgetsRepo: MockBudgetsRepository{},
+	}
+
+	r := httptest.NewRequest("POST", "/custom-budgets/2020-07/copy", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"year_month": "2020-07",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.CopyCustomBudgets(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusCreated)
+	testutil.AssertResponseBody(t, res, &model.BudgetYearMonths{}, &model.BudgetYearMonths{})
+}
+
+func TestValidateRolloverSettings(t *testing.T) {
+	bigCategoriesList, err := MockCategoriesRepository{}.GetBigCategoriesList()
+	if err != nil {
+		t.Fatalf("unexpected error by GetBigCategoriesList() '%#v'", err)
+	}
+
+	tests := []struct {
+		name                   string
+		budgetRolloverSettings model.BudgetRolloverSettings
+		wantErr                error
+	}{
+		{
+			name: "expense big categories",
+			budgetRolloverSettings: model.BudgetRolloverSettings{BudgetRolloverSettings: []model.BudgetRolloverSetting{
+				{BigCategoryID: 2, Rollover: true, RolloverCap: model.NullInt{Int: 10000, Valid: true}},
+				{BigCategoryID: 17},
+			}},
+			wantErr: nil,
+		},
+		{
+			name: "income big category",
+			budgetRolloverSettings: model.BudgetRolloverSettings{BudgetRolloverSettings: []model.BudgetRolloverSetting{
+				{BigCategoryID: 1, Rollover: true},
+			}},
+			wantErr: &BudgetValidationErrorMsg{"大カテゴリーを正しく指定してください。"},
+		},
+		{
+			name: "nonexistent big category",
+			budgetRolloverSettings: model.BudgetRolloverSettings{BudgetRolloverSettings: []model.BudgetRolloverSetting{
+				{BigCategoryID: 18, Rollover: true},
+			}},
+			wantErr: &BudgetValidationErrorMsg{"大カテゴリーを正しく指定してください。"},
+		},
+		{
+			name: "duplicated big category",
+			budgetRolloverSettings: model.BudgetRolloverSettings{BudgetRolloverSettings: []model.BudgetRolloverSetting{
+				{BigCategoryID: 2, Rollover: true},
+				{BigCategoryID: 2},
+			}},
+			wantErr: &BudgetValidationErrorMsg{"大カテゴリーを正しく指定してください。"},
+		},
+		{
+			name: "negative rollover cap",
+			budgetRolloverSettings: model.BudgetRolloverSettings{BudgetRolloverSettings: []model.BudgetRolloverSetting{
+				{BigCategoryID: 2, Rollover: true, RolloverCap: model.NullInt{Int: -1, Valid: true}},
+			}},
+			wantErr: &BudgetValidationErrorMsg{"繰越上限額は0以上の整数を入力してください。"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRolloverSettings(tt.budgetRolloverSettings, bigCategoriesList)
+
+			if diff := cmp.Diff(tt.wantErr, err); len(diff) != 0 {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCrossedBudgetThresholds(t *testing.T) {
+	tests := []struct {
+		name             string
+		budget           int
+		previousExpenses int
+		currentExpenses  int
+		want             []int
+	}{
+		{name: "below every threshold", budget: 10000, previousExpenses: 0, currentExpenses: 7999, want: nil},
+		{name: "exactly at 80%", budget: 10000, previousExpenses: 7999, currentExpenses: 8000, want: []int{80}},
+		{name: "exactly at 100%", budget: 10000, previousExpenses: 9000, currentExpenses: 10000, want: []int{100}},
+		{name: "jump past both thresholds at once", budget: 10000, previousExpenses: 0, currentExpenses: 12000, want: []int{80, 100}},
+		{name: "from above 80% past 100%", budget: 10000, previousExpenses: 8500, currentExpenses: 10500, want: []int{100}},
+		{name: "already above 80%", budget: 10000, previousExpenses: 8000, currentExpenses: 9000, want: nil},
+		{name: "already above 100%", budget: 10000, previousExpenses: 10000, currentExpenses: 15000, want: nil},
+		{name: "expenses decreased", budget: 10000, previousExpenses: 12000, currentExpenses: 5000, want: nil},
+		{name: "no budget", budget: 0, previousExpenses: 0, currentExpenses: 1000, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := crossedBudgetThresholds(tt.budget, tt.previousExpenses, tt.currentExpenses)
+
+			if diff := cmp.Diff(tt.want, got); len(diff) != 0 {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNotifyBudgetThresholdEvents(t *testing.T) {
+	budgetNotifier := &MockRecordingBudgetNotifier{}
+
+	h := DBHandler{
+		BudgetsRepo:      MockBudgetsRepository{},
+		TransactionsRepo: MockTransactionsRepository{},
+		BudgetNotifier:   budgetNotifier,
+		TimeManage:       MockTime{},
+	}
+
+	yearMonth := time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	// The current status of the month is 食費 55000/37000, 日用品 5000/8000, 通信費 7000/4400 and 水道・光熱費 13000/3000.
+	previousBudgetStatus := model.NewBudgetStatus(yearMonth, "CustomBudget", []model.BudgetStatusByCategory{
+		model.NewBudgetStatusByCategory(2, "食費", 37000, 20000),
+		model.NewBudgetStatusByCategory(3, "日用品", 8000, 5000),
+		model.NewBudgetStatusByCategory(9, "通信費", 4400, 4400),
+		model.NewBudgetStatusByCategory(12, "水道・光熱費", 3000, 2400),
+	})
+
+	if err := notifyBudgetThresholdEvents(&h, &previousBudgetStatus, "userID1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []model.BudgetThresholdEvent{
+		{UserID: "userID1", YearMonth: yearMonth, BigCategoryID: 2, BigCategoryName: "食費", Threshold: 80, Budget: 37000, Expenses: 55000},
+		{UserID: "userID1", YearMonth: yearMonth, BigCategoryID: 2, BigCategoryName: "食費", Threshold: 100, Budget: 37000, Expenses: 55000},
+		{UserID: "userID1", YearMonth: yearMonth, BigCategoryID: 12, BigCategoryName: "水道・光熱費", Threshold: 100, Budget: 3000, Expenses: 13000},
+	}
+
+	if diff := cmp.Diff(want, budgetNotifier.budgetThresholdEventList); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestGeneratePreviousBudgetStatusList(t *testing.T) {
+	tests := []struct {
+		name                string
+		transactionDateList []time.Time
+		want                []time.Time
+	}{
+		{
+			name:                "posted transaction",
+			transactionDateList: []time.Time{time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)},
+			want:                []time.Time{time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:                "transaction edited within the month",
+			transactionDateList: []time.Time{time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC), time.Date(2020, 7, 20, 0, 0, 0, 0, time.UTC)},
+			want:                []time.Time{time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:                "transaction moved to another month",
+			transactionDateList: []time.Time{time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC), time.Date(2020, 6, 30, 0, 0, 0, 0, time.UTC)},
+			want:                []time.Time{time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := DBHandler{
+				BudgetsRepo:      MockBudgetsRepository{},
+				TransactionsRepo: MockTransactionsRepository{},
+				TimeManage:       MockTime{},
+			}
+
+			previousBudgetStatusList := generatePreviousBudgetStatusList(&h, "userID1", tt.transactionDateList...)
+
+			got := make([]time.Time, len(previousBudgetStatusList))
+			for i, previousBudgetStatus := range previousBudgetStatusList {
+				got[i] = previousBudgetStatus.YearMonth.Time
+			}
+
+			if diff := cmp.Diff(tt.want, got); len(diff) != 0 {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+// MockRolloverBudgetsRepository has only the standard budget of 食費, 25000, and the rollover setting given by the test.
+type MockRolloverBudgetsRepository struct {
+	MockBudgetsRepository
+	budgetRolloverSetting model.BudgetRolloverSetting
+}
+
+func (m MockRolloverBudgetsRepository) GetStandardBudgets(userID string) (*model.StandardBudgets, error) {
+	return &model.StandardBudgets{
+		StandardBudgets: []model.StandardBudgetByCategory{
+			{BigCategoryID: 2, BigCategoryName: "食費", Budget: 25000, ChildBudgets: []model.ChildBudgetByCategory{}},
+		},
+	}, nil
+}
+
+func (m MockRolloverBudgetsRepository) GetMonthlyCustomBudgetByCategoryList(firstMonth time.Time, lastMonth time.Time, userID string) ([]model.MonthlyCustomBudgetByCategory, error) {
+	return nil, nil
+}
+
+func (m MockRolloverBudgetsRepository) GetBudgetRolloverSettings(userID string) (*model.BudgetRolloverSettings, error) {
+	return &model.BudgetRolloverSettings{
+		BudgetRolloverSettings: []model.BudgetRolloverSetting{m.budgetRolloverSetting},
+	}, nil
+}
+
+// MockRolloverTransactionsRepository returns the monthly expenses of 食費 given by the test, keyed by "2006-01" formatted month.
+type MockRolloverTransactionsRepository struct {
+	MockTransactionsRepository
+	expensesByMonth map[string]int
+}
+
+func (t MockRolloverTransactionsRepository) GetMonthlyTransactionTotalAmountByBigCategoryList(userID string, firstDay time.Time, lastDay time.Time) ([]model.MonthlyTransactionTotalAmountByBigCategory, error) {
+	var monthlyTransactionTotalAmountByBigCategoryList []model.MonthlyTransactionTotalAmountByBigCategory
+	for month := firstDay; month.Before(lastDay); month = month.AddDate(0, 1, 0) {
+		if expenses, ok := t.expensesByMonth[month.Format("2006-01")]; ok {
+			monthlyTransactionTotalAmountByBigCategoryList = append(monthlyTransactionTotalAmountByBigCategoryList, model.MonthlyTransactionTotalAmountByBigCategory{
+				Month:         month,
+				BigCategoryID: 2,
+				TotalAmount:   expenses,
+			})
+		}
+	}
+
+	return monthlyTransactionTotalAmountByBigCategoryList, nil
+}
+
+func TestGenerateBudgetRollovers(t *testing.T) {
+	rolloverStartMonth := sql.NullTime{Time: time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC), Valid: true}
+
+	tests := []struct {
+		name                  string
+		budgetRolloverSetting model.BudgetRolloverSetting
+		expensesByMonth       map[string]int
+		firstMonth            time.Time
+		lastMonth             time.Time
+		want                  map[string]map[int]int
+	}{
+		{
+			name:                  "unused amounts are carried month by month",
+			budgetRolloverSetting: model.BudgetRolloverSetting{BigCategoryID: 2, Rollover: true, RolloverStartMonth: rolloverStartMonth},
+			expensesByMonth:       map[string]int{"2020-07": 20000, "2020-08": 25000, "2020-09": 15000, "2020-10": 30000},
+			firstMonth:            time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC),
+			lastMonth:             time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC),
+			want: map[string]map[int]int{
+				"2020-08": {2: 5000},
+				"2020-09": {2: 5000},
+				"2020-10": {2: 15000},
+			},
+		},
+		{
+			name:                  "carry is limited by the cap",
+			budgetRolloverSetting: model.BudgetRolloverSetting{BigCategoryID: 2, Rollover: true, RolloverCap: model.NullInt{Int: 8000, Valid: true}, RolloverStartMonth: rolloverStartMonth},
+			expensesByMonth:       map[string]int{"2020-07": 20000, "2020-08": 25000, "2020-09": 15000, "2020-10": 30000},
+			firstMonth:            time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC),
+			lastMonth:             time.Date(2020, 11, 1, 0, 0, 0, 0, time.UTC),
+			want: map[string]map[int]int{
+				"2020-08": {2: 5000},
+				"2020-09": {2: 5000},
+				"2020-10": {2: 8000},
+				"2020-11": {2: 3000},
+			},
+		},
+		{
+			name:                  "overspent month resets the carry",
+			budgetRolloverSetting: model.BudgetRolloverSetting{BigCategoryID: 2, Rollover: true, RolloverStartMonth: rolloverStartMonth},
+			expensesByMonth:       map[string]int{"2020-07": 10000, "2020-08": 45000, "2020-09": 20000, "2020-10": 25000},
+			firstMonth:            time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC),
+			lastMonth:             time.Date(2020, 11, 1, 0, 0, 0, 0, time.UTC),
+			want: map[string]map[int]int{
+				"2020-08": {2: 15000},
+				"2020-09": {2: 0},
+				"2020-10": {2: 5000},
+				"2020-11": {2: 5000},
+			},
+		},
+		{
+			name:                  "nothing is carried past the current month",
+			budgetRolloverSetting: model.BudgetRolloverSetting{BigCategoryID: 2, Rollover: true, RolloverStartMonth: rolloverStartMonth},
+			expensesByMonth:       map[string]int{"2020-07": 20000, "2020-08": 25000, "2020-09": 15000, "2020-10": 30000, "2020-11": 0},
+			firstMonth:            time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC),
+			lastMonth:             time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: map[string]map[int]int{
+				"2020-10": {2: 15000},
+				"2020-11": {2: 10000},
+			},
+		},
+		{
+			name:                  "future months only",
+			budgetRolloverSetting: model.BudgetRolloverSetting{BigCategoryID: 2, Rollover: true, RolloverStartMonth: rolloverStartMonth},
+			expensesByMonth:       map[string]int{"2020-07": 20000},
+			firstMonth:            time.Date(2020, 12, 1, 0, 0, 0, 0, time.UTC),
+			lastMonth:             time.Date(2020, 12, 1, 0, 0, 0, 0, time.UTC),
+			want:                  map[string]map[int]int{},
+		},
+		{
+			name:                  "rollover disabled",
+			budgetRolloverSetting: model.BudgetRolloverSetting{BigCategoryID: 2},
+			expensesByMonth:       map[string]int{"2020-07": 20000},
+			firstMonth:            time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC),
+			lastMonth:             time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC),
+			want:                  map[string]map[int]int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := DBHandler{
+				BudgetsRepo:      MockRolloverBudgetsRepository{budgetRolloverSetting: tt.budgetRolloverSetting},
+				TransactionsRepo: MockRolloverTransactionsRepository{expensesByMonth: tt.expensesByMonth},
+				TimeManage:       MockTime{},
+			}
+
+			got, err := generateBudgetRollovers(&h, tt.firstMonth, tt.lastMonth, "userID1")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, got); len(diff) != 0 {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+type MockForecastTime struct {
+	now time.Time
+}
+
+func (m MockForecastTime) Now() time.Time {
+	return m.now
+}
+
+// MockForecastTransactionsRepository aggregates the expense transactions given by the test within the requested period.
+type MockForecastTransactionsRepository struct {
+	MockTransactionsRepository
+	transactionsList []model.TransactionSender
+}
+
+func (t MockForecastTransactionsRepository) GetMonthlyTransactionsList(userID string, firstDay time.Time, lastDay time.Time) ([]model.TransactionSender, error) {
+	var transactionsList []model.TransactionSender
+	for _, transaction := range t.transactionsList {
+		if !transaction.TransactionDate.Time.Before(firstDay) && !transaction.TransactionDate.Time.After(lastDay) {
+			transactionsList = append(transactionsList, transaction)
+		}
+	}
+
+	return transactionsList, nil
+}
+
+func (t MockForecastTransactionsRepository) GetMonthlyTransactionTotalAmountByBigCategory(userID string, firstDay time.Time, lastDay time.Time) ([]model.TransactionTotalAmountByBigCategory, error) {
+	transactionsList, err := t.GetMonthlyTransactionsList(userID, firstDay, lastDay)
+	if err != nil {
+		return nil, err
+	}
+
+	var transactionTotalAmountByBigCategoryList []model.TransactionTotalAmountByBigCategory
+	for _, transaction := range transactionsList {
+		transactionTotalAmountByBigCategoryList = append(transactionTotalAmountByBigCategoryList, model.TransactionTotalAmountByBigCategory{
+			BigCategoryID: transaction.BigCategoryID,
+			TotalAmount:   transaction.Amount,
+		})
+	}
+
+	return transactionTotalAmountByBigCategoryList, nil
+}
+
+func (t MockForecastTransactionsRepository) GetMonthlyTransactionTotalAmountByBigCategoryList(userID string, firstDay time.Time, lastDay time.Time) ([]model.MonthlyTransactionTotalAmountByBigCategory, error) {
+	transactionsList, err := t.GetMonthlyTransactionsList(userID, firstDay, lastDay)
+	if err != nil {
+		return nil, err
+	}
+
+	var monthlyTransactionTotalAmountByBigCategoryList []model.MonthlyTransactionTotalAmountByBigCategory
+	for _, transaction := range transactionsList {
+		monthlyTransactionTotalAmountByBigCategoryList = append(monthlyTransactionTotalAmountByBigCategoryList, model.MonthlyTransactionTotalAmountByBigCategory{
+			Month:         time.Date(transaction.TransactionDate.Time.Year(), transaction.TransactionDate.Time.Month(), 1, 0, 0, 0, 0, time.UTC),
+			BigCategoryID: transaction.BigCategoryID,
+			TotalAmount:   transaction.Amount,
+		})
+	}
+
+	return monthlyTransactionTotalAmountByBigCategoryList, nil
+}
+
+func TestGenerateForecastExpenses(t *testing.T) {
+	newTransaction := func(transactionDate time.Time, bigCategoryID int, shop string, amount int) model.TransactionSender {
+		return model.TransactionSender{
+			TransactionType: "expense",
+			TransactionDate: model.SenderDate{Time: transactionDate},
+			Shop:            model.NullString{NullString: sql.NullString{String: shop, Valid: len(shop) != 0}},
+			Amount:          amount,
+			BigCategoryID:   bigCategoryID,
+		}
+	}
+
+	// 食費 varies from month to month, while 住宅 is charged the same amount on the 27th of every month.
+	historyList := []model.TransactionSender{
+		newTransaction(time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC), 2, "", 2000),
+		newTransaction(time.Date(2020, 8, 20, 0, 0, 0, 0, time.UTC), 2, "", 10000),
+		newTransaction(time.Date(2020, 8, 27, 0, 0, 0, 0, time.UTC), 11, "大家", 50000),
+		newTransaction(time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC), 2, "", 3000),
+		newTransaction(time.Date(2020, 9, 20, 0, 0, 0, 0, time.UTC), 2, "", 9000),
+		newTransaction(time.Date(2020, 9, 27, 0, 0, 0, 0, time.UTC), 11, "大家", 50000),
+		newTransaction(time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC), 2, "", 4000),
+		newTransaction(time.Date(2020, 10, 20, 0, 0, 0, 0, time.UTC), 2, "", 8000),
+		newTransaction(time.Date(2020, 10, 27, 0, 0, 0, 0, time.UTC), 11, "大家", 50000),
+	}
+
+	currentMonthList := []model.TransactionSender{
+		newTransaction(time.Date(2020, 11, 1, 0, 0, 0, 0, time.UTC), 2, "", 3000),
+		newTransaction(time.Date(2020, 11, 20, 0, 0, 0, 0, time.UTC), 2, "", 12000),
+		newTransaction(time.Date(2020, 11, 27, 0, 0, 0, 0, time.UTC), 11, "大家", 50000),
+	}
+
+	firstDayOfMonth := time.Date(2020, 11, 1, 12, 0, 0, 0, time.UTC)
+	lastDayOfMonth := time.Date(2020, 11, 30, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		forecastMethod   string
+		now              time.Time
+		transactionsList []model.TransactionSender
+		want             map[int]int
+	}{
+		{
+			name:             "linear on the first day of the month",
+			forecastMethod:   linearForecastMethod,
+			now:              firstDayOfMonth,
+			transactionsList: append(historyList, currentMonthList...),
+			want:             map[int]int{2: 90000},
+		},
+		{
+			name:             "linear on the last day of the month",
+			forecastMethod:   linearForecastMethod,
+			now:              lastDayOfMonth,
+			transactionsList: append(historyList, currentMonthList...),
+			want:             map[int]int{2: 15000, 11: 50000},
+		},
+		{
+			name:             "historical on the first day of the month",
+			forecastMethod:   historicalForecastMethod,
+			now:              firstDayOfMonth,
+			transactionsList: append(historyList, currentMonthList...),
+			want:             map[int]int{2: 12000, 11: 50000},
+		},
+		{
+			name:             "historical on the last day of the month",
+			forecastMethod:   historicalForecastMethod,
+			now:              lastDayOfMonth,
+			transactionsList: append(historyList, currentMonthList...),
+			want:             map[int]int{2: 15000, 11: 50000},
+		},
+		{
+			name:             "historical without history",
+			forecastMethod:   historicalForecastMethod,
+			now:              firstDayOfMonth,
+			transactionsList: currentMonthList,
+			want:             map[int]int{2: 90000},
+		},
+		{
+			name:             "recurring on the first day of the month",
+			forecastMethod:   recurringForecastMethod,
+			now:              firstDayOfMonth,
+			transactionsList: append(historyList, currentMonthList...),
+			want:             map[int]int{2: 90000, 11: 50000},
+		},
+		{
+			name:             "recurring on the last day of the month",
+			forecastMethod:   recurringForecastMethod,
+			now:              lastDayOfMonth,
+			transactionsList: append(historyList, currentMonthList...),
+			want:             map[int]int{2: 15000, 11: 50000},
+		},
+		{
+			name:             "recurring without history",
+			forecastMethod:   recurringForecastMethod,
+			now:              firstDayOfMonth,
+			transactionsList: currentMonthList,
+			want:             map[int]int{2: 90000},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := DBHandler{
+				TransactionsRepo: MockForecastTransactionsRepository{transactionsList: tt.transactionsList},
+				TimeManage:       MockForecastTime{now: tt.now},
+			}
+
+			period := newForecastPeriod(h.TimeManage.Now())
+
+			transactionTotalAmountByBigCategoryList, err := h.TransactionsRepo.GetMonthlyTransactionTotalAmountByBigCategory("userID1", period.firstDay, period.endOfToday)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			expensesByBigCategory := make(map[int]int)
+			for _, transactionTotalAmountByBigCategory := range transactionTotalAmountByBigCategoryList {
+				expensesByBigCategory[transactionTotalAmountByBigCategory.BigCategoryID] += transactionTotalAmountByBigCategory.TotalAmount
+			}
+
+			got, err := generateForecastExpenses(&h, tt.forecastMethod, period, expensesByBigCategory, "userID1")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, got); len(diff) != 0 {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewForecastPeriod(t *testing.T) {
+	tests := []struct {
+		name                      string
+		now                       time.Time
+		wantElapsedDays           int
+		wantDaysInMonth           int
+		wantSameDayOfMonthPeriods [][2]time.Time
+	}{
+		{
+			name:            "first day of the month",
+			now:             time.Date(2020, 11, 1, 9, 0, 0, 0, time.UTC),
+			wantElapsedDays: 1,
+			wantDaysInMonth: 30,
+			wantSameDayOfMonthPeriods: [][2]time.Time{
+				{time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 10, 1, 23, 59, 59, 0, time.UTC)},
+				{time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 9, 1, 23, 59, 59, 0, time.UTC)},
+				{time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 8, 1, 23, 59, 59, 0, time.UTC)},
+			},
+		},
+		{
+			name:            "last day of the month clamped in shorter months",
+			now:             time.Date(2020, 3, 31, 23, 0, 0, 0, time.UTC),
+			wantElapsedDays: 31,
+			wantDaysInMonth: 31,
+			wantSameDayOfMonthPeriods: [][2]time.Time{
+				{time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 2, 29, 23, 59, 59, 0, time.UTC)},
+				{time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 1, 31, 23, 59, 59, 0, time.UTC)},
+				{time.Date(2019, 12, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 12, 31, 23, 59, 59, 0, time.UTC)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			period := newForecastPeriod(tt.now)
+
+			if diff := cmp.Diff(tt.wantElapsedDays, period.elapsedDays); len(diff) != 0 {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.wantDaysInMonth, period.daysInMonth); len(diff) != 0 {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.wantSameDayOfMonthPeriods, period.sameDayOfMonthPeriods()); len(diff) != 0 {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseForecastMethod(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		want    string
+		wantErr error
+	}{
+		{name: "default method", target: "/budgets/forecast", want: linearForecastMethod},
+		{name: "historical method", target: "/budgets/forecast?method=historical", want: historicalForecastMethod},
+		{name: "recurring method", target: "/budgets/forecast?method=recurring", want: recurringForecastMethod},
+		{name: "invalid method", target: "/budgets/forecast?method=average", wantErr: &BadRequestErrorMsg{"予測方法を正しく指定してください。"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseForecastMethod(httptest.NewRequest("GET", tt.target, nil))
+
+			if diff := cmp.Diff(tt.wantErr, err); len(diff) != 0 {
+				t.Fatalf("differs: (-want +got)\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.want, got); len(diff) != 0 {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"log"
 	"net"
 	"net/http"
 	"strconv"
@@ -54,18 +55,74 @@ func validateCustomCategory(r *http.Request, customCategory *model.CustomCategor
 	return nil
 }
 
-func putShoppingListCustomCategoryIdToMediumCategoryId(mediumCategoryID int, customCategoryID int) error {
+func validateCustomCategoriesOrder(customCategoriesOrder *model.CustomCategoriesOrder, customCategoriesList []model.AssociatedCategory) error {
+	customCategoriesIDMap := make(map[int]bool)
+	for _, customCategory := range customCategoriesList {
+		if customCategory.BigCategoryID == customCategoriesOrder.BigCategoryID {
+			customCategoriesIDMap[customCategory.ID] = false
+		}
+	}
+
+	if len(customCategoriesOrder.CustomCategoryIDList) != len(customCategoriesIDMap) {
+		return &BadRequestErrorMsg{"並び替えには大カテゴリーに含まれる全てのカスタムカテゴリーを指定してください。"}
+	}
+
+	for _, customCategoryID := range customCategoriesOrder.CustomCategoryIDList {
+		if listed, ok := customCategoriesIDMap[customCategoryID]; !ok || listed {
+			return &BadRequestErrorMsg{"並び替えには大カテゴリーに含まれる全てのカスタムカテゴリーを指定してください。"}
+		}
+
+		customCategoriesIDMap[customCategoryID] = true
+	}
+
+	return nil
+}
+
+// selectGroupCustomCategoriesToCopy picks the group custom categories to copy in the order of the id list.
+// Duplicated ids are copied only once.
+func selectGroupCustomCategoriesToCopy(groupCustomCategoryIDList []int, groupCustomCategoriesList []model.GroupAssociatedCategory) ([]model.GroupAssociatedCategory, error) {
+	if len(groupCustomCategoryIDList) == 0 {
+		return nil, &BadRequestErrorMsg{"コピーするカスタムカテゴリーが選択されていません。"}
+	}
+
+	groupCustomCategoriesMap := make(map[int]model.GroupAssociatedCategory, len(groupCustomCategoriesList))
+	for _, groupCustomCategory := range groupCustomCategoriesList {
+		groupCustomCategoriesMap[groupCustomCategory.ID] = groupCustomCategory
+	}
+
+	selectedGroupCustomCategoriesList := make([]model.GroupAssociatedCategory, 0, len(groupCustomCategoryIDList))
+	selectedGroupCustomCategoryIDs := make(map[int]bool, len(groupCustomCategoryIDList))
+	for _, groupCustomCategoryID := range groupCustomCategoryIDList {
+		groupCustomCategory, ok := groupCustomCategoriesMap[groupCustomCategoryID]
+		if !ok {
+			return nil, &BadRequestErrorMsg{"コピーするカスタムカテゴリーを正しく選択してください。"}
+		}
+
+		if selectedGroupCustomCategoryIDs[groupCustomCategoryID] {
+			continue
+		}
+
+		selectedGroupCustomCategoryIDs[groupCustomCategoryID] = true
+		selectedGroupCustomCategoriesList = append(selectedGroupCustomCategoriesList, groupCustomCategory)
+	}
+
+	return selectedGroupCustomCategoriesList, nil
+}
+
+func putShoppingListCustomCategoryIdToReplaceCategoryId(replaceCategoriesID model.CategoriesID, customCategoryID int) error {
 	requestURL := fmt.Sprintf(
 		"http://%s:%d/shopping-list/categories",
 		config.Env.TodoApi.Host, config.Env.TodoApi.Port,
 	)
 
 	categoriesID := struct {
-		MediumCategoryID int `json:"medium_category_id"`
-		CustomCategoryID int `json:"custom_category_id"`
+		MediumCategoryID        model.NullInt64 `json:"medium_category_id"`
+		CustomCategoryID        int             `json:"custom_category_id"`
+		ReplaceCustomCategoryID model.NullInt64 `json:"replace_custom_category_id"`
 	}{
-		MediumCategoryID: mediumCategoryID,
-		CustomCategoryID: customCategoryID,
+		MediumCategoryID:        replaceCategoriesID.MediumCategoryID,
+		CustomCategoryID:        customCategoryID,
+		ReplaceCustomCategoryID: replaceCategoriesID.CustomCategoryID,
 	}
 
 	requestBody, err := json.Marshal(&categoriesID)
@@ -116,36 +173,229 @@ func putShoppingListCustomCategoryIdToMediumCategoryId(mediumCategoryID int, cus
 	return errors.New("couldn't replace customCategoryID in shopping list with default mediumCategoryID")
 }
 
+// mergedShoppingItemsID holds the shopping items whose custom category was reassigned by the todo service,
+// so that the reassignment can be reverted if merging the custom category fails.
+type mergedShoppingItemsID struct {
+	ShoppingItemIDList        []int `json:"shopping_item_id_list"`
+	RegularShoppingItemIDList []int `json:"regular_shopping_item_id_list"`
+}
+
+// mergeShoppingListCustomCategoryMaxAttempts bounds how many times the todo service is asked to merge,
+// which is safe because the todo service answers a repeated merge with the shopping items moved by the first one.
+const mergeShoppingListCustomCategoryMaxAttempts = 3
+
+var mergeShoppingListCustomCategoryRetryInterval = 500 * time.Millisecond
+
+// mergeShoppingListCustomCategoryWithRetry retries the merge so that a merge whose response was lost
+// is not left applied in the todo service while the custom category itself stays unmerged.
+func mergeShoppingListCustomCategoryWithRetry(sourceCustomCategoryID int, targetCustomCategoryID int) (*mergedShoppingItemsID, error) {
+	var err error
+	for attempt := 1; attempt <= mergeShoppingListCustomCategoryMaxAttempts; attempt++ {
+		var mergedItemsID *mergedShoppingItemsID
+		if mergedItemsID, err = mergeShoppingListCustomCategory(sourceCustomCategoryID, targetCustomCategoryID); err == nil {
+			return mergedItemsID, nil
+		}
+
+		log.Println(err)
+
+		if attempt < mergeShoppingListCustomCategoryMaxAttempts {
+			time.Sleep(mergeShoppingListCustomCategoryRetryInterval)
+		}
+	}
+
+	return nil, err
+}
+
+func mergeShoppingListCustomCategory(sourceCustomCategoryID int, targetCustomCategoryID int) (*mergedShoppingItemsID, error) {
+	requestURL := fmt.Sprintf(
+		"http://%s:%d/shopping-list/categories/merge",
+		config.Env.TodoApi.Host, config.Env.TodoApi.Port,
+	)
+
+	customCategoriesID := struct {
+		SourceCustomCategoryID int `json:"source_custom_category_id"`
+		TargetCustomCategoryID int `json:"target_custom_category_id"`
+	}{
+		SourceCustomCategoryID: sourceCustomCategoryID,
+		TargetCustomCategoryID: targetCustomCategoryID,
+	}
+
+	requestBody, err := json.Marshal(&customCategoriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequest(
+		"PUT",
+		requestURL,
+		bytes.NewBuffer(requestBody),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	request.Header.Set("Authorization", "Bearer "+config.Env.ServiceAuth.Token)
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: (&net.Dialer{
+				Timeout:   30 * time.Second,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			MaxIdleConns:          500,
+			MaxIdleConnsPerHost:   100,
+			IdleConnTimeout:       90 * time.Second,
+			ResponseHeaderTimeout: 10 * time.Second,
+			ExpectContinueTimeout: 1 * time.Second,
+		},
+		Timeout: 60 * time.Second,
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_, _ = io.Copy(ioutil.Discard, response.Body)
+		response.Body.Close()
+	}()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.New("couldn't merge customCategoryID in shopping list")
+	}
+
+	var mergedShoppingItemsID mergedShoppingItemsID
+	if err := json.NewDecoder(response.Body).Decode(&mergedShoppingItemsID); err != nil {
+		return nil, err
+	}
+
+	return &mergedShoppingItemsID, nil
+}
+
+func revertShoppingListCustomCategoryMerge(sourceCustomCategoryID int, mergedItemsID *mergedShoppingItemsID) error {
+	requestURL := fmt.Sprintf(
+		"http://%s:%d/shopping-list/categories/merge/revert",
+		config.Env.TodoApi.Host, config.Env.TodoApi.Port,
+	)
+
+	revertedShoppingItemsID := struct {
+		SourceCustomCategoryID int `json:"source_custom_category_id"`
+		*mergedShoppingItemsID
+	}{
+		SourceCustomCategoryID: sourceCustomCategoryID,
+		mergedShoppingItemsID:  mergedItemsID,
+	}
+
+	requestBody, err := json.Marshal(&revertedShoppingItemsID)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest(
+		"PUT",
+		requestURL,
+		bytes.NewBuffer(requestBody),
+	)
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	request.Header.Set("Authorization", "Bearer "+config.Env.ServiceAuth.Token)
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: (&net.Dialer{
+				Timeout:   30 * time.Second,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			MaxIdleConns:          500,
+			MaxIdleConnsPerHost:   100,
+			IdleConnTimeout:       90 * time.Second,
+			ResponseHeaderTimeout: 10 * time.Second,
+			ExpectContinueTimeout: 1 * time.Second,
+		},
+		Timeout: 60 * time.Second,
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_, _ = io.Copy(ioutil.Discard, response.Body)
+		response.Body.Close()
+	}()
+
+	if response.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	return errors.New("couldn't revert merged customCategoryID in shopping list")
+}
+
 func (h *DBHandler) GetCategoriesList(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	bigCategoriesList, err := h.CategoriesRepo.GetBigCategoriesList()
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	mediumCategoriesList, err := h.CategoriesRepo.GetMediumCategoriesList()
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	customCategoriesList, err := h.CategoriesRepo.GetCustomCategoriesList(userID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
+	if r.URL.Query().Get("include_archived") != "true" {
+		unarchivedCustomCategoriesList := make([]model.AssociatedCategory, 0, len(customCategoriesList))
+		for _, customCategory := range customCategoriesList {
+			if !customCategory.IsArchived {
+				unarchivedCustomCategoriesList = append(unarchivedCustomCategoriesList, customCategory)
+			}
+		}
+
+		customCategoriesList = unarchivedCustomCategoriesList
+	}
+
+	if locale := requestLocale(r.Context()); locale != defaultLocale {
+		categoryTranslationsList, err := h.CategoriesRepo.GetCategoryTranslationsList(locale)
+		if err != nil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		categoryTranslations := model.NewCategoryTranslations(categoryTranslationsList)
+
+		for i, bigCategory := range bigCategoriesList {
+			bigCategoriesList[i].Name = categoryTranslations.TranslateBigCategoryName(bigCategory.ID, bigCategory.Name)
+		}
+
+		for i, mediumCategory := range mediumCategoriesList {
+			mediumCategoriesList[i].Name = categoryTranslations.TranslateMediumCategoryName(mediumCategory.ID, mediumCategory.Name)
+		}
+	}
+
 	for i, bigCategory := range bigCategoriesList {
 		for _, customCategory := range customCategoriesList {
 			if bigCategory.TransactionType == "income" && bigCategory.ID == customCategory.BigCategoryID {
@@ -175,56 +425,51 @@ func (h *DBHandler) GetCategoriesList(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(&categoriesList); err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
+	responseByJSONWithETag(w, r, &categoriesList)
 }
 
 func (h *DBHandler) PostCustomCategory(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	customCategory := model.NewCustomCategory()
 	if err := json.NewDecoder(r.Body).Decode(&customCategory); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if err := validateCustomCategory(r, &customCategory); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, err))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
 		return
 	}
 
 	if err := h.CategoriesRepo.FindCustomCategory(&customCategory, userID); err != sql.ErrNoRows {
 		if err == nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusConflict, &ConflictErrorMsg{"中カテゴリーの登録に失敗しました。 同じカテゴリー名が既に存在していないか確認してください。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusConflict, &ConflictErrorMsg{"中カテゴリーの登録に失敗しました。 同じカテゴリー名が既に存在していないか確認してください。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	result, err := h.CategoriesRepo.PostCustomCategory(&customCategory, userID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	lastInsertId, err := result.LastInsertId()
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -242,43 +487,43 @@ func (h *DBHandler) PutCustomCategory(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	customCategory := model.NewCustomCategory()
 	if err := json.NewDecoder(r.Body).Decode(&customCategory); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	customCategory.ID, err = strconv.Atoi(mux.Vars(r)["id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"custom category ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"custom category ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := validateCustomCategory(r, &customCategory); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, err))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
 		return
 	}
 
 	if err := h.CategoriesRepo.FindCustomCategory(&customCategory, userID); err != sql.ErrNoRows {
 		if err == nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusConflict, &ConflictErrorMsg{"中カテゴリーの更新に失敗しました。 同じカテゴリー名が既に存在していないか確認してください。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusConflict, &ConflictErrorMsg{"中カテゴリーの更新に失敗しました。 同じカテゴリー名が既に存在していないか確認してください。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if err := h.CategoriesRepo.PutCustomCategory(&customCategory); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -290,54 +535,384 @@ func (h *DBHandler) PutCustomCategory(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// getReplaceCategoriesID returns the category chosen by the user to replace the deleted custom category,
+// or the fallback medium category of the big category if none is chosen.
+func (h *DBHandler) getReplaceCategoriesID(r *http.Request, customCategoryID int, bigCategoryID int, userID string) (model.CategoriesID, error) {
+	replaceCategoriesID, err := parseReplaceCategoriesID(r)
+	if err != nil {
+		return replaceCategoriesID, err
+	}
+
+	var replaceBigCategoryID int
+	switch {
+	case replaceCategoriesID.MediumCategoryID.Valid:
+		replaceBigCategoryID, err = h.CategoriesRepo.GetMediumCategoryBigCategoryID(int(replaceCategoriesID.MediumCategoryID.Int64))
+	case replaceCategoriesID.CustomCategoryID.Valid:
+		if int(replaceCategoriesID.CustomCategoryID.Int64) == customCategoryID {
+			return replaceCategoriesID, &BadRequestErrorMsg{"置き換え先には削除するカスタムカテゴリー以外を指定してください。"}
+		}
+
+		replaceBigCategoryID, err = h.CategoriesRepo.GetCustomCategoryBigCategoryID(int(replaceCategoriesID.CustomCategoryID.Int64), userID)
+	default:
+		mediumCategoryID, err := h.CategoriesRepo.GetFallbackMediumCategoryID(bigCategoryID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return replaceCategoriesID, &NotFoundErrorMsg{"大カテゴリーに関連する中カテゴリーが見つかりませんでした。"}
+			}
+
+			return replaceCategoriesID, err
+		}
+
+		replaceCategoriesID.MediumCategoryID = model.NullInt64{NullInt64: sql.NullInt64{Int64: int64(mediumCategoryID), Valid: true}}
+
+		return replaceCategoriesID, nil
+	}
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return replaceCategoriesID, &BadRequestErrorMsg{"置き換え先のカテゴリーを正しく指定してください。"}
+		}
+
+		return replaceCategoriesID, err
+	}
+
+	if replaceBigCategoryID != bigCategoryID {
+		return replaceCategoriesID, &BadRequestErrorMsg{"置き換え先は削除するカスタムカテゴリーと同じ大カテゴリーのカテゴリーを指定してください。"}
+	}
+
+	return replaceCategoriesID, nil
+}
+
 func (h *DBHandler) DeleteCustomCategory(w http.ResponseWriter, r *http.Request) {
-	_, err := verifySessionID(h, w, r)
+	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	customCategoryID, err := strconv.Atoi(mux.Vars(r)["id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"カスタムカテゴリーID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"カスタムカテゴリーID を正しく指定してください。"}))
 		return
 	}
 
 	bigCategoryID, err := h.CategoriesRepo.GetBigCategoryID(customCategoryID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			errorResponseByJSON(w, NewHTTPError(http.StatusNotFound, &NotFoundErrorMsg{"カスタムカテゴリーに関連する大カテゴリーが見つかりませんでした。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusNotFound, &NotFoundErrorMsg{"カスタムカテゴリーに関連する大カテゴリーが見つかりませんでした。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	replaceCategoriesID, err := h.getReplaceCategoriesID(r, customCategoryID, bigCategoryID, userID)
+	if err != nil {
+		if badRequestErrorMsg, ok := err.(*BadRequestErrorMsg); ok {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+			return
+		}
+
+		if notFoundErrorMsg, ok := err.(*NotFoundErrorMsg); ok {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusNotFound, notFoundErrorMsg))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if err := h.CategoriesRepo.DeleteCustomCategory(customCategoryID, replaceCategoriesID); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if err := putShoppingListCustomCategoryIdToReplaceCategoryId(replaceCategoriesID, customCategoryID); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&DeleteContentMsg{localizeMessage(r.Context(), "カスタムカテゴリーを削除しました。")}); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) PutCustomCategoryArchive(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	var customCategoryArchive model.CustomCategoryArchive
+	if err := json.NewDecoder(r.Body).Decode(&customCategoryArchive); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	customCategoryArchive.ID, err = strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"custom category ID を正しく指定してください。"}))
+		return
+	}
+
+	if _, err := h.CategoriesRepo.GetCustomCategoryBigCategoryID(customCategoryArchive.ID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"指定されたカスタムカテゴリーは既に削除されています。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if err := h.CategoriesRepo.PutCustomCategoryArchive(&customCategoryArchive); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&customCategoryArchive); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) PutCustomCategoriesOrder(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	var customCategoriesOrder model.CustomCategoriesOrder
+	if err := json.NewDecoder(r.Body).Decode(&customCategoriesOrder); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	customCategoriesList, err := h.CategoriesRepo.GetCustomCategoriesList(userID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if err := validateCustomCategoriesOrder(&customCategoriesOrder, customCategoriesList); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
+		return
+	}
+
+	if err := h.CategoriesRepo.PutCustomCategoriesOrder(&customCategoriesOrder, userID); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&customCategoriesOrder); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) MergeCustomCategory(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	sourceCustomCategoryID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"custom category ID を正しく指定してください。"}))
+		return
+	}
+
+	var customCategoriesMerge model.CustomCategoriesMerge
+	if err := json.NewDecoder(r.Body).Decode(&customCategoriesMerge); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if customCategoriesMerge.TargetCustomCategoryID == sourceCustomCategoryID {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"統合先には統合元以外のカスタムカテゴリーを指定してください。"}))
+		return
+	}
+
+	sourceBigCategoryID, err := h.CategoriesRepo.GetCustomCategoryBigCategoryID(sourceCustomCategoryID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"指定されたカスタムカテゴリーは既に削除されています。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	mediumCategoryID, err := replaceMediumCategoryID(bigCategoryID)
+	targetBigCategoryID, err := h.CategoriesRepo.GetCustomCategoryBigCategoryID(customCategoriesMerge.TargetCustomCategoryID, userID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusNotFound, err))
+		if errors.Is(err, sql.ErrNoRows) {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"統合先のカスタムカテゴリーを正しく指定してください。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	if err := h.CategoriesRepo.DeleteCustomCategory(customCategoryID, mediumCategoryID); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+	if sourceBigCategoryID != targetBigCategoryID {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"統合先は統合元と同じ大カテゴリーのカスタムカテゴリーを指定してください。"}))
 		return
 	}
 
-	if err := putShoppingListCustomCategoryIdToMediumCategoryId(mediumCategoryID, customCategoryID); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+	mergedItemsID, err := mergeShoppingListCustomCategoryWithRetry(sourceCustomCategoryID, customCategoriesMerge.TargetCustomCategoryID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if err := h.CategoriesRepo.MergeCustomCategory(sourceCustomCategoryID, customCategoriesMerge.TargetCustomCategoryID); err != nil {
+		if err := revertShoppingListCustomCategoryMerge(sourceCustomCategoryID, mergedItemsID); err != nil {
+			log.Println(err)
+
+			shoppingListMergeRevertFailure := model.ShoppingListMergeRevertFailure{
+				UserID:                    userID,
+				SourceCustomCategoryID:    sourceCustomCategoryID,
+				TargetCustomCategoryID:    customCategoriesMerge.TargetCustomCategoryID,
+				ShoppingItemIDList:        mergedItemsID.ShoppingItemIDList,
+				RegularShoppingItemIDList: mergedItemsID.RegularShoppingItemIDList,
+				ErrorMessage:              err.Error(),
+			}
+
+			if err := h.CategoriesRepo.PostShoppingListMergeRevertFailure(&shoppingListMergeRevertFailure); err != nil {
+				log.Println(err)
+			}
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(&DeleteContentMsg{"カスタムカテゴリーを削除しました。"}); err != nil {
+	if err := json.NewEncoder(w).Encode(&MergeContentMsg{localizeMessage(r.Context(), "カスタムカテゴリーを統合しました。")}); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) CopyGroupCustomCategoriesToPersonal(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		return
+	}
+
+	if err := verifyGroupAffiliation(groupID, userID); err != nil {
+		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
+		if !ok {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		return
+	}
+
+	var customCategoriesCopy model.CustomCategoriesCopy
+	if err := json.NewDecoder(r.Body).Decode(&customCategoriesCopy); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	groupCustomCategoriesList, err := h.GroupCategoriesRepo.GetGroupCustomCategoriesList(groupID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	selectedGroupCustomCategoriesList, err := selectGroupCustomCategoriesToCopy(customCategoriesCopy.GroupCustomCategoryIDList, groupCustomCategoriesList)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
+		return
+	}
+
+	copiedCustomCategories := model.NewCopiedCustomCategories()
+	for _, groupCustomCategory := range selectedGroupCustomCategoriesList {
+		customCategory := model.NewCustomCategory()
+		customCategory.Name = groupCustomCategory.Name
+		customCategory.BigCategoryID = groupCustomCategory.BigCategoryID
+
+		if err := h.CategoriesRepo.FindCustomCategory(&customCategory, userID); err != sql.ErrNoRows {
+			if err == nil {
+				skippedGroupCustomCategory := model.NewGroupCustomCategory()
+				skippedGroupCustomCategory.ID = groupCustomCategory.ID
+				skippedGroupCustomCategory.Name = groupCustomCategory.Name
+				skippedGroupCustomCategory.BigCategoryID = groupCustomCategory.BigCategoryID
+
+				copiedCustomCategories.SkippedGroupCustomCategoriesList = append(copiedCustomCategories.SkippedGroupCustomCategoriesList, skippedGroupCustomCategory)
+				continue
+			}
+
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		copiedCustomCategories.CreatedCustomCategoriesList = append(copiedCustomCategories.CreatedCustomCategoriesList, customCategory)
+	}
+
+	// The custom categories are created in a single transaction, so a failure leaves none of them created.
+	if len(copiedCustomCategories.CreatedCustomCategoriesList) != 0 {
+		if err := h.CategoriesRepo.PostCustomCategoriesList(copiedCustomCategories.CreatedCustomCategoriesList, userID); err != nil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	if len(copiedCustomCategories.CreatedCustomCategoriesList) == 0 {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusCreated)
+	}
+	if err := json.NewEncoder(w).Encode(&copiedCustomCategories); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -346,7 +921,7 @@ func (h *DBHandler) DeleteCustomCategory(w http.ResponseWriter, r *http.Request)
 func (h *DBHandler) GetCategoriesName(w http.ResponseWriter, r *http.Request) {
 	var categoriesID model.CategoriesID
 	if err := json.NewDecoder(r.Body).Decode(&categoriesID); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -356,18 +931,23 @@ func (h *DBHandler) GetCategoriesName(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(&categoriesName); err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		return
+	if locale := requestLocale(r.Context()); locale != defaultLocale {
+		categoryTranslationsList, err := h.CategoriesRepo.GetCategoryTranslationsList(locale)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		model.NewCategoryTranslations(categoryTranslationsList).TranslateCategoriesName(categoriesName)
 	}
+
+	responseByJSONWithETag(w, r, &categoriesName)
 }
 
 func (h *DBHandler) GetCategoriesNameList(w http.ResponseWriter, r *http.Request) {
 	var categoriesIDList []model.CategoriesID
 	if err := json.NewDecoder(r.Body).Decode(&categoriesIDList); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -377,10 +957,18 @@ func (h *DBHandler) GetCategoriesNameList(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(&categoriesNameList); err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		return
+	if locale := requestLocale(r.Context()); locale != defaultLocale {
+		categoryTranslationsList, err := h.CategoriesRepo.GetCategoryTranslationsList(locale)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		categoryTranslations := model.NewCategoryTranslations(categoryTranslationsList)
+		for i := range categoriesNameList {
+			categoryTranslations.TranslateCategoriesName(&categoriesNameList[i])
+		}
 	}
+
+	responseByJSONWithETag(w, r, &categoriesNameList)
 }
@@ -2,13 +2,16 @@ package handler
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 
@@ -148,6 +151,7 @@ func (m MockCategoriesRepository) GetMediumCategoriesList() ([]model.AssociatedC
 func (m MockCategoriesRepository) GetCustomCategoriesList(userID string) ([]model.AssociatedCategory, error) {
 	return []model.AssociatedCategory{
 		{CategoryType: "CustomCategory", ID: 14, Name: "株配当金", BigCategoryID: 1},
+		{CategoryType: "CustomCategory", ID: 7, Name: "コーヒー豆", BigCategoryID: 2, IsArchived: true},
 		{CategoryType: "CustomCategory", ID: 3, Name: "米", BigCategoryID: 2},
 		{CategoryType: "CustomCategory", ID: 2, Name: "パン", BigCategoryID: 2},
 		{CategoryType: "CustomCategory", ID: 1, Name: "調味料", BigCategoryID: 2},
@@ -158,6 +162,10 @@ func (m MockCategoriesRepository) GetCustomCategoriesList(userID string) ([]mode
 }
 
 func (m MockCategoriesRepository) FindCustomCategory(customCategory *model.CustomCategory, userID string) error {
+	if customCategory.Name == "米" {
+		return nil
+	}
+
 	return sql.ErrNoRows
 }
 
@@ -165,21 +173,59 @@ func (m MockCategoriesRepository) PostCustomCategory(customCategory *model.Custo
 	return MockSqlResult{}, nil
 }
 
+func (m MockCategoriesRepository) PostCustomCategoriesList(customCategoriesList []model.CustomCategory, userID string) error {
+	for i := range customCategoriesList {
+		customCategoriesList[i].ID = i + 1
+	}
+
+	return nil
+}
+
 func (m MockCategoriesRepository) PutCustomCategory(customCategory *model.CustomCategory) error {
 	return nil
 }
 
+func (m MockCategoriesRepository) PutCustomCategoryArchive(customCategoryArchive *model.CustomCategoryArchive) error {
+	return nil
+}
+
+func (m MockCategoriesRepository) PutCustomCategoriesOrder(customCategoriesOrder *model.CustomCategoriesOrder, userID string) error {
+	return nil
+}
+
+func (m MockCategoriesRepository) MergeCustomCategory(sourceCustomCategoryID int, targetCustomCategoryID int) error {
+	return nil
+}
+
+func (m MockCategoriesRepository) PostShoppingListMergeRevertFailure(shoppingListMergeRevertFailure *model.ShoppingListMergeRevertFailure) error {
+	return nil
+}
+
 func (m MockCategoriesRepository) GetBigCategoryID(customCategoryID int) (int, error) {
 	return 2, nil
 }
 
-func (m MockCategoriesRepository) DeleteCustomCategory(previousCustomCategoryID int, replaceMediumCategoryID int) error {
+func (m MockCategoriesRepository) GetCustomCategoryBigCategoryID(customCategoryID int, userID string) (int, error) {
+	return 2, nil
+}
+
+func (m MockCategoriesRepository) GetMediumCategoryBigCategoryID(mediumCategoryID int) (int, error) {
+	return 2, nil
+}
+
+func (m MockCategoriesRepository) GetFallbackMediumCategoryID(bigCategoryID int) (int, error) {
+	return 12, nil
+}
+
+func (m MockCategoriesRepository) DeleteCustomCategory(previousCustomCategoryID int, replaceCategoriesID model.CategoriesID) error {
 	return nil
 }
 
 func (m MockCategoriesRepository) GetCategoriesName(categoriesID model.CategoriesID) (*model.CategoriesName, error) {
 	return &model.CategoriesName{
+		BigCategoryID:      2,
 		BigCategoryName:    model.NullString{NullString: sql.NullString{String: "食費", Valid: true}},
+		MediumCategoryID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 6, Valid: true}},
 		MediumCategoryName: model.NullString{NullString: sql.NullString{String: "食料品", Valid: true}},
 		CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
 	}, nil
@@ -188,18 +234,142 @@ func (m MockCategoriesRepository) GetCategoriesName(categoriesID model.Categorie
 func (m MockCategoriesRepository) GetCategoriesNameList(categoriesIDList []model.CategoriesID) ([]model.CategoriesName, error) {
 	return []model.CategoriesName{
 		{
+			BigCategoryID:      2,
 			BigCategoryName:    model.NullString{NullString: sql.NullString{String: "食費", Valid: true}},
 			MediumCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
 			CustomCategoryName: model.NullString{NullString: sql.NullString{String: "米", Valid: true}},
 		},
 		{
+			BigCategoryID:      3,
 			BigCategoryName:    model.NullString{NullString: sql.NullString{String: "日用品", Valid: true}},
+			MediumCategoryID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 13, Valid: true}},
 			MediumCategoryName: model.NullString{NullString: sql.NullString{String: "消耗品", Valid: true}},
 			CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
 		},
 	}, nil
 }
 
+func (m MockCategoriesRepository) GetCategoryTranslationsList(locale string) ([]model.CategoryTranslation, error) {
+	return []model.CategoryTranslation{
+		{CategoryType: "BigCategory", ID: 1, TranslatedName: "Income"},
+		{CategoryType: "BigCategory", ID: 2, TranslatedName: "Food"},
+		{CategoryType: "BigCategory", ID: 3, TranslatedName: "Daily Goods"},
+		{CategoryType: "BigCategory", ID: 4, TranslatedName: "Hobbies"},
+		{CategoryType: "BigCategory", ID: 5, TranslatedName: "Socializing"},
+		{CategoryType: "BigCategory", ID: 6, TranslatedName: "Transportation"},
+		{CategoryType: "BigCategory", ID: 7, TranslatedName: "Clothing & Beauty"},
+		{CategoryType: "BigCategory", ID: 8, TranslatedName: "Health & Medical"},
+		{CategoryType: "BigCategory", ID: 9, TranslatedName: "Communication"},
+		{CategoryType: "BigCategory", ID: 10, TranslatedName: "Education"},
+		{CategoryType: "BigCategory", ID: 11, TranslatedName: "Housing"},
+		{CategoryType: "BigCategory", ID: 12, TranslatedName: "Utilities"},
+		{CategoryType: "BigCategory", ID: 13, TranslatedName: "Car"},
+		{CategoryType: "BigCategory", ID: 14, TranslatedName: "Insurance"},
+		{CategoryType: "BigCategory", ID: 15, TranslatedName: "Taxes & Social Security"},
+		{CategoryType: "BigCategory", ID: 16, TranslatedName: "Cash & Card"},
+		{CategoryType: "BigCategory", ID: 17, TranslatedName: "Other"},
+		{CategoryType: "MediumCategory", ID: 1, TranslatedName: "Salary"},
+		{CategoryType: "MediumCategory", ID: 2, TranslatedName: "Bonus"},
+		{CategoryType: "MediumCategory", ID: 3, TranslatedName: "Temporary Income"},
+		{CategoryType: "MediumCategory", ID: 4, TranslatedName: "Business Income"},
+		{CategoryType: "MediumCategory", ID: 5, TranslatedName: "Other Income"},
+		{CategoryType: "MediumCategory", ID: 6, TranslatedName: "Groceries"},
+		{CategoryType: "MediumCategory", ID: 7, TranslatedName: "Breakfast"},
+		{CategoryType: "MediumCategory", ID: 8, TranslatedName: "Lunch"},
+		{CategoryType: "MediumCategory", ID: 9, TranslatedName: "Dinner"},
+		{CategoryType: "MediumCategory", ID: 10, TranslatedName: "Eating Out"},
+		{CategoryType: "MediumCategory", ID: 11, TranslatedName: "Cafe"},
+		{CategoryType: "MediumCategory", ID: 12, TranslatedName: "Other Food"},
+		{CategoryType: "MediumCategory", ID: 13, TranslatedName: "Consumables"},
+		{CategoryType: "MediumCategory", ID: 14, TranslatedName: "Childcare Goods"},
+		{CategoryType: "MediumCategory", ID: 15, TranslatedName: "Pet Supplies"},
+		{CategoryType: "MediumCategory", ID: 16, TranslatedName: "Furniture"},
+		{CategoryType: "MediumCategory", ID: 17, TranslatedName: "Home Appliances"},
+		{CategoryType: "MediumCategory", ID: 18, TranslatedName: "Other Daily Goods"},
+		{CategoryType: "MediumCategory", ID: 19, TranslatedName: "Outdoor"},
+		{CategoryType: "MediumCategory", ID: 20, TranslatedName: "Travel"},
+		{CategoryType: "MediumCategory", ID: 21, TranslatedName: "Events"},
+		{CategoryType: "MediumCategory", ID: 22, TranslatedName: "Sports"},
+		{CategoryType: "MediumCategory", ID: 23, TranslatedName: "Movies & Videos"},
+		{CategoryType: "MediumCategory", ID: 24, TranslatedName: "Music"},
+		{CategoryType: "MediumCategory", ID: 25, TranslatedName: "Comics"},
+		{CategoryType: "MediumCategory", ID: 26, TranslatedName: "Books"},
+		{CategoryType: "MediumCategory", ID: 27, TranslatedName: "Games"},
+		{CategoryType: "MediumCategory", ID: 28, TranslatedName: "Other Hobbies"},
+		{CategoryType: "MediumCategory", ID: 29, TranslatedName: "Drinking Parties"},
+		{CategoryType: "MediumCategory", ID: 30, TranslatedName: "Gifts"},
+		{CategoryType: "MediumCategory", ID: 31, TranslatedName: "Ceremonies"},
+		{CategoryType: "MediumCategory", ID: 32, TranslatedName: "Other Socializing"},
+		{CategoryType: "MediumCategory", ID: 33, TranslatedName: "Train"},
+		{CategoryType: "MediumCategory", ID: 34, TranslatedName: "Bus"},
+		{CategoryType: "MediumCategory", ID: 35, TranslatedName: "Taxi"},
+		{CategoryType: "MediumCategory", ID: 36, TranslatedName: "Shinkansen"},
+		{CategoryType: "MediumCategory", ID: 37, TranslatedName: "Airplane"},
+		{CategoryType: "MediumCategory", ID: 38, TranslatedName: "Other Transportation"},
+		{CategoryType: "MediumCategory", ID: 39, TranslatedName: "Clothes"},
+		{CategoryType: "MediumCategory", ID: 40, TranslatedName: "Accessories"},
+		{CategoryType: "MediumCategory", ID: 41, TranslatedName: "Dry Cleaning"},
+		{CategoryType: "MediumCategory", ID: 42, TranslatedName: "Hair Salon"},
+		{CategoryType: "MediumCategory", ID: 43, TranslatedName: "Cosmetics"},
+		{CategoryType: "MediumCategory", ID: 44, TranslatedName: "Spa & Nails"},
+		{CategoryType: "MediumCategory", ID: 45, TranslatedName: "Other Clothing & Beauty"},
+		{CategoryType: "MediumCategory", ID: 46, TranslatedName: "Hospital"},
+		{CategoryType: "MediumCategory", ID: 47, TranslatedName: "Medicine"},
+		{CategoryType: "MediumCategory", ID: 48, TranslatedName: "Body Care"},
+		{CategoryType: "MediumCategory", ID: 49, TranslatedName: "Fitness"},
+		{CategoryType: "MediumCategory", ID: 50, TranslatedName: "Other Health & Medical"},
+		{CategoryType: "MediumCategory", ID: 51, TranslatedName: "Mobile Phone"},
+		{CategoryType: "MediumCategory", ID: 52, TranslatedName: "Landline"},
+		{CategoryType: "MediumCategory", ID: 53, TranslatedName: "Internet"},
+		{CategoryType: "MediumCategory", ID: 54, TranslatedName: "Broadcasting"},
+		{CategoryType: "MediumCategory", ID: 55, TranslatedName: "Information Services"},
+		{CategoryType: "MediumCategory", ID: 56, TranslatedName: "Delivery & Shipping"},
+		{CategoryType: "MediumCategory", ID: 57, TranslatedName: "Stamps & Postcards"},
+		{CategoryType: "MediumCategory", ID: 58, TranslatedName: "Other Communication"},
+		{CategoryType: "MediumCategory", ID: 59, TranslatedName: "Newspaper"},
+		{CategoryType: "MediumCategory", ID: 60, TranslatedName: "Reference Books"},
+		{CategoryType: "MediumCategory", ID: 61, TranslatedName: "Exam Fees"},
+		{CategoryType: "MediumCategory", ID: 62, TranslatedName: "Tuition"},
+		{CategoryType: "MediumCategory", ID: 63, TranslatedName: "Lessons"},
+		{CategoryType: "MediumCategory", ID: 64, TranslatedName: "Cram School"},
+		{CategoryType: "MediumCategory", ID: 65, TranslatedName: "Other Education"},
+		{CategoryType: "MediumCategory", ID: 66, TranslatedName: "Rent"},
+		{CategoryType: "MediumCategory", ID: 67, TranslatedName: "Mortgage"},
+		{CategoryType: "MediumCategory", ID: 68, TranslatedName: "Renovation"},
+		{CategoryType: "MediumCategory", ID: 69, TranslatedName: "Other Housing"},
+		{CategoryType: "MediumCategory", ID: 70, TranslatedName: "Water"},
+		{CategoryType: "MediumCategory", ID: 71, TranslatedName: "Electricity"},
+		{CategoryType: "MediumCategory", ID: 72, TranslatedName: "Gas"},
+		{CategoryType: "MediumCategory", ID: 73, TranslatedName: "Other Utilities"},
+		{CategoryType: "MediumCategory", ID: 74, TranslatedName: "Car Loan"},
+		{CategoryType: "MediumCategory", ID: 75, TranslatedName: "Gasoline"},
+		{CategoryType: "MediumCategory", ID: 76, TranslatedName: "Parking"},
+		{CategoryType: "MediumCategory", ID: 77, TranslatedName: "Highway Tolls"},
+		{CategoryType: "MediumCategory", ID: 78, TranslatedName: "Car Inspection"},
+		{CategoryType: "MediumCategory", ID: 79, TranslatedName: "Other Car"},
+		{CategoryType: "MediumCategory", ID: 80, TranslatedName: "Life Insurance"},
+		{CategoryType: "MediumCategory", ID: 81, TranslatedName: "Medical Insurance"},
+		{CategoryType: "MediumCategory", ID: 82, TranslatedName: "Car Insurance"},
+		{CategoryType: "MediumCategory", ID: 83, TranslatedName: "Home Insurance"},
+		{CategoryType: "MediumCategory", ID: 84, TranslatedName: "Education Insurance"},
+		{CategoryType: "MediumCategory", ID: 85, TranslatedName: "Other Insurance"},
+		{CategoryType: "MediumCategory", ID: 86, TranslatedName: "Income Tax"},
+		{CategoryType: "MediumCategory", ID: 87, TranslatedName: "Resident Tax"},
+		{CategoryType: "MediumCategory", ID: 88, TranslatedName: "Pension Premiums"},
+		{CategoryType: "MediumCategory", ID: 89, TranslatedName: "Car Tax"},
+		{CategoryType: "MediumCategory", ID: 90, TranslatedName: "Other Taxes"},
+		{CategoryType: "MediumCategory", ID: 91, TranslatedName: "Cash Withdrawal"},
+		{CategoryType: "MediumCategory", ID: 92, TranslatedName: "Card Payment"},
+		{CategoryType: "MediumCategory", ID: 93, TranslatedName: "E-Money"},
+		{CategoryType: "MediumCategory", ID: 94, TranslatedName: "Advance Payment"},
+		{CategoryType: "MediumCategory", ID: 95, TranslatedName: "Other Cash & Card"},
+		{CategoryType: "MediumCategory", ID: 96, TranslatedName: "Allowance to Family"},
+		{CategoryType: "MediumCategory", ID: 97, TranslatedName: "Pocket Money"},
+		{CategoryType: "MediumCategory", ID: 98, TranslatedName: "Unknown Expenses"},
+		{CategoryType: "MediumCategory", ID: 99, TranslatedName: "Miscellaneous"},
+	}, nil
+}
+
 func TestDBHandler_GetCategoriesList(t *testing.T) {
 	h := DBHandler{
 		AuthRepo:       MockAuthRepository{},
@@ -225,6 +395,101 @@ func TestDBHandler_GetCategoriesList(t *testing.T) {
 	testutil.AssertResponseBody(t, res, &model.CategoriesList{}, &model.CategoriesList{})
 }
 
+func TestDBHandler_GetCategoriesListNotModified(t *testing.T) {
+	h := DBHandler{
+		AuthRepo:       MockAuthRepository{},
+		CategoriesRepo: MockCategoriesRepository{},
+	}
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r := httptest.NewRequest("GET", "/categories", nil)
+	w := httptest.NewRecorder()
+
+	r.AddCookie(cookie)
+
+	h.GetCategoriesList(w, r)
+
+	etag := w.Result().Header.Get("ETag")
+	if len(etag) == 0 {
+		t.Fatalf("ETag header is not set")
+	}
+
+	r = httptest.NewRequest("GET", "/categories", nil)
+	w = httptest.NewRecorder()
+
+	r.AddCookie(cookie)
+	r.Header.Set("If-None-Match", etag)
+
+	h.GetCategoriesList(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	if diff := cmp.Diff(http.StatusNotModified, res.StatusCode); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+
+	if diff := cmp.Diff(0, w.Body.Len()); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestDBHandler_GetCategoriesListIncludeArchived(t *testing.T) {
+	h := DBHandler{
+		AuthRepo:       MockAuthRepository{},
+		CategoriesRepo: MockCategoriesRepository{},
+	}
+
+	r := httptest.NewRequest("GET", "/categories?include_archived=true", nil)
+	w := httptest.NewRecorder()
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.GetCategoriesList(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &model.CategoriesList{}, &model.CategoriesList{})
+}
+
+func TestDBHandler_GetCategoriesListInEnglish(t *testing.T) {
+	h := DBHandler{
+		AuthRepo:       MockAuthRepository{},
+		CategoriesRepo: MockCategoriesRepository{},
+	}
+
+	r := httptest.NewRequest("GET", "/categories", nil)
+	w := httptest.NewRecorder()
+
+	r.Header.Set("Accept-Language", "en-US,en;q=0.9,ja;q=0.8")
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	LocaleMiddleware(http.HandlerFunc(h.GetCategoriesList)).ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &model.CategoriesList{}, &model.CategoriesList{})
+}
+
 func TestDBHandler_PostCustomCategory(t *testing.T) {
 	h := DBHandler{
 		AuthRepo:       MockAuthRepository{},
@@ -333,6 +598,467 @@ func TestDBHandler_DeleteCustomCategory(t *testing.T) {
 	testutil.AssertResponseBody(t, res, &DeleteContentMsg{}, &DeleteContentMsg{})
 }
 
+func TestDBHandler_DeleteCustomCategoryWithReplaceCategory(t *testing.T) {
+	todoHostURL := fmt.Sprintf(
+		"%s:%d",
+		config.Env.TodoApi.Host, config.Env.TodoApi.Port,
+	)
+
+	mockPutShoppingListCustomCategoryIdToMediumCategoryId := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/shopping-list/categories", mockPutShoppingListCustomCategoryIdToMediumCategoryId).Methods("PUT")
+
+	listener, err := net.Listen("tcp", todoHostURL)
+	if err != nil {
+		t.Fatalf("unexpected error by net.Listen() '%#v'", err)
+	}
+
+	ts := httptest.Server{
+		Listener: listener,
+		Config:   &http.Server{Handler: router},
+	}
+
+	ts.Start()
+	defer ts.Close()
+
+	h := DBHandler{
+		AuthRepo:       MockAuthRepository{},
+		CategoriesRepo: MockCategoriesRepository{},
+	}
+
+	r := httptest.NewRequest("DELETE", "/categories/custom-categories/1?custom_category_id=2", nil)
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"id": "1",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.DeleteCustomCategory(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &DeleteContentMsg{}, &DeleteContentMsg{})
+}
+
+func TestDBHandler_PutCustomCategoryArchive(t *testing.T) {
+	h := DBHandler{
+		AuthRepo:       MockAuthRepository{},
+		CategoriesRepo: MockCategoriesRepository{},
+	}
+
+	r := httptest.NewRequest("PUT", "/categories/custom-categories/7/archive", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"id": "7",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.PutCustomCategoryArchive(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &model.CustomCategoryArchive{}, &model.CustomCategoryArchive{})
+}
+
+func TestDBHandler_PutCustomCategoriesOrder(t *testing.T) {
+	h := DBHandler{
+		AuthRepo:       MockAuthRepository{},
+		CategoriesRepo: MockCategoriesRepository{},
+	}
+
+	r := httptest.NewRequest("PUT", "/categories/custom-categories/order", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
+	w := httptest.NewRecorder()
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.PutCustomCategoriesOrder(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &model.CustomCategoriesOrder{}, &model.CustomCategoriesOrder{})
+}
+
+func TestDBHandler_MergeCustomCategory(t *testing.T) {
+	todoHostURL := fmt.Sprintf(
+		"%s:%d",
+		config.Env.TodoApi.Host, config.Env.TodoApi.Port,
+	)
+
+	mockMergeShoppingListCustomCategory := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if diff := cmp.Diff("Bearer "+config.Env.ServiceAuth.Token, r.Header.Get("Authorization")); len(diff) != 0 {
+			t.Errorf("differs: (-want +got)\n%s", diff)
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(`{"shopping_item_id_list":[1,3],"regular_shopping_item_id_list":[2]}`)); err != nil {
+			t.Errorf("unexpected error by w.Write() '%#v'", err)
+		}
+	})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/shopping-list/categories/merge", mockMergeShoppingListCustomCategory).Methods("PUT")
+
+	listener, err := net.Listen("tcp", todoHostURL)
+	if err != nil {
+		t.Fatalf("unexpected error by net.Listen() '%#v'", err)
+	}
+
+	ts := httptest.Server{
+		Listener: listener,
+		Config:   &http.Server{Handler: router},
+	}
+
+	ts.Start()
+	defer ts.Close()
+
+	h := DBHandler{
+		AuthRepo:       MockAuthRepository{},
+		CategoriesRepo: MockCategoriesRepository{},
+	}
+
+	r := httptest.NewRequest("POST", "/categories/custom-categories/1/merge", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"id": "1",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.MergeCustomCategory(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &MergeContentMsg{}, &MergeContentMsg{})
+}
+
+func TestDBHandler_MergeCustomCategoryWithLostResponse(t *testing.T) {
+	todoHostURL := fmt.Sprintf(
+		"%s:%d",
+		config.Env.TodoApi.Host, config.Env.TodoApi.Port,
+	)
+
+	retryInterval := mergeShoppingListCustomCategoryRetryInterval
+	mergeShoppingListCustomCategoryRetryInterval = 0
+	defer func() { mergeShoppingListCustomCategoryRetryInterval = retryInterval }()
+
+	var mergeRequestCount int32
+	mockMergeShoppingListCustomCategory := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The first merge is applied by the todo service, but its response never reaches the account service.
+		if atomic.AddInt32(&mergeRequestCount, 1) == 1 {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Errorf("unexpected error by Hijack() '%#v'", err)
+				return
+			}
+
+			conn.Close()
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(`{"shopping_item_id_list":[1,3],"regular_shopping_item_id_list":[2]}`)); err != nil {
+			t.Errorf("unexpected error by w.Write() '%#v'", err)
+		}
+	})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/shopping-list/categories/merge", mockMergeShoppingListCustomCategory).Methods("PUT")
+
+	listener, err := net.Listen("tcp", todoHostURL)
+	if err != nil {
+		t.Fatalf("unexpected error by net.Listen() '%#v'", err)
+	}
+
+	ts := httptest.Server{
+		Listener: listener,
+		Config:   &http.Server{Handler: router},
+	}
+
+	ts.Start()
+	defer ts.Close()
+
+	h := DBHandler{
+		AuthRepo:       MockAuthRepository{},
+		CategoriesRepo: MockCategoriesRepository{},
+	}
+
+	r := httptest.NewRequest("POST", "/categories/custom-categories/1/merge", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"id": "1",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.MergeCustomCategory(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &MergeContentMsg{}, &MergeContentMsg{})
+
+	if diff := cmp.Diff(int32(2), atomic.LoadInt32(&mergeRequestCount)); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+type MockMergeFailureCategoriesRepository struct {
+	MockCategoriesRepository
+	shoppingListMergeRevertFailureList []model.ShoppingListMergeRevertFailure
+}
+
+func (m *MockMergeFailureCategoriesRepository) MergeCustomCategory(sourceCustomCategoryID int, targetCustomCategoryID int) error {
+	return errors.New("merge custom category error")
+}
+
+func (m *MockMergeFailureCategoriesRepository) PostShoppingListMergeRevertFailure(shoppingListMergeRevertFailure *model.ShoppingListMergeRevertFailure) error {
+	m.shoppingListMergeRevertFailureList = append(m.shoppingListMergeRevertFailureList, *shoppingListMergeRevertFailure)
+
+	return nil
+}
+
+func TestDBHandler_MergeCustomCategory_RevertFailure(t *testing.T) {
+	todoHostURL := fmt.Sprintf(
+		"%s:%d",
+		config.Env.TodoApi.Host, config.Env.TodoApi.Port,
+	)
+
+	mockMergeShoppingListCustomCategory := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if diff := cmp.Diff("Bearer "+config.Env.ServiceAuth.Token, r.Header.Get("Authorization")); len(diff) != 0 {
+			t.Errorf("differs: (-want +got)\n%s", diff)
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(`{"shopping_item_id_list":[1,3],"regular_shopping_item_id_list":[2]}`)); err != nil {
+			t.Errorf("unexpected error by w.Write() '%#v'", err)
+		}
+	})
+
+	mockRevertShoppingListCustomCategoryMerge := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if diff := cmp.Diff("Bearer "+config.Env.ServiceAuth.Token, r.Header.Get("Authorization")); len(diff) != 0 {
+			t.Errorf("differs: (-want +got)\n%s", diff)
+		}
+
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/shopping-list/categories/merge", mockMergeShoppingListCustomCategory).Methods("PUT")
+	router.HandleFunc("/shopping-list/categories/merge/revert", mockRevertShoppingListCustomCategoryMerge).Methods("PUT")
+
+	listener, err := net.Listen("tcp", todoHostURL)
+	if err != nil {
+		t.Fatalf("unexpected error by net.Listen() '%#v'", err)
+	}
+
+	ts := httptest.Server{
+		Listener: listener,
+		Config:   &http.Server{Handler: router},
+	}
+
+	ts.Start()
+	defer ts.Close()
+
+	categoriesRepo := &MockMergeFailureCategoriesRepository{}
+
+	h := DBHandler{
+		AuthRepo:       MockAuthRepository{},
+		CategoriesRepo: categoriesRepo,
+	}
+
+	r := httptest.NewRequest("POST", "/categories/custom-categories/1/merge", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"id": "1",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.MergeCustomCategory(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusInternalServerError)
+
+	want := []model.ShoppingListMergeRevertFailure{
+		{
+			UserID:                    "userID1",
+			SourceCustomCategoryID:    1,
+			TargetCustomCategoryID:    2,
+			ShoppingItemIDList:        []int{1, 3},
+			RegularShoppingItemIDList: []int{2},
+			ErrorMessage:              "couldn't revert merged customCategoryID in shopping list",
+		},
+	}
+
+	if diff := cmp.Diff(want, categoriesRepo.shoppingListMergeRevertFailureList); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestDBHandler_CopyGroupCustomCategoriesToPersonal(t *testing.T) {
+	h := DBHandler{
+		AuthRepo:            MockAuthRepository{},
+		CategoriesRepo:      MockCategoriesRepository{},
+		GroupCategoriesRepo: MockGroupCategoriesRepository{},
+	}
+
+	r := httptest.NewRequest("POST", "/categories/custom-categories/copy-from-group/1", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"group_id": "1",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.CopyGroupCustomCategoriesToPersonal(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusCreated)
+	testutil.AssertResponseBody(t, res, &model.CopiedCustomCategories{}, &model.CopiedCustomCategories{})
+}
+
+type MockCopyFailureCategoriesRepository struct {
+	MockCategoriesRepository
+	findErrorCategoryName       string
+	postErr                     error
+	postedCustomCategoriesLists [][]model.CustomCategory
+}
+
+func (m *MockCopyFailureCategoriesRepository) FindCustomCategory(customCategory *model.CustomCategory, userID string) error {
+	if customCategory.Name == m.findErrorCategoryName {
+		return errors.New("find custom category error")
+	}
+
+	return m.MockCategoriesRepository.FindCustomCategory(customCategory, userID)
+}
+
+func (m *MockCopyFailureCategoriesRepository) PostCustomCategoriesList(customCategoriesList []model.CustomCategory, userID string) error {
+	m.postedCustomCategoriesLists = append(m.postedCustomCategoriesLists, append([]model.CustomCategory(nil), customCategoriesList...))
+
+	return m.postErr
+}
+
+func TestDBHandler_CopyGroupCustomCategoriesToPersonal_Failure(t *testing.T) {
+	tests := []struct {
+		name                            string
+		categoriesRepo                  *MockCopyFailureCategoriesRepository
+		wantPostedCustomCategoriesLists [][]model.CustomCategory
+	}{
+		{
+			name:                            "failure in the middle of checking the categories creates nothing",
+			categoriesRepo:                  &MockCopyFailureCategoriesRepository{findErrorCategoryName: "洗剤"},
+			wantPostedCustomCategoriesLists: nil,
+		},
+		{
+			name:           "failure in creating the categories",
+			categoriesRepo: &MockCopyFailureCategoriesRepository{postErr: errors.New("post custom categories list error")},
+			wantPostedCustomCategoriesLists: [][]model.CustomCategory{
+				{
+					{CategoryType: "CustomCategory", Name: "パン", BigCategoryID: 2},
+					{CategoryType: "CustomCategory", Name: "洗剤", BigCategoryID: 3},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := DBHandler{
+				AuthRepo:            MockAuthRepository{},
+				CategoriesRepo:      tt.categoriesRepo,
+				GroupCategoriesRepo: MockGroupCategoriesRepository{},
+			}
+
+			r := httptest.NewRequest("POST", "/categories/custom-categories/copy-from-group/1", strings.NewReader(`{"group_custom_category_id_list": [2, 3, 4]}`))
+			w := httptest.NewRecorder()
+
+			r = mux.SetURLVars(r, map[string]string{
+				"group_id": "1",
+			})
+
+			cookie := &http.Cookie{
+				Name:  config.Env.Cookie.Name,
+				Value: uuid.New().String(),
+			}
+
+			r.AddCookie(cookie)
+
+			h.CopyGroupCustomCategoriesToPersonal(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+
+			testutil.AssertResponseHeader(t, res, http.StatusInternalServerError)
+
+			if diff := cmp.Diff(tt.wantPostedCustomCategoriesLists, tt.categoriesRepo.postedCustomCategoriesLists); len(diff) != 0 {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestDBHandler_GetCategoriesName(t *testing.T) {
 	h := DBHandler{
 		AuthRepo:       MockAuthRepository{},
@@ -382,3 +1108,30 @@ func TestDBHandler_GetCategoriesNameList(t *testing.T) {
 	testutil.AssertResponseHeader(t, res, http.StatusOK)
 	testutil.AssertResponseBody(t, res, &[]model.CategoriesName{}, &[]model.CategoriesName{})
 }
+
+func TestDBHandler_GetCategoriesNameListInEnglish(t *testing.T) {
+	h := DBHandler{
+		AuthRepo:       MockAuthRepository{},
+		CategoriesRepo: MockCategoriesRepository{},
+	}
+
+	r := httptest.NewRequest("GET", "/categories/names", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
+	w := httptest.NewRecorder()
+
+	r.Header.Set("Accept-Language", "en")
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	LocaleMiddleware(http.HandlerFunc(h.GetCategoriesNameList)).ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &[]model.CategoriesName{}, &[]model.CategoriesName{})
+}
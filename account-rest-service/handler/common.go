@@ -1,16 +1,24 @@
 package handler
 
 import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
 	"net"
 	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hryze/kakeibo-app-api/account-rest-service/config"
+	"github.com/hryze/kakeibo-app-api/account-rest-service/domain/model"
 	"github.com/hryze/kakeibo-app-api/account-rest-service/domain/repository"
 )
 
@@ -24,12 +32,18 @@ type DBHandler struct {
 	GroupCategoriesRepo   repository.GroupCategoriesRepository
 	GroupBudgetsRepo      repository.GroupBudgetsRepository
 	TimeManage            TimeManager
+	BudgetNotifier        BudgetNotifier
 }
 
 type TimeManager interface {
 	Now() time.Time
 }
 
+type BudgetNotifier interface {
+	NotifyBudgetThreshold(budgetThresholdEvent model.BudgetThresholdEvent) error
+	NotifyGroupBudgetThreshold(groupBudgetThresholdEvent model.GroupBudgetThresholdEvent) error
+}
+
 type RealTime struct{}
 
 type NoContentMsg struct {
@@ -40,6 +54,14 @@ type DeleteContentMsg struct {
 	Message string `json:"message"`
 }
 
+type CopyContentMsg struct {
+	Message string `json:"message"`
+}
+
+type MergeContentMsg struct {
+	Message string `json:"message"`
+}
+
 type HTTPError struct {
 	Status       int   `json:"status"`
 	ErrorMessage error `json:"error"`
@@ -117,13 +139,17 @@ func (e *InternalServerErrorMsg) Error() string {
 	return e.Message
 }
 
-func errorResponseByJSON(w http.ResponseWriter, err error) {
+func errorResponseByJSON(w http.ResponseWriter, r *http.Request, err error) {
 	httpError, ok := err.(*HTTPError)
 	if !ok {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
+	if locale := requestLocale(r.Context()); locale != defaultLocale {
+		localizeMessageFields(reflect.ValueOf(httpError), locale)
+	}
+
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(httpError.Status)
 	if err := json.NewEncoder(w).Encode(httpError); err != nil {
@@ -131,117 +157,96 @@ func errorResponseByJSON(w http.ResponseWriter, err error) {
 	}
 }
 
-func replaceMediumCategoryID(bigCategoryID int) (int, error) {
-	const (
-		_ = iota
-		income
-		foodExpenses
-		dailyNecessities
-		hobby
-		entertainment
-		transportation
-		clothes
-		health
-		data
-		education
-		housing
-		waterSupply
-		car
-		insurance
-		tax
-		cash
-		other
-	)
-
-	const (
-		otherIncome           = 5
-		otherFoodExpenses     = 12
-		otherDailyNecessities = 18
-		otherHobby            = 28
-		otherEntertainment    = 32
-		otherTransportation   = 38
-		otherClothes          = 45
-		otherHealth           = 50
-		otherData             = 58
-		otherEducation        = 65
-		otherHousing          = 69
-		otherWaterSupply      = 73
-		otherCar              = 79
-		otherInsurance        = 85
-		otherTax              = 90
-		otherCash             = 95
-		unclearMoney          = 98
-	)
-
-	if bigCategoryID == income {
-		return otherIncome, nil
+// responseByJSONWithETag responds with the body and its ETag, or with 304 Not Modified and no body
+// if the If-None-Match header of the request already holds the ETag.
+func responseByJSONWithETag(w http.ResponseWriter, r *http.Request, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
 	}
 
-	if bigCategoryID == foodExpenses {
-		return otherFoodExpenses, nil
-	}
+	// json.Encoder appends a newline, so the body is kept identical to the other responses.
+	body = append(body, '\n')
 
-	if bigCategoryID == dailyNecessities {
-		return otherDailyNecessities, nil
-	}
+	etag := fmt.Sprintf(`"%x"`, sha256.Sum256(body))
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "private, no-cache")
 
-	if bigCategoryID == hobby {
-		return otherHobby, nil
+	if matchETag(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
 	}
 
-	if bigCategoryID == entertainment {
-		return otherEntertainment, nil
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(body); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
 	}
+}
 
-	if bigCategoryID == transportation {
-		return otherTransportation, nil
+// matchETag reports whether the If-None-Match header holds the ETag, comparing weakly as RFC 7232 requires.
+func matchETag(ifNoneMatch string, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
 	}
 
-	if bigCategoryID == clothes {
-		return otherClothes, nil
-	}
+	return false
+}
 
-	if bigCategoryID == health {
-		return otherHealth, nil
-	}
+// parseReplaceCategoriesID reads the category which replaces a deleted custom category from the query,
+// in which either medium_category_id or custom_category_id can be specified.
+func parseReplaceCategoriesID(r *http.Request) (model.CategoriesID, error) {
+	var replaceCategoriesID model.CategoriesID
 
-	if bigCategoryID == data {
-		return otherData, nil
+	mediumCategoryID := r.URL.Query().Get("medium_category_id")
+	customCategoryID := r.URL.Query().Get("custom_category_id")
+	if len(mediumCategoryID) != 0 && len(customCategoryID) != 0 {
+		return replaceCategoriesID, &BadRequestErrorMsg{"置き換え先は中カテゴリーかカスタムカテゴリーのどちらか一方を指定してください。"}
 	}
 
-	if bigCategoryID == education {
-		return otherEducation, nil
-	}
+	if len(mediumCategoryID) != 0 {
+		id, err := strconv.Atoi(mediumCategoryID)
+		if err != nil || id < 1 {
+			return replaceCategoriesID, &BadRequestErrorMsg{"置き換え先のカテゴリーを正しく指定してください。"}
+		}
 
-	if bigCategoryID == housing {
-		return otherHousing, nil
+		replaceCategoriesID.MediumCategoryID = model.NullInt64{NullInt64: sql.NullInt64{Int64: int64(id), Valid: true}}
 	}
 
-	if bigCategoryID == waterSupply {
-		return otherWaterSupply, nil
-	}
+	if len(customCategoryID) != 0 {
+		id, err := strconv.Atoi(customCategoryID)
+		if err != nil || id < 1 {
+			return replaceCategoriesID, &BadRequestErrorMsg{"置き換え先のカテゴリーを正しく指定してください。"}
+		}
 
-	if bigCategoryID == car {
-		return otherCar, nil
+		replaceCategoriesID.CustomCategoryID = model.NullInt64{NullInt64: sql.NullInt64{Int64: int64(id), Valid: true}}
 	}
 
-	if bigCategoryID == insurance {
-		return otherInsurance, nil
-	}
+	return replaceCategoriesID, nil
+}
 
-	if bigCategoryID == tax {
-		return otherTax, nil
-	}
+// parseIdempotencyKey reads the Idempotency-Key header, with which a retried request to post a transaction
+// is responded with the transaction posted by the first request instead of posting another one.
+func parseIdempotencyKey(r *http.Request) (model.NullString, error) {
+	var idempotencyKey model.NullString
 
-	if bigCategoryID == cash {
-		return otherCash, nil
+	key := r.Header.Get("Idempotency-Key")
+	if len(key) == 0 {
+		return idempotencyKey, nil
 	}
 
-	if bigCategoryID == other {
-		return unclearMoney, nil
+	if len(key) > 64 {
+		return idempotencyKey, &BadRequestErrorMsg{"Idempotency-Key は64文字以内で指定してください。"}
 	}
 
-	return 0, &NotFoundErrorMsg{"大カテゴリーに関連する中カテゴリーが見つかりませんでした。"}
+	idempotencyKey = model.NullString{NullString: sql.NullString{String: key, Valid: true}}
+
+	return idempotencyKey, nil
 }
 
 func verifySessionID(h *DBHandler, w http.ResponseWriter, r *http.Request) (string, error) {
@@ -259,6 +264,30 @@ func verifySessionID(h *DBHandler, w http.ResponseWriter, r *http.Request) (stri
 	return userID, nil
 }
 
+// errInvalidServiceToken is returned when a request sent by another service fails to authenticate.
+var errInvalidServiceToken = errors.New("invalid service token")
+
+// verifyRequestUserID returns the user ID of the session, or the user ID in the X-User-ID header
+// when the request is sent by todo-rest-service with the service token on behalf of the user.
+func verifyRequestUserID(h *DBHandler, w http.ResponseWriter, r *http.Request) (string, error) {
+	authorization := r.Header.Get("Authorization")
+	if len(authorization) == 0 {
+		return verifySessionID(h, w, r)
+	}
+
+	token := strings.TrimPrefix(authorization, "Bearer ")
+	if len(token) == len(authorization) || len(config.Env.ServiceAuth.Token) == 0 || subtle.ConstantTimeCompare([]byte(token), []byte(config.Env.ServiceAuth.Token)) != 1 {
+		return "", errInvalidServiceToken
+	}
+
+	userID := r.Header.Get("X-User-ID")
+	if len(userID) == 0 {
+		return "", errInvalidServiceToken
+	}
+
+	return userID, nil
+}
+
 func verifyGroupAffiliation(groupID int, userID string) error {
 	requestURL := fmt.Sprintf(
 		"http://%s:%d/groups/%d/users/%s/verify",
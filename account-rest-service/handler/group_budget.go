@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"database/sql"
 	"encoding/json"
+	"log"
 	"net/http"
 	"strconv"
 	"time"
@@ -12,6 +14,414 @@ import (
 	"github.com/hryze/kakeibo-app-api/account-rest-service/domain/model"
 )
 
+func generateGroupCategoryBigCategoryIDs(h *DBHandler, groupID int) (map[int]int, map[int]int, error) {
+	groupMediumCategoriesList, err := h.GroupCategoriesRepo.GetGroupMediumCategoriesList()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	groupCustomCategoriesList, err := h.GroupCategoriesRepo.GetGroupCustomCategoriesList(groupID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mediumCategoryBigCategoryIDs := make(map[int]int, len(groupMediumCategoriesList))
+	for _, groupMediumCategory := range groupMediumCategoriesList {
+		mediumCategoryBigCategoryIDs[groupMediumCategory.ID] = groupMediumCategory.BigCategoryID
+	}
+
+	customCategoryBigCategoryIDs := make(map[int]int, len(groupCustomCategoriesList))
+	for _, groupCustomCategory := range groupCustomCategoriesList {
+		customCategoryBigCategoryIDs[groupCustomCategory.ID] = groupCustomCategory.BigCategoryID
+	}
+
+	return mediumCategoryBigCategoryIDs, customCategoryBigCategoryIDs, nil
+}
+
+func setGroupChildBudgetsLastMonthExpenses(childBudgets []model.ChildBudgetByCategory, groupTransactionTotalAmountByMediumCategoryList []model.GroupTransactionTotalAmountByMediumCategory) {
+	for _, groupTransactionTotalAmountByMediumCategory := range groupTransactionTotalAmountByMediumCategoryList {
+		for i, childBudget := range childBudgets {
+			if groupTransactionTotalAmountByMediumCategory.MediumCategoryID == childBudget.MediumCategoryID && groupTransactionTotalAmountByMediumCategory.CustomCategoryID == childBudget.CustomCategoryID {
+				childBudgets[i].LastMonthExpenses = groupTransactionTotalAmountByMediumCategory.TotalAmount
+
+				break
+			}
+		}
+	}
+}
+
+// generateGroupBudgetRollovers calculates the rollover of each group big category for every month from firstMonth to lastMonth,
+// which is cut off at the current month.
+// The result is keyed by "2006-01" formatted month and big category ID.
+func generateGroupBudgetRollovers(h *DBHandler, firstMonth time.Time, lastMonth time.Time, groupID int) (map[string]map[int]int, error) {
+	budgetRollovers := make(map[string]map[int]int)
+
+	// The unused amount of a month is only settled when the month ends,
+	// so nothing is carried into the months after the current one.
+	now := h.TimeManage.Now()
+	if currentMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC); lastMonth.After(currentMonth) {
+		lastMonth = currentMonth
+	}
+
+	if lastMonth.Before(firstMonth) {
+		return budgetRollovers, nil
+	}
+
+	groupBudgetRolloverSettings, err := h.GroupBudgetsRepo.GetGroupBudgetRolloverSettings(groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	var enabledGroupBudgetRolloverSettings []model.GroupBudgetRolloverSetting
+	var rolloverStartMonth time.Time
+	for _, groupBudgetRolloverSetting := range groupBudgetRolloverSettings.GroupBudgetRolloverSettings {
+		if !bool(groupBudgetRolloverSetting.Rollover) || !groupBudgetRolloverSetting.RolloverStartMonth.Valid || groupBudgetRolloverSetting.RolloverStartMonth.Time.After(lastMonth) {
+			continue
+		}
+
+		if len(enabledGroupBudgetRolloverSettings) == 0 || groupBudgetRolloverSetting.RolloverStartMonth.Time.Before(rolloverStartMonth) {
+			rolloverStartMonth = groupBudgetRolloverSetting.RolloverStartMonth.Time
+		}
+
+		enabledGroupBudgetRolloverSettings = append(enabledGroupBudgetRolloverSettings, groupBudgetRolloverSetting)
+	}
+
+	if len(enabledGroupBudgetRolloverSettings) == 0 {
+		return budgetRollovers, nil
+	}
+
+	rolloverStartMonth = time.Date(rolloverStartMonth.Year(), rolloverStartMonth.Month(), 1, 0, 0, 0, 0, time.UTC)
+	lastDayOfLastMonth := lastMonth.AddDate(0, 1, 0).Add(-1 * time.Second)
+
+	groupStandardBudgets, err := h.GroupBudgetsRepo.GetGroupStandardBudgets(groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	standardBudgetByBigCategory := make(map[int]int, len(groupStandardBudgets.GroupStandardBudgets))
+	for _, groupStandardBudgetByCategory := range groupStandardBudgets.GroupStandardBudgets {
+		standardBudgetByBigCategory[groupStandardBudgetByCategory.BigCategoryID] = groupStandardBudgetByCategory.Budget
+	}
+
+	monthlyGroupCustomBudgetByCategoryList, err := h.GroupBudgetsRepo.GetMonthlyGroupCustomBudgetByCategoryList(rolloverStartMonth, lastMonth, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	customBudgetsByMonth := make(map[string]map[int]int)
+	for _, monthlyGroupCustomBudgetByCategory := range monthlyGroupCustomBudgetByCategoryList {
+		month := monthlyGroupCustomBudgetByCategory.Month.Format("2006-01")
+		if _, ok := customBudgetsByMonth[month]; !ok {
+			customBudgetsByMonth[month] = make(map[int]int)
+		}
+
+		customBudgetsByMonth[month][monthlyGroupCustomBudgetByCategory.BigCategoryID] = monthlyGroupCustomBudgetByCategory.Budget
+	}
+
+	monthlyGroupTransactionTotalAmountByBigCategoryList, err := h.GroupTransactionsRepo.GetMonthlyGroupTransactionTotalAmountByBigCategoryList(groupID, rolloverStartMonth, lastDayOfLastMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	expensesByMonth := make(map[string]map[int]int)
+	for _, monthlyGroupTransactionTotalAmountByBigCategory := range monthlyGroupTransactionTotalAmountByBigCategoryList {
+		month := monthlyGroupTransactionTotalAmountByBigCategory.Month.Format("2006-01")
+		if _, ok := expensesByMonth[month]; !ok {
+			expensesByMonth[month] = make(map[int]int)
+		}
+
+		expensesByMonth[month][monthlyGroupTransactionTotalAmountByBigCategory.BigCategoryID] = monthlyGroupTransactionTotalAmountByBigCategory.TotalAmount
+	}
+
+	for _, groupBudgetRolloverSetting := range enabledGroupBudgetRolloverSettings {
+		bigCategoryID := groupBudgetRolloverSetting.BigCategoryID
+		startMonth := time.Date(groupBudgetRolloverSetting.RolloverStartMonth.Time.Year(), groupBudgetRolloverSetting.RolloverStartMonth.Time.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+		rollover := 0
+		for month := startMonth; !month.After(lastMonth); month = month.AddDate(0, 1, 0) {
+			formattedMonth := month.Format("2006-01")
+
+			if !month.Before(firstMonth) {
+				if _, ok := budgetRollovers[formattedMonth]; !ok {
+					budgetRollovers[formattedMonth] = make(map[int]int)
+				}
+
+				budgetRollovers[formattedMonth][bigCategoryID] = rollover
+			}
+
+			budget := standardBudgetByBigCategory[bigCategoryID]
+			if customBudgets, ok := customBudgetsByMonth[formattedMonth]; ok {
+				budget = customBudgets[bigCategoryID]
+			}
+
+			rollover = groupBudgetRolloverSetting.CarryOver(budget, rollover, expensesByMonth[formattedMonth][bigCategoryID])
+		}
+	}
+
+	return budgetRollovers, nil
+}
+
+func setGroupCustomBudgetsRollover(h *DBHandler, groupCustomBudgets *model.GroupCustomBudgets, yearMonth time.Time, groupID int) error {
+	budgetRollovers, err := generateGroupBudgetRollovers(h, yearMonth, yearMonth, groupID)
+	if err != nil {
+		return err
+	}
+
+	for i, groupCustomBudgetByCategory := range groupCustomBudgets.GroupCustomBudgets {
+		rollover := budgetRollovers[yearMonth.Format("2006-01")][groupCustomBudgetByCategory.BigCategoryID]
+
+		groupCustomBudgets.GroupCustomBudgets[i].Rollover = rollover
+		groupCustomBudgets.GroupCustomBudgets[i].EffectiveBudget = groupCustomBudgetByCategory.Budget + rollover
+	}
+
+	return nil
+}
+
+func generateGroupBudgetStatus(h *DBHandler, yearMonth time.Time, groupID int) (*model.GroupBudgetStatus, error) {
+	firstDay := time.Date(yearMonth.Year(), yearMonth.Month(), 1, 0, 0, 0, 0, time.UTC)
+	lastDay := firstDay.AddDate(0, 1, 0).Add(-1 * time.Second)
+
+	groupTransactionTotalAmountByBigCategoryList, err := h.GroupTransactionsRepo.GetMonthlyGroupTransactionTotalAmountByBigCategory(groupID, firstDay, lastDay)
+	if err != nil {
+		return nil, err
+	}
+
+	expensesByBigCategory := make(map[int]int, len(groupTransactionTotalAmountByBigCategoryList))
+	for _, groupTransactionTotalAmountByBigCategory := range groupTransactionTotalAmountByBigCategoryList {
+		expensesByBigCategory[groupTransactionTotalAmountByBigCategory.BigCategoryID] = groupTransactionTotalAmountByBigCategory.TotalAmount
+	}
+
+	budgetRollovers, err := generateGroupBudgetRollovers(h, firstDay, firstDay, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	rolloverByBigCategory := budgetRollovers[firstDay.Format("2006-01")]
+
+	groupCustomBudgets, err := h.GroupBudgetsRepo.GetGroupCustomBudgets(firstDay, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(groupCustomBudgets.GroupCustomBudgets) != 0 {
+		groupBudgetStatusByCategoryList := make([]model.GroupBudgetStatusByCategory, len(groupCustomBudgets.GroupCustomBudgets))
+		for i, groupCustomBudgetByCategory := range groupCustomBudgets.GroupCustomBudgets {
+			groupBudgetStatusByCategoryList[i] = model.NewGroupBudgetStatusByCategory(groupCustomBudgetByCategory.BigCategoryID, groupCustomBudgetByCategory.BigCategoryName, groupCustomBudgetByCategory.Budget+rolloverByBigCategory[groupCustomBudgetByCategory.BigCategoryID], expensesByBigCategory[groupCustomBudgetByCategory.BigCategoryID])
+		}
+
+		groupBudgetStatus := model.NewGroupBudgetStatus(firstDay, "CustomBudget", groupBudgetStatusByCategoryList)
+
+		return &groupBudgetStatus, nil
+	}
+
+	groupStandardBudgets, err := h.GroupBudgetsRepo.GetGroupStandardBudgets(groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	groupBudgetStatusByCategoryList := make([]model.GroupBudgetStatusByCategory, len(groupStandardBudgets.GroupStandardBudgets))
+	for i, groupStandardBudgetByCategory := range groupStandardBudgets.GroupStandardBudgets {
+		groupBudgetStatusByCategoryList[i] = model.NewGroupBudgetStatusByCategory(groupStandardBudgetByCategory.BigCategoryID, groupStandardBudgetByCategory.BigCategoryName, groupStandardBudgetByCategory.Budget+rolloverByBigCategory[groupStandardBudgetByCategory.BigCategoryID], expensesByBigCategory[groupStandardBudgetByCategory.BigCategoryID])
+	}
+
+	groupBudgetStatus := model.NewGroupBudgetStatus(firstDay, "StandardBudget", groupBudgetStatusByCategoryList)
+
+	return &groupBudgetStatus, nil
+}
+
+func newGroupRecurringChargeKey(groupTransactionSender model.GroupTransactionSender) recurringChargeKey {
+	return recurringChargeKey{
+		bigCategoryID:    groupTransactionSender.BigCategoryID,
+		mediumCategoryID: groupTransactionSender.MediumCategoryID.Int64,
+		customCategoryID: groupTransactionSender.CustomCategoryID.Int64,
+		shop:             groupTransactionSender.Shop.String,
+		amount:           groupTransactionSender.Amount,
+	}
+}
+
+func generateGroupForecastExpenses(h *DBHandler, forecastMethod string, period forecastPeriod, expensesByBigCategory map[int]int, groupID int) (map[int]int, error) {
+	forecastExpensesByBigCategory := make(map[int]int, len(expensesByBigCategory))
+
+	switch forecastMethod {
+	case historicalForecastMethod:
+		groupTransactionTotalAmountByBigCategoryList, err := h.GroupTransactionsRepo.GetMonthlyGroupTransactionTotalAmountByBigCategoryList(groupID, period.firstDay.AddDate(0, -forecastLookbackMonths, 0), period.firstDay.Add(-1*time.Second))
+		if err != nil {
+			return nil, err
+		}
+
+		historicalExpensesByBigCategory := make(map[int]int)
+		for _, groupTransactionTotalAmountByBigCategory := range groupTransactionTotalAmountByBigCategoryList {
+			historicalExpensesByBigCategory[groupTransactionTotalAmountByBigCategory.BigCategoryID] += groupTransactionTotalAmountByBigCategory.TotalAmount
+		}
+
+		historicalExpensesToDateByBigCategory := make(map[int]int)
+		for _, sameDayOfMonthPeriod := range period.sameDayOfMonthPeriods() {
+			groupTransactionTotalAmountByBigCategoryList, err := h.GroupTransactionsRepo.GetMonthlyGroupTransactionTotalAmountByBigCategory(groupID, sameDayOfMonthPeriod[0], sameDayOfMonthPeriod[1])
+			if err != nil {
+				return nil, err
+			}
+
+			for _, groupTransactionTotalAmountByBigCategory := range groupTransactionTotalAmountByBigCategoryList {
+				historicalExpensesToDateByBigCategory[groupTransactionTotalAmountByBigCategory.BigCategoryID] += groupTransactionTotalAmountByBigCategory.TotalAmount
+			}
+		}
+
+		for bigCategoryID, historicalExpenses := range historicalExpensesByBigCategory {
+			forecastExpensesByBigCategory[bigCategoryID] = forecastExpensesByHistory(expensesByBigCategory[bigCategoryID], historicalExpensesToDateByBigCategory[bigCategoryID], historicalExpenses, period.elapsedDays, period.daysInMonth)
+		}
+
+		for bigCategoryID, expenses := range expensesByBigCategory {
+			if _, ok := historicalExpensesByBigCategory[bigCategoryID]; !ok {
+				forecastExpensesByBigCategory[bigCategoryID] = forecastExpensesByPace(expenses, period.elapsedDays, period.daysInMonth)
+			}
+		}
+	case recurringForecastMethod:
+		pastGroupTransactionsList, err := h.GroupTransactionsRepo.GetMonthlyGroupTransactionsList(groupID, period.firstDay.AddDate(0, -forecastLookbackMonths, 0), period.firstDay.Add(-1*time.Second))
+		if err != nil {
+			return nil, err
+		}
+
+		groupTransactionsList, err := h.GroupTransactionsRepo.GetMonthlyGroupTransactionsList(groupID, period.firstDay, period.endOfToday)
+		if err != nil {
+			return nil, err
+		}
+
+		recurringChargeFinder := newRecurringChargeFinder()
+		for _, groupTransaction := range pastGroupTransactionsList {
+			if groupTransaction.TransactionType == "expense" {
+				recurringChargeFinder.addPastCharge(newGroupRecurringChargeKey(groupTransaction), groupTransaction.TransactionDate.Time)
+			}
+		}
+
+		for _, groupTransaction := range groupTransactionsList {
+			if groupTransaction.TransactionType == "expense" {
+				recurringChargeFinder.addPaidCharge(newGroupRecurringChargeKey(groupTransaction))
+			}
+		}
+
+		recurringChargesByBigCategory, paidRecurringChargesByBigCategory := recurringChargeFinder.recurringChargesByBigCategory()
+
+		for bigCategoryID, recurringCharges := range recurringChargesByBigCategory {
+			forecastExpensesByBigCategory[bigCategoryID] = forecastExpensesByRecurringCharges(expensesByBigCategory[bigCategoryID], paidRecurringChargesByBigCategory[bigCategoryID], recurringCharges, period.elapsedDays, period.daysInMonth)
+		}
+
+		for bigCategoryID, expenses := range expensesByBigCategory {
+			if _, ok := recurringChargesByBigCategory[bigCategoryID]; !ok {
+				forecastExpensesByBigCategory[bigCategoryID] = forecastExpensesByPace(expenses, period.elapsedDays, period.daysInMonth)
+			}
+		}
+	default:
+		for bigCategoryID, expenses := range expensesByBigCategory {
+			forecastExpensesByBigCategory[bigCategoryID] = forecastExpensesByPace(expenses, period.elapsedDays, period.daysInMonth)
+		}
+	}
+
+	for bigCategoryID, expenses := range expensesByBigCategory {
+		if forecastExpensesByBigCategory[bigCategoryID] < expenses {
+			forecastExpensesByBigCategory[bigCategoryID] = expenses
+		}
+	}
+
+	return forecastExpensesByBigCategory, nil
+}
+
+// generateGroupBudgetForecast projects month-end expenses of the current month from month-to-date group transactions,
+// and compares them with the effective group budget of the month.
+func generateGroupBudgetForecast(h *DBHandler, forecastMethod string, groupID int) (*model.GroupBudgetForecast, error) {
+	period := newForecastPeriod(h.TimeManage.Now())
+
+	groupTransactionTotalAmountByBigCategoryList, err := h.GroupTransactionsRepo.GetMonthlyGroupTransactionTotalAmountByBigCategory(groupID, period.firstDay, period.endOfToday)
+	if err != nil {
+		return nil, err
+	}
+
+	expensesByBigCategory := make(map[int]int, len(groupTransactionTotalAmountByBigCategoryList))
+	for _, groupTransactionTotalAmountByBigCategory := range groupTransactionTotalAmountByBigCategoryList {
+		expensesByBigCategory[groupTransactionTotalAmountByBigCategory.BigCategoryID] = groupTransactionTotalAmountByBigCategory.TotalAmount
+	}
+
+	forecastExpensesByBigCategory, err := generateGroupForecastExpenses(h, forecastMethod, period, expensesByBigCategory, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	groupBudgetStatus, err := generateGroupBudgetStatus(h, period.firstDay, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	groupBudgetForecastByCategoryList := make([]model.GroupBudgetForecastByCategory, len(groupBudgetStatus.GroupBudgetStatusByCategory))
+	for i, groupBudgetStatusByCategory := range groupBudgetStatus.GroupBudgetStatusByCategory {
+		groupBudgetForecastByCategoryList[i] = model.NewGroupBudgetForecastByCategory(groupBudgetStatusByCategory.BigCategoryID, groupBudgetStatusByCategory.BigCategoryName, groupBudgetStatusByCategory.Budget, expensesByBigCategory[groupBudgetStatusByCategory.BigCategoryID], forecastExpensesByBigCategory[groupBudgetStatusByCategory.BigCategoryID])
+	}
+
+	groupBudgetForecast := model.NewGroupBudgetForecast(*groupBudgetStatus, forecastMethod, period.elapsedDays, period.daysInMonth, groupBudgetForecastByCategoryList)
+
+	return &groupBudgetForecast, nil
+}
+
+// generatePreviousGroupBudgetStatusList generates the group budget status of every month touched by a group transaction before it is written.
+// A month moved out of by an edit is included as well, since its budget is carried into the following months.
+// The notification is best-effort, so that a month whose group budget status can't be generated is logged and skipped.
+func generatePreviousGroupBudgetStatusList(h *DBHandler, groupID int, transactionDateList ...time.Time) []*model.GroupBudgetStatus {
+	previousGroupBudgetStatusList := make([]*model.GroupBudgetStatus, 0, len(transactionDateList))
+	generatedMonths := make(map[string]bool, len(transactionDateList))
+
+	for _, transactionDate := range transactionDateList {
+		if generatedMonths[transactionDate.Format("2006-01")] {
+			continue
+		}
+
+		generatedMonths[transactionDate.Format("2006-01")] = true
+
+		previousGroupBudgetStatus, err := generateGroupBudgetStatus(h, transactionDate, groupID)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+
+		previousGroupBudgetStatusList = append(previousGroupBudgetStatusList, previousGroupBudgetStatus)
+	}
+
+	return previousGroupBudgetStatusList
+}
+
+// notifyGroupBudgetThresholdEvents compares the group budget status before a group transaction was written with the current one,
+// and notifies every threshold that has been newly reached.
+func notifyGroupBudgetThresholdEvents(h *DBHandler, previousGroupBudgetStatus *model.GroupBudgetStatus, groupID int) error {
+	currentGroupBudgetStatus, err := generateGroupBudgetStatus(h, previousGroupBudgetStatus.YearMonth.Time, groupID)
+	if err != nil {
+		return err
+	}
+
+	previousExpensesByBigCategory := make(map[int]int, len(previousGroupBudgetStatus.GroupBudgetStatusByCategory))
+	for _, previousGroupBudgetStatusByCategory := range previousGroupBudgetStatus.GroupBudgetStatusByCategory {
+		previousExpensesByBigCategory[previousGroupBudgetStatusByCategory.BigCategoryID] = previousGroupBudgetStatusByCategory.Expenses
+	}
+
+	for _, groupBudgetStatusByCategory := range currentGroupBudgetStatus.GroupBudgetStatusByCategory {
+		previousExpenses := previousExpensesByBigCategory[groupBudgetStatusByCategory.BigCategoryID]
+
+		for _, threshold := range crossedBudgetThresholds(groupBudgetStatusByCategory.Budget, previousExpenses, groupBudgetStatusByCategory.Expenses) {
+			groupBudgetThresholdEvent := model.GroupBudgetThresholdEvent{
+				GroupID:         groupID,
+				YearMonth:       currentGroupBudgetStatus.YearMonth.Time,
+				BigCategoryID:   groupBudgetStatusByCategory.BigCategoryID,
+				BigCategoryName: groupBudgetStatusByCategory.BigCategoryName,
+				Threshold:       threshold,
+				Budget:          groupBudgetStatusByCategory.Budget,
+				Expenses:        groupBudgetStatusByCategory.Expenses,
+			}
+
+			if err := h.BudgetNotifier.NotifyGroupBudgetThreshold(groupBudgetThresholdEvent); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 func (h *DBHandler) PostInitGroupStandardBudgets(w http.ResponseWriter, r *http.Request) {
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
@@ -31,34 +441,34 @@ func (h *DBHandler) GetGroupStandardBudgets(w http.ResponseWriter, r *http.Reque
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	groupStandardBudgets, err := h.GroupBudgetsRepo.GetGroupStandardBudgets(groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -68,7 +478,7 @@ func (h *DBHandler) GetGroupStandardBudgets(w http.ResponseWriter, r *http.Reque
 
 	groupTransactionTotalAmountByBigCategoryList, err := h.GroupTransactionsRepo.GetMonthlyGroupTransactionTotalAmountByBigCategory(groupID, firstDayOfLastMonth, lastDayOfLastMonth)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -82,6 +492,16 @@ func (h *DBHandler) GetGroupStandardBudgets(w http.ResponseWriter, r *http.Reque
 		}
 	}
 
+	groupTransactionTotalAmountByMediumCategoryList, err := h.GroupTransactionsRepo.GetMonthlyGroupTransactionTotalAmountByMediumCategory(groupID, firstDayOfLastMonth, lastDayOfLastMonth)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	for _, groupStandardBudgetByCategory := range groupStandardBudgets.GroupStandardBudgets {
+		setGroupChildBudgetsLastMonthExpenses(groupStandardBudgetByCategory.ChildBudgets, groupTransactionTotalAmountByMediumCategoryList)
+	}
+
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(&groupStandardBudgets); err != nil {
@@ -94,50 +514,61 @@ func (h *DBHandler) PutGroupStandardBudgets(w http.ResponseWriter, r *http.Reque
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	var groupStandardBudgets model.GroupStandardBudgets
 	if err := json.NewDecoder(r.Body).Decode(&groupStandardBudgets); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if err := validateBudgets(groupStandardBudgets); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, err))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
+		return
+	}
+
+	mediumCategoryBigCategoryIDs, customCategoryBigCategoryIDs, err := generateGroupCategoryBigCategoryIDs(h, groupID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if err := validateChildBudgetCategories(groupStandardBudgets, mediumCategoryBigCategoryIDs, customCategoryBigCategoryIDs); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
 		return
 	}
 
 	if err := h.GroupBudgetsRepo.PutGroupStandardBudgets(&groupStandardBudgets, groupID); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	dbGroupStandardBudgets, err := h.GroupBudgetsRepo.GetGroupStandardBudgets(groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -147,7 +578,7 @@ func (h *DBHandler) PutGroupStandardBudgets(w http.ResponseWriter, r *http.Reque
 
 	groupTransactionTotalAmountByBigCategoryList, err := h.GroupTransactionsRepo.GetMonthlyGroupTransactionTotalAmountByBigCategory(groupID, firstDayOfLastMonth, lastDayOfLastMonth)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -161,6 +592,16 @@ func (h *DBHandler) PutGroupStandardBudgets(w http.ResponseWriter, r *http.Reque
 		}
 	}
 
+	groupTransactionTotalAmountByMediumCategoryList, err := h.GroupTransactionsRepo.GetMonthlyGroupTransactionTotalAmountByMediumCategory(groupID, firstDayOfLastMonth, lastDayOfLastMonth)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	for _, groupStandardBudgetByCategory := range dbGroupStandardBudgets.GroupStandardBudgets {
+		setGroupChildBudgetsLastMonthExpenses(groupStandardBudgetByCategory.ChildBudgets, groupTransactionTotalAmountByMediumCategoryList)
+	}
+
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(&dbGroupStandardBudgets); err != nil {
@@ -173,40 +614,45 @@ func (h *DBHandler) GetGroupCustomBudgets(w http.ResponseWriter, r *http.Request
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	yearMonth, err := time.Parse("2006-01", mux.Vars(r)["year_month"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
 		return
 	}
 
 	dbGroupCustomBudgets, err := h.GroupBudgetsRepo.GetGroupCustomBudgets(yearMonth, groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if err := setGroupCustomBudgetsRollover(h, dbGroupCustomBudgets, yearMonth, groupID); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -216,7 +662,7 @@ func (h *DBHandler) GetGroupCustomBudgets(w http.ResponseWriter, r *http.Request
 
 	groupTransactionTotalAmountByBigCategoryList, err := h.GroupTransactionsRepo.GetMonthlyGroupTransactionTotalAmountByBigCategory(groupID, firstDayOfLastMonth, lastDayOfLastMonth)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -230,6 +676,16 @@ func (h *DBHandler) GetGroupCustomBudgets(w http.ResponseWriter, r *http.Request
 		}
 	}
 
+	groupTransactionTotalAmountByMediumCategoryList, err := h.GroupTransactionsRepo.GetMonthlyGroupTransactionTotalAmountByMediumCategory(groupID, firstDayOfLastMonth, lastDayOfLastMonth)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	for _, groupCustomBudgetByCategory := range dbGroupCustomBudgets.GroupCustomBudgets {
+		setGroupChildBudgetsLastMonthExpenses(groupCustomBudgetByCategory.ChildBudgets, groupTransactionTotalAmountByMediumCategoryList)
+	}
+
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(&dbGroupCustomBudgets); err != nil {
@@ -242,56 +698,72 @@ func (h *DBHandler) PostGroupCustomBudgets(w http.ResponseWriter, r *http.Reques
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	yearMonth, err := time.Parse("2006-01", mux.Vars(r)["year_month"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
 		return
 	}
 
 	var groupCustomBudgets model.GroupCustomBudgets
 	if err := json.NewDecoder(r.Body).Decode(&groupCustomBudgets); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if err := validateBudgets(groupCustomBudgets); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, err))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
+		return
+	}
+
+	mediumCategoryBigCategoryIDs, customCategoryBigCategoryIDs, err := generateGroupCategoryBigCategoryIDs(h, groupID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if err := validateChildBudgetCategories(groupCustomBudgets, mediumCategoryBigCategoryIDs, customCategoryBigCategoryIDs); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
 		return
 	}
 
 	if err := h.GroupBudgetsRepo.PostGroupCustomBudgets(&groupCustomBudgets, yearMonth, groupID); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	dbGroupCustomBudgets, err := h.GroupBudgetsRepo.GetGroupCustomBudgets(yearMonth, groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if err := setGroupCustomBudgetsRollover(h, dbGroupCustomBudgets, yearMonth, groupID); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -301,7 +773,7 @@ func (h *DBHandler) PostGroupCustomBudgets(w http.ResponseWriter, r *http.Reques
 
 	groupTransactionTotalAmountByBigCategoryList, err := h.GroupTransactionsRepo.GetMonthlyGroupTransactionTotalAmountByBigCategory(groupID, firstDayOfLastMonth, lastDayOfLastMonth)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -315,6 +787,16 @@ func (h *DBHandler) PostGroupCustomBudgets(w http.ResponseWriter, r *http.Reques
 		}
 	}
 
+	groupTransactionTotalAmountByMediumCategoryList, err := h.GroupTransactionsRepo.GetMonthlyGroupTransactionTotalAmountByMediumCategory(groupID, firstDayOfLastMonth, lastDayOfLastMonth)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	for _, groupCustomBudgetByCategory := range dbGroupCustomBudgets.GroupCustomBudgets {
+		setGroupChildBudgetsLastMonthExpenses(groupCustomBudgetByCategory.ChildBudgets, groupTransactionTotalAmountByMediumCategoryList)
+	}
+
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusCreated)
 	if err := json.NewEncoder(w).Encode(&dbGroupCustomBudgets); err != nil {
@@ -327,56 +809,72 @@ func (h *DBHandler) PutGroupCustomBudgets(w http.ResponseWriter, r *http.Request
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	yearMonth, err := time.Parse("2006-01", mux.Vars(r)["year_month"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
 		return
 	}
 
 	var groupCustomBudgets model.GroupCustomBudgets
 	if err := json.NewDecoder(r.Body).Decode(&groupCustomBudgets); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if err := validateBudgets(groupCustomBudgets); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, err))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
+		return
+	}
+
+	mediumCategoryBigCategoryIDs, customCategoryBigCategoryIDs, err := generateGroupCategoryBigCategoryIDs(h, groupID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if err := validateChildBudgetCategories(groupCustomBudgets, mediumCategoryBigCategoryIDs, customCategoryBigCategoryIDs); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
 		return
 	}
 
 	if err := h.GroupBudgetsRepo.PutGroupCustomBudgets(&groupCustomBudgets, yearMonth, groupID); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	dbGroupCustomBudgets, err := h.GroupBudgetsRepo.GetGroupCustomBudgets(yearMonth, groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if err := setGroupCustomBudgetsRollover(h, dbGroupCustomBudgets, yearMonth, groupID); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -386,7 +884,7 @@ func (h *DBHandler) PutGroupCustomBudgets(w http.ResponseWriter, r *http.Request
 
 	groupTransactionTotalAmountByBigCategoryList, err := h.GroupTransactionsRepo.GetMonthlyGroupTransactionTotalAmountByBigCategory(groupID, firstDayOfLastMonth, lastDayOfLastMonth)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -400,6 +898,16 @@ func (h *DBHandler) PutGroupCustomBudgets(w http.ResponseWriter, r *http.Request
 		}
 	}
 
+	groupTransactionTotalAmountByMediumCategoryList, err := h.GroupTransactionsRepo.GetMonthlyGroupTransactionTotalAmountByMediumCategory(groupID, firstDayOfLastMonth, lastDayOfLastMonth)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	for _, groupCustomBudgetByCategory := range dbGroupCustomBudgets.GroupCustomBudgets {
+		setGroupChildBudgetsLastMonthExpenses(groupCustomBudgetByCategory.ChildBudgets, groupTransactionTotalAmountByMediumCategoryList)
+	}
+
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(&dbGroupCustomBudgets); err != nil {
@@ -412,45 +920,45 @@ func (h *DBHandler) DeleteGroupCustomBudgets(w http.ResponseWriter, r *http.Requ
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	yearMonth, err := time.Parse("2006-01", mux.Vars(r)["year_month"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
 		return
 	}
 
 	if err := h.GroupBudgetsRepo.DeleteGroupCustomBudgets(yearMonth, groupID); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(&DeleteContentMsg{"カスタム予算を削除しました。"}); err != nil {
+	if err := json.NewEncoder(w).Encode(&DeleteContentMsg{localizeMessage(r.Context(), "カスタム予算を削除しました。")}); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -460,46 +968,52 @@ func (h *DBHandler) GetYearlyGroupBudgets(w http.ResponseWriter, r *http.Request
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	year, err := time.Parse("2006", mux.Vars(r)["year"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年を正しく指定してください。"}))
 		return
 	}
 
 	monthlyGroupStandardBudget, err := h.GroupBudgetsRepo.GetMonthlyGroupStandardBudget(groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	monthlyGroupCustomBudgets, err := h.GroupBudgetsRepo.GetMonthlyGroupCustomBudgets(year, groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	budgetRollovers, err := generateGroupBudgetRollovers(h, year, year.AddDate(0, 11, 0), groupID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -511,12 +1025,17 @@ func (h *DBHandler) GetYearlyGroupBudgets(w http.ResponseWriter, r *http.Request
 			yearlyBudget.GroupMonthlyBudgets[i] = monthlyGroupCustomBudgets[j]
 
 			j++
-			continue
+		} else {
+			monthlyGroupStandardBudget.Month.Time = year.AddDate(0, i, 0)
+			yearlyBudget.YearlyTotalBudget += monthlyGroupStandardBudget.MonthlyTotalBudget
+			yearlyBudget.GroupMonthlyBudgets[i] = monthlyGroupStandardBudget
+		}
+
+		for _, rollover := range budgetRollovers[year.AddDate(0, i, 0).Format("2006-01")] {
+			yearlyBudget.GroupMonthlyBudgets[i].MonthlyTotalRollover += rollover
 		}
 
-		monthlyGroupStandardBudget.Month.Time = year.AddDate(0, i, 0)
-		yearlyBudget.YearlyTotalBudget += monthlyGroupStandardBudget.MonthlyTotalBudget
-		yearlyBudget.GroupMonthlyBudgets[i] = monthlyGroupStandardBudget
+		yearlyBudget.GroupMonthlyBudgets[i].MonthlyTotalEffectiveBudget = yearlyBudget.GroupMonthlyBudgets[i].MonthlyTotalBudget + yearlyBudget.GroupMonthlyBudgets[i].MonthlyTotalRollover
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
@@ -526,3 +1045,461 @@ func (h *DBHandler) GetYearlyGroupBudgets(w http.ResponseWriter, r *http.Request
 		return
 	}
 }
+
+func (h *DBHandler) GetGroupBudgetStatus(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		return
+	}
+
+	if err := verifyGroupAffiliation(groupID, userID); err != nil {
+		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
+		if !ok {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		return
+	}
+
+	yearMonth, err := time.Parse("2006-01", mux.Vars(r)["year_month"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
+		return
+	}
+
+	groupBudgetStatus, err := generateGroupBudgetStatus(h, yearMonth, groupID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(groupBudgetStatus); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) GetGroupBudgetForecast(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		return
+	}
+
+	if err := verifyGroupAffiliation(groupID, userID); err != nil {
+		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
+		if !ok {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		return
+	}
+
+	forecastMethod, err := parseForecastMethod(r)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
+		return
+	}
+
+	groupBudgetForecast, err := generateGroupBudgetForecast(h, forecastMethod, groupID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(groupBudgetForecast); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) GetGroupBudgetRolloverSettings(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		return
+	}
+
+	if err := verifyGroupAffiliation(groupID, userID); err != nil {
+		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
+		if !ok {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		return
+	}
+
+	groupBudgetRolloverSettings, err := h.GroupBudgetsRepo.GetGroupBudgetRolloverSettings(groupID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(groupBudgetRolloverSettings); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) PutGroupBudgetRolloverSettings(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		return
+	}
+
+	if err := verifyGroupAffiliation(groupID, userID); err != nil {
+		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
+		if !ok {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		return
+	}
+
+	var groupBudgetRolloverSettings model.GroupBudgetRolloverSettings
+	if err := json.NewDecoder(r.Body).Decode(&groupBudgetRolloverSettings); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	bigCategoriesList, err := h.CategoriesRepo.GetBigCategoriesList()
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if err := validateRolloverSettings(groupBudgetRolloverSettings, bigCategoriesList); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
+		return
+	}
+
+	dbGroupBudgetRolloverSettings, err := h.GroupBudgetsRepo.GetGroupBudgetRolloverSettings(groupID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	// The rollover is accumulated from the month in which it was enabled, so keep the start month while it stays enabled.
+	now := h.TimeManage.Now()
+	firstDayOfThisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	for i, groupBudgetRolloverSetting := range groupBudgetRolloverSettings.GroupBudgetRolloverSettings {
+		if !groupBudgetRolloverSetting.Rollover {
+			groupBudgetRolloverSettings.GroupBudgetRolloverSettings[i].RolloverStartMonth = sql.NullTime{}
+			continue
+		}
+
+		groupBudgetRolloverSettings.GroupBudgetRolloverSettings[i].RolloverStartMonth = sql.NullTime{Time: firstDayOfThisMonth, Valid: true}
+
+		for _, dbGroupBudgetRolloverSetting := range dbGroupBudgetRolloverSettings.GroupBudgetRolloverSettings {
+			if dbGroupBudgetRolloverSetting.BigCategoryID == groupBudgetRolloverSetting.BigCategoryID && bool(dbGroupBudgetRolloverSetting.Rollover) && dbGroupBudgetRolloverSetting.RolloverStartMonth.Valid {
+				groupBudgetRolloverSettings.GroupBudgetRolloverSettings[i].RolloverStartMonth = dbGroupBudgetRolloverSetting.RolloverStartMonth
+
+				break
+			}
+		}
+	}
+
+	if err := h.GroupBudgetsRepo.PutGroupBudgetRolloverSettings(&groupBudgetRolloverSettings, groupID); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	dbGroupBudgetRolloverSettings, err = h.GroupBudgetsRepo.GetGroupBudgetRolloverSettings(groupID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(dbGroupBudgetRolloverSettings); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) CopyGroupCustomBudgets(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		return
+	}
+
+	if err := verifyGroupAffiliation(groupID, userID); err != nil {
+		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
+		if !ok {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		return
+	}
+
+	yearMonth, err := time.Parse("2006-01", mux.Vars(r)["year_month"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
+		return
+	}
+
+	var budgetTargetMonths model.BudgetTargetMonths
+	if err := json.NewDecoder(r.Body).Decode(&budgetTargetMonths); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	targetMonthList, err := generateBudgetCopyTargetMonths(r.Context(), budgetTargetMonths, yearMonth)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
+		return
+	}
+
+	groupCustomBudgets, err := h.GroupBudgetsRepo.GetGroupCustomBudgets(yearMonth, groupID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if len(groupCustomBudgets.GroupCustomBudgets) == 0 {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BudgetValidationErrorMsg{"コピー元のカスタム予算が登録されていません。"}))
+		return
+	}
+
+	if err := validateBudgets(groupCustomBudgets); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
+		return
+	}
+
+	if err := h.GroupBudgetsRepo.ReplaceGroupCustomBudgetsList(groupCustomBudgets, targetMonthList, groupID); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	budgetYearMonths := model.NewBudgetYearMonths(targetMonthList)
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(&budgetYearMonths); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) CopyStandardBudgetsToGroup(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		return
+	}
+
+	if err := verifyGroupAffiliation(groupID, userID); err != nil {
+		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
+		if !ok {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		return
+	}
+
+	standardBudgets, err := h.BudgetsRepo.GetStandardBudgets(userID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	groupStandardBudgets := model.NewGroupStandardBudgetsFromStandardBudgets(*standardBudgets)
+
+	if err := validateBudgets(groupStandardBudgets); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
+		return
+	}
+
+	mediumCategoryBigCategoryIDs, customCategoryBigCategoryIDs, err := generateGroupCategoryBigCategoryIDs(h, groupID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if err := validateChildBudgetCategories(groupStandardBudgets, mediumCategoryBigCategoryIDs, customCategoryBigCategoryIDs); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
+		return
+	}
+
+	if err := h.GroupBudgetsRepo.PutGroupStandardBudgets(&groupStandardBudgets, groupID); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&CopyContentMsg{localizeMessage(r.Context(), "個人の標準予算をグループの標準予算にコピーしました。")}); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) CopyCustomBudgetsToGroup(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		return
+	}
+
+	if err := verifyGroupAffiliation(groupID, userID); err != nil {
+		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
+		if !ok {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		return
+	}
+
+	yearMonth, err := time.Parse("2006-01", mux.Vars(r)["year_month"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
+		return
+	}
+
+	customBudgets, err := h.BudgetsRepo.GetCustomBudgets(yearMonth, userID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if len(customBudgets.CustomBudgets) == 0 {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BudgetValidationErrorMsg{"コピー元のカスタム予算が登録されていません。"}))
+		return
+	}
+
+	groupCustomBudgets := model.NewGroupCustomBudgetsFromCustomBudgets(*customBudgets)
+
+	if err := validateBudgets(groupCustomBudgets); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
+		return
+	}
+
+	mediumCategoryBigCategoryIDs, customCategoryBigCategoryIDs, err := generateGroupCategoryBigCategoryIDs(h, groupID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if err := validateChildBudgetCategories(groupCustomBudgets, mediumCategoryBigCategoryIDs, customCategoryBigCategoryIDs); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
+		return
+	}
+
+	targetMonthList := []time.Time{yearMonth}
+
+	if err := h.GroupBudgetsRepo.ReplaceGroupCustomBudgetsList(&groupCustomBudgets, targetMonthList, groupID); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	budgetYearMonths := model.NewBudgetYearMonths(targetMonthList)
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(&budgetYearMonths); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
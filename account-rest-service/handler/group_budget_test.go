@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"database/sql"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -25,22 +26,28 @@ func (m MockGroupBudgetsRepository) PostInitGroupStandardBudgets(groupID int) er
 func (m MockGroupBudgetsRepository) GetGroupStandardBudgets(groupID int) (*model.GroupStandardBudgets, error) {
 	return &model.GroupStandardBudgets{
 		GroupStandardBudgets: []model.GroupStandardBudgetByCategory{
-			{BigCategoryID: 2, BigCategoryName: "食費", Budget: 25000},
-			{BigCategoryID: 3, BigCategoryName: "日用品", Budget: 5000},
-			{BigCategoryID: 4, BigCategoryName: "趣味・娯楽", Budget: 4500},
-			{BigCategoryID: 5, BigCategoryName: "交際費", Budget: 1000},
-			{BigCategoryID: 6, BigCategoryName: "交通費", Budget: 1000},
-			{BigCategoryID: 7, BigCategoryName: "衣服・美容", Budget: 0},
-			{BigCategoryID: 8, BigCategoryName: "健康・医療", Budget: 4900},
-			{BigCategoryID: 9, BigCategoryName: "通信費", Budget: 4400},
-			{BigCategoryID: 10, BigCategoryName: "教養・教育", Budget: 10000},
-			{BigCategoryID: 11, BigCategoryName: "住宅", Budget: 15000},
-			{BigCategoryID: 12, BigCategoryName: "水道・光熱費", Budget: 3000},
-			{BigCategoryID: 13, BigCategoryName: "自動車", Budget: 0},
-			{BigCategoryID: 14, BigCategoryName: "保険", Budget: 9800},
-			{BigCategoryID: 15, BigCategoryName: "税金・社会保険", Budget: 0},
-			{BigCategoryID: 16, BigCategoryName: "現金・カード", Budget: 0},
-			{BigCategoryID: 17, BigCategoryName: "その他", Budget: 0},
+			{BigCategoryID: 2, BigCategoryName: "食費", Budget: 25000, ChildBudgets: []model.ChildBudgetByCategory{
+				{MediumCategoryID: model.NullInt64{NullInt64: sql.NullInt64{Int64: 6, Valid: true}}, CategoryName: "食料品", Budget: 15000},
+				{MediumCategoryID: model.NullInt64{NullInt64: sql.NullInt64{Int64: 10, Valid: true}}, CategoryName: "外食", Budget: 8000},
+				{CustomCategoryID: model.NullInt64{NullInt64: sql.NullInt64{Int64: 3, Valid: true}}, CategoryName: "米", Budget: 2000},
+			}},
+			{BigCategoryID: 3, BigCategoryName: "日用品", Budget: 5000, ChildBudgets: []model.ChildBudgetByCategory{
+				{MediumCategoryID: model.NullInt64{NullInt64: sql.NullInt64{Int64: 13, Valid: true}}, CategoryName: "消耗品", Budget: 3000},
+			}},
+			{BigCategoryID: 4, BigCategoryName: "趣味・娯楽", Budget: 4500, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 5, BigCategoryName: "交際費", Budget: 1000, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 6, BigCategoryName: "交通費", Budget: 1000, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 7, BigCategoryName: "衣服・美容", Budget: 0, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 8, BigCategoryName: "健康・医療", Budget: 4900, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 9, BigCategoryName: "通信費", Budget: 4400, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 10, BigCategoryName: "教養・教育", Budget: 10000, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 11, BigCategoryName: "住宅", Budget: 15000, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 12, BigCategoryName: "水道・光熱費", Budget: 3000, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 13, BigCategoryName: "自動車", Budget: 0, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 14, BigCategoryName: "保険", Budget: 9800, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 15, BigCategoryName: "税金・社会保険", Budget: 0, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 16, BigCategoryName: "現金・カード", Budget: 0, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 17, BigCategoryName: "その他", Budget: 0, ChildBudgets: []model.ChildBudgetByCategory{}},
 		},
 	}, nil
 }
@@ -52,22 +59,28 @@ func (m MockGroupBudgetsRepository) PutGroupStandardBudgets(groupStandardBudgets
 func (m MockGroupBudgetsRepository) GetGroupCustomBudgets(yearMonth time.Time, groupID int) (*model.GroupCustomBudgets, error) {
 	return &model.GroupCustomBudgets{
 		GroupCustomBudgets: []model.GroupCustomBudgetByCategory{
-			{BigCategoryID: 2, BigCategoryName: "食費", Budget: 30000},
-			{BigCategoryID: 3, BigCategoryName: "日用品", Budget: 5000},
-			{BigCategoryID: 4, BigCategoryName: "趣味・娯楽", Budget: 4500},
-			{BigCategoryID: 5, BigCategoryName: "交際費", Budget: 1000},
-			{BigCategoryID: 6, BigCategoryName: "交通費", Budget: 1000},
-			{BigCategoryID: 7, BigCategoryName: "衣服・美容", Budget: 0},
-			{BigCategoryID: 8, BigCategoryName: "健康・医療", Budget: 4900},
-			{BigCategoryID: 9, BigCategoryName: "通信費", Budget: 4400},
-			{BigCategoryID: 10, BigCategoryName: "教養・教育", Budget: 10000},
-			{BigCategoryID: 11, BigCategoryName: "住宅", Budget: 15000},
-			{BigCategoryID: 12, BigCategoryName: "水道・光熱費", Budget: 3000},
-			{BigCategoryID: 13, BigCategoryName: "自動車", Budget: 0},
-			{BigCategoryID: 14, BigCategoryName: "保険", Budget: 9800},
-			{BigCategoryID: 15, BigCategoryName: "税金・社会保険", Budget: 0},
-			{BigCategoryID: 16, BigCategoryName: "現金・カード", Budget: 0},
-			{BigCategoryID: 17, BigCategoryName: "その他", Budget: 0},
+			{BigCategoryID: 2, BigCategoryName: "食費", Budget: 30000, ChildBudgets: []model.ChildBudgetByCategory{
+				{MediumCategoryID: model.NullInt64{NullInt64: sql.NullInt64{Int64: 6, Valid: true}}, CategoryName: "食料品", Budget: 15000},
+				{MediumCategoryID: model.NullInt64{NullInt64: sql.NullInt64{Int64: 10, Valid: true}}, CategoryName: "外食", Budget: 8000},
+				{CustomCategoryID: model.NullInt64{NullInt64: sql.NullInt64{Int64: 3, Valid: true}}, CategoryName: "米", Budget: 2000},
+			}},
+			{BigCategoryID: 3, BigCategoryName: "日用品", Budget: 5000, ChildBudgets: []model.ChildBudgetByCategory{
+				{MediumCategoryID: model.NullInt64{NullInt64: sql.NullInt64{Int64: 13, Valid: true}}, CategoryName: "消耗品", Budget: 3000},
+			}},
+			{BigCategoryID: 4, BigCategoryName: "趣味・娯楽", Budget: 4500, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 5, BigCategoryName: "交際費", Budget: 1000, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 6, BigCategoryName: "交通費", Budget: 1000, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 7, BigCategoryName: "衣服・美容", Budget: 0, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 8, BigCategoryName: "健康・医療", Budget: 4900, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 9, BigCategoryName: "通信費", Budget: 4400, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 10, BigCategoryName: "教養・教育", Budget: 10000, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 11, BigCategoryName: "住宅", Budget: 15000, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 12, BigCategoryName: "水道・光熱費", Budget: 3000, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 13, BigCategoryName: "自動車", Budget: 0, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 14, BigCategoryName: "保険", Budget: 9800, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 15, BigCategoryName: "税金・社会保険", Budget: 0, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 16, BigCategoryName: "現金・カード", Budget: 0, ChildBudgets: []model.ChildBudgetByCategory{}},
+			{BigCategoryID: 17, BigCategoryName: "その他", Budget: 0, ChildBudgets: []model.ChildBudgetByCategory{}},
 		},
 	}, nil
 }
@@ -98,6 +111,44 @@ func (m MockGroupBudgetsRepository) GetMonthlyGroupCustomBudgets(year time.Time,
 	}, nil
 }
 
+func (m MockGroupBudgetsRepository) GetMonthlyGroupCustomBudgetByCategoryList(firstMonth time.Time, lastMonth time.Time, groupID int) ([]model.MonthlyGroupCustomBudgetByCategory, error) {
+	return []model.MonthlyGroupCustomBudgetByCategory{
+		{Month: time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC), BigCategoryID: 2, Budget: 30000},
+		{Month: time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC), BigCategoryID: 3, Budget: 5000},
+	}, nil
+}
+
+func (m MockGroupBudgetsRepository) GetGroupBudgetRolloverSettings(groupID int) (*model.GroupBudgetRolloverSettings, error) {
+	return &model.GroupBudgetRolloverSettings{
+		GroupBudgetRolloverSettings: []model.GroupBudgetRolloverSetting{
+			{BigCategoryID: 2, Rollover: true, RolloverCap: model.NullInt{Int: 10000, Valid: true}, RolloverStartMonth: sql.NullTime{Time: time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC), Valid: true}},
+			{BigCategoryID: 3, Rollover: true, RolloverStartMonth: sql.NullTime{Time: time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC), Valid: true}},
+			{BigCategoryID: 4},
+			{BigCategoryID: 5},
+			{BigCategoryID: 6},
+			{BigCategoryID: 7},
+			{BigCategoryID: 8},
+			{BigCategoryID: 9},
+			{BigCategoryID: 10},
+			{BigCategoryID: 11},
+			{BigCategoryID: 12},
+			{BigCategoryID: 13},
+			{BigCategoryID: 14},
+			{BigCategoryID: 15},
+			{BigCategoryID: 16},
+			{BigCategoryID: 17},
+		},
+	}, nil
+}
+
+func (m MockGroupBudgetsRepository) PutGroupBudgetRolloverSettings(groupBudgetRolloverSettings *model.GroupBudgetRolloverSettings, groupID int) error {
+	return nil
+}
+
+func (m MockGroupBudgetsRepository) ReplaceGroupCustomBudgetsList(groupCustomBudgets *model.GroupCustomBudgets, yearMonthList []time.Time, groupID int) error {
+	return nil
+}
+
 func TestDBHandler_PostInitGroupStandardBudgets(t *testing.T) {
 	h := DBHandler{
 		AuthRepo:         MockAuthRepository{},
@@ -158,6 +209,7 @@ func TestDBHandler_PutGroupStandardBudgets(t *testing.T) {
 		GroupBudgetsRepo:      MockGroupBudgetsRepository{},
 		GroupTransactionsRepo: MockGroupTransactionsRepository{},
 		TimeManage:            MockTime{},
+		GroupCategoriesRepo:   MockGroupCategoriesRepository{},
 	}
 
 	r := httptest.NewRequest("PUT", "/groups/1/standard-budgets", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
@@ -221,6 +273,7 @@ func TestDBHandler_PostGroupCustomBudgets(t *testing.T) {
 		GroupBudgetsRepo:      MockGroupBudgetsRepository{},
 		GroupTransactionsRepo: MockGroupTransactionsRepository{},
 		TimeManage:            MockTime{},
+		GroupCategoriesRepo:   MockGroupCategoriesRepository{},
 	}
 
 	r := httptest.NewRequest("POST", "/groups/1/custom-budgets/2020-07", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
@@ -253,6 +306,7 @@ func TestDBHandler_PutGroupCustomBudgets(t *testing.T) {
 		GroupBudgetsRepo:      MockGroupBudgetsRepository{},
 		GroupTransactionsRepo: MockGroupTransactionsRepository{},
 		TimeManage:            MockTime{},
+		GroupCategoriesRepo:   MockGroupCategoriesRepository{},
 	}
 
 	r := httptest.NewRequest("PUT", "/groups/1/custom-budgets/2020-07", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
@@ -311,8 +365,10 @@ func TestDBHandler_DeleteGroupCustomBudgets(t *testing.T) {
 
 func TestDBHandler_GetYearlyGroupBudgets(t *testing.T) {
 	h := DBHandler{
-		AuthRepo:         MockAuthRepository{},
-		GroupBudgetsRepo: MockGroupBudgetsRepository{},
+		AuthRepo:              MockAuthRepository{},
+		GroupBudgetsRepo:      MockGroupBudgetsRepository{},
+		GroupTransactionsRepo: MockGroupTransactionsRepository{},
+		TimeManage:            MockTime{},
 	}
 
 	r := httptest.NewRequest("GET", "/groups/1/budgets/2020", nil)
@@ -338,3 +394,219 @@ func TestDBHandler_GetYearlyGroupBudgets(t *testing.T) {
 	testutil.AssertResponseHeader(t, res, http.StatusOK)
 	testutil.AssertResponseBody(t, res, &model.YearlyGroupBudget{}, &model.YearlyGroupBudget{})
 }
+
+func TestDBHandler_GetGroupBudgetStatus(t *testing.T) {
+	h := DBHandler{
+		AuthRepo:              MockAuthRepository{},
+		GroupBudgetsRepo:      MockGroupBudgetsRepository{},
+		GroupTransactionsRepo: MockGroupTransactionsRepository{},
+		TimeManage:            MockTime{},
+	}
+
+	r := httptest.NewRequest("GET", "/groups/1/budgets/2020-07/status", nil)
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"group_id":   "1",
+		"year_month": "2020-07",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.GetGroupBudgetStatus(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &model.GroupBudgetStatus{}, &model.GroupBudgetStatus{})
+}
+
+func TestDBHandler_GetGroupBudgetForecast(t *testing.T) {
+	h := DBHandler{
+		AuthRepo:              MockAuthRepository{},
+		GroupBudgetsRepo:      MockGroupBudgetsRepository{},
+		GroupTransactionsRepo: MockGroupTransactionsRepository{},
+		TimeManage:            MockTime{},
+	}
+
+	r := httptest.NewRequest("GET", "/groups/1/budgets/forecast?method=linear", nil)
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"group_id": "1",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.GetGroupBudgetForecast(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &model.GroupBudgetForecast{}, &model.GroupBudgetForecast{})
+}
+
+func TestDBHandler_GetGroupBudgetRolloverSettings(t *testing.T) {
+	h := DBHandler{
+		AuthRepo:         MockAuthRepository{},
+		GroupBudgetsRepo: MockGroupBudgetsRepository{},
+	}
+
+	r := httptest.NewRequest("GET", "/groups/1/standard-budgets/rollover", nil)
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"group_id": "1",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.GetGroupBudgetRolloverSettings(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &model.GroupBudgetRolloverSettings{}, &model.GroupBudgetRolloverSettings{})
+}
+
+func TestDBHandler_PutGroupBudgetRolloverSettings(t *testing.T) {
+	h := DBHandler{
+		AuthRepo:         MockAuthRepository{},
+		GroupBudgetsRepo: MockGroupBudgetsRepository{},
+		CategoriesRepo:   MockCategoriesRepository{},
+		TimeManage:       MockTime{},
+	}
+
+	r := httptest.NewRequest("PUT", "/groups/1/standard-budgets/rollover", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"group_id": "1",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.PutGroupBudgetRolloverSettings(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &model.GroupBudgetRolloverSettings{}, &model.GroupBudgetRolloverSettings{})
+}
+
+func TestDBHandler_CopyGroupCustomBudgets(t *testing.T) {
+	h := DBHandler{
+		AuthRepo:         MockAuthRepository{},
+		GroupBudgetsRepo: MockGroupBudgetsRepository{},
+	}
+
+	r := httptest.NewRequest("POST", "/groups/1/custom-budgets/2020-07/copy", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"group_id":   "1",
+		"year_month": "2020-07",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.CopyGroupCustomBudgets(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusCreated)
+	testutil.AssertResponseBody(t, res, &model.BudgetYearMonths{}, &model.BudgetYearMonths{})
+}
+
+func TestDBHandler_CopyStandardBudgetsToGroup(t *testing.T) {
+	h := DBHandler{
+		AuthRepo:            MockAuthRepository{},
+		GroupBudgetsRepo:    MockGroupBudgetsRepository{},
+		BudgetsRepo:         MockBudgetsRepository{},
+		GroupCategoriesRepo: MockGroupCategoriesRepository{},
+	}
+
+	r := httptest.NewRequest("PUT", "/groups/1/standard-budgets/copy-from-personal", nil)
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"group_id": "1",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.CopyStandardBudgetsToGroup(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &CopyContentMsg{}, &CopyContentMsg{})
+}
+
+func TestDBHandler_CopyCustomBudgetsToGroup(t *testing.T) {
+	h := DBHandler{
+		AuthRepo:            MockAuthRepository{},
+		GroupBudgetsRepo:    MockGroupBudgetsRepository{},
+		BudgetsRepo:         MockBudgetsRepository{},
+		GroupCategoriesRepo: MockGroupCategoriesRepository{},
+	}
+
+	r := httptest.NewRequest("POST", "/groups/1/custom-budgets/2020-07/copy-from-personal", nil)
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"group_id":   "1",
+		"year_month": "2020-07",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.CopyCustomBudgetsToGroup(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusCreated)
+	testutil.AssertResponseBody(t, res, &model.BudgetYearMonths{}, &model.BudgetYearMonths{})
+}
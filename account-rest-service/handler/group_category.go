@@ -54,18 +54,51 @@ func validateGroupCustomCategory(r *http.Request, groupCustomCategory *model.Gro
 	return nil
 }
 
-func putGroupShoppingListCustomCategoryIdToMediumCategoryId(mediumCategoryID int, customCategoryID int, groupID int) error {
+// selectCustomCategoriesToCopy picks the custom categories to copy in the order of the id list.
+// Duplicated ids are copied only once.
+func selectCustomCategoriesToCopy(customCategoryIDList []int, customCategoriesList []model.AssociatedCategory) ([]model.AssociatedCategory, error) {
+	if len(customCategoryIDList) == 0 {
+		return nil, &BadRequestErrorMsg{"コピーするカスタムカテゴリーが選択されていません。"}
+	}
+
+	customCategoriesMap := make(map[int]model.AssociatedCategory, len(customCategoriesList))
+	for _, customCategory := range customCategoriesList {
+		customCategoriesMap[customCategory.ID] = customCategory
+	}
+
+	selectedCustomCategoriesList := make([]model.AssociatedCategory, 0, len(customCategoryIDList))
+	selectedCustomCategoryIDs := make(map[int]bool, len(customCategoryIDList))
+	for _, customCategoryID := range customCategoryIDList {
+		customCategory, ok := customCategoriesMap[customCategoryID]
+		if !ok {
+			return nil, &BadRequestErrorMsg{"コピーするカスタムカテゴリーを正しく選択してください。"}
+		}
+
+		if selectedCustomCategoryIDs[customCategoryID] {
+			continue
+		}
+
+		selectedCustomCategoryIDs[customCategoryID] = true
+		selectedCustomCategoriesList = append(selectedCustomCategoriesList, customCategory)
+	}
+
+	return selectedCustomCategoriesList, nil
+}
+
+func putGroupShoppingListCustomCategoryIdToReplaceCategoryId(replaceCategoriesID model.CategoriesID, customCategoryID int, groupID int) error {
 	requestURL := fmt.Sprintf(
 		"http://%s:%d/groups/%d/shopping-list/categories",
 		config.Env.TodoApi.Host, config.Env.TodoApi.Port, groupID,
 	)
 
 	categoriesID := struct {
-		MediumCategoryID int `json:"medium_category_id"`
-		CustomCategoryID int `json:"custom_category_id"`
+		MediumCategoryID        model.NullInt64 `json:"medium_category_id"`
+		CustomCategoryID        int             `json:"custom_category_id"`
+		ReplaceCustomCategoryID model.NullInt64 `json:"replace_custom_category_id"`
 	}{
-		MediumCategoryID: mediumCategoryID,
-		CustomCategoryID: customCategoryID,
+		MediumCategoryID:        replaceCategoriesID.MediumCategoryID,
+		CustomCategoryID:        customCategoryID,
+		ReplaceCustomCategoryID: replaceCategoriesID.CustomCategoryID,
 	}
 
 	requestBody, err := json.Marshal(&categoriesID)
@@ -120,49 +153,67 @@ func (h *DBHandler) GetGroupCategoriesList(w http.ResponseWriter, r *http.Reques
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	groupBigCategoriesList, err := h.GroupCategoriesRepo.GetGroupBigCategoriesList()
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupMediumCategoriesList, err := h.GroupCategoriesRepo.GetGroupMediumCategoriesList()
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupCustomCategoriesList, err := h.GroupCategoriesRepo.GetGroupCustomCategoriesList(groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
+	if locale := requestLocale(r.Context()); locale != defaultLocale {
+		categoryTranslationsList, err := h.GroupCategoriesRepo.GetGroupCategoryTranslationsList(locale)
+		if err != nil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		categoryTranslations := model.NewCategoryTranslations(categoryTranslationsList)
+
+		for i, groupBigCategory := range groupBigCategoriesList {
+			groupBigCategoriesList[i].Name = categoryTranslations.TranslateBigCategoryName(groupBigCategory.ID, groupBigCategory.Name)
+		}
+
+		for i, groupMediumCategory := range groupMediumCategoriesList {
+			groupMediumCategoriesList[i].Name = categoryTranslations.TranslateMediumCategoryName(groupMediumCategory.ID, groupMediumCategory.Name)
+		}
+	}
+
 	for i, groupBigCategory := range groupBigCategoriesList {
 		for _, groupCustomCategory := range groupCustomCategoriesList {
 			if groupBigCategory.TransactionType == "income" && groupBigCategory.ID == groupCustomCategory.BigCategoryID {
@@ -192,73 +243,68 @@ func (h *DBHandler) GetGroupCategoriesList(w http.ResponseWriter, r *http.Reques
 		}
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(&groupCategoriesList); err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
+	responseByJSONWithETag(w, r, &groupCategoriesList)
 }
 
 func (h *DBHandler) PostGroupCustomCategory(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	groupCustomCategory := model.NewGroupCustomCategory()
 	if err := json.NewDecoder(r.Body).Decode(&groupCustomCategory); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if err := validateGroupCustomCategory(r, &groupCustomCategory); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, err))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
 		return
 	}
 
 	if err := h.GroupCategoriesRepo.FindGroupCustomCategory(&groupCustomCategory, groupID); err != sql.ErrNoRows {
 		if err == nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusConflict, &ConflictErrorMsg{"中カテゴリーの登録に失敗しました。 同じカテゴリー名が既に存在していないか確認してください。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusConflict, &ConflictErrorMsg{"中カテゴリーの登録に失敗しました。 同じカテゴリー名が既に存在していないか確認してください。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	result, err := h.GroupCategoriesRepo.PostGroupCustomCategory(&groupCustomCategory, groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	lastInsertId, err := result.LastInsertId()
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -276,60 +322,60 @@ func (h *DBHandler) PutGroupCustomCategory(w http.ResponseWriter, r *http.Reques
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	groupCustomCategory := model.NewGroupCustomCategory()
 	if err := json.NewDecoder(r.Body).Decode(&groupCustomCategory); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupCustomCategory.ID, err = strconv.Atoi(mux.Vars(r)["id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"custom category ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"custom category ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := validateGroupCustomCategory(r, &groupCustomCategory); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, err))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
 		return
 	}
 
 	if err := h.GroupCategoriesRepo.FindGroupCustomCategory(&groupCustomCategory, groupID); err != sql.ErrNoRows {
 		if err == nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusConflict, &ConflictErrorMsg{"中カテゴリーの更新に失敗しました。 同じカテゴリー名が既に存在していないか確認してください。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusConflict, &ConflictErrorMsg{"中カテゴリーの更新に失敗しました。 同じカテゴリー名が既に存在していないか確認してください。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if err := h.GroupCategoriesRepo.PutGroupCustomCategory(&groupCustomCategory); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -341,81 +387,229 @@ func (h *DBHandler) PutGroupCustomCategory(w http.ResponseWriter, r *http.Reques
 	}
 }
 
+// getGroupReplaceCategoriesID returns the category chosen by the user to replace the deleted group custom category,
+// or the fallback medium category of the big category if none is chosen.
+func (h *DBHandler) getGroupReplaceCategoriesID(r *http.Request, groupCustomCategoryID int, bigCategoryID int, groupID int) (model.CategoriesID, error) {
+	replaceCategoriesID, err := parseReplaceCategoriesID(r)
+	if err != nil {
+		return replaceCategoriesID, err
+	}
+
+	var replaceBigCategoryID int
+	switch {
+	case replaceCategoriesID.MediumCategoryID.Valid:
+		replaceBigCategoryID, err = h.CategoriesRepo.GetMediumCategoryBigCategoryID(int(replaceCategoriesID.MediumCategoryID.Int64))
+	case replaceCategoriesID.CustomCategoryID.Valid:
+		if int(replaceCategoriesID.CustomCategoryID.Int64) == groupCustomCategoryID {
+			return replaceCategoriesID, &BadRequestErrorMsg{"置き換え先には削除するカスタムカテゴリー以外を指定してください。"}
+		}
+
+		replaceBigCategoryID, err = h.GroupCategoriesRepo.GetGroupCustomCategoryBigCategoryID(int(replaceCategoriesID.CustomCategoryID.Int64), groupID)
+	default:
+		mediumCategoryID, err := h.CategoriesRepo.GetFallbackMediumCategoryID(bigCategoryID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return replaceCategoriesID, &NotFoundErrorMsg{"大カテゴリーに関連する中カテゴリーが見つかりませんでした。"}
+			}
+
+			return replaceCategoriesID, err
+		}
+
+		replaceCategoriesID.MediumCategoryID = model.NullInt64{NullInt64: sql.NullInt64{Int64: int64(mediumCategoryID), Valid: true}}
+
+		return replaceCategoriesID, nil
+	}
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return replaceCategoriesID, &BadRequestErrorMsg{"置き換え先のカテゴリーを正しく指定してください。"}
+		}
+
+		return replaceCategoriesID, err
+	}
+
+	if replaceBigCategoryID != bigCategoryID {
+		return replaceCategoriesID, &BadRequestErrorMsg{"置き換え先は削除するカスタムカテゴリーと同じ大カテゴリーのカテゴリーを指定してください。"}
+	}
+
+	return replaceCategoriesID, nil
+}
+
 func (h *DBHandler) DeleteGroupCustomCategory(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	groupCustomCategoryID, err := strconv.Atoi(mux.Vars(r)["id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"custom category ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"custom category ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := h.GroupCategoriesRepo.FindGroupCustomCategoryID(groupCustomCategoryID); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"指定されたカスタムカテゴリーは既に削除されています。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"指定されたカスタムカテゴリーは既に削除されています。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	bigCategoryID, err := h.GroupCategoriesRepo.GetBigCategoryID(groupCustomCategoryID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			errorResponseByJSON(w, NewHTTPError(http.StatusNotFound, &NotFoundErrorMsg{"カスタムカテゴリーに関連する大カテゴリーが見つかりませんでした。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusNotFound, &NotFoundErrorMsg{"カスタムカテゴリーに関連する大カテゴリーが見つかりませんでした。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	mediumCategoryID, err := replaceMediumCategoryID(bigCategoryID)
+	replaceCategoriesID, err := h.getGroupReplaceCategoriesID(r, groupCustomCategoryID, bigCategoryID, groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusNotFound, err))
+		if badRequestErrorMsg, ok := err.(*BadRequestErrorMsg); ok {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+			return
+		}
+
+		if notFoundErrorMsg, ok := err.(*NotFoundErrorMsg); ok {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusNotFound, notFoundErrorMsg))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	if err := h.GroupCategoriesRepo.DeleteGroupCustomCategory(groupCustomCategoryID, mediumCategoryID); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+	if err := h.GroupCategoriesRepo.DeleteGroupCustomCategory(groupCustomCategoryID, replaceCategoriesID); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	if err := putGroupShoppingListCustomCategoryIdToMediumCategoryId(mediumCategoryID, groupCustomCategoryID, groupID); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+	if err := putGroupShoppingListCustomCategoryIdToReplaceCategoryId(replaceCategoriesID, groupCustomCategoryID, groupID); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(&DeleteContentMsg{"カスタムカテゴリーを削除しました。"}); err != nil {
+	if err := json.NewEncoder(w).Encode(&DeleteContentMsg{localizeMessage(r.Context(), "カスタムカテゴリーを削除しました。")}); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) CopyCustomCategoriesToGroup(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		return
+	}
+
+	if err := verifyGroupAffiliation(groupID, userID); err != nil {
+		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
+		if !ok {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		return
+	}
+
+	var groupCustomCategoriesCopy model.GroupCustomCategoriesCopy
+	if err := json.NewDecoder(r.Body).Decode(&groupCustomCategoriesCopy); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	customCategoriesList, err := h.CategoriesRepo.GetCustomCategoriesList(userID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	selectedCustomCategoriesList, err := selectCustomCategoriesToCopy(groupCustomCategoriesCopy.CustomCategoryIDList, customCategoriesList)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
+		return
+	}
+
+	copiedGroupCustomCategories := model.NewCopiedGroupCustomCategories()
+	for _, customCategory := range selectedCustomCategoriesList {
+		groupCustomCategory := model.NewGroupCustomCategory()
+		groupCustomCategory.Name = customCategory.Name
+		groupCustomCategory.BigCategoryID = customCategory.BigCategoryID
+
+		if err := h.GroupCategoriesRepo.FindGroupCustomCategory(&groupCustomCategory, groupID); err != sql.ErrNoRows {
+			if err == nil {
+				skippedCustomCategory := model.NewCustomCategory()
+				skippedCustomCategory.ID = customCategory.ID
+				skippedCustomCategory.Name = customCategory.Name
+				skippedCustomCategory.BigCategoryID = customCategory.BigCategoryID
+
+				copiedGroupCustomCategories.SkippedCustomCategoriesList = append(copiedGroupCustomCategories.SkippedCustomCategoriesList, skippedCustomCategory)
+				continue
+			}
+
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		copiedGroupCustomCategories.CreatedGroupCustomCategoriesList = append(copiedGroupCustomCategories.CreatedGroupCustomCategoriesList, groupCustomCategory)
+	}
+
+	if len(copiedGroupCustomCategories.CreatedGroupCustomCategoriesList) != 0 {
+		if err := h.GroupCategoriesRepo.PostGroupCustomCategoriesList(copiedGroupCustomCategories.CreatedGroupCustomCategoriesList, groupID); err != nil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	if len(copiedGroupCustomCategories.CreatedGroupCustomCategoriesList) == 0 {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusCreated)
+	}
+	if err := json.NewEncoder(w).Encode(&copiedGroupCustomCategories); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -424,7 +618,7 @@ func (h *DBHandler) DeleteGroupCustomCategory(w http.ResponseWriter, r *http.Req
 func (h *DBHandler) GetGroupCategoriesName(w http.ResponseWriter, r *http.Request) {
 	var categoriesID model.CategoriesID
 	if err := json.NewDecoder(r.Body).Decode(&categoriesID); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -434,18 +628,23 @@ func (h *DBHandler) GetGroupCategoriesName(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(&categoriesName); err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		return
+	if locale := requestLocale(r.Context()); locale != defaultLocale {
+		categoryTranslationsList, err := h.GroupCategoriesRepo.GetGroupCategoryTranslationsList(locale)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		model.NewCategoryTranslations(categoryTranslationsList).TranslateCategoriesName(categoriesName)
 	}
+
+	responseByJSONWithETag(w, r, &categoriesName)
 }
 
 func (h *DBHandler) GetGroupCategoriesNameList(w http.ResponseWriter, r *http.Request) {
 	var categoriesIDList []model.CategoriesID
 	if err := json.NewDecoder(r.Body).Decode(&categoriesIDList); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -455,10 +654,18 @@ func (h *DBHandler) GetGroupCategoriesNameList(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(&categoriesNameList); err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		return
+	if locale := requestLocale(r.Context()); locale != defaultLocale {
+		categoryTranslationsList, err := h.GroupCategoriesRepo.GetGroupCategoryTranslationsList(locale)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		categoryTranslations := model.NewCategoryTranslations(categoryTranslationsList)
+		for i := range categoriesNameList {
+			categoryTranslations.TranslateCategoriesName(&categoriesNameList[i])
+		}
 	}
+
+	responseByJSONWithETag(w, r, &categoriesNameList)
 }
@@ -158,6 +158,10 @@ func (m MockGroupCategoriesRepository) GetGroupCustomCategoriesList(groupID int)
 }
 
 func (m MockGroupCategoriesRepository) FindGroupCustomCategory(groupCustomCategory *model.GroupCustomCategory, groupID int) error {
+	if groupCustomCategory.Name == "米" {
+		return nil
+	}
+
 	return sql.ErrNoRows
 }
 
@@ -165,6 +169,14 @@ func (m MockGroupCategoriesRepository) PostGroupCustomCategory(groupCustomCatego
 	return MockSqlResult{}, nil
 }
 
+func (m MockGroupCategoriesRepository) PostGroupCustomCategoriesList(groupCustomCategoriesList []model.GroupCustomCategory, groupID int) error {
+	for i := range groupCustomCategoriesList {
+		groupCustomCategoriesList[i].ID = i + 1
+	}
+
+	return nil
+}
+
 func (m MockGroupCategoriesRepository) PutGroupCustomCategory(groupCustomCategory *model.GroupCustomCategory) error {
 	return nil
 }
@@ -177,13 +189,19 @@ func (m MockGroupCategoriesRepository) GetBigCategoryID(groupCustomCategoryID in
 	return 2, nil
 }
 
-func (m MockGroupCategoriesRepository) DeleteGroupCustomCategory(previousGroupCustomCategoryID int, replaceMediumCategoryID int) error {
+func (m MockGroupCategoriesRepository) GetGroupCustomCategoryBigCategoryID(groupCustomCategoryID int, groupID int) (int, error) {
+	return 2, nil
+}
+
+func (m MockGroupCategoriesRepository) DeleteGroupCustomCategory(previousGroupCustomCategoryID int, replaceCategoriesID model.CategoriesID) error {
 	return nil
 }
 
 func (m MockGroupCategoriesRepository) GetGroupCategoriesName(categoriesID model.CategoriesID) (*model.CategoriesName, error) {
 	return &model.CategoriesName{
+		BigCategoryID:      2,
 		BigCategoryName:    model.NullString{NullString: sql.NullString{String: "食費", Valid: true}},
+		MediumCategoryID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 6, Valid: true}},
 		MediumCategoryName: model.NullString{NullString: sql.NullString{String: "食料品", Valid: true}},
 		CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
 	}, nil
@@ -192,18 +210,142 @@ func (m MockGroupCategoriesRepository) GetGroupCategoriesName(categoriesID model
 func (m MockGroupCategoriesRepository) GetGroupCategoriesNameList(categoriesIDList []model.CategoriesID) ([]model.CategoriesName, error) {
 	return []model.CategoriesName{
 		{
+			BigCategoryID:      2,
 			BigCategoryName:    model.NullString{NullString: sql.NullString{String: "食費", Valid: true}},
 			MediumCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
 			CustomCategoryName: model.NullString{NullString: sql.NullString{String: "米", Valid: true}},
 		},
 		{
+			BigCategoryID:      3,
 			BigCategoryName:    model.NullString{NullString: sql.NullString{String: "日用品", Valid: true}},
+			MediumCategoryID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 13, Valid: true}},
 			MediumCategoryName: model.NullString{NullString: sql.NullString{String: "消耗品", Valid: true}},
 			CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
 		},
 	}, nil
 }
 
+func (m MockGroupCategoriesRepository) GetGroupCategoryTranslationsList(locale string) ([]model.CategoryTranslation, error) {
+	return []model.CategoryTranslation{
+		{CategoryType: "BigCategory", ID: 1, TranslatedName: "Income"},
+		{CategoryType: "BigCategory", ID: 2, TranslatedName: "Food"},
+		{CategoryType: "BigCategory", ID: 3, TranslatedName: "Daily Goods"},
+		{CategoryType: "BigCategory", ID: 4, TranslatedName: "Hobbies"},
+		{CategoryType: "BigCategory", ID: 5, TranslatedName: "Socializing"},
+		{CategoryType: "BigCategory", ID: 6, TranslatedName: "Transportation"},
+		{CategoryType: "BigCategory", ID: 7, TranslatedName: "Clothing & Beauty"},
+		{CategoryType: "BigCategory", ID: 8, TranslatedName: "Health & Medical"},
+		{CategoryType: "BigCategory", ID: 9, TranslatedName: "Communication"},
+		{CategoryType: "BigCategory", ID: 10, TranslatedName: "Education"},
+		{CategoryType: "BigCategory", ID: 11, TranslatedName: "Housing"},
+		{CategoryType: "BigCategory", ID: 12, TranslatedName: "Utilities"},
+		{CategoryType: "BigCategory", ID: 13, TranslatedName: "Car"},
+		{CategoryType: "BigCategory", ID: 14, TranslatedName: "Insurance"},
+		{CategoryType: "BigCategory", ID: 15, TranslatedName: "Taxes & Social Security"},
+		{CategoryType: "BigCategory", ID: 16, TranslatedName: "Cash & Card"},
+		{CategoryType: "BigCategory", ID: 17, TranslatedName: "Other"},
+		{CategoryType: "MediumCategory", ID: 1, TranslatedName: "Salary"},
+		{CategoryType: "MediumCategory", ID: 2, TranslatedName: "Bonus"},
+		{CategoryType: "MediumCategory", ID: 3, TranslatedName: "Temporary Income"},
+		{CategoryType: "MediumCategory", ID: 4, TranslatedName: "Business Income"},
+		{CategoryType: "MediumCategory", ID: 5, TranslatedName: "Other Income"},
+		{CategoryType: "MediumCategory", ID: 6, TranslatedName: "Groceries"},
+		{CategoryType: "MediumCategory", ID: 7, TranslatedName: "Breakfast"},
+		{CategoryType: "MediumCategory", ID: 8, TranslatedName: "Lunch"},
+		{CategoryType: "MediumCategory", ID: 9, TranslatedName: "Dinner"},
+		{CategoryType: "MediumCategory", ID: 10, TranslatedName: "Eating Out"},
+		{CategoryType: "MediumCategory", ID: 11, TranslatedName: "Cafe"},
+		{CategoryType: "MediumCategory", ID: 12, TranslatedName: "Other Food"},
+		{CategoryType: "MediumCategory", ID: 13, TranslatedName: "Consumables"},
+		{CategoryType: "MediumCategory", ID: 14, TranslatedName: "Childcare Goods"},
+		{CategoryType: "MediumCategory", ID: 15, TranslatedName: "Pet Supplies"},
+		{CategoryType: "MediumCategory", ID: 16, TranslatedName: "Furniture"},
+		{CategoryType: "MediumCategory", ID: 17, TranslatedName: "Home Appliances"},
+		{CategoryType: "MediumCategory", ID: 18, TranslatedName: "Other Daily Goods"},
+		{CategoryType: "MediumCategory", ID: 19, TranslatedName: "Outdoor"},
+		{CategoryType: "MediumCategory", ID: 20, TranslatedName: "Travel"},
+		{CategoryType: "MediumCategory", ID: 21, TranslatedName: "Events"},
+		{CategoryType: "MediumCategory", ID: 22, TranslatedName: "Sports"},
+		{CategoryType: "MediumCategory", ID: 23, TranslatedName: "Movies & Videos"},
+		{CategoryType: "MediumCategory", ID: 24, TranslatedName: "Music"},
+		{CategoryType: "MediumCategory", ID: 25, TranslatedName: "Comics"},
+		{CategoryType: "MediumCategory", ID: 26, TranslatedName: "Books"},
+		{CategoryType: "MediumCategory", ID: 27, TranslatedName: "Games"},
+		{CategoryType: "MediumCategory", ID: 28, TranslatedName: "Other Hobbies"},
+		{CategoryType: "MediumCategory", ID: 29, TranslatedName: "Drinking Parties"},
+		{CategoryType: "MediumCategory", ID: 30, TranslatedName: "Gifts"},
+		{CategoryType: "MediumCategory", ID: 31, TranslatedName: "Ceremonies"},
+		{CategoryType: "MediumCategory", ID: 32, TranslatedName: "Other Socializing"},
+		{CategoryType: "MediumCategory", ID: 33, TranslatedName: "Train"},
+		{CategoryType: "MediumCategory", ID: 34, TranslatedName: "Bus"},
+		{CategoryType: "MediumCategory", ID: 35, TranslatedName: "Taxi"},
+		{CategoryType: "MediumCategory", ID: 36, TranslatedName: "Shinkansen"},
+		{CategoryType: "MediumCategory", ID: 37, TranslatedName: "Airplane"},
+		{CategoryType: "MediumCategory", ID: 38, TranslatedName: "Other Transportation"},
+		{CategoryType: "MediumCategory", ID: 39, TranslatedName: "Clothes"},
+		{CategoryType: "MediumCategory", ID: 40, TranslatedName: "Accessories"},
+		{CategoryType: "MediumCategory", ID: 41, TranslatedName: "Dry Cleaning"},
+		{CategoryType: "MediumCategory", ID: 42, TranslatedName: "Hair Salon"},
+		{CategoryType: "MediumCategory", ID: 43, TranslatedName: "Cosmetics"},
+		{CategoryType: "MediumCategory", ID: 44, TranslatedName: "Spa & Nails"},
+		{CategoryType: "MediumCategory", ID: 45, TranslatedName: "Other Clothing & Beauty"},
+		{CategoryType: "MediumCategory", ID: 46, TranslatedName: "Hospital"},
+		{CategoryType: "MediumCategory", ID: 47, TranslatedName: "Medicine"},
+		{CategoryType: "MediumCategory", ID: 48, TranslatedName: "Body Care"},
+		{CategoryType: "MediumCategory", ID: 49, TranslatedName: "Fitness"},
+		{CategoryType: "MediumCategory", ID: 50, TranslatedName: "Other Health & Medical"},
+		{CategoryType: "MediumCategory", ID: 51, TranslatedName: "Mobile Phone"},
+		{CategoryType: "MediumCategory", ID: 52, TranslatedName: "Landline"},
+		{CategoryType: "MediumCategory", ID: 53, TranslatedName: "Internet"},
+		{CategoryType: "MediumCategory", ID: 54, TranslatedName: "Broadcasting"},
+		{CategoryType: "MediumCategory", ID: 55, TranslatedName: "Information Services"},
+		{CategoryType: "MediumCategory", ID: 56, TranslatedName: "Delivery & Shipping"},
+		{CategoryType: "MediumCategory", ID: 57, TranslatedName: "Stamps & Postcards"},
+		{CategoryType: "MediumCategory", ID: 58, TranslatedName: "Other Communication"},
+		{CategoryType: "MediumCategory", ID: 59, TranslatedName: "Newspaper"},
+		{CategoryType: "MediumCategory", ID: 60, TranslatedName: "Reference Books"},
+		{CategoryType: "MediumCategory", ID: 61, TranslatedName: "Exam Fees"},
+		{CategoryType: "MediumCategory", ID: 62, TranslatedName: "Tuition"},
+		{CategoryType: "MediumCategory", ID: 63, TranslatedName: "Lessons"},
+		{CategoryType: "MediumCategory", ID: 64, TranslatedName: "Cram School"},
+		{CategoryType: "MediumCategory", ID: 65, TranslatedName: "Other Education"},
+		{CategoryType: "MediumCategory", ID: 66, TranslatedName: "Rent"},
+		{CategoryType: "MediumCategory", ID: 67, TranslatedName: "Mortgage"},
+		{CategoryType: "MediumCategory", ID: 68, TranslatedName: "Renovation"},
+		{CategoryType: "MediumCategory", ID: 69, TranslatedName: "Other Housing"},
+		{CategoryType: "MediumCategory", ID: 70, TranslatedName: "Water"},
+		{CategoryType: "MediumCategory", ID: 71, TranslatedName: "Electricity"},
+		{CategoryType: "MediumCategory", ID: 72, TranslatedName: "Gas"},
+		{CategoryType: "MediumCategory", ID: 73, TranslatedName: "Other Utilities"},
+		{CategoryType: "MediumCategory", ID: 74, TranslatedName: "Car Loan"},
+		{CategoryType: "MediumCategory", ID: 75, TranslatedName: "Gasoline"},
+		{CategoryType: "MediumCategory", ID: 76, TranslatedName: "Parking"},
+		{CategoryType: "MediumCategory", ID: 77, TranslatedName: "Highway Tolls"},
+		{CategoryType: "MediumCategory", ID: 78, TranslatedName: "Car Inspection"},
+		{CategoryType: "MediumCategory", ID: 79, TranslatedName: "Other Car"},
+		{CategoryType: "MediumCategory", ID: 80, TranslatedName: "Life Insurance"},
+		{CategoryType: "MediumCategory", ID: 81, TranslatedName: "Medical Insurance"},
+		{CategoryType: "MediumCategory", ID: 82, TranslatedName: "Car Insurance"},
+		{CategoryType: "MediumCategory", ID: 83, TranslatedName: "Home Insurance"},
+		{CategoryType: "MediumCategory", ID: 84, TranslatedName: "Education Insurance"},
+		{CategoryType: "MediumCategory", ID: 85, TranslatedName: "Other Insurance"},
+		{CategoryType: "MediumCategory", ID: 86, TranslatedName: "Income Tax"},
+		{CategoryType: "MediumCategory", ID: 87, TranslatedName: "Resident Tax"},
+		{CategoryType: "MediumCategory", ID: 88, TranslatedName: "Pension Premiums"},
+		{CategoryType: "MediumCategory", ID: 89, TranslatedName: "Car Tax"},
+		{CategoryType: "MediumCategory", ID: 90, TranslatedName: "Other Taxes"},
+		{CategoryType: "MediumCategory", ID: 91, TranslatedName: "Cash Withdrawal"},
+		{CategoryType: "MediumCategory", ID: 92, TranslatedName: "Card Payment"},
+		{CategoryType: "MediumCategory", ID: 93, TranslatedName: "E-Money"},
+		{CategoryType: "MediumCategory", ID: 94, TranslatedName: "Advance Payment"},
+		{CategoryType: "MediumCategory", ID: 95, TranslatedName: "Other Cash & Card"},
+		{CategoryType: "MediumCategory", ID: 96, TranslatedName: "Allowance to Family"},
+		{CategoryType: "MediumCategory", ID: 97, TranslatedName: "Pocket Money"},
+		{CategoryType: "MediumCategory", ID: 98, TranslatedName: "Unknown Expenses"},
+		{CategoryType: "MediumCategory", ID: 99, TranslatedName: "Miscellaneous"},
+	}, nil
+}
+
 func TestDBHandler_GetGroupCategoriesList(t *testing.T) {
 	h := DBHandler{
 		AuthRepo:            MockAuthRepository{},
@@ -233,6 +375,37 @@ func TestDBHandler_GetGroupCategoriesList(t *testing.T) {
 	testutil.AssertResponseBody(t, res, &model.GroupCategoriesList{}, &model.GroupCategoriesList{})
 }
 
+func TestDBHandler_GetGroupCategoriesListInEnglish(t *testing.T) {
+	h := DBHandler{
+		AuthRepo:            MockAuthRepository{},
+		GroupCategoriesRepo: MockGroupCategoriesRepository{},
+	}
+
+	r := httptest.NewRequest("GET", "/groups/1/categories", nil)
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"group_id": "1",
+	})
+
+	r.Header.Set("Accept-Language", "en")
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	LocaleMiddleware(http.HandlerFunc(h.GetGroupCategoriesList)).ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &model.GroupCategoriesList{}, &model.GroupCategoriesList{})
+}
+
 func TestDBHandler_PostGroupCustomCategory(t *testing.T) {
 	h := DBHandler{
 		AuthRepo:            MockAuthRepository{},
@@ -320,6 +493,7 @@ func TestDBHandler_DeleteGroupCustomCategory(t *testing.T) {
 
 	h := DBHandler{
 		AuthRepo:            MockAuthRepository{},
+		CategoriesRepo:      MockCategoriesRepository{},
 		GroupCategoriesRepo: MockGroupCategoriesRepository{},
 	}
 
@@ -347,6 +521,92 @@ func TestDBHandler_DeleteGroupCustomCategory(t *testing.T) {
 	testutil.AssertResponseBody(t, res, &DeleteContentMsg{}, &DeleteContentMsg{})
 }
 
+func TestDBHandler_DeleteGroupCustomCategoryWithReplaceCategory(t *testing.T) {
+	todoHostURL := fmt.Sprintf(
+		"%s:%d",
+		config.Env.TodoApi.Host, config.Env.TodoApi.Port,
+	)
+
+	mockGroupPutShoppingListCustomCategoryIdToMediumCategoryId := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/groups/{group_id:[0-9]+}/shopping-list/categories", mockGroupPutShoppingListCustomCategoryIdToMediumCategoryId).Methods("PUT")
+
+	listener, err := net.Listen("tcp", todoHostURL)
+	if err != nil {
+		t.Fatalf("unexpected error by net.Listen() '%#v'", err)
+	}
+
+	ts := httptest.Server{
+		Listener: listener,
+		Config:   &http.Server{Handler: router},
+	}
+
+	ts.Start()
+	defer ts.Close()
+
+	h := DBHandler{
+		AuthRepo:            MockAuthRepository{},
+		CategoriesRepo:      MockCategoriesRepository{},
+		GroupCategoriesRepo: MockGroupCategoriesRepository{},
+	}
+
+	r := httptest.NewRequest("DELETE", "/groups/1/categories/custom-categories/1?medium_category_id=6", nil)
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"group_id": "1",
+		"id":       "1",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.DeleteGroupCustomCategory(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &DeleteContentMsg{}, &DeleteContentMsg{})
+}
+
+func TestDBHandler_CopyCustomCategoriesToGroup(t *testing.T) {
+	h := DBHandler{
+		AuthRepo:            MockAuthRepository{},
+		CategoriesRepo:      MockCategoriesRepository{},
+		GroupCategoriesRepo: MockGroupCategoriesRepository{},
+	}
+
+	r := httptest.NewRequest("POST", "/groups/1/categories/custom-categories/copy-from-personal", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"group_id": "1",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.CopyCustomCategoriesToGroup(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusCreated)
+	testutil.AssertResponseBody(t, res, &model.CopiedGroupCustomCategories{}, &model.CopiedGroupCustomCategories{})
+}
+
 func TestDBHandler_GetGroupCategoriesName(t *testing.T) {
 	h := DBHandler{
 		AuthRepo:            MockAuthRepository{},
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"log"
 	"math"
 	"net"
 	"net/http"
@@ -21,6 +22,7 @@ import (
 
 	"github.com/hryze/kakeibo-app-api/account-rest-service/config"
 	"github.com/hryze/kakeibo-app-api/account-rest-service/domain/model"
+	"github.com/hryze/kakeibo-app-api/account-rest-service/domain/repository"
 )
 
 type GroupTransactionsSearchQuery struct {
@@ -357,34 +359,34 @@ func (h *DBHandler) GetMonthlyGroupTransactionsList(w http.ResponseWriter, r *ht
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	firstDay, err := time.Parse("2006-01", mux.Vars(r)["year_month"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
 		return
 	}
 
@@ -392,14 +394,14 @@ func (h *DBHandler) GetMonthlyGroupTransactionsList(w http.ResponseWriter, r *ht
 
 	dbGroupTransactionsList, err := h.GroupTransactionsRepo.GetMonthlyGroupTransactionsList(groupID, firstDay, lastDay)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if len(dbGroupTransactionsList) == 0 {
 		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 		w.WriteHeader(http.StatusOK)
-		if err := json.NewEncoder(w).Encode(&NoContentMsg{"条件に一致する取引履歴は見つかりませんでした。"}); err != nil {
+		if err := json.NewEncoder(w).Encode(&NoContentMsg{localizeMessage(r.Context(), "条件に一致する取引履歴は見つかりませんでした。")}); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
@@ -421,41 +423,41 @@ func (h *DBHandler) Get10LatestGroupTransactionsList(w http.ResponseWriter, r *h
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	latestGroupTransactionsList, err := h.GroupTransactionsRepo.Get10LatestGroupTransactionsList(groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if len(latestGroupTransactionsList.GroupTransactionsList) == 0 {
 		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 		w.WriteHeader(http.StatusOK)
-		if err := json.NewEncoder(w).Encode(&NoContentMsg{"取引履歴がありません。"}); err != nil {
+		if err := json.NewEncoder(w).Encode(&NoContentMsg{localizeMessage(r.Context(), "取引履歴がありません。")}); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
@@ -471,77 +473,139 @@ func (h *DBHandler) Get10LatestGroupTransactionsList(w http.ResponseWriter, r *h
 	}
 }
 
+// getGroupTransactionByIdempotencyKey returns the group transaction posted by the first request with the idempotency key.
+func getGroupTransactionByIdempotencyKey(h *DBHandler, idempotencyKey string, groupID int) (*model.GroupTransactionSender, error) {
+	groupTransactionID, err := h.GroupTransactionsRepo.GetGroupTransactionIDByIdempotencyKey(idempotencyKey, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.GroupTransactionsRepo.GetGroupTransaction(groupTransactionID)
+}
+
 func (h *DBHandler) PostGroupTransaction(w http.ResponseWriter, r *http.Request) {
-	userID, err := verifySessionID(h, w, r)
+	userID, err := verifyRequestUserID(h, w, r)
 	if err != nil {
-		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+		if err == http.ErrNoCookie || err == redis.ErrNil || err == errInvalidServiceToken {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	var groupTransactionReceiver model.GroupTransactionReceiver
 	if err := json.NewDecoder(r.Body).Decode(&groupTransactionReceiver); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
+	groupTransactionReceiver.IdempotencyKey, err = parseIdempotencyKey(r)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
+		return
+	}
+
+	// A retried request is responded with the transaction posted by the first request with the same idempotency key.
+	if groupTransactionReceiver.IdempotencyKey.Valid {
+		dbGroupTransactionSender, err := getGroupTransactionByIdempotencyKey(h, groupTransactionReceiver.IdempotencyKey.String, groupID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		if err == nil {
+			w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+			w.WriteHeader(http.StatusOK)
+			if err := json.NewEncoder(w).Encode(dbGroupTransactionSender); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			return
+		}
+	}
+
 	// Check if the transaction date of the json request transaction has been settled.
 	yearMonth := time.Date(groupTransactionReceiver.TransactionDate.Time.Year(), groupTransactionReceiver.TransactionDate.Time.Month(), 1, 0, 0, 0, 0, time.UTC)
 
 	dbGroupAccountsList, err := h.GroupTransactionsRepo.GetGroupAccountsList(yearMonth, groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	} else if len(dbGroupAccountsList) != 0 {
-		message := fmt.Sprintf("%d年%d月の取引は精算済みのため追加できません。", yearMonth.Year(), yearMonth.Month())
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &GroupTransactionProcessLockErrorMsg{Message: message}))
+		message := localizeMessagef(r.Context(), "%d年%d月の取引は精算済みのため追加できません。", yearMonth.Year(), yearMonth.Month())
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &GroupTransactionProcessLockErrorMsg{Message: message}))
 		return
 	}
 
 	if err := validateTransaction(&groupTransactionReceiver); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, err))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
 		return
 	}
 
+	previousGroupBudgetStatusList := generatePreviousGroupBudgetStatusList(h, groupID, groupTransactionReceiver.TransactionDate.Time)
+
 	result, err := h.GroupTransactionsRepo.PostGroupTransaction(&groupTransactionReceiver, groupID, userID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		// A concurrent request with the same idempotency key has posted the transaction after the lookup above.
+		if errors.Is(err, repository.ErrDuplicateIdempotencyKey) {
+			dbGroupTransactionSender, err := getGroupTransactionByIdempotencyKey(h, groupTransactionReceiver.IdempotencyKey.String, groupID)
+			if err != nil {
+				errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+			w.WriteHeader(http.StatusOK)
+			if err := json.NewEncoder(w).Encode(dbGroupTransactionSender); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	lastInsertId, err := result.LastInsertId()
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	dbGroupTransactionSender, err := h.GroupTransactionsRepo.GetGroupTransaction(int(lastInsertId))
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
+	for _, previousGroupBudgetStatus := range previousGroupBudgetStatusList {
+		if err := notifyGroupBudgetThresholdEvents(h, previousGroupBudgetStatus, groupID); err != nil {
+			log.Println(err)
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusCreated)
 	if err := json.NewEncoder(w).Encode(dbGroupTransactionSender); err != nil {
@@ -551,48 +615,48 @@ func (h *DBHandler) PostGroupTransaction(w http.ResponseWriter, r *http.Request)
 }
 
 func (h *DBHandler) PutGroupTransaction(w http.ResponseWriter, r *http.Request) {
-	userID, err := verifySessionID(h, w, r)
+	userID, err := verifyRequestUserID(h, w, r)
 	if err != nil {
-		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+		if err == http.ErrNoCookie || err == redis.ErrNil || err == errInvalidServiceToken {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	groupTransactionID, err := strconv.Atoi(mux.Vars(r)["id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"transaction ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"transaction ID を正しく指定してください。"}))
 		return
 	}
 
 	dbGroupTransaction, err := h.GroupTransactionsRepo.GetGroupTransaction(groupTransactionID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			errorResponseByJSON(w, NewHTTPError(http.StatusNotFound, &NotFoundErrorMsg{"該当する取引が見つかりませんでした。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusNotFound, &NotFoundErrorMsg{"該当する取引が見つかりませんでした。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -601,17 +665,17 @@ func (h *DBHandler) PutGroupTransaction(w http.ResponseWriter, r *http.Request)
 
 	dbGroupAccountsList, err := h.GroupTransactionsRepo.GetGroupAccountsList(yearMonth, groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	} else if len(dbGroupAccountsList) != 0 {
-		message := fmt.Sprintf("%d年%d月の取引は精算済みのため更新できません。", yearMonth.Year(), yearMonth.Month())
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &GroupTransactionProcessLockErrorMsg{Message: message}))
+		message := localizeMessagef(r.Context(), "%d年%d月の取引は精算済みのため更新できません。", yearMonth.Year(), yearMonth.Month())
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &GroupTransactionProcessLockErrorMsg{Message: message}))
 		return
 	}
 
 	var groupTransactionReceiver model.GroupTransactionReceiver
 	if err := json.NewDecoder(r.Body).Decode(&groupTransactionReceiver); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -620,35 +684,43 @@ func (h *DBHandler) PutGroupTransaction(w http.ResponseWriter, r *http.Request)
 
 	dbGroupAccountsList, err = h.GroupTransactionsRepo.GetGroupAccountsList(yearMonth, groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	} else if len(dbGroupAccountsList) != 0 {
-		message := fmt.Sprintf("%d年%d月の取引は精算済みのため更新できません。", yearMonth.Year(), yearMonth.Month())
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &GroupTransactionProcessLockErrorMsg{Message: message}))
+		message := localizeMessagef(r.Context(), "%d年%d月の取引は精算済みのため更新できません。", yearMonth.Year(), yearMonth.Month())
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &GroupTransactionProcessLockErrorMsg{Message: message}))
 		return
 	}
 
 	if err := validateTransaction(&groupTransactionReceiver); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, err))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
 		return
 	}
 
+	previousGroupBudgetStatusList := generatePreviousGroupBudgetStatusList(h, groupID, dbGroupTransaction.TransactionDate.Time, groupTransactionReceiver.TransactionDate.Time)
+
 	if err := h.GroupTransactionsRepo.PutGroupTransaction(&groupTransactionReceiver, groupTransactionID, userID); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupTransactionSender, err := h.GroupTransactionsRepo.GetGroupTransaction(groupTransactionID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"トランザクションを取得できませんでした。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"トランザクションを取得できませんでした。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
+	for _, previousGroupBudgetStatus := range previousGroupBudgetStatusList {
+		if err := notifyGroupBudgetThresholdEvents(h, previousGroupBudgetStatus, groupID); err != nil {
+			log.Println(err)
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(groupTransactionSender); err != nil {
@@ -658,48 +730,48 @@ func (h *DBHandler) PutGroupTransaction(w http.ResponseWriter, r *http.Request)
 }
 
 func (h *DBHandler) DeleteGroupTransaction(w http.ResponseWriter, r *http.Request) {
-	userID, err := verifySessionID(h, w, r)
+	userID, err := verifyRequestUserID(h, w, r)
 	if err != nil {
-		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+		if err == http.ErrNoCookie || err == redis.ErrNil || err == errInvalidServiceToken {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	groupTransactionID, err := strconv.Atoi(mux.Vars(r)["id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"transaction ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"transaction ID を正しく指定してください。"}))
 		return
 	}
 
 	dbGroupTransaction, err := h.GroupTransactionsRepo.GetGroupTransaction(groupTransactionID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			errorResponseByJSON(w, NewHTTPError(http.StatusNotFound, &NotFoundErrorMsg{"該当する取引が見つかりませんでした。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusNotFound, &NotFoundErrorMsg{"該当する取引が見つかりませんでした。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -708,22 +780,22 @@ func (h *DBHandler) DeleteGroupTransaction(w http.ResponseWriter, r *http.Reques
 
 	dbGroupAccountsList, err := h.GroupTransactionsRepo.GetGroupAccountsList(yearMonth, groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	} else if len(dbGroupAccountsList) != 0 {
-		message := fmt.Sprintf("%d年%d月の取引は精算済みのため削除できません。", yearMonth.Year(), yearMonth.Month())
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &GroupTransactionProcessLockErrorMsg{Message: message}))
+		message := localizeMessagef(r.Context(), "%d年%d月の取引は精算済みのため削除できません。", yearMonth.Year(), yearMonth.Month())
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &GroupTransactionProcessLockErrorMsg{Message: message}))
 		return
 	}
 
 	if err := h.GroupTransactionsRepo.DeleteGroupTransaction(groupTransactionID); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(&DeleteContentMsg{"トランザクションを削除しました。"}); err != nil {
+	if err := json.NewEncoder(w).Encode(&DeleteContentMsg{localizeMessage(r.Context(), "トランザクションを削除しました。")}); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -733,11 +805,11 @@ func (h *DBHandler) SearchGroupTransactionsList(w http.ResponseWriter, r *http.R
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -745,23 +817,23 @@ func (h *DBHandler) SearchGroupTransactionsList(w http.ResponseWriter, r *http.R
 
 	groupID, err := strconv.Atoi(strGroupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	if err := r.ParseForm(); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -769,20 +841,20 @@ func (h *DBHandler) SearchGroupTransactionsList(w http.ResponseWriter, r *http.R
 
 	query, err := generateGroupTransactionsSqlQuery(searchQuery)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	dbGroupTransactionsList, err := h.GroupTransactionsRepo.SearchGroupTransactionsList(query)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if len(dbGroupTransactionsList) == 0 {
 		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 		w.WriteHeader(http.StatusOK)
-		if err := json.NewEncoder(w).Encode(&NoContentMsg{"条件に一致する取引履歴は見つかりませんでした。"}); err != nil {
+		if err := json.NewEncoder(w).Encode(&NoContentMsg{localizeMessage(r.Context(), "条件に一致する取引履歴は見つかりませんでした。")}); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
@@ -803,7 +875,7 @@ func (h *DBHandler) SearchGroupTransactionsList(w http.ResponseWriter, r *http.R
 func (h *DBHandler) GetGroupShoppingItemRelatedTransactionDataList(w http.ResponseWriter, r *http.Request) {
 	var transactionIdList []int
 	if err := json.NewDecoder(r.Body).Decode(&transactionIdList); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -825,46 +897,46 @@ func (h *DBHandler) GetYearlyAccountingStatus(w http.ResponseWriter, r *http.Req
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	firstDayOfYear, err := time.Parse("2006", mux.Vars(r)["year"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年を正しく指定してください。"}))
 		return
 	}
 
 	transactionExistenceByMonths, err := h.GroupTransactionsRepo.YearlyGroupTransactionExistenceConfirmation(firstDayOfYear, groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	yearlyGroupAccountsList, err := h.GroupTransactionsRepo.GetYearlyGroupAccountsList(firstDayOfYear, groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -878,38 +950,86 @@ func (h *DBHandler) GetYearlyAccountingStatus(w http.ResponseWriter, r *http.Req
 	}
 }
 
+func (h *DBHandler) GetUnsettledGroupAccountsList(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		return
+	}
+
+	if err := verifyGroupAffiliation(groupID, userID); err != nil {
+		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
+		if !ok {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		return
+	}
+
+	groupAccountsList, err := h.GroupTransactionsRepo.GetUnsettledGroupAccountsList(groupID, userID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	unsettledGroupAccountsList := model.UnsettledGroupAccountsList{
+		GroupID:                    groupID,
+		UnsettledGroupAccountsList: groupAccountsList,
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&unsettledGroupAccountsList); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
 func (h *DBHandler) GetMonthlyGroupTransactionsAccount(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	firstDay, err := time.Parse("2006-01", mux.Vars(r)["year_month"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
 		return
 	}
 
@@ -919,17 +1039,17 @@ func (h *DBHandler) GetMonthlyGroupTransactionsAccount(w http.ResponseWriter, r
 	if err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	userPaymentAmountList, err := h.GroupTransactionsRepo.GetUserPaymentAmountList(groupID, groupUserIDList, firstDay, lastDay)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -942,7 +1062,7 @@ func (h *DBHandler) GetMonthlyGroupTransactionsAccount(w http.ResponseWriter, r
 	}
 
 	if !isNotZero {
-		errorResponseByJSON(w, NewHTTPError(http.StatusNotFound, &NotFoundErrorMsg{"当月の取引履歴が見つかりませんでした。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusNotFound, &NotFoundErrorMsg{"当月の取引履歴が見つかりませんでした。"}))
 		return
 	}
 
@@ -954,12 +1074,12 @@ func (h *DBHandler) GetMonthlyGroupTransactionsAccount(w http.ResponseWriter, r
 
 	groupAccountsList.GroupAccountsList, err = h.GroupTransactionsRepo.GetGroupAccountsList(firstDay, groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if len(groupAccountsList.GroupAccountsList) == 0 {
-		errorResponseByJSON(w, NewHTTPError(http.StatusNotFound, &NotFoundErrorMsg{"当月は未会計です。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusNotFound, &NotFoundErrorMsg{"当月は未会計です。"}))
 		return
 	}
 
@@ -977,34 +1097,34 @@ func (h *DBHandler) PostMonthlyGroupTransactionsAccount(w http.ResponseWriter, r
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	firstDay, err := time.Parse("2006-01", mux.Vars(r)["year_month"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
 		return
 	}
 
@@ -1014,33 +1134,33 @@ func (h *DBHandler) PostMonthlyGroupTransactionsAccount(w http.ResponseWriter, r
 	if err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	if len(groupUserIDList) == 1 {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"グループ人数が1人のため会計できません。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"グループ人数が1人のため会計できません。"}))
 		return
 	}
 
 	dbGroupAccountsList, err := h.GroupTransactionsRepo.GetGroupAccountsList(firstDay, groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if len(dbGroupAccountsList) >= 1 {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"当月は会計済です。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"当月は会計済です。"}))
 		return
 	}
 
 	userPaymentAmountList, err := h.GroupTransactionsRepo.GetUserPaymentAmountList(groupID, groupUserIDList, firstDay, lastDay)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -1053,7 +1173,7 @@ func (h *DBHandler) PostMonthlyGroupTransactionsAccount(w http.ResponseWriter, r
 	}
 
 	if !isNotZero {
-		errorResponseByJSON(w, NewHTTPError(http.StatusNotFound, &NotFoundErrorMsg{"当月の取引履歴が見つかりませんでした。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusNotFound, &NotFoundErrorMsg{"当月の取引履歴が見つかりませんでした。"}))
 		return
 	}
 
@@ -1078,13 +1198,13 @@ func (h *DBHandler) PostMonthlyGroupTransactionsAccount(w http.ResponseWriter, r
 	}
 
 	if err := h.GroupTransactionsRepo.PostGroupAccountsList(groupAccountsList.GroupAccountsList); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupAccountsList.GroupAccountsList, err = h.GroupTransactionsRepo.GetGroupAccountsList(firstDay, groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -1102,62 +1222,62 @@ func (h *DBHandler) PutMonthlyGroupTransactionsAccount(w http.ResponseWriter, r
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	yearMonth, err := time.Parse("2006-01", mux.Vars(r)["year_month"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
 		return
 	}
 
 	dbGroupAccountsList, err := h.GroupTransactionsRepo.GetGroupAccountsList(yearMonth, groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if len(dbGroupAccountsList) == 0 {
-		errorResponseByJSON(w, NewHTTPError(http.StatusNotFound, &NotFoundErrorMsg{"当月は未会計です。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusNotFound, &NotFoundErrorMsg{"当月は未会計です。"}))
 		return
 	}
 
 	groupAccountID, err := strconv.Atoi(mux.Vars(r)["id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"会計ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"会計ID を正しく指定してください。"}))
 		return
 	}
 
 	var groupAccount model.GroupAccount
 	if err := json.NewDecoder(r.Body).Decode(&groupAccount); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if err := h.GroupTransactionsRepo.PutGroupAccount(groupAccount, groupAccountID); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -1173,56 +1293,56 @@ func (h *DBHandler) DeleteMonthlyGroupTransactionsAccount(w http.ResponseWriter,
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	yearMonth, err := time.Parse("2006-01", mux.Vars(r)["year_month"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
 		return
 	}
 
 	dbGroupAccountsList, err := h.GroupTransactionsRepo.GetGroupAccountsList(yearMonth, groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if len(dbGroupAccountsList) == 0 {
-		errorResponseByJSON(w, NewHTTPError(http.StatusNotFound, &NotFoundErrorMsg{"当月は未会計です。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusNotFound, &NotFoundErrorMsg{"当月は未会計です。"}))
 		return
 	}
 
 	if err := h.GroupTransactionsRepo.DeleteGroupAccountsList(yearMonth, groupID); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(&DeleteContentMsg{"グループ会計データを削除しました。"}); err != nil {
+	if err := json.NewEncoder(w).Encode(&DeleteContentMsg{localizeMessage(r.Context(), "グループ会計データを削除しました。")}); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/hryze/kakeibo-app-api/account-rest-service/config"
 	"github.com/hryze/kakeibo-app-api/account-rest-service/domain/model"
+	"github.com/hryze/kakeibo-app-api/account-rest-service/domain/repository"
 	"github.com/hryze/kakeibo-app-api/account-rest-service/testutil"
 )
 
@@ -333,6 +334,14 @@ func (m MockGroupTransactionsRepository) GetGroupTransaction(groupTransactionID
 	}, nil
 }
 
+func (m MockGroupTransactionsRepository) GetGroupTransactionIDByIdempotencyKey(idempotencyKey string, groupID int) (int, error) {
+	if idempotencyKey == "retried-idempotency-key" {
+		return 1, nil
+	}
+
+	return 0, sql.ErrNoRows
+}
+
 func (m MockGroupTransactionsRepository) PostGroupTransaction(groupTransaction *model.GroupTransactionReceiver, groupID int, postedUserID string) (sql.Result, error) {
 	return MockSqlResult{}, nil
 }
@@ -580,6 +589,27 @@ func (m MockGroupTransactionsRepository) GetMonthlyGroupTransactionTotalAmountBy
 	}, nil
 }
 
+func (m MockGroupTransactionsRepository) GetMonthlyGroupTransactionTotalAmountByMediumCategory(groupID int, firstDay time.Time, lastDay time.Time) ([]model.GroupTransactionTotalAmountByMediumCategory, error) {
+	return []model.GroupTransactionTotalAmountByMediumCategory{
+		{
+			MediumCategoryID: model.NullInt64{NullInt64: sql.NullInt64{Int64: 6, Valid: true}},
+			TotalAmount:      30000,
+		},
+		{
+			MediumCategoryID: model.NullInt64{NullInt64: sql.NullInt64{Int64: 10, Valid: true}},
+			TotalAmount:      18000,
+		},
+		{
+			CustomCategoryID: model.NullInt64{NullInt64: sql.NullInt64{Int64: 3, Valid: true}},
+			TotalAmount:      7000,
+		},
+		{
+			MediumCategoryID: model.NullInt64{NullInt64: sql.NullInt64{Int64: 13, Valid: true}},
+			TotalAmount:      5000,
+		},
+	}, nil
+}
+
 func (m MockGroupTransactionsRepository) YearlyGroupTransactionExistenceConfirmation(firstDayOfYear time.Time, groupID int) ([]time.Time, error) {
 	return []time.Time{
 		time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC),
@@ -591,6 +621,31 @@ func (m MockGroupTransactionsRepository) YearlyGroupTransactionExistenceConfirma
 	}, nil
 }
 
+func (m MockGroupTransactionsRepository) GetUnsettledGroupAccountsList(groupID int, userID string) ([]model.GroupAccount, error) {
+	return []model.GroupAccount{
+		{
+			ID:                  3,
+			GroupID:             2,
+			Month:               time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC),
+			Payer:               model.NullString{NullString: sql.NullString{String: "userID2", Valid: true}},
+			Recipient:           model.NullString{NullString: sql.NullString{String: "userID1", Valid: true}},
+			PaymentAmount:       model.NullInt{Int: 8000, Valid: true},
+			PaymentConfirmation: true,
+			ReceiptConfirmation: false,
+		},
+		{
+			ID:                  5,
+			GroupID:             2,
+			Month:               time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC),
+			Payer:               model.NullString{NullString: sql.NullString{String: "userID1", Valid: true}},
+			Recipient:           model.NullString{NullString: sql.NullString{String: "userID3", Valid: true}},
+			PaymentAmount:       model.NullInt{Int: 2500, Valid: true},
+			PaymentConfirmation: false,
+			ReceiptConfirmation: false,
+		},
+	}, nil
+}
+
 func (m MockGroupTransactionsRepository) GetYearlyGroupAccountsList(firstDayOfYear time.Time, groupID int) ([]model.GroupAccount, error) {
 	return []model.GroupAccount{
 		{
@@ -638,6 +693,14 @@ func (m MockGroupTransactionsRepository) GetYearlyGroupAccountsList(firstDayOfYe
 	}, nil
 }
 
+func (m MockGroupTransactionsRepository) GetMonthlyGroupTransactionTotalAmountByBigCategoryList(groupID int, firstDay time.Time, lastDay time.Time) ([]model.MonthlyGroupTransactionTotalAmountByBigCategory, error) {
+	return []model.MonthlyGroupTransactionTotalAmountByBigCategory{
+		{Month: time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC), BigCategoryID: 2, TotalAmount: 20000},
+		{Month: time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC), BigCategoryID: 2, TotalAmount: 28000},
+		{Month: time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC), BigCategoryID: 3, TotalAmount: 2000},
+	}, nil
+}
+
 func TestDBHandler_GetMonthlyGroupTransactionsList(t *testing.T) {
 	h := DBHandler{
 		AuthRepo:              MockAuthRepository{},
@@ -701,6 +764,9 @@ func TestDBHandler_PostGroupTransaction(t *testing.T) {
 	h := DBHandler{
 		AuthRepo:              MockAuthRepository{},
 		GroupTransactionsRepo: MockGroupTransactionsRepository{},
+		GroupBudgetsRepo:      MockGroupBudgetsRepository{},
+		BudgetNotifier:        MockBudgetNotifier{},
+		TimeManage:            MockTime{},
 	}
 
 	r := httptest.NewRequest("POST", "/groups/1/transactions", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
@@ -726,10 +792,171 @@ func TestDBHandler_PostGroupTransaction(t *testing.T) {
 	testutil.AssertResponseBody(t, res, &model.GroupTransactionSender{}, &model.GroupTransactionSender{})
 }
 
+func TestDBHandler_PostGroupTransactionWithIdempotencyKey(t *testing.T) {
+	h := DBHandler{
+		AuthRepo:              MockAuthRepository{},
+		GroupTransactionsRepo: MockGroupTransactionsRepository{},
+		GroupBudgetsRepo:      MockGroupBudgetsRepository{},
+		BudgetNotifier:        MockBudgetNotifier{},
+	}
+
+	r := httptest.NewRequest("POST", "/groups/1/transactions", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"group_id": "1",
+	})
+
+	r.Header.Set("Idempotency-Key", "retried-idempotency-key")
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.PostGroupTransaction(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &model.GroupTransactionSender{}, &model.GroupTransactionSender{})
+}
+
+// MockBudgetThresholdGroupTransactionsRepository totals nothing in 日用品 until the group transaction of 15000 yen is posted.
+type MockBudgetThresholdGroupTransactionsRepository struct {
+	MockGroupTransactionsRepository
+	posted *bool
+}
+
+func (m MockBudgetThresholdGroupTransactionsRepository) GetMonthlyGroupTransactionTotalAmountByBigCategory(groupID int, firstDay time.Time, lastDay time.Time) ([]model.GroupTransactionTotalAmountByBigCategory, error) {
+	if !*m.posted {
+		return []model.GroupTransactionTotalAmountByBigCategory{}, nil
+	}
+
+	return []model.GroupTransactionTotalAmountByBigCategory{
+		{BigCategoryID: 3, TotalAmount: 15000},
+	}, nil
+}
+
+func (m MockBudgetThresholdGroupTransactionsRepository) PostGroupTransaction(groupTransaction *model.GroupTransactionReceiver, groupID int, postedUserID string) (sql.Result, error) {
+	*m.posted = true
+
+	return MockSqlResult{}, nil
+}
+
+func TestDBHandler_PostGroupTransactionWithBudgetThresholdEvents(t *testing.T) {
+	posted := false
+	budgetNotifier := &MockRecordingBudgetNotifier{}
+
+	h := DBHandler{
+		AuthRepo:              MockAuthRepository{},
+		GroupTransactionsRepo: MockBudgetThresholdGroupTransactionsRepository{posted: &posted},
+		GroupBudgetsRepo:      MockGroupBudgetsRepository{},
+		BudgetNotifier:        budgetNotifier,
+		TimeManage:            MockTime{},
+	}
+
+	r := httptest.NewRequest("POST", "/groups/1/transactions", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"group_id": "1",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.PostGroupTransaction(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusCreated)
+
+	// The budget of 日用品 in 2020-07 is 5000 with 3000 carried over from 2020-06.
+	yearMonth := time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	want := []model.GroupBudgetThresholdEvent{
+		{GroupID: 1, YearMonth: yearMonth, BigCategoryID: 3, BigCategoryName: "日用品", Threshold: 80, Budget: 8000, Expenses: 15000},
+		{GroupID: 1, YearMonth: yearMonth, BigCategoryID: 3, BigCategoryName: "日用品", Threshold: 100, Budget: 8000, Expenses: 15000},
+	}
+
+	if diff := cmp.Diff(want, budgetNotifier.groupBudgetThresholdEventList); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+// MockConcurrentIdempotencyKeyGroupTransactionsRepository behaves as if a concurrent request with the same idempotency key
+// has posted the group transaction between the lookup of the idempotency key and the insert.
+type MockConcurrentIdempotencyKeyGroupTransactionsRepository struct {
+	MockGroupTransactionsRepository
+	posted *bool
+}
+
+func (m MockConcurrentIdempotencyKeyGroupTransactionsRepository) GetGroupTransactionIDByIdempotencyKey(idempotencyKey string, groupID int) (int, error) {
+	if !*m.posted {
+		return 0, sql.ErrNoRows
+	}
+
+	return 1, nil
+}
+
+func (m MockConcurrentIdempotencyKeyGroupTransactionsRepository) PostGroupTransaction(groupTransaction *model.GroupTransactionReceiver, groupID int, postedUserID string) (sql.Result, error) {
+	*m.posted = true
+
+	return nil, repository.ErrDuplicateIdempotencyKey
+}
+
+func TestDBHandler_PostGroupTransactionWithConcurrentIdempotencyKey(t *testing.T) {
+	posted := false
+
+	h := DBHandler{
+		AuthRepo:              MockAuthRepository{},
+		GroupTransactionsRepo: MockConcurrentIdempotencyKeyGroupTransactionsRepository{posted: &posted},
+		GroupBudgetsRepo:      MockGroupBudgetsRepository{},
+		BudgetNotifier:        MockBudgetNotifier{},
+		TimeManage:            MockTime{},
+	}
+
+	r := httptest.NewRequest("POST", "/groups/1/transactions", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"group_id": "1",
+	})
+
+	r.Header.Set("Idempotency-Key", "concurrent-idempotency-key")
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.PostGroupTransaction(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &model.GroupTransactionSender{}, &model.GroupTransactionSender{})
+}
+
 func TestDBHandler_PutGroupTransaction(t *testing.T) {
 	h := DBHandler{
 		AuthRepo:              MockAuthRepository{},
 		GroupTransactionsRepo: MockGroupTransactionsRepository{},
+		GroupBudgetsRepo:      MockGroupBudgetsRepository{},
+		BudgetNotifier:        MockBudgetNotifier{},
+		TimeManage:            MockTime{},
 	}
 
 	r := httptest.NewRequest("PUT", "/groups/1/transactions/2", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
@@ -910,6 +1137,35 @@ func TestDBHandler_GetYearlyAccountingStatus(t *testing.T) {
 	}
 }
 
+func TestDBHandler_GetUnsettledGroupAccountsList(t *testing.T) {
+	h := DBHandler{
+		AuthRepo:              MockAuthRepository{},
+		GroupTransactionsRepo: MockGroupTransactionsRepository{},
+	}
+
+	r := httptest.NewRequest("GET", "/groups/2/transactions/account/unsettled", nil)
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"group_id": "2",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.GetUnsettledGroupAccountsList(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &model.UnsettledGroupAccountsList{}, &model.UnsettledGroupAccountsList{})
+}
+
 func TestDBHandler_GetMonthlyGroupTransactionsAccount(t *testing.T) {
 	h := DBHandler{
 		AuthRepo:              MockAuthRepository{},
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hryze/kakeibo-app-api/account-rest-service/domain/model"
+)
+
+func TestLocaleMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		want           string
+	}{
+		{name: "english preferred", acceptLanguage: "en-US,en;q=0.9,ja;q=0.8", want: englishLocale},
+		{name: "japanese preferred", acceptLanguage: "ja,en;q=0.5", want: japaneseLocale},
+		{name: "unsupported locale", acceptLanguage: "fr", want: defaultLocale},
+		{name: "no header", acceptLanguage: "", want: defaultLocale},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/categories", nil)
+			r.Header.Set("Accept-Language", tt.acceptLanguage)
+			w := httptest.NewRecorder()
+
+			var got string
+			LocaleMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = requestLocale(r.Context())
+			})).ServeHTTP(w, r)
+
+			if diff := cmp.Diff(tt.want, got); len(diff) != 0 {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.want, w.Header().Get("Content-Language")); len(diff) != 0 {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLocalizeMessagef(t *testing.T) {
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		format string
+		args   []interface{}
+		want   string
+	}{
+		{
+			name:   "japanese",
+			ctx:    context.WithValue(context.Background(), localeContextKey{}, japaneseLocale),
+			format: "%d年%d月の取引は精算済みのため追加できません。",
+			args:   []interface{}{2020, 7},
+			want:   "2020年7月の取引は精算済みのため追加できません。",
+		},
+		{
+			name:   "english",
+			ctx:    context.WithValue(context.Background(), localeContextKey{}, englishLocale),
+			format: "%d年%d月の取引は精算済みのため追加できません。",
+			args:   []interface{}{2020, 7},
+			want:   "Transactions cannot be added because 2020/07 has already been settled.",
+		},
+		{
+			name:   "no locale in the context",
+			ctx:    context.Background(),
+			format: "対象期間は%dヶ月以内で指定してください。",
+			args:   []interface{}{maxBudgetTargetMonths},
+			want:   "対象期間は12ヶ月以内で指定してください。",
+		},
+		{
+			name:   "format not in the catalogue",
+			ctx:    context.WithValue(context.Background(), localeContextKey{}, englishLocale),
+			format: "%d件の取引",
+			args:   []interface{}{3},
+			want:   "3件の取引",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := localizeMessagef(tt.ctx, tt.format, tt.args...)
+
+			if diff := cmp.Diff(tt.want, got); len(diff) != 0 {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCategoryTranslations_TranslateCategoriesName(t *testing.T) {
+	categoryTranslations := model.NewCategoryTranslations([]model.CategoryTranslation{
+		{CategoryType: "BigCategory", ID: 2, TranslatedName: "Food"},
+		{CategoryType: "MediumCategory", ID: 6, TranslatedName: "Groceries"},
+	})
+
+	// The categories have been renamed since the translations were registered.
+	categoriesName := model.CategoriesName{
+		BigCategoryID:      2,
+		BigCategoryName:    model.NullString{NullString: sql.NullString{String: "食費・外食", Valid: true}},
+		MediumCategoryID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 6, Valid: true}},
+		MediumCategoryName: model.NullString{NullString: sql.NullString{String: "食材", Valid: true}},
+	}
+
+	categoryTranslations.TranslateCategoriesName(&categoriesName)
+
+	want := model.CategoriesName{
+		BigCategoryID:      2,
+		BigCategoryName:    model.NullString{NullString: sql.NullString{String: "Food", Valid: true}},
+		MediumCategoryID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 6, Valid: true}},
+		MediumCategoryName: model.NullString{NullString: sql.NullString{String: "Groceries", Valid: true}},
+	}
+
+	if diff := cmp.Diff(want, categoriesName); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
@@ -0,0 +1,100 @@
+package handler
+
+// messageCatalogue maps each Japanese message or message format to its translation by locale.
+// A translated format must take the arguments in the same order as the Japanese one.
+var messageCatalogue = map[string]map[string]string{
+	englishLocale: {
+		// common
+		"このページを表示するにはログインが必要です。":        "You need to log in to view this page.",
+		"指定されたグループに所属していません。":           "You do not belong to the specified group.",
+		"大カテゴリーに関連する中カテゴリーが見つかりませんでした。": "No medium category was found for the big category.",
+		"group ID を正しく指定してください。":        "Please specify a valid group ID.",
+		"年を正しく指定してください。":                "Please specify a valid year.",
+		"年月を正しく指定してください。":               "Please specify a valid year and month.",
+
+		// category
+		"custom category ID を正しく指定してください。":               "Please specify a valid custom category ID.",
+		"カスタムカテゴリーID を正しく指定してください。":                      "Please specify a valid custom category ID.",
+		"カスタムカテゴリーに関連する大カテゴリーが見つかりませんでした。":               "No big category was found for the custom category.",
+		"カスタムカテゴリーを削除しました。":                              "The custom category has been deleted.",
+		"カテゴリー名は9文字以下で入力してください。":                         "Please enter a category name of 9 characters or less.",
+		"中カテゴリーの登録に失敗しました。 同じカテゴリー名が既に存在していないか確認してください。": "Failed to create the medium category. Please check that the same category name does not already exist.",
+		"中カテゴリーの登録に失敗しました。 文字列先頭に空白がないか確認してください。":        "Failed to create the medium category. Please check that the name does not start with a space.",
+		"中カテゴリーの登録に失敗しました。 文字列末尾に空白がないか確認してください。":        "Failed to create the medium category. Please check that the name does not end with a space.",
+		"中カテゴリーの更新に失敗しました。 同じカテゴリー名が既に存在していないか確認してください。": "Failed to update the medium category. Please check that the same category name does not already exist.",
+		"中カテゴリーの更新に失敗しました。 文字列先頭に空白がないか確認してください。":        "Failed to update the medium category. Please check that the name does not start with a space.",
+		"中カテゴリーの更新に失敗しました。 文字列末尾に空白がないか確認してください。":        "Failed to update the medium category. Please check that the name does not end with a space.",
+		"指定されたカスタムカテゴリーは既に削除されています。":                     "The specified custom category has already been deleted.",
+		"カスタムカテゴリーを統合しました。":                              "The custom categories have been merged.",
+		"統合先のカスタムカテゴリーを正しく指定してください。":                     "Please specify a valid custom category to merge into.",
+		"統合先には統合元以外のカスタムカテゴリーを指定してください。":                 "Please specify a custom category other than the source to merge into.",
+		"統合先は統合元と同じ大カテゴリーのカスタムカテゴリーを指定してください。":           "Please specify a custom category in the same big category as the source to merge into.",
+		"コピーするカスタムカテゴリーが選択されていません。":                      "No custom category to copy is selected.",
+		"コピーするカスタムカテゴリーを正しく選択してください。":                    "Please select valid custom categories to copy.",
+		"並び替えには大カテゴリーに含まれる全てのカスタムカテゴリーを指定してください。":        "Please specify all custom categories in the big category to reorder them.",
+		"置き換え先のカテゴリーを正しく指定してください。":                       "Please specify a valid replacement category.",
+		"置き換え先は中カテゴリーかカスタムカテゴリーのどちらか一方を指定してください。":        "Please specify either a medium category or a custom category as the replacement.",
+		"置き換え先には削除するカスタムカテゴリー以外を指定してください。":               "Please specify a replacement other than the custom category to be deleted.",
+		"置き換え先は削除するカスタムカテゴリーと同じ大カテゴリーのカテゴリーを指定してください。":   "Please specify a replacement in the same big category as the custom category to be deleted.",
+
+		// transaction
+		"transaction ID を正しく指定してください。":        "Please specify a valid transaction ID.",
+		"Idempotency-Key は64文字以内で指定してください。":   "Please specify an Idempotency-Key of 64 characters or less.",
+		"トランザクションを取得できませんでした":                 "Failed to get the transaction.",
+		"トランザクションを取得できませんでした。":                "Failed to get the transaction.",
+		"トランザクションを削除しました。":                    "The transaction has been deleted.",
+		"取引タイプが選択されていません。":                    "No transaction type is selected.",
+		"取引タイプを正しく選択してください。":                  "Please select a valid transaction type.",
+		"日付を正しく選択してください。":                     "Please select a valid date.",
+		"店名は20文字以内で入力してください。":                 "Please enter a shop name of 20 characters or less.",
+		"店名の文字列先頭か末尾に空白がないか確認してください。":         "Please check that the shop name does not start or end with a space.",
+		"メモは50文字以内で入力してください":                  "Please enter a memo of 50 characters or less.",
+		"メモの文字列先頭か末尾に空白がないか確認してください。":         "Please check that the memo does not start or end with a space.",
+		"金額が入力されていません。 金額は1以上の正の整数を入力してください。": "No amount is entered. Please enter a positive integer of 1 or more.",
+		"金額は1以上の正の整数を入力してください。":               "Please enter a positive integer of 1 or more for the amount.",
+		"カテゴリーが選択されていません。":                    "No category is selected.",
+		"カテゴリーを正しく選択してください。":                  "Please select a valid category.",
+		"中カテゴリーを正しく選択してください。":                 "Please select a valid medium category.",
+		"条件に一致する取引履歴は見つかりませんでした。":             "No transactions matched the conditions.",
+		"取引履歴がありません。":                         "There are no transactions.",
+		"該当する取引が見つかりませんでした。":                  "The transaction was not found.",
+		"当月の取引履歴が見つかりませんでした。":                 "No transactions were found for this month.",
+
+		// group account
+		"会計ID を正しく指定してください。":         "Please specify a valid account ID.",
+		"グループ人数が1人のため会計できません。":       "The account cannot be settled because the group has only one member.",
+		"グループ会計データを削除しました。":          "The group account data has been deleted.",
+		"当月は会計済です。":                  "The account for this month has already been settled.",
+		"当月は未会計です。":                  "The account for this month has not been settled yet.",
+		"指定されたグループには、ユーザーは所属していません。": "The user does not belong to the specified group.",
+		"%d年%d月の取引は精算済みのため追加できません。":  "Transactions cannot be added because %d/%02d has already been settled.",
+		"%d年%d月の取引は精算済みのため更新できません。":  "Transactions cannot be updated because %d/%02d has already been settled.",
+		"%d年%d月の取引は精算済みのため削除できません。":  "Transactions cannot be deleted because %d/%02d has already been settled.",
+
+		// budget
+		"予算は0以上の整数を入力してください。":                               "Please enter an integer of 0 or more for the budget.",
+		"中カテゴリーの予算の合計は大カテゴリーの予算以下にしてください。":                  "The total of the medium category budgets must not exceed the big category budget.",
+		"同じ中カテゴリーの予算が重複しています。":                              "The same medium category budget is specified more than once.",
+		"繰越上限額は0以上の整数を入力してください。":                            "Please enter an integer of 0 or more for the rollover cap.",
+		"カスタム予算を削除しました。":                                    "The custom budget has been deleted.",
+		"予測方法を正しく指定してください。":                                 "Please specify a valid forecast method.",
+		"budget template ID を正しく指定してください。":                  "Please specify a valid budget template ID.",
+		"テンプレート名を入力してください。":                                 "Please enter a template name.",
+		"テンプレート名の文字列先頭に空白がないか確認してください。":                     "Please check that the template name does not start with a space.",
+		"テンプレート名の文字列末尾に空白がないか確認してください。":                     "Please check that the template name does not end with a space.",
+		"テンプレート名は20文字以下で入力してください。":                          "Please enter a template name of 20 characters or less.",
+		"全ての大カテゴリーの予算を入力してください。":                            "Please enter the budgets of all big categories.",
+		"予算テンプレートが見つかりませんでした。":                              "The budget template was not found.",
+		"予算テンプレートは登録されていません。":                               "No budget templates are registered.",
+		"予算テンプレートを削除しました。":                                  "The budget template has been deleted.",
+		"予算テンプレートの登録に失敗しました。 同じテンプレート名が既に存在していないか確認してください。": "Failed to create the budget template. Please check that the same template name does not already exist.",
+		"予算テンプレートの更新に失敗しました。 同じテンプレート名が既に存在していないか確認してください。": "Failed to update the budget template. Please check that the same template name does not already exist.",
+		"対象期間の開始年月を正しく指定してください。":                            "Please specify a valid first month of the target period.",
+		"対象期間の終了年月を正しく指定してください。":                            "Please specify a valid last month of the target period.",
+		"対象期間の終了年月は開始年月以降を指定してください。":                        "The last month of the target period must not be earlier than the first month.",
+		"対象期間は%dヶ月以内で指定してください。":                             "Please specify a target period of %d months or less.",
+		"コピー元と異なる年月を指定してください。":                              "Please specify a month different from the source month.",
+		"コピー元のカスタム予算が登録されていません。":                            "No custom budget is registered for the source month.",
+		"個人の標準予算をグループの標準予算にコピーしました。":                        "The personal standard budget has been copied to the group standard budget.",
+	},
+}
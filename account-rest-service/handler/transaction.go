@@ -20,6 +20,7 @@ import (
 	"github.com/gorilla/mux"
 
 	"github.com/hryze/kakeibo-app-api/account-rest-service/domain/model"
+	"github.com/hryze/kakeibo-app-api/account-rest-service/domain/repository"
 )
 
 type TransactionReceivers interface {
@@ -356,17 +357,17 @@ func (h *DBHandler) GetMonthlyTransactionsList(w http.ResponseWriter, r *http.Re
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	firstDay, err := time.Parse("2006-01", mux.Vars(r)["year_month"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
 		return
 	}
 
@@ -374,14 +375,14 @@ func (h *DBHandler) GetMonthlyTransactionsList(w http.ResponseWriter, r *http.Re
 
 	dbTransactionsList, err := h.TransactionsRepo.GetMonthlyTransactionsList(userID, firstDay, lastDay)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if len(dbTransactionsList) == 0 {
 		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 		w.WriteHeader(http.StatusOK)
-		if err := json.NewEncoder(w).Encode(&NoContentMsg{"条件に一致する取引履歴は見つかりませんでした。"}); err != nil {
+		if err := json.NewEncoder(w).Encode(&NoContentMsg{localizeMessage(r.Context(), "条件に一致する取引履歴は見つかりませんでした。")}); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
@@ -403,24 +404,24 @@ func (h *DBHandler) Get10LatestTransactionsList(w http.ResponseWriter, r *http.R
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	latestTransactionsList, err := h.TransactionsRepo.Get10LatestTransactionsList(userID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if len(latestTransactionsList.TransactionsList) == 0 {
 		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 		w.WriteHeader(http.StatusOK)
-		if err := json.NewEncoder(w).Encode(&NoContentMsg{"取引履歴がありません。"}); err != nil {
+		if err := json.NewEncoder(w).Encode(&NoContentMsg{localizeMessage(r.Context(), "取引履歴がありません。")}); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
@@ -436,38 +437,96 @@ func (h *DBHandler) Get10LatestTransactionsList(w http.ResponseWriter, r *http.R
 	}
 }
 
+// getTransactionByIdempotencyKey returns the transaction posted by the first request with the idempotency key.
+func getTransactionByIdempotencyKey(h *DBHandler, idempotencyKey string, userID string) (*model.TransactionSender, error) {
+	transactionID, err := h.TransactionsRepo.GetTransactionIDByIdempotencyKey(idempotencyKey, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var transactionSender model.TransactionSender
+
+	return h.TransactionsRepo.GetTransaction(&transactionSender, transactionID)
+}
+
 func (h *DBHandler) PostTransaction(w http.ResponseWriter, r *http.Request) {
-	userID, err := verifySessionID(h, w, r)
+	userID, err := verifyRequestUserID(h, w, r)
 	if err != nil {
-		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+		if err == http.ErrNoCookie || err == redis.ErrNil || err == errInvalidServiceToken {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	var transactionReceiver model.TransactionReceiver
 	if err := json.NewDecoder(r.Body).Decode(&transactionReceiver); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	transactionReceiver.IdempotencyKey, err = parseIdempotencyKey(r)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
 		return
 	}
 
+	// A retried request is responded with the transaction posted by the first request with the same idempotency key.
+	if transactionReceiver.IdempotencyKey.Valid {
+		dbTransactionSender, err := getTransactionByIdempotencyKey(h, transactionReceiver.IdempotencyKey.String, userID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		if err == nil {
+			w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+			w.WriteHeader(http.StatusOK)
+			if err := json.NewEncoder(w).Encode(dbTransactionSender); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			return
+		}
+	}
+
 	if err := validateTransaction(&transactionReceiver); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, err))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
 		return
 	}
 
+	previousBudgetStatusList := generatePreviousBudgetStatusList(h, userID, transactionReceiver.TransactionDate.Time)
+
 	result, err := h.TransactionsRepo.PostTransaction(&transactionReceiver, userID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		// A concurrent request with the same idempotency key has posted the transaction after the lookup above.
+		if errors.Is(err, repository.ErrDuplicateIdempotencyKey) {
+			dbTransactionSender, err := getTransactionByIdempotencyKey(h, transactionReceiver.IdempotencyKey.String, userID)
+			if err != nil {
+				errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+			w.WriteHeader(http.StatusOK)
+			if err := json.NewEncoder(w).Encode(dbTransactionSender); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	lastInsertId, err := result.LastInsertId()
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -475,14 +534,20 @@ func (h *DBHandler) PostTransaction(w http.ResponseWriter, r *http.Request) {
 	dbTransactionSender, err := h.TransactionsRepo.GetTransaction(&transactionSender, int(lastInsertId))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"トランザクションを取得できませんでした"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"トランザクションを取得できませんでした"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
+	for _, previousBudgetStatus := range previousBudgetStatusList {
+		if err := notifyBudgetThresholdEvents(h, previousBudgetStatus, userID); err != nil {
+			log.Println(err)
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusCreated)
 	if err := json.NewEncoder(w).Encode(dbTransactionSender); err != nil {
@@ -492,36 +557,49 @@ func (h *DBHandler) PostTransaction(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *DBHandler) PutTransaction(w http.ResponseWriter, r *http.Request) {
-	_, err := verifySessionID(h, w, r)
+	userID, err := verifyRequestUserID(h, w, r)
 	if err != nil {
-		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+		if err == http.ErrNoCookie || err == redis.ErrNil || err == errInvalidServiceToken {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	var transactionReceiver model.TransactionReceiver
 	if err := json.NewDecoder(r.Body).Decode(&transactionReceiver); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if err := validateTransaction(&transactionReceiver); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, err))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
 		return
 	}
 
 	transactionID, err := strconv.Atoi(mux.Vars(r)["id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"transaction ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"transaction ID を正しく指定してください。"}))
 		return
 	}
 
+	var dbTransaction model.TransactionSender
+	if _, err := h.TransactionsRepo.GetTransaction(&dbTransaction, transactionID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"トランザクションを取得できませんでした"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	previousBudgetStatusList := generatePreviousBudgetStatusList(h, userID, dbTransaction.TransactionDate.Time, transactionReceiver.TransactionDate.Time)
+
 	if err := h.TransactionsRepo.PutTransaction(&transactionReceiver, transactionID); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -529,14 +607,20 @@ func (h *DBHandler) PutTransaction(w http.ResponseWriter, r *http.Request) {
 	dbTransactionSender, err := h.TransactionsRepo.GetTransaction(&transactionSender, transactionID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"トランザクションを取得できませんでした"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"トランザクションを取得できませんでした"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
+	for _, previousBudgetStatus := range previousBudgetStatusList {
+		if err := notifyBudgetThresholdEvents(h, previousBudgetStatus, userID); err != nil {
+			log.Println(err)
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(dbTransactionSender); err != nil {
@@ -546,31 +630,31 @@ func (h *DBHandler) PutTransaction(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *DBHandler) DeleteTransaction(w http.ResponseWriter, r *http.Request) {
-	_, err := verifySessionID(h, w, r)
+	_, err := verifyRequestUserID(h, w, r)
 	if err != nil {
-		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+		if err == http.ErrNoCookie || err == redis.ErrNil || err == errInvalidServiceToken {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	transactionID, err := strconv.Atoi(mux.Vars(r)["id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"transaction ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"transaction ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := h.TransactionsRepo.DeleteTransaction(transactionID); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(&DeleteContentMsg{"トランザクションを削除しました。"}); err != nil {
+	if err := json.NewEncoder(w).Encode(&DeleteContentMsg{localizeMessage(r.Context(), "トランザクションを削除しました。")}); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -580,11 +664,11 @@ func (h *DBHandler) SearchTransactionsList(w http.ResponseWriter, r *http.Reques
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -594,20 +678,20 @@ func (h *DBHandler) SearchTransactionsList(w http.ResponseWriter, r *http.Reques
 
 	query, err := generateTransactionsSqlQuery(searchQuery)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	dbTransactionsList, err := h.TransactionsRepo.SearchTransactionsList(query)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if len(dbTransactionsList) == 0 {
 		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 		w.WriteHeader(http.StatusOK)
-		if err := json.NewEncoder(w).Encode(&NoContentMsg{"条件に一致する取引履歴は見つかりませんでした。"}); err != nil {
+		if err := json.NewEncoder(w).Encode(&NoContentMsg{localizeMessage(r.Context(), "条件に一致する取引履歴は見つかりませんでした。")}); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
@@ -628,7 +712,7 @@ func (h *DBHandler) SearchTransactionsList(w http.ResponseWriter, r *http.Reques
 func (h *DBHandler) GetShoppingItemRelatedTransactionDataList(w http.ResponseWriter, r *http.Request) {
 	var transactionIdList []int
 	if err := json.NewDecoder(r.Body).Decode(&transactionIdList); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
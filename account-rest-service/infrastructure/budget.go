@@ -79,6 +79,60 @@ func (r *BudgetsRepository) GetStandardBudgets(userID string) (*model.StandardBu
 		return nil, err
 	}
 
+	childBudgetQuery := `
+        SELECT
+            standard_child_budgets.big_category_id big_category_id,
+            standard_child_budgets.medium_category_id medium_category_id,
+            standard_child_budgets.custom_category_id custom_category_id,
+            COALESCE(medium_categories.category_name, custom_categories.category_name) category_name,
+            standard_child_budgets.budget budget
+        FROM
+            standard_child_budgets
+        LEFT JOIN
+            medium_categories
+        ON
+            standard_child_budgets.medium_category_id = medium_categories.id
+        LEFT JOIN
+            custom_categories
+        ON
+            standard_child_budgets.custom_category_id = custom_categories.id
+        WHERE
+            standard_child_budgets.user_id = ?
+        ORDER BY
+            standard_child_budgets.big_category_id,
+            standard_child_budgets.medium_category_id IS NULL,
+            standard_child_budgets.medium_category_id,
+            standard_child_budgets.custom_category_id`
+
+	childBudgetRows, err := r.MySQLHandler.conn.Queryx(childBudgetQuery, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer childBudgetRows.Close()
+
+	childBudgetsByBigCategory := make(map[int][]model.ChildBudgetByCategory)
+	for childBudgetRows.Next() {
+		var childBudgetByCategory model.ChildBudgetByCategory
+		if err := childBudgetRows.StructScan(&childBudgetByCategory); err != nil {
+			return nil, err
+		}
+
+		childBudgetsByBigCategory[childBudgetByCategory.BigCategoryID] = append(childBudgetsByBigCategory[childBudgetByCategory.BigCategoryID], childBudgetByCategory)
+	}
+
+	if err := childBudgetRows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, standardBudgetByCategory := range standardBudgetByCategoryList {
+		childBudgets, ok := childBudgetsByBigCategory[standardBudgetByCategory.BigCategoryID]
+		if !ok {
+			childBudgets = make([]model.ChildBudgetByCategory, 0)
+		}
+
+		standardBudgetByCategoryList[i].ChildBudgets = childBudgets
+	}
+
 	standardBudgets := model.NewStandardBudgets(standardBudgetByCategoryList)
 
 	return &standardBudgets, nil
@@ -95,6 +149,19 @@ func (r *BudgetsRepository) PutStandardBudgets(standardBudgets *model.StandardBu
 	    AND
 	        big_category_id = ?`
 
+	deleteChildBudgetsQuery := `
+        DELETE
+        FROM
+            standard_child_budgets
+        WHERE
+            user_id = ?`
+
+	insertChildBudgetQuery := `
+        INSERT INTO standard_child_budgets
+            (user_id, big_category_id, medium_category_id, custom_category_id, budget)
+        VALUES
+            (?,?,?,?,?)`
+
 	tx, err := r.MySQLHandler.conn.Begin()
 	if err != nil {
 		return err
@@ -102,11 +169,23 @@ func (r *BudgetsRepository) PutStandardBudgets(standardBudgets *model.StandardBu
 
 	transactions := func(tx *sql.Tx) error {
 		for _, standardBudgetByCategory := range standardBudgets.StandardBudgets {
-			if _, err := r.MySQLHandler.conn.Exec(query, standardBudgetByCategory.Budget, userID, standardBudgetByCategory.BigCategoryID); err != nil {
+			if _, err := tx.Exec(query, standardBudgetByCategory.Budget, userID, standardBudgetByCategory.BigCategoryID); err != nil {
 				return err
 			}
 		}
 
+		if _, err := tx.Exec(deleteChildBudgetsQuery, userID); err != nil {
+			return err
+		}
+
+		for _, standardBudgetByCategory := range standardBudgets.StandardBudgets {
+			for _, childBudgetByCategory := range standardBudgetByCategory.ChildBudgets {
+				if _, err := tx.Exec(insertChildBudgetQuery, userID, standardBudgetByCategory.BigCategoryID, childBudgetByCategory.MediumCategoryID, childBudgetByCategory.CustomCategoryID, childBudgetByCategory.Budget); err != nil {
+					return err
+				}
+			}
+		}
+
 		return nil
 	}
 
@@ -164,6 +243,62 @@ func (r *BudgetsRepository) GetCustomBudgets(yearMonth time.Time, userID string)
 		return nil, err
 	}
 
+	childBudgetQuery := `
+        SELECT
+            custom_child_budgets.big_category_id big_category_id,
+            custom_child_budgets.medium_category_id medium_category_id,
+            custom_child_budgets.custom_category_id custom_category_id,
+            COALESCE(medium_categories.category_name, custom_categories.category_name) category_name,
+            custom_child_budgets.budget budget
+        FROM
+            custom_child_budgets
+        LEFT JOIN
+            medium_categories
+        ON
+            custom_child_budgets.medium_category_id = medium_categories.id
+        LEFT JOIN
+            custom_categories
+        ON
+            custom_child_budgets.custom_category_id = custom_categories.id
+        WHERE
+            custom_child_budgets.user_id = ?
+        AND
+            custom_child_budgets.years_months = ?
+        ORDER BY
+            custom_child_budgets.big_category_id,
+            custom_child_budgets.medium_category_id IS NULL,
+            custom_child_budgets.medium_category_id,
+            custom_child_budgets.custom_category_id`
+
+	childBudgetRows, err := r.MySQLHandler.conn.Queryx(childBudgetQuery, userID, yearMonth)
+	if err != nil {
+		return nil, err
+	}
+	defer childBudgetRows.Close()
+
+	childBudgetsByBigCategory := make(map[int][]model.ChildBudgetByCategory)
+	for childBudgetRows.Next() {
+		var childBudgetByCategory model.ChildBudgetByCategory
+		if err := childBudgetRows.StructScan(&childBudgetByCategory); err != nil {
+			return nil, err
+		}
+
+		childBudgetsByBigCategory[childBudgetByCategory.BigCategoryID] = append(childBudgetsByBigCategory[childBudgetByCategory.BigCategoryID], childBudgetByCategory)
+	}
+
+	if err := childBudgetRows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, customBudgetByCategory := range customBudgetByCategoryList {
+		childBudgets, ok := childBudgetsByBigCategory[customBudgetByCategory.BigCategoryID]
+		if !ok {
+			childBudgets = make([]model.ChildBudgetByCategory, 0)
+		}
+
+		customBudgetByCategoryList[i].ChildBudgets = childBudgets
+	}
+
 	customBudgets := model.NewCustomBudgets(customBudgetByCategoryList)
 
 	return &customBudgets, nil
@@ -191,14 +326,51 @@ func (r *BudgetsRepository) PostCustomBudgets(customBudgets *model.CustomBudgets
             (?,?,?,?),
             (?,?,?,?)`
 
+	insertChildBudgetQuery := `
+        INSERT INTO custom_child_budgets
+            (user_id, years_months, big_category_id, medium_category_id, custom_category_id, budget)
+        VALUES
+            (?,?,?,?,?,?)`
+
 	var queryArgs []interface{}
 	for _, customBudgetByCategory := range customBudgets.CustomBudgets {
 		queryArgs = append(queryArgs, userID, yearMonth, customBudgetByCategory.BigCategoryID, customBudgetByCategory.Budget)
 	}
 
-	_, err := r.MySQLHandler.conn.Exec(query, queryArgs...)
+	tx, err := r.MySQLHandler.conn.Begin()
+	if err != nil {
+		return err
+	}
 
-	return err
+	transactions := func(tx *sql.Tx) error {
+		if _, err := tx.Exec(query, queryArgs...); err != nil {
+			return err
+		}
+
+		for _, customBudgetByCategory := range customBudgets.CustomBudgets {
+			for _, childBudgetByCategory := range customBudgetByCategory.ChildBudgets {
+				if _, err := tx.Exec(insertChildBudgetQuery, userID, yearMonth, customBudgetByCategory.BigCategoryID, childBudgetByCategory.MediumCategoryID, childBudgetByCategory.CustomCategoryID, childBudgetByCategory.Budget); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	}
+
+	if err := transactions(tx); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return err
+		}
+
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
 }
 
 func (r *BudgetsRepository) PutCustomBudgets(customBudgets *model.CustomBudgets, yearMonth time.Time, userID string) error {
@@ -214,6 +386,21 @@ func (r *BudgetsRepository) PutCustomBudgets(customBudgets *model.CustomBudgets,
 	    AND
 	        big_category_id = ?`
 
+	deleteChildBudgetsQuery := `
+        DELETE
+        FROM
+            custom_child_budgets
+        WHERE
+            user_id = ?
+        AND
+            years_months = ?`
+
+	insertChildBudgetQuery := `
+        INSERT INTO custom_child_budgets
+            (user_id, years_months, big_category_id, medium_category_id, custom_category_id, budget)
+        VALUES
+            (?,?,?,?,?,?)`
+
 	tx, err := r.MySQLHandler.conn.Begin()
 	if err != nil {
 		return err
@@ -221,11 +408,23 @@ func (r *BudgetsRepository) PutCustomBudgets(customBudgets *model.CustomBudgets,
 
 	transactions := func(tx *sql.Tx) error {
 		for _, customBudgetByCategory := range customBudgets.CustomBudgets {
-			if _, err := r.MySQLHandler.conn.Exec(query, customBudgetByCategory.Budget, userID, yearMonth, customBudgetByCategory.BigCategoryID); err != nil {
+			if _, err := tx.Exec(query, customBudgetByCategory.Budget, userID, yearMonth, customBudgetByCategory.BigCategoryID); err != nil {
 				return err
 			}
 		}
 
+		if _, err := tx.Exec(deleteChildBudgetsQuery, userID, yearMonth); err != nil {
+			return err
+		}
+
+		for _, customBudgetByCategory := range customBudgets.CustomBudgets {
+			for _, childBudgetByCategory := range customBudgetByCategory.ChildBudgets {
+				if _, err := tx.Exec(insertChildBudgetQuery, userID, yearMonth, customBudgetByCategory.BigCategoryID, childBudgetByCategory.MediumCategoryID, childBudgetByCategory.CustomCategoryID, childBudgetByCategory.Budget); err != nil {
+					return err
+				}
+			}
+		}
+
 		return nil
 	}
 
@@ -314,3 +513,502 @@ func (r *BudgetsRepository) GetMonthlyCustomBudgets(year time.Time, userID strin
 
 	return monthlyCustomBudgets, nil
 }
+
+func (r *BudgetsRepository) GetMonthlyCustomBudgetByCategoryList(firstMonth time.Time, lastMonth time.Time, userID string) ([]model.MonthlyCustomBudgetByCategory, error) {
+	query := `
+        SELECT
+            years_months,
+            big_category_id,
+            budget
+        FROM
+            custom_budgets
+        WHERE
+            user_id = ?
+        AND
+            years_months >= ?
+        AND
+            years_months <= ?
+        ORDER BY
+            years_months,
+            big_category_id`
+
+	rows, err := r.MySQLHandler.conn.Queryx(query, userID, firstMonth, lastMonth)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var monthlyCustomBudgetByCategoryList []model.MonthlyCustomBudgetByCategory
+	for rows.Next() {
+		var monthlyCustomBudgetByCategory model.MonthlyCustomBudgetByCategory
+		if err := rows.StructScan(&monthlyCustomBudgetByCategory); err != nil {
+			return nil, err
+		}
+
+		monthlyCustomBudgetByCategoryList = append(monthlyCustomBudgetByCategoryList, monthlyCustomBudgetByCategory)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return monthlyCustomBudgetByCategoryList, nil
+}
+
+func (r *BudgetsRepository) GetBudgetRolloverSettings(userID string) (*model.BudgetRolloverSettings, error) {
+	query := `
+        SELECT
+            big_category_id,
+            rollover,
+            rollover_cap,
+            rollover_start_month
+        FROM
+            standard_budgets
+        WHERE
+            user_id = ?
+        ORDER BY
+            big_category_id`
+
+	rows, err := r.MySQLHandler.conn.Queryx(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var budgetRolloverSettingList []model.BudgetRolloverSetting
+	for rows.Next() {
+		var budgetRolloverSetting model.BudgetRolloverSetting
+		if err := rows.StructScan(&budgetRolloverSetting); err != nil {
+			return nil, err
+		}
+
+		budgetRolloverSettingList = append(budgetRolloverSettingList, budgetRolloverSetting)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &model.BudgetRolloverSettings{BudgetRolloverSettings: budgetRolloverSettingList}, nil
+}
+
+func (r *BudgetsRepository) PutBudgetRolloverSettings(budgetRolloverSettings *model.BudgetRolloverSettings, userID string) error {
+	query := `
+        UPDATE
+            standard_budgets
+        SET
+            rollover = ?,
+            rollover_cap = ?,
+            rollover_start_month = ?
+        WHERE
+            user_id = ?
+        AND
+            big_category_id = ?`
+
+	tx, err := r.MySQLHandler.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	transactions := func(tx *sql.Tx) error {
+		for _, budgetRolloverSetting := range budgetRolloverSettings.BudgetRolloverSettings {
+			if _, err := tx.Exec(query, budgetRolloverSetting.Rollover, budgetRolloverSetting.RolloverCap, budgetRolloverSetting.RolloverStartMonth, userID, budgetRolloverSetting.BigCategoryID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if err := transactions(tx); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return err
+		}
+
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *BudgetsRepository) ReplaceCustomBudgetsList(customBudgets *model.CustomBudgets, yearMonthList []time.Time, userID string) error {
+	deleteQuery := `
+        DELETE
+        FROM
+            custom_budgets
+        WHERE
+            user_id = ?
+        AND
+            years_months = ?`
+
+	insertQuery := `
+        INSERT INTO custom_budgets
+            (user_id, years_months, big_category_id, budget)
+        VALUES
+            (?,?,?,?)`
+
+	insertChildBudgetQuery := `
+        INSERT INTO custom_child_budgets
+            (user_id, years_months, big_category_id, medium_category_id, custom_category_id, budget)
+        VALUES
+            (?,?,?,?,?,?)`
+
+	tx, err := r.MySQLHandler.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	transactions := func(tx *sql.Tx) error {
+		for _, yearMonth := range yearMonthList {
+			if _, err := tx.Exec(deleteQuery, userID, yearMonth); err != nil {
+				return err
+			}
+
+			for _, customBudgetByCategory := range customBudgets.CustomBudgets {
+				if _, err := tx.Exec(insertQuery, userID, yearMonth, customBudgetByCategory.BigCategoryID, customBudgetByCategory.Budget); err != nil {
+					return err
+				}
+
+				for _, childBudgetByCategory := range customBudgetByCategory.ChildBudgets {
+					if _, err := tx.Exec(insertChildBudgetQuery, userID, yearMonth, customBudgetByCategory.BigCategoryID, childBudgetByCategory.MediumCategoryID, childBudgetByCategory.CustomCategoryID, childBudgetByCategory.Budget); err != nil {
+						return err
+					}
+				}
+			}
+		}
+
+		return nil
+	}
+
+	if err := transactions(tx); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return err
+		}
+
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *BudgetsRepository) GetBudgetTemplatesList(userID string) ([]model.BudgetTemplate, error) {
+	templateQuery := `
+        SELECT
+            id,
+            template_name
+        FROM
+            budget_templates
+        WHERE
+            user_id = ?
+        ORDER BY
+            id`
+
+	budgetQuery := `
+        SELECT
+            budget_template_budgets.template_id template_id,
+            budget_template_budgets.big_category_id big_category_id,
+            big_categories.category_name big_category_name,
+            budget_template_budgets.budget budget
+        FROM
+            budget_template_budgets
+        INNER JOIN
+            budget_templates
+        ON
+            budget_template_budgets.template_id = budget_templates.id
+        INNER JOIN
+            big_categories
+        ON
+            budget_template_budgets.big_category_id = big_categories.id
+        WHERE
+            budget_templates.user_id = ?
+        ORDER BY
+            budget_template_budgets.template_id,
+            budget_template_budgets.big_category_id`
+
+	childBudgetQuery := `
+        SELECT
+            budget_template_child_budgets.template_id template_id,
+            budget_template_child_budgets.big_category_id big_category_id,
+            budget_template_child_budgets.medium_category_id medium_category_id,
+            budget_template_child_budgets.custom_category_id custom_category_id,
+            COALESCE(medium_categories.category_name, custom_categories.category_name) category_name,
+            budget_template_child_budgets.budget budget
+        FROM
+            budget_template_child_budgets
+        INNER JOIN
+            budget_templates
+        ON
+            budget_template_child_budgets.template_id = budget_templates.id
+        LEFT JOIN
+            medium_categories
+        ON
+            budget_template_child_budgets.medium_category_id = medium_categories.id
+        LEFT JOIN
+            custom_categories
+        ON
+            budget_template_child_budgets.custom_category_id = custom_categories.id
+        WHERE
+            budget_templates.user_id = ?
+        ORDER BY
+            budget_template_child_budgets.template_id,
+            budget_template_child_budgets.big_category_id,
+            budget_template_child_budgets.medium_category_id IS NULL,
+            budget_template_child_budgets.medium_category_id,
+            budget_template_child_budgets.custom_category_id`
+
+	templateRows, err := r.MySQLHandler.conn.Queryx(templateQuery, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer templateRows.Close()
+
+	var budgetTemplatesList []model.BudgetTemplate
+	budgetTemplateIndexes := make(map[int]int)
+	for templateRows.Next() {
+		budgetTemplate := model.BudgetTemplate{BudgetTemplateByCategory: make([]model.BudgetTemplateByCategory, 0)}
+		if err := templateRows.StructScan(&budgetTemplate); err != nil {
+			return nil, err
+		}
+
+		budgetTemplateIndexes[budgetTemplate.ID] = len(budgetTemplatesList)
+		budgetTemplatesList = append(budgetTemplatesList, budgetTemplate)
+	}
+
+	if err := templateRows.Err(); err != nil {
+		return nil, err
+	}
+
+	childBudgetRows, err := r.MySQLHandler.conn.Queryx(childBudgetQuery, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer childBudgetRows.Close()
+
+	childBudgetsByTemplate := make(map[int]map[int][]model.ChildBudgetByCategory)
+	for childBudgetRows.Next() {
+		var budgetTemplateChildBudget model.BudgetTemplateChildBudget
+		if err := childBudgetRows.StructScan(&budgetTemplateChildBudget); err != nil {
+			return nil, err
+		}
+
+		if _, ok := childBudgetsByTemplate[budgetTemplateChildBudget.TemplateID]; !ok {
+			childBudgetsByTemplate[budgetTemplateChildBudget.TemplateID] = make(map[int][]model.ChildBudgetByCategory)
+		}
+
+		childBudgetsByBigCategory := childBudgetsByTemplate[budgetTemplateChildBudget.TemplateID]
+		childBudgetsByBigCategory[budgetTemplateChildBudget.BigCategoryID] = append(childBudgetsByBigCategory[budgetTemplateChildBudget.BigCategoryID], budgetTemplateChildBudget.ChildBudgetByCategory)
+	}
+
+	if err := childBudgetRows.Err(); err != nil {
+		return nil, err
+	}
+
+	budgetRows, err := r.MySQLHandler.conn.Queryx(budgetQuery, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer budgetRows.Close()
+
+	for budgetRows.Next() {
+		var budgetTemplateByCategory model.BudgetTemplateByCategory
+		if err := budgetRows.StructScan(&budgetTemplateByCategory); err != nil {
+			return nil, err
+		}
+
+		i, ok := budgetTemplateIndexes[budgetTemplateByCategory.TemplateID]
+		if !ok {
+			continue
+		}
+
+		childBudgets, ok := childBudgetsByTemplate[budgetTemplateByCategory.TemplateID][budgetTemplateByCategory.BigCategoryID]
+		if !ok {
+			childBudgets = make([]model.ChildBudgetByCategory, 0)
+		}
+
+		budgetTemplateByCategory.ChildBudgets = childBudgets
+		budgetTemplatesList[i].BudgetTemplateByCategory = append(budgetTemplatesList[i].BudgetTemplateByCategory, budgetTemplateByCategory)
+	}
+
+	if err := budgetRows.Err(); err != nil {
+		return nil, err
+	}
+
+	return budgetTemplatesList, nil
+}
+
+func (r *BudgetsRepository) GetBudgetTemplate(templateID int, userID string) (*model.BudgetTemplate, error) {
+	budgetTemplatesList, err := r.GetBudgetTemplatesList(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, budgetTemplate := range budgetTemplatesList {
+		if budgetTemplate.ID == templateID {
+			return &budgetTemplate, nil
+		}
+	}
+
+	return nil, sql.ErrNoRows
+}
+
+func (r *BudgetsRepository) FindBudgetTemplate(budgetTemplate *model.BudgetTemplate, userID string) error {
+	query := `
+        SELECT
+            id
+        FROM
+            budget_templates
+        WHERE
+            template_name = ?
+        AND
+            user_id = ?
+        AND
+            id <> ?`
+
+	var budgetTemplateID int
+	err := r.MySQLHandler.conn.QueryRowx(query, budgetTemplate.Name, userID, budgetTemplate.ID).Scan(&budgetTemplateID)
+
+	return err
+}
+
+func (r *BudgetsRepository) PostBudgetTemplate(budgetTemplate *model.BudgetTemplate, userID string) (sql.Result, error) {
+	templateQuery := `
+        INSERT INTO budget_templates
+            (template_name, user_id)
+        VALUES
+            (?,?)`
+
+	tx, err := r.MySQLHandler.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	var result sql.Result
+	transactions := func(tx *sql.Tx) error {
+		result, err = tx.Exec(templateQuery, budgetTemplate.Name, userID)
+		if err != nil {
+			return err
+		}
+
+		templateID, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+
+		return insertBudgetTemplateBudgets(tx, budgetTemplate, int(templateID))
+	}
+
+	if err := transactions(tx); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return nil, err
+		}
+
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *BudgetsRepository) PutBudgetTemplate(budgetTemplate *model.BudgetTemplate, userID string) error {
+	templateQuery := `
+        UPDATE
+            budget_templates
+        SET
+            template_name = ?
+        WHERE
+            id = ?
+        AND
+            user_id = ?`
+
+	deleteBudgetsQuery := `
+        DELETE
+        FROM
+            budget_template_budgets
+        WHERE
+            template_id = ?`
+
+	tx, err := r.MySQLHandler.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	transactions := func(tx *sql.Tx) error {
+		if _, err := tx.Exec(templateQuery, budgetTemplate.Name, budgetTemplate.ID, userID); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(deleteBudgetsQuery, budgetTemplate.ID); err != nil {
+			return err
+		}
+
+		return insertBudgetTemplateBudgets(tx, budgetTemplate, budgetTemplate.ID)
+	}
+
+	if err := transactions(tx); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return err
+		}
+
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *BudgetsRepository) DeleteBudgetTemplate(templateID int, userID string) error {
+	query := `
+        DELETE
+        FROM
+            budget_templates
+        WHERE
+            id = ?
+        AND
+            user_id = ?`
+
+	_, err := r.MySQLHandler.conn.Exec(query, templateID, userID)
+
+	return err
+}
+
+func insertBudgetTemplateBudgets(tx *sql.Tx, budgetTemplate *model.BudgetTemplate, templateID int) error {
+	budgetQuery := `
+        INSERT INTO budget_template_budgets
+            (template_id, big_category_id, budget)
+        VALUES
+            (?,?,?)`
+
+	childBudgetQuery := `
+        INSERT INTO budget_template_child_budgets
+            (template_id, big_category_id, medium_category_id, custom_category_id, budget)
+        VALUES
+            (?,?,?,?,?)`
+
+	for _, budgetTemplateByCategory := range budgetTemplate.BudgetTemplateByCategory {
+		if _, err := tx.Exec(budgetQuery, templateID, budgetTemplateByCategory.BigCategoryID, budgetTemplateByCategory.Budget); err != nil {
+			return err
+		}
+
+		for _, childBudgetByCategory := range budgetTemplateByCategory.ChildBudgets {
+			if _, err := tx.Exec(childBudgetQuery, templateID, budgetTemplateByCategory.BigCategoryID, childBudgetByCategory.MediumCategoryID, childBudgetByCategory.CustomCategoryID, childBudgetByCategory.Budget); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
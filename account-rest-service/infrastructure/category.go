@@ -2,6 +2,8 @@ package infrastructure
 
 import (
 	"database/sql"
+	"encoding/json"
+	"fmt"
 	"strings"
 
 	"github.com/hryze/kakeibo-app-api/account-rest-service/domain/model"
@@ -80,13 +82,13 @@ func (r *CategoriesRepository) GetMediumCategoriesList() ([]model.AssociatedCate
 func (r *CategoriesRepository) GetCustomCategoriesList(userID string) ([]model.AssociatedCategory, error) {
 	query := `
         SELECT
-            id, category_name, big_category_id 
+            id, category_name, big_category_id, is_archived 
         FROM 
             custom_categories 
         WHERE 
             user_id = ? 
         ORDER BY 
-            id DESC`
+            display_order, id DESC`
 
 	rows, err := r.MySQLHandler.conn.Queryx(query, userID)
 	if err != nil {
@@ -142,6 +144,53 @@ func (r *CategoriesRepository) PostCustomCategory(customCategory *model.CustomCa
 	return result, err
 }
 
+// PostCustomCategoriesList creates the custom categories in a single transaction and sets the ID of each created one,
+// so that either all of them or none of them are created.
+func (r *CategoriesRepository) PostCustomCategoriesList(customCategoriesList []model.CustomCategory, userID string) error {
+	query := `
+        INSERT INTO custom_categories
+            (category_name, big_category_id, user_id) 
+        VALUES
+            (?,?,?)`
+
+	tx, err := r.MySQLHandler.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	transactions := func(tx *sql.Tx) error {
+		for i := range customCategoriesList {
+			result, err := tx.Exec(query, customCategoriesList[i].Name, customCategoriesList[i].BigCategoryID, userID)
+			if err != nil {
+				return err
+			}
+
+			lastInsertId, err := result.LastInsertId()
+			if err != nil {
+				return err
+			}
+
+			customCategoriesList[i].ID = int(lastInsertId)
+		}
+
+		return nil
+	}
+
+	if err := transactions(tx); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return err
+		}
+
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (r *CategoriesRepository) PutCustomCategory(customCategory *model.CustomCategory) error {
 	query := `
         UPDATE 
@@ -156,6 +205,256 @@ func (r *CategoriesRepository) PutCustomCategory(customCategory *model.CustomCat
 	return err
 }
 
+func (r *CategoriesRepository) PutCustomCategoryArchive(customCategoryArchive *model.CustomCategoryArchive) error {
+	query := `
+        UPDATE 
+            custom_categories 
+        SET 
+            is_archived = ? 
+        WHERE 
+            id = ?`
+
+	_, err := r.MySQLHandler.conn.Exec(query, customCategoryArchive.IsArchived, customCategoryArchive.ID)
+
+	return err
+}
+
+func (r *CategoriesRepository) PutCustomCategoriesOrder(customCategoriesOrder *model.CustomCategoriesOrder, userID string) error {
+	query := `
+        UPDATE 
+            custom_categories 
+        SET 
+            display_order = ? 
+        WHERE 
+            id = ?
+        AND
+            user_id = ?
+        AND
+            big_category_id = ?`
+
+	tx, err := r.MySQLHandler.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	transactions := func(tx *sql.Tx) error {
+		for i, customCategoryID := range customCategoriesOrder.CustomCategoryIDList {
+			if _, err := tx.Exec(query, i+1, customCategoryID, userID, customCategoriesOrder.BigCategoryID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if err := transactions(tx); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return err
+		}
+
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// customCategoryChildBudgetTables lists the child budget tables which reference custom_categories with ON DELETE CASCADE,
+// together with the column identifying the parent budget of each row.
+var customCategoryChildBudgetTables = []struct {
+	name      string
+	parentKey string
+}{
+	{name: "standard_child_budgets", parentKey: "user_id"},
+	{name: "custom_child_budgets", parentKey: "CAST(years_months AS CHAR)"},
+	{name: "budget_template_child_budgets", parentKey: "CAST(template_id AS CHAR)"},
+}
+
+func (r *CategoriesRepository) MergeCustomCategory(sourceCustomCategoryID int, targetCustomCategoryID int) error {
+	transactionQuery := `
+        UPDATE
+            transactions
+        SET 
+            custom_category_id = ?
+        WHERE
+            custom_category_id = ?`
+
+	categoryQuery := `
+        DELETE 
+        FROM 
+            custom_categories 
+        WHERE 
+            id = ?`
+
+	tx, err := r.MySQLHandler.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	transactions := func(tx *sql.Tx) error {
+		if _, err := tx.Exec(transactionQuery, targetCustomCategoryID, sourceCustomCategoryID); err != nil {
+			return err
+		}
+
+		// The child budgets of the source are merged into the target before the source is deleted,
+		// as deleting it would otherwise cascade to them.
+		for _, table := range customCategoryChildBudgetTables {
+			if err := mergeCustomCategoryChildBudgets(tx, table.name, table.parentKey, sourceCustomCategoryID, targetCustomCategoryID); err != nil {
+				return err
+			}
+		}
+
+		if _, err := tx.Exec(categoryQuery, sourceCustomCategoryID); err != nil {
+			return err
+		}
+
+		return nil
+	}
+
+	if err := transactions(tx); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return err
+		}
+
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func mergeCustomCategoryChildBudgets(tx *sql.Tx, table string, parentKey string, sourceCustomCategoryID int, targetCustomCategoryID int) error {
+	childBudgetsQuery := fmt.Sprintf(`
+        SELECT
+            id, %s parent_key, budget
+        FROM
+            %s
+        WHERE
+            custom_category_id = ?
+        ORDER BY
+            id
+        FOR UPDATE`, parentKey, table)
+
+	updateBudgetQuery := fmt.Sprintf(`
+        UPDATE
+            %s
+        SET
+            budget = ?
+        WHERE
+            id = ?`, table)
+
+	deleteQuery := fmt.Sprintf(`
+        DELETE
+        FROM
+            %s
+        WHERE
+            id = ?`, table)
+
+	moveQuery := fmt.Sprintf(`
+        UPDATE
+            %s
+        SET
+            custom_category_id = ?
+        WHERE
+            id = ?`, table)
+
+	sourceChildBudgets, err := selectCustomCategoryChildBudgets(tx, childBudgetsQuery, sourceCustomCategoryID)
+	if err != nil {
+		return err
+	}
+
+	if len(sourceChildBudgets) == 0 {
+		return nil
+	}
+
+	targetChildBudgets, err := selectCustomCategoryChildBudgets(tx, childBudgetsQuery, targetCustomCategoryID)
+	if err != nil {
+		return err
+	}
+
+	childBudgetsMerge := model.NewCustomCategoryChildBudgetsMerge(sourceChildBudgets, targetChildBudgets)
+
+	for _, summedChildBudget := range childBudgetsMerge.SummedChildBudgets {
+		if _, err := tx.Exec(updateBudgetQuery, summedChildBudget.Budget, summedChildBudget.ID); err != nil {
+			return err
+		}
+	}
+
+	for _, deletedID := range childBudgetsMerge.DeletedIDList {
+		if _, err := tx.Exec(deleteQuery, deletedID); err != nil {
+			return err
+		}
+	}
+
+	for _, movedID := range childBudgetsMerge.MovedIDList {
+		if _, err := tx.Exec(moveQuery, targetCustomCategoryID, movedID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func selectCustomCategoryChildBudgets(tx *sql.Tx, query string, customCategoryID int) ([]model.CustomCategoryChildBudget, error) {
+	rows, err := tx.Query(query, customCategoryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var childBudgets []model.CustomCategoryChildBudget
+	for rows.Next() {
+		var childBudget model.CustomCategoryChildBudget
+		if err := rows.Scan(&childBudget.ID, &childBudget.ParentKey, &childBudget.Budget); err != nil {
+			return nil, err
+		}
+
+		childBudgets = append(childBudgets, childBudget)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return childBudgets, nil
+}
+
+func (r *CategoriesRepository) PostShoppingListMergeRevertFailure(shoppingListMergeRevertFailure *model.ShoppingListMergeRevertFailure) error {
+	query := `
+        INSERT INTO shopping_list_merge_revert_failures
+            (user_id, source_custom_category_id, target_custom_category_id, shopping_item_id_list, regular_shopping_item_id_list, error_message)
+        VALUES
+            (?,?,?,?,?,?)`
+
+	shoppingItemIDList, err := json.Marshal(shoppingListMergeRevertFailure.ShoppingItemIDList)
+	if err != nil {
+		return err
+	}
+
+	regularShoppingItemIDList, err := json.Marshal(shoppingListMergeRevertFailure.RegularShoppingItemIDList)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.MySQLHandler.conn.Exec(
+		query,
+		shoppingListMergeRevertFailure.UserID,
+		shoppingListMergeRevertFailure.SourceCustomCategoryID,
+		shoppingListMergeRevertFailure.TargetCustomCategoryID,
+		shoppingItemIDList,
+		regularShoppingItemIDList,
+		shoppingListMergeRevertFailure.ErrorMessage,
+	)
+
+	return err
+}
+
 func (r *CategoriesRepository) GetBigCategoryID(customCategoryID int) (int, error) {
 	query := `
         SELECT 
@@ -173,7 +472,65 @@ func (r *CategoriesRepository) GetBigCategoryID(customCategoryID int) (int, erro
 	return bigCategoryID, nil
 }
 
-func (r *CategoriesRepository) DeleteCustomCategory(previousCustomCategoryID int, replaceMediumCategoryID int) error {
+func (r *CategoriesRepository) GetCustomCategoryBigCategoryID(customCategoryID int, userID string) (int, error) {
+	query := `
+        SELECT 
+            big_category_id 
+        FROM 
+            custom_categories 
+        WHERE 
+            id = ?
+        AND
+            user_id = ?`
+
+	var bigCategoryID int
+	if err := r.MySQLHandler.conn.QueryRowx(query, customCategoryID, userID).Scan(&bigCategoryID); err != nil {
+		return bigCategoryID, err
+	}
+
+	return bigCategoryID, nil
+}
+
+func (r *CategoriesRepository) GetMediumCategoryBigCategoryID(mediumCategoryID int) (int, error) {
+	query := `
+        SELECT 
+            big_category_id 
+        FROM 
+            medium_categories 
+        WHERE 
+            id = ?`
+
+	var bigCategoryID int
+	if err := r.MySQLHandler.conn.QueryRowx(query, mediumCategoryID).Scan(&bigCategoryID); err != nil {
+		return bigCategoryID, err
+	}
+
+	return bigCategoryID, nil
+}
+
+func (r *CategoriesRepository) GetFallbackMediumCategoryID(bigCategoryID int) (int, error) {
+	query := `
+        SELECT 
+            id 
+        FROM 
+            medium_categories 
+        WHERE 
+            big_category_id = ?
+        AND
+            is_fallback = TRUE
+        ORDER BY
+            id
+        LIMIT 1`
+
+	var mediumCategoryID int
+	if err := r.MySQLHandler.conn.QueryRowx(query, bigCategoryID).Scan(&mediumCategoryID); err != nil {
+		return mediumCategoryID, err
+	}
+
+	return mediumCategoryID, nil
+}
+
+func (r *CategoriesRepository) DeleteCustomCategory(previousCustomCategoryID int, replaceCategoriesID model.CategoriesID) error {
 	transactionQuery := `
         UPDATE
             transactions
@@ -196,7 +553,7 @@ func (r *CategoriesRepository) DeleteCustomCategory(previousCustomCategoryID int
 	}
 
 	transactions := func(tx *sql.Tx) error {
-		if _, err := tx.Exec(transactionQuery, replaceMediumCategoryID, nil, previousCustomCategoryID); err != nil {
+		if _, err := tx.Exec(transactionQuery, replaceCategoriesID.MediumCategoryID, replaceCategoriesID.CustomCategoryID, previousCustomCategoryID); err != nil {
 			return err
 		}
 
@@ -230,7 +587,9 @@ func (r *CategoriesRepository) GetCategoriesName(categoriesID model.CategoriesID
 	if categoriesID.MediumCategoryID.Valid {
 		query = `
             SELECT
+                big_categories.id big_category_id,
                 big_categories.category_name big_category_name,
+                medium_categories.id medium_category_id,
                 medium_categories.category_name medium_category_name
             FROM
                 medium_categories
@@ -245,6 +604,7 @@ func (r *CategoriesRepository) GetCategoriesName(categoriesID model.CategoriesID
 	} else if categoriesID.CustomCategoryID.Valid {
 		query = `
             SELECT
+                big_categories.id big_category_id,
                 big_categories.category_name big_category_name,
                 custom_categories.category_name custom_category_name
             FROM
@@ -274,7 +634,9 @@ func (r *CategoriesRepository) GetCategoriesNameList(categoriesIDList []model.Ca
 		if categoriesID.MediumCategoryID.Valid {
 			sliceQuery[i] = `
             SELECT
+                big_categories.id big_category_id,
                 big_categories.category_name big_category_name,
+                medium_categories.id medium_category_id,
                 medium_categories.category_name medium_category_name,
                 NULL custom_category_name
             FROM
@@ -290,7 +652,9 @@ func (r *CategoriesRepository) GetCategoriesNameList(categoriesIDList []model.Ca
 		} else if categoriesID.CustomCategoryID.Valid {
 			sliceQuery[i] = `
             SELECT
+                big_categories.id big_category_id,
                 big_categories.category_name big_category_name,
+                NULL medium_category_id,
                 NULL medium_category_name,
                 custom_categories.category_name custom_category_name
             FROM
@@ -330,3 +694,46 @@ func (r *CategoriesRepository) GetCategoriesNameList(categoriesIDList []model.Ca
 
 	return categoriesNameList, nil
 }
+
+func (r *CategoriesRepository) GetCategoryTranslationsList(locale string) ([]model.CategoryTranslation, error) {
+	query := `
+        SELECT
+            "BigCategory" category_type,
+            big_category_id id,
+            category_name translated_name
+        FROM
+            big_category_translations
+        WHERE
+            locale = ?
+        UNION ALL
+        SELECT
+            "MediumCategory" category_type,
+            medium_category_id id,
+            category_name translated_name
+        FROM
+            medium_category_translations
+        WHERE
+            locale = ?`
+
+	rows, err := r.MySQLHandler.conn.Queryx(query, locale, locale)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	categoryTranslationsList := make([]model.CategoryTranslation, 0)
+	for rows.Next() {
+		var categoryTranslation model.CategoryTranslation
+		if err := rows.StructScan(&categoryTranslation); err != nil {
+			return nil, err
+		}
+
+		categoryTranslationsList = append(categoryTranslationsList, categoryTranslation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return categoryTranslationsList, nil
+}
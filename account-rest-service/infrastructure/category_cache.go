@@ -0,0 +1,224 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/hryze/kakeibo-app-api/account-rest-service/domain/model"
+	"github.com/hryze/kakeibo-app-api/account-rest-service/domain/repository"
+)
+
+const (
+	// maxCustomCategoriesCacheEntries bounds the number of users or groups whose custom categories are kept.
+	maxCustomCategoriesCacheEntries = 1024
+
+	// customCategoriesCacheTTL bounds how long a list is kept, since a change made through another instance
+	// only drops the list kept by that instance.
+	customCategoriesCacheTTL = time.Minute
+)
+
+type customCategoriesCacheEntry struct {
+	customCategoriesList []model.AssociatedCategory
+	expiresAt            time.Time
+}
+
+// CachedCategoriesRepository keeps the big and medium categories, which are static seed data,
+// and the custom categories of each user in process for customCategoriesCacheTTL,
+// and drops the custom categories of a user when they are changed.
+type CachedCategoriesRepository struct {
+	repository.CategoriesRepository
+
+	now                     func() time.Time
+	mu                      sync.RWMutex
+	generation              uint64
+	bigCategoriesList       []model.BigCategory
+	mediumCategoriesList    []model.AssociatedCategory
+	customCategoriesLists   map[string]customCategoriesCacheEntry
+	categoryTranslationsMap map[string][]model.CategoryTranslation
+}
+
+func NewCachedCategoriesRepository(categoriesRepo repository.CategoriesRepository) *CachedCategoriesRepository {
+	return &CachedCategoriesRepository{
+		CategoriesRepository:    categoriesRepo,
+		now:                     time.Now,
+		customCategoriesLists:   make(map[string]customCategoriesCacheEntry),
+		categoryTranslationsMap: make(map[string][]model.CategoryTranslation),
+	}
+}
+
+func (r *CachedCategoriesRepository) GetBigCategoriesList() ([]model.BigCategory, error) {
+	r.mu.RLock()
+	bigCategoriesList := r.bigCategoriesList
+	r.mu.RUnlock()
+
+	if bigCategoriesList == nil {
+		var err error
+		if bigCategoriesList, err = r.CategoriesRepository.GetBigCategoriesList(); err != nil {
+			return nil, err
+		}
+
+		r.mu.Lock()
+		r.bigCategoriesList = bigCategoriesList
+		r.mu.Unlock()
+	}
+
+	return append([]model.BigCategory(nil), bigCategoriesList...), nil
+}
+
+func (r *CachedCategoriesRepository) GetMediumCategoriesList() ([]model.AssociatedCategory, error) {
+	r.mu.RLock()
+	mediumCategoriesList := r.mediumCategoriesList
+	r.mu.RUnlock()
+
+	if mediumCategoriesList == nil {
+		var err error
+		if mediumCategoriesList, err = r.CategoriesRepository.GetMediumCategoriesList(); err != nil {
+			return nil, err
+		}
+
+		r.mu.Lock()
+		r.mediumCategoriesList = mediumCategoriesList
+		r.mu.Unlock()
+	}
+
+	return append([]model.AssociatedCategory(nil), mediumCategoriesList...), nil
+}
+
+func (r *CachedCategoriesRepository) GetCategoryTranslationsList(locale string) ([]model.CategoryTranslation, error) {
+	r.mu.RLock()
+	categoryTranslationsList, ok := r.categoryTranslationsMap[locale]
+	r.mu.RUnlock()
+
+	if !ok {
+		var err error
+		if categoryTranslationsList, err = r.CategoriesRepository.GetCategoryTranslationsList(locale); err != nil {
+			return nil, err
+		}
+
+		r.mu.Lock()
+		r.categoryTranslationsMap[locale] = categoryTranslationsList
+		r.mu.Unlock()
+	}
+
+	return append([]model.CategoryTranslation(nil), categoryTranslationsList...), nil
+}
+
+func (r *CachedCategoriesRepository) GetCustomCategoriesList(userID string) ([]model.AssociatedCategory, error) {
+	now := r.now()
+
+	r.mu.RLock()
+	entry, ok := r.customCategoriesLists[userID]
+	generation := r.generation
+	r.mu.RUnlock()
+
+	customCategoriesList := entry.customCategoriesList
+	if !ok || !now.Before(entry.expiresAt) {
+		var err error
+		if customCategoriesList, err = r.CategoriesRepository.GetCustomCategoriesList(userID); err != nil {
+			return nil, err
+		}
+
+		// The list is not cached if custom categories were changed while it was being read,
+		// since it may already be out of date.
+		r.mu.Lock()
+		if r.generation == generation {
+			r.setCustomCategoriesList(userID, customCategoriesList, now)
+		}
+		r.mu.Unlock()
+	}
+
+	return append([]model.AssociatedCategory(nil), customCategoriesList...), nil
+}
+
+// setCustomCategoriesList must be called with r.mu held.
+// Expired lists are dropped first when the cache is full, and any list is dropped if none has expired.
+func (r *CachedCategoriesRepository) setCustomCategoriesList(userID string, customCategoriesList []model.AssociatedCategory, now time.Time) {
+	if _, ok := r.customCategoriesLists[userID]; !ok && len(r.customCategoriesLists) >= maxCustomCategoriesCacheEntries {
+		for key, entry := range r.customCategoriesLists {
+			if !now.Before(entry.expiresAt) {
+				delete(r.customCategoriesLists, key)
+			}
+		}
+
+		for key := range r.customCategoriesLists {
+			if len(r.customCategoriesLists) < maxCustomCategoriesCacheEntries {
+				break
+			}
+
+			delete(r.customCategoriesLists, key)
+		}
+	}
+
+	r.customCategoriesLists[userID] = customCategoriesCacheEntry{
+		customCategoriesList: customCategoriesList,
+		expiresAt:            now.Add(customCategoriesCacheTTL),
+	}
+}
+
+func (r *CachedCategoriesRepository) PostCustomCategory(customCategory *model.CustomCategory, userID string) (sql.Result, error) {
+	defer r.invalidateCustomCategoriesList(userID)
+
+	return r.CategoriesRepository.PostCustomCategory(customCategory, userID)
+}
+
+func (r *CachedCategoriesRepository) PostCustomCategoriesList(customCategoriesList []model.CustomCategory, userID string) error {
+	defer r.invalidateCustomCategoriesList(userID)
+
+	return r.CategoriesRepository.PostCustomCategoriesList(customCategoriesList, userID)
+}
+
+func (r *CachedCategoriesRepository) PutCustomCategory(customCategory *model.CustomCategory) error {
+	defer r.invalidateCustomCategoriesListByCustomCategoryID(customCategory.ID)
+
+	return r.CategoriesRepository.PutCustomCategory(customCategory)
+}
+
+func (r *CachedCategoriesRepository) PutCustomCategoryArchive(customCategoryArchive *model.CustomCategoryArchive) error {
+	defer r.invalidateCustomCategoriesListByCustomCategoryID(customCategoryArchive.ID)
+
+	return r.CategoriesRepository.PutCustomCategoryArchive(customCategoryArchive)
+}
+
+func (r *CachedCategoriesRepository) PutCustomCategoriesOrder(customCategoriesOrder *model.CustomCategoriesOrder, userID string) error {
+	defer r.invalidateCustomCategoriesList(userID)
+
+	return r.CategoriesRepository.PutCustomCategoriesOrder(customCategoriesOrder, userID)
+}
+
+func (r *CachedCategoriesRepository) MergeCustomCategory(sourceCustomCategoryID int, targetCustomCategoryID int) error {
+	defer r.invalidateCustomCategoriesListByCustomCategoryID(sourceCustomCategoryID)
+
+	return r.CategoriesRepository.MergeCustomCategory(sourceCustomCategoryID, targetCustomCategoryID)
+}
+
+func (r *CachedCategoriesRepository) DeleteCustomCategory(previousCustomCategoryID int, replaceCategoriesID model.CategoriesID) error {
+	defer r.invalidateCustomCategoriesListByCustomCategoryID(previousCustomCategoryID)
+
+	return r.CategoriesRepository.DeleteCustomCategory(previousCustomCategoryID, replaceCategoriesID)
+}
+
+func (r *CachedCategoriesRepository) invalidateCustomCategoriesList(userID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.generation++
+	delete(r.customCategoriesLists, userID)
+}
+
+// invalidateCustomCategoriesListByCustomCategoryID drops the cached list which contains the custom category.
+// If no list contains it, the list of its owner is not cached and there is nothing to drop.
+func (r *CachedCategoriesRepository) invalidateCustomCategoriesListByCustomCategoryID(customCategoryID int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.generation++
+	for userID, entry := range r.customCategoriesLists {
+		for _, customCategory := range entry.customCategoriesList {
+			if customCategory.ID == customCategoryID {
+				delete(r.customCategoriesLists, userID)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hryze/kakeibo-app-api/account-rest-service/domain/model"
+	"github.com/hryze/kakeibo-app-api/account-rest-service/domain/repository"
+)
+
+type stubCategoriesRepository struct {
+	repository.CategoriesRepository
+	customCategoriesLists map[string][]model.AssociatedCategory
+	readCount             int
+}
+
+func (r *stubCategoriesRepository) GetCustomCategoriesList(userID string) ([]model.AssociatedCategory, error) {
+	r.readCount++
+
+	return append([]model.AssociatedCategory(nil), r.customCategoriesLists[userID]...), nil
+}
+
+func (r *stubCategoriesRepository) PostCustomCategory(customCategory *model.CustomCategory, userID string) (sql.Result, error) {
+	r.customCategoriesLists[userID] = append(r.customCategoriesLists[userID], model.AssociatedCategory{
+		CategoryType:  "CustomCategory",
+		ID:            customCategory.ID,
+		Name:          customCategory.Name,
+		BigCategoryID: customCategory.BigCategoryID,
+	})
+
+	return nil, nil
+}
+
+func (r *stubCategoriesRepository) PutCustomCategory(customCategory *model.CustomCategory) error {
+	for userID, customCategoriesList := range r.customCategoriesLists {
+		for i := range customCategoriesList {
+			if customCategoriesList[i].ID == customCategory.ID {
+				r.customCategoriesLists[userID][i].Name = customCategory.Name
+			}
+		}
+	}
+
+	return nil
+}
+
+func TestCachedCategoriesRepository_GetCustomCategoriesList(t *testing.T) {
+	now := time.Date(2020, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		write         func(r *CachedCategoriesRepository) error
+		elapsed       time.Duration
+		wantList      []model.AssociatedCategory
+		wantReadCount int
+	}{
+		{
+			name:    "cached list is reused",
+			write:   func(r *CachedCategoriesRepository) error { return nil },
+			elapsed: 30 * time.Second,
+			wantList: []model.AssociatedCategory{
+				{CategoryType: "CustomCategory", ID: 1, Name: "米", BigCategoryID: 2},
+			},
+			wantReadCount: 1,
+		},
+		{
+			name: "posting a custom category invalidates the list",
+			write: func(r *CachedCategoriesRepository) error {
+				_, err := r.PostCustomCategory(&model.CustomCategory{ID: 2, Name: "パン", BigCategoryID: 2}, "userID1")
+				return err
+			},
+			wantList: []model.AssociatedCategory{
+				{CategoryType: "CustomCategory", ID: 1, Name: "米", BigCategoryID: 2},
+				{CategoryType: "CustomCategory", ID: 2, Name: "パン", BigCategoryID: 2},
+			},
+			wantReadCount: 2,
+		},
+		{
+			name: "putting a custom category invalidates the list",
+			write: func(r *CachedCategoriesRepository) error {
+				return r.PutCustomCategory(&model.CustomCategory{ID: 1, Name: "お米", BigCategoryID: 2})
+			},
+			wantList: []model.AssociatedCategory{
+				{CategoryType: "CustomCategory", ID: 1, Name: "お米", BigCategoryID: 2},
+			},
+			wantReadCount: 2,
+		},
+		{
+			name:    "expired list is read again",
+			write:   func(r *CachedCategoriesRepository) error { return nil },
+			elapsed: customCategoriesCacheTTL,
+			wantList: []model.AssociatedCategory{
+				{CategoryType: "CustomCategory", ID: 1, Name: "米", BigCategoryID: 2},
+			},
+			wantReadCount: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &stubCategoriesRepository{
+				customCategoriesLists: map[string][]model.AssociatedCategory{
+					"userID1": {
+						{CategoryType: "CustomCategory", ID: 1, Name: "米", BigCategoryID: 2},
+					},
+				},
+			}
+
+			currentTime := now
+			r := NewCachedCategoriesRepository(stub)
+			r.now = func() time.Time { return currentTime }
+
+			if _, err := r.GetCustomCategoriesList("userID1"); err != nil {
+				t.Fatalf("unexpected error by GetCustomCategoriesList() '%#v'", err)
+			}
+
+			if err := tt.write(r); err != nil {
+				t.Fatalf("unexpected error '%#v'", err)
+			}
+
+			currentTime = now.Add(tt.elapsed)
+
+			got, err := r.GetCustomCategoriesList("userID1")
+			if err != nil {
+				t.Fatalf("unexpected error by GetCustomCategoriesList() '%#v'", err)
+			}
+
+			if diff := cmp.Diff(tt.wantList, got); len(diff) != 0 {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+
+			if stub.readCount != tt.wantReadCount {
+				t.Errorf("readCount = %d, want %d", stub.readCount, tt.wantReadCount)
+			}
+		})
+	}
+}
+
+func TestCachedCategoriesRepository_GetCustomCategoriesList_Bounded(t *testing.T) {
+	stub := &stubCategoriesRepository{customCategoriesLists: make(map[string][]model.AssociatedCategory)}
+	r := NewCachedCategoriesRepository(stub)
+
+	for i := 0; i < maxCustomCategoriesCacheEntries*2; i++ {
+		if _, err := r.GetCustomCategoriesList("userID" + strconv.Itoa(i)); err != nil {
+			t.Fatalf("unexpected error by GetCustomCategoriesList() '%#v'", err)
+		}
+	}
+
+	if len(r.customCategoriesLists) != maxCustomCategoriesCacheEntries {
+		t.Errorf("len(customCategoriesLists) = %d, want %d", len(r.customCategoriesLists), maxCustomCategoriesCacheEntries)
+	}
+}
@@ -79,6 +79,60 @@ func (r *GroupBudgetsRepository) GetGroupStandardBudgets(groupID int) (*model.Gr
 		return nil, err
 	}
 
+	childBudgetQuery := `
+        SELECT
+            group_standard_child_budgets.big_category_id big_category_id,
+            group_standard_child_budgets.medium_category_id medium_category_id,
+            group_standard_child_budgets.custom_category_id custom_category_id,
+            COALESCE(medium_categories.category_name, group_custom_categories.category_name) category_name,
+            group_standard_child_budgets.budget budget
+        FROM
+            group_standard_child_budgets
+        LEFT JOIN
+            medium_categories
+        ON
+            group_standard_child_budgets.medium_category_id = medium_categories.id
+        LEFT JOIN
+            group_custom_categories
+        ON
+            group_standard_child_budgets.custom_category_id = group_custom_categories.id
+        WHERE
+            group_standard_child_budgets.group_id = ?
+        ORDER BY
+            group_standard_child_budgets.big_category_id,
+            group_standard_child_budgets.medium_category_id IS NULL,
+            group_standard_child_budgets.medium_category_id,
+            group_standard_child_budgets.custom_category_id`
+
+	childBudgetRows, err := r.MySQLHandler.conn.Queryx(childBudgetQuery, groupID)
+	if err != nil {
+		return nil, err
+	}
+	defer childBudgetRows.Close()
+
+	childBudgetsByBigCategory := make(map[int][]model.ChildBudgetByCategory)
+	for childBudgetRows.Next() {
+		var childBudgetByCategory model.ChildBudgetByCategory
+		if err := childBudgetRows.StructScan(&childBudgetByCategory); err != nil {
+			return nil, err
+		}
+
+		childBudgetsByBigCategory[childBudgetByCategory.BigCategoryID] = append(childBudgetsByBigCategory[childBudgetByCategory.BigCategoryID], childBudgetByCategory)
+	}
+
+	if err := childBudgetRows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, groupStandardBudgetByCategory := range groupStandardBudgetByCategoryList {
+		childBudgets, ok := childBudgetsByBigCategory[groupStandardBudgetByCategory.BigCategoryID]
+		if !ok {
+			childBudgets = make([]model.ChildBudgetByCategory, 0)
+		}
+
+		groupStandardBudgetByCategoryList[i].ChildBudgets = childBudgets
+	}
+
 	groupStandardBudgets := model.NewGroupStandardBudgets(groupStandardBudgetByCategoryList)
 
 	return &groupStandardBudgets, nil
@@ -95,6 +149,19 @@ func (r *GroupBudgetsRepository) PutGroupStandardBudgets(groupStandardBudgets *m
 	    AND
 	        big_category_id = ?`
 
+	deleteChildBudgetsQuery := `
+        DELETE
+        FROM
+            group_standard_child_budgets
+        WHERE
+            group_id = ?`
+
+	insertChildBudgetQuery := `
+        INSERT INTO group_standard_child_budgets
+            (group_id, big_category_id, medium_category_id, custom_category_id, budget)
+        VALUES
+            (?,?,?,?,?)`
+
 	tx, err := r.MySQLHandler.conn.Begin()
 	if err != nil {
 		return err
@@ -102,11 +169,23 @@ func (r *GroupBudgetsRepository) PutGroupStandardBudgets(groupStandardBudgets *m
 
 	transactions := func(tx *sql.Tx) error {
 		for _, groupStandardBudgetByCategory := range groupStandardBudgets.GroupStandardBudgets {
-			if _, err := r.MySQLHandler.conn.Exec(query, groupStandardBudgetByCategory.Budget, groupID, groupStandardBudgetByCategory.BigCategoryID); err != nil {
+			if _, err := tx.Exec(query, groupStandardBudgetByCategory.Budget, groupID, groupStandardBudgetByCategory.BigCategoryID); err != nil {
 				return err
 			}
 		}
 
+		if _, err := tx.Exec(deleteChildBudgetsQuery, groupID); err != nil {
+			return err
+		}
+
+		for _, groupStandardBudgetByCategory := range groupStandardBudgets.GroupStandardBudgets {
+			for _, childBudgetByCategory := range groupStandardBudgetByCategory.ChildBudgets {
+				if _, err := tx.Exec(insertChildBudgetQuery, groupID, groupStandardBudgetByCategory.BigCategoryID, childBudgetByCategory.MediumCategoryID, childBudgetByCategory.CustomCategoryID, childBudgetByCategory.Budget); err != nil {
+					return err
+				}
+			}
+		}
+
 		return nil
 	}
 
@@ -164,6 +243,62 @@ func (r *GroupBudgetsRepository) GetGroupCustomBudgets(yearMonth time.Time, grou
 		return nil, err
 	}
 
+	childBudgetQuery := `
+        SELECT
+            group_custom_child_budgets.big_category_id big_category_id,
+            group_custom_child_budgets.medium_category_id medium_category_id,
+            group_custom_child_budgets.custom_category_id custom_category_id,
+            COALESCE(medium_categories.category_name, group_custom_categories.category_name) category_name,
+            group_custom_child_budgets.budget budget
+        FROM
+            group_custom_child_budgets
+        LEFT JOIN
+            medium_categories
+        ON
+            group_custom_child_budgets.medium_category_id = medium_categories.id
+        LEFT JOIN
+            group_custom_categories
+        ON
+            group_custom_child_budgets.custom_category_id = group_custom_categories.id
+        WHERE
+            group_custom_child_budgets.group_id = ?
+        AND
+            group_custom_child_budgets.years_months = ?
+        ORDER BY
+            group_custom_child_budgets.big_category_id,
+            group_custom_child_budgets.medium_category_id IS NULL,
+            group_custom_child_budgets.medium_category_id,
+            group_custom_child_budgets.custom_category_id`
+
+	childBudgetRows, err := r.MySQLHandler.conn.Queryx(childBudgetQuery, groupID, yearMonth)
+	if err != nil {
+		return nil, err
+	}
+	defer childBudgetRows.Close()
+
+	childBudgetsByBigCategory := make(map[int][]model.ChildBudgetByCategory)
+	for childBudgetRows.Next() {
+		var childBudgetByCategory model.ChildBudgetByCategory
+		if err := childBudgetRows.StructScan(&childBudgetByCategory); err != nil {
+			return nil, err
+		}
+
+		childBudgetsByBigCategory[childBudgetByCategory.BigCategoryID] = append(childBudgetsByBigCategory[childBudgetByCategory.BigCategoryID], childBudgetByCategory)
+	}
+
+	if err := childBudgetRows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, groupCustomBudgetByCategory := range groupCustomBudgetByCategoryList {
+		childBudgets, ok := childBudgetsByBigCategory[groupCustomBudgetByCategory.BigCategoryID]
+		if !ok {
+			childBudgets = make([]model.ChildBudgetByCategory, 0)
+		}
+
+		groupCustomBudgetByCategoryList[i].ChildBudgets = childBudgets
+	}
+
 	groupCustomBudgets := model.NewGroupCustomBudgets(groupCustomBudgetByCategoryList)
 
 	return &groupCustomBudgets, nil
@@ -191,14 +326,51 @@ func (r *GroupBudgetsRepository) PostGroupCustomBudgets(groupCustomBudgets *mode
             (?,?,?,?),
             (?,?,?,?)`
 
+	insertChildBudgetQuery := `
+        INSERT INTO group_custom_child_budgets
+            (group_id, years_months, big_category_id, medium_category_id, custom_category_id, budget)
+        VALUES
+            (?,?,?,?,?,?)`
+
 	var queryArgs []interface{}
 	for _, groupCustomBudgetByCategory := range groupCustomBudgets.GroupCustomBudgets {
 		queryArgs = append(queryArgs, groupID, yearMonth, groupCustomBudgetByCategory.BigCategoryID, groupCustomBudgetByCategory.Budget)
 	}
 
-	_, err := r.MySQLHandler.conn.Exec(query, queryArgs...)
+	tx, err := r.MySQLHandler.conn.Begin()
+	if err != nil {
+		return err
+	}
 
-	return err
+	transactions := func(tx *sql.Tx) error {
+		if _, err := tx.Exec(query, queryArgs...); err != nil {
+			return err
+		}
+
+		for _, groupCustomBudgetByCategory := range groupCustomBudgets.GroupCustomBudgets {
+			for _, childBudgetByCategory := range groupCustomBudgetByCategory.ChildBudgets {
+				if _, err := tx.Exec(insertChildBudgetQuery, groupID, yearMonth, groupCustomBudgetByCategory.BigCategoryID, childBudgetByCategory.MediumCategoryID, childBudgetByCategory.CustomCategoryID, childBudgetByCategory.Budget); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	}
+
+	if err := transactions(tx); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return err
+		}
+
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
 }
 
 func (r *GroupBudgetsRepository) PutGroupCustomBudgets(groupCustomBudgets *model.GroupCustomBudgets, yearMonth time.Time, groupID int) error {
@@ -214,6 +386,21 @@ func (r *GroupBudgetsRepository) PutGroupCustomBudgets(groupCustomBudgets *model
 	    AND
 	        big_category_id = ?`
 
+	deleteChildBudgetsQuery := `
+        DELETE
+        FROM
+            group_custom_child_budgets
+        WHERE
+            group_id = ?
+        AND
+            years_months = ?`
+
+	insertChildBudgetQuery := `
+        INSERT INTO group_custom_child_budgets
+            (group_id, years_months, big_category_id, medium_category_id, custom_category_id, budget)
+        VALUES
+            (?,?,?,?,?,?)`
+
 	tx, err := r.MySQLHandler.conn.Begin()
 	if err != nil {
 		return err
@@ -221,11 +408,23 @@ func (r *GroupBudgetsRepository) PutGroupCustomBudgets(groupCustomBudgets *model
 
 	transactions := func(tx *sql.Tx) error {
 		for _, groupCustomBudgetByCategory := range groupCustomBudgets.GroupCustomBudgets {
-			if _, err := r.MySQLHandler.conn.Exec(query, groupCustomBudgetByCategory.Budget, groupID, yearMonth, groupCustomBudgetByCategory.BigCategoryID); err != nil {
+			if _, err := tx.Exec(query, groupCustomBudgetByCategory.Budget, groupID, yearMonth, groupCustomBudgetByCategory.BigCategoryID); err != nil {
 				return err
 			}
 		}
 
+		if _, err := tx.Exec(deleteChildBudgetsQuery, groupID, yearMonth); err != nil {
+			return err
+		}
+
+		for _, groupCustomBudgetByCategory := range groupCustomBudgets.GroupCustomBudgets {
+			for _, childBudgetByCategory := range groupCustomBudgetByCategory.ChildBudgets {
+				if _, err := tx.Exec(insertChildBudgetQuery, groupID, yearMonth, groupCustomBudgetByCategory.BigCategoryID, childBudgetByCategory.MediumCategoryID, childBudgetByCategory.CustomCategoryID, childBudgetByCategory.Budget); err != nil {
+					return err
+				}
+			}
+		}
+
 		return nil
 	}
 
@@ -314,3 +513,188 @@ func (r *GroupBudgetsRepository) GetMonthlyGroupCustomBudgets(year time.Time, gr
 
 	return monthlyGroupCustomBudgets, nil
 }
+
+func (r *GroupBudgetsRepository) GetMonthlyGroupCustomBudgetByCategoryList(firstMonth time.Time, lastMonth time.Time, groupID int) ([]model.MonthlyGroupCustomBudgetByCategory, error) {
+	query := `
+        SELECT
+            years_months,
+            big_category_id,
+            budget
+        FROM
+            group_custom_budgets
+        WHERE
+            group_id = ?
+        AND
+            years_months >= ?
+        AND
+            years_months <= ?
+        ORDER BY
+            years_months,
+            big_category_id`
+
+	rows, err := r.MySQLHandler.conn.Queryx(query, groupID, firstMonth, lastMonth)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var monthlyGroupCustomBudgetByCategoryList []model.MonthlyGroupCustomBudgetByCategory
+	for rows.Next() {
+		var monthlyGroupCustomBudgetByCategory model.MonthlyGroupCustomBudgetByCategory
+		if err := rows.StructScan(&monthlyGroupCustomBudgetByCategory); err != nil {
+			return nil, err
+		}
+
+		monthlyGroupCustomBudgetByCategoryList = append(monthlyGroupCustomBudgetByCategoryList, monthlyGroupCustomBudgetByCategory)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return monthlyGroupCustomBudgetByCategoryList, nil
+}
+
+func (r *GroupBudgetsRepository) GetGroupBudgetRolloverSettings(groupID int) (*model.GroupBudgetRolloverSettings, error) {
+	query := `
+        SELECT
+            big_category_id,
+            rollover,
+            rollover_cap,
+            rollover_start_month
+        FROM
+            group_standard_budgets
+        WHERE
+            group_id = ?
+        ORDER BY
+            big_category_id`
+
+	rows, err := r.MySQLHandler.conn.Queryx(query, groupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var groupBudgetRolloverSettingList []model.GroupBudgetRolloverSetting
+	for rows.Next() {
+		var groupBudgetRolloverSetting model.GroupBudgetRolloverSetting
+		if err := rows.StructScan(&groupBudgetRolloverSetting); err != nil {
+			return nil, err
+		}
+
+		groupBudgetRolloverSettingList = append(groupBudgetRolloverSettingList, groupBudgetRolloverSetting)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &model.GroupBudgetRolloverSettings{GroupBudgetRolloverSettings: groupBudgetRolloverSettingList}, nil
+}
+
+func (r *GroupBudgetsRepository) PutGroupBudgetRolloverSettings(groupBudgetRolloverSettings *model.GroupBudgetRolloverSettings, groupID int) error {
+	query := `
+        UPDATE
+            group_standard_budgets
+        SET
+            rollover = ?,
+            rollover_cap = ?,
+            rollover_start_month = ?
+        WHERE
+            group_id = ?
+        AND
+            big_category_id = ?`
+
+	tx, err := r.MySQLHandler.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	transactions := func(tx *sql.Tx) error {
+		for _, groupBudgetRolloverSetting := range groupBudgetRolloverSettings.GroupBudgetRolloverSettings {
+			if _, err := tx.Exec(query, groupBudgetRolloverSetting.Rollover, groupBudgetRolloverSetting.RolloverCap, groupBudgetRolloverSetting.RolloverStartMonth, groupID, groupBudgetRolloverSetting.BigCategoryID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if err := transactions(tx); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return err
+		}
+
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *GroupBudgetsRepository) ReplaceGroupCustomBudgetsList(groupCustomBudgets *model.GroupCustomBudgets, yearMonthList []time.Time, groupID int) error {
+	deleteQuery := `
+        DELETE
+        FROM
+            group_custom_budgets
+        WHERE
+            group_id = ?
+        AND
+            years_months = ?`
+
+	insertQuery := `
+        INSERT INTO group_custom_budgets
+            (group_id, years_months, big_category_id, budget)
+        VALUES
+            (?,?,?,?)`
+
+	insertChildBudgetQuery := `
+        INSERT INTO group_custom_child_budgets
+            (group_id, years_months, big_category_id, medium_category_id, custom_category_id, budget)
+        VALUES
+            (?,?,?,?,?,?)`
+
+	tx, err := r.MySQLHandler.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	transactions := func(tx *sql.Tx) error {
+		for _, yearMonth := range yearMonthList {
+			if _, err := tx.Exec(deleteQuery, groupID, yearMonth); err != nil {
+				return err
+			}
+
+			for _, groupCustomBudgetByCategory := range groupCustomBudgets.GroupCustomBudgets {
+				if _, err := tx.Exec(insertQuery, groupID, yearMonth, groupCustomBudgetByCategory.BigCategoryID, groupCustomBudgetByCategory.Budget); err != nil {
+					return err
+				}
+
+				for _, childBudgetByCategory := range groupCustomBudgetByCategory.ChildBudgets {
+					if _, err := tx.Exec(insertChildBudgetQuery, groupID, yearMonth, groupCustomBudgetByCategory.BigCategoryID, childBudgetByCategory.MediumCategoryID, childBudgetByCategory.CustomCategoryID, childBudgetByCategory.Budget); err != nil {
+						return err
+					}
+				}
+			}
+		}
+
+		return nil
+	}
+
+	if err := transactions(tx); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return err
+		}
+
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
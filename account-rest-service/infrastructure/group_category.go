@@ -142,6 +142,53 @@ func (r *GroupCategoriesRepository) PostGroupCustomCategory(groupCustomCategory
 	return result, err
 }
 
+// PostGroupCustomCategoriesList creates the group custom categories in a single transaction
+// in the same way as CategoriesRepository.PostCustomCategoriesList.
+func (r *GroupCategoriesRepository) PostGroupCustomCategoriesList(groupCustomCategoriesList []model.GroupCustomCategory, groupID int) error {
+	query := `
+        INSERT INTO group_custom_categories
+            (category_name, big_category_id, group_id) 
+        VALUES
+            (?,?,?)`
+
+	tx, err := r.MySQLHandler.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	transactions := func(tx *sql.Tx) error {
+		for i := range groupCustomCategoriesList {
+			result, err := tx.Exec(query, groupCustomCategoriesList[i].Name, groupCustomCategoriesList[i].BigCategoryID, groupID)
+			if err != nil {
+				return err
+			}
+
+			lastInsertId, err := result.LastInsertId()
+			if err != nil {
+				return err
+			}
+
+			groupCustomCategoriesList[i].ID = int(lastInsertId)
+		}
+
+		return nil
+	}
+
+	if err := transactions(tx); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return err
+		}
+
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (r *GroupCategoriesRepository) PutGroupCustomCategory(groupCustomCategory *model.GroupCustomCategory) error {
 	query := `
         UPDATE 
@@ -188,7 +235,26 @@ func (r *GroupCategoriesRepository) GetBigCategoryID(groupCustomCategoryID int)
 	return bigCategoryID, nil
 }
 
-func (r *GroupCategoriesRepository) DeleteGroupCustomCategory(previousGroupCustomCategoryID int, replaceMediumCategoryID int) error {
+func (r *GroupCategoriesRepository) GetGroupCustomCategoryBigCategoryID(groupCustomCategoryID int, groupID int) (int, error) {
+	query := `
+        SELECT 
+            big_category_id 
+        FROM 
+            group_custom_categories 
+        WHERE 
+            id = ?
+        AND
+            group_id = ?`
+
+	var bigCategoryID int
+	if err := r.MySQLHandler.conn.QueryRowx(query, groupCustomCategoryID, groupID).Scan(&bigCategoryID); err != nil {
+		return bigCategoryID, err
+	}
+
+	return bigCategoryID, nil
+}
+
+func (r *GroupCategoriesRepository) DeleteGroupCustomCategory(previousGroupCustomCategoryID int, replaceCategoriesID model.CategoriesID) error {
 	transactionQuery := `
         UPDATE
             group_transactions
@@ -211,7 +277,7 @@ func (r *GroupCategoriesRepository) DeleteGroupCustomCategory(previousGroupCusto
 	}
 
 	transactions := func(tx *sql.Tx) error {
-		if _, err := tx.Exec(transactionQuery, replaceMediumCategoryID, nil, previousGroupCustomCategoryID); err != nil {
+		if _, err := tx.Exec(transactionQuery, replaceCategoriesID.MediumCategoryID, replaceCategoriesID.CustomCategoryID, previousGroupCustomCategoryID); err != nil {
 			return err
 		}
 
@@ -245,7 +311,9 @@ func (r *GroupCategoriesRepository) GetGroupCategoriesName(categoriesID model.Ca
 	if categoriesID.MediumCategoryID.Valid {
 		query = `
             SELECT
+                big_categories.id big_category_id,
                 big_categories.category_name big_category_name,
+                medium_categories.id medium_category_id,
                 medium_categories.category_name medium_category_name
             FROM
                 medium_categories
@@ -260,6 +328,7 @@ func (r *GroupCategoriesRepository) GetGroupCategoriesName(categoriesID model.Ca
 	} else if categoriesID.CustomCategoryID.Valid {
 		query = `
             SELECT
+                big_categories.id big_category_id,
                 big_categories.category_name big_category_name,
                 group_custom_categories.category_name custom_category_name
             FROM
@@ -289,7 +358,9 @@ func (r *GroupCategoriesRepository) GetGroupCategoriesNameList(categoriesIDList
 		if categoriesID.MediumCategoryID.Valid {
 			sliceQuery[i] = `
             SELECT
+                big_categories.id big_category_id,
                 big_categories.category_name big_category_name,
+                medium_categories.id medium_category_id,
                 medium_categories.category_name medium_category_name,
                 NULL custom_category_name
             FROM
@@ -305,7 +376,9 @@ func (r *GroupCategoriesRepository) GetGroupCategoriesNameList(categoriesIDList
 		} else if categoriesID.CustomCategoryID.Valid {
 			sliceQuery[i] = `
             SELECT
+                big_categories.id big_category_id,
                 big_categories.category_name big_category_name,
+                NULL medium_category_id,
                 NULL medium_category_name,
                 group_custom_categories.category_name custom_category_name
             FROM
@@ -345,3 +418,46 @@ func (r *GroupCategoriesRepository) GetGroupCategoriesNameList(categoriesIDList
 
 	return categoriesNameList, nil
 }
+
+func (r *GroupCategoriesRepository) GetGroupCategoryTranslationsList(locale string) ([]model.CategoryTranslation, error) {
+	query := `
+        SELECT
+            "BigCategory" category_type,
+            big_category_id id,
+            category_name translated_name
+        FROM
+            big_category_translations
+        WHERE
+            locale = ?
+        UNION ALL
+        SELECT
+            "MediumCategory" category_type,
+            medium_category_id id,
+            category_name translated_name
+        FROM
+            medium_category_translations
+        WHERE
+            locale = ?`
+
+	rows, err := r.MySQLHandler.conn.Queryx(query, locale, locale)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	categoryTranslationsList := make([]model.CategoryTranslation, 0)
+	for rows.Next() {
+		var categoryTranslation model.CategoryTranslation
+		if err := rows.StructScan(&categoryTranslation); err != nil {
+			return nil, err
+		}
+
+		categoryTranslationsList = append(categoryTranslationsList, categoryTranslation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return categoryTranslationsList, nil
+}
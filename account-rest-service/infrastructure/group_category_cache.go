@@ -0,0 +1,194 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/hryze/kakeibo-app-api/account-rest-service/domain/model"
+	"github.com/hryze/kakeibo-app-api/account-rest-service/domain/repository"
+)
+
+type groupCustomCategoriesCacheEntry struct {
+	groupCustomCategoriesList []model.GroupAssociatedCategory
+	expiresAt                 time.Time
+}
+
+// CachedGroupCategoriesRepository keeps the big and medium categories and the custom categories of each group in process,
+// in the same way as CachedCategoriesRepository.
+type CachedGroupCategoriesRepository struct {
+	repository.GroupCategoriesRepository
+
+	now                        func() time.Time
+	mu                         sync.RWMutex
+	generation                 uint64
+	groupBigCategoriesList     []model.GroupBigCategory
+	groupMediumCategoriesList  []model.GroupAssociatedCategory
+	groupCustomCategoriesLists map[int]groupCustomCategoriesCacheEntry
+	categoryTranslationsMap    map[string][]model.CategoryTranslation
+}
+
+func NewCachedGroupCategoriesRepository(groupCategoriesRepo repository.GroupCategoriesRepository) *CachedGroupCategoriesRepository {
+	return &CachedGroupCategoriesRepository{
+		GroupCategoriesRepository:  groupCategoriesRepo,
+		now:                        time.Now,
+		groupCustomCategoriesLists: make(map[int]groupCustomCategoriesCacheEntry),
+		categoryTranslationsMap:    make(map[string][]model.CategoryTranslation),
+	}
+}
+
+func (r *CachedGroupCategoriesRepository) GetGroupBigCategoriesList() ([]model.GroupBigCategory, error) {
+	r.mu.RLock()
+	groupBigCategoriesList := r.groupBigCategoriesList
+	r.mu.RUnlock()
+
+	if groupBigCategoriesList == nil {
+		var err error
+		if groupBigCategoriesList, err = r.GroupCategoriesRepository.GetGroupBigCategoriesList(); err != nil {
+			return nil, err
+		}
+
+		r.mu.Lock()
+		r.groupBigCategoriesList = groupBigCategoriesList
+		r.mu.Unlock()
+	}
+
+	return append([]model.GroupBigCategory(nil), groupBigCategoriesList...), nil
+}
+
+func (r *CachedGroupCategoriesRepository) GetGroupMediumCategoriesList() ([]model.GroupAssociatedCategory, error) {
+	r.mu.RLock()
+	groupMediumCategoriesList := r.groupMediumCategoriesList
+	r.mu.RUnlock()
+
+	if groupMediumCategoriesList == nil {
+		var err error
+		if groupMediumCategoriesList, err = r.GroupCategoriesRepository.GetGroupMediumCategoriesList(); err != nil {
+			return nil, err
+		}
+
+		r.mu.Lock()
+		r.groupMediumCategoriesList = groupMediumCategoriesList
+		r.mu.Unlock()
+	}
+
+	return append([]model.GroupAssociatedCategory(nil), groupMediumCategoriesList...), nil
+}
+
+func (r *CachedGroupCategoriesRepository) GetGroupCategoryTranslationsList(locale string) ([]model.CategoryTranslation, error) {
+	r.mu.RLock()
+	categoryTranslationsList, ok := r.categoryTranslationsMap[locale]
+	r.mu.RUnlock()
+
+	if !ok {
+		var err error
+		if categoryTranslationsList, err = r.GroupCategoriesRepository.GetGroupCategoryTranslationsList(locale); err != nil {
+			return nil, err
+		}
+
+		r.mu.Lock()
+		r.categoryTranslationsMap[locale] = categoryTranslationsList
+		r.mu.Unlock()
+	}
+
+	return append([]model.CategoryTranslation(nil), categoryTranslationsList...), nil
+}
+
+func (r *CachedGroupCategoriesRepository) GetGroupCustomCategoriesList(groupID int) ([]model.GroupAssociatedCategory, error) {
+	now := r.now()
+
+	r.mu.RLock()
+	entry, ok := r.groupCustomCategoriesLists[groupID]
+	generation := r.generation
+	r.mu.RUnlock()
+
+	groupCustomCategoriesList := entry.groupCustomCategoriesList
+	if !ok || !now.Before(entry.expiresAt) {
+		var err error
+		if groupCustomCategoriesList, err = r.GroupCategoriesRepository.GetGroupCustomCategoriesList(groupID); err != nil {
+			return nil, err
+		}
+
+		// The list is not cached if custom categories were changed while it was being read,
+		// since it may already be out of date.
+		r.mu.Lock()
+		if r.generation == generation {
+			r.setGroupCustomCategoriesList(groupID, groupCustomCategoriesList, now)
+		}
+		r.mu.Unlock()
+	}
+
+	return append([]model.GroupAssociatedCategory(nil), groupCustomCategoriesList...), nil
+}
+
+// setGroupCustomCategoriesList must be called with r.mu held.
+func (r *CachedGroupCategoriesRepository) setGroupCustomCategoriesList(groupID int, groupCustomCategoriesList []model.GroupAssociatedCategory, now time.Time) {
+	if _, ok := r.groupCustomCategoriesLists[groupID]; !ok && len(r.groupCustomCategoriesLists) >= maxCustomCategoriesCacheEntries {
+		for key, entry := range r.groupCustomCategoriesLists {
+			if !now.Before(entry.expiresAt) {
+				delete(r.groupCustomCategoriesLists, key)
+			}
+		}
+
+		for key := range r.groupCustomCategoriesLists {
+			if len(r.groupCustomCategoriesLists) < maxCustomCategoriesCacheEntries {
+				break
+			}
+
+			delete(r.groupCustomCategoriesLists, key)
+		}
+	}
+
+	r.groupCustomCategoriesLists[groupID] = groupCustomCategoriesCacheEntry{
+		groupCustomCategoriesList: groupCustomCategoriesList,
+		expiresAt:                 now.Add(customCategoriesCacheTTL),
+	}
+}
+
+func (r *CachedGroupCategoriesRepository) PostGroupCustomCategory(groupCustomCategory *model.GroupCustomCategory, groupID int) (sql.Result, error) {
+	defer r.invalidateGroupCustomCategoriesList(groupID)
+
+	return r.GroupCategoriesRepository.PostGroupCustomCategory(groupCustomCategory, groupID)
+}
+
+func (r *CachedGroupCategoriesRepository) PostGroupCustomCategoriesList(groupCustomCategoriesList []model.GroupCustomCategory, groupID int) error {
+	defer r.invalidateGroupCustomCategoriesList(groupID)
+
+	return r.GroupCategoriesRepository.PostGroupCustomCategoriesList(groupCustomCategoriesList, groupID)
+}
+
+func (r *CachedGroupCategoriesRepository) PutGroupCustomCategory(groupCustomCategory *model.GroupCustomCategory) error {
+	defer r.invalidateGroupCustomCategoriesListByGroupCustomCategoryID(groupCustomCategory.ID)
+
+	return r.GroupCategoriesRepository.PutGroupCustomCategory(groupCustomCategory)
+}
+
+func (r *CachedGroupCategoriesRepository) DeleteGroupCustomCategory(previousGroupCustomCategoryID int, replaceCategoriesID model.CategoriesID) error {
+	defer r.invalidateGroupCustomCategoriesListByGroupCustomCategoryID(previousGroupCustomCategoryID)
+
+	return r.GroupCategoriesRepository.DeleteGroupCustomCategory(previousGroupCustomCategoryID, replaceCategoriesID)
+}
+
+func (r *CachedGroupCategoriesRepository) invalidateGroupCustomCategoriesList(groupID int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.generation++
+	delete(r.groupCustomCategoriesLists, groupID)
+}
+
+// invalidateGroupCustomCategoriesListByGroupCustomCategoryID drops the cached list which contains the group custom category.
+func (r *CachedGroupCategoriesRepository) invalidateGroupCustomCategoriesListByGroupCustomCategoryID(groupCustomCategoryID int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.generation++
+	for groupID, entry := range r.groupCustomCategoriesLists {
+		for _, groupCustomCategory := range entry.groupCustomCategoriesList {
+			if groupCustomCategory.ID == groupCustomCategoryID {
+				delete(r.groupCustomCategoriesLists, groupID)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hryze/kakeibo-app-api/account-rest-service/domain/model"
+	"github.com/hryze/kakeibo-app-api/account-rest-service/domain/repository"
+)
+
+type stubGroupCategoriesRepository struct {
+	repository.GroupCategoriesRepository
+	groupCustomCategoriesLists map[int][]model.GroupAssociatedCategory
+	readCount                  int
+}
+
+func (r *stubGroupCategoriesRepository) GetGroupCustomCategoriesList(groupID int) ([]model.GroupAssociatedCategory, error) {
+	r.readCount++
+
+	return append([]model.GroupAssociatedCategory(nil), r.groupCustomCategoriesLists[groupID]...), nil
+}
+
+func (r *stubGroupCategoriesRepository) PostGroupCustomCategory(groupCustomCategory *model.GroupCustomCategory, groupID int) (sql.Result, error) {
+	r.groupCustomCategoriesLists[groupID] = append(r.groupCustomCategoriesLists[groupID], model.GroupAssociatedCategory{
+		CategoryType:  "CustomCategory",
+		ID:            groupCustomCategory.ID,
+		Name:          groupCustomCategory.Name,
+		BigCategoryID: groupCustomCategory.BigCategoryID,
+	})
+
+	return nil, nil
+}
+
+func (r *stubGroupCategoriesRepository) PutGroupCustomCategory(groupCustomCategory *model.GroupCustomCategory) error {
+	for groupID, groupCustomCategoriesList := range r.groupCustomCategoriesLists {
+		for i := range groupCustomCategoriesList {
+			if groupCustomCategoriesList[i].ID == groupCustomCategory.ID {
+				r.groupCustomCategoriesLists[groupID][i].Name = groupCustomCategory.Name
+			}
+		}
+	}
+
+	return nil
+}
+
+func TestCachedGroupCategoriesRepository_GetGroupCustomCategoriesList(t *testing.T) {
+	now := time.Date(2020, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		write         func(r *CachedGroupCategoriesRepository) error
+		elapsed       time.Duration
+		wantList      []model.GroupAssociatedCategory
+		wantReadCount int
+	}{
+		{
+			name:    "cached list is reused",
+			write:   func(r *CachedGroupCategoriesRepository) error { return nil },
+			elapsed: 30 * time.Second,
+			wantList: []model.GroupAssociatedCategory{
+				{CategoryType: "CustomCategory", ID: 1, Name: "米", BigCategoryID: 2},
+			},
+			wantReadCount: 1,
+		},
+		{
+			name: "posting a group custom category invalidates the list",
+			write: func(r *CachedGroupCategoriesRepository) error {
+				_, err := r.PostGroupCustomCategory(&model.GroupCustomCategory{ID: 2, Name: "パン", BigCategoryID: 2}, 1)
+				return err
+			},
+			wantList: []model.GroupAssociatedCategory{
+				{CategoryType: "CustomCategory", ID: 1, Name: "米", BigCategoryID: 2},
+				{CategoryType: "CustomCategory", ID: 2, Name: "パン", BigCategoryID: 2},
+			},
+			wantReadCount: 2,
+		},
+		{
+			name: "putting a group custom category invalidates the list",
+			write: func(r *CachedGroupCategoriesRepository) error {
+				return r.PutGroupCustomCategory(&model.GroupCustomCategory{ID: 1, Name: "お米", BigCategoryID: 2})
+			},
+			wantList: []model.GroupAssociatedCategory{
+				{CategoryType: "CustomCategory", ID: 1, Name: "お米", BigCategoryID: 2},
+			},
+			wantReadCount: 2,
+		},
+		{
+			name:    "expired list is read again",
+			write:   func(r *CachedGroupCategoriesRepository) error { return nil },
+			elapsed: customCategoriesCacheTTL,
+			wantList: []model.GroupAssociatedCategory{
+				{CategoryType: "CustomCategory", ID: 1, Name: "米", BigCategoryID: 2},
+			},
+			wantReadCount: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &stubGroupCategoriesRepository{
+				groupCustomCategoriesLists: map[int][]model.GroupAssociatedCategory{
+					1: {
+						{CategoryType: "CustomCategory", ID: 1, Name: "米", BigCategoryID: 2},
+					},
+				},
+			}
+
+			currentTime := now
+			r := NewCachedGroupCategoriesRepository(stub)
+			r.now = func() time.Time { return currentTime }
+
+			if _, err := r.GetGroupCustomCategoriesList(1); err != nil {
+				t.Fatalf("unexpected error by GetGroupCustomCategoriesList() '%#v'", err)
+			}
+
+			if err := tt.write(r); err != nil {
+				t.Fatalf("unexpected error '%#v'", err)
+			}
+
+			currentTime = now.Add(tt.elapsed)
+
+			got, err := r.GetGroupCustomCategoriesList(1)
+			if err != nil {
+				t.Fatalf("unexpected error by GetGroupCustomCategoriesList() '%#v'", err)
+			}
+
+			if diff := cmp.Diff(tt.wantList, got); len(diff) != 0 {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+
+			if stub.readCount != tt.wantReadCount {
+				t.Errorf("readCount = %d, want %d", stub.readCount, tt.wantReadCount)
+			}
+		})
+	}
+}
+
+func TestCachedGroupCategoriesRepository_GetGroupCustomCategoriesList_Bounded(t *testing.T) {
+	stub := &stubGroupCategoriesRepository{groupCustomCategoriesLists: make(map[int][]model.GroupAssociatedCategory)}
+	r := NewCachedGroupCategoriesRepository(stub)
+
+	for i := 0; i < maxCustomCategoriesCacheEntries*2; i++ {
+		if _, err := r.GetGroupCustomCategoriesList(i); err != nil {
+			t.Fatalf("unexpected error by GetGroupCustomCategoriesList() '%#v'", err)
+		}
+	}
+
+	if len(r.groupCustomCategoriesLists) != maxCustomCategoriesCacheEntries {
+		t.Errorf("len(groupCustomCategoriesLists) = %d, want %d", len(r.groupCustomCategoriesLists), maxCustomCategoriesCacheEntries)
+	}
+}
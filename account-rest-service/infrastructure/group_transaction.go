@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/hryze/kakeibo-app-api/account-rest-service/domain/model"
+	"github.com/hryze/kakeibo-app-api/account-rest-service/domain/repository"
 )
 
 type GroupTransactionsRepository struct {
@@ -193,14 +194,36 @@ func (r *GroupTransactionsRepository) GetGroupTransaction(groupTransactionID int
 	return &groupTransactionSender, nil
 }
 
+func (r *GroupTransactionsRepository) GetGroupTransactionIDByIdempotencyKey(idempotencyKey string, groupID int) (int, error) {
+	query := `
+        SELECT
+            id
+        FROM
+            group_transactions
+        WHERE
+            group_id = ?
+        AND
+            idempotency_key = ?`
+
+	var groupTransactionID int
+	if err := r.MySQLHandler.conn.QueryRowx(query, groupID, idempotencyKey).Scan(&groupTransactionID); err != nil {
+		return 0, err
+	}
+
+	return groupTransactionID, nil
+}
+
 func (r *GroupTransactionsRepository) PostGroupTransaction(groupTransaction *model.GroupTransactionReceiver, groupID int, postedUserID string) (sql.Result, error) {
 	query := `
         INSERT INTO group_transactions
-            (transaction_type, transaction_date, shop, memo, amount, group_id, posted_user_id, payment_user_id, big_category_id, medium_category_id, custom_category_id)
+            (transaction_type, transaction_date, shop, memo, amount, group_id, posted_user_id, payment_user_id, big_category_id, medium_category_id, custom_category_id, idempotency_key)
         VALUES
-            (?,?,?,?,?,?,?,?,?,?,?)`
+            (?,?,?,?,?,?,?,?,?,?,?,?)`
 
-	result, err := r.MySQLHandler.conn.Exec(query, groupTransaction.TransactionType, groupTransaction.TransactionDate, groupTransaction.Shop, groupTransaction.Memo, groupTransaction.Amount, groupID, postedUserID, groupTransaction.PaymentUserID, groupTransaction.BigCategoryID, groupTransaction.MediumCategoryID, groupTransaction.CustomCategoryID)
+	result, err := r.MySQLHandler.conn.Exec(query, groupTransaction.TransactionType, groupTransaction.TransactionDate, groupTransaction.Shop, groupTransaction.Memo, groupTransaction.Amount, groupID, postedUserID, groupTransaction.PaymentUserID, groupTransaction.BigCategoryID, groupTransaction.MediumCategoryID, groupTransaction.CustomCategoryID, groupTransaction.IdempotencyKey)
+	if err != nil && isDuplicateEntryError(err) {
+		return nil, repository.ErrDuplicateIdempotencyKey
+	}
 
 	return result, err
 }
@@ -632,3 +655,138 @@ func (r *GroupTransactionsRepository) GetYearlyGroupAccountsList(firstDayOfYear
 
 	return yearlyGroupAccountsList, nil
 }
+
+// GetUnsettledGroupAccountsList returns the group accounts paid or received by the user, whose payment or receipt is not confirmed yet.
+func (r *GroupTransactionsRepository) GetUnsettledGroupAccountsList(groupID int, userID string) ([]model.GroupAccount, error) {
+	query := `
+        SELECT
+            id,
+            years_months,
+            payer_user_id,
+            recipient_user_id,
+            payment_amount,
+            payment_confirmation,
+            receipt_confirmation,
+            group_id
+        FROM
+            group_accounts
+        WHERE
+            group_id = ?
+        AND
+            (payer_user_id = ? OR recipient_user_id = ?)
+        AND
+            (payment_confirmation = b'0' OR receipt_confirmation = b'0')
+        ORDER BY
+            years_months, id`
+
+	rows, err := r.MySQLHandler.conn.Queryx(query, groupID, userID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	unsettledGroupAccountsList := make([]model.GroupAccount, 0)
+	for rows.Next() {
+		var groupAccount model.GroupAccount
+		if err := rows.StructScan(&groupAccount); err != nil {
+			return nil, err
+		}
+
+		unsettledGroupAccountsList = append(unsettledGroupAccountsList, groupAccount)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return unsettledGroupAccountsList, nil
+}
+
+func (r *GroupTransactionsRepository) GetMonthlyGroupTransactionTotalAmountByMediumCategory(groupID int, firstDay time.Time, lastDay time.Time) ([]model.GroupTransactionTotalAmountByMediumCategory, error) {
+	query := `
+        SELECT
+            medium_category_id,
+            custom_category_id,
+            SUM(amount) total_amount
+        FROM
+            group_transactions
+        WHERE
+            group_id = ?
+        AND
+            transaction_type = "expense"
+        AND
+            transaction_date >= ?
+        AND
+            transaction_date <= ?
+        GROUP BY
+            medium_category_id,
+            custom_category_id`
+
+	rows, err := r.MySQLHandler.conn.Queryx(query, groupID, firstDay, lastDay)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	groupTransactionTotalAmountByMediumCategoryList := make([]model.GroupTransactionTotalAmountByMediumCategory, 0)
+	for rows.Next() {
+		var groupTransactionTotalAmountByMediumCategory model.GroupTransactionTotalAmountByMediumCategory
+		if err := rows.StructScan(&groupTransactionTotalAmountByMediumCategory); err != nil {
+			return nil, err
+		}
+
+		groupTransactionTotalAmountByMediumCategoryList = append(groupTransactionTotalAmountByMediumCategoryList, groupTransactionTotalAmountByMediumCategory)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return groupTransactionTotalAmountByMediumCategoryList, nil
+}
+
+func (r *GroupTransactionsRepository) GetMonthlyGroupTransactionTotalAmountByBigCategoryList(groupID int, firstDay time.Time, lastDay time.Time) ([]model.MonthlyGroupTransactionTotalAmountByBigCategory, error) {
+	query := `
+        SELECT
+            CAST(DATE_FORMAT(transaction_date, "%Y-%m-01") AS DATE) years_months,
+            big_category_id,
+            SUM(amount) total_amount
+        FROM
+            group_transactions
+        WHERE
+            group_id = ?
+        AND
+            transaction_type = "expense"
+        AND
+            transaction_date >= ?
+        AND
+            transaction_date <= ?
+        GROUP BY
+            years_months,
+            big_category_id
+        ORDER BY
+            years_months,
+            big_category_id`
+
+	rows, err := r.MySQLHandler.conn.Queryx(query, groupID, firstDay, lastDay)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	monthlyGroupTransactionTotalAmountByBigCategoryList := make([]model.MonthlyGroupTransactionTotalAmountByBigCategory, 0)
+	for rows.Next() {
+		var monthlyGroupTransactionTotalAmountByBigCategory model.MonthlyGroupTransactionTotalAmountByBigCategory
+		if err := rows.StructScan(&monthlyGroupTransactionTotalAmountByBigCategory); err != nil {
+			return nil, err
+		}
+
+		monthlyGroupTransactionTotalAmountByBigCategoryList = append(monthlyGroupTransactionTotalAmountByBigCategoryList, monthlyGroupTransactionTotalAmountByBigCategory)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return monthlyGroupTransactionTotalAmountByBigCategoryList, nil
+}
@@ -0,0 +1,38 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/hryze/kakeibo-app-api/account-rest-service/domain/model"
+)
+
+type BudgetNotifier struct {
+	logger *log.Logger
+}
+
+func NewBudgetNotifier(logger *log.Logger) *BudgetNotifier {
+	return &BudgetNotifier{logger}
+}
+
+func (n *BudgetNotifier) NotifyBudgetThreshold(budgetThresholdEvent model.BudgetThresholdEvent) error {
+	b, err := json.Marshal(budgetThresholdEvent)
+	if err != nil {
+		return err
+	}
+
+	n.logger.Printf("budget threshold reached: %s", b)
+
+	return nil
+}
+
+func (n *BudgetNotifier) NotifyGroupBudgetThreshold(groupBudgetThresholdEvent model.GroupBudgetThresholdEvent) error {
+	b, err := json.Marshal(groupBudgetThresholdEvent)
+	if err != nil {
+		return err
+	}
+
+	n.logger.Printf("group budget threshold reached: %s", b)
+
+	return nil
+}
@@ -1,8 +1,10 @@
 package infrastructure
 
 import (
+	"errors"
+
 	"github.com/garyburd/redigo/redis"
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
 
 	"github.com/hryze/kakeibo-app-api/account-rest-service/config"
@@ -62,3 +64,10 @@ func NewRedisHandler() (*RedisHandler, error) {
 		pool: pool,
 	}, nil
 }
+
+// isDuplicateEntryError reports whether err is caused by a violation of a unique key.
+func isDuplicateEntryError(err error) bool {
+	var mysqlErr *mysql.MySQLError
+
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == 1062
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/hryze/kakeibo-app-api/account-rest-service/domain/model"
+	"github.com/hryze/kakeibo-app-api/account-rest-service/domain/repository"
 )
 
 type TransactionsRepository struct {
@@ -183,14 +184,36 @@ func (r *TransactionsRepository) GetTransaction(transactionSender *model.Transac
 	return transactionSender, nil
 }
 
+func (r *TransactionsRepository) GetTransactionIDByIdempotencyKey(idempotencyKey string, userID string) (int, error) {
+	query := `
+        SELECT
+            id
+        FROM
+            transactions
+        WHERE
+            user_id = ?
+        AND
+            idempotency_key = ?`
+
+	var transactionID int
+	if err := r.MySQLHandler.conn.QueryRowx(query, userID, idempotencyKey).Scan(&transactionID); err != nil {
+		return 0, err
+	}
+
+	return transactionID, nil
+}
+
 func (r *TransactionsRepository) PostTransaction(transaction *model.TransactionReceiver, userID string) (sql.Result, error) {
 	query := `
         INSERT INTO transactions
-            (transaction_type, transaction_date, shop, memo, amount, user_id, big_category_id, medium_category_id, custom_category_id)
+            (transaction_type, transaction_date, shop, memo, amount, user_id, big_category_id, medium_category_id, custom_category_id, idempotency_key)
         VALUES
-            (?,?,?,?,?,?,?,?,?)`
+            (?,?,?,?,?,?,?,?,?,?)`
 
-	result, err := r.MySQLHandler.conn.Exec(query, transaction.TransactionType, transaction.TransactionDate, transaction.Shop, transaction.Memo, transaction.Amount, userID, transaction.BigCategoryID, transaction.MediumCategoryID, transaction.CustomCategoryID)
+	result, err := r.MySQLHandler.conn.Exec(query, transaction.TransactionType, transaction.TransactionDate, transaction.Shop, transaction.Memo, transaction.Amount, userID, transaction.BigCategoryID, transaction.MediumCategoryID, transaction.CustomCategoryID, transaction.IdempotencyKey)
+	if err != nil && isDuplicateEntryError(err) {
+		return nil, repository.ErrDuplicateIdempotencyKey
+	}
 
 	return result, err
 }
@@ -359,3 +382,92 @@ func (r *TransactionsRepository) GetMonthlyTransactionTotalAmountByBigCategory(u
 
 	return transactionTotalAmountByBigCategoryList, nil
 }
+
+func (r *TransactionsRepository) GetMonthlyTransactionTotalAmountByMediumCategory(userID string, firstDay time.Time, lastDay time.Time) ([]model.TransactionTotalAmountByMediumCategory, error) {
+	query := `
+        SELECT
+            medium_category_id,
+            custom_category_id,
+            SUM(amount) total_amount
+        FROM
+            transactions
+        WHERE
+            user_id = ?
+        AND
+            transaction_type = "expense"
+        AND
+            transaction_date >= ?
+        AND
+            transaction_date <= ?
+        GROUP BY
+            medium_category_id,
+            custom_category_id`
+
+	rows, err := r.MySQLHandler.conn.Queryx(query, userID, firstDay, lastDay)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	transactionTotalAmountByMediumCategoryList := make([]model.TransactionTotalAmountByMediumCategory, 0)
+	for rows.Next() {
+		var transactionTotalAmountByMediumCategory model.TransactionTotalAmountByMediumCategory
+		if err := rows.StructScan(&transactionTotalAmountByMediumCategory); err != nil {
+			return nil, err
+		}
+
+		transactionTotalAmountByMediumCategoryList = append(transactionTotalAmountByMediumCategoryList, transactionTotalAmountByMediumCategory)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return transactionTotalAmountByMediumCategoryList, nil
+}
+
+func (r *TransactionsRepository) GetMonthlyTransactionTotalAmountByBigCategoryList(userID string, firstDay time.Time, lastDay time.Time) ([]model.MonthlyTransactionTotalAmountByBigCategory, error) {
+	query := `
+        SELECT
+            CAST(DATE_FORMAT(transaction_date, "%Y-%m-01") AS DATE) years_months,
+            big_category_id,
+            SUM(amount) total_amount
+        FROM
+            transactions
+        WHERE
+            user_id = ?
+        AND
+            transaction_type = "expense"
+        AND
+            transaction_date >= ?
+        AND
+            transaction_date <= ?
+        GROUP BY
+            years_months,
+            big_category_id
+        ORDER BY
+            years_months,
+            big_category_id`
+
+	rows, err := r.MySQLHandler.conn.Queryx(query, userID, firstDay, lastDay)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	monthlyTransactionTotalAmountByBigCategoryList := make([]model.MonthlyTransactionTotalAmountByBigCategory, 0)
+	for rows.Next() {
+		var monthlyTransactionTotalAmountByBigCategory model.MonthlyTransactionTotalAmountByBigCategory
+		if err := rows.StructScan(&monthlyTransactionTotalAmountByBigCategory); err != nil {
+			return nil, err
+		}
+
+		monthlyTransactionTotalAmountByBigCategoryList = append(monthlyTransactionTotalAmountByBigCategoryList, monthlyTransactionTotalAmountByBigCategory)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return monthlyTransactionTotalAmountByBigCategoryList, nil
+}
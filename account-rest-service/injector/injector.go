@@ -2,6 +2,7 @@ package injector
 
 import (
 	"fmt"
+	"log"
 	"os"
 
 	"github.com/hryze/kakeibo-app-api/account-rest-service/handler"
@@ -33,11 +34,12 @@ func InjectDBHandler() *handler.DBHandler {
 		HealthRepo:            infrastructure.NewHealthRepository(InjectRedis(), InjectMySQL()),
 		AuthRepo:              infrastructure.NewAuthRepository(InjectRedis()),
 		TransactionsRepo:      infrastructure.NewTransactionsRepository(InjectMySQL()),
-		CategoriesRepo:        infrastructure.NewCategoriesRepository(InjectMySQL()),
+		CategoriesRepo:        infrastructure.NewCachedCategoriesRepository(infrastructure.NewCategoriesRepository(InjectMySQL())),
 		BudgetsRepo:           infrastructure.NewBudgetsRepository(InjectMySQL()),
 		GroupTransactionsRepo: infrastructure.NewGroupTransactionsRepository(InjectMySQL()),
-		GroupCategoriesRepo:   infrastructure.NewGroupCategoriesRepository(InjectMySQL()),
+		GroupCategoriesRepo:   infrastructure.NewCachedGroupCategoriesRepository(infrastructure.NewGroupCategoriesRepository(InjectMySQL())),
 		GroupBudgetsRepo:      infrastructure.NewGroupBudgetsRepository(InjectMySQL()),
 		TimeManage:            handler.NewRealTime(),
+		BudgetNotifier:        infrastructure.NewBudgetNotifier(log.New(os.Stdout, "", log.LstdFlags)),
 	}
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/rs/cors"
 
 	"github.com/hryze/kakeibo-app-api/account-rest-service/config"
+	"github.com/hryze/kakeibo-app-api/account-rest-service/handler"
 	"github.com/hryze/kakeibo-app-api/account-rest-service/injector"
 )
 
@@ -21,11 +22,16 @@ func Run() error {
 	h := injector.InjectDBHandler()
 
 	router := mux.NewRouter()
+	router.Use(handler.LocaleMiddleware)
 	router.HandleFunc("/readyz", h.Readyz).Methods("GET")
 	router.HandleFunc("/categories", h.GetCategoriesList).Methods("GET")
 	router.HandleFunc("/categories/custom-categories", h.PostCustomCategory).Methods("POST")
 	router.HandleFunc("/categories/custom-categories/{id:[0-9]+}", h.PutCustomCategory).Methods("PUT")
 	router.HandleFunc("/categories/custom-categories/{id:[0-9]+}", h.DeleteCustomCategory).Methods("DELETE")
+	router.HandleFunc("/categories/custom-categories/{id:[0-9]+}/archive", h.PutCustomCategoryArchive).Methods("PUT")
+	router.HandleFunc("/categories/custom-categories/{id:[0-9]+}/merge", h.MergeCustomCategory).Methods("POST")
+	router.HandleFunc("/categories/custom-categories/order", h.PutCustomCategoriesOrder).Methods("PUT")
+	router.HandleFunc("/categories/custom-categories/copy-from-group/{group_id:[0-9]+}", h.CopyGroupCustomCategoriesToPersonal).Methods("POST")
 	router.HandleFunc("/categories/name", h.GetCategoriesName).Methods("GET")
 	router.HandleFunc("/categories/names", h.GetCategoriesNameList).Methods("GET")
 	router.HandleFunc("/transactions/{year_month:[0-9]{4}-[0-9]{2}}", h.GetMonthlyTransactionsList).Methods("GET")
@@ -38,15 +44,26 @@ func Run() error {
 	router.HandleFunc("/standard-budgets", h.PostInitStandardBudgets).Methods("POST")
 	router.HandleFunc("/standard-budgets", h.GetStandardBudgets).Methods("GET")
 	router.HandleFunc("/standard-budgets", h.PutStandardBudgets).Methods("PUT")
+	router.HandleFunc("/standard-budgets/rollover", h.GetBudgetRolloverSettings).Methods("GET")
+	router.HandleFunc("/standard-budgets/rollover", h.PutBudgetRolloverSettings).Methods("PUT")
 	router.HandleFunc("/custom-budgets/{year_month:[0-9]{4}-[0-9]{2}}", h.GetCustomBudgets).Methods("GET")
 	router.HandleFunc("/custom-budgets/{year_month:[0-9]{4}-[0-9]{2}}", h.PostCustomBudgets).Methods("POST")
 	router.HandleFunc("/custom-budgets/{year_month:[0-9]{4}-[0-9]{2}}", h.PutCustomBudgets).Methods("PUT")
 	router.HandleFunc("/custom-budgets/{year_month:[0-9]{4}-[0-9]{2}}", h.DeleteCustomBudgets).Methods("DELETE")
+	router.HandleFunc("/custom-budgets/{year_month:[0-9]{4}-[0-9]{2}}/copy", h.CopyCustomBudgets).Methods("POST")
+	router.HandleFunc("/budget-templates", h.GetBudgetTemplatesList).Methods("GET")
+	router.HandleFunc("/budget-templates", h.PostBudgetTemplate).Methods("POST")
+	router.HandleFunc("/budget-templates/{id:[0-9]+}", h.PutBudgetTemplate).Methods("PUT")
+	router.HandleFunc("/budget-templates/{id:[0-9]+}", h.DeleteBudgetTemplate).Methods("DELETE")
+	router.HandleFunc("/budget-templates/{id:[0-9]+}/apply", h.ApplyBudgetTemplate).Methods("POST")
 	router.HandleFunc("/budgets/{year:[0-9]{4}}", h.GetYearlyBudgets).Methods("GET")
+	router.HandleFunc("/budgets/{year_month:[0-9]{4}-[0-9]{2}}/status", h.GetBudgetStatus).Methods("GET")
+	router.HandleFunc("/budgets/forecast", h.GetBudgetForecast).Methods("GET")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/categories", h.GetGroupCategoriesList).Methods("GET")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/categories/custom-categories", h.PostGroupCustomCategory).Methods("POST")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/categories/custom-categories/{id:[0-9]+}", h.PutGroupCustomCategory).Methods("PUT")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/categories/custom-categories/{id:[0-9]+}", h.DeleteGroupCustomCategory).Methods("DELETE")
+	router.HandleFunc("/groups/{group_id:[0-9]+}/categories/custom-categories/copy-from-personal", h.CopyCustomCategoriesToGroup).Methods("POST")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/categories/name", h.GetGroupCategoriesName).Methods("GET")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/categories/names", h.GetGroupCategoriesNameList).Methods("GET")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/transactions/{year_month:[0-9]{4}-[0-9]{2}}", h.GetMonthlyGroupTransactionsList).Methods("GET")
@@ -57,6 +74,7 @@ func Run() error {
 	router.HandleFunc("/groups/{group_id:[0-9]+}/transactions/search", h.SearchGroupTransactionsList).Methods("GET")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/transactions/related-shopping-list", h.GetGroupShoppingItemRelatedTransactionDataList).Methods("GET")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/transactions/{year:[0-9]{4}}/account", h.GetYearlyAccountingStatus).Methods("GET")
+	router.HandleFunc("/groups/{group_id:[0-9]+}/transactions/account/unsettled", h.GetUnsettledGroupAccountsList).Methods("GET")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/transactions/{year_month:[0-9]{4}-[0-9]{2}}/account", h.GetMonthlyGroupTransactionsAccount).Methods("GET")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/transactions/{year_month:[0-9]{4}-[0-9]{2}}/account", h.PostMonthlyGroupTransactionsAccount).Methods("POST")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/transactions/{year_month:[0-9]{4}-[0-9]{2}}/account/{id:[0-9]+}", h.PutMonthlyGroupTransactionsAccount).Methods("PUT")
@@ -64,11 +82,18 @@ func Run() error {
 	router.HandleFunc("/groups/{group_id:[0-9]+}/standard-budgets", h.PostInitGroupStandardBudgets).Methods("POST")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/standard-budgets", h.GetGroupStandardBudgets).Methods("GET")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/standard-budgets", h.PutGroupStandardBudgets).Methods("PUT")
+	router.HandleFunc("/groups/{group_id:[0-9]+}/standard-budgets/rollover", h.GetGroupBudgetRolloverSettings).Methods("GET")
+	router.HandleFunc("/groups/{group_id:[0-9]+}/standard-budgets/rollover", h.PutGroupBudgetRolloverSettings).Methods("PUT")
+	router.HandleFunc("/groups/{group_id:[0-9]+}/standard-budgets/copy-from-personal", h.CopyStandardBudgetsToGroup).Methods("PUT")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/custom-budgets/{year_month:[0-9]{4}-[0-9]{2}}", h.GetGroupCustomBudgets).Methods("GET")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/custom-budgets/{year_month:[0-9]{4}-[0-9]{2}}", h.PostGroupCustomBudgets).Methods("POST")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/custom-budgets/{year_month:[0-9]{4}-[0-9]{2}}", h.PutGroupCustomBudgets).Methods("PUT")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/custom-budgets/{year_month:[0-9]{4}-[0-9]{2}}", h.DeleteGroupCustomBudgets).Methods("DELETE")
+	router.HandleFunc("/groups/{group_id:[0-9]+}/custom-budgets/{year_month:[0-9]{4}-[0-9]{2}}/copy", h.CopyGroupCustomBudgets).Methods("POST")
+	router.HandleFunc("/groups/{group_id:[0-9]+}/custom-budgets/{year_month:[0-9]{4}-[0-9]{2}}/copy-from-personal", h.CopyCustomBudgetsToGroup).Methods("POST")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/budgets/{year:[0-9]{4}}", h.GetYearlyGroupBudgets).Methods("GET")
+	router.HandleFunc("/groups/{group_id:[0-9]+}/budgets/{year_month:[0-9]{4}-[0-9]{2}}/status", h.GetGroupBudgetStatus).Methods("GET")
+	router.HandleFunc("/groups/{group_id:[0-9]+}/budgets/forecast", h.GetGroupBudgetForecast).Methods("GET")
 
 	corsWrapper := cors.New(cors.Options{
 		AllowedOrigins:   config.Env.Cors.AllowedOrigins,
@@ -17,6 +17,12 @@ func init() {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
+
+	// envconfig only requires the variable to be set, and an empty token would authenticate a bare "Bearer " header.
+	if len(Env.ServiceAuth.Token) == 0 {
+		fmt.Fprintln(os.Stderr, "required key SERVICE_AUTH_TOKEN has empty value")
+		os.Exit(1)
+	}
 }
 
 type ENV struct {
@@ -27,6 +33,7 @@ type ENV struct {
 	Redis
 	UserApi
 	AccountApi
+	ServiceAuth
 }
 
 type Server struct {
@@ -64,3 +71,7 @@ type AccountApi struct {
 	Host string `envconfig:"ACCOUNT_HOST" required:"true"`
 	Port int    `envconfig:"ACCOUNT_PORT" required:"true"`
 }
+
+type ServiceAuth struct {
+	Token string `envconfig:"SERVICE_AUTH_TOKEN" required:"true"`
+}
@@ -11,9 +11,43 @@ CREATE TABLE todo_list
   due_date DATE NOT NULL,
   todo_content VARCHAR(100) NOT NULL,
   complete_flag bit(1) NOT NULL DEFAULT b'0',
+  recurrence_type ENUM('schedule', 'after_completion') DEFAULT NULL,
+  recurrence_rule VARCHAR(255) DEFAULT NULL,
+  recurrence_interval INT DEFAULT NULL,
+  recurrence_start_date DATE DEFAULT NULL,
+  series_id INT DEFAULT NULL,
+  priority TINYINT NOT NULL DEFAULT 0,
   user_id VARCHAR(10) NOT NULL,
   PRIMARY KEY(id),
-  INDEX idx_user_id(user_id)
+  INDEX idx_user_id(user_id),
+  INDEX idx_series_id(series_id)
+);
+
+CREATE TABLE todo_labels
+(
+  id INT NOT NULL AUTO_INCREMENT,
+  todo_id INT NOT NULL,
+  label_name VARCHAR(20) NOT NULL,
+  PRIMARY KEY(id),
+  UNIQUE uq_todo_label(todo_id, label_name),
+  INDEX idx_label_name(label_name),
+  FOREIGN KEY fk_todo_label_id(todo_id)
+    REFERENCES todo_list(id)
+    ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE todo_subtasks
+(
+  id INT NOT NULL AUTO_INCREMENT,
+  todo_id INT NOT NULL,
+  subtask_content VARCHAR(100) NOT NULL,
+  complete_flag bit(1) NOT NULL DEFAULT b'0',
+  subtask_order INT NOT NULL,
+  PRIMARY KEY(id),
+  INDEX idx_todo_id(todo_id),
+  FOREIGN KEY fk_todo_subtask_id(todo_id)
+    REFERENCES todo_list(id)
+    ON DELETE CASCADE ON UPDATE CASCADE
 );
 
 CREATE TABLE regular_shopping_list
@@ -22,11 +56,18 @@ CREATE TABLE regular_shopping_list
   posted_date DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
   updated_date DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
   expected_purchase_date DATE NOT NULL,
-  cycle_type ENUM('daily', 'weekly', 'monthly', 'custom') NOT NULL,
+  cycle_type ENUM('daily', 'weekly', 'monthly', 'custom', 'rrule') NOT NULL,
   cycle INT DEFAULT NULL,
+  recurrence_rule VARCHAR(255) NOT NULL,
+  recurrence_start_date DATE NOT NULL,
+  finished_flag bit(1) NOT NULL DEFAULT b'0',
+  paused_until DATE DEFAULT NULL,
   purchase VARCHAR(50) NOT NULL,
   shop VARCHAR(20) DEFAULT NULL,
   amount INT DEFAULT NULL,
+  quantity INT DEFAULT NULL,
+  unit VARCHAR(10) DEFAULT NULL,
+  unit_price INT DEFAULT NULL,
   big_category_id INT NOT NULL,
   medium_category_id INT DEFAULT NULL,
   custom_category_id INT DEFAULT NULL,
@@ -35,6 +76,19 @@ CREATE TABLE regular_shopping_list
   PRIMARY KEY(id)
 );
 
+CREATE TABLE regular_shopping_skips
+(
+  id INT NOT NULL AUTO_INCREMENT,
+  regular_shopping_list_id INT NOT NULL,
+  skipped_date DATE NOT NULL,
+  skip_reason ENUM('skip', 'pause') NOT NULL,
+  PRIMARY KEY(id),
+  UNIQUE uq_regular_shopping_skip(regular_shopping_list_id, skipped_date),
+  FOREIGN KEY fk_regular_shopping_skip_id(regular_shopping_list_id)
+    REFERENCES regular_shopping_list(id)
+    ON DELETE CASCADE ON UPDATE CASCADE
+);
+
 CREATE TABLE shopping_list
 (
   id INT NOT NULL AUTO_INCREMENT,
@@ -45,6 +99,9 @@ CREATE TABLE shopping_list
   purchase VARCHAR(50) NOT NULL,
   shop VARCHAR(20) DEFAULT NULL,
   amount INT DEFAULT NULL,
+  quantity INT DEFAULT NULL,
+  unit VARCHAR(10) DEFAULT NULL,
+  unit_price INT DEFAULT NULL,
   big_category_id INT NOT NULL,
   medium_category_id INT DEFAULT NULL,
   custom_category_id INT DEFAULT NULL,
@@ -58,6 +115,27 @@ CREATE TABLE shopping_list
     ON DELETE SET NULL ON UPDATE CASCADE
 );
 
+CREATE TABLE shopping_list_custom_category_merges
+(
+  source_custom_category_id INT NOT NULL,
+  target_custom_category_id INT NOT NULL,
+  shopping_item_id_list JSON NOT NULL,
+  regular_shopping_item_id_list JSON NOT NULL,
+  merged_date DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(source_custom_category_id)
+);
+
+CREATE TABLE shop_sections
+(
+  id INT NOT NULL AUTO_INCREMENT,
+  user_id VARCHAR(10) NOT NULL,
+  shop VARCHAR(20) NOT NULL,
+  big_category_id INT NOT NULL,
+  section_order INT NOT NULL,
+  PRIMARY KEY(id),
+  UNIQUE uq_shop_section(user_id, shop, big_category_id)
+);
+
 CREATE TABLE group_todo_list
 (
   id INT NOT NULL AUTO_INCREMENT,
@@ -67,10 +145,59 @@ CREATE TABLE group_todo_list
   due_date DATE NOT NULL,
   todo_content VARCHAR(100) NOT NULL,
   complete_flag bit(1) NOT NULL DEFAULT b'0',
+  recurrence_type ENUM('schedule', 'after_completion') DEFAULT NULL,
+  recurrence_rule VARCHAR(255) DEFAULT NULL,
+  recurrence_interval INT DEFAULT NULL,
+  recurrence_start_date DATE DEFAULT NULL,
+  series_id INT DEFAULT NULL,
+  priority TINYINT NOT NULL DEFAULT 0,
+  completed_user_id VARCHAR(10) DEFAULT NULL,
+  completed_date DATETIME DEFAULT NULL,
   user_id VARCHAR(10) NOT NULL,
   group_id INT NOT NULL,
   PRIMARY KEY(id),
-  INDEX idx_group_id(group_id)
+  INDEX idx_group_id(group_id),
+  INDEX idx_series_id(series_id)
+);
+
+CREATE TABLE group_todo_labels
+(
+  id INT NOT NULL AUTO_INCREMENT,
+  todo_id INT NOT NULL,
+  label_name VARCHAR(20) NOT NULL,
+  PRIMARY KEY(id),
+  UNIQUE uq_group_todo_label(todo_id, label_name),
+  INDEX idx_label_name(label_name),
+  FOREIGN KEY fk_group_todo_label_id(todo_id)
+    REFERENCES group_todo_list(id)
+    ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE group_todo_subtasks
+(
+  id INT NOT NULL AUTO_INCREMENT,
+  todo_id INT NOT NULL,
+  subtask_content VARCHAR(100) NOT NULL,
+  complete_flag bit(1) NOT NULL DEFAULT b'0',
+  subtask_order INT NOT NULL,
+  PRIMARY KEY(id),
+  INDEX idx_todo_id(todo_id),
+  FOREIGN KEY fk_group_todo_subtask_id(todo_id)
+    REFERENCES group_todo_list(id)
+    ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE group_todo_assignees
+(
+  id INT NOT NULL AUTO_INCREMENT,
+  todo_id INT NOT NULL,
+  user_id VARCHAR(10) NOT NULL,
+  PRIMARY KEY(id),
+  UNIQUE uq_group_todo_assignee(todo_id, user_id),
+  INDEX idx_user_id(user_id),
+  FOREIGN KEY fk_group_todo_assignee_id(todo_id)
+    REFERENCES group_todo_list(id)
+    ON DELETE CASCADE ON UPDATE CASCADE
 );
 
 CREATE TABLE group_regular_shopping_list
@@ -79,11 +206,18 @@ CREATE TABLE group_regular_shopping_list
   posted_date DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
   updated_date DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
   expected_purchase_date DATE NOT NULL,
-  cycle_type ENUM('daily', 'weekly', 'monthly', 'custom') NOT NULL,
+  cycle_type ENUM('daily', 'weekly', 'monthly', 'custom', 'rrule') NOT NULL,
   cycle INT DEFAULT NULL,
+  recurrence_rule VARCHAR(255) NOT NULL,
+  recurrence_start_date DATE NOT NULL,
+  finished_flag bit(1) NOT NULL DEFAULT b'0',
+  paused_until DATE DEFAULT NULL,
   purchase VARCHAR(50) NOT NULL,
   shop VARCHAR(20) DEFAULT NULL,
   amount INT DEFAULT NULL,
+  quantity INT DEFAULT NULL,
+  unit VARCHAR(10) DEFAULT NULL,
+  unit_price INT DEFAULT NULL,
   big_category_id INT NOT NULL,
   medium_category_id INT DEFAULT NULL,
   custom_category_id INT DEFAULT NULL,
@@ -93,6 +227,19 @@ CREATE TABLE group_regular_shopping_list
   PRIMARY KEY(id)
 );
 
+CREATE TABLE group_regular_shopping_skips
+(
+  id INT NOT NULL AUTO_INCREMENT,
+  regular_shopping_list_id INT NOT NULL,
+  skipped_date DATE NOT NULL,
+  skip_reason ENUM('skip', 'pause') NOT NULL,
+  PRIMARY KEY(id),
+  UNIQUE uq_group_regular_shopping_skip(regular_shopping_list_id, skipped_date),
+  FOREIGN KEY fk_group_regular_shopping_skip_id(regular_shopping_list_id)
+    REFERENCES group_regular_shopping_list(id)
+    ON DELETE CASCADE ON UPDATE CASCADE
+);
+
 CREATE TABLE group_shopping_list
 (
   id INT NOT NULL AUTO_INCREMENT,
@@ -103,11 +250,17 @@ CREATE TABLE group_shopping_list
   purchase VARCHAR(50) NOT NULL,
   shop VARCHAR(20) DEFAULT NULL,
   amount INT DEFAULT NULL,
+  quantity INT DEFAULT NULL,
+  unit VARCHAR(10) DEFAULT NULL,
+  unit_price INT DEFAULT NULL,
   big_category_id INT NOT NULL,
   medium_category_id INT DEFAULT NULL,
   custom_category_id INT DEFAULT NULL,
   regular_shopping_list_id INT DEFAULT NULL,
   payment_user_id VARCHAR(10) DEFAULT NULL,
+  claimed_user_id VARCHAR(10) DEFAULT NULL,
+  claimed_date DATETIME DEFAULT NULL,
+  claim_version INT NOT NULL DEFAULT 0,
   group_id INT NOT NULL,
   transaction_auto_add bit(1) NOT NULL DEFAULT b'0',
   transaction_id INT DEFAULT NULL,
@@ -142,3 +295,36 @@ CREATE TABLE group_tasks
     ON DELETE SET NULL ON UPDATE CASCADE,
   INDEX idx_group_id(group_id)
 );
+
+CREATE TABLE transaction_outbox
+(
+  id INT NOT NULL AUTO_INCREMENT,
+  posted_date DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_date DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  command ENUM('create', 'update', 'delete') NOT NULL,
+  user_id VARCHAR(10) NOT NULL,
+  group_id INT DEFAULT NULL,
+  shopping_item_id INT NOT NULL,
+  transaction_id INT DEFAULT NULL,
+  idempotency_key VARCHAR(64) NOT NULL,
+  payload TEXT DEFAULT NULL,
+  status ENUM('pending', 'delivered', 'failed', 'cancelled') NOT NULL DEFAULT 'pending',
+  attempts INT NOT NULL DEFAULT 0,
+  next_attempt_date DATETIME NOT NULL,
+  last_error VARCHAR(255) DEFAULT NULL,
+  PRIMARY KEY(id),
+  INDEX idx_status_next_attempt_date(status, next_attempt_date),
+  INDEX idx_shopping_item_id(shopping_item_id)
+);
+
+CREATE TABLE calendar_feed_tokens
+(
+  id INT NOT NULL AUTO_INCREMENT,
+  user_id VARCHAR(10) NOT NULL,
+  group_id INT DEFAULT NULL,
+  token_hash CHAR(64) NOT NULL,
+  created_date DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(id),
+  UNIQUE uq_token_hash(token_hash),
+  INDEX idx_user_id_group_id(user_id, group_id)
+);
@@ -0,0 +1,27 @@
+UPDATE
+  regular_shopping_list
+SET
+  recurrence_rule = CASE DAY(recurrence_start_date)
+    WHEN 31 THEN 'FREQ=MONTHLY;BYMONTHDAY=-1'
+    ELSE CONCAT('FREQ=MONTHLY;BYMONTHDAY=', DAY(recurrence_start_date), ',-1;BYSETPOS=1')
+  END
+WHERE
+  cycle_type = 'monthly'
+AND
+  recurrence_rule = 'FREQ=MONTHLY'
+AND
+  DAY(recurrence_start_date) >= 29;
+
+UPDATE
+  group_regular_shopping_list
+SET
+  recurrence_rule = CASE DAY(recurrence_start_date)
+    WHEN 31 THEN 'FREQ=MONTHLY;BYMONTHDAY=-1'
+    ELSE CONCAT('FREQ=MONTHLY;BYMONTHDAY=', DAY(recurrence_start_date), ',-1;BYSETPOS=1')
+  END
+WHERE
+  cycle_type = 'monthly'
+AND
+  recurrence_rule = 'FREQ=MONTHLY'
+AND
+  DAY(recurrence_start_date) >= 29;
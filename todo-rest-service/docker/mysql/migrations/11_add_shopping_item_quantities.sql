@@ -0,0 +1,19 @@
+ALTER TABLE regular_shopping_list
+  ADD quantity INT DEFAULT NULL AFTER amount,
+  ADD unit VARCHAR(10) DEFAULT NULL AFTER quantity,
+  ADD unit_price INT DEFAULT NULL AFTER unit;
+
+ALTER TABLE shopping_list
+  ADD quantity INT DEFAULT NULL AFTER amount,
+  ADD unit VARCHAR(10) DEFAULT NULL AFTER quantity,
+  ADD unit_price INT DEFAULT NULL AFTER unit;
+
+ALTER TABLE group_regular_shopping_list
+  ADD quantity INT DEFAULT NULL AFTER amount,
+  ADD unit VARCHAR(10) DEFAULT NULL AFTER quantity,
+  ADD unit_price INT DEFAULT NULL AFTER unit;
+
+ALTER TABLE group_shopping_list
+  ADD quantity INT DEFAULT NULL AFTER amount,
+  ADD unit VARCHAR(10) DEFAULT NULL AFTER quantity,
+  ADD unit_price INT DEFAULT NULL AFTER unit;
@@ -0,0 +1,10 @@
+CREATE TABLE shop_sections
+(
+  id INT NOT NULL AUTO_INCREMENT,
+  user_id VARCHAR(10) NOT NULL,
+  shop VARCHAR(20) NOT NULL,
+  big_category_id INT NOT NULL,
+  section_order INT NOT NULL,
+  PRIMARY KEY(id),
+  UNIQUE uq_shop_section(user_id, shop, big_category_id)
+);
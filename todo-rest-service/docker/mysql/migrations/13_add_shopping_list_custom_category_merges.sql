@@ -0,0 +1,9 @@
+CREATE TABLE shopping_list_custom_category_merges
+(
+  source_custom_category_id INT NOT NULL,
+  target_custom_category_id INT NOT NULL,
+  shopping_item_id_list JSON NOT NULL,
+  regular_shopping_item_id_list JSON NOT NULL,
+  merged_date DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(source_custom_category_id)
+);
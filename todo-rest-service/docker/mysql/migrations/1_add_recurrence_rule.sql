@@ -0,0 +1,41 @@
+ALTER TABLE regular_shopping_list
+  MODIFY cycle_type ENUM('daily', 'weekly', 'monthly', 'custom', 'rrule') NOT NULL,
+  ADD recurrence_rule VARCHAR(255) DEFAULT NULL AFTER cycle,
+  ADD recurrence_start_date DATE DEFAULT NULL AFTER recurrence_rule,
+  ADD finished_flag bit(1) NOT NULL DEFAULT b'0' AFTER recurrence_start_date;
+
+UPDATE
+  regular_shopping_list
+SET
+  recurrence_rule = CASE cycle_type
+    WHEN 'daily' THEN 'FREQ=DAILY'
+    WHEN 'weekly' THEN 'FREQ=WEEKLY'
+    WHEN 'monthly' THEN 'FREQ=MONTHLY'
+    WHEN 'custom' THEN CONCAT('FREQ=DAILY;INTERVAL=', cycle)
+  END,
+  recurrence_start_date = expected_purchase_date;
+
+ALTER TABLE regular_shopping_list
+  MODIFY recurrence_rule VARCHAR(255) NOT NULL,
+  MODIFY recurrence_start_date DATE NOT NULL;
+
+ALTER TABLE group_regular_shopping_list
+  MODIFY cycle_type ENUM('daily', 'weekly', 'monthly', 'custom', 'rrule') NOT NULL,
+  ADD recurrence_rule VARCHAR(255) DEFAULT NULL AFTER cycle,
+  ADD recurrence_start_date DATE DEFAULT NULL AFTER recurrence_rule,
+  ADD finished_flag bit(1) NOT NULL DEFAULT b'0' AFTER recurrence_start_date;
+
+UPDATE
+  group_regular_shopping_list
+SET
+  recurrence_rule = CASE cycle_type
+    WHEN 'daily' THEN 'FREQ=DAILY'
+    WHEN 'weekly' THEN 'FREQ=WEEKLY'
+    WHEN 'monthly' THEN 'FREQ=MONTHLY'
+    WHEN 'custom' THEN CONCAT('FREQ=DAILY;INTERVAL=', cycle)
+  END,
+  recurrence_start_date = expected_purchase_date;
+
+ALTER TABLE group_regular_shopping_list
+  MODIFY recurrence_rule VARCHAR(255) NOT NULL,
+  MODIFY recurrence_start_date DATE NOT NULL;
@@ -0,0 +1,31 @@
+ALTER TABLE regular_shopping_list
+  ADD paused_until DATE DEFAULT NULL AFTER finished_flag;
+
+CREATE TABLE regular_shopping_skips
+(
+  id INT NOT NULL AUTO_INCREMENT,
+  regular_shopping_list_id INT NOT NULL,
+  skipped_date DATE NOT NULL,
+  skip_reason ENUM('skip', 'pause') NOT NULL,
+  PRIMARY KEY(id),
+  UNIQUE uq_regular_shopping_skip(regular_shopping_list_id, skipped_date),
+  FOREIGN KEY fk_regular_shopping_skip_id(regular_shopping_list_id)
+    REFERENCES regular_shopping_list(id)
+    ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+ALTER TABLE group_regular_shopping_list
+  ADD paused_until DATE DEFAULT NULL AFTER finished_flag;
+
+CREATE TABLE group_regular_shopping_skips
+(
+  id INT NOT NULL AUTO_INCREMENT,
+  regular_shopping_list_id INT NOT NULL,
+  skipped_date DATE NOT NULL,
+  skip_reason ENUM('skip', 'pause') NOT NULL,
+  PRIMARY KEY(id),
+  UNIQUE uq_group_regular_shopping_skip(regular_shopping_list_id, skipped_date),
+  FOREIGN KEY fk_group_regular_shopping_skip_id(regular_shopping_list_id)
+    REFERENCES group_regular_shopping_list(id)
+    ON DELETE CASCADE ON UPDATE CASCADE
+);
@@ -0,0 +1,15 @@
+ALTER TABLE todo_list
+  ADD recurrence_type ENUM('schedule', 'after_completion') DEFAULT NULL AFTER complete_flag,
+  ADD recurrence_rule VARCHAR(255) DEFAULT NULL AFTER recurrence_type,
+  ADD recurrence_interval INT DEFAULT NULL AFTER recurrence_rule,
+  ADD recurrence_start_date DATE DEFAULT NULL AFTER recurrence_interval,
+  ADD series_id INT DEFAULT NULL AFTER recurrence_start_date,
+  ADD INDEX idx_series_id(series_id);
+
+ALTER TABLE group_todo_list
+  ADD recurrence_type ENUM('schedule', 'after_completion') DEFAULT NULL AFTER complete_flag,
+  ADD recurrence_rule VARCHAR(255) DEFAULT NULL AFTER recurrence_type,
+  ADD recurrence_interval INT DEFAULT NULL AFTER recurrence_rule,
+  ADD recurrence_start_date DATE DEFAULT NULL AFTER recurrence_interval,
+  ADD series_id INT DEFAULT NULL AFTER recurrence_start_date,
+  ADD INDEX idx_series_id(series_id);
@@ -0,0 +1,59 @@
+ALTER TABLE todo_list
+  ADD priority TINYINT NOT NULL DEFAULT 0 AFTER series_id;
+
+CREATE TABLE todo_labels
+(
+  id INT NOT NULL AUTO_INCREMENT,
+  todo_id INT NOT NULL,
+  label_name VARCHAR(20) NOT NULL,
+  PRIMARY KEY(id),
+  UNIQUE uq_todo_label(todo_id, label_name),
+  INDEX idx_label_name(label_name),
+  FOREIGN KEY fk_todo_label_id(todo_id)
+    REFERENCES todo_list(id)
+    ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE todo_subtasks
+(
+  id INT NOT NULL AUTO_INCREMENT,
+  todo_id INT NOT NULL,
+  subtask_content VARCHAR(100) NOT NULL,
+  complete_flag bit(1) NOT NULL DEFAULT b'0',
+  subtask_order INT NOT NULL,
+  PRIMARY KEY(id),
+  INDEX idx_todo_id(todo_id),
+  FOREIGN KEY fk_todo_subtask_id(todo_id)
+    REFERENCES todo_list(id)
+    ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+ALTER TABLE group_todo_list
+  ADD priority TINYINT NOT NULL DEFAULT 0 AFTER series_id;
+
+CREATE TABLE group_todo_labels
+(
+  id INT NOT NULL AUTO_INCREMENT,
+  todo_id INT NOT NULL,
+  label_name VARCHAR(20) NOT NULL,
+  PRIMARY KEY(id),
+  UNIQUE uq_group_todo_label(todo_id, label_name),
+  INDEX idx_label_name(label_name),
+  FOREIGN KEY fk_group_todo_label_id(todo_id)
+    REFERENCES group_todo_list(id)
+    ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE group_todo_subtasks
+(
+  id INT NOT NULL AUTO_INCREMENT,
+  todo_id INT NOT NULL,
+  subtask_content VARCHAR(100) NOT NULL,
+  complete_flag bit(1) NOT NULL DEFAULT b'0',
+  subtask_order INT NOT NULL,
+  PRIMARY KEY(id),
+  INDEX idx_todo_id(todo_id),
+  FOREIGN KEY fk_group_todo_subtask_id(todo_id)
+    REFERENCES group_todo_list(id)
+    ON DELETE CASCADE ON UPDATE CASCADE
+);
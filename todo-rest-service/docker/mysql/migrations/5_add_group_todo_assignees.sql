@@ -0,0 +1,16 @@
+ALTER TABLE group_todo_list
+  ADD completed_user_id VARCHAR(10) DEFAULT NULL AFTER priority,
+  ADD completed_date DATETIME DEFAULT NULL AFTER completed_user_id;
+
+CREATE TABLE group_todo_assignees
+(
+  id INT NOT NULL AUTO_INCREMENT,
+  todo_id INT NOT NULL,
+  user_id VARCHAR(10) NOT NULL,
+  PRIMARY KEY(id),
+  UNIQUE uq_group_todo_assignee(todo_id, user_id),
+  INDEX idx_user_id(user_id),
+  FOREIGN KEY fk_group_todo_assignee_id(todo_id)
+    REFERENCES group_todo_list(id)
+    ON DELETE CASCADE ON UPDATE CASCADE
+);
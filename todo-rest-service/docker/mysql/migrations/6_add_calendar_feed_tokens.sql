@@ -0,0 +1,11 @@
+CREATE TABLE calendar_feed_tokens
+(
+  id INT NOT NULL AUTO_INCREMENT,
+  user_id VARCHAR(10) NOT NULL,
+  group_id INT DEFAULT NULL,
+  token_hash CHAR(64) NOT NULL,
+  created_date DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(id),
+  UNIQUE uq_token_hash(token_hash),
+  INDEX idx_user_id_group_id(user_id, group_id)
+);
@@ -0,0 +1,4 @@
+ALTER TABLE group_shopping_list
+  ADD claimed_user_id VARCHAR(10) DEFAULT NULL AFTER payment_user_id,
+  ADD claimed_date DATETIME DEFAULT NULL AFTER claimed_user_id,
+  ADD claim_version INT NOT NULL DEFAULT 0 AFTER claimed_date;
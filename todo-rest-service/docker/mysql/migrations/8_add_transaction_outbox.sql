@@ -0,0 +1,21 @@
+CREATE TABLE transaction_outbox
+(
+  id INT NOT NULL AUTO_INCREMENT,
+  posted_date DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_date DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  command ENUM('create', 'update', 'delete') NOT NULL,
+  user_id VARCHAR(10) NOT NULL,
+  group_id INT DEFAULT NULL,
+  shopping_item_id INT NOT NULL,
+  transaction_id INT DEFAULT NULL,
+  idempotency_key VARCHAR(64) NOT NULL,
+  payload TEXT DEFAULT NULL,
+  session_id VARCHAR(64) NOT NULL,
+  status ENUM('pending', 'delivered', 'failed', 'cancelled') NOT NULL DEFAULT 'pending',
+  attempts INT NOT NULL DEFAULT 0,
+  next_attempt_date DATETIME NOT NULL,
+  last_error VARCHAR(255) DEFAULT NULL,
+  PRIMARY KEY(id),
+  INDEX idx_status_next_attempt_date(status, next_attempt_date),
+  INDEX idx_shopping_item_id(shopping_item_id)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE transaction_outbox
+  DROP COLUMN session_id;
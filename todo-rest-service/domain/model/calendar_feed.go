@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+type CalendarFeed struct {
+	Token    string `json:"token"`
+	FeedPath string `json:"feed_path"`
+}
+
+type CalendarFeedToken struct {
+	ID          int       `db:"id"`
+	UserID      string    `db:"user_id"`
+	GroupID     NullInt   `db:"group_id"`
+	TokenHash   string    `db:"token_hash"`
+	CreatedDate time.Time `db:"created_date"`
+}
+
+func NewCalendarFeed(token string, feedPath string) CalendarFeed {
+	return CalendarFeed{
+		Token:    token,
+		FeedPath: feedPath,
+	}
+}
@@ -0,0 +1,84 @@
+package model
+
+import "time"
+
+type Dashboard struct {
+	TodoList                   *TodoList                         `json:"todo_list"`
+	GroupTodoList              []DailyGroupTodoList              `json:"group_todo_list"`
+	ExpiredTodoList            *ExpiredTodoList                  `json:"expired_todo_list"`
+	ExpiredShoppingList        *ExpiredShoppingList              `json:"expired_shopping_list"`
+	ExpiredGroupTodoList       []ExpiredGroupTodoListByGroup     `json:"expired_group_todo_list"`
+	ExpiredGroupShoppingList   []ExpiredGroupShoppingListByGroup `json:"expired_group_shopping_list"`
+	AssignedGroupTasksList     []GroupTasksListByGroup           `json:"assigned_group_tasks_list"`
+	BudgetStatus               *BudgetStatus                     `json:"budget_status"`
+	UnsettledGroupAccountsList []UnsettledGroupAccountsList      `json:"unsettled_group_accounts_list"`
+	FailedSourceList           []DashboardFailedSource           `json:"failed_source_list"`
+}
+
+type DailyGroupTodoList struct {
+	GroupID                     int         `json:"group_id"`
+	ImplementationGroupTodoList []GroupTodo `json:"implementation_todo_list"`
+	DueGroupTodoList            []GroupTodo `json:"due_todo_list"`
+}
+
+type ExpiredGroupTodoListByGroup struct {
+	GroupID              int         `json:"group_id"`
+	ExpiredGroupTodoList []GroupTodo `json:"expired_group_todo_list"`
+}
+
+type ExpiredGroupShoppingListByGroup struct {
+	GroupID                  int                 `json:"group_id"`
+	ExpiredGroupShoppingList []GroupShoppingItem `json:"expired_shopping_list"`
+}
+
+type GroupTasksListByGroup struct {
+	GroupID        int         `json:"group_id"`
+	GroupTasksList []GroupTask `json:"tasks_list"`
+}
+
+type BudgetStatus struct {
+	TotalBudget   int `json:"total_budget"`
+	TotalExpenses int `json:"total_expenses"`
+}
+
+type UnsettledGroupAccountsList struct {
+	GroupID                    int            `json:"group_id"`
+	UnsettledGroupAccountsList []GroupAccount `json:"unsettled_group_accounts_list"`
+}
+
+type GroupAccount struct {
+	ID                  int        `json:"id"`
+	GroupID             int        `json:"group_id"`
+	Month               time.Time  `json:"month"`
+	Payer               NullString `json:"payer_user_id"`
+	Recipient           NullString `json:"recipient_user_id"`
+	PaymentAmount       NullInt    `json:"payment_amount"`
+	PaymentConfirmation BitBool    `json:"payment_confirmation"`
+	ReceiptConfirmation BitBool    `json:"receipt_confirmation"`
+}
+
+type DashboardFailedSource struct {
+	Source  string `json:"source"`
+	GroupID int    `json:"group_id,omitempty"`
+	Message string `json:"message"`
+}
+
+type GroupList struct {
+	ApprovedGroupList []Group `json:"approved_group_list"`
+}
+
+type Group struct {
+	GroupID   int    `json:"group_id"`
+	GroupName string `json:"group_name"`
+}
+
+func NewDashboard() Dashboard {
+	return Dashboard{
+		GroupTodoList:              make([]DailyGroupTodoList, 0),
+		ExpiredGroupTodoList:       make([]ExpiredGroupTodoListByGroup, 0),
+		ExpiredGroupShoppingList:   make([]ExpiredGroupShoppingListByGroup, 0),
+		AssignedGroupTasksList:     make([]GroupTasksListByGroup, 0),
+		UnsettledGroupAccountsList: make([]UnsettledGroupAccountsList, 0),
+		FailedSourceList:           make([]DashboardFailedSource, 0),
+	}
+}
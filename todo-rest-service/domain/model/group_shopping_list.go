@@ -10,6 +10,11 @@ type GroupShoppingDataByDay struct {
 	GroupShoppingList
 }
 
+type MonthlyGroupShoppingDataByDay struct {
+	GroupShoppingDataByDay
+	GroupSkippedShoppingList
+}
+
 type GroupShoppingDataByCategory struct {
 	GroupRegularShoppingList
 	GroupShoppingListByCategory []GroupShoppingListByCategory `json:"shopping_list_by_categories"`
@@ -20,6 +25,16 @@ type GroupShoppingListByCategory struct {
 	GroupShoppingList []GroupShoppingItem `json:"shopping_list"`
 }
 
+type GroupShoppingDataByShop struct {
+	GroupRegularShoppingList
+	GroupShoppingListByShop []GroupShoppingListByShop `json:"shopping_list_by_shops"`
+}
+
+type GroupShoppingListByShop struct {
+	Shop              NullString          `json:"shop"`
+	GroupShoppingList []GroupShoppingItem `json:"shopping_list"`
+}
+
 type GroupRegularShoppingList struct {
 	GroupRegularShoppingList []GroupRegularShoppingItem `json:"regular_shopping_list"`
 }
@@ -29,11 +44,16 @@ type GroupRegularShoppingItem struct {
 	PostedDate           time.Time  `json:"posted_date"            db:"posted_date"`
 	UpdatedDate          time.Time  `json:"updated_date"           db:"updated_date"`
 	ExpectedPurchaseDate Date       `json:"expected_purchase_date" db:"expected_purchase_date" validate:"required,date_range"`
-	CycleType            string     `json:"cycle_type"             db:"cycle_type"             validate:"required,oneof=daily weekly monthly custom"`
+	CycleType            string     `json:"cycle_type"             db:"cycle_type"             validate:"required,oneof=daily weekly monthly custom rrule,with_cycle"`
 	Cycle                NullInt    `json:"cycle"                  db:"cycle"                  validate:"omitempty,min=1"`
+	RecurrenceRule       NullString `json:"recurrence_rule"        db:"recurrence_rule"        validate:"omitempty,max=255,recurrence_rule"`
+	RecurrenceStartDate  Date       `json:"-"                      db:"recurrence_start_date"`
 	Purchase             string     `json:"purchase"               db:"purchase"               validate:"required,max=50,blank"`
 	Shop                 NullString `json:"shop"                   db:"shop"                   validate:"omitempty,max=20,blank"`
-	Amount               NullInt64  `json:"amount"                 db:"amount"                 validate:"omitempty,min=1"`
+	Amount               NullInt64  `json:"amount"                 db:"amount"                 validate:"omitempty,min=1,max=1000000000,unit_price_total"`
+	Quantity             NullInt64  `json:"quantity"               db:"quantity"               validate:"omitempty,min=1,max=1000"`
+	Unit                 NullString `json:"unit"                   db:"unit"                   validate:"omitempty,max=10,blank,with_quantity"`
+	UnitPrice            NullInt64  `json:"unit_price"             db:"unit_price"             validate:"omitempty,min=1,max=1000000"`
 	BigCategoryID        int        `json:"big_category_id"        db:"big_category_id"        validate:"required,min=2,max=17,either_id"`
 	BigCategoryName      string     `json:"big_category_name"      db:"big_category_name"`
 	MediumCategoryID     NullInt64  `json:"medium_category_id"     db:"medium_category_id"     validate:"omitempty,min=1,max=99"`
@@ -42,9 +62,63 @@ type GroupRegularShoppingItem struct {
 	CustomCategoryName   NullString `json:"custom_category_name"   db:"custom_category_name"`
 	PaymentUserID        NullString `json:"payment_user_id"        db:"payment_user_id"        validate:"omitempty,min=1,max=10,excludesall= 　"`
 	TransactionAutoAdd   BitBool    `json:"transaction_auto_add"   db:"transaction_auto_add"`
+	FinishedFlag         BitBool    `json:"finished_flag"          db:"finished_flag"`
+	PausedUntil          NullTime   `json:"paused_until"           db:"paused_until"`
+	SkippedDateList      []Date     `json:"-"                      db:"-"`
 	Today                time.Time  `json:"-"`
 }
 
+// NextExpectedPurchaseDate returns the expected purchase date following date by the recurrence rule, leaving out the skipped dates.
+// It returns false when the recurrence has ended, or the recurrence rule is invalid.
+func (r GroupRegularShoppingItem) NextExpectedPurchaseDate(date time.Time) (time.Time, bool) {
+	return nextUnskippedDate(r.RecurrenceRule, r.RecurrenceStartDate, r.SkippedDateList, date)
+}
+
+// RescheduledExpectedPurchaseDate returns the expected purchase date after the skipped dates have changed.
+func (r GroupRegularShoppingItem) RescheduledExpectedPurchaseDate(today time.Time) (time.Time, bool) {
+	return rescheduledDate(r.RecurrenceRule, r.RecurrenceStartDate, r.SkippedDateList, r.ExpectedPurchaseDate, today)
+}
+
+// UpcomingExpectedPurchaseDateList returns the expected purchase dates starting from date, which must be an expected purchase date itself.
+// The dates are limited to count unless count is 0, and to the ones on or before until unless until is zero.
+func (r GroupRegularShoppingItem) UpcomingExpectedPurchaseDateList(date time.Time, count int, until time.Time) []time.Time {
+	return upcomingDateList(r.RecurrenceRule, r.RecurrenceStartDate, r.SkippedDateList, date, count, until)
+}
+
+// IsExpectedPurchaseDate reports whether date is an expected purchase date by the recurrence rule, which has not been skipped.
+func (r GroupRegularShoppingItem) IsExpectedPurchaseDate(date time.Time) bool {
+	return isUnskippedDate(r.RecurrenceRule, r.RecurrenceStartDate, r.SkippedDateList, date)
+}
+
+// Materialization returns the group shopping items which are due by today, following the expected purchase date.
+func (r GroupRegularShoppingItem) Materialization(today time.Time) RegularShoppingMaterialization {
+	return materialize(r.NextExpectedPurchaseDate, r.ExpectedPurchaseDate.Time, today)
+}
+
+type GroupSkippedShoppingList struct {
+	GroupSkippedShoppingList []GroupSkippedShoppingItem `json:"skipped_shopping_list"`
+}
+
+type GroupSkippedShoppingItem struct {
+	ID                    int        `json:"id"                       db:"id"`
+	ExpectedPurchaseDate  Date       `json:"expected_purchase_date"   db:"skipped_date"`
+	SkipReason            string     `json:"skip_reason"              db:"skip_reason"`
+	Purchase              string     `json:"purchase"                 db:"purchase"`
+	Shop                  NullString `json:"shop"                     db:"shop"`
+	Amount                NullInt64  `json:"amount"                   db:"amount"`
+	Quantity              NullInt64  `json:"quantity"                 db:"quantity"`
+	Unit                  NullString `json:"unit"                     db:"unit"`
+	UnitPrice             NullInt64  `json:"unit_price"               db:"unit_price"`
+	BigCategoryID         int        `json:"big_category_id"          db:"big_category_id"`
+	BigCategoryName       string     `json:"big_category_name"        db:"big_category_name"`
+	MediumCategoryID      NullInt64  `json:"medium_category_id"       db:"medium_category_id"`
+	MediumCategoryName    NullString `json:"medium_category_name"     db:"medium_category_name"`
+	CustomCategoryID      NullInt64  `json:"custom_category_id"       db:"custom_category_id"`
+	CustomCategoryName    NullString `json:"custom_category_name"     db:"custom_category_name"`
+	RegularShoppingListID int        `json:"regular_shopping_list_id" db:"regular_shopping_list_id"`
+	PaymentUserID         NullString `json:"payment_user_id"          db:"payment_user_id"`
+}
+
 type GroupShoppingList struct {
 	GroupShoppingList []GroupShoppingItem `json:"shopping_list"`
 }
@@ -61,7 +135,10 @@ type GroupShoppingItem struct {
 	CompleteFlag           BitBool               `json:"complete_flag"            db:"complete_flag"`
 	Purchase               string                `json:"purchase"                 db:"purchase"               validate:"required,max=50,blank"`
 	Shop                   NullString            `json:"shop"                     db:"shop"                   validate:"omitempty,max=20,blank"`
-	Amount                 NullInt64             `json:"amount"                   db:"amount"                 validate:"omitempty,min=1"`
+	Amount                 NullInt64             `json:"amount"                   db:"amount"                 validate:"omitempty,min=1,max=1000000000,unit_price_total"`
+	Quantity               NullInt64             `json:"quantity"                 db:"quantity"               validate:"omitempty,min=1,max=1000"`
+	Unit                   NullString            `json:"unit"                     db:"unit"                   validate:"omitempty,max=10,blank,with_quantity"`
+	UnitPrice              NullInt64             `json:"unit_price"               db:"unit_price"             validate:"omitempty,min=1,max=1000000"`
 	BigCategoryID          int                   `json:"big_category_id"          db:"big_category_id"        validate:"required,min=2,max=17,either_id"`
 	BigCategoryName        string                `json:"big_category_name"        db:"big_category_name"`
 	MediumCategoryID       NullInt64             `json:"medium_category_id"       db:"medium_category_id"     validate:"omitempty,min=1,max=99"`
@@ -70,11 +147,54 @@ type GroupShoppingItem struct {
 	CustomCategoryName     NullString            `json:"custom_category_name"     db:"custom_category_name"`
 	RegularShoppingListID  NullInt64             `json:"regular_shopping_list_id" db:"regular_shopping_list_id"`
 	PaymentUserID          NullString            `json:"payment_user_id"          db:"payment_user_id"        validate:"omitempty,min=1,max=10,excludesall= 　"`
+	ClaimedUserID          NullString            `json:"claimed_user_id"          db:"claimed_user_id"`
+	ClaimedDate            NullTime              `json:"claimed_date"             db:"claimed_date"`
+	ClaimVersion           int                   `json:"claim_version"            db:"claim_version"`
 	TransactionAutoAdd     BitBool               `json:"transaction_auto_add"     db:"transaction_auto_add"`
 	RelatedTransactionData *GroupTransactionData `json:"related_transaction_data" db:"transaction_id"`
+	PriceSuggestion        *PriceSuggestion      `json:"price_suggestion,omitempty"`
 	Today                  time.Time             `json:"-"`
 }
 
+type GroupShoppingItemClaim struct {
+	ClaimedUserID string `json:"claimed_user_id"`
+	ClaimVersion  int    `json:"claim_version"`
+}
+
+type ClaimedGroupShoppingList struct {
+	ClaimedGroupShoppingList []GroupShoppingListByClaimedUser `json:"claimed_shopping_list"`
+}
+
+type GroupShoppingListByClaimedUser struct {
+	ClaimedUserID     string              `json:"claimed_user_id"`
+	GroupShoppingList []GroupShoppingItem `json:"shopping_list"`
+}
+
+type GroupShoppingItemPrice struct {
+	GroupShoppingItemID    int                   `json:"shopping_item_id" db:"id"`
+	PurchaseDate           Date                  `json:"purchase_date"    db:"expected_purchase_date"`
+	Purchase               string                `json:"purchase"         db:"purchase"`
+	Shop                   NullString            `json:"shop"             db:"shop"`
+	Amount                 NullInt64             `json:"amount"           db:"amount"`
+	PaymentUserID          NullString            `json:"payment_user_id"  db:"payment_user_id"`
+	Quantity               NullInt64             `json:"-"                db:"quantity"`
+	RelatedTransactionData *GroupTransactionData `json:"-"                db:"transaction_id"`
+}
+
+type GroupShoppingItemPriceHistoryList struct {
+	GroupShoppingItemPriceHistoryList []GroupShoppingItemPriceHistory `json:"price_history_list"`
+}
+
+type GroupShoppingItemPriceHistory struct {
+	Purchase     string                   `json:"purchase"`
+	Shop         NullString               `json:"shop"`
+	LastPrice    int64                    `json:"last_price"`
+	AveragePrice int64                    `json:"average_price"`
+	MinPrice     int64                    `json:"min_price"`
+	MaxPrice     int64                    `json:"max_price"`
+	PriceList    []GroupShoppingItemPrice `json:"price_list"`
+}
+
 type GroupTransactionData struct {
 	ID                 NullInt64  `json:"id"`
 	TransactionType    string     `json:"transaction_type"`
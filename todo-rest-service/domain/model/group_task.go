@@ -39,6 +39,20 @@ type GroupTask struct {
 	GroupTasksUserID NullInt    `json:"group_tasks_users_id" db:"group_tasks_users_id"`
 }
 
+type GroupTaskAssignment struct {
+	GroupTasksID     int       `json:"group_tasks_id"`
+	TaskName         string    `json:"task_name"`
+	CycleType        string    `json:"cycle_type"`
+	StartDate        time.Time `json:"start_date"`
+	EndDate          time.Time `json:"end_date"`
+	GroupTasksUserID int       `json:"group_tasks_users_id"`
+	UserID           string    `json:"user_id"`
+}
+
+type GroupTasksSchedule struct {
+	GroupTaskAssignmentList []GroupTaskAssignment `json:"group_tasks_schedule"`
+}
+
 func NewGroupTasksListForEachUser(groupTasksUsersList []GroupTasksUser) GroupTasksListForEachUser {
 	return GroupTasksListForEachUser{
 		GroupTasksUsersList: groupTasksUsersList,
@@ -51,6 +65,16 @@ func NewGroupTasksList(groupTasksList []GroupTask) GroupTasksList {
 	}
 }
 
+func NewGroupTasksSchedule(groupTaskAssignmentList []GroupTaskAssignment) GroupTasksSchedule {
+	if groupTaskAssignmentList == nil {
+		groupTaskAssignmentList = make([]GroupTaskAssignment, 0)
+	}
+
+	return GroupTasksSchedule{
+		GroupTaskAssignmentList: groupTaskAssignmentList,
+	}
+}
+
 type NullTime struct {
 	sql.NullTime
 }
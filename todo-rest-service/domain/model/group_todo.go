@@ -18,15 +18,36 @@ type SearchGroupTodoList struct {
 	SearchGroupTodoList []GroupTodo `json:"search_todo_list"`
 }
 
+type AssignedGroupTodoList struct {
+	AssignedGroupTodoList []GroupTodoListByGroup `json:"assigned_todo_list"`
+}
+
+type GroupTodoListByGroup struct {
+	GroupID       int         `json:"group_id"`
+	GroupTodoList []GroupTodo `json:"todo_list"`
+}
+
 type GroupTodo struct {
-	ID                 int       `json:"id"                  db:"id"`
-	PostedDate         time.Time `json:"posted_date"         db:"posted_date"`
-	UpdatedDate        time.Time `json:"updated_date"        db:"updated_date"`
-	ImplementationDate Date      `json:"implementation_date" db:"implementation_date" validate:"required,date"`
-	DueDate            Date      `json:"due_date"            db:"due_date"            validate:"required,date"`
-	TodoContent        string    `json:"todo_content"        db:"todo_content"        validate:"required,max=100,blank"`
-	CompleteFlag       BitBool   `json:"complete_flag"       db:"complete_flag"`
-	UserID             string    `json:"user_id"             db:"user_id"`
+	ID                  int           `json:"id"                  db:"id"`
+	PostedDate          time.Time     `json:"posted_date"         db:"posted_date"`
+	UpdatedDate         time.Time     `json:"updated_date"        db:"updated_date"`
+	ImplementationDate  Date          `json:"implementation_date" db:"implementation_date" validate:"required,date"`
+	DueDate             Date          `json:"due_date"            db:"due_date"            validate:"required,date"`
+	TodoContent         string        `json:"todo_content"        db:"todo_content"        validate:"required,max=100,blank"`
+	CompleteFlag        BitBool       `json:"complete_flag"       db:"complete_flag"`
+	UserID              string        `json:"user_id"             db:"user_id"`
+	RecurrenceType      NullString    `json:"recurrence_type"     db:"recurrence_type"     validate:"omitempty,oneof=schedule after_completion,with_recurrence"`
+	RecurrenceRule      NullString    `json:"recurrence_rule"     db:"recurrence_rule"     validate:"omitempty,max=255,recurrence_rule"`
+	RecurrenceInterval  NullInt       `json:"recurrence_interval" db:"recurrence_interval" validate:"omitempty,min=1,max=365"`
+	RecurrenceStartDate NullTime      `json:"-"                   db:"recurrence_start_date"`
+	SeriesID            NullInt64     `json:"series_id"           db:"series_id"`
+	Priority            int           `json:"priority"            db:"priority"            validate:"min=0,max=3"`
+	LabelList           []string      `json:"label_list"          db:"-"                   validate:"max=10,unique,dive,required,max=20,blank"`
+	SubtaskList         []TodoSubtask `json:"subtask_list"        db:"-"                   validate:"max=30,dive"`
+	AssigneeList        []string      `json:"assignee_list"       db:"-"                   validate:"max=10,unique,dive,required,max=10,excludesall= 　"`
+	CompletedUserID     NullString    `json:"completed_user_id"   db:"completed_user_id"`
+	CompletedDate       NullTime      `json:"completed_date"      db:"completed_date"`
+	GroupID             int           `json:"-"                   db:"group_id"`
 }
 
 func NewGroupTodoList(implementationGroupTodoList []GroupTodo, dueGroupTodoList []GroupTodo) GroupTodoList {
@@ -50,3 +71,28 @@ func (t GroupTodo) ShowTodo() (string, error) {
 
 	return string(b), nil
 }
+
+// NextGroupTodo returns the next instance of the recurring group todo, which is completed on completionDate.
+// It returns false when the group todo does not recur, or the recurrence has ended.
+func (t GroupTodo) NextGroupTodo(completionDate time.Time) (GroupTodo, bool) {
+	implementationDate, ok := nextRecurrenceDate(t.RecurrenceType, t.RecurrenceRule, t.RecurrenceInterval, t.RecurrenceStartDate, t.ImplementationDate, completionDate)
+	if !ok {
+		return GroupTodo{}, false
+	}
+
+	return GroupTodo{
+		ImplementationDate:  Date{Time: implementationDate},
+		DueDate:             Date{Time: implementationDate.AddDate(0, 0, recurrenceDueDays(t.ImplementationDate, t.DueDate))},
+		TodoContent:         t.TodoContent,
+		UserID:              t.UserID,
+		RecurrenceType:      t.RecurrenceType,
+		RecurrenceRule:      t.RecurrenceRule,
+		RecurrenceInterval:  t.RecurrenceInterval,
+		RecurrenceStartDate: t.RecurrenceStartDate,
+		SeriesID:            t.SeriesID,
+		Priority:            t.Priority,
+		LabelList:           t.LabelList,
+		SubtaskList:         nextSubtaskList(t.SubtaskList),
+		AssigneeList:        t.AssigneeList,
+	}, true
+}
@@ -0,0 +1,485 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RecurrenceRule is the subset of the iCalendar recurrence rule (RFC 5545) supported by todo-rest-service,
+// made of FREQ (DAILY, WEEKLY or MONTHLY), INTERVAL, BYDAY, BYMONTHDAY, BYSETPOS, UNTIL and COUNT.
+// Weeks start on Monday, and the first occurrence is always the start date as DTSTART is in RFC 5545.
+type RecurrenceRule struct {
+	Freq       string
+	Interval   int
+	ByDay      []RecurrenceWeekday
+	ByMonthDay []int
+	BySetPos   []int
+	Until      time.Time
+	Count      int
+}
+
+// RecurrenceWeekday is a BYDAY value such as "SA", "2SA" or "-1FR".
+// Ordinal is 0 when the value is not preceded by an ordinal.
+type RecurrenceWeekday struct {
+	Ordinal int
+	Weekday time.Weekday
+}
+
+// recurrenceMaxEmptyPeriods bounds the search for the next occurrence of a rule that never matches any date,
+// such as FREQ=MONTHLY;INTERVAL=12;BYMONTHDAY=31 starting in February.
+const recurrenceMaxEmptyPeriods = 1000
+
+var recurrenceWeekdays = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// CycleRecurrenceRule returns the recurrence rule equivalent to the cycle type of a regular item starting on start,
+// which was the only way to specify the recurrence before recurrence rules were supported.
+// The monthly cycle starting on the 29th or later falls on the last day of the shorter months, instead of skipping them.
+func CycleRecurrenceRule(cycleType string, cycle int, start time.Time) string {
+	switch cycleType {
+	case "daily":
+		return "FREQ=DAILY"
+	case "weekly":
+		return "FREQ=WEEKLY"
+	case "monthly":
+		switch day := start.Day(); {
+		case day == 31:
+			return "FREQ=MONTHLY;BYMONTHDAY=-1"
+		case day >= 29:
+			return fmt.Sprintf("FREQ=MONTHLY;BYMONTHDAY=%d,-1;BYSETPOS=1", day)
+		}
+
+		return "FREQ=MONTHLY"
+	case "custom":
+		return fmt.Sprintf("FREQ=DAILY;INTERVAL=%d", cycle)
+	}
+
+	return ""
+}
+
+func ParseRecurrenceRule(rule string) (*RecurrenceRule, error) {
+	rule = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(rule)), "RRULE:")
+	if len(rule) == 0 {
+		return nil, errors.New("recurrence rule is empty")
+	}
+
+	recurrenceRule := RecurrenceRule{
+		Interval: 1,
+	}
+
+	parsedNames := make(map[string]bool)
+	for _, part := range strings.Split(rule, ";") {
+		nameValue := strings.SplitN(part, "=", 2)
+		if len(nameValue) != 2 || len(nameValue[1]) == 0 {
+			return nil, fmt.Errorf("invalid recurrence rule part %q", part)
+		}
+
+		name, value := nameValue[0], nameValue[1]
+		if parsedNames[name] {
+			return nil, fmt.Errorf("duplicate recurrence rule part %s", name)
+		}
+
+		parsedNames[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			if value != "DAILY" && value != "WEEKLY" && value != "MONTHLY" {
+				return nil, fmt.Errorf("unsupported FREQ %s", value)
+			}
+
+			recurrenceRule.Freq = value
+		case "INTERVAL":
+			recurrenceRule.Interval, err = parseRecurrenceInt(value, 1, 1000)
+		case "BYDAY":
+			recurrenceRule.ByDay, err = parseRecurrenceWeekdayList(value)
+		case "BYMONTHDAY":
+			recurrenceRule.ByMonthDay, err = parseRecurrenceIntList(value, 31)
+		case "BYSETPOS":
+			recurrenceRule.BySetPos, err = parseRecurrenceIntList(value, 366)
+		case "UNTIL":
+			recurrenceRule.Until, err = parseRecurrenceUntil(value)
+		case "COUNT":
+			recurrenceRule.Count, err = parseRecurrenceInt(value, 1, 1000)
+		default:
+			return nil, fmt.Errorf("unsupported recurrence rule part %s", name)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", name, err)
+		}
+	}
+
+	if len(recurrenceRule.Freq) == 0 {
+		return nil, errors.New("FREQ is required")
+	}
+
+	if !recurrenceRule.Until.IsZero() && recurrenceRule.Count != 0 {
+		return nil, errors.New("UNTIL and COUNT must not be specified together")
+	}
+
+	if recurrenceRule.Freq == "WEEKLY" && len(recurrenceRule.ByMonthDay) != 0 {
+		return nil, errors.New("BYMONTHDAY must not be specified with FREQ=WEEKLY")
+	}
+
+	if recurrenceRule.Freq != "MONTHLY" {
+		for _, byDay := range recurrenceRule.ByDay {
+			if byDay.Ordinal != 0 {
+				return nil, fmt.Errorf("BYDAY with an ordinal must not be specified with FREQ=%s", recurrenceRule.Freq)
+			}
+		}
+	}
+
+	if len(recurrenceRule.BySetPos) != 0 && len(recurrenceRule.ByDay) == 0 && len(recurrenceRule.ByMonthDay) == 0 {
+		return nil, errors.New("BYSETPOS must be specified with BYDAY or BYMONTHDAY")
+	}
+
+	return &recurrenceRule, nil
+}
+
+func parseRecurrenceInt(value string, min int, max int) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, err
+	}
+
+	if n < min || n > max {
+		return 0, fmt.Errorf("%d is out of range %d to %d", n, min, max)
+	}
+
+	return n, nil
+}
+
+// parseRecurrenceIntList parses a list of non-zero integers between -max and max.
+func parseRecurrenceIntList(value string, max int) ([]int, error) {
+	var list []int
+	for _, v := range strings.Split(value, ",") {
+		n, err := parseRecurrenceInt(strings.TrimPrefix(v, "+"), -max, max)
+		if err != nil {
+			return nil, err
+		}
+
+		if n == 0 {
+			return nil, errors.New("0 is not allowed")
+		}
+
+		list = append(list, n)
+	}
+
+	return list, nil
+}
+
+func parseRecurrenceWeekdayList(value string) ([]RecurrenceWeekday, error) {
+	var list []RecurrenceWeekday
+	for _, v := range strings.Split(value, ",") {
+		if len(v) < 2 {
+			return nil, fmt.Errorf("invalid weekday %q", v)
+		}
+
+		recurrenceWeekday := RecurrenceWeekday{
+			Weekday: -1,
+		}
+
+		for i, weekday := range recurrenceWeekdays {
+			if v[len(v)-2:] == weekday {
+				recurrenceWeekday.Weekday = time.Weekday(i)
+			}
+		}
+
+		if recurrenceWeekday.Weekday < 0 {
+			return nil, fmt.Errorf("invalid weekday %q", v)
+		}
+
+		if ordinal := v[:len(v)-2]; len(ordinal) != 0 {
+			n, err := parseRecurrenceInt(strings.TrimPrefix(ordinal, "+"), -5, 5)
+			if err != nil {
+				return nil, err
+			}
+
+			if n == 0 {
+				return nil, errors.New("0 is not allowed")
+			}
+
+			recurrenceWeekday.Ordinal = n
+		}
+
+		list = append(list, recurrenceWeekday)
+	}
+
+	return list, nil
+}
+
+func parseRecurrenceUntil(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102", "20060102T150405Z"} {
+		if until, err := time.Parse(layout, value); err == nil {
+			return time.Date(until.Year(), until.Month(), until.Day(), 0, 0, 0, 0, time.UTC), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
+
+// String returns the recurrence rule in the canonical order of the parts.
+func (r *RecurrenceRule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+
+	if r.Interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", r.Interval))
+	}
+
+	if len(r.ByDay) != 0 {
+		byDay := make([]string, 0, len(r.ByDay))
+		for _, recurrenceWeekday := range r.ByDay {
+			if recurrenceWeekday.Ordinal != 0 {
+				byDay = append(byDay, fmt.Sprintf("%d%s", recurrenceWeekday.Ordinal, recurrenceWeekdays[recurrenceWeekday.Weekday]))
+			} else {
+				byDay = append(byDay, recurrenceWeekdays[recurrenceWeekday.Weekday])
+			}
+		}
+
+		parts = append(parts, "BYDAY="+strings.Join(byDay, ","))
+	}
+
+	if len(r.ByMonthDay) != 0 {
+		parts = append(parts, "BYMONTHDAY="+joinRecurrenceIntList(r.ByMonthDay))
+	}
+
+	if len(r.BySetPos) != 0 {
+		parts = append(parts, "BYSETPOS="+joinRecurrenceIntList(r.BySetPos))
+	}
+
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+	}
+
+	if r.Count != 0 {
+		parts = append(parts, fmt.Sprintf("COUNT=%d", r.Count))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+func joinRecurrenceIntList(list []int) string {
+	values := make([]string, 0, len(list))
+	for _, n := range list {
+		values = append(values, strconv.Itoa(n))
+	}
+
+	return strings.Join(values, ",")
+}
+
+// Next returns the first occurrence after date of the recurrence starting on start.
+// It returns false when the recurrence has ended by UNTIL or COUNT before date.
+func (r *RecurrenceRule) Next(start time.Time, date time.Time) (time.Time, bool) {
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+	if date.Before(start) {
+		if !r.Until.IsZero() && start.After(r.Until) {
+			return time.Time{}, false
+		}
+
+		return start, true
+	}
+
+	// Without COUNT the occurrences before the period of date need not be counted, and are skipped.
+	var period int
+	if r.Count == 0 {
+		period = r.periodIndex(start, date)
+	}
+
+	var count int
+	for emptyPeriods := 0; emptyPeriods < recurrenceMaxEmptyPeriods; period++ {
+		periodStart := r.periodStart(start, period)
+		if !r.Until.IsZero() && periodStart.After(r.Until) {
+			return time.Time{}, false
+		}
+
+		occurrences := r.expand(start, periodStart)
+		if period == 0 && (len(occurrences) == 0 || !occurrences[0].Equal(start)) {
+			occurrences = append([]time.Time{start}, occurrences...)
+		}
+
+		if len(occurrences) == 0 {
+			emptyPeriods++
+			continue
+		}
+
+		emptyPeriods = 0
+
+		for _, occurrence := range occurrences {
+			if !r.Until.IsZero() && occurrence.After(r.Until) {
+				return time.Time{}, false
+			}
+
+			count++
+			if r.Count != 0 && count > r.Count {
+				return time.Time{}, false
+			}
+
+			if occurrence.After(date) {
+				return occurrence, true
+			}
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// periodIndex returns the index of the last period starting on or before date,
+// where the periods are FREQ-long and INTERVAL apart from the period of start.
+func (r *RecurrenceRule) periodIndex(start time.Time, date time.Time) int {
+	var periods int
+	switch r.Freq {
+	case "DAILY":
+		periods = int(date.Sub(start).Hours() / 24)
+	case "WEEKLY":
+		periods = int(recurrenceWeekStart(date).Sub(recurrenceWeekStart(start)).Hours() / (24 * 7))
+	case "MONTHLY":
+		periods = (date.Year()-start.Year())*12 + int(date.Month()) - int(start.Month())
+	}
+
+	return periods / r.Interval
+}
+
+func (r *RecurrenceRule) periodStart(start time.Time, period int) time.Time {
+	switch r.Freq {
+	case "WEEKLY":
+		return recurrenceWeekStart(start).AddDate(0, 0, 7*period*r.Interval)
+	case "MONTHLY":
+		return time.Date(start.Year(), start.Month()+time.Month(period*r.Interval), 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	return start.AddDate(0, 0, period*r.Interval)
+}
+
+func recurrenceWeekStart(date time.Time) time.Time {
+	return date.AddDate(0, 0, -(int(date.Weekday())+6)%7)
+}
+
+// expand returns the occurrences in the period starting on periodStart in ascending order, excluding the ones before start.
+func (r *RecurrenceRule) expand(start time.Time, periodStart time.Time) []time.Time {
+	var candidates []time.Time
+	switch r.Freq {
+	case "DAILY":
+		if r.matchByDay(periodStart, false) && r.matchByMonthDay(periodStart) {
+			candidates = append(candidates, periodStart)
+		}
+	case "WEEKLY":
+		for i := 0; i < 7; i++ {
+			day := periodStart.AddDate(0, 0, i)
+			if len(r.ByDay) == 0 && day.Weekday() != start.Weekday() {
+				continue
+			}
+
+			if r.matchByDay(day, false) {
+				candidates = append(candidates, day)
+			}
+		}
+	case "MONTHLY":
+		for day := periodStart; day.Month() == periodStart.Month(); day = day.AddDate(0, 0, 1) {
+			if len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 && day.Day() != start.Day() {
+				continue
+			}
+
+			if r.matchByDay(day, true) && r.matchByMonthDay(day) {
+				candidates = append(candidates, day)
+			}
+		}
+	}
+
+	if len(r.BySetPos) != 0 {
+		candidates = r.selectBySetPos(candidates)
+	}
+
+	occurrences := make([]time.Time, 0, len(candidates))
+	for _, candidate := range candidates {
+		if !candidate.Before(start) {
+			occurrences = append(occurrences, candidate)
+		}
+	}
+
+	return occurrences
+}
+
+// matchByDay reports whether date matches BYDAY. The ordinals are counted within the month when inMonth is true.
+func (r *RecurrenceRule) matchByDay(date time.Time, inMonth bool) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+
+	daysInMonth := recurrenceDaysInMonth(date)
+	for _, recurrenceWeekday := range r.ByDay {
+		if date.Weekday() != recurrenceWeekday.Weekday {
+			continue
+		}
+
+		switch {
+		case recurrenceWeekday.Ordinal == 0 || !inMonth:
+			return true
+		case recurrenceWeekday.Ordinal > 0 && (date.Day()-1)/7+1 == recurrenceWeekday.Ordinal:
+			return true
+		case recurrenceWeekday.Ordinal < 0 && (daysInMonth-date.Day())/7+1 == -recurrenceWeekday.Ordinal:
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *RecurrenceRule) matchByMonthDay(date time.Time) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+
+	daysInMonth := recurrenceDaysInMonth(date)
+	for _, monthDay := range r.ByMonthDay {
+		if monthDay < 0 {
+			monthDay = daysInMonth + monthDay + 1
+		}
+
+		if date.Day() == monthDay {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *RecurrenceRule) selectBySetPos(candidates []time.Time) []time.Time {
+	var selected []time.Time
+	for _, setPos := range r.BySetPos {
+		index := setPos - 1
+		if setPos < 0 {
+			index = len(candidates) + setPos
+		}
+
+		if index < 0 || index >= len(candidates) {
+			continue
+		}
+
+		duplicate := false
+		for _, s := range selected {
+			if s.Equal(candidates[index]) {
+				duplicate = true
+			}
+		}
+
+		if !duplicate {
+			selected = append(selected, candidates[index])
+		}
+	}
+
+	sort.Slice(selected, func(i, j int) bool {
+		return selected[i].Before(selected[j])
+	})
+
+	return selected
+}
+
+func recurrenceDaysInMonth(date time.Time) int {
+	return time.Date(date.Year(), date.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,340 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func recurrenceDate(t *testing.T, value string) time.Time {
+	t.Helper()
+
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		t.Fatalf("unexpected error by time.Parse() '%#v'", err)
+	}
+
+	return date
+}
+
+// recurrenceOccurrences returns at most n occurrences of rule starting on start by calling Next repeatedly.
+func recurrenceOccurrences(t *testing.T, rule string, start string, n int) []string {
+	t.Helper()
+
+	recurrenceRule, err := ParseRecurrenceRule(rule)
+	if err != nil {
+		t.Fatalf("unexpected error by ParseRecurrenceRule() '%#v'", err)
+	}
+
+	startDate := recurrenceDate(t, start)
+
+	var occurrences []string
+	for date := startDate.AddDate(0, 0, -1); len(occurrences) < n; {
+		next, ok := recurrenceRule.Next(startDate, date)
+		if !ok {
+			break
+		}
+
+		occurrences = append(occurrences, next.Format("2006-01-02"))
+		date = next
+	}
+
+	return occurrences
+}
+
+func TestRecurrenceRule_Next_Daily(t *testing.T) {
+	got := recurrenceOccurrences(t, "FREQ=DAILY", "2020-09-29", 4)
+	if diff := cmp.Diff([]string{"2020-09-29", "2020-09-30", "2020-10-01", "2020-10-02"}, got); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestRecurrenceRule_Next_DailyWithInterval(t *testing.T) {
+	got := recurrenceOccurrences(t, "FREQ=DAILY;INTERVAL=3", "2020-09-01", 4)
+	if diff := cmp.Diff([]string{"2020-09-01", "2020-09-04", "2020-09-07", "2020-09-10"}, got); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestRecurrenceRule_Next_DailyWithByDay(t *testing.T) {
+	got := recurrenceOccurrences(t, "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR", "2020-10-01", 5)
+	if diff := cmp.Diff([]string{"2020-10-01", "2020-10-02", "2020-10-05", "2020-10-06", "2020-10-07"}, got); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestRecurrenceRule_Next_Weekly(t *testing.T) {
+	got := recurrenceOccurrences(t, "FREQ=WEEKLY", "2020-09-26", 4)
+	if diff := cmp.Diff([]string{"2020-09-26", "2020-10-03", "2020-10-10", "2020-10-17"}, got); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestRecurrenceRule_Next_WeeklyWithIntervalAndByDay(t *testing.T) {
+	got := recurrenceOccurrences(t, "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH", "2020-09-01", 6)
+	if diff := cmp.Diff([]string{"2020-09-01", "2020-09-03", "2020-09-14", "2020-09-17", "2020-09-28", "2020-10-01"}, got); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestRecurrenceRule_Next_WeeklyAcrossSunday(t *testing.T) {
+	got := recurrenceOccurrences(t, "FREQ=WEEKLY;INTERVAL=2;BYDAY=SU,MO", "2020-11-01", 6)
+	if diff := cmp.Diff([]string{"2020-11-01", "2020-11-09", "2020-11-15", "2020-11-23", "2020-11-29", "2020-12-07"}, got); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestRecurrenceRule_Next_Monthly(t *testing.T) {
+	got := recurrenceOccurrences(t, "FREQ=MONTHLY", "2020-09-15", 4)
+	if diff := cmp.Diff([]string{"2020-09-15", "2020-10-15", "2020-11-15", "2020-12-15"}, got); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestRecurrenceRule_Next_MonthlySkipsShortMonths(t *testing.T) {
+	got := recurrenceOccurrences(t, "FREQ=MONTHLY", "2020-10-31", 5)
+	if diff := cmp.Diff([]string{"2020-10-31", "2020-12-31", "2021-01-31", "2021-03-31", "2021-05-31"}, got); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestRecurrenceRule_Next_MonthlyWithInterval(t *testing.T) {
+	got := recurrenceOccurrences(t, "FREQ=MONTHLY;INTERVAL=2;BYMONTHDAY=1,15", "2020-09-01", 5)
+	if diff := cmp.Diff([]string{"2020-09-01", "2020-09-15", "2020-11-01", "2020-11-15", "2021-01-01"}, got); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestRecurrenceRule_Next_MonthlyWithNegativeByMonthDay(t *testing.T) {
+	got := recurrenceOccurrences(t, "FREQ=MONTHLY;BYMONTHDAY=-1", "2021-01-31", 4)
+	if diff := cmp.Diff([]string{"2021-01-31", "2021-02-28", "2021-03-31", "2021-04-30"}, got); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestRecurrenceRule_Next_MonthlyWithLeapDay(t *testing.T) {
+	got := recurrenceOccurrences(t, "FREQ=MONTHLY;BYMONTHDAY=-2", "2020-01-30", 3)
+	if diff := cmp.Diff([]string{"2020-01-30", "2020-02-28", "2020-03-30"}, got); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestRecurrenceRule_Next_MonthlyWithOrdinalByDay(t *testing.T) {
+	got := recurrenceOccurrences(t, "FREQ=MONTHLY;BYDAY=2SA,4SA", "2020-09-12", 6)
+	if diff := cmp.Diff([]string{"2020-09-12", "2020-09-26", "2020-10-10", "2020-10-24", "2020-11-14", "2020-11-28"}, got); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestRecurrenceRule_Next_MonthlyWithLastFriday(t *testing.T) {
+	got := recurrenceOccurrences(t, "FREQ=MONTHLY;BYDAY=-1FR", "2020-09-25", 5)
+	if diff := cmp.Diff([]string{"2020-09-25", "2020-10-30", "2020-11-27", "2020-12-25", "2021-01-29"}, got); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestRecurrenceRule_Next_MonthlyWithFifthWeekday(t *testing.T) {
+	got := recurrenceOccurrences(t, "FREQ=MONTHLY;BYDAY=5SA", "2020-10-31", 3)
+	if diff := cmp.Diff([]string{"2020-10-31", "2021-01-30", "2021-05-29"}, got); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestRecurrenceRule_Next_MonthlyWithBySetPos(t *testing.T) {
+	got := recurrenceOccurrences(t, "FREQ=MONTHLY;BYMONTHDAY=23,24,25;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", "2020-09-25", 5)
+	if diff := cmp.Diff([]string{"2020-09-25", "2020-10-23", "2020-11-25", "2020-12-25", "2021-01-25"}, got); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestRecurrenceRule_Next_MonthlyWithFirstBusinessDay(t *testing.T) {
+	got := recurrenceOccurrences(t, "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=1", "2020-09-01", 6)
+	if diff := cmp.Diff([]string{"2020-09-01", "2020-10-01", "2020-11-02", "2020-12-01", "2021-01-01", "2021-02-01"}, got); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestRecurrenceRule_Next_WithUntil(t *testing.T) {
+	got := recurrenceOccurrences(t, "FREQ=DAILY;INTERVAL=2;UNTIL=20200905", "2020-09-01", 10)
+	if diff := cmp.Diff([]string{"2020-09-01", "2020-09-03", "2020-09-05"}, got); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestRecurrenceRule_Next_WithUntilDateTime(t *testing.T) {
+	got := recurrenceOccurrences(t, "FREQ=WEEKLY;BYDAY=TU;UNTIL=20200915T235959Z", "2020-09-01", 10)
+	if diff := cmp.Diff([]string{"2020-09-01", "2020-09-08", "2020-09-15"}, got); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestRecurrenceRule_Next_WithCount(t *testing.T) {
+	got := recurrenceOccurrences(t, "FREQ=WEEKLY;BYDAY=TH;COUNT=3", "2020-09-10", 10)
+	if diff := cmp.Diff([]string{"2020-09-10", "2020-09-17", "2020-09-24"}, got); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestRecurrenceRule_Next_StartNotMatchingRule(t *testing.T) {
+	got := recurrenceOccurrences(t, "FREQ=MONTHLY;BYMONTHDAY=15", "2020-09-01", 4)
+	if diff := cmp.Diff([]string{"2020-09-01", "2020-09-15", "2020-10-15", "2020-11-15"}, got); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestRecurrenceRule_Next_StartNotMatchingRuleWithCount(t *testing.T) {
+	got := recurrenceOccurrences(t, "FREQ=MONTHLY;BYMONTHDAY=15;COUNT=3", "2020-09-01", 10)
+	if diff := cmp.Diff([]string{"2020-09-01", "2020-09-15", "2020-10-15"}, got); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestRecurrenceRule_Next_FarFromStart(t *testing.T) {
+	recurrenceRule, err := ParseRecurrenceRule("FREQ=WEEKLY;BYDAY=SA")
+	if err != nil {
+		t.Fatalf("unexpected error by ParseRecurrenceRule() '%#v'", err)
+	}
+
+	next, ok := recurrenceRule.Next(recurrenceDate(t, "2020-01-04"), recurrenceDate(t, "2021-02-01"))
+	if !ok {
+		t.Fatalf("recurrence has unexpectedly ended")
+	}
+
+	if diff := cmp.Diff("2021-02-06", next.Format("2006-01-02")); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestRecurrenceRule_Next_BeforeStart(t *testing.T) {
+	recurrenceRule, err := ParseRecurrenceRule("FREQ=MONTHLY;BYDAY=2SA,4SA")
+	if err != nil {
+		t.Fatalf("unexpected error by ParseRecurrenceRule() '%#v'", err)
+	}
+
+	next, ok := recurrenceRule.Next(recurrenceDate(t, "2020-09-10"), recurrenceDate(t, "2020-09-01"))
+	if !ok {
+		t.Fatalf("recurrence has unexpectedly ended")
+	}
+
+	if diff := cmp.Diff("2020-09-10", next.Format("2006-01-02")); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestRecurrenceRule_Next_NeverMatching(t *testing.T) {
+	got := recurrenceOccurrences(t, "FREQ=MONTHLY;INTERVAL=12;BYMONTHDAY=31", "2021-02-01", 10)
+	if diff := cmp.Diff([]string{"2021-02-01"}, got); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestCycleRecurrenceRule(t *testing.T) {
+	start := recurrenceDate(t, "2020-09-13")
+	got := []string{
+		CycleRecurrenceRule("daily", 0, start),
+		CycleRecurrenceRule("weekly", 0, start),
+		CycleRecurrenceRule("monthly", 0, start),
+		CycleRecurrenceRule("custom", 10, start),
+	}
+
+	if diff := cmp.Diff([]string{"FREQ=DAILY", "FREQ=WEEKLY", "FREQ=MONTHLY", "FREQ=DAILY;INTERVAL=10"}, got); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestCycleRecurrenceRule_MonthlyEndOfMonth(t *testing.T) {
+	tests := []struct {
+		name  string
+		start string
+		want  []string
+	}{
+		{
+			name:  "28th stays on the same day",
+			start: "2021-01-28",
+			want:  []string{"2021-01-28", "2021-02-28", "2021-03-28", "2021-04-28"},
+		},
+		{
+			name:  "29th falls on the last day of February",
+			start: "2021-01-29",
+			want:  []string{"2021-01-29", "2021-02-28", "2021-03-29", "2021-04-29"},
+		},
+		{
+			name:  "30th falls on the last day of February",
+			start: "2020-01-30",
+			want:  []string{"2020-01-30", "2020-02-29", "2020-03-30", "2020-04-30"},
+		},
+		{
+			name:  "31st falls on the last day of the shorter months",
+			start: "2021-01-31",
+			want:  []string{"2021-01-31", "2021-02-28", "2021-03-31", "2021-04-30", "2021-05-31", "2021-06-30"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := CycleRecurrenceRule("monthly", 0, recurrenceDate(t, tt.start))
+
+			got := recurrenceOccurrences(t, rule, tt.start, len(tt.want))
+			if diff := cmp.Diff(tt.want, got); len(diff) != 0 {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseRecurrenceRule(t *testing.T) {
+	recurrenceRule, err := ParseRecurrenceRule("rrule:count=5;bysetpos=+1,-1;byday=mo,-1fr;interval=2;freq=monthly")
+	if err != nil {
+		t.Fatalf("unexpected error by ParseRecurrenceRule() '%#v'", err)
+	}
+
+	want := &RecurrenceRule{
+		Freq:     "MONTHLY",
+		Interval: 2,
+		ByDay: []RecurrenceWeekday{
+			{Ordinal: 0, Weekday: time.Monday},
+			{Ordinal: -1, Weekday: time.Friday},
+		},
+		BySetPos: []int{1, -1},
+		Count:    5,
+	}
+
+	if diff := cmp.Diff(want, recurrenceRule); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+
+	if diff := cmp.Diff("FREQ=MONTHLY;INTERVAL=2;BYDAY=MO,-1FR;BYSETPOS=1,-1;COUNT=5", recurrenceRule.String()); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestParseRecurrenceRule_Invalid(t *testing.T) {
+	for _, rule := range []string{
+		"",
+		"RRULE:",
+		"FREQ=YEARLY",
+		"FREQ=DAILY;",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=DAILY;BYHOUR=9",
+		"INTERVAL=2",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;INTERVAL=1001",
+		"FREQ=DAILY;COUNT=0",
+		"FREQ=DAILY;COUNT=2;UNTIL=20201231",
+		"FREQ=DAILY;UNTIL=2020-12-31",
+		"FREQ=MONTHLY;BYMONTHDAY=0",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=MONTHLY;BYDAY=6SA",
+		"FREQ=MONTHLY;BYDAY=0SA",
+		"FREQ=MONTHLY;BYDAY=XX",
+		"FREQ=MONTHLY;BYSETPOS=1",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=WEEKLY;BYDAY=2SA",
+		"FREQ=DAILY;BYDAY=-1FR",
+	} {
+		if _, err := ParseRecurrenceRule(rule); err == nil {
+			t.Errorf("no error for invalid recurrence rule %q", rule)
+		}
+	}
+}
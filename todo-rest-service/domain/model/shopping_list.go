@@ -13,6 +13,11 @@ type ShoppingDataByDay struct {
 	ShoppingList
 }
 
+type MonthlyShoppingDataByDay struct {
+	ShoppingDataByDay
+	SkippedShoppingList
+}
+
 type ShoppingDataByCategory struct {
 	RegularShoppingList
 	ShoppingListByCategory []ShoppingListByCategory `json:"shopping_list_by_categories"`
@@ -23,6 +28,30 @@ type ShoppingListByCategory struct {
 	ShoppingList    []ShoppingItem `json:"shopping_list"`
 }
 
+type ShoppingDataByShop struct {
+	RegularShoppingList
+	ShoppingListByShop []ShoppingListByShop `json:"shopping_list_by_shops"`
+}
+
+type ShoppingListByShop struct {
+	Shop         NullString     `json:"shop"`
+	ShoppingList []ShoppingItem `json:"shopping_list"`
+}
+
+type ShopSection struct {
+	Shop          string `db:"shop"`
+	BigCategoryID int    `db:"big_category_id"`
+}
+
+type ShopSectionOrderList struct {
+	ShopSectionOrderList []ShopSectionOrder `json:"shop_section_order_list"`
+}
+
+type ShopSectionOrder struct {
+	Shop              string `json:"shop"`
+	BigCategoryIDList []int  `json:"big_category_id_list"`
+}
+
 type RegularShoppingList struct {
 	RegularShoppingList []RegularShoppingItem `json:"regular_shopping_list"`
 }
@@ -32,11 +61,16 @@ type RegularShoppingItem struct {
 	PostedDate           time.Time  `json:"posted_date"            db:"posted_date"`
 	UpdatedDate          time.Time  `json:"updated_date"           db:"updated_date"`
 	ExpectedPurchaseDate Date       `json:"expected_purchase_date" db:"expected_purchase_date" validate:"required,date_range"`
-	CycleType            string     `json:"cycle_type"             db:"cycle_type"             validate:"required,oneof=daily weekly monthly custom"`
+	CycleType            string     `json:"cycle_type"             db:"cycle_type"             validate:"required,oneof=daily weekly monthly custom rrule,with_cycle"`
 	Cycle                NullInt    `json:"cycle"                  db:"cycle"                  validate:"omitempty,min=1"`
+	RecurrenceRule       NullString `json:"recurrence_rule"        db:"recurrence_rule"        validate:"omitempty,max=255,recurrence_rule"`
+	RecurrenceStartDate  Date       `json:"-"                      db:"recurrence_start_date"`
 	Purchase             string     `json:"purchase"               db:"purchase"               validate:"required,max=50,blank"`
 	Shop                 NullString `json:"shop"                   db:"shop"                   validate:"omitempty,max=20,blank"`
-	Amount               NullInt64  `json:"amount"                 db:"amount"                 validate:"omitempty,min=1"`
+	Amount               NullInt64  `json:"amount"                 db:"amount"                 validate:"omitempty,min=1,max=1000000000,unit_price_total"`
+	Quantity             NullInt64  `json:"quantity"               db:"quantity"               validate:"omitempty,min=1,max=1000"`
+	Unit                 NullString `json:"unit"                   db:"unit"                   validate:"omitempty,max=10,blank,with_quantity"`
+	UnitPrice            NullInt64  `json:"unit_price"             db:"unit_price"             validate:"omitempty,min=1,max=1000000"`
 	BigCategoryID        int        `json:"big_category_id"        db:"big_category_id"        validate:"required,min=2,max=17,either_id"`
 	BigCategoryName      string     `json:"big_category_name"      db:"big_category_name"`
 	MediumCategoryID     NullInt64  `json:"medium_category_id"     db:"medium_category_id"     validate:"omitempty,min=1,max=99"`
@@ -44,9 +78,176 @@ type RegularShoppingItem struct {
 	CustomCategoryID     NullInt64  `json:"custom_category_id"     db:"custom_category_id"     validate:"omitempty,min=1"`
 	CustomCategoryName   NullString `json:"custom_category_name"   db:"custom_category_name"`
 	TransactionAutoAdd   BitBool    `json:"transaction_auto_add"   db:"transaction_auto_add"`
+	FinishedFlag         BitBool    `json:"finished_flag"          db:"finished_flag"`
+	PausedUntil          NullTime   `json:"paused_until"           db:"paused_until"`
+	SkippedDateList      []Date     `json:"-"                      db:"-"`
 	Today                time.Time  `json:"-"`
 }
 
+// NextExpectedPurchaseDate returns the expected purchase date following date by the recurrence rule, leaving out the skipped dates.
+// It returns false when the recurrence has ended, or the recurrence rule is invalid.
+func (r RegularShoppingItem) NextExpectedPurchaseDate(date time.Time) (time.Time, bool) {
+	return nextUnskippedDate(r.RecurrenceRule, r.RecurrenceStartDate, r.SkippedDateList, date)
+}
+
+// RescheduledExpectedPurchaseDate returns the expected purchase date after the skipped dates have changed.
+func (r RegularShoppingItem) RescheduledExpectedPurchaseDate(today time.Time) (time.Time, bool) {
+	return rescheduledDate(r.RecurrenceRule, r.RecurrenceStartDate, r.SkippedDateList, r.ExpectedPurchaseDate, today)
+}
+
+// UpcomingExpectedPurchaseDateList returns the expected purchase dates starting from date, which must be an expected purchase date itself.
+// The dates are limited to count unless count is 0, and to the ones on or before until unless until is zero.
+func (r RegularShoppingItem) UpcomingExpectedPurchaseDateList(date time.Time, count int, until time.Time) []time.Time {
+	return upcomingDateList(r.RecurrenceRule, r.RecurrenceStartDate, r.SkippedDateList, date, count, until)
+}
+
+// IsExpectedPurchaseDate reports whether date is an expected purchase date by the recurrence rule, which has not been skipped.
+func (r RegularShoppingItem) IsExpectedPurchaseDate(date time.Time) bool {
+	return isUnskippedDate(r.RecurrenceRule, r.RecurrenceStartDate, r.SkippedDateList, date)
+}
+
+// Materialization returns the shopping items which are due by today, following the expected purchase date.
+func (r RegularShoppingItem) Materialization(today time.Time) RegularShoppingMaterialization {
+	return materialize(r.NextExpectedPurchaseDate, r.ExpectedPurchaseDate.Time, today)
+}
+
+// RegularShoppingMaterialization holds the expected purchase dates of the shopping items a regular shopping item materialises,
+// the expected purchase date it is advanced to, and whether its recurrence has ended on the way.
+type RegularShoppingMaterialization struct {
+	ExpectedPurchaseDateList []time.Time
+	NextExpectedPurchaseDate time.Time
+	Finished                 bool
+}
+
+type RegularShoppingItemSkip struct {
+	SkipCount int      `json:"skip_count"`
+	SkipDate  NullTime `json:"skip_date"`
+}
+
+type RegularShoppingItemPause struct {
+	PausedUntil NullTime `json:"paused_until"`
+}
+
+type SkippedShoppingList struct {
+	SkippedShoppingList []SkippedShoppingItem `json:"skipped_shopping_list"`
+}
+
+type SkippedShoppingItem struct {
+	ID                    int        `json:"id"                       db:"id"`
+	ExpectedPurchaseDate  Date       `json:"expected_purchase_date"   db:"skipped_date"`
+	SkipReason            string     `json:"skip_reason"              db:"skip_reason"`
+	Purchase              string     `json:"purchase"                 db:"purchase"`
+	Shop                  NullString `json:"shop"                     db:"shop"`
+	Amount                NullInt64  `json:"amount"                   db:"amount"`
+	Quantity              NullInt64  `json:"quantity"                 db:"quantity"`
+	Unit                  NullString `json:"unit"                     db:"unit"`
+	UnitPrice             NullInt64  `json:"unit_price"               db:"unit_price"`
+	BigCategoryID         int        `json:"big_category_id"          db:"big_category_id"`
+	BigCategoryName       string     `json:"big_category_name"        db:"big_category_name"`
+	MediumCategoryID      NullInt64  `json:"medium_category_id"       db:"medium_category_id"`
+	MediumCategoryName    NullString `json:"medium_category_name"     db:"medium_category_name"`
+	CustomCategoryID      NullInt64  `json:"custom_category_id"       db:"custom_category_id"`
+	CustomCategoryName    NullString `json:"custom_category_name"     db:"custom_category_name"`
+	RegularShoppingListID int        `json:"regular_shopping_list_id" db:"regular_shopping_list_id"`
+}
+
+// materialize advances date by next until it passes today, collecting every date on the way so that the missed ones are not lost.
+// The date stays on the last one when the recurrence ends.
+func materialize(next func(date time.Time) (time.Time, bool), date time.Time, today time.Time) RegularShoppingMaterialization {
+	materialization := RegularShoppingMaterialization{
+		NextExpectedPurchaseDate: date,
+	}
+
+	for !today.Before(materialization.NextExpectedPurchaseDate) {
+		expectedPurchaseDate, ok := next(materialization.NextExpectedPurchaseDate)
+		if !ok {
+			materialization.Finished = true
+			break
+		}
+
+		materialization.NextExpectedPurchaseDate = expectedPurchaseDate
+		materialization.ExpectedPurchaseDateList = append(materialization.ExpectedPurchaseDateList, expectedPurchaseDate)
+	}
+
+	return materialization
+}
+
+func nextUnskippedDate(recurrenceRule NullString, start Date, skippedDateList []Date, date time.Time) (time.Time, bool) {
+	rule, err := ParseRecurrenceRule(recurrenceRule.String)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	for {
+		next, ok := rule.Next(start.Time, date)
+		if !ok {
+			return time.Time{}, false
+		}
+
+		if !containsDate(skippedDateList, next) {
+			return next, true
+		}
+
+		date = next
+	}
+}
+
+// rescheduledDate returns the first date which is not skipped, from the first occurrence after today.
+// The occurrences between today and the current expected purchase date are taken into account,
+// so that the dates whose skips have been cancelled are brought back.
+func rescheduledDate(recurrenceRule NullString, start Date, skippedDateList []Date, expectedPurchaseDate Date, today time.Time) (time.Time, bool) {
+	date := expectedPurchaseDate.Time
+	if date.After(today) {
+		rule, err := ParseRecurrenceRule(recurrenceRule.String)
+		if err != nil {
+			return time.Time{}, false
+		}
+
+		next, ok := rule.Next(start.Time, today)
+		if !ok {
+			return time.Time{}, false
+		}
+
+		date = next
+	}
+
+	if !containsDate(skippedDateList, date) {
+		return date, true
+	}
+
+	return nextUnskippedDate(recurrenceRule, start, skippedDateList, date)
+}
+
+func upcomingDateList(recurrenceRule NullString, start Date, skippedDateList []Date, date time.Time, count int, until time.Time) []time.Time {
+	var dateList []time.Time
+	for ok := true; ok && (count == 0 || len(dateList) < count) && (until.IsZero() || !date.After(until)); date, ok = nextUnskippedDate(recurrenceRule, start, skippedDateList, date) {
+		dateList = append(dateList, date)
+	}
+
+	return dateList
+}
+
+func isUnskippedDate(recurrenceRule NullString, start Date, skippedDateList []Date, date time.Time) bool {
+	rule, err := ParseRecurrenceRule(recurrenceRule.String)
+	if err != nil {
+		return false
+	}
+
+	next, ok := rule.Next(start.Time, date.AddDate(0, 0, -1))
+
+	return ok && next.Equal(date) && !containsDate(skippedDateList, date)
+}
+
+func containsDate(dateList []Date, date time.Time) bool {
+	for _, d := range dateList {
+		if d.Time.Equal(date) {
+			return true
+		}
+	}
+
+	return false
+}
+
 type ShoppingList struct {
 	ShoppingList []ShoppingItem `json:"shopping_list"`
 }
@@ -63,7 +264,10 @@ type ShoppingItem struct {
 	CompleteFlag           BitBool          `json:"complete_flag"            db:"complete_flag"`
 	Purchase               string           `json:"purchase"                 db:"purchase"               validate:"required,max=50,blank"`
 	Shop                   NullString       `json:"shop"                     db:"shop"                   validate:"omitempty,max=20,blank"`
-	Amount                 NullInt64        `json:"amount"                   db:"amount"                 validate:"omitempty,min=1"`
+	Amount                 NullInt64        `json:"amount"                   db:"amount"                 validate:"omitempty,min=1,max=1000000000,unit_price_total"`
+	Quantity               NullInt64        `json:"quantity"                 db:"quantity"               validate:"omitempty,min=1,max=1000"`
+	Unit                   NullString       `json:"unit"                     db:"unit"                   validate:"omitempty,max=10,blank,with_quantity"`
+	UnitPrice              NullInt64        `json:"unit_price"               db:"unit_price"             validate:"omitempty,min=1,max=1000000"`
 	BigCategoryID          int              `json:"big_category_id"          db:"big_category_id"        validate:"required,min=2,max=17,either_id"`
 	BigCategoryName        string           `json:"big_category_name"        db:"big_category_name"`
 	MediumCategoryID       NullInt64        `json:"medium_category_id"       db:"medium_category_id"     validate:"omitempty,min=1,max=99"`
@@ -73,9 +277,42 @@ type ShoppingItem struct {
 	RegularShoppingListID  NullInt64        `json:"regular_shopping_list_id" db:"regular_shopping_list_id"`
 	TransactionAutoAdd     BitBool          `json:"transaction_auto_add"     db:"transaction_auto_add"`
 	RelatedTransactionData *TransactionData `json:"related_transaction_data" db:"transaction_id"`
+	PriceSuggestion        *PriceSuggestion `json:"price_suggestion,omitempty"`
 	Today                  time.Time        `json:"-"`
 }
 
+type ShoppingItemPrice struct {
+	ShoppingItemID         int              `json:"shopping_item_id" db:"id"`
+	PurchaseDate           Date             `json:"purchase_date"    db:"expected_purchase_date"`
+	Purchase               string           `json:"purchase"         db:"purchase"`
+	Shop                   NullString       `json:"shop"             db:"shop"`
+	Amount                 NullInt64        `json:"amount"           db:"amount"`
+	Quantity               NullInt64        `json:"-"                db:"quantity"`
+	RelatedTransactionData *TransactionData `json:"-"                db:"transaction_id"`
+}
+
+type ShoppingItemPriceHistoryList struct {
+	ShoppingItemPriceHistoryList []ShoppingItemPriceHistory `json:"price_history_list"`
+}
+
+type ShoppingItemPriceHistory struct {
+	Purchase     string              `json:"purchase"`
+	Shop         NullString          `json:"shop"`
+	LastPrice    int64               `json:"last_price"`
+	AveragePrice int64               `json:"average_price"`
+	MinPrice     int64               `json:"min_price"`
+	MaxPrice     int64               `json:"max_price"`
+	PriceList    []ShoppingItemPrice `json:"price_list"`
+}
+
+type PriceSuggestion struct {
+	LastPrice                int64      `json:"last_price"`
+	LastShop                 NullString `json:"last_shop"`
+	AveragePrice             int64      `json:"average_price"`
+	CheapestShop             NullString `json:"cheapest_shop"`
+	CheapestShopAveragePrice NullInt64  `json:"cheapest_shop_average_price"`
+}
+
 type TransactionData struct {
 	ID                 NullInt64  `json:"id"`
 	TransactionType    string     `json:"transaction_type"`
@@ -104,6 +341,17 @@ func (t *TransactionData) Scan(value interface{}) error {
 	return nil
 }
 
+type CustomCategoriesMerge struct {
+	SourceCustomCategoryID int `json:"source_custom_category_id"`
+	TargetCustomCategoryID int `json:"target_custom_category_id"`
+}
+
+type MergedShoppingItemsID struct {
+	SourceCustomCategoryID    int   `json:"source_custom_category_id,omitempty"`
+	ShoppingItemIDList        []int `json:"shopping_item_id_list"`
+	RegularShoppingItemIDList []int `json:"regular_shopping_item_id_list"`
+}
+
 type NullInt64 struct {
 	sql.NullInt64
 }
@@ -0,0 +1,216 @@
+package model
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func weeklyRegularShoppingItem(t *testing.T, expectedPurchaseDate string, skippedDateList ...string) RegularShoppingItem {
+	t.Helper()
+
+	regularShoppingItem := RegularShoppingItem{
+		ExpectedPurchaseDate: Date{Time: recurrenceDate(t, expectedPurchaseDate)},
+		RecurrenceRule:       NullString{NullString: sql.NullString{String: "FREQ=WEEKLY", Valid: true}},
+		RecurrenceStartDate:  Date{Time: recurrenceDate(t, "2020-09-13")},
+	}
+
+	for _, skippedDate := range skippedDateList {
+		regularShoppingItem.SkippedDateList = append(regularShoppingItem.SkippedDateList, Date{Time: recurrenceDate(t, skippedDate)})
+	}
+
+	return regularShoppingItem
+}
+
+func formatDateList(dateList []time.Time) []string {
+	var formattedDateList []string
+	for _, date := range dateList {
+		formattedDateList = append(formattedDateList, date.Format("2006-01-02"))
+	}
+
+	return formattedDateList
+}
+
+func TestRegularShoppingItem_NextExpectedPurchaseDate(t *testing.T) {
+	regularShoppingItem := weeklyRegularShoppingItem(t, "2020-09-13", "2020-09-20", "2020-09-27")
+
+	got, ok := regularShoppingItem.NextExpectedPurchaseDate(recurrenceDate(t, "2020-09-13"))
+	if !ok {
+		t.Fatalf("unexpected end of recurrence")
+	}
+
+	if diff := cmp.Diff("2020-10-04", got.Format("2006-01-02")); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestRegularShoppingItem_UpcomingExpectedPurchaseDateList(t *testing.T) {
+	regularShoppingItem := weeklyRegularShoppingItem(t, "2020-09-13", "2020-09-20")
+
+	got := formatDateList(regularShoppingItem.UpcomingExpectedPurchaseDateList(recurrenceDate(t, "2020-09-13"), 3, time.Time{}))
+	if diff := cmp.Diff([]string{"2020-09-13", "2020-09-27", "2020-10-04"}, got); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+
+	got = formatDateList(regularShoppingItem.UpcomingExpectedPurchaseDateList(recurrenceDate(t, "2020-09-13"), 0, recurrenceDate(t, "2020-10-03")))
+	if diff := cmp.Diff([]string{"2020-09-13", "2020-09-27"}, got); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestRegularShoppingItem_IsExpectedPurchaseDate(t *testing.T) {
+	regularShoppingItem := weeklyRegularShoppingItem(t, "2020-09-13", "2020-09-20")
+
+	tests := map[string]bool{
+		"2020-09-13": true,
+		"2020-09-20": false,
+		"2020-09-21": false,
+		"2020-09-27": true,
+	}
+
+	for date, want := range tests {
+		if got := regularShoppingItem.IsExpectedPurchaseDate(recurrenceDate(t, date)); got != want {
+			t.Errorf("IsExpectedPurchaseDate(%s) = %t, want %t", date, got, want)
+		}
+	}
+}
+
+func TestRegularShoppingItem_RescheduledExpectedPurchaseDate(t *testing.T) {
+	tests := []struct {
+		name                 string
+		expectedPurchaseDate string
+		skippedDateList      []string
+		want                 string
+	}{
+		{name: "skipped", expectedPurchaseDate: "2020-09-13", skippedDateList: []string{"2020-09-13", "2020-09-20"}, want: "2020-09-27"},
+		{name: "skip cancelled", expectedPurchaseDate: "2020-09-27", want: "2020-09-13"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			regularShoppingItem := weeklyRegularShoppingItem(t, tt.expectedPurchaseDate, tt.skippedDateList...)
+
+			got, ok := regularShoppingItem.RescheduledExpectedPurchaseDate(recurrenceDate(t, "2020-09-06"))
+			if !ok {
+				t.Fatalf("unexpected end of recurrence")
+			}
+
+			if diff := cmp.Diff(tt.want, got.Format("2006-01-02")); len(diff) != 0 {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRegularShoppingItem_Materialization(t *testing.T) {
+	type materialization struct {
+		ExpectedPurchaseDateList []string
+		NextExpectedPurchaseDate string
+		Finished                 bool
+	}
+
+	tests := []struct {
+		name                string
+		regularShoppingItem RegularShoppingItem
+		today               string
+		want                materialization
+	}{
+		{
+			name:                "not due yet",
+			regularShoppingItem: weeklyRegularShoppingItem(t, "2020-09-20"),
+			today:               "2020-09-13",
+			want: materialization{
+				NextExpectedPurchaseDate: "2020-09-20",
+			},
+		},
+		{
+			name:                "due today",
+			regularShoppingItem: weeklyRegularShoppingItem(t, "2020-09-13"),
+			today:               "2020-09-13",
+			want: materialization{
+				ExpectedPurchaseDateList: []string{"2020-09-20"},
+				NextExpectedPurchaseDate: "2020-09-20",
+			},
+		},
+		{
+			name:                "every missed date is materialised",
+			regularShoppingItem: weeklyRegularShoppingItem(t, "2020-09-13"),
+			today:               "2020-10-05",
+			want: materialization{
+				ExpectedPurchaseDateList: []string{"2020-09-20", "2020-09-27", "2020-10-04", "2020-10-11"},
+				NextExpectedPurchaseDate: "2020-10-11",
+			},
+		},
+		{
+			name:                "skipped dates are left out",
+			regularShoppingItem: weeklyRegularShoppingItem(t, "2020-09-13", "2020-09-20"),
+			today:               "2020-09-13",
+			want: materialization{
+				ExpectedPurchaseDateList: []string{"2020-09-27"},
+				NextExpectedPurchaseDate: "2020-09-27",
+			},
+		},
+		{
+			name: "recurrence ends on the way",
+			regularShoppingItem: func() RegularShoppingItem {
+				regularShoppingItem := weeklyRegularShoppingItem(t, "2020-09-13")
+				regularShoppingItem.RecurrenceRule = NullString{NullString: sql.NullString{String: "FREQ=WEEKLY;COUNT=3", Valid: true}}
+
+				return regularShoppingItem
+			}(),
+			today: "2020-10-05",
+			want: materialization{
+				ExpectedPurchaseDateList: []string{"2020-09-20", "2020-09-27"},
+				NextExpectedPurchaseDate: "2020-09-27",
+				Finished:                 true,
+			},
+		},
+		{
+			name: "recurrence has already ended",
+			regularShoppingItem: func() RegularShoppingItem {
+				regularShoppingItem := weeklyRegularShoppingItem(t, "2020-09-20")
+				regularShoppingItem.RecurrenceRule = NullString{NullString: sql.NullString{String: "FREQ=WEEKLY;COUNT=2", Valid: true}}
+
+				return regularShoppingItem
+			}(),
+			today: "2020-10-05",
+			want: materialization{
+				NextExpectedPurchaseDate: "2020-09-20",
+				Finished:                 true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			regularShoppingMaterialization := tt.regularShoppingItem.Materialization(recurrenceDate(t, tt.today))
+
+			got := materialization{
+				ExpectedPurchaseDateList: formatDateList(regularShoppingMaterialization.ExpectedPurchaseDateList),
+				NextExpectedPurchaseDate: regularShoppingMaterialization.NextExpectedPurchaseDate.Format("2006-01-02"),
+				Finished:                 regularShoppingMaterialization.Finished,
+			}
+
+			if diff := cmp.Diff(tt.want, got); len(diff) != 0 {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGroupRegularShoppingItem_Materialization(t *testing.T) {
+	regularShoppingItem := weeklyRegularShoppingItem(t, "2020-09-13", "2020-09-27")
+	groupRegularShoppingItem := GroupRegularShoppingItem{
+		ExpectedPurchaseDate: regularShoppingItem.ExpectedPurchaseDate,
+		RecurrenceRule:       regularShoppingItem.RecurrenceRule,
+		RecurrenceStartDate:  regularShoppingItem.RecurrenceStartDate,
+		SkippedDateList:      regularShoppingItem.SkippedDateList,
+	}
+
+	today := recurrenceDate(t, "2020-10-05")
+	if diff := cmp.Diff(regularShoppingItem.Materialization(today), groupRegularShoppingItem.Materialization(today)); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
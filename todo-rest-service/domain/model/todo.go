@@ -1,6 +1,7 @@
 package model
 
 import (
+	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
@@ -22,13 +23,79 @@ type SearchTodoList struct {
 }
 
 type Todo struct {
-	ID                 int       `json:"id"                  db:"id"`
-	PostedDate         time.Time `json:"posted_date"         db:"posted_date"`
-	UpdatedDate        time.Time `json:"updated_date"        db:"updated_date"`
-	ImplementationDate Date      `json:"implementation_date" db:"implementation_date" validate:"required,date"`
-	DueDate            Date      `json:"due_date"            db:"due_date"            validate:"required,date"`
-	TodoContent        string    `json:"todo_content"        db:"todo_content"        validate:"required,max=100,blank"`
-	CompleteFlag       BitBool   `json:"complete_flag"       db:"complete_flag"`
+	ID                  int           `json:"id"                  db:"id"`
+	PostedDate          time.Time     `json:"posted_date"         db:"posted_date"`
+	UpdatedDate         time.Time     `json:"updated_date"        db:"updated_date"`
+	ImplementationDate  Date          `json:"implementation_date" db:"implementation_date" validate:"required,date"`
+	DueDate             Date          `json:"due_date"            db:"due_date"            validate:"required,date"`
+	TodoContent         string        `json:"todo_content"        db:"todo_content"        validate:"required,max=100,blank"`
+	CompleteFlag        BitBool       `json:"complete_flag"       db:"complete_flag"`
+	RecurrenceType      NullString    `json:"recurrence_type"     db:"recurrence_type"     validate:"omitempty,oneof=schedule after_completion,with_recurrence"`
+	RecurrenceRule      NullString    `json:"recurrence_rule"     db:"recurrence_rule"     validate:"omitempty,max=255,recurrence_rule"`
+	RecurrenceInterval  NullInt       `json:"recurrence_interval" db:"recurrence_interval" validate:"omitempty,min=1,max=365"`
+	RecurrenceStartDate NullTime      `json:"-"                   db:"recurrence_start_date"`
+	SeriesID            NullInt64     `json:"series_id"           db:"series_id"`
+	Priority            int           `json:"priority"            db:"priority"            validate:"min=0,max=3"`
+	LabelList           []string      `json:"label_list"          db:"-"                   validate:"max=10,unique,dive,required,max=20,blank"`
+	SubtaskList         []TodoSubtask `json:"subtask_list"        db:"-"                   validate:"max=30,dive"`
+}
+
+type TodoSubtask struct {
+	ID             int     `json:"id"              db:"id"`
+	SubtaskContent string  `json:"subtask_content" db:"subtask_content" validate:"required,max=100,blank"`
+	CompleteFlag   BitBool `json:"complete_flag"   db:"complete_flag"`
+}
+
+const (
+	TodoEditScopeThis      = "this"
+	TodoEditScopeFollowing = "following"
+)
+
+type TodoSearchDateType string
+
+const (
+	TodoSearchDateTypeImplementation TodoSearchDateType = "implementation_date"
+	TodoSearchDateTypeDue            TodoSearchDateType = "due_date"
+)
+
+type TodoSearchSort string
+
+const (
+	TodoSearchSortImplementationDate TodoSearchSort = "implementation_date"
+	TodoSearchSortDueDate            TodoSearchSort = "due_date"
+	TodoSearchSortPostedDate         TodoSearchSort = "posted_date"
+	TodoSearchSortUpdatedDate        TodoSearchSort = "updated_date"
+	TodoSearchSortTodoContent        TodoSearchSort = "todo_content"
+	TodoSearchSortCompleteFlag       TodoSearchSort = "complete_flag"
+	TodoSearchSortPriority           TodoSearchSort = "priority"
+	TodoSearchSortLabel              TodoSearchSort = "label"
+)
+
+type TodoSearchSortType string
+
+const (
+	TodoSearchSortTypeAsc  TodoSearchSortType = "asc"
+	TodoSearchSortTypeDesc TodoSearchSortType = "desc"
+)
+
+// TodoSearchCriteria is the conditions of the todo search.
+// An empty or invalid field is not used as a condition, and the todo list is sorted by the implementation date by default.
+type TodoSearchCriteria struct {
+	DateType     TodoSearchDateType
+	StartDate    NullTime
+	EndDate      NullTime
+	CompleteFlag sql.NullBool
+	TodoContent  string
+	Priority     NullInt
+	Label        string
+	Sort         TodoSearchSort
+	SortType     TodoSearchSortType
+	Limit        NullInt
+}
+
+type GroupTodoSearchCriteria struct {
+	TodoSearchCriteria
+	UsersID []string
 }
 
 type Date struct {
@@ -44,6 +111,34 @@ func NewTodoList(implementationTodoList []Todo, dueTodoList []Todo) TodoList {
 	}
 }
 
+func (t TodoSearchDateType) IsValid() bool {
+	switch t {
+	case TodoSearchDateTypeImplementation, TodoSearchDateTypeDue:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s TodoSearchSort) IsValid() bool {
+	switch s {
+	case TodoSearchSortImplementationDate, TodoSearchSortDueDate, TodoSearchSortPostedDate, TodoSearchSortUpdatedDate,
+		TodoSearchSortTodoContent, TodoSearchSortCompleteFlag, TodoSearchSortPriority, TodoSearchSortLabel:
+		return true
+	default:
+		return false
+	}
+}
+
+func (t TodoSearchSortType) IsValid() bool {
+	switch t {
+	case TodoSearchSortTypeAsc, TodoSearchSortTypeDesc:
+		return true
+	default:
+		return false
+	}
+}
+
 func NewSearchTodoList(searchTodoList []Todo) SearchTodoList {
 	return SearchTodoList{
 		SearchTodoList: searchTodoList,
@@ -123,3 +218,73 @@ func (t Todo) ShowTodo() (string, error) {
 
 	return string(b), nil
 }
+
+// NextTodo returns the next instance of the recurring todo, which is completed on completionDate.
+// It returns false when the todo does not recur, or the recurrence has ended.
+func (t Todo) NextTodo(completionDate time.Time) (Todo, bool) {
+	implementationDate, ok := nextRecurrenceDate(t.RecurrenceType, t.RecurrenceRule, t.RecurrenceInterval, t.RecurrenceStartDate, t.ImplementationDate, completionDate)
+	if !ok {
+		return Todo{}, false
+	}
+
+	return Todo{
+		ImplementationDate:  Date{Time: implementationDate},
+		DueDate:             Date{Time: implementationDate.AddDate(0, 0, recurrenceDueDays(t.ImplementationDate, t.DueDate))},
+		TodoContent:         t.TodoContent,
+		RecurrenceType:      t.RecurrenceType,
+		RecurrenceRule:      t.RecurrenceRule,
+		RecurrenceInterval:  t.RecurrenceInterval,
+		RecurrenceStartDate: t.RecurrenceStartDate,
+		SeriesID:            t.SeriesID,
+		Priority:            t.Priority,
+		LabelList:           t.LabelList,
+		SubtaskList:         nextSubtaskList(t.SubtaskList),
+	}, true
+}
+
+// nextRecurrenceDate returns the implementation date of the next instance of a recurring todo.
+// The todo recurs either on the dates of the recurrence rule, or the days of the recurrence interval after its completion.
+func nextRecurrenceDate(recurrenceType NullString, recurrenceRule NullString, recurrenceInterval NullInt, recurrenceStartDate NullTime, implementationDate Date, completionDate time.Time) (time.Time, bool) {
+	if !recurrenceType.Valid {
+		return time.Time{}, false
+	}
+
+	switch recurrenceType.String {
+	case "schedule":
+		rule, err := ParseRecurrenceRule(recurrenceRule.String)
+		if err != nil {
+			return time.Time{}, false
+		}
+
+		start := implementationDate.Time
+		if recurrenceStartDate.Valid {
+			start = recurrenceStartDate.Time
+		}
+
+		return rule.Next(start, implementationDate.Time)
+	case "after_completion":
+		if !recurrenceInterval.Valid {
+			return time.Time{}, false
+		}
+
+		return completionDate.AddDate(0, 0, recurrenceInterval.Int), true
+	}
+
+	return time.Time{}, false
+}
+
+// nextSubtaskList returns the subtasks of the next instance of a recurring todo, which are all incomplete.
+func nextSubtaskList(subtaskList []TodoSubtask) []TodoSubtask {
+	nextSubtaskList := make([]TodoSubtask, 0, len(subtaskList))
+	for _, subtask := range subtaskList {
+		nextSubtaskList = append(nextSubtaskList, TodoSubtask{SubtaskContent: subtask.SubtaskContent})
+	}
+
+	return nextSubtaskList
+}
+
+// recurrenceDueDays returns the number of days from the implementation date to the due date,
+// which is kept by the following instances of a recurring todo.
+func recurrenceDueDays(implementationDate Date, dueDate Date) int {
+	return int(dueDate.Time.Sub(implementationDate.Time).Hours() / 24)
+}
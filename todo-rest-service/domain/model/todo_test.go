@@ -0,0 +1,51 @@
+package model
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTodo_NextTodo(t *testing.T) {
+	tests := []struct {
+		name           string
+		recurrenceType string
+		recurrenceRule string
+		completionDate string
+		want           []string
+		wantOK         bool
+	}{
+		{name: "schedule", recurrenceType: "schedule", recurrenceRule: "FREQ=WEEKLY;INTERVAL=2", completionDate: "2020-09-20", want: []string{"2020-09-27", "2020-09-29"}, wantOK: true},
+		{name: "after completion", recurrenceType: "after_completion", completionDate: "2020-09-20", want: []string{"2020-10-04", "2020-10-06"}, wantOK: true},
+		{name: "recurrence ended", recurrenceType: "schedule", recurrenceRule: "FREQ=WEEKLY;UNTIL=20200919", completionDate: "2020-09-13", wantOK: false},
+		{name: "not recurring", completionDate: "2020-09-13", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			todo := Todo{
+				ImplementationDate:  Date{Time: recurrenceDate(t, "2020-09-13")},
+				DueDate:             Date{Time: recurrenceDate(t, "2020-09-15")},
+				TodoContent:         "シーツ洗濯",
+				RecurrenceType:      NullString{NullString: sql.NullString{String: tt.recurrenceType, Valid: len(tt.recurrenceType) != 0}},
+				RecurrenceRule:      NullString{NullString: sql.NullString{String: tt.recurrenceRule, Valid: len(tt.recurrenceRule) != 0}},
+				RecurrenceInterval:  NullInt{Int: 14, Valid: tt.recurrenceType == "after_completion"},
+				RecurrenceStartDate: NullTime{NullTime: sql.NullTime{Time: recurrenceDate(t, "2020-09-13"), Valid: true}},
+			}
+
+			got, ok := todo.NextTodo(recurrenceDate(t, tt.completionDate))
+			if ok != tt.wantOK {
+				t.Fatalf("NextTodo() ok = %t, want %t", ok, tt.wantOK)
+			}
+
+			if !ok {
+				return
+			}
+
+			if diff := cmp.Diff(tt.want, []string{got.ImplementationDate.Format("2006-01-02"), got.DueDate.Format("2006-01-02")}); len(diff) != 0 {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
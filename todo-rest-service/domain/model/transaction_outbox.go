@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+type TransactionCommand struct {
+	ID              int        `db:"id"`
+	Command         string     `db:"command"`
+	UserID          string     `db:"user_id"`
+	GroupID         NullInt64  `db:"group_id"`
+	ShoppingItemID  int        `db:"shopping_item_id"`
+	TransactionID   NullInt64  `db:"transaction_id"`
+	IdempotencyKey  string     `db:"idempotency_key"`
+	Payload         NullString `db:"payload"`
+	Status          string     `db:"status"`
+	Attempts        int        `db:"attempts"`
+	NextAttemptDate time.Time  `db:"next_attempt_date"`
+	LastError       NullString `db:"last_error"`
+}
+
+type TransactionReconciliation struct {
+	TransactionMismatchList []TransactionMismatch `json:"transaction_mismatch_list"`
+}
+
+type TransactionMismatch struct {
+	ShoppingItemID int       `json:"shopping_item_id"`
+	TransactionID  NullInt64 `json:"transaction_id"`
+	Reason         string    `json:"reason"`
+	Repaired       bool      `json:"repaired"`
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"time"
 
@@ -16,17 +17,22 @@ type AuthRepository interface {
 	GetUserID(sessionID string) (string, error)
 }
 
+type SchedulerLockRepository interface {
+	AcquireSchedulerLock(name string, owner string, ttl time.Duration) (bool, error)
+	ReleaseSchedulerLock(name string, owner string) error
+}
+
 type TodoRepository interface {
-	GetDailyImplementationTodoList(date time.Time, userID string) ([]model.Todo, error)
-	GetDailyDueTodoList(date time.Time, userID string) ([]model.Todo, error)
+	GetDailyImplementationTodoList(ctx context.Context, date time.Time, userID string) ([]model.Todo, error)
+	GetDailyDueTodoList(ctx context.Context, date time.Time, userID string) ([]model.Todo, error)
 	GetMonthlyImplementationTodoList(firstDay time.Time, lastDay time.Time, userID string) ([]model.Todo, error)
 	GetMonthlyDueTodoList(firstDay time.Time, lastDay time.Time, userID string) ([]model.Todo, error)
-	GetExpiredTodoList(dueDate time.Time, userID string) (*model.ExpiredTodoList, error)
+	GetExpiredTodoList(ctx context.Context, dueDate time.Time, userID string) (*model.ExpiredTodoList, error)
 	GetTodo(todoId int) (*model.Todo, error)
 	PostTodo(todo *model.Todo, userID string) (sql.Result, error)
-	PutTodo(todo *model.Todo, todoID int) error
+	PutTodo(todo *model.Todo, todoID int, editScope string, nextTodo *model.Todo) error
 	DeleteTodo(todoID int) error
-	SearchTodoList(todoSqlQuery string) ([]model.Todo, error)
+	SearchTodoList(todoSearchCriteria model.TodoSearchCriteria, userID string) ([]model.Todo, error)
 }
 
 type ShoppingListRepository interface {
@@ -37,30 +43,47 @@ type ShoppingListRepository interface {
 	GetShoppingListRelatedToUpdatedRegularShoppingItem(regularShoppingItemID int) (model.ShoppingList, error)
 	PutRegularShoppingItem(regularShoppingItem *model.RegularShoppingItem, regularShoppingItemID int, userID string, today time.Time) error
 	PutRegularShoppingList(regularShoppingList model.RegularShoppingList, userID string, today time.Time) error
+	GetDueRegularShoppingListUserIDList(today time.Time) ([]string, error)
+	SkipRegularShoppingItem(regularShoppingItem *model.RegularShoppingItem, skippedDateList []time.Time, userID string, today time.Time) (bool, error)
+	PauseRegularShoppingItem(regularShoppingItem *model.RegularShoppingItem, userID string, today time.Time) (bool, error)
+	GetMonthlySkippedShoppingList(firstDay time.Time, lastDay time.Time, userID string) (model.SkippedShoppingList, error)
 	DeleteRegularShoppingItem(regularShoppingItemID int) error
 	GetDailyShoppingListByDay(date time.Time, userID string) (model.ShoppingList, error)
 	GetDailyShoppingListByCategory(date time.Time, userID string) (model.ShoppingList, error)
 	GetMonthlyShoppingListByDay(firstDay time.Time, lastDay time.Time, userID string) (model.ShoppingList, error)
 	GetMonthlyShoppingListByCategory(firstDay time.Time, lastDay time.Time, userID string) (model.ShoppingList, error)
-	GetExpiredShoppingList(dueDate time.Time, userID string) (model.ExpiredShoppingList, error)
+	GetDailyShoppingListByShop(date time.Time, userID string) (model.ShoppingList, error)
+	GetMonthlyShoppingListByShop(firstDay time.Time, lastDay time.Time, userID string) (model.ShoppingList, error)
+	GetExpiredShoppingList(ctx context.Context, dueDate time.Time, userID string) (model.ExpiredShoppingList, error)
 	GetShoppingItem(shoppingItemID int) (model.ShoppingItem, error)
+	GetShoppingItemPriceList(userID string) ([]model.ShoppingItemPrice, error)
+	GetShoppingItemPriceListByPurchase(userID string, normalizedPurchase string, limit int) ([]model.ShoppingItemPrice, error)
 	PostShoppingItem(shoppingItem *model.ShoppingItem, userID string) (sql.Result, error)
 	PutShoppingItem(shoppingItem *model.ShoppingItem) (sql.Result, error)
+	PutShoppingItemWithTransactionCommand(shoppingItem *model.ShoppingItem, transactionCommand *model.TransactionCommand) (sql.Result, error)
 	DeleteShoppingItem(shoppingItemID int) error
 	PutShoppingListCustomCategoryIdToMediumCategoryId(mediumCategoryID int, customCategoryID int) error
+	PutShoppingListCustomCategoryIdToCustomCategoryId(replaceCustomCategoryID int, customCategoryID int) error
+	MergeShoppingListCustomCategory(customCategoriesMerge *model.CustomCategoriesMerge) (*model.MergedShoppingItemsID, error)
+	RevertShoppingListCustomCategoryMerge(mergedShoppingItemsID *model.MergedShoppingItemsID) error
+	GetShopSectionList(userID string) ([]model.ShopSection, error)
+	PutShopSectionOrder(shopSectionOrder *model.ShopSectionOrder, userID string) error
+	GetTransactionRelatedShoppingList(userID string) (model.ShoppingList, error)
 }
 
 type GroupTodoRepository interface {
-	GetDailyImplementationGroupTodoList(date time.Time, groupID int) ([]model.GroupTodo, error)
-	GetDailyDueGroupTodoList(date time.Time, groupID int) ([]model.GroupTodo, error)
+	GetDailyImplementationGroupTodoList(ctx context.Context, date time.Time, groupID int) ([]model.GroupTodo, error)
+	GetDailyDueGroupTodoList(ctx context.Context, date time.Time, groupID int) ([]model.GroupTodo, error)
 	GetMonthlyImplementationGroupTodoList(firstDay time.Time, lastDay time.Time, groupID int) ([]model.GroupTodo, error)
 	GetMonthlyDueGroupTodoList(firstDay time.Time, lastDay time.Time, groupID int) ([]model.GroupTodo, error)
-	GetExpiredGroupTodoList(dueDate time.Time, groupID int) (*model.ExpiredGroupTodoList, error)
+	GetExpiredGroupTodoList(ctx context.Context, dueDate time.Time, groupID int) (*model.ExpiredGroupTodoList, error)
+	GetAssignedGroupTodoList(userID string) ([]model.GroupTodo, error)
+	GetExpiredAssignedGroupTodoList(dueDate time.Time, groupID int, userID string) (*model.ExpiredGroupTodoList, error)
 	PostGroupTodo(groupTodo *model.GroupTodo, userID string, groupID int) (sql.Result, error)
 	GetGroupTodo(groupTodoId int) (*model.GroupTodo, error)
-	PutGroupTodo(groupTodo *model.GroupTodo, groupTodoID int) error
+	PutGroupTodo(groupTodo *model.GroupTodo, groupTodoID int, editScope string, nextGroupTodo *model.GroupTodo) error
 	DeleteGroupTodo(groupTodoID int) error
-	SearchGroupTodoList(groupTodoSqlQuery string) ([]model.GroupTodo, error)
+	SearchGroupTodoList(groupTodoSearchCriteria model.GroupTodoSearchCriteria, groupID int) ([]model.GroupTodo, error)
 }
 
 type GroupShoppingListRepository interface {
@@ -71,22 +94,46 @@ type GroupShoppingListRepository interface {
 	GetGroupShoppingListRelatedToUpdatedGroupRegularShoppingItem(groupRegularShoppingItemID int) (model.GroupShoppingList, error)
 	PutGroupRegularShoppingItem(groupRegularShoppingItem *model.GroupRegularShoppingItem, groupRegularShoppingItemID int, groupID int, today time.Time) error
 	PutGroupRegularShoppingList(groupRegularShoppingList model.GroupRegularShoppingList, groupID int, today time.Time) error
+	GetDueGroupRegularShoppingListGroupIDList(today time.Time) ([]int, error)
+	SkipGroupRegularShoppingItem(groupRegularShoppingItem *model.GroupRegularShoppingItem, skippedDateList []time.Time, groupID int, today time.Time) (bool, error)
+	PauseGroupRegularShoppingItem(groupRegularShoppingItem *model.GroupRegularShoppingItem, groupID int, today time.Time) (bool, error)
+	GetMonthlyGroupSkippedShoppingList(firstDay time.Time, lastDay time.Time, groupID int) (model.GroupSkippedShoppingList, error)
 	DeleteGroupRegularShoppingItem(groupRegularShoppingItemID int) error
 	GetDailyGroupShoppingListByDay(date time.Time, groupID int) (model.GroupShoppingList, error)
 	GetDailyGroupShoppingListByCategory(date time.Time, groupID int) (model.GroupShoppingList, error)
 	GetMonthlyGroupShoppingListByDay(firstDay time.Time, lastDay time.Time, groupID int) (model.GroupShoppingList, error)
 	GetMonthlyGroupShoppingListByCategory(firstDay time.Time, lastDay time.Time, groupID int) (model.GroupShoppingList, error)
-	GetExpiredGroupShoppingList(dueDate time.Time, groupID int) (model.ExpiredGroupShoppingList, error)
+	GetDailyGroupShoppingListByShop(date time.Time, groupID int) (model.GroupShoppingList, error)
+	GetMonthlyGroupShoppingListByShop(firstDay time.Time, lastDay time.Time, groupID int) (model.GroupShoppingList, error)
+	GetExpiredGroupShoppingList(ctx context.Context, dueDate time.Time, groupID int) (model.ExpiredGroupShoppingList, error)
 	GetGroupShoppingItem(groupShoppingItemID int) (model.GroupShoppingItem, error)
+	GetGroupShoppingItemPriceList(groupID int) ([]model.GroupShoppingItemPrice, error)
+	GetGroupShoppingItemPriceListByPurchase(groupID int, normalizedPurchase string, limit int) ([]model.GroupShoppingItemPrice, error)
 	PostGroupShoppingItem(groupShoppingItem *model.GroupShoppingItem, groupID int) (sql.Result, error)
 	PutGroupShoppingItem(groupShoppingItem *model.GroupShoppingItem) (sql.Result, error)
+	PutGroupShoppingItemWithTransactionCommand(groupShoppingItem *model.GroupShoppingItem, transactionCommand *model.TransactionCommand) (sql.Result, error)
+	ClaimGroupShoppingItem(groupShoppingItemClaim *model.GroupShoppingItemClaim, groupShoppingItemID int, groupID int, claimedDate time.Time) (sql.Result, error)
+	UnclaimGroupShoppingItem(claimVersion int, claimedUserID string, groupShoppingItemID int, groupID int) (sql.Result, error)
+	GetClaimedGroupShoppingList(groupID int) (model.GroupShoppingList, error)
 	DeleteGroupShoppingItem(groupShoppingItemID int) error
 	PutGroupShoppingListCustomCategoryIdToMediumCategoryId(mediumCategoryID int, customCategoryID int) error
+	PutGroupShoppingListCustomCategoryIdToCustomCategoryId(replaceCustomCategoryID int, customCategoryID int) error
+	GetTransactionRelatedGroupShoppingList(groupID int) (model.GroupShoppingList, error)
+}
+
+type TransactionOutboxRepository interface {
+	PostTransactionCommand(transactionCommand *model.TransactionCommand) (sql.Result, error)
+	GetPendingTransactionCommandList(now time.Time, limit int) ([]model.TransactionCommand, error)
+	GetUndeliveredTransactionCommandList(userID string) ([]model.TransactionCommand, error)
+	GetUndeliveredGroupTransactionCommandList(groupID int) ([]model.TransactionCommand, error)
+	LeaseTransactionCommand(transactionCommand *model.TransactionCommand, leaseDate time.Time) (bool, error)
+	DeliverTransactionCommand(transactionCommand *model.TransactionCommand) error
+	PutTransactionCommand(transactionCommand *model.TransactionCommand) error
 }
 
 type GroupTasksRepository interface {
-	GetGroupTasksUsersList(groupID int) ([]model.GroupTasksUser, error)
-	GetGroupTasksListAssignedToUser(groupID int) ([]model.GroupTask, error)
+	GetGroupTasksUsersList(ctx context.Context, groupID int) ([]model.GroupTasksUser, error)
+	GetGroupTasksListAssignedToUser(ctx context.Context, groupID int) ([]model.GroupTask, error)
 	PutGroupTasksListAssignedToUser(groupTasksList []model.GroupTask, updateTaskIndexList []int) error
 	PostGroupTasksUsersList(groupTasksUsersList model.GroupTasksUsersListReceiver, groupID int) error
 	GetGroupTasksIDListAssignedToUser(groupTasksUsersIdList []int, groupID int) ([]int, error)
@@ -97,3 +144,9 @@ type GroupTasksRepository interface {
 	PutGroupTask(groupTask *model.GroupTask, groupTasksID int) (sql.Result, error)
 	DeleteGroupTask(groupTasksID int) error
 }
+
+type CalendarFeedRepository interface {
+	GetCalendarFeedToken(tokenHash string) (*model.CalendarFeedToken, error)
+	PutCalendarFeedToken(calendarFeedToken *model.CalendarFeedToken) error
+	DeleteCalendarFeedToken(userID string, groupID model.NullInt) error
+}
@@ -0,0 +1,515 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/gorilla/mux"
+
+	"github.com/hryze/kakeibo-app-api/todo-rest-service/domain/model"
+)
+
+const (
+	calendarFeedTokenBytes   = 32
+	calendarFeedPastMonths   = 1
+	calendarFeedFutureMonths = 3
+	calendarProductID        = "-//kakeibo-app//todo-rest-service//JA"
+	calendarUIDDomain        = "kakeibo-app"
+	calendarName             = "家計簿アプリ"
+
+	// calendarLineOctets is the limit of the length of a content line, excluding the line break, by RFC 5545.
+	calendarLineOctets = 75
+)
+
+// calendarTextReplacer escapes TEXT property values by RFC 5545.
+var calendarTextReplacer = strings.NewReplacer(`\`, `\\`, `;`, `\;`, `,`, `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// calendarEvent is an all-day event in the calendar feed, which lasts from startDate to endDate inclusive.
+type calendarEvent struct {
+	uid         string
+	startDate   time.Time
+	endDate     time.Time
+	summary     string
+	location    string
+	description string
+}
+
+func generateCalendarFeedToken() (string, error) {
+	b := make([]byte, calendarFeedTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// hashCalendarFeedToken returns the hash of the token, which is only stored instead of the token itself,
+// so that the feeds can not be read with the data leaked from the database.
+func hashCalendarFeedToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(hash[:])
+}
+
+func generateCalendarFeedPath(token string) string {
+	return fmt.Sprintf("/calendar/%s.ics", token)
+}
+
+func generateCalendarFeedPeriod(now time.Time) (time.Time, time.Time) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	firstDay := today.AddDate(0, -calendarFeedPastMonths, 0)
+	lastDay := today.AddDate(0, calendarFeedFutureMonths, 0).Add(24*time.Hour - time.Second)
+
+	return firstDay, lastDay
+}
+
+func generateCalendarEventUID(kind string, id int, date time.Time) string {
+	if date.IsZero() {
+		return fmt.Sprintf("%s-%d@%s", kind, id, calendarUIDDomain)
+	}
+
+	return fmt.Sprintf("%s-%d-%s@%s", kind, id, date.Format("20060102"), calendarUIDDomain)
+}
+
+func generateCalendarEventSummary(content string, completeFlag model.BitBool) string {
+	if completeFlag {
+		return "【完了】" + content
+	}
+
+	return content
+}
+
+func generateTodoCalendarEventList(todoList []model.Todo) []calendarEvent {
+	calendarEventList := make([]calendarEvent, 0, len(todoList))
+	for _, todo := range todoList {
+		calendarEventList = append(calendarEventList, calendarEvent{
+			uid:       generateCalendarEventUID("todo", todo.ID, time.Time{}),
+			startDate: todo.DueDate.Time,
+			endDate:   todo.DueDate.Time,
+			summary:   generateCalendarEventSummary(todo.TodoContent, todo.CompleteFlag),
+		})
+	}
+
+	return calendarEventList
+}
+
+func generateGroupTodoCalendarEventList(groupTodoList []model.GroupTodo) []calendarEvent {
+	calendarEventList := make([]calendarEvent, 0, len(groupTodoList))
+	for _, groupTodo := range groupTodoList {
+		var description string
+		if len(groupTodo.AssigneeList) != 0 {
+			description = "担当: " + strings.Join(groupTodo.AssigneeList, ", ")
+		}
+
+		calendarEventList = append(calendarEventList, calendarEvent{
+			uid:         generateCalendarEventUID("group-todo", groupTodo.ID, time.Time{}),
+			startDate:   groupTodo.DueDate.Time,
+			endDate:     groupTodo.DueDate.Time,
+			summary:     generateCalendarEventSummary(groupTodo.TodoContent, groupTodo.CompleteFlag),
+			description: description,
+		})
+	}
+
+	return calendarEventList
+}
+
+func generateGroupTaskCalendarEventList(groupTaskAssignmentList []model.GroupTaskAssignment) []calendarEvent {
+	calendarEventList := make([]calendarEvent, 0, len(groupTaskAssignmentList))
+	for _, groupTaskAssignment := range groupTaskAssignmentList {
+		calendarEventList = append(calendarEventList, calendarEvent{
+			uid:         generateCalendarEventUID("group-task", groupTaskAssignment.GroupTasksID, groupTaskAssignment.StartDate),
+			startDate:   groupTaskAssignment.StartDate,
+			endDate:     groupTaskAssignment.EndDate,
+			summary:     fmt.Sprintf("%s（%s）", groupTaskAssignment.TaskName, groupTaskAssignment.UserID),
+			description: "担当: " + groupTaskAssignment.UserID,
+		})
+	}
+
+	return calendarEventList
+}
+
+// generateExpectedPurchaseDateList leaves out the upcoming expected purchase dates before firstDay,
+// which remain when the regular shopping item has not been scheduled yet.
+func generateExpectedPurchaseDateList(upcomingExpectedPurchaseDateList []time.Time, firstDay time.Time) []time.Time {
+	var expectedPurchaseDateList []time.Time
+	for _, expectedPurchaseDate := range upcomingExpectedPurchaseDateList {
+		if expectedPurchaseDate.Before(firstDay) {
+			continue
+		}
+
+		expectedPurchaseDateList = append(expectedPurchaseDateList, expectedPurchaseDate)
+	}
+
+	return expectedPurchaseDateList
+}
+
+func generateRegularShoppingCalendarEventList(regularShoppingList model.RegularShoppingList, firstDay time.Time, lastDay time.Time) []calendarEvent {
+	var calendarEventList []calendarEvent
+	for _, regularShoppingItem := range regularShoppingList.RegularShoppingList {
+		if regularShoppingItem.FinishedFlag {
+			continue
+		}
+
+		upcomingExpectedPurchaseDateList := regularShoppingItem.UpcomingExpectedPurchaseDateList(regularShoppingItem.ExpectedPurchaseDate.Time, 0, lastDay)
+		for _, expectedPurchaseDate := range generateExpectedPurchaseDateList(upcomingExpectedPurchaseDateList, firstDay) {
+			calendarEventList = append(calendarEventList, calendarEvent{
+				uid:       generateCalendarEventUID("regular-shopping", regularShoppingItem.ID, expectedPurchaseDate),
+				startDate: expectedPurchaseDate,
+				endDate:   expectedPurchaseDate,
+				summary:   "購入予定: " + regularShoppingItem.Purchase,
+				location:  regularShoppingItem.Shop.String,
+			})
+		}
+	}
+
+	return calendarEventList
+}
+
+func generateGroupRegularShoppingCalendarEventList(groupRegularShoppingList model.GroupRegularShoppingList, firstDay time.Time, lastDay time.Time) []calendarEvent {
+	var calendarEventList []calendarEvent
+	for _, groupRegularShoppingItem := range groupRegularShoppingList.GroupRegularShoppingList {
+		if groupRegularShoppingItem.FinishedFlag {
+			continue
+		}
+
+		upcomingExpectedPurchaseDateList := groupRegularShoppingItem.UpcomingExpectedPurchaseDateList(groupRegularShoppingItem.ExpectedPurchaseDate.Time, 0, lastDay)
+		for _, expectedPurchaseDate := range generateExpectedPurchaseDateList(upcomingExpectedPurchaseDateList, firstDay) {
+			calendarEventList = append(calendarEventList, calendarEvent{
+				uid:       generateCalendarEventUID("group-regular-shopping", groupRegularShoppingItem.ID, expectedPurchaseDate),
+				startDate: expectedPurchaseDate,
+				endDate:   expectedPurchaseDate,
+				summary:   "購入予定: " + groupRegularShoppingItem.Purchase,
+				location:  groupRegularShoppingItem.Shop.String,
+			})
+		}
+	}
+
+	return calendarEventList
+}
+
+func (h *DBHandler) generateCalendarEventList(userID string, now time.Time) ([]calendarEvent, error) {
+	firstDay, lastDay := generateCalendarFeedPeriod(now)
+
+	dueTodoList, err := h.TodoRepo.GetMonthlyDueTodoList(firstDay, lastDay, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	regularShoppingList, err := h.ShoppingListRepo.GetRegularShoppingList(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	calendarEventList := generateTodoCalendarEventList(dueTodoList)
+	calendarEventList = append(calendarEventList, generateRegularShoppingCalendarEventList(regularShoppingList, firstDay, lastDay)...)
+
+	return calendarEventList, nil
+}
+
+func (h *DBHandler) generateGroupCalendarEventList(ctx context.Context, groupID int, now time.Time) ([]calendarEvent, error) {
+	firstDay, lastDay := generateCalendarFeedPeriod(now)
+
+	dueGroupTodoList, err := h.GroupTodoRepo.GetMonthlyDueGroupTodoList(firstDay, lastDay, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	groupTasksUsersList, err := h.GroupTasksRepo.GetGroupTasksUsersList(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	groupTasksListAssignedToUser, err := h.GroupTasksRepo.GetGroupTasksListAssignedToUser(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	groupRegularShoppingList, err := h.GroupShoppingListRepo.GetGroupRegularShoppingList(groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	var groupTaskAssignmentList []model.GroupTaskAssignment
+	for _, groupTaskAssignedToUser := range groupTasksListAssignedToUser {
+		groupTaskAssignmentList = append(groupTaskAssignmentList, generateGroupTaskAssignmentList(groupTaskAssignedToUser, groupTasksUsersList, now, firstDay, lastDay)...)
+	}
+
+	calendarEventList := generateGroupTodoCalendarEventList(dueGroupTodoList)
+	calendarEventList = append(calendarEventList, generateGroupTaskCalendarEventList(groupTaskAssignmentList)...)
+	calendarEventList = append(calendarEventList, generateGroupRegularShoppingCalendarEventList(groupRegularShoppingList, firstDay, lastDay)...)
+
+	return calendarEventList, nil
+}
+
+// writeCalendarLine writes the content line folded by RFC 5545, without splitting a multi-byte character.
+func writeCalendarLine(buf *bytes.Buffer, line string) {
+	limit := calendarLineOctets
+	for len(line) > limit {
+		i := limit
+		for i > 0 && !utf8.RuneStart(line[i]) {
+			i--
+		}
+
+		buf.WriteString(line[:i])
+		buf.WriteString("\r\n ")
+		line = line[i:]
+
+		// The leading space of the continuation line is counted in its length.
+		limit = calendarLineOctets - 1
+	}
+
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
+
+func escapeCalendarText(text string) string {
+	return calendarTextReplacer.Replace(text)
+}
+
+// generateCalendar returns the iCalendar object of the events by RFC 5545.
+func generateCalendar(name string, calendarEventList []calendarEvent, now time.Time) []byte {
+	var buf bytes.Buffer
+	dtStamp := now.UTC().Format("20060102T150405Z")
+
+	writeCalendarLine(&buf, "BEGIN:VCALENDAR")
+	writeCalendarLine(&buf, "VERSION:2.0")
+	writeCalendarLine(&buf, "PRODID:"+calendarProductID)
+	writeCalendarLine(&buf, "CALSCALE:GREGORIAN")
+	writeCalendarLine(&buf, "METHOD:PUBLISH")
+	writeCalendarLine(&buf, "X-WR-CALNAME:"+escapeCalendarText(name))
+
+	for _, event := range calendarEventList {
+		writeCalendarLine(&buf, "BEGIN:VEVENT")
+		writeCalendarLine(&buf, "UID:"+event.uid)
+		writeCalendarLine(&buf, "DTSTAMP:"+dtStamp)
+		writeCalendarLine(&buf, "DTSTART;VALUE=DATE:"+event.startDate.Format("20060102"))
+
+		// DTEND of an all-day event is the day after the event ends.
+		writeCalendarLine(&buf, "DTEND;VALUE=DATE:"+event.endDate.AddDate(0, 0, 1).Format("20060102"))
+		writeCalendarLine(&buf, "SUMMARY:"+escapeCalendarText(event.summary))
+
+		if len(event.location) != 0 {
+			writeCalendarLine(&buf, "LOCATION:"+escapeCalendarText(event.location))
+		}
+
+		if len(event.description) != 0 {
+			writeCalendarLine(&buf, "DESCRIPTION:"+escapeCalendarText(event.description))
+		}
+
+		writeCalendarLine(&buf, "TRANSP:TRANSPARENT")
+		writeCalendarLine(&buf, "END:VEVENT")
+	}
+
+	writeCalendarLine(&buf, "END:VCALENDAR")
+
+	return buf.Bytes()
+}
+
+func (h *DBHandler) putCalendarFeedToken(userID string, groupID model.NullInt) (model.CalendarFeed, error) {
+	token, err := generateCalendarFeedToken()
+	if err != nil {
+		return model.CalendarFeed{}, err
+	}
+
+	calendarFeedToken := model.CalendarFeedToken{
+		UserID:    userID,
+		GroupID:   groupID,
+		TokenHash: hashCalendarFeedToken(token),
+	}
+
+	if err := h.CalendarFeedRepo.PutCalendarFeedToken(&calendarFeedToken); err != nil {
+		return model.CalendarFeed{}, err
+	}
+
+	return model.NewCalendarFeed(token, generateCalendarFeedPath(token)), nil
+}
+
+func (h *DBHandler) PostCalendarFeedToken(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	calendarFeed, err := h.putCalendarFeedToken(userID, model.NullInt{})
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(&calendarFeed); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) DeleteCalendarFeedToken(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if err := h.CalendarFeedRepo.DeleteCalendarFeedToken(userID, model.NullInt{}); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(DeleteContentMsg{localizeMessage(r.Context(), "カレンダーの購読URLを無効にしました。")}); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) PostGroupCalendarFeedToken(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		return
+	}
+
+	if err := verifyGroupAffiliation(groupID, userID); err != nil {
+		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
+		if !ok {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		return
+	}
+
+	calendarFeed, err := h.putCalendarFeedToken(userID, model.NullInt{Int: groupID, Valid: true})
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(&calendarFeed); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) DeleteGroupCalendarFeedToken(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		return
+	}
+
+	// The token is revoked even if the user has left the group, so that the group affiliation is not verified.
+	if err := h.CalendarFeedRepo.DeleteCalendarFeedToken(userID, model.NullInt{Int: groupID, Valid: true}); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(DeleteContentMsg{localizeMessage(r.Context(), "カレンダーの購読URLを無効にしました。")}); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+// GetCalendarFeed serves the feed authenticated by the token in the URL instead of the session cookie,
+// since calendar applications subscribe to it without logging in.
+func (h *DBHandler) GetCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	calendarFeedToken, err := h.CalendarFeedRepo.GetCalendarFeedToken(hashCalendarFeedToken(mux.Vars(r)["token"]))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"カレンダーの購読URLが無効です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	now := h.TimeManage.Now()
+
+	var calendarEventList []calendarEvent
+	name := calendarName
+	if calendarFeedToken.GroupID.Valid {
+		// The member who has left the group can no longer read its feed with the token issued before.
+		if err := verifyGroupAffiliation(calendarFeedToken.GroupID.Int, calendarFeedToken.UserID); err != nil {
+			badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
+			if !ok {
+				errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+				return
+			}
+
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+			return
+		}
+
+		calendarEventList, err = h.generateGroupCalendarEventList(r.Context(), calendarFeedToken.GroupID.Int, now)
+		name = fmt.Sprintf("%s（グループ%d）", calendarName, calendarFeedToken.GroupID.Int)
+	} else {
+		calendarEventList, err = h.generateCalendarEventList(calendarFeedToken.UserID, now)
+	}
+
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(generateCalendar(name, calendarEventList, now)); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
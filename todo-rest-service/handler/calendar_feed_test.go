@@ -0,0 +1,704 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/hryze/kakeibo-app-api/todo-rest-service/config"
+	"github.com/hryze/kakeibo-app-api/todo-rest-service/domain/model"
+	"github.com/hryze/kakeibo-app-api/todo-rest-service/testutil"
+)
+
+const (
+	testCalendarFeedToken      = "0f1e2d3c4b5a69788796a5b4c3d2e1f00f1e2d3c4b5a69788796a5b4c3d2e1f0"
+	testGroupCalendarFeedToken = "8796a5b4c3d2e1f00f1e2d3c4b5a69788796a5b4c3d2e1f00f1e2d3c4b5a6978"
+)
+
+type MockCalendarFeedRepository struct {
+	mu                    sync.Mutex
+	calendarFeedTokenList []model.CalendarFeedToken
+}
+
+func NewMockCalendarFeedRepository() *MockCalendarFeedRepository {
+	return &MockCalendarFeedRepository{
+		calendarFeedTokenList: []model.CalendarFeedToken{
+			{ID: 1, UserID: "userID1", TokenHash: hashCalendarFeedToken(testCalendarFeedToken)},
+			{ID: 2, UserID: "userID1", GroupID: model.NullInt{Int: 1, Valid: true}, TokenHash: hashCalendarFeedToken(testGroupCalendarFeedToken)},
+		},
+	}
+}
+
+func (m *MockCalendarFeedRepository) GetCalendarFeedToken(tokenHash string) (*model.CalendarFeedToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, calendarFeedToken := range m.calendarFeedTokenList {
+		if calendarFeedToken.TokenHash == tokenHash {
+			return &calendarFeedToken, nil
+		}
+	}
+
+	return nil, sql.ErrNoRows
+}
+
+func (m *MockCalendarFeedRepository) PutCalendarFeedToken(calendarFeedToken *model.CalendarFeedToken) error {
+	m.deleteCalendarFeedToken(calendarFeedToken.UserID, calendarFeedToken.GroupID)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, storedCalendarFeedToken := range m.calendarFeedTokenList {
+		if storedCalendarFeedToken.ID > calendarFeedToken.ID {
+			calendarFeedToken.ID = storedCalendarFeedToken.ID
+		}
+	}
+
+	calendarFeedToken.ID++
+	m.calendarFeedTokenList = append(m.calendarFeedTokenList, *calendarFeedToken)
+
+	return nil
+}
+
+func (m *MockCalendarFeedRepository) DeleteCalendarFeedToken(userID string, groupID model.NullInt) error {
+	m.deleteCalendarFeedToken(userID, groupID)
+
+	return nil
+}
+
+func (m *MockCalendarFeedRepository) deleteCalendarFeedToken(userID string, groupID model.NullInt) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	calendarFeedTokenList := make([]model.CalendarFeedToken, 0, len(m.calendarFeedTokenList))
+	for _, calendarFeedToken := range m.calendarFeedTokenList {
+		if calendarFeedToken.UserID == userID && calendarFeedToken.GroupID == groupID {
+			continue
+		}
+
+		calendarFeedTokenList = append(calendarFeedTokenList, calendarFeedToken)
+	}
+
+	m.calendarFeedTokenList = calendarFeedTokenList
+}
+
+// MockCalendarFeedShoppingListRepository returns the regular shopping list whose recurrences can be expanded into the feed.
+type MockCalendarFeedShoppingListRepository struct {
+	MockShoppingListRepository
+}
+
+func (m MockCalendarFeedShoppingListRepository) GetRegularShoppingList(userID string) (model.RegularShoppingList, error) {
+	return model.RegularShoppingList{
+		RegularShoppingList: []model.RegularShoppingItem{
+			{
+				ID:                   1,
+				ExpectedPurchaseDate: model.Date{Time: time.Date(2020, 9, 25, 0, 0, 0, 0, time.UTC)},
+				CycleType:            "monthly",
+				RecurrenceRule:       model.NullString{NullString: sql.NullString{String: "FREQ=MONTHLY", Valid: true}},
+				RecurrenceStartDate:  model.Date{Time: time.Date(2020, 8, 25, 0, 0, 0, 0, time.UTC)},
+				Purchase:             "米",
+				Shop:                 model.NullString{NullString: sql.NullString{String: "コストコ", Valid: true}},
+				BigCategoryID:        2,
+			},
+			{
+				ID:                   2,
+				ExpectedPurchaseDate: model.Date{Time: time.Date(2020, 9, 13, 0, 0, 0, 0, time.UTC)},
+				CycleType:            "rrule",
+				RecurrenceRule:       model.NullString{NullString: sql.NullString{String: "FREQ=WEEKLY;INTERVAL=2", Valid: true}},
+				RecurrenceStartDate:  model.Date{Time: time.Date(2020, 9, 13, 0, 0, 0, 0, time.UTC)},
+				Purchase:             "トイレットペーパー 12ロール, ダブル; 香り付き\\詰め替え用も忘れずに購入する",
+				Shop:                 model.NullString{NullString: sql.NullString{String: "クリエイト", Valid: true}},
+				BigCategoryID:        3,
+				SkippedDateList:      []model.Date{{Time: time.Date(2020, 9, 27, 0, 0, 0, 0, time.UTC)}},
+			},
+			{
+				ID:                   3,
+				ExpectedPurchaseDate: model.Date{Time: time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC)},
+				CycleType:            "daily",
+				RecurrenceRule:       model.NullString{NullString: sql.NullString{String: "FREQ=DAILY;COUNT=3", Valid: true}},
+				RecurrenceStartDate:  model.Date{Time: time.Date(2020, 8, 30, 0, 0, 0, 0, time.UTC)},
+				Purchase:             "牛乳",
+				BigCategoryID:        2,
+				FinishedFlag:         true,
+			},
+		},
+	}, nil
+}
+
+// MockCalendarFeedGroupShoppingListRepository returns the group regular shopping list whose recurrences can be expanded into the feed.
+type MockCalendarFeedGroupShoppingListRepository struct {
+	MockGroupShoppingListRepository
+}
+
+func (m MockCalendarFeedGroupShoppingListRepository) GetGroupRegularShoppingList(groupID int) (model.GroupRegularShoppingList, error) {
+	return model.GroupRegularShoppingList{
+		GroupRegularShoppingList: []model.GroupRegularShoppingItem{
+			{
+				ID:                   1,
+				ExpectedPurchaseDate: model.Date{Time: time.Date(2020, 9, 10, 0, 0, 0, 0, time.UTC)},
+				CycleType:            "monthly",
+				RecurrenceRule:       model.NullString{NullString: sql.NullString{String: "FREQ=MONTHLY", Valid: true}},
+				RecurrenceStartDate:  model.Date{Time: time.Date(2020, 9, 10, 0, 0, 0, 0, time.UTC)},
+				Purchase:             "洗濯用洗剤",
+				Shop:                 model.NullString{NullString: sql.NullString{String: "クリエイト", Valid: true}},
+				BigCategoryID:        3,
+			},
+		},
+	}, nil
+}
+
+// MockCalendarFeedGroupTasksRepository returns the group tasks whose cycles are long enough to keep the feed small.
+type MockCalendarFeedGroupTasksRepository struct {
+	MockGroupTasksRepository
+}
+
+func (m MockCalendarFeedGroupTasksRepository) GetGroupTasksListAssignedToUser(ctx context.Context, groupID int) ([]model.GroupTask, error) {
+	return []model.GroupTask{
+		{
+			ID:               5,
+			BaseDate:         model.NullTime{NullTime: sql.NullTime{Time: time.Date(2020, 8, 31, 0, 0, 0, 0, time.UTC), Valid: true}},
+			CycleType:        model.NullString{NullString: sql.NullString{String: "consecutive", Valid: true}},
+			Cycle:            model.NullInt{Int: 14, Valid: true},
+			TaskName:         "風呂掃除",
+			GroupID:          1,
+			GroupTasksUserID: model.NullInt{Int: 2, Valid: true},
+		},
+		{
+			ID:               6,
+			BaseDate:         model.NullTime{NullTime: sql.NullTime{Time: time.Date(2020, 9, 5, 0, 0, 0, 0, time.UTC), Valid: true}},
+			CycleType:        model.NullString{NullString: sql.NullString{String: "none", Valid: true}},
+			Cycle:            model.NullInt{Int: 3, Valid: true},
+			TaskName:         "大掃除",
+			GroupID:          1,
+			GroupTasksUserID: model.NullInt{Int: 3, Valid: true},
+		},
+		{
+			ID:               7,
+			BaseDate:         model.NullTime{NullTime: sql.NullTime{Time: time.Date(2020, 8, 20, 0, 0, 0, 0, time.UTC), Valid: true}},
+			CycleType:        model.NullString{NullString: sql.NullString{String: "none", Valid: true}},
+			Cycle:            model.NullInt{Int: 7, Valid: true},
+			TaskName:         "窓拭き",
+			GroupID:          1,
+			GroupTasksUserID: model.NullInt{Int: 1, Valid: true},
+		},
+	}, nil
+}
+
+func newCalendarFeedTestDBHandler(calendarFeedRepo *MockCalendarFeedRepository) DBHandler {
+	return DBHandler{
+		AuthRepo:              MockAuthRepository{},
+		TodoRepo:              MockTodoRepository{},
+		ShoppingListRepo:      MockCalendarFeedShoppingListRepository{},
+		GroupTodoRepo:         MockGroupTodoRepository{},
+		GroupShoppingListRepo: MockCalendarFeedGroupShoppingListRepository{},
+		GroupTasksRepo:        MockCalendarFeedGroupTasksRepository{},
+		CalendarFeedRepo:      calendarFeedRepo,
+		TimeManage:            MockTime{},
+	}
+}
+
+func getCalendarFeed(h *DBHandler, token string) *http.Response {
+	r := httptest.NewRequest("GET", generateCalendarFeedPath(token), nil)
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"token": token,
+	})
+
+	h.GetCalendarFeed(w, r)
+
+	return w.Result()
+}
+
+var (
+	calendarContentLineRegexp = regexp.MustCompile(`^([A-Za-z0-9-]+)((?:;[A-Za-z0-9-]+=(?:"[^"]*"|[^";:,]*)(?:,(?:"[^"]*"|[^";:,]*))*)*):(.*)$`)
+	calendarDateRegexp        = regexp.MustCompile(`^[0-9]{8}$`)
+	calendarDateTimeRegexp    = regexp.MustCompile(`^[0-9]{8}T[0-9]{6}Z$`)
+	calendarTextRegexp        = regexp.MustCompile(`^(?:[^\\;,]|\\[\\;,nN])*$`)
+)
+
+// calendarTextProperties are the properties whose values are TEXT in the feed.
+var calendarTextProperties = map[string]bool{
+	"SUMMARY":      true,
+	"LOCATION":     true,
+	"DESCRIPTION":  true,
+	"X-WR-CALNAME": true,
+}
+
+type calendarProperty struct {
+	name   string
+	params string
+	value  string
+}
+
+// assertCalendar validates the iCalendar object by RFC 5545, as far as the feed uses it.
+func assertCalendar(t *testing.T, data []byte) {
+	t.Helper()
+
+	content := string(data)
+	if !strings.HasSuffix(content, "\r\n") {
+		t.Fatalf("content does not end with CRLF")
+	}
+
+	var contentLineList []string
+	for i, line := range strings.Split(strings.TrimSuffix(content, "\r\n"), "\r\n") {
+		if strings.ContainsAny(line, "\r\n") {
+			t.Errorf("line %d has a bare CR or LF: %q", i+1, line)
+		}
+
+		if len(line) > calendarLineOctets {
+			t.Errorf("line %d is longer than %d octets: %q", i+1, calendarLineOctets, line)
+		}
+
+		if !utf8.ValidString(line) {
+			t.Errorf("line %d splits a multi-byte character: %q", i+1, line)
+		}
+
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			if len(contentLineList) == 0 {
+				t.Fatalf("line %d continues no content line", i+1)
+			}
+
+			contentLineList[len(contentLineList)-1] += line[1:]
+			continue
+		}
+
+		contentLineList = append(contentLineList, line)
+	}
+
+	if contentLineList[0] != "BEGIN:VCALENDAR" || contentLineList[len(contentLineList)-1] != "END:VCALENDAR" {
+		t.Fatalf("content is not a VCALENDAR component")
+	}
+
+	var componentStack []string
+	var calendarPropertyList, eventPropertyList []calendarProperty
+	uidList := make(map[string]bool)
+
+	for i, contentLine := range contentLineList {
+		matches := calendarContentLineRegexp.FindStringSubmatch(contentLine)
+		if matches == nil {
+			t.Errorf("content line %d is malformed: %q", i+1, contentLine)
+			continue
+		}
+
+		property := calendarProperty{name: strings.ToUpper(matches[1]), params: matches[2], value: matches[3]}
+
+		switch {
+		case property.name == "BEGIN":
+			if len(componentStack) == 0 && property.value != "VCALENDAR" || len(componentStack) == 1 && property.value != "VEVENT" || len(componentStack) > 1 {
+				t.Fatalf("component %s is not expected in %v", property.value, componentStack)
+			}
+
+			if i != 0 && len(componentStack) == 0 {
+				t.Fatalf("content line %d is outside of VCALENDAR", i+1)
+			}
+
+			componentStack = append(componentStack, property.value)
+			eventPropertyList = nil
+		case property.name == "END":
+			if len(componentStack) == 0 || componentStack[len(componentStack)-1] != property.value {
+				t.Fatalf("END:%s does not close %v", property.value, componentStack)
+			}
+
+			if property.value == "VEVENT" {
+				assertCalendarEventProperties(t, eventPropertyList, uidList)
+			} else {
+				assertCalendarProperties(t, calendarPropertyList)
+			}
+
+			componentStack = componentStack[:len(componentStack)-1]
+		case len(componentStack) == 0:
+			t.Fatalf("content line %d is outside of VCALENDAR", i+1)
+		default:
+			if calendarTextProperties[property.name] && !calendarTextRegexp.MatchString(property.value) {
+				t.Errorf("TEXT value of %s is not escaped: %q", property.name, property.value)
+			}
+
+			if len(componentStack) == 1 {
+				calendarPropertyList = append(calendarPropertyList, property)
+			} else {
+				eventPropertyList = append(eventPropertyList, property)
+			}
+		}
+	}
+
+	if len(componentStack) != 0 {
+		t.Fatalf("components are not closed: %v", componentStack)
+	}
+}
+
+func groupCalendarProperties(t *testing.T, propertyList []calendarProperty, requiredNameList []string) map[string]calendarProperty {
+	t.Helper()
+
+	properties := make(map[string]calendarProperty)
+	for _, property := range propertyList {
+		if _, ok := properties[property.name]; ok && !strings.HasPrefix(property.name, "X-") {
+			t.Errorf("%s is specified more than once in %v", property.name, propertyList)
+		}
+
+		properties[property.name] = property
+	}
+
+	for _, name := range requiredNameList {
+		if _, ok := properties[name]; !ok {
+			t.Fatalf("%s is required in %v", name, propertyList)
+		}
+	}
+
+	return properties
+}
+
+func assertCalendarProperties(t *testing.T, propertyList []calendarProperty) {
+	t.Helper()
+
+	properties := groupCalendarProperties(t, propertyList, []string{"VERSION", "PRODID"})
+
+	if diff := cmp.Diff("2.0", properties["VERSION"].value); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func assertCalendarEventProperties(t *testing.T, propertyList []calendarProperty, uidList map[string]bool) {
+	t.Helper()
+
+	properties := groupCalendarProperties(t, propertyList, []string{"UID", "DTSTAMP", "DTSTART"})
+
+	uid := properties["UID"].value
+	if uidList[uid] {
+		t.Errorf("UID %s is not unique", uid)
+	}
+	uidList[uid] = true
+
+	if !calendarDateTimeRegexp.MatchString(properties["DTSTAMP"].value) {
+		t.Errorf("DTSTAMP is not a UTC date-time: %q", properties["DTSTAMP"].value)
+	}
+
+	dtStart := properties["DTSTART"]
+	if dtStart.params != ";VALUE=DATE" || !calendarDateRegexp.MatchString(dtStart.value) {
+		t.Errorf("DTSTART is not a date: %q", dtStart.params+":"+dtStart.value)
+	}
+
+	startDate, err := time.Parse("20060102", dtStart.value)
+	if err != nil {
+		t.Errorf("DTSTART is not a valid date: %q", dtStart.value)
+	}
+
+	dtEnd, ok := properties["DTEND"]
+	if !ok {
+		return
+	}
+
+	if dtEnd.params != dtStart.params {
+		t.Errorf("DTEND is not the same value type as DTSTART: %q", dtEnd.params)
+	}
+
+	endDate, err := time.Parse("20060102", dtEnd.value)
+	if err != nil {
+		t.Errorf("DTEND is not a valid date: %q", dtEnd.value)
+	}
+
+	if !endDate.After(startDate) {
+		t.Errorf("DTEND %s is not later than DTSTART %s", dtEnd.value, dtStart.value)
+	}
+}
+
+func assertCalendarHeader(t *testing.T, res *http.Response) []byte {
+	t.Helper()
+
+	if diff := cmp.Diff(http.StatusOK, res.StatusCode); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+
+	if diff := cmp.Diff("text/calendar; charset=UTF-8", res.Header.Get("Content-Type")); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+
+	gotData, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		t.Fatalf("unexpected error by ioutil.ReadAll() '%#v'", err)
+	}
+
+	return gotData
+}
+
+func assertCalendarBody(t *testing.T, gotData []byte) {
+	t.Helper()
+
+	wantData, err := ioutil.ReadFile(filepath.Join("testdata", t.Name(), "response.ics.golden"))
+	if err != nil {
+		t.Fatalf("unexpected error by ioutil.ReadFile '%#v'", err)
+	}
+
+	if diff := cmp.Diff(string(wantData), string(gotData)); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+
+	assertCalendar(t, gotData)
+}
+
+func TestDBHandler_GetCalendarFeed(t *testing.T) {
+	h := newCalendarFeedTestDBHandler(NewMockCalendarFeedRepository())
+
+	res := getCalendarFeed(&h, testCalendarFeedToken)
+	defer res.Body.Close()
+
+	assertCalendarBody(t, assertCalendarHeader(t, res))
+}
+
+func TestDBHandler_GetGroupCalendarFeed(t *testing.T) {
+	h := newCalendarFeedTestDBHandler(NewMockCalendarFeedRepository())
+
+	res := getCalendarFeed(&h, testGroupCalendarFeedToken)
+	defer res.Body.Close()
+
+	assertCalendarBody(t, assertCalendarHeader(t, res))
+}
+
+func TestDBHandler_GetCalendarFeedWithInvalidToken(t *testing.T) {
+	h := newCalendarFeedTestDBHandler(NewMockCalendarFeedRepository())
+
+	res := getCalendarFeed(&h, strings.Repeat("0", 64))
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusUnauthorized)
+	testutil.AssertResponseBody(t, res, &HTTPError{ErrorMessage: &AuthenticationErrorMsg{}}, &HTTPError{ErrorMessage: &AuthenticationErrorMsg{}})
+}
+
+func TestDBHandler_PostCalendarFeedToken(t *testing.T) {
+	calendarFeedRepo := NewMockCalendarFeedRepository()
+	h := newCalendarFeedTestDBHandler(calendarFeedRepo)
+
+	postCalendarFeedToken := func() model.CalendarFeed {
+		r := httptest.NewRequest("POST", "/calendar/token", nil)
+		w := httptest.NewRecorder()
+
+		cookie := &http.Cookie{
+			Name:  config.Env.Cookie.Name,
+			Value: uuid.New().String(),
+		}
+
+		r.AddCookie(cookie)
+
+		h.PostCalendarFeedToken(w, r)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		testutil.AssertResponseHeader(t, res, http.StatusCreated)
+
+		var calendarFeed model.CalendarFeed
+		if err := json.NewDecoder(res.Body).Decode(&calendarFeed); err != nil {
+			t.Fatalf("unexpected error by json.Decode() '%#v'", err)
+		}
+
+		if !regexp.MustCompile(`^[0-9a-f]{64}$`).MatchString(calendarFeed.Token) {
+			t.Fatalf("token is not 64 hexadecimal digits: %q", calendarFeed.Token)
+		}
+
+		if diff := cmp.Diff("/calendar/"+calendarFeed.Token+".ics", calendarFeed.FeedPath); len(diff) != 0 {
+			t.Errorf("differs: (-want +got)\n%s", diff)
+		}
+
+		return calendarFeed
+	}
+
+	calendarFeed := postCalendarFeedToken()
+
+	calendarFeedToken, err := calendarFeedRepo.GetCalendarFeedToken(hashCalendarFeedToken(calendarFeed.Token))
+	if err != nil {
+		t.Fatalf("token is not stored by its hash '%#v'", err)
+	}
+
+	if diff := cmp.Diff(model.CalendarFeedToken{ID: 3, UserID: "userID1", TokenHash: hashCalendarFeedToken(calendarFeed.Token)}, *calendarFeedToken); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+
+	// The token issued before is revoked by the new one, while the group feed is kept.
+	for token, wantStatusCode := range map[string]int{testCalendarFeedToken: http.StatusUnauthorized, calendarFeed.Token: http.StatusOK, testGroupCalendarFeedToken: http.StatusOK} {
+		res := getCalendarFeed(&h, token)
+		res.Body.Close()
+
+		if diff := cmp.Diff(wantStatusCode, res.StatusCode); len(diff) != 0 {
+			t.Errorf("differs: (-want +got)\n%s", diff)
+		}
+	}
+
+	reissuedCalendarFeed := postCalendarFeedToken()
+
+	for token, wantStatusCode := range map[string]int{calendarFeed.Token: http.StatusUnauthorized, reissuedCalendarFeed.Token: http.StatusOK} {
+		res := getCalendarFeed(&h, token)
+		res.Body.Close()
+
+		if diff := cmp.Diff(wantStatusCode, res.StatusCode); len(diff) != 0 {
+			t.Errorf("differs: (-want +got)\n%s", diff)
+		}
+	}
+}
+
+func TestDBHandler_PostGroupCalendarFeedToken(t *testing.T) {
+	calendarFeedRepo := NewMockCalendarFeedRepository()
+	h := newCalendarFeedTestDBHandler(calendarFeedRepo)
+
+	r := httptest.NewRequest("POST", "/groups/1/calendar/token", nil)
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"group_id": "1",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.PostGroupCalendarFeedToken(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusCreated)
+
+	var calendarFeed model.CalendarFeed
+	if err := json.NewDecoder(res.Body).Decode(&calendarFeed); err != nil {
+		t.Fatalf("unexpected error by json.Decode() '%#v'", err)
+	}
+
+	calendarFeedToken, err := calendarFeedRepo.GetCalendarFeedToken(hashCalendarFeedToken(calendarFeed.Token))
+	if err != nil {
+		t.Fatalf("token is not stored by its hash '%#v'", err)
+	}
+
+	if diff := cmp.Diff(model.NullInt{Int: 1, Valid: true}, calendarFeedToken.GroupID); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+
+	feedRes := getCalendarFeed(&h, testGroupCalendarFeedToken)
+	feedRes.Body.Close()
+
+	if diff := cmp.Diff(http.StatusUnauthorized, feedRes.StatusCode); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestDBHandler_DeleteCalendarFeedToken(t *testing.T) {
+	h := newCalendarFeedTestDBHandler(NewMockCalendarFeedRepository())
+
+	r := httptest.NewRequest("DELETE", "/calendar/token", nil)
+	w := httptest.NewRecorder()
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.DeleteCalendarFeedToken(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &DeleteContentMsg{}, &DeleteContentMsg{})
+
+	feedRes := getCalendarFeed(&h, testCalendarFeedToken)
+	feedRes.Body.Close()
+
+	if diff := cmp.Diff(http.StatusUnauthorized, feedRes.StatusCode); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestDBHandler_DeleteGroupCalendarFeedToken(t *testing.T) {
+	h := newCalendarFeedTestDBHandler(NewMockCalendarFeedRepository())
+
+	r := httptest.NewRequest("DELETE", "/groups/1/calendar/token", nil)
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"group_id": "1",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.DeleteGroupCalendarFeedToken(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &DeleteContentMsg{}, &DeleteContentMsg{})
+
+	for token, wantStatusCode := range map[string]int{testGroupCalendarFeedToken: http.StatusUnauthorized, testCalendarFeedToken: http.StatusOK} {
+		feedRes := getCalendarFeed(&h, token)
+		feedRes.Body.Close()
+
+		if diff := cmp.Diff(wantStatusCode, feedRes.StatusCode); len(diff) != 0 {
+			t.Errorf("differs: (-want +got)\n%s", diff)
+		}
+	}
+}
+
+func TestWriteCalendarLine(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+	}{
+		{name: "short line", line: "SUMMARY:米"},
+		{name: "ascii line", line: "DESCRIPTION:" + strings.Repeat("0123456789", 20)},
+		{name: "multi-byte line", line: "SUMMARY:" + strings.Repeat("トイレットペーパー", 10)},
+		{name: "mixed line", line: "SUMMARY:a" + strings.Repeat("買い物🛒", 20)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			writeCalendarLine(&buf, tt.line)
+
+			folded := buf.String()
+			if !strings.HasSuffix(folded, "\r\n") {
+				t.Fatalf("line does not end with CRLF: %q", folded)
+			}
+
+			for _, line := range strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n") {
+				if len(line) > calendarLineOctets || !utf8.ValidString(line) {
+					t.Errorf("line is not folded by RFC 5545: %q", line)
+				}
+			}
+
+			if diff := cmp.Diff(tt.line+"\r\n", strings.ReplaceAll(folded, "\r\n ", "")); len(diff) != 0 {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEscapeCalendarText(t *testing.T) {
+	got := escapeCalendarText("米, 卵; 牛乳\\豆腐\r\n納豆\nパン")
+
+	if diff := cmp.Diff(`米\, 卵\; 牛乳\\豆腐\n納豆\nパン`, got); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
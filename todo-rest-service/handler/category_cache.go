@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"net/http"
+	"sync"
+)
+
+// maxCategoriesNameCacheEntries bounds the number of responses kept by categoriesNameResponses.
+const maxCategoriesNameCacheEntries = 1024
+
+// categoriesNameResponses keeps the category names fetched from account-rest-service with their ETag,
+// so that a request can be sent with If-None-Match and the kept body reused on 304 Not Modified.
+var categoriesNameResponses = newCategoriesNameCache(maxCategoriesNameCacheEntries)
+
+type categoriesNameCacheEntry struct {
+	etag string
+	body []byte
+}
+
+type categoriesNameCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string]categoriesNameCacheEntry
+}
+
+func newCategoriesNameCache(maxEntries int) *categoriesNameCache {
+	return &categoriesNameCache{
+		maxEntries: maxEntries,
+		entries:    make(map[string]categoriesNameCacheEntry),
+	}
+}
+
+func categoriesNameCacheKey(request *http.Request, requestBody []byte) string {
+	return request.URL.String() + "\n" + request.Header.Get("Accept-Language") + "\n" + string(requestBody)
+}
+
+// setIfNoneMatch sets the ETag of the kept response to the request, and returns the body of the kept response
+// to be reused when account-rest-service responds with 304 Not Modified.
+func (c *categoriesNameCache) setIfNoneMatch(request *http.Request, requestBody []byte) ([]byte, bool) {
+	c.mu.Lock()
+	entry, ok := c.entries[categoriesNameCacheKey(request, requestBody)]
+	c.mu.Unlock()
+
+	if ok {
+		request.Header.Set("If-None-Match", entry.etag)
+	}
+
+	return entry.body, ok
+}
+
+func (c *categoriesNameCache) set(request *http.Request, requestBody []byte, response *http.Response, body []byte) {
+	etag := response.Header.Get("ETag")
+	if response.StatusCode != http.StatusOK || len(etag) == 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Any entry is dropped when the cache is full, since the category names are cheap to fetch again.
+	if len(c.entries) >= c.maxEntries {
+		for key := range c.entries {
+			delete(c.entries, key)
+			break
+		}
+	}
+
+	c.entries[categoriesNameCacheKey(request, requestBody)] = categoriesNameCacheEntry{etag: etag, body: body}
+}
@@ -1,13 +1,17 @@
 package handler
 
 import (
+	"crypto/subtle"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
 	"net"
 	"net/http"
+	"reflect"
+	"strings"
 	"time"
 
 	"github.com/hryze/kakeibo-app-api/todo-rest-service/config"
@@ -17,11 +21,14 @@ import (
 type DBHandler struct {
 	HealthRepo            repository.HealthRepository
 	AuthRepo              repository.AuthRepository
+	SchedulerLockRepo     repository.SchedulerLockRepository
 	TodoRepo              repository.TodoRepository
 	ShoppingListRepo      repository.ShoppingListRepository
 	GroupTodoRepo         repository.GroupTodoRepository
 	GroupShoppingListRepo repository.GroupShoppingListRepository
 	GroupTasksRepo        repository.GroupTasksRepository
+	TransactionOutboxRepo repository.TransactionOutboxRepository
+	CalendarFeedRepo      repository.CalendarFeedRepository
 	TimeManage            TimeManager
 }
 
@@ -48,6 +55,10 @@ type AuthenticationErrorMsg struct {
 	Message string `json:"message"`
 }
 
+type NotFoundErrorMsg struct {
+	Message string `json:"message"`
+}
+
 type ConflictErrorMsg struct {
 	Message string `json:"message"`
 }
@@ -96,6 +107,10 @@ func (e *AuthenticationErrorMsg) Error() string {
 	return e.Message
 }
 
+func (e *NotFoundErrorMsg) Error() string {
+	return e.Message
+}
+
 func (e *ConflictErrorMsg) Error() string {
 	return e.Message
 }
@@ -104,13 +119,17 @@ func (e *InternalServerErrorMsg) Error() string {
 	return e.Message
 }
 
-func errorResponseByJSON(w http.ResponseWriter, err error) {
+func errorResponseByJSON(w http.ResponseWriter, r *http.Request, err error) {
 	httpError, ok := err.(*HTTPError)
 	if !ok {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
+	if locale := requestLocale(r.Context()); locale != defaultLocale {
+		localizeMessageFields(reflect.ValueOf(httpError), locale)
+	}
+
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(httpError.Status)
 	if err := json.NewEncoder(w).Encode(httpError); err != nil {
@@ -133,6 +152,21 @@ func verifySessionID(h *DBHandler, w http.ResponseWriter, r *http.Request) (stri
 	return userID, nil
 }
 
+// errInvalidServiceToken is returned when a request sent by another service fails to authenticate.
+var errInvalidServiceToken = errors.New("invalid service token")
+
+// verifyServiceToken verifies that the request is sent by account-rest-service with the service token.
+func verifyServiceToken(r *http.Request) error {
+	authorization := r.Header.Get("Authorization")
+
+	token := strings.TrimPrefix(authorization, "Bearer ")
+	if len(token) == len(authorization) || len(config.Env.ServiceAuth.Token) == 0 || subtle.ConstantTimeCompare([]byte(token), []byte(config.Env.ServiceAuth.Token)) != 1 {
+		return errInvalidServiceToken
+	}
+
+	return nil
+}
+
 func verifyGroupAffiliation(groupID int, userID string) error {
 	requestURL := fmt.Sprintf(
 		"http://%s:%d/groups/%d/users/%s/verify",
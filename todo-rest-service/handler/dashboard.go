@@ -0,0 +1,466 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+
+	"github.com/hryze/kakeibo-app-api/todo-rest-service/config"
+	"github.com/hryze/kakeibo-app-api/todo-rest-service/domain/model"
+)
+
+const (
+	dashboardRepositoryTimeout = 3 * time.Second
+	dashboardServiceTimeout    = 5 * time.Second
+)
+
+// dashboardSource is one of the data sources fetched concurrently to build the dashboard.
+// fetch returns a function to set the fetched data to the dashboard, so that the data of a source
+// that has timed out is never written to the dashboard being responded.
+type dashboardSource struct {
+	name    string
+	groupID int
+	timeout time.Duration
+	fetch   func(ctx context.Context) (func(dashboard *model.Dashboard), error)
+}
+
+type dashboardSourceResult struct {
+	apply func(dashboard *model.Dashboard)
+	err   error
+}
+
+func fetchDashboardSource(ctx context.Context, source dashboardSource) dashboardSourceResult {
+	ctx, cancel := context.WithTimeout(ctx, source.timeout)
+	defer cancel()
+
+	resultCh := make(chan dashboardSourceResult, 1)
+
+	go func() {
+		apply, err := source.fetch(ctx)
+		resultCh <- dashboardSourceResult{apply: apply, err: err}
+	}()
+
+	select {
+	case result := <-resultCh:
+		return result
+	case <-ctx.Done():
+		return dashboardSourceResult{err: ctx.Err()}
+	}
+}
+
+// fetchDashboardSourceList fetches all the sources concurrently, and returns the results in the order of sourceList.
+func fetchDashboardSourceList(ctx context.Context, sourceList []dashboardSource) []dashboardSourceResult {
+	resultList := make([]dashboardSourceResult, len(sourceList))
+
+	var wg sync.WaitGroup
+	for i, source := range sourceList {
+		wg.Add(1)
+
+		go func(i int, source dashboardSource) {
+			defer wg.Done()
+			resultList[i] = fetchDashboardSource(ctx, source)
+		}(i, source)
+	}
+
+	wg.Wait()
+
+	return resultList
+}
+
+func generateDashboardFailedSource(source dashboardSource, err error) model.DashboardFailedSource {
+	message := "データを取得できませんでした。"
+	// An error of an HTTP request to the account service wraps the deadline of its context in *url.Error.
+	if errors.Is(err, context.DeadlineExceeded) {
+		message = "データの取得がタイムアウトしました。"
+	}
+
+	return model.DashboardFailedSource{
+		Source:  source.name,
+		GroupID: source.groupID,
+		Message: message,
+	}
+}
+
+func requestDashboardData(ctx context.Context, requestURL string, sessionID string, v interface{}) error {
+	request, err := http.NewRequestWithContext(
+		ctx,
+		"GET",
+		requestURL,
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	request.AddCookie(&http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: sessionID,
+	})
+	request.Header.Set("Content-Type", "application/json; charset=UTF-8")
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: (&net.Dialer{
+				Timeout:   30 * time.Second,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			MaxIdleConns:          500,
+			MaxIdleConnsPerHost:   100,
+			IdleConnTimeout:       90 * time.Second,
+			ResponseHeaderTimeout: 10 * time.Second,
+			ExpectContinueTimeout: 1 * time.Second,
+		},
+		Timeout: 60 * time.Second,
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_, _ = io.Copy(ioutil.Discard, response.Body)
+		response.Body.Close()
+	}()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s", requestURL, http.StatusText(response.StatusCode))
+	}
+
+	return json.NewDecoder(response.Body).Decode(v)
+}
+
+func generateTodoListDashboardSourceList(h *DBHandler, today time.Time, dueDate time.Time, userID string, sessionID string, locale string) []dashboardSource {
+	return []dashboardSource{
+		{
+			name:    "todo_list",
+			timeout: dashboardRepositoryTimeout,
+			fetch: func(ctx context.Context) (func(dashboard *model.Dashboard), error) {
+				implementationTodoList, err := h.TodoRepo.GetDailyImplementationTodoList(ctx, today, userID)
+				if err != nil {
+					return nil, err
+				}
+
+				dueTodoList, err := h.TodoRepo.GetDailyDueTodoList(ctx, today, userID)
+				if err != nil {
+					return nil, err
+				}
+
+				todoList := model.NewTodoList(implementationTodoList, dueTodoList)
+
+				return func(dashboard *model.Dashboard) {
+					dashboard.TodoList = &todoList
+				}, nil
+			},
+		},
+		{
+			name:    "expired_todo_list",
+			timeout: dashboardRepositoryTimeout,
+			fetch: func(ctx context.Context) (func(dashboard *model.Dashboard), error) {
+				expiredTodoList, err := h.TodoRepo.GetExpiredTodoList(ctx, dueDate, userID)
+				if err != nil {
+					return nil, err
+				}
+
+				return func(dashboard *model.Dashboard) {
+					dashboard.ExpiredTodoList = expiredTodoList
+				}, nil
+			},
+		},
+		{
+			name:    "expired_shopping_list",
+			timeout: dashboardServiceTimeout,
+			fetch: func(ctx context.Context) (func(dashboard *model.Dashboard), error) {
+				expiredShoppingList, err := h.ShoppingListRepo.GetExpiredShoppingList(ctx, dueDate, userID)
+				if err != nil {
+					return nil, err
+				}
+
+				if len(expiredShoppingList.ExpiredShoppingList) != 0 {
+					categoriesIdList := make([]CategoriesID, len(expiredShoppingList.ExpiredShoppingList))
+
+					for i, shoppingItem := range expiredShoppingList.ExpiredShoppingList {
+						categoriesIdList[i] = CategoriesID{
+							MediumCategoryID: shoppingItem.MediumCategoryID,
+							CustomCategoryID: shoppingItem.CustomCategoryID,
+						}
+					}
+
+					categoriesNameListBytes, err := getShoppingItemCategoriesNameList(ctx, categoriesIdList, locale)
+					if err != nil {
+						return nil, err
+					}
+
+					if err := json.Unmarshal(categoriesNameListBytes, &expiredShoppingList.ExpiredShoppingList); err != nil {
+						return nil, err
+					}
+				}
+
+				return func(dashboard *model.Dashboard) {
+					dashboard.ExpiredShoppingList = &expiredShoppingList
+				}, nil
+			},
+		},
+		{
+			name:    "budget_status",
+			timeout: dashboardServiceTimeout,
+			fetch: func(ctx context.Context) (func(dashboard *model.Dashboard), error) {
+				requestURL := fmt.Sprintf(
+					"http://%s:%d/budgets/%s/status",
+					config.Env.AccountApi.Host, config.Env.AccountApi.Port, today.Format("2006-01"),
+				)
+
+				var budgetStatus model.BudgetStatus
+				if err := requestDashboardData(ctx, requestURL, sessionID, &budgetStatus); err != nil {
+					return nil, err
+				}
+
+				return func(dashboard *model.Dashboard) {
+					dashboard.BudgetStatus = &budgetStatus
+				}, nil
+			},
+		},
+	}
+}
+
+func generateGroupDashboardSourceList(h *DBHandler, groupID int, today time.Time, dueDate time.Time, userID string, sessionID string, locale string) []dashboardSource {
+	return []dashboardSource{
+		{
+			name:    "group_todo_list",
+			groupID: groupID,
+			timeout: dashboardRepositoryTimeout,
+			fetch: func(ctx context.Context) (func(dashboard *model.Dashboard), error) {
+				implementationGroupTodoList, err := h.GroupTodoRepo.GetDailyImplementationGroupTodoList(ctx, today, groupID)
+				if err != nil {
+					return nil, err
+				}
+
+				dueGroupTodoList, err := h.GroupTodoRepo.GetDailyDueGroupTodoList(ctx, today, groupID)
+				if err != nil {
+					return nil, err
+				}
+
+				return func(dashboard *model.Dashboard) {
+					dashboard.GroupTodoList = append(dashboard.GroupTodoList, model.DailyGroupTodoList{
+						GroupID:                     groupID,
+						ImplementationGroupTodoList: implementationGroupTodoList,
+						DueGroupTodoList:            dueGroupTodoList,
+					})
+				}, nil
+			},
+		},
+		{
+			name:    "expired_group_todo_list",
+			groupID: groupID,
+			timeout: dashboardRepositoryTimeout,
+			fetch: func(ctx context.Context) (func(dashboard *model.Dashboard), error) {
+				expiredGroupTodoList, err := h.GroupTodoRepo.GetExpiredGroupTodoList(ctx, dueDate, groupID)
+				if err != nil {
+					return nil, err
+				}
+
+				return func(dashboard *model.Dashboard) {
+					dashboard.ExpiredGroupTodoList = append(dashboard.ExpiredGroupTodoList, model.ExpiredGroupTodoListByGroup{
+						GroupID:              groupID,
+						ExpiredGroupTodoList: expiredGroupTodoList.ExpiredGroupTodoList,
+					})
+				}, nil
+			},
+		},
+		{
+			name:    "expired_group_shopping_list",
+			groupID: groupID,
+			timeout: dashboardServiceTimeout,
+			fetch: func(ctx context.Context) (func(dashboard *model.Dashboard), error) {
+				expiredGroupShoppingList, err := h.GroupShoppingListRepo.GetExpiredGroupShoppingList(ctx, dueDate, groupID)
+				if err != nil {
+					return nil, err
+				}
+
+				if len(expiredGroupShoppingList.ExpiredGroupShoppingList) != 0 {
+					categoriesIdList := make([]CategoriesID, len(expiredGroupShoppingList.ExpiredGroupShoppingList))
+
+					for i, groupShoppingItem := range expiredGroupShoppingList.ExpiredGroupShoppingList {
+						categoriesIdList[i] = CategoriesID{
+							MediumCategoryID: groupShoppingItem.MediumCategoryID,
+							CustomCategoryID: groupShoppingItem.CustomCategoryID,
+						}
+					}
+
+					categoriesNameListBytes, err := getGroupShoppingItemCategoriesNameList(ctx, categoriesIdList, groupID, locale)
+					if err != nil {
+						return nil, err
+					}
+
+					if err := json.Unmarshal(categoriesNameListBytes, &expiredGroupShoppingList.ExpiredGroupShoppingList); err != nil {
+						return nil, err
+					}
+				}
+
+				return func(dashboard *model.Dashboard) {
+					dashboard.ExpiredGroupShoppingList = append(dashboard.ExpiredGroupShoppingList, model.ExpiredGroupShoppingListByGroup{
+						GroupID:                  groupID,
+						ExpiredGroupShoppingList: expiredGroupShoppingList.ExpiredGroupShoppingList,
+					})
+				}, nil
+			},
+		},
+		{
+			name:    "assigned_group_tasks_list",
+			groupID: groupID,
+			timeout: dashboardRepositoryTimeout,
+			fetch: func(ctx context.Context) (func(dashboard *model.Dashboard), error) {
+				groupTasksUsersList, err := h.GroupTasksRepo.GetGroupTasksUsersList(ctx, groupID)
+				if err != nil {
+					return nil, err
+				}
+
+				groupTasksListAssignedToUser, err := h.GroupTasksRepo.GetGroupTasksListAssignedToUser(ctx, groupID)
+				if err != nil {
+					return nil, err
+				}
+
+				// The tasks are rotated only in memory, since the dashboard is a read-only view
+				// and the rotation is saved when the group tasks list is fetched.
+				rotateGroupTasksListAssignedToUser(groupTasksListAssignedToUser, groupTasksUsersList, today)
+
+				groupTasksList := make([]model.GroupTask, 0)
+				for _, groupTasksUser := range groupTasksUsersList {
+					if groupTasksUser.UserID != userID {
+						continue
+					}
+
+					for _, groupTask := range groupTasksListAssignedToUser {
+						if groupTask.GroupTasksUserID.Valid && groupTask.GroupTasksUserID.Int == groupTasksUser.ID {
+							groupTasksList = append(groupTasksList, groupTask)
+						}
+					}
+				}
+
+				return func(dashboard *model.Dashboard) {
+					dashboard.AssignedGroupTasksList = append(dashboard.AssignedGroupTasksList, model.GroupTasksListByGroup{
+						GroupID:        groupID,
+						GroupTasksList: groupTasksList,
+					})
+				}, nil
+			},
+		},
+		{
+			name:    "unsettled_group_accounts_list",
+			groupID: groupID,
+			timeout: dashboardServiceTimeout,
+			fetch: func(ctx context.Context) (func(dashboard *model.Dashboard), error) {
+				requestURL := fmt.Sprintf(
+					"http://%s:%d/groups/%d/transactions/account/unsettled",
+					config.Env.AccountApi.Host, config.Env.AccountApi.Port, groupID,
+				)
+
+				var unsettledGroupAccountsList model.UnsettledGroupAccountsList
+				if err := requestDashboardData(ctx, requestURL, sessionID, &unsettledGroupAccountsList); err != nil {
+					return nil, err
+				}
+
+				unsettledGroupAccountsList.GroupID = groupID
+				if unsettledGroupAccountsList.UnsettledGroupAccountsList == nil {
+					unsettledGroupAccountsList.UnsettledGroupAccountsList = make([]model.GroupAccount, 0)
+				}
+
+				return func(dashboard *model.Dashboard) {
+					dashboard.UnsettledGroupAccountsList = append(dashboard.UnsettledGroupAccountsList, unsettledGroupAccountsList)
+				}, nil
+			},
+		},
+	}
+}
+
+func (h *DBHandler) GetDashboard(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	cookie, err := r.Cookie(config.Env.Cookie.Name)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	sessionID := cookie.Value
+	locale := requestLocale(r.Context())
+
+	now := h.TimeManage.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	dueDate := today.AddDate(0, 0, -1)
+
+	dashboard := model.NewDashboard()
+
+	var groupList model.GroupList
+	groupListSource := dashboardSource{
+		name:    "group_list",
+		timeout: dashboardServiceTimeout,
+		fetch: func(ctx context.Context) (func(dashboard *model.Dashboard), error) {
+			requestURL := fmt.Sprintf(
+				"http://%s:%d/groups",
+				config.Env.UserApi.Host, config.Env.UserApi.Port,
+			)
+
+			var fetchedGroupList model.GroupList
+			if err := requestDashboardData(ctx, requestURL, sessionID, &fetchedGroupList); err != nil {
+				return nil, err
+			}
+
+			return func(dashboard *model.Dashboard) {
+				groupList = fetchedGroupList
+			}, nil
+		},
+	}
+
+	sourceList := generateTodoListDashboardSourceList(h, today, dueDate, userID, sessionID, locale)
+
+	if result := fetchDashboardSource(r.Context(), groupListSource); result.err != nil {
+		dashboard.FailedSourceList = append(dashboard.FailedSourceList, generateDashboardFailedSource(groupListSource, result.err))
+	} else {
+		result.apply(&dashboard)
+
+		for _, group := range groupList.ApprovedGroupList {
+			sourceList = append(sourceList, generateGroupDashboardSourceList(h, group.GroupID, today, dueDate, userID, sessionID, locale)...)
+		}
+	}
+
+	resultList := fetchDashboardSourceList(r.Context(), sourceList)
+
+	for i, result := range resultList {
+		if result.err != nil {
+			dashboard.FailedSourceList = append(dashboard.FailedSourceList, generateDashboardFailedSource(sourceList[i], result.err))
+			continue
+		}
+
+		result.apply(&dashboard)
+	}
+
+	for i := range dashboard.FailedSourceList {
+		dashboard.FailedSourceList[i].Message = localizeMessage(r.Context(), dashboard.FailedSourceList[i].Message)
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&dashboard); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,330 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/hryze/kakeibo-app-api/todo-rest-service/config"
+	"github.com/hryze/kakeibo-app-api/todo-rest-service/domain/model"
+	"github.com/hryze/kakeibo-app-api/todo-rest-service/testutil"
+)
+
+// MockTaskMemberAuthRepository logs in as the member in charge of the group tasks in MockGroupTasksRepository.
+type MockTaskMemberAuthRepository struct{}
+
+func (t MockTaskMemberAuthRepository) GetUserID(sessionID string) (string, error) {
+	return "userID2", nil
+}
+
+func setUpMockGroupListServer(t *testing.T) func() {
+	t.Helper()
+
+	mockGetGroupList := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		groupList := model.GroupList{
+			ApprovedGroupList: []model.Group{
+				{GroupID: 1, GroupName: "シェアハウス"},
+			},
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(&groupList); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/groups", mockGetGroupList).Methods("GET")
+
+	// The user api mock set up in TestMain responds with an empty body, so that the group list is served on another port.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error by net.Listen() '%#v'", err)
+	}
+
+	ts := httptest.Server{
+		Listener: listener,
+		Config:   &http.Server{Handler: router},
+	}
+
+	ts.Start()
+
+	userApiPort := config.Env.UserApi.Port
+	config.Env.UserApi.Port = listener.Addr().(*net.TCPAddr).Port
+
+	return func() {
+		config.Env.UserApi.Port = userApiPort
+		ts.Close()
+	}
+}
+
+// MockBlockingTodoRepository blocks in GetExpiredTodoList until the context passed by the dashboard source is done.
+type MockBlockingTodoRepository struct {
+	MockTodoRepository
+	released chan struct{}
+}
+
+func (m MockBlockingTodoRepository) GetExpiredTodoList(ctx context.Context, dueDate time.Time, userID string) (*model.ExpiredTodoList, error) {
+	<-ctx.Done()
+	close(m.released)
+
+	return nil, ctx.Err()
+}
+
+func setUpMockAccountServer(t *testing.T) func() {
+	t.Helper()
+
+	accountHostURL := fmt.Sprintf("%s:%d", config.Env.AccountApi.Host, config.Env.AccountApi.Port)
+
+	mockGetCategoriesNameList := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mockCategoriesNameList := []MockCategoriesName{
+			{
+				BigCategoryName:    model.NullString{NullString: sql.NullString{String: "食費", Valid: true}},
+				MediumCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+				CustomCategoryName: model.NullString{NullString: sql.NullString{String: "米", Valid: true}},
+			},
+			{
+				BigCategoryName:    model.NullString{NullString: sql.NullString{String: "日用品", Valid: true}},
+				MediumCategoryName: model.NullString{NullString: sql.NullString{String: "消耗品", Valid: true}},
+				CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+			},
+			{
+				BigCategoryName:    model.NullString{NullString: sql.NullString{String: "日用品", Valid: true}},
+				MediumCategoryName: model.NullString{NullString: sql.NullString{String: "消耗品", Valid: true}},
+				CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+			},
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(&mockCategoriesNameList); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	})
+
+	mockGetBudgetStatus := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		budgetStatus := model.BudgetStatus{
+			TotalBudget:   150000,
+			TotalExpenses: 64800,
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(&budgetStatus); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	})
+
+	mockGetUnsettledGroupAccountsList := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		unsettledGroupAccountsList := model.UnsettledGroupAccountsList{
+			GroupID: 1,
+			UnsettledGroupAccountsList: []model.GroupAccount{
+				{
+					ID:                  4,
+					GroupID:             1,
+					Month:               time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC),
+					Payer:               model.NullString{NullString: sql.NullString{String: "userID1", Valid: true}},
+					Recipient:           model.NullString{NullString: sql.NullString{String: "userID2", Valid: true}},
+					PaymentAmount:       model.NullInt{Int: 3200, Valid: true},
+					PaymentConfirmation: false,
+					ReceiptConfirmation: false,
+				},
+			},
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(&unsettledGroupAccountsList); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/categories/names", mockGetCategoriesNameList).Methods("GET")
+	router.HandleFunc("/groups/{group_id:[0-9]+}/categories/names", mockGetCategoriesNameList).Methods("GET")
+	router.HandleFunc("/budgets/{year_month:[0-9]{4}-[0-9]{2}}/status", mockGetBudgetStatus).Methods("GET")
+	router.HandleFunc("/groups/{group_id:[0-9]+}/transactions/account/unsettled", mockGetUnsettledGroupAccountsList).Methods("GET")
+
+	listener, err := net.Listen("tcp", accountHostURL)
+	if err != nil {
+		t.Fatalf("unexpected error by net.Listen() '%#v'", err)
+	}
+
+	ts := httptest.Server{
+		Listener: listener,
+		Config:   &http.Server{Handler: router},
+	}
+
+	ts.Start()
+
+	return ts.Close
+}
+
+func TestDBHandler_GetDashboard(t *testing.T) {
+	tearDownGroupList := setUpMockGroupListServer(t)
+	defer tearDownGroupList()
+
+	tearDownAccount := setUpMockAccountServer(t)
+	defer tearDownAccount()
+
+	h := DBHandler{
+		AuthRepo:              MockTaskMemberAuthRepository{},
+		TodoRepo:              MockTodoRepository{},
+		ShoppingListRepo:      MockShoppingListRepository{},
+		GroupTodoRepo:         MockGroupTodoRepository{},
+		GroupShoppingListRepo: MockGroupShoppingListRepository{},
+		GroupTasksRepo:        MockGroupTasksRepository{},
+		TimeManage:            MockTime{},
+	}
+
+	r := httptest.NewRequest("GET", "/dashboard", nil)
+	w := httptest.NewRecorder()
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.GetDashboard(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &model.Dashboard{}, &model.Dashboard{})
+}
+
+func TestDBHandler_GetDashboardWithFailedSources(t *testing.T) {
+	tearDown := setUpMockGroupListServer(t)
+	defer tearDown()
+
+	// The account api is not set up, so that the sources fetched from it are reported as failed.
+
+	h := DBHandler{
+		AuthRepo:              MockAuthRepository{},
+		TodoRepo:              MockTodoRepository{},
+		ShoppingListRepo:      MockShoppingListRepository{},
+		GroupTodoRepo:         MockGroupTodoRepository{},
+		GroupShoppingListRepo: MockGroupShoppingListRepository{},
+		GroupTasksRepo:        MockGroupTasksRepository{},
+		TimeManage:            MockTime{},
+	}
+
+	r := httptest.NewRequest("GET", "/dashboard", nil)
+	w := httptest.NewRecorder()
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.GetDashboard(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &model.Dashboard{}, &model.Dashboard{})
+}
+
+func TestDBHandler_GetDashboardWithTimedOutSource(t *testing.T) {
+	tearDownGroupList := setUpMockGroupListServer(t)
+	defer tearDownGroupList()
+
+	tearDownAccount := setUpMockAccountServer(t)
+	defer tearDownAccount()
+
+	released := make(chan struct{})
+
+	h := DBHandler{
+		AuthRepo:              MockTaskMemberAuthRepository{},
+		TodoRepo:              MockBlockingTodoRepository{released: released},
+		ShoppingListRepo:      MockShoppingListRepository{},
+		GroupTodoRepo:         MockGroupTodoRepository{},
+		GroupShoppingListRepo: MockGroupShoppingListRepository{},
+		GroupTasksRepo:        MockGroupTasksRepository{},
+		TimeManage:            MockTime{},
+	}
+
+	r := httptest.NewRequest("GET", "/dashboard", nil)
+	w := httptest.NewRecorder()
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.GetDashboard(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	// The repository returns only when the context passed down to it has timed out.
+	select {
+	case <-released:
+	case <-time.After(time.Second):
+		t.Fatal("GetExpiredTodoList() was not released by the context of the dashboard source")
+	}
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &model.Dashboard{}, &model.Dashboard{})
+}
+
+func TestGenerateDashboardFailedSource(t *testing.T) {
+	source := dashboardSource{name: "unsettled_group_accounts_list", groupID: 1}
+
+	tests := []struct {
+		name string
+		err  error
+		want model.DashboardFailedSource
+	}{
+		{
+			name: "deadline exceeded",
+			err:  context.DeadlineExceeded,
+			want: model.DashboardFailedSource{Source: "unsettled_group_accounts_list", GroupID: 1, Message: "データの取得がタイムアウトしました。"},
+		},
+		{
+			name: "deadline exceeded wrapped in url.Error",
+			err:  &url.Error{Op: "Get", URL: "http://localhost:8081/groups/1/transactions/account/unsettled", Err: context.DeadlineExceeded},
+			want: model.DashboardFailedSource{Source: "unsettled_group_accounts_list", GroupID: 1, Message: "データの取得がタイムアウトしました。"},
+		},
+		{
+			name: "other error",
+			err:  errors.New("dashboard source error"),
+			want: model.DashboardFailedSource{Source: "unsettled_group_accounts_list", GroupID: 1, Message: "データを取得できませんでした。"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := generateDashboardFailedSource(source, tt.err)
+
+			if diff := cmp.Diff(tt.want, got); len(diff) != 0 {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
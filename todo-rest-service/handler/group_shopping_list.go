@@ -2,8 +2,11 @@ package handler
 
 import (
 	"bytes"
+	"context"
+	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -11,6 +14,7 @@ import (
 	"net"
 	"net/http"
 	"reflect"
+	"sort"
 	"strconv"
 	"time"
 
@@ -75,6 +79,22 @@ func validateGroupRegularShoppingItem(groupRegularShoppingItem model.GroupRegula
 		return err
 	}
 
+	if err := validate.RegisterValidation("with_quantity", withQuantityValidation); err != nil {
+		return err
+	}
+
+	if err := validate.RegisterValidation("unit_price_total", unitPriceTotalValidation); err != nil {
+		return err
+	}
+
+	if err := validate.RegisterValidation("with_cycle", withCycleValidation); err != nil {
+		return err
+	}
+
+	if err := validate.RegisterValidation("recurrence_rule", recurrenceRuleValidation); err != nil {
+		return err
+	}
+
 	err := validate.Struct(groupRegularShoppingItem)
 	if err == nil {
 		return nil
@@ -101,9 +121,23 @@ func validateGroupRegularShoppingItem(groupRegularShoppingItem model.GroupRegula
 				errorMessage = "購入周期タイプが選択されていません。"
 			case "oneof":
 				errorMessage = "購入周期タイプを正しく選択してください。"
+			case "with_cycle":
+				if err.Value() == "custom" {
+					errorMessage = "購入周期は1以上の正の整数を入力してください。"
+				} else {
+					errorMessage = "繰り返しルールが入力されていません。"
+				}
 			}
 		case "Cycle":
 			errorMessage = "購入周期は1以上の正の整数を入力してください。"
+		case "RecurrenceRule":
+			tagName := err.Tag()
+			switch tagName {
+			case "max":
+				errorMessage = "繰り返しルールは255文字以内で入力してください。"
+			case "recurrence_rule":
+				errorMessage = "繰り返しルールを正しく入力してください。"
+			}
 		case "Purchase":
 			tagName := err.Tag()
 			switch tagName {
@@ -121,7 +155,41 @@ func validateGroupRegularShoppingItem(groupRegularShoppingItem model.GroupRegula
 				errorMessage = "店名の文字列先頭か末尾に空白がないか確認してください。"
 			}
 		case "Amount":
-			errorMessage = "金額は1以上の正の整数を入力してください。"
+			tagName := err.Tag()
+			switch tagName {
+			case "min":
+				errorMessage = "金額は1以上の正の整数を入力してください。"
+			case "max":
+				errorMessage = "金額は10億円以下で入力してください。"
+			case "unit_price_total":
+				errorMessage = "金額は数量と単価の積と一致するように入力してください。"
+			}
+		case "Quantity":
+			tagName := err.Tag()
+			switch tagName {
+			case "min":
+				errorMessage = "数量は1以上の正の整数を入力してください。"
+			case "max":
+				errorMessage = "数量は1000以下で入力してください。"
+			}
+		case "Unit":
+			tagName := err.Tag()
+			switch tagName {
+			case "max":
+				errorMessage = "単位は10文字以内で入力してください。"
+			case "blank":
+				errorMessage = "単位の文字列先頭か末尾に空白がないか確認してください。"
+			case "with_quantity":
+				errorMessage = "単位を入力する場合は数量を入力してください。"
+			}
+		case "UnitPrice":
+			tagName := err.Tag()
+			switch tagName {
+			case "min":
+				errorMessage = "単価は1以上の正の整数を入力してください。"
+			case "max":
+				errorMessage = "単価は100万円以下で入力してください。"
+			}
 		case "BigCategoryID":
 			tagName := err.Tag()
 			switch tagName {
@@ -172,6 +240,14 @@ func validateGroupShoppingItem(groupShoppingItem model.GroupShoppingItem) error
 		return err
 	}
 
+	if err := validate.RegisterValidation("with_quantity", withQuantityValidation); err != nil {
+		return err
+	}
+
+	if err := validate.RegisterValidation("unit_price_total", unitPriceTotalValidation); err != nil {
+		return err
+	}
+
 	err := validate.Struct(groupShoppingItem)
 	if err == nil {
 		return nil
@@ -208,7 +284,41 @@ func validateGroupShoppingItem(groupShoppingItem model.GroupShoppingItem) error
 				errorMessage = "店名の文字列先頭か末尾に空白がないか確認してください。"
 			}
 		case "Amount":
-			errorMessage = "金額は1以上の正の整数を入力してください。"
+			tagName := err.Tag()
+			switch tagName {
+			case "min":
+				errorMessage = "金額は1以上の正の整数を入力してください。"
+			case "max":
+				errorMessage = "金額は10億円以下で入力してください。"
+			case "unit_price_total":
+				errorMessage = "金額は数量と単価の積と一致するように入力してください。"
+			}
+		case "Quantity":
+			tagName := err.Tag()
+			switch tagName {
+			case "min":
+				errorMessage = "数量は1以上の正の整数を入力してください。"
+			case "max":
+				errorMessage = "数量は1000以下で入力してください。"
+			}
+		case "Unit":
+			tagName := err.Tag()
+			switch tagName {
+			case "max":
+				errorMessage = "単位は10文字以内で入力してください。"
+			case "blank":
+				errorMessage = "単位の文字列先頭か末尾に空白がないか確認してください。"
+			case "with_quantity":
+				errorMessage = "単位を入力する場合は数量を入力してください。"
+			}
+		case "UnitPrice":
+			tagName := err.Tag()
+			switch tagName {
+			case "min":
+				errorMessage = "単価は1以上の正の整数を入力してください。"
+			case "max":
+				errorMessage = "単価は100万円以下で入力してください。"
+			}
 		case "BigCategoryID":
 			tagName := err.Tag()
 			switch tagName {
@@ -244,7 +354,7 @@ func groupShoppingItemValidateValuer(field reflect.Value) interface{} {
 	return nil
 }
 
-func getGroupShoppingItemCategoriesName(categoriesID CategoriesID, groupID int) ([]byte, error) {
+func getGroupShoppingItemCategoriesName(categoriesID CategoriesID, groupID int, locale string) ([]byte, error) {
 	requestURL := fmt.Sprintf(
 		"http://%s:%d/groups/%d/categories/name",
 		config.Env.AccountApi.Host, config.Env.AccountApi.Port, groupID,
@@ -265,6 +375,9 @@ func getGroupShoppingItemCategoriesName(categoriesID CategoriesID, groupID int)
 	}
 
 	request.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	request.Header.Set("Accept-Language", locale)
+
+	cachedCategoriesNameBytes, isCached := categoriesNameResponses.setIfNoneMatch(request, requestBody)
 
 	client := &http.Client{
 		Transport: &http.Transport{
@@ -291,6 +404,10 @@ func getGroupShoppingItemCategoriesName(categoriesID CategoriesID, groupID int)
 		response.Body.Close()
 	}()
 
+	if response.StatusCode == http.StatusNotModified && isCached {
+		return cachedCategoriesNameBytes, nil
+	}
+
 	if response.StatusCode == http.StatusInternalServerError {
 		return nil, &InternalServerErrorMsg{"500 Internal Server Error"}
 	}
@@ -300,10 +417,12 @@ func getGroupShoppingItemCategoriesName(categoriesID CategoriesID, groupID int)
 		return nil, err
 	}
 
+	categoriesNameResponses.set(request, requestBody, response, categoriesNameBytes)
+
 	return categoriesNameBytes, nil
 }
 
-func getGroupShoppingItemCategoriesNameList(categoriesIdList []CategoriesID, groupID int) ([]byte, error) {
+func getGroupShoppingItemCategoriesNameList(ctx context.Context, categoriesIdList []CategoriesID, groupID int, locale string) ([]byte, error) {
 	requestURL := fmt.Sprintf(
 		"http://%s:%d/groups/%d/categories/names",
 		config.Env.AccountApi.Host, config.Env.AccountApi.Port, groupID,
@@ -314,7 +433,8 @@ func getGroupShoppingItemCategoriesNameList(categoriesIdList []CategoriesID, gro
 		return nil, err
 	}
 
-	request, err := http.NewRequest(
+	request, err := http.NewRequestWithContext(
+		ctx,
 		"GET",
 		requestURL,
 		bytes.NewBuffer(requestBody),
@@ -324,6 +444,9 @@ func getGroupShoppingItemCategoriesNameList(categoriesIdList []CategoriesID, gro
 	}
 
 	request.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	request.Header.Set("Accept-Language", locale)
+
+	cachedCategoriesNameBytes, isCached := categoriesNameResponses.setIfNoneMatch(request, requestBody)
 
 	client := &http.Client{
 		Transport: &http.Transport{
@@ -350,6 +473,10 @@ func getGroupShoppingItemCategoriesNameList(categoriesIdList []CategoriesID, gro
 		response.Body.Close()
 	}()
 
+	if response.StatusCode == http.StatusNotModified && isCached {
+		return cachedCategoriesNameBytes, nil
+	}
+
 	if response.StatusCode == http.StatusInternalServerError {
 		return nil, &InternalServerErrorMsg{"500 Internal Server Error"}
 	}
@@ -359,6 +486,8 @@ func getGroupShoppingItemCategoriesNameList(categoriesIdList []CategoriesID, gro
 		return nil, err
 	}
 
+	categoriesNameResponses.set(request, requestBody, response, categoriesNameBytes)
+
 	return categoriesNameBytes, nil
 }
 
@@ -421,152 +550,107 @@ func getGroupShoppingItemRelatedTransactionDataList(transactionIdList []int64, g
 	return groupShoppingItemRelatedTransactionDataList, nil
 }
 
-func postGroupRelatedTransaction(groupShoppingItem model.GroupShoppingItem, groupID int, cookie *http.Cookie) (model.GroupShoppingItem, error) {
-	requestURL := fmt.Sprintf(
-		"http://%s:%d/groups/%d/transactions",
-		config.Env.AccountApi.Host, config.Env.AccountApi.Port, groupID,
-	)
-
-	groupRelatedTransaction := GroupRelatedTransaction{
+func generateGroupRelatedTransaction(groupShoppingItem model.GroupShoppingItem) GroupRelatedTransaction {
+	return GroupRelatedTransaction{
 		TransactionType:  "expense",
 		TransactionDate:  groupShoppingItem.ExpectedPurchaseDate.Time,
 		Shop:             groupShoppingItem.Shop,
-		Memo:             fmt.Sprintf("【買い物リスト】%s", groupShoppingItem.Purchase),
+		Memo:             generateShoppingItemMemo(groupShoppingItem.Purchase, groupShoppingItem.Quantity, groupShoppingItem.Unit),
 		Amount:           groupShoppingItem.Amount.Int64,
 		PaymentUserID:    groupShoppingItem.PaymentUserID.String,
 		BigCategoryID:    groupShoppingItem.BigCategoryID,
 		MediumCategoryID: groupShoppingItem.MediumCategoryID,
 		CustomCategoryID: groupShoppingItem.CustomCategoryID,
 	}
+}
 
-	requestBody, err := json.Marshal(&groupRelatedTransaction)
-	if err != nil {
-		return groupShoppingItem, err
-	}
-
-	request, err := http.NewRequest(
-		"POST",
-		requestURL,
-		bytes.NewBuffer(requestBody),
-	)
-	if err != nil {
-		return groupShoppingItem, err
-	}
-
-	request.AddCookie(cookie)
-	request.Header.Set("Content-Type", "application/json; charset=UTF-8")
+func generateGroupRegularShoppingList(ctx context.Context, groupRegularShoppingList model.GroupRegularShoppingList, groupID int, locale string) (model.GroupRegularShoppingList, error) {
+	categoriesIdList := make([]CategoriesID, len(groupRegularShoppingList.GroupRegularShoppingList))
 
-	client := &http.Client{
-		Transport: &http.Transport{
-			DialContext: (&net.Dialer{
-				Timeout:   30 * time.Second,
-				KeepAlive: 30 * time.Second,
-			}).DialContext,
-			MaxIdleConns:          500,
-			MaxIdleConnsPerHost:   100,
-			IdleConnTimeout:       90 * time.Second,
-			ResponseHeaderTimeout: 10 * time.Second,
-			ExpectContinueTimeout: 1 * time.Second,
-		},
-		Timeout: 60 * time.Second,
+	for i, groupRegularShoppingItem := range groupRegularShoppingList.GroupRegularShoppingList {
+		categoriesIdList[i] = CategoriesID{
+			MediumCategoryID: groupRegularShoppingItem.MediumCategoryID,
+			CustomCategoryID: groupRegularShoppingItem.CustomCategoryID,
+		}
 	}
 
-	response, err := client.Do(request)
+	categoriesNameListBytes, err := getGroupShoppingItemCategoriesNameList(ctx, categoriesIdList, groupID, locale)
 	if err != nil {
-		return groupShoppingItem, err
-	}
-
-	defer func() {
-		_, _ = io.Copy(ioutil.Discard, response.Body)
-		response.Body.Close()
-	}()
-
-	if err := json.NewDecoder(response.Body).Decode(&groupShoppingItem.RelatedTransactionData); err != nil {
-		return groupShoppingItem, err
+		return groupRegularShoppingList, err
 	}
 
-	if response.StatusCode == http.StatusInternalServerError {
-		return groupShoppingItem, &InternalServerErrorMsg{"500 Internal Server Error"}
+	if err := json.Unmarshal(categoriesNameListBytes, &groupRegularShoppingList.GroupRegularShoppingList); err != nil {
+		return groupRegularShoppingList, err
 	}
 
-	return groupShoppingItem, nil
+	return groupRegularShoppingList, nil
 }
 
-func deleteGroupRelatedTransaction(groupShoppingItem model.GroupShoppingItem, groupID int, cookie *http.Cookie) (model.GroupShoppingItem, error) {
-	requestURL := fmt.Sprintf(
-		"http://%s:%d/groups/%d/transactions/%d",
-		config.Env.AccountApi.Host, config.Env.AccountApi.Port, groupID, groupShoppingItem.RelatedTransactionData.ID.Int64,
-	)
+type UpdatedGroupRegularShoppingData struct {
+	GroupRegularShoppingItem model.GroupRegularShoppingItem `json:"regular_shopping_item"`
+	model.GroupShoppingList
+}
 
-	request, err := http.NewRequest(
-		"DELETE",
-		requestURL,
-		nil,
-	)
+func generateUpdatedGroupRegularShoppingData(h *DBHandler, groupRegularShoppingItemID int, groupID int, locale string) (UpdatedGroupRegularShoppingData, error) {
+	groupRegularShoppingItem, err := h.GroupShoppingListRepo.GetGroupRegularShoppingItem(groupRegularShoppingItemID)
 	if err != nil {
-		return groupShoppingItem, err
+		return UpdatedGroupRegularShoppingData{}, err
 	}
 
-	request.AddCookie(cookie)
-	request.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	groupShoppingList, err := h.GroupShoppingListRepo.GetGroupShoppingListRelatedToUpdatedGroupRegularShoppingItem(groupRegularShoppingItemID)
+	if err != nil {
+		return UpdatedGroupRegularShoppingData{}, err
+	}
 
-	client := &http.Client{
-		Transport: &http.Transport{
-			DialContext: (&net.Dialer{
-				Timeout:   30 * time.Second,
-				KeepAlive: 30 * time.Second,
-			}).DialContext,
-			MaxIdleConns:          500,
-			MaxIdleConnsPerHost:   100,
-			IdleConnTimeout:       90 * time.Second,
-			ResponseHeaderTimeout: 10 * time.Second,
-			ExpectContinueTimeout: 1 * time.Second,
-		},
-		Timeout: 60 * time.Second,
+	categoriesID := CategoriesID{
+		MediumCategoryID: groupRegularShoppingItem.MediumCategoryID,
+		CustomCategoryID: groupRegularShoppingItem.CustomCategoryID,
 	}
 
-	response, err := client.Do(request)
+	categoriesNameBytes, err := getGroupShoppingItemCategoriesName(categoriesID, groupID, locale)
 	if err != nil {
-		return groupShoppingItem, err
+		return UpdatedGroupRegularShoppingData{}, err
 	}
 
-	defer func() {
-		_, _ = io.Copy(ioutil.Discard, response.Body)
-		response.Body.Close()
-	}()
-
-	if response.StatusCode == http.StatusInternalServerError {
-		return groupShoppingItem, &InternalServerErrorMsg{"500 Internal Server Error"}
+	if err := json.Unmarshal(categoriesNameBytes, &groupRegularShoppingItem); err != nil {
+		return UpdatedGroupRegularShoppingData{}, err
 	}
 
-	groupShoppingItem.RelatedTransactionData = nil
+	for i := 0; i < len(groupShoppingList.GroupShoppingList); i++ {
+		if err := json.Unmarshal(categoriesNameBytes, &groupShoppingList.GroupShoppingList[i]); err != nil {
+			return UpdatedGroupRegularShoppingData{}, err
+		}
+	}
 
-	return groupShoppingItem, nil
+	return UpdatedGroupRegularShoppingData{
+		GroupRegularShoppingItem: groupRegularShoppingItem,
+		GroupShoppingList:        groupShoppingList,
+	}, nil
 }
 
-func generateGroupRegularShoppingList(groupRegularShoppingList model.GroupRegularShoppingList, groupID int) (model.GroupRegularShoppingList, error) {
-	categoriesIdList := make([]CategoriesID, len(groupRegularShoppingList.GroupRegularShoppingList))
+func generateGroupSkippedShoppingList(ctx context.Context, groupSkippedShoppingList model.GroupSkippedShoppingList, groupID int, locale string) (model.GroupSkippedShoppingList, error) {
+	categoriesIdList := make([]CategoriesID, len(groupSkippedShoppingList.GroupSkippedShoppingList))
 
-	for i, groupRegularShoppingItem := range groupRegularShoppingList.GroupRegularShoppingList {
+	for i, groupSkippedShoppingItem := range groupSkippedShoppingList.GroupSkippedShoppingList {
 		categoriesIdList[i] = CategoriesID{
-			MediumCategoryID: groupRegularShoppingItem.MediumCategoryID,
-			CustomCategoryID: groupRegularShoppingItem.CustomCategoryID,
+			MediumCategoryID: groupSkippedShoppingItem.MediumCategoryID,
+			CustomCategoryID: groupSkippedShoppingItem.CustomCategoryID,
 		}
 	}
 
-	categoriesNameListBytes, err := getGroupShoppingItemCategoriesNameList(categoriesIdList, groupID)
+	categoriesNameListBytes, err := getGroupShoppingItemCategoriesNameList(ctx, categoriesIdList, groupID, locale)
 	if err != nil {
-		return groupRegularShoppingList, err
+		return groupSkippedShoppingList, err
 	}
 
-	if err := json.Unmarshal(categoriesNameListBytes, &groupRegularShoppingList.GroupRegularShoppingList); err != nil {
-		return groupRegularShoppingList, err
+	if err := json.Unmarshal(categoriesNameListBytes, &groupSkippedShoppingList.GroupSkippedShoppingList); err != nil {
+		return groupSkippedShoppingList, err
 	}
 
-	return groupRegularShoppingList, nil
+	return groupSkippedShoppingList, nil
 }
 
-func generateGroupShoppingList(groupShoppingList model.GroupShoppingList, groupID int) (model.GroupShoppingList, error) {
+func generateGroupShoppingList(ctx context.Context, groupShoppingList model.GroupShoppingList, groupID int, locale string) (model.GroupShoppingList, error) {
 	categoriesIdList := make([]CategoriesID, len(groupShoppingList.GroupShoppingList))
 	var transactionIdList []int64
 
@@ -581,7 +665,7 @@ func generateGroupShoppingList(groupShoppingList model.GroupShoppingList, groupI
 		}
 	}
 
-	categoriesNameListBytes, err := getGroupShoppingItemCategoriesNameList(categoriesIdList, groupID)
+	categoriesNameListBytes, err := getGroupShoppingItemCategoriesNameList(ctx, categoriesIdList, groupID, locale)
 	if err != nil {
 		return groupShoppingList, err
 	}
@@ -648,79 +732,231 @@ func generateGroupShoppingListByCategories(groupShoppingList model.GroupShopping
 	return groupShoppingListByCategories
 }
 
+func generateClaimedGroupShoppingList(groupShoppingList model.GroupShoppingList) model.ClaimedGroupShoppingList {
+	claimedGroupShoppingList := model.ClaimedGroupShoppingList{
+		ClaimedGroupShoppingList: make([]model.GroupShoppingListByClaimedUser, 0),
+	}
+
+	for _, groupShoppingItem := range groupShoppingList.GroupShoppingList {
+		lastIndex := len(claimedGroupShoppingList.ClaimedGroupShoppingList) - 1
+		if lastIndex < 0 || claimedGroupShoppingList.ClaimedGroupShoppingList[lastIndex].ClaimedUserID != groupShoppingItem.ClaimedUserID.String {
+			claimedGroupShoppingList.ClaimedGroupShoppingList = append(claimedGroupShoppingList.ClaimedGroupShoppingList, model.GroupShoppingListByClaimedUser{
+				ClaimedUserID:     groupShoppingItem.ClaimedUserID.String,
+				GroupShoppingList: make([]model.GroupShoppingItem, 0),
+			})
+
+			lastIndex++
+		}
+
+		claimedGroupShoppingList.ClaimedGroupShoppingList[lastIndex].GroupShoppingList = append(claimedGroupShoppingList.ClaimedGroupShoppingList[lastIndex].GroupShoppingList, groupShoppingItem)
+	}
+
+	return claimedGroupShoppingList
+}
+
+func generateGroupShoppingListByShops(groupShoppingList model.GroupShoppingList, shopSectionOrders map[string]map[int]int) []model.GroupShoppingListByShop {
+	groupShoppingListByShops := make([]model.GroupShoppingListByShop, 0)
+	noShopGroupShoppingList := make([]model.GroupShoppingItem, 0)
+
+	for _, groupShoppingItem := range groupShoppingList.GroupShoppingList {
+		if !groupShoppingItem.Shop.Valid || len(groupShoppingItem.Shop.String) == 0 {
+			noShopGroupShoppingList = append(noShopGroupShoppingList, groupShoppingItem)
+			continue
+		}
+
+		if len(groupShoppingListByShops) == 0 || groupShoppingListByShops[len(groupShoppingListByShops)-1].Shop.String != groupShoppingItem.Shop.String {
+			groupShoppingListByShops = append(groupShoppingListByShops, model.GroupShoppingListByShop{
+				Shop:              groupShoppingItem.Shop,
+				GroupShoppingList: make([]model.GroupShoppingItem, 0),
+			})
+		}
+
+		lastIndex := len(groupShoppingListByShops) - 1
+		groupShoppingListByShops[lastIndex].GroupShoppingList = append(groupShoppingListByShops[lastIndex].GroupShoppingList, groupShoppingItem)
+	}
+
+	// Items with no shop are put together in the last bucket.
+	if len(noShopGroupShoppingList) != 0 {
+		groupShoppingListByShops = append(groupShoppingListByShops, model.GroupShoppingListByShop{
+			GroupShoppingList: noShopGroupShoppingList,
+		})
+	}
+
+	for _, groupShoppingListByShop := range groupShoppingListByShops {
+		sectionOrder := shopSectionOrders[groupShoppingListByShop.Shop.String]
+		groupShoppingList := groupShoppingListByShop.GroupShoppingList
+
+		sort.SliceStable(groupShoppingList, func(i, j int) bool {
+			return shopSectionRank(sectionOrder, groupShoppingList[i].BigCategoryID) < shopSectionRank(sectionOrder, groupShoppingList[j].BigCategoryID)
+		})
+	}
+
+	return groupShoppingListByShops
+}
+
+// generateGroupShoppingItemPriceList replaces the amount of each purchased group shopping item with the amount of its related transaction,
+// which records what was actually paid, and drops the items whose price is unknown.
+func generateGroupShoppingItemPriceList(groupShoppingItemPriceList []model.GroupShoppingItemPrice, groupID int) ([]model.GroupShoppingItemPrice, error) {
+	var transactionIdList []int64
+	for _, groupShoppingItemPrice := range groupShoppingItemPriceList {
+		if groupShoppingItemPrice.RelatedTransactionData != nil {
+			transactionIdList = append(transactionIdList, groupShoppingItemPrice.RelatedTransactionData.ID.Int64)
+		}
+	}
+
+	if len(transactionIdList) != 0 {
+		groupShoppingItemRelatedTransactionDataList, err := getGroupShoppingItemRelatedTransactionDataList(transactionIdList, groupID)
+		if err != nil {
+			return groupShoppingItemPriceList, err
+		}
+
+		for _, groupShoppingItemRelatedTransactionData := range groupShoppingItemRelatedTransactionDataList {
+			for i, groupShoppingItemPrice := range groupShoppingItemPriceList {
+				if groupShoppingItemPrice.RelatedTransactionData != nil && groupShoppingItemRelatedTransactionData.ID.Int64 == groupShoppingItemPrice.RelatedTransactionData.ID.Int64 {
+					groupShoppingItemPriceList[i].Amount = model.NullInt64{NullInt64: sql.NullInt64{Int64: int64(groupShoppingItemRelatedTransactionData.Amount), Valid: true}}
+				}
+			}
+		}
+	}
+
+	pricedGroupShoppingItemPriceList := make([]model.GroupShoppingItemPrice, 0, len(groupShoppingItemPriceList))
+	for _, groupShoppingItemPrice := range groupShoppingItemPriceList {
+		if groupShoppingItemPrice.Amount.Valid {
+			pricedGroupShoppingItemPriceList = append(pricedGroupShoppingItemPriceList, groupShoppingItemPrice)
+		}
+	}
+
+	return pricedGroupShoppingItemPriceList, nil
+}
+
+func (h *DBHandler) suggestGroupShoppingItemPrice(groupID int, purchase string) (*model.PriceSuggestion, error) {
+	groupShoppingItemPriceList, err := h.GroupShoppingListRepo.GetGroupShoppingItemPriceListByPurchase(groupID, normalizeShoppingItemName(purchase), maxPriceSuggestionHistory)
+	if err != nil {
+		return nil, err
+	}
+
+	groupShoppingItemPriceList, err = generateGroupShoppingItemPriceList(groupShoppingItemPriceList, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	purchasedPriceList := newGroupPurchasedPriceList(groupShoppingItemPriceList)
+	for i, groupShoppingItemPrice := range groupShoppingItemPriceList {
+		purchasedPriceList[i].amount = unitPurchasedPrice(groupShoppingItemPrice.Amount.Int64, groupShoppingItemPrice.Quantity)
+	}
+
+	return suggestPrice(purchasedPriceList, purchase), nil
+}
+
+func newGroupPurchasedPriceList(groupShoppingItemPriceList []model.GroupShoppingItemPrice) []purchasedPrice {
+	purchasedPriceList := make([]purchasedPrice, len(groupShoppingItemPriceList))
+	for i, groupShoppingItemPrice := range groupShoppingItemPriceList {
+		purchasedPriceList[i] = purchasedPrice{
+			purchase: groupShoppingItemPrice.Purchase,
+			shop:     groupShoppingItemPrice.Shop,
+			amount:   groupShoppingItemPrice.Amount.Int64,
+		}
+	}
+
+	return purchasedPriceList
+}
+
+func generateGroupShoppingItemPriceHistoryList(groupShoppingItemPriceList []model.GroupShoppingItemPrice, purchaseQuery string, shopQuery string) model.GroupShoppingItemPriceHistoryList {
+	groupShoppingItemPriceHistoryList := model.GroupShoppingItemPriceHistoryList{
+		GroupShoppingItemPriceHistoryList: make([]model.GroupShoppingItemPriceHistory, 0),
+	}
+
+	purchasedPriceList := newGroupPurchasedPriceList(groupShoppingItemPriceList)
+
+	var filteredGroupShoppingItemPriceList []model.GroupShoppingItemPrice
+	var filteredPurchasedPriceList []purchasedPrice
+	for _, i := range filterPurchasedPrices(purchasedPriceList, purchaseQuery, shopQuery) {
+		filteredGroupShoppingItemPriceList = append(filteredGroupShoppingItemPriceList, groupShoppingItemPriceList[i])
+		filteredPurchasedPriceList = append(filteredPurchasedPriceList, purchasedPriceList[i])
+	}
+
+	for _, indexList := range groupPurchasedPrices(filteredPurchasedPriceList) {
+		lastPrice, averagePrice, minPrice, maxPrice := summarizePurchasedPrices(filteredPurchasedPriceList, indexList)
+
+		groupShoppingItemPriceHistory := model.GroupShoppingItemPriceHistory{
+			Purchase:     filteredGroupShoppingItemPriceList[indexList[0]].Purchase,
+			Shop:         filteredGroupShoppingItemPriceList[indexList[0]].Shop,
+			LastPrice:    lastPrice,
+			AveragePrice: averagePrice,
+			MinPrice:     minPrice,
+			MaxPrice:     maxPrice,
+			PriceList:    make([]model.GroupShoppingItemPrice, len(indexList)),
+		}
+
+		for j, i := range indexList {
+			groupShoppingItemPriceHistory.PriceList[j] = filteredGroupShoppingItemPriceList[i]
+		}
+
+		groupShoppingItemPriceHistoryList.GroupShoppingItemPriceHistoryList = append(groupShoppingItemPriceHistoryList.GroupShoppingItemPriceHistoryList, groupShoppingItemPriceHistory)
+	}
+
+	return groupShoppingItemPriceHistoryList
+}
+
 func (h *DBHandler) GetDailyGroupShoppingDataByDay(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	groupRegularShoppingList, err := h.GroupShoppingListRepo.GetGroupRegularShoppingList(groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if len(groupRegularShoppingList.GroupRegularShoppingList) != 0 {
-		now := h.TimeManage.Now()
-		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
-
-		if err = h.GroupShoppingListRepo.PutGroupRegularShoppingList(groupRegularShoppingList, groupID, today); err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
-			return
-		}
-
-		groupRegularShoppingList, err = h.GroupShoppingListRepo.GetGroupRegularShoppingList(groupID)
+		groupRegularShoppingList, err = generateGroupRegularShoppingList(r.Context(), groupRegularShoppingList, groupID, requestLocale(r.Context()))
 		if err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
-			return
-		}
-
-		groupRegularShoppingList, err = generateGroupRegularShoppingList(groupRegularShoppingList, groupID)
-		if err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 	}
 
 	date, err := time.Parse("2006-01-02", mux.Vars(r)["date"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"日付を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"日付を正しく指定してください。"}))
 		return
 	}
 
 	groupShoppingList, err := h.GroupShoppingListRepo.GetDailyGroupShoppingListByDay(date, groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if len(groupShoppingList.GroupShoppingList) != 0 {
-		groupShoppingList, err = generateGroupShoppingList(groupShoppingList, groupID)
+		groupShoppingList, err = generateGroupShoppingList(r.Context(), groupShoppingList, groupID, requestLocale(r.Context()))
 		if err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 	}
@@ -742,75 +978,61 @@ func (h *DBHandler) GetDailyGroupShoppingDataByCategory(w http.ResponseWriter, r
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	groupRegularShoppingList, err := h.GroupShoppingListRepo.GetGroupRegularShoppingList(groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if len(groupRegularShoppingList.GroupRegularShoppingList) != 0 {
-		now := h.TimeManage.Now()
-		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
-
-		if err = h.GroupShoppingListRepo.PutGroupRegularShoppingList(groupRegularShoppingList, groupID, today); err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
-			return
-		}
-
-		groupRegularShoppingList, err = h.GroupShoppingListRepo.GetGroupRegularShoppingList(groupID)
+		groupRegularShoppingList, err = generateGroupRegularShoppingList(r.Context(), groupRegularShoppingList, groupID, requestLocale(r.Context()))
 		if err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
-			return
-		}
-
-		groupRegularShoppingList, err = generateGroupRegularShoppingList(groupRegularShoppingList, groupID)
-		if err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 	}
 
 	date, err := time.Parse("2006-01-02", mux.Vars(r)["date"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"日付を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"日付を正しく指定してください。"}))
 		return
 	}
 
 	groupShoppingList, err := h.GroupShoppingListRepo.GetDailyGroupShoppingListByCategory(date, groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if len(groupShoppingList.GroupShoppingList) != 0 {
-		groupShoppingList, err = generateGroupShoppingList(groupShoppingList, groupID)
+		groupShoppingList, err = generateGroupShoppingList(r.Context(), groupShoppingList, groupID, requestLocale(r.Context()))
 		if err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 	}
@@ -834,62 +1056,48 @@ func (h *DBHandler) GetMonthlyGroupShoppingDataByDay(w http.ResponseWriter, r *h
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	groupRegularShoppingList, err := h.GroupShoppingListRepo.GetGroupRegularShoppingList(groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if len(groupRegularShoppingList.GroupRegularShoppingList) != 0 {
-		now := h.TimeManage.Now()
-		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
-
-		if err = h.GroupShoppingListRepo.PutGroupRegularShoppingList(groupRegularShoppingList, groupID, today); err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
-			return
-		}
-
-		groupRegularShoppingList, err = h.GroupShoppingListRepo.GetGroupRegularShoppingList(groupID)
+		groupRegularShoppingList, err = generateGroupRegularShoppingList(r.Context(), groupRegularShoppingList, groupID, requestLocale(r.Context()))
 		if err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
-			return
-		}
-
-		groupRegularShoppingList, err = generateGroupRegularShoppingList(groupRegularShoppingList, groupID)
-		if err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 	}
 
 	firstDay, err := time.Parse("2006-01", mux.Vars(r)["year_month"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
 		return
 	}
 
@@ -897,21 +1105,38 @@ func (h *DBHandler) GetMonthlyGroupShoppingDataByDay(w http.ResponseWriter, r *h
 
 	groupShoppingList, err := h.GroupShoppingListRepo.GetMonthlyGroupShoppingListByDay(firstDay, lastDay, groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if len(groupShoppingList.GroupShoppingList) != 0 {
-		groupShoppingList, err = generateGroupShoppingList(groupShoppingList, groupID)
+		groupShoppingList, err = generateGroupShoppingList(r.Context(), groupShoppingList, groupID, requestLocale(r.Context()))
 		if err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 	}
 
-	groupShoppingData := model.GroupShoppingDataByDay{
-		GroupRegularShoppingList: groupRegularShoppingList,
-		GroupShoppingList:        groupShoppingList,
+	groupSkippedShoppingList, err := h.GroupShoppingListRepo.GetMonthlyGroupSkippedShoppingList(firstDay, lastDay, groupID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if len(groupSkippedShoppingList.GroupSkippedShoppingList) != 0 {
+		groupSkippedShoppingList, err = generateGroupSkippedShoppingList(r.Context(), groupSkippedShoppingList, groupID, requestLocale(r.Context()))
+		if err != nil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+	}
+
+	groupShoppingData := model.MonthlyGroupShoppingDataByDay{
+		GroupShoppingDataByDay: model.GroupShoppingDataByDay{
+			GroupRegularShoppingList: groupRegularShoppingList,
+			GroupShoppingList:        groupShoppingList,
+		},
+		GroupSkippedShoppingList: groupSkippedShoppingList,
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
@@ -926,62 +1151,48 @@ func (h *DBHandler) GetMonthlyGroupShoppingDataByCategory(w http.ResponseWriter,
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	groupRegularShoppingList, err := h.GroupShoppingListRepo.GetGroupRegularShoppingList(groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if len(groupRegularShoppingList.GroupRegularShoppingList) != 0 {
-		now := h.TimeManage.Now()
-		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
-
-		if err = h.GroupShoppingListRepo.PutGroupRegularShoppingList(groupRegularShoppingList, groupID, today); err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
-			return
-		}
-
-		groupRegularShoppingList, err = h.GroupShoppingListRepo.GetGroupRegularShoppingList(groupID)
-		if err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
-			return
-		}
-
-		groupRegularShoppingList, err = generateGroupRegularShoppingList(groupRegularShoppingList, groupID)
+		groupRegularShoppingList, err = generateGroupRegularShoppingList(r.Context(), groupRegularShoppingList, groupID, requestLocale(r.Context()))
 		if err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 	}
 
 	firstDay, err := time.Parse("2006-01", mux.Vars(r)["year_month"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
 		return
 	}
 
@@ -989,14 +1200,14 @@ func (h *DBHandler) GetMonthlyGroupShoppingDataByCategory(w http.ResponseWriter,
 
 	groupShoppingList, err := h.GroupShoppingListRepo.GetMonthlyGroupShoppingListByCategory(firstDay, lastDay, groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if len(groupShoppingList.GroupShoppingList) != 0 {
-		groupShoppingList, err = generateGroupShoppingList(groupShoppingList, groupID)
+		groupShoppingList, err = generateGroupShoppingList(r.Context(), groupShoppingList, groupID, requestLocale(r.Context()))
 		if err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 	}
@@ -1016,260 +1227,332 @@ func (h *DBHandler) GetMonthlyGroupShoppingDataByCategory(w http.ResponseWriter,
 	}
 }
 
-func (h *DBHandler) GetExpiredGroupShoppingList(w http.ResponseWriter, r *http.Request) {
+func (h *DBHandler) GetDailyGroupShoppingDataByShop(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
-	now := h.TimeManage.Now()
-	dueDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
-
-	expiredGroupShoppingList, err := h.GroupShoppingListRepo.GetExpiredGroupShoppingList(dueDate, groupID)
+	groupRegularShoppingList, err := h.GroupShoppingListRepo.GetGroupRegularShoppingList(groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	if len(expiredGroupShoppingList.ExpiredGroupShoppingList) != 0 {
-		categoriesIdList := make([]CategoriesID, len(expiredGroupShoppingList.ExpiredGroupShoppingList))
-
-		for i, groupShoppingItem := range expiredGroupShoppingList.ExpiredGroupShoppingList {
-			categoriesIdList[i] = CategoriesID{
-				MediumCategoryID: groupShoppingItem.MediumCategoryID,
-				CustomCategoryID: groupShoppingItem.CustomCategoryID,
-			}
-		}
-
-		categoriesNameListBytes, err := getGroupShoppingItemCategoriesNameList(categoriesIdList, groupID)
+	if len(groupRegularShoppingList.GroupRegularShoppingList) != 0 {
+		groupRegularShoppingList, err = generateGroupRegularShoppingList(r.Context(), groupRegularShoppingList, groupID, requestLocale(r.Context()))
 		if err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
+	}
 
-		if err := json.Unmarshal(categoriesNameListBytes, &expiredGroupShoppingList.ExpiredGroupShoppingList); err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+	date, err := time.Parse("2006-01-02", mux.Vars(r)["date"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"日付を正しく指定してください。"}))
+		return
+	}
+
+	groupShoppingList, err := h.GroupShoppingListRepo.GetDailyGroupShoppingListByShop(date, groupID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if len(groupShoppingList.GroupShoppingList) != 0 {
+		groupShoppingList, err = generateGroupShoppingList(r.Context(), groupShoppingList, groupID, requestLocale(r.Context()))
+		if err != nil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 	}
 
+	shopSectionList, err := h.ShoppingListRepo.GetShopSectionList(userID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	groupShoppingListByShops := generateGroupShoppingListByShops(groupShoppingList, generateShopSectionOrders(shopSectionList))
+
+	shoppingDataByShops := model.GroupShoppingDataByShop{
+		GroupRegularShoppingList: groupRegularShoppingList,
+		GroupShoppingListByShop:  groupShoppingListByShops,
+	}
+
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(&expiredGroupShoppingList); err != nil {
+	if err := json.NewEncoder(w).Encode(&shoppingDataByShops); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 }
 
-func (h *DBHandler) PostGroupRegularShoppingItem(w http.ResponseWriter, r *http.Request) {
+func (h *DBHandler) GetMonthlyGroupShoppingDataByShop(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
-	var groupRegularShoppingItem model.GroupRegularShoppingItem
-	if err := json.NewDecoder(r.Body).Decode(&groupRegularShoppingItem); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+	groupRegularShoppingList, err := h.GroupShoppingListRepo.GetGroupRegularShoppingList(groupID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	now := h.TimeManage.Now()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
-
-	groupRegularShoppingItem.Today = today
-
-	if err := validateGroupRegularShoppingItem(groupRegularShoppingItem); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, err))
-		return
+	if len(groupRegularShoppingList.GroupRegularShoppingList) != 0 {
+		groupRegularShoppingList, err = generateGroupRegularShoppingList(r.Context(), groupRegularShoppingList, groupID, requestLocale(r.Context()))
+		if err != nil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
 	}
 
-	groupRegularShoppingItemResult, todayGroupShoppingItemResult, laterThanTodayGroupShoppingItemResult, err := h.GroupShoppingListRepo.PostGroupRegularShoppingItem(&groupRegularShoppingItem, groupID, today)
+	firstDay, err := time.Parse("2006-01", mux.Vars(r)["year_month"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
 		return
 	}
 
-	groupRegularShoppingItemID, err := groupRegularShoppingItemResult.LastInsertId()
+	lastDay := time.Date(firstDay.Year(), firstDay.Month()+1, 1, 0, 0, 0, 0, firstDay.Location()).Add(-1 * time.Second)
+
+	groupShoppingList, err := h.GroupShoppingListRepo.GetMonthlyGroupShoppingListByShop(firstDay, lastDay, groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	var todayGroupShoppingItemID int64
-	if todayGroupShoppingItemResult != nil {
-		todayGroupShoppingItemID, err = todayGroupShoppingItemResult.LastInsertId()
+	if len(groupShoppingList.GroupShoppingList) != 0 {
+		groupShoppingList, err = generateGroupShoppingList(r.Context(), groupShoppingList, groupID, requestLocale(r.Context()))
 		if err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 	}
 
-	laterThanTodayGroupShoppingItemID, err := laterThanTodayGroupShoppingItemResult.LastInsertId()
+	shopSectionList, err := h.ShoppingListRepo.GetShopSectionList(userID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	groupRegularShoppingItem, err = h.GroupShoppingListRepo.GetGroupRegularShoppingItem(int(groupRegularShoppingItemID))
-	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+	groupShoppingListByShops := generateGroupShoppingListByShops(groupShoppingList, generateShopSectionOrders(shopSectionList))
+
+	shoppingDataByShops := model.GroupShoppingDataByShop{
+		GroupRegularShoppingList: groupRegularShoppingList,
+		GroupShoppingListByShop:  groupShoppingListByShops,
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&shoppingDataByShops); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
+}
 
-	groupShoppingList, err := h.GroupShoppingListRepo.GetGroupShoppingListRelatedToPostedGroupRegularShoppingItem(int(todayGroupShoppingItemID), int(laterThanTodayGroupShoppingItemID))
+func (h *DBHandler) GetExpiredGroupShoppingList(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	categoriesID := CategoriesID{
-		MediumCategoryID: groupRegularShoppingItem.MediumCategoryID,
-		CustomCategoryID: groupRegularShoppingItem.CustomCategoryID,
+	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		return
 	}
 
-	categoriesNameBytes, err := getGroupShoppingItemCategoriesName(categoriesID, groupID)
-	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+	if err := verifyGroupAffiliation(groupID, userID); err != nil {
+		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
+		if !ok {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
-	if err := json.Unmarshal(categoriesNameBytes, &groupRegularShoppingItem); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+	now := h.TimeManage.Now()
+	dueDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+
+	expiredGroupShoppingList, err := h.GroupShoppingListRepo.GetExpiredGroupShoppingList(r.Context(), dueDate, groupID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	for i := 0; i < len(groupShoppingList.GroupShoppingList); i++ {
-		if err := json.Unmarshal(categoriesNameBytes, &groupShoppingList.GroupShoppingList[i]); err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+	if len(expiredGroupShoppingList.ExpiredGroupShoppingList) != 0 {
+		categoriesIdList := make([]CategoriesID, len(expiredGroupShoppingList.ExpiredGroupShoppingList))
+
+		for i, groupShoppingItem := range expiredGroupShoppingList.ExpiredGroupShoppingList {
+			categoriesIdList[i] = CategoriesID{
+				MediumCategoryID: groupShoppingItem.MediumCategoryID,
+				CustomCategoryID: groupShoppingItem.CustomCategoryID,
+			}
+		}
+
+		categoriesNameListBytes, err := getGroupShoppingItemCategoriesNameList(r.Context(), categoriesIdList, groupID, requestLocale(r.Context()))
+		if err != nil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
-	}
 
-	shoppingData := struct {
-		GroupRegularShoppingItem model.GroupRegularShoppingItem `json:"regular_shopping_item"`
-		model.GroupShoppingList
-	}{
-		GroupRegularShoppingItem: groupRegularShoppingItem,
-		GroupShoppingList:        groupShoppingList,
+		if err := json.Unmarshal(categoriesNameListBytes, &expiredGroupShoppingList.ExpiredGroupShoppingList); err != nil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
-	w.WriteHeader(http.StatusCreated)
-	if err := json.NewEncoder(w).Encode(&shoppingData); err != nil {
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&expiredGroupShoppingList); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 }
 
-func (h *DBHandler) PutGroupRegularShoppingItem(w http.ResponseWriter, r *http.Request) {
+func (h *DBHandler) PostGroupRegularShoppingItem(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	var groupRegularShoppingItem model.GroupRegularShoppingItem
 	if err := json.NewDecoder(r.Body).Decode(&groupRegularShoppingItem); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	groupRegularShoppingItemID, err := strconv.Atoi(mux.Vars(r)["id"])
+	now := h.TimeManage.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	groupRegularShoppingItem.Today = today
+
+	if err := validateGroupRegularShoppingItem(groupRegularShoppingItem); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
+		return
+	}
+
+	groupRegularShoppingItem.Amount = generateShoppingItemAmount(groupRegularShoppingItem.Amount, groupRegularShoppingItem.Quantity, groupRegularShoppingItem.UnitPrice)
+	groupRegularShoppingItem.RecurrenceRule = generateRecurrenceRule(groupRegularShoppingItem.CycleType, groupRegularShoppingItem.Cycle, groupRegularShoppingItem.RecurrenceRule, groupRegularShoppingItem.ExpectedPurchaseDate)
+	groupRegularShoppingItem.RecurrenceStartDate = groupRegularShoppingItem.ExpectedPurchaseDate
+
+	groupRegularShoppingItemResult, todayGroupShoppingItemResult, laterThanTodayGroupShoppingItemResult, err := h.GroupShoppingListRepo.PostGroupRegularShoppingItem(&groupRegularShoppingItem, groupID, today)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"定期ショッピングアイテムIDを正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	if err := validateGroupRegularShoppingItem(groupRegularShoppingItem); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, err))
+	groupRegularShoppingItemID, err := groupRegularShoppingItemResult.LastInsertId()
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	now := h.TimeManage.Now()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	var todayGroupShoppingItemID int64
+	if todayGroupShoppingItemResult != nil {
+		todayGroupShoppingItemID, err = todayGroupShoppingItemResult.LastInsertId()
+		if err != nil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+	}
 
-	if err := h.GroupShoppingListRepo.PutGroupRegularShoppingItem(&groupRegularShoppingItem, groupRegularShoppingItemID, groupID, today); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
-		return
+	var laterThanTodayGroupShoppingItemID int64
+	if laterThanTodayGroupShoppingItemResult != nil {
+		laterThanTodayGroupShoppingItemID, err = laterThanTodayGroupShoppingItemResult.LastInsertId()
+		if err != nil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
 	}
 
-	groupRegularShoppingItem, err = h.GroupShoppingListRepo.GetGroupRegularShoppingItem(groupRegularShoppingItemID)
+	groupRegularShoppingItem, err = h.GroupShoppingListRepo.GetGroupRegularShoppingItem(int(groupRegularShoppingItemID))
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	groupShoppingList, err := h.GroupShoppingListRepo.GetGroupShoppingListRelatedToUpdatedGroupRegularShoppingItem(groupRegularShoppingItemID)
+	groupShoppingList, err := h.GroupShoppingListRepo.GetGroupShoppingListRelatedToPostedGroupRegularShoppingItem(int(todayGroupShoppingItemID), int(laterThanTodayGroupShoppingItemID))
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -1278,20 +1561,20 @@ func (h *DBHandler) PutGroupRegularShoppingItem(w http.ResponseWriter, r *http.R
 		CustomCategoryID: groupRegularShoppingItem.CustomCategoryID,
 	}
 
-	categoriesNameBytes, err := getGroupShoppingItemCategoriesName(categoriesID, groupID)
+	categoriesNameBytes, err := getGroupShoppingItemCategoriesName(categoriesID, groupID, requestLocale(r.Context()))
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if err := json.Unmarshal(categoriesNameBytes, &groupRegularShoppingItem); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	for i := 0; i < len(groupShoppingList.GroupShoppingList); i++ {
 		if err := json.Unmarshal(categoriesNameBytes, &groupShoppingList.GroupShoppingList[i]); err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 	}
@@ -1305,228 +1588,570 @@ func (h *DBHandler) PutGroupRegularShoppingItem(w http.ResponseWriter, r *http.R
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
-	w.WriteHeader(http.StatusOK)
+	w.WriteHeader(http.StatusCreated)
 	if err := json.NewEncoder(w).Encode(&shoppingData); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 }
 
-func (h *DBHandler) DeleteGroupRegularShoppingItem(w http.ResponseWriter, r *http.Request) {
+func (h *DBHandler) PutGroupRegularShoppingItem(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		return
+	}
+
+	var groupRegularShoppingItem model.GroupRegularShoppingItem
+	if err := json.NewDecoder(r.Body).Decode(&groupRegularShoppingItem); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupRegularShoppingItemID, err := strconv.Atoi(mux.Vars(r)["id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"定期ショッピングアイテムIDを正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"定期ショッピングアイテムIDを正しく指定してください。"}))
 		return
 	}
 
-	if err := h.GroupShoppingListRepo.DeleteGroupRegularShoppingItem(groupRegularShoppingItemID); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+	if err := validateGroupRegularShoppingItem(groupRegularShoppingItem); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
+		return
+	}
+
+	groupRegularShoppingItem.Amount = generateShoppingItemAmount(groupRegularShoppingItem.Amount, groupRegularShoppingItem.Quantity, groupRegularShoppingItem.UnitPrice)
+	groupRegularShoppingItem.RecurrenceRule = generateRecurrenceRule(groupRegularShoppingItem.CycleType, groupRegularShoppingItem.Cycle, groupRegularShoppingItem.RecurrenceRule, groupRegularShoppingItem.ExpectedPurchaseDate)
+	groupRegularShoppingItem.RecurrenceStartDate = groupRegularShoppingItem.ExpectedPurchaseDate
+
+	now := h.TimeManage.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	if err := h.GroupShoppingListRepo.PutGroupRegularShoppingItem(&groupRegularShoppingItem, groupRegularShoppingItemID, groupID, today); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	shoppingData, err := generateUpdatedGroupRegularShoppingData(h, groupRegularShoppingItemID, groupID, requestLocale(r.Context()))
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(&DeleteContentMsg{"定期ショッピングアイテムを削除しました。"}); err != nil {
+	if err := json.NewEncoder(w).Encode(&shoppingData); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 }
 
-func (h *DBHandler) PostGroupShoppingItem(w http.ResponseWriter, r *http.Request) {
+func (h *DBHandler) SkipGroupRegularShoppingItem(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
-	var groupShoppingItem model.GroupShoppingItem
-	if err := json.NewDecoder(r.Body).Decode(&groupShoppingItem); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+	groupRegularShoppingItemID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"定期ショッピングアイテムIDを正しく指定してください。"}))
 		return
 	}
 
-	now := h.TimeManage.Now()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
-
-	groupShoppingItem.Today = today
-
-	if err := validateGroupShoppingItem(groupShoppingItem); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, err))
+	var regularShoppingItemSkip model.RegularShoppingItemSkip
+	if err := json.NewDecoder(r.Body).Decode(&regularShoppingItemSkip); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	result, err := h.GroupShoppingListRepo.PostGroupShoppingItem(&groupShoppingItem, groupID)
+	groupRegularShoppingItem, err := h.GroupShoppingListRepo.GetGroupRegularShoppingItem(groupRegularShoppingItemID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		if errors.Is(err, sql.ErrNoRows) {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"指定された定期ショッピングアイテムは存在しません。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	lastInsertId, err := result.LastInsertId()
-	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+	if groupRegularShoppingItem.FinishedFlag {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"繰り返しが終了した定期ショッピングアイテムはスキップできません。"}))
 		return
 	}
 
-	groupShoppingItem, err = h.GroupShoppingListRepo.GetGroupShoppingItem(int(lastInsertId))
+	now := h.TimeManage.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	skippedDateList, err := generateSkippedDateList(regularShoppingItemSkip, groupRegularShoppingItem, groupRegularShoppingItem.ExpectedPurchaseDate.Time, today)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
 		return
 	}
 
-	categoriesID := CategoriesID{
-		MediumCategoryID: groupShoppingItem.MediumCategoryID,
-		CustomCategoryID: groupShoppingItem.CustomCategoryID,
+	skipped, err := h.GroupShoppingListRepo.SkipGroupRegularShoppingItem(&groupRegularShoppingItem, skippedDateList, groupID, today)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusNotFound, &NotFoundErrorMsg{"指定された定期ショッピングアイテムは存在しません。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
 	}
 
-	categoriesNameBytes, err := getGroupShoppingItemCategoriesName(categoriesID, groupID)
-	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+	if !skipped {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusConflict, &ConflictErrorMsg{"この定期ショッピングアイテムは既に更新されています。最新の買い物リストを確認してください。"}))
 		return
 	}
 
-	if err := json.Unmarshal(categoriesNameBytes, &groupShoppingItem); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+	shoppingData, err := generateUpdatedGroupRegularShoppingData(h, groupRegularShoppingItemID, groupID, requestLocale(r.Context()))
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
-	w.WriteHeader(http.StatusCreated)
-	if err := json.NewEncoder(w).Encode(&groupShoppingItem); err != nil {
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&shoppingData); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 }
 
-func (h *DBHandler) PutGroupShoppingItem(w http.ResponseWriter, r *http.Request) {
+func (h *DBHandler) PauseGroupRegularShoppingItem(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
-		return
-	}
-
-	var groupShoppingItem model.GroupShoppingItem
-	if err := json.NewDecoder(r.Body).Decode(&groupShoppingItem); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
-	groupShoppingItem.ID, err = strconv.Atoi(mux.Vars(r)["id"])
+	groupRegularShoppingItemID, err := strconv.Atoi(mux.Vars(r)["id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"ショッピングアイテムIDを正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"定期ショッピングアイテムIDを正しく指定してください。"}))
 		return
 	}
 
-	if err := validateGroupShoppingItem(groupShoppingItem); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, err))
+	var regularShoppingItemPause model.RegularShoppingItemPause
+	if err := json.NewDecoder(r.Body).Decode(&regularShoppingItemPause); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	if groupShoppingItem.CompleteFlag && groupShoppingItem.TransactionAutoAdd && groupShoppingItem.RelatedTransactionData == nil {
-		cookie, err := r.Cookie(config.Env.Cookie.Name)
-		if err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+	groupRegularShoppingItem, err := h.GroupShoppingListRepo.GetGroupRegularShoppingItem(groupRegularShoppingItemID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"指定された定期ショッピングアイテムは存在しません。"}))
 			return
 		}
 
-		groupShoppingItem, err = postGroupRelatedTransaction(groupShoppingItem, groupID, cookie)
-		if err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	now := h.TimeManage.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	if err := validatePausedUntil(regularShoppingItemPause.PausedUntil, today); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
+		return
+	}
+
+	groupRegularShoppingItem.PausedUntil = regularShoppingItemPause.PausedUntil
+
+	paused, err := h.GroupShoppingListRepo.PauseGroupRegularShoppingItem(&groupRegularShoppingItem, groupID, today)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusNotFound, &NotFoundErrorMsg{"指定された定期ショッピングアイテムは存在しません。"}))
 			return
 		}
-	} else if !groupShoppingItem.CompleteFlag && groupShoppingItem.RelatedTransactionData != nil {
-		cookie, err := r.Cookie(config.Env.Cookie.Name)
-		if err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if !paused {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusConflict, &ConflictErrorMsg{"この定期ショッピングアイテムは既に更新されています。最新の買い物リストを確認してください。"}))
+		return
+	}
+
+	shoppingData, err := generateUpdatedGroupRegularShoppingData(h, groupRegularShoppingItemID, groupID, requestLocale(r.Context()))
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&shoppingData); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) DeleteGroupRegularShoppingItem(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		groupShoppingItem, err = deleteGroupRelatedTransaction(groupShoppingItem, groupID, cookie)
-		if err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		return
+	}
+
+	if err := verifyGroupAffiliation(groupID, userID); err != nil {
+		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
+		if !ok {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		return
+	}
+
+	groupRegularShoppingItemID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"定期ショッピングアイテムIDを正しく指定してください。"}))
+		return
+	}
+
+	if err := h.GroupShoppingListRepo.DeleteGroupRegularShoppingItem(groupRegularShoppingItemID); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&DeleteContentMsg{localizeMessage(r.Context(), "定期ショッピングアイテムを削除しました。")}); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) GetGroupShoppingItemPriceHistoryList(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		return
+	}
+
+	if err := verifyGroupAffiliation(groupID, userID); err != nil {
+		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
+		if !ok {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		return
+	}
+
+	groupShoppingItemPriceList, err := h.GroupShoppingListRepo.GetGroupShoppingItemPriceList(groupID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
 	}
 
-	if _, err := h.GroupShoppingListRepo.PutGroupShoppingItem(&groupShoppingItem); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+	groupShoppingItemPriceList, err = generateGroupShoppingItemPriceList(groupShoppingItemPriceList, groupID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
+	groupShoppingItemPriceHistoryList := generateGroupShoppingItemPriceHistoryList(groupShoppingItemPriceList, r.URL.Query().Get("purchase"), r.URL.Query().Get("shop"))
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&groupShoppingItemPriceHistoryList); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) PostGroupShoppingItem(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		return
+	}
+
+	if err := verifyGroupAffiliation(groupID, userID); err != nil {
+		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
+		if !ok {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		return
+	}
+
+	var groupShoppingItem model.GroupShoppingItem
+	if err := json.NewDecoder(r.Body).Decode(&groupShoppingItem); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	now := h.TimeManage.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	groupShoppingItem.Today = today
+
+	if err := validateGroupShoppingItem(groupShoppingItem); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
+		return
+	}
+
+	groupShoppingItem.Amount = generateShoppingItemAmount(groupShoppingItem.Amount, groupShoppingItem.Quantity, groupShoppingItem.UnitPrice)
+
+	result, err := h.GroupShoppingListRepo.PostGroupShoppingItem(&groupShoppingItem, groupID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	lastInsertId, err := result.LastInsertId()
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	groupShoppingItem, err = h.GroupShoppingListRepo.GetGroupShoppingItem(int(lastInsertId))
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	categoriesID := CategoriesID{
+		MediumCategoryID: groupShoppingItem.MediumCategoryID,
+		CustomCategoryID: groupShoppingItem.CustomCategoryID,
+	}
+
+	categoriesNameBytes, err := getGroupShoppingItemCategoriesName(categoriesID, groupID, requestLocale(r.Context()))
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if err := json.Unmarshal(categoriesNameBytes, &groupShoppingItem); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if groupShoppingItem.PriceSuggestion, err = h.suggestGroupShoppingItemPrice(groupID, groupShoppingItem.Purchase); err != nil {
+		log.Println(err)
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(&groupShoppingItem); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) PutGroupShoppingItem(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		return
+	}
+
+	if err := verifyGroupAffiliation(groupID, userID); err != nil {
+		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
+		if !ok {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		return
+	}
+
+	var groupShoppingItem model.GroupShoppingItem
+	if err := json.NewDecoder(r.Body).Decode(&groupShoppingItem); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	groupShoppingItem.ID, err = strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"ショッピングアイテムIDを正しく指定してください。"}))
+		return
+	}
+
+	if err := validateGroupShoppingItem(groupShoppingItem); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
+		return
+	}
+
+	groupShoppingItem.Amount = generateShoppingItemAmount(groupShoppingItem.Amount, groupShoppingItem.Quantity, groupShoppingItem.UnitPrice)
+
 	dbShoppingItem, err := h.GroupShoppingListRepo.GetGroupShoppingItem(groupShoppingItem.ID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	// The related transaction is taken from the stored shopping item, since it is linked by the transaction outbox
+	// after the transaction has been posted to account-rest-service.
+	if groupShoppingItem.RelatedTransactionData == nil || dbShoppingItem.RelatedTransactionData == nil || groupShoppingItem.RelatedTransactionData.ID != dbShoppingItem.RelatedTransactionData.ID {
+		groupShoppingItem.RelatedTransactionData = dbShoppingItem.RelatedTransactionData
+	}
+
+	transactionCommand, err := generateGroupTransactionCommand(groupShoppingItem, dbShoppingItem, groupID, userID, h.TimeManage.Now())
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if transactionCommand == nil {
+		if _, err := h.GroupShoppingListRepo.PutGroupShoppingItem(&groupShoppingItem); err != nil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+	} else {
+		if transactionCommand.Command == "delete" {
+			groupShoppingItem.RelatedTransactionData = nil
+		}
+
+		result, err := h.GroupShoppingListRepo.PutGroupShoppingItemWithTransactionCommand(&groupShoppingItem, transactionCommand)
+		if err != nil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		transactionCommandID, err := result.LastInsertId()
+		if err != nil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		transactionCommand.ID = int(transactionCommandID)
+
+		// The transaction command is delivered right away if possible, otherwise it is retried by the transaction outbox worker.
+		responseBody, err := deliverTransactionCommand(h, *transactionCommand)
+		if err != nil {
+			log.Println(err)
+		} else if len(responseBody) != 0 && transactionCommand.Command != "delete" {
+			if err := json.Unmarshal(responseBody, &groupShoppingItem.RelatedTransactionData); err != nil {
+				errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+				return
+			}
+		}
+	}
+
+	dbShoppingItem, err = h.GroupShoppingListRepo.GetGroupShoppingItem(groupShoppingItem.ID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -1538,14 +2163,14 @@ func (h *DBHandler) PutGroupShoppingItem(w http.ResponseWriter, r *http.Request)
 		CustomCategoryID: groupShoppingItem.CustomCategoryID,
 	}
 
-	categoriesNameBytes, err := getGroupShoppingItemCategoriesName(categoriesID, groupID)
+	categoriesNameBytes, err := getGroupShoppingItemCategoriesName(categoriesID, groupID, requestLocale(r.Context()))
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if err := json.Unmarshal(categoriesNameBytes, &groupShoppingItem); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -1561,45 +2186,45 @@ func (h *DBHandler) DeleteGroupShoppingItem(w http.ResponseWriter, r *http.Reque
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	groupShoppingItemID, err := strconv.Atoi(mux.Vars(r)["id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"ショッピングアイテムIDを正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"ショッピングアイテムIDを正しく指定してください。"}))
 		return
 	}
 
 	if err := h.GroupShoppingListRepo.DeleteGroupShoppingItem(groupShoppingItemID); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(&DeleteContentMsg{"ショッピングアイテムを削除しました。"}); err != nil {
+	if err := json.NewEncoder(w).Encode(&DeleteContentMsg{localizeMessage(r.Context(), "ショッピングアイテムを削除しました。")}); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -1607,19 +2232,255 @@ func (h *DBHandler) DeleteGroupShoppingItem(w http.ResponseWriter, r *http.Reque
 
 func (h *DBHandler) PutGroupShoppingListCustomCategoryIdToMediumCategoryId(w http.ResponseWriter, r *http.Request) {
 	categoriesID := struct {
-		MediumCategoryID int `json:"medium_category_id"`
-		CustomCategoryID int `json:"custom_category_id"`
+		MediumCategoryID        int             `json:"medium_category_id"`
+		CustomCategoryID        int             `json:"custom_category_id"`
+		ReplaceCustomCategoryID model.NullInt64 `json:"replace_custom_category_id"`
 	}{}
 
 	if err := json.NewDecoder(r.Body).Decode(&categoriesID); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if categoriesID.ReplaceCustomCategoryID.Valid {
+		if err := h.GroupShoppingListRepo.PutGroupShoppingListCustomCategoryIdToCustomCategoryId(int(categoriesID.ReplaceCustomCategoryID.Int64), categoriesID.CustomCategoryID); err != nil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
 		return
 	}
 
 	if err := h.GroupShoppingListRepo.PutGroupShoppingListCustomCategoryIdToMediumCategoryId(categoriesID.MediumCategoryID, categoriesID.CustomCategoryID); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *DBHandler) GetClaimedGroupShoppingList(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		return
+	}
+
+	if err := verifyGroupAffiliation(groupID, userID); err != nil {
+		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
+		if !ok {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		return
+	}
+
+	groupShoppingList, err := h.GroupShoppingListRepo.GetClaimedGroupShoppingList(groupID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if len(groupShoppingList.GroupShoppingList) != 0 {
+		groupShoppingList, err = generateGroupShoppingList(r.Context(), groupShoppingList, groupID, requestLocale(r.Context()))
+		if err != nil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+	}
+
+	claimedGroupShoppingList := generateClaimedGroupShoppingList(groupShoppingList)
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&claimedGroupShoppingList); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) ClaimGroupShoppingItem(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
+	if err := verifyGroupAffiliation(groupID, userID); err != nil {
+		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
+		if !ok {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		return
+	}
+
+	groupShoppingItemID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"ショッピングアイテムIDを正しく指定してください。"}))
+		return
+	}
+
+	var groupShoppingItemClaim model.GroupShoppingItemClaim
+	if err := json.NewDecoder(r.Body).Decode(&groupShoppingItemClaim); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if len(groupShoppingItemClaim.ClaimedUserID) == 0 {
+		groupShoppingItemClaim.ClaimedUserID = userID
+	} else if groupShoppingItemClaim.ClaimedUserID != userID {
+		if err := verifyGroupAffiliation(groupID, groupShoppingItemClaim.ClaimedUserID); err != nil {
+			if _, ok := err.(*BadRequestErrorMsg); !ok {
+				errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+				return
+			}
+
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"担当者はグループに所属するメンバーから選択してください。"}))
+			return
+		}
+	}
+
+	result, err := h.GroupShoppingListRepo.ClaimGroupShoppingItem(&groupShoppingItemClaim, groupShoppingItemID, groupID, h.TimeManage.Now())
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	// The claim is rejected when someone else holds the item, or the claim has changed since it was fetched.
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if rowsAffected == 0 {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusConflict, &ConflictErrorMsg{"この買い物リストは他のメンバーが担当しているか、既に更新されています。最新の買い物リストを確認してください。"}))
+		return
+	}
+
+	groupShoppingItem, err := h.GroupShoppingListRepo.GetGroupShoppingItem(groupShoppingItemID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	groupShoppingList, err := generateGroupShoppingList(r.Context(), model.GroupShoppingList{GroupShoppingList: []model.GroupShoppingItem{groupShoppingItem}}, groupID, requestLocale(r.Context()))
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&groupShoppingList.GroupShoppingList[0]); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) UnclaimGroupShoppingItem(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		return
+	}
+
+	if err := verifyGroupAffiliation(groupID, userID); err != nil {
+		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
+		if !ok {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		return
+	}
+
+	groupShoppingItemID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"ショッピングアイテムIDを正しく指定してください。"}))
+		return
+	}
+
+	var groupShoppingItemClaim model.GroupShoppingItemClaim
+	if err := json.NewDecoder(r.Body).Decode(&groupShoppingItemClaim); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	result, err := h.GroupShoppingListRepo.UnclaimGroupShoppingItem(groupShoppingItemClaim.ClaimVersion, userID, groupShoppingItemID, groupID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	// Only the member holding the claim can release it, and only while the claim is unchanged since it was fetched.
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if rowsAffected == 0 {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusConflict, &ConflictErrorMsg{"この買い物リストは他のメンバーが担当しているか、既に更新されています。最新の買い物リストを確認してください。"}))
+		return
+	}
+
+	groupShoppingItem, err := h.GroupShoppingListRepo.GetGroupShoppingItem(groupShoppingItemID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	groupShoppingList, err := generateGroupShoppingList(r.Context(), model.GroupShoppingList{GroupShoppingList: []model.GroupShoppingItem{groupShoppingItem}}, groupID, requestLocale(r.Context()))
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&groupShoppingList.GroupShoppingList[0]); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
 }
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
@@ -24,64 +25,16 @@ import (
 type MockGroupShoppingListRepository struct{}
 
 func (m MockGroupShoppingListRepository) GetGroupRegularShoppingList(groupID int) (model.GroupRegularShoppingList, error) {
-	if dbCounter == 1 {
-		atomic.AddInt64(&dbCounter, -1)
-
-		return model.GroupRegularShoppingList{
-			GroupRegularShoppingList: []model.GroupRegularShoppingItem{
-				{
-					ID:                   1,
-					PostedDate:           time.Date(2020, 12, 18, 14, 0, 0, 0, time.UTC),
-					UpdatedDate:          time.Date(2020, 12, 19, 20, 0, 0, 0, time.UTC),
-					ExpectedPurchaseDate: model.Date{Time: time.Date(2020, 12, 25, 0, 0, 0, 0, time.UTC)},
-					CycleType:            "monthly",
-					Cycle:                model.NullInt{Int: 0, Valid: false},
-					Purchase:             "米",
-					Shop:                 model.NullString{NullString: sql.NullString{String: "コストコ", Valid: true}},
-					Amount:               model.NullInt64{NullInt64: sql.NullInt64{Int64: 4000, Valid: true}},
-					BigCategoryID:        2,
-					BigCategoryName:      "",
-					MediumCategoryID:     model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
-					MediumCategoryName:   model.NullString{NullString: sql.NullString{String: "", Valid: false}},
-					CustomCategoryID:     model.NullInt64{NullInt64: sql.NullInt64{Int64: 1, Valid: true}},
-					CustomCategoryName:   model.NullString{NullString: sql.NullString{String: "", Valid: false}},
-					PaymentUserID:        model.NullString{NullString: sql.NullString{String: "userID1", Valid: true}},
-					TransactionAutoAdd:   true,
-				},
-				{
-					ID:                   2,
-					PostedDate:           time.Date(2020, 12, 18, 14, 0, 0, 0, time.UTC),
-					UpdatedDate:          time.Date(2020, 12, 19, 20, 0, 0, 0, time.UTC),
-					ExpectedPurchaseDate: model.Date{Time: time.Date(2020, 12, 25, 0, 0, 0, 0, time.UTC)},
-					CycleType:            "weekly",
-					Cycle:                model.NullInt{Int: 0, Valid: false},
-					Purchase:             "トイレットペーパー",
-					Shop:                 model.NullString{NullString: sql.NullString{String: "クリエイト", Valid: true}},
-					Amount:               model.NullInt64{NullInt64: sql.NullInt64{Int64: 300, Valid: true}},
-					BigCategoryID:        3,
-					BigCategoryName:      "",
-					MediumCategoryID:     model.NullInt64{NullInt64: sql.NullInt64{Int64: 13, Valid: true}},
-					MediumCategoryName:   model.NullString{NullString: sql.NullString{String: "", Valid: false}},
-					CustomCategoryID:     model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
-					CustomCategoryName:   model.NullString{NullString: sql.NullString{String: "", Valid: false}},
-					PaymentUserID:        model.NullString{NullString: sql.NullString{String: "userID1", Valid: true}},
-					TransactionAutoAdd:   true,
-				},
-			},
-		}, nil
-	}
-
-	atomic.AddInt64(&dbCounter, 1)
-
 	return model.GroupRegularShoppingList{
 		GroupRegularShoppingList: []model.GroupRegularShoppingItem{
 			{
 				ID:                   1,
 				PostedDate:           time.Date(2020, 12, 18, 14, 0, 0, 0, time.UTC),
-				UpdatedDate:          time.Date(2020, 12, 18, 14, 0, 0, 0, time.UTC),
+				UpdatedDate:          time.Date(2020, 12, 19, 20, 0, 0, 0, time.UTC),
 				ExpectedPurchaseDate: model.Date{Time: time.Date(2020, 12, 25, 0, 0, 0, 0, time.UTC)},
 				CycleType:            "monthly",
 				Cycle:                model.NullInt{Int: 0, Valid: false},
+				RecurrenceRule:       model.NullString{NullString: sql.NullString{String: "FREQ=MONTHLY", Valid: true}},
 				Purchase:             "米",
 				Shop:                 model.NullString{NullString: sql.NullString{String: "コストコ", Valid: true}},
 				Amount:               model.NullInt64{NullInt64: sql.NullInt64{Int64: 4000, Valid: true}},
@@ -97,10 +50,11 @@ func (m MockGroupShoppingListRepository) GetGroupRegularShoppingList(groupID int
 			{
 				ID:                   2,
 				PostedDate:           time.Date(2020, 12, 18, 14, 0, 0, 0, time.UTC),
-				UpdatedDate:          time.Date(2020, 12, 18, 14, 0, 0, 0, time.UTC),
-				ExpectedPurchaseDate: model.Date{Time: time.Date(2020, 12, 18, 0, 0, 0, 0, time.UTC)},
+				UpdatedDate:          time.Date(2020, 12, 19, 20, 0, 0, 0, time.UTC),
+				ExpectedPurchaseDate: model.Date{Time: time.Date(2020, 12, 25, 0, 0, 0, 0, time.UTC)},
 				CycleType:            "weekly",
 				Cycle:                model.NullInt{Int: 0, Valid: false},
+				RecurrenceRule:       model.NullString{NullString: sql.NullString{String: "FREQ=WEEKLY", Valid: true}},
 				Purchase:             "トイレットペーパー",
 				Shop:                 model.NullString{NullString: sql.NullString{String: "クリエイト", Valid: true}},
 				Amount:               model.NullInt64{NullInt64: sql.NullInt64{Int64: 300, Valid: true}},
@@ -125,6 +79,8 @@ func (m MockGroupShoppingListRepository) GetGroupRegularShoppingItem(groupRegula
 		ExpectedPurchaseDate: model.Date{Time: time.Date(2020, 9, 13, 0, 0, 0, 0, time.UTC)},
 		CycleType:            "weekly",
 		Cycle:                model.NullInt{Int: 0, Valid: false},
+		RecurrenceRule:       model.NullString{NullString: sql.NullString{String: "FREQ=WEEKLY", Valid: true}},
+		RecurrenceStartDate:  model.Date{Time: time.Date(2020, 9, 13, 0, 0, 0, 0, time.UTC)},
 		Purchase:             "トイレットペーパー",
 		Shop:                 model.NullString{NullString: sql.NullString{String: "クリエイト", Valid: true}},
 		Amount:               model.NullInt64{NullInt64: sql.NullInt64{Int64: 300, Valid: true}},
@@ -245,10 +201,45 @@ func (m MockGroupShoppingListRepository) PutGroupRegularShoppingList(groupRegula
 	return nil
 }
 
+func (m MockGroupShoppingListRepository) GetDueGroupRegularShoppingListGroupIDList(today time.Time) ([]int, error) {
+	return nil, nil
+}
+
 func (m MockGroupShoppingListRepository) DeleteGroupRegularShoppingItem(groupRegularShoppingItemID int) error {
 	return nil
 }
 
+func (m MockGroupShoppingListRepository) SkipGroupRegularShoppingItem(groupRegularShoppingItem *model.GroupRegularShoppingItem, skippedDateList []time.Time, groupID int, today time.Time) (bool, error) {
+	return true, nil
+}
+
+func (m MockGroupShoppingListRepository) PauseGroupRegularShoppingItem(groupRegularShoppingItem *model.GroupRegularShoppingItem, groupID int, today time.Time) (bool, error) {
+	return true, nil
+}
+
+func (m MockGroupShoppingListRepository) GetMonthlyGroupSkippedShoppingList(firstDay time.Time, lastDay time.Time, groupID int) (model.GroupSkippedShoppingList, error) {
+	return model.GroupSkippedShoppingList{
+		GroupSkippedShoppingList: []model.GroupSkippedShoppingItem{
+			{
+				ID:                    1,
+				ExpectedPurchaseDate:  model.Date{Time: time.Date(2020, 12, 13, 0, 0, 0, 0, time.UTC)},
+				SkipReason:            "skip",
+				Purchase:              "トイレットペーパー",
+				Shop:                  model.NullString{NullString: sql.NullString{String: "クリエイト", Valid: true}},
+				Amount:                model.NullInt64{NullInt64: sql.NullInt64{Int64: 300, Valid: true}},
+				BigCategoryID:         3,
+				BigCategoryName:       "",
+				MediumCategoryID:      model.NullInt64{NullInt64: sql.NullInt64{Int64: 13, Valid: true}},
+				MediumCategoryName:    model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+				CustomCategoryID:      model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
+				CustomCategoryName:    model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+				RegularShoppingListID: 1,
+				PaymentUserID:         model.NullString{NullString: sql.NullString{String: "userID1", Valid: true}},
+			},
+		},
+	}, nil
+}
+
 func (m MockGroupShoppingListRepository) GetDailyGroupShoppingListByDay(date time.Time, groupID int) (model.GroupShoppingList, error) {
 	return model.GroupShoppingList{
 		GroupShoppingList: []model.GroupShoppingItem{
@@ -445,7 +436,105 @@ func (m MockGroupShoppingListRepository) GetMonthlyGroupShoppingListByCategory(f
 	}, nil
 }
 
-func (m MockGroupShoppingListRepository) GetExpiredGroupShoppingList(dueDate time.Time, groupID int) (model.ExpiredGroupShoppingList, error) {
+func (m MockGroupShoppingListRepository) GetDailyGroupShoppingListByShop(date time.Time, groupID int) (model.GroupShoppingList, error) {
+	return model.GroupShoppingList{
+		GroupShoppingList: []model.GroupShoppingItem{
+			{
+				ID:                    1,
+				PostedDate:            time.Date(2020, 12, 18, 14, 0, 0, 0, time.UTC),
+				UpdatedDate:           time.Date(2020, 12, 18, 14, 0, 0, 0, time.UTC),
+				ExpectedPurchaseDate:  model.Date{Time: time.Date(2020, 12, 18, 0, 0, 0, 0, time.UTC)},
+				CompleteFlag:          true,
+				Purchase:              "米",
+				Shop:                  model.NullString{NullString: sql.NullString{String: "コストコ", Valid: true}},
+				Amount:                model.NullInt64{NullInt64: sql.NullInt64{Int64: 4000, Valid: true}},
+				BigCategoryID:         2,
+				BigCategoryName:       "",
+				MediumCategoryID:      model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
+				MediumCategoryName:    model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+				CustomCategoryID:      model.NullInt64{NullInt64: sql.NullInt64{Int64: 1, Valid: true}},
+				CustomCategoryName:    model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+				RegularShoppingListID: model.NullInt64{NullInt64: sql.NullInt64{Int64: 1, Valid: true}},
+				PaymentUserID:         model.NullString{NullString: sql.NullString{String: "userID1", Valid: true}},
+				TransactionAutoAdd:    true,
+				RelatedTransactionData: &model.GroupTransactionData{
+					ID: model.NullInt64{NullInt64: sql.NullInt64{Int64: 1, Valid: true}},
+				},
+			},
+			{
+				ID:                     2,
+				PostedDate:             time.Date(2020, 12, 18, 14, 0, 0, 0, time.UTC),
+				UpdatedDate:            time.Date(2020, 12, 18, 14, 0, 0, 0, time.UTC),
+				ExpectedPurchaseDate:   model.Date{Time: time.Date(2020, 12, 18, 0, 0, 0, 0, time.UTC)},
+				CompleteFlag:           false,
+				Purchase:               "トイレットペーパー",
+				Shop:                   model.NullString{NullString: sql.NullString{String: "コストコ", Valid: true}},
+				Amount:                 model.NullInt64{NullInt64: sql.NullInt64{Int64: 300, Valid: true}},
+				BigCategoryID:          3,
+				BigCategoryName:        "",
+				MediumCategoryID:       model.NullInt64{NullInt64: sql.NullInt64{Int64: 13, Valid: true}},
+				MediumCategoryName:     model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+				CustomCategoryID:       model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
+				CustomCategoryName:     model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+				RegularShoppingListID:  model.NullInt64{NullInt64: sql.NullInt64{Int64: 2, Valid: true}},
+				PaymentUserID:          model.NullString{NullString: sql.NullString{String: "userID1", Valid: true}},
+				TransactionAutoAdd:     true,
+				RelatedTransactionData: nil,
+			},
+		},
+	}, nil
+}
+
+func (m MockGroupShoppingListRepository) GetMonthlyGroupShoppingListByShop(firstDay time.Time, lastDay time.Time, groupID int) (model.GroupShoppingList, error) {
+	return model.GroupShoppingList{
+		GroupShoppingList: []model.GroupShoppingItem{
+			{
+				ID:                    1,
+				PostedDate:            time.Date(2020, 12, 18, 14, 0, 0, 0, time.UTC),
+				UpdatedDate:           time.Date(2020, 12, 18, 14, 0, 0, 0, time.UTC),
+				ExpectedPurchaseDate:  model.Date{Time: time.Date(2020, 12, 18, 0, 0, 0, 0, time.UTC)},
+				CompleteFlag:          true,
+				Purchase:              "米",
+				Shop:                  model.NullString{NullString: sql.NullString{String: "コストコ", Valid: true}},
+				Amount:                model.NullInt64{NullInt64: sql.NullInt64{Int64: 4000, Valid: true}},
+				BigCategoryID:         2,
+				BigCategoryName:       "",
+				MediumCategoryID:      model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
+				MediumCategoryName:    model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+				CustomCategoryID:      model.NullInt64{NullInt64: sql.NullInt64{Int64: 1, Valid: true}},
+				CustomCategoryName:    model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+				RegularShoppingListID: model.NullInt64{NullInt64: sql.NullInt64{Int64: 1, Valid: true}},
+				PaymentUserID:         model.NullString{NullString: sql.NullString{String: "userID1", Valid: true}},
+				TransactionAutoAdd:    true,
+				RelatedTransactionData: &model.GroupTransactionData{
+					ID: model.NullInt64{NullInt64: sql.NullInt64{Int64: 1, Valid: true}},
+				},
+			},
+			{
+				ID:                     2,
+				PostedDate:             time.Date(2020, 12, 18, 14, 0, 0, 0, time.UTC),
+				UpdatedDate:            time.Date(2020, 12, 18, 14, 0, 0, 0, time.UTC),
+				ExpectedPurchaseDate:   model.Date{Time: time.Date(2020, 12, 20, 0, 0, 0, 0, time.UTC)},
+				CompleteFlag:           false,
+				Purchase:               "トイレットペーパー",
+				Shop:                   model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+				Amount:                 model.NullInt64{NullInt64: sql.NullInt64{Int64: 300, Valid: true}},
+				BigCategoryID:          3,
+				BigCategoryName:        "",
+				MediumCategoryID:       model.NullInt64{NullInt64: sql.NullInt64{Int64: 13, Valid: true}},
+				MediumCategoryName:     model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+				CustomCategoryID:       model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
+				CustomCategoryName:     model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+				RegularShoppingListID:  model.NullInt64{NullInt64: sql.NullInt64{Int64: 2, Valid: true}},
+				PaymentUserID:          model.NullString{NullString: sql.NullString{String: "userID1", Valid: true}},
+				TransactionAutoAdd:     true,
+				RelatedTransactionData: nil,
+			},
+		},
+	}, nil
+}
+
+func (m MockGroupShoppingListRepository) GetExpiredGroupShoppingList(ctx context.Context, dueDate time.Time, groupID int) (model.ExpiredGroupShoppingList, error) {
 	return model.ExpiredGroupShoppingList{
 		ExpiredGroupShoppingList: []model.GroupShoppingItem{
 			{
@@ -513,28 +602,51 @@ func (m MockGroupShoppingListRepository) GetExpiredGroupShoppingList(dueDate tim
 }
 
 func (m MockGroupShoppingListRepository) GetGroupShoppingItem(groupShoppingItemID int) (model.GroupShoppingItem, error) {
+	if groupShoppingItemID == 3 {
+		return model.GroupShoppingItem{
+			ID:                     3,
+			PostedDate:             time.Date(2020, 12, 24, 16, 0, 0, 0, time.UTC),
+			UpdatedDate:            time.Date(2020, 12, 25, 9, 0, 0, 0, time.UTC),
+			ExpectedPurchaseDate:   model.Date{Time: time.Date(2020, 12, 25, 0, 0, 0, 0, time.UTC)},
+			CompleteFlag:           false,
+			Purchase:               "牛乳",
+			Shop:                   model.NullString{NullString: sql.NullString{String: "コストコ", Valid: true}},
+			Amount:                 model.NullInt64{NullInt64: sql.NullInt64{Int64: 200, Valid: true}},
+			BigCategoryID:          2,
+			BigCategoryName:        "",
+			MediumCategoryID:       model.NullInt64{NullInt64: sql.NullInt64{Int64: 6, Valid: true}},
+			MediumCategoryName:     model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+			CustomCategoryID:       model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
+			CustomCategoryName:     model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+			RegularShoppingListID:  model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
+			PaymentUserID:          model.NullString{NullString: sql.NullString{String: "userID1", Valid: true}},
+			ClaimedUserID:          model.NullString{NullString: sql.NullString{String: "userID2", Valid: true}},
+			ClaimedDate:            model.NullTime{NullTime: sql.NullTime{Time: time.Date(2020, 12, 25, 9, 0, 0, 0, time.UTC), Valid: true}},
+			TransactionAutoAdd:     true,
+			RelatedTransactionData: nil,
+		}, nil
+	}
+
 	if groupShoppingItemID == 2 {
 		return model.GroupShoppingItem{
-			ID:                    1,
-			PostedDate:            time.Date(2020, 12, 14, 16, 0, 0, 0, time.UTC),
-			UpdatedDate:           time.Date(2020, 12, 15, 16, 0, 0, 0, time.UTC),
-			ExpectedPurchaseDate:  model.Date{Time: time.Date(2020, 12, 15, 0, 0, 0, 0, time.UTC)},
-			CompleteFlag:          true,
-			Purchase:              "鶏肉3kg",
-			Shop:                  model.NullString{NullString: sql.NullString{String: "コストコ", Valid: true}},
-			Amount:                model.NullInt64{NullInt64: sql.NullInt64{Int64: 1000, Valid: true}},
-			BigCategoryID:         2,
-			BigCategoryName:       "",
-			MediumCategoryID:      model.NullInt64{NullInt64: sql.NullInt64{Int64: 6, Valid: true}},
-			MediumCategoryName:    model.NullString{NullString: sql.NullString{String: "", Valid: false}},
-			CustomCategoryID:      model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
-			CustomCategoryName:    model.NullString{NullString: sql.NullString{String: "", Valid: false}},
-			RegularShoppingListID: model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
-			PaymentUserID:         model.NullString{NullString: sql.NullString{String: "userID1", Valid: true}},
-			TransactionAutoAdd:    true,
-			RelatedTransactionData: &model.GroupTransactionData{
-				ID: model.NullInt64{NullInt64: sql.NullInt64{Int64: 1, Valid: true}},
-			},
+			ID:                     1,
+			PostedDate:             time.Date(2020, 12, 14, 16, 0, 0, 0, time.UTC),
+			UpdatedDate:            time.Date(2020, 12, 15, 16, 0, 0, 0, time.UTC),
+			ExpectedPurchaseDate:   model.Date{Time: time.Date(2020, 12, 15, 0, 0, 0, 0, time.UTC)},
+			CompleteFlag:           true,
+			Purchase:               "鶏肉3kg",
+			Shop:                   model.NullString{NullString: sql.NullString{String: "コストコ", Valid: true}},
+			Amount:                 model.NullInt64{NullInt64: sql.NullInt64{Int64: 1000, Valid: true}},
+			BigCategoryID:          2,
+			BigCategoryName:        "",
+			MediumCategoryID:       model.NullInt64{NullInt64: sql.NullInt64{Int64: 6, Valid: true}},
+			MediumCategoryName:     model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+			CustomCategoryID:       model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
+			CustomCategoryName:     model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+			RegularShoppingListID:  model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
+			PaymentUserID:          model.NullString{NullString: sql.NullString{String: "userID1", Valid: true}},
+			TransactionAutoAdd:     true,
+			RelatedTransactionData: nil,
 		}, nil
 	}
 
@@ -560,6 +672,50 @@ func (m MockGroupShoppingListRepository) GetGroupShoppingItem(groupShoppingItemI
 	}, nil
 }
 
+func (m MockGroupShoppingListRepository) GetGroupShoppingItemPriceList(groupID int) ([]model.GroupShoppingItemPrice, error) {
+	return []model.GroupShoppingItemPrice{
+		{
+			GroupShoppingItemID: 8,
+			PurchaseDate:        model.Date{Time: time.Date(2020, 12, 10, 0, 0, 0, 0, time.UTC)},
+			Purchase:            "鶏肉3kg",
+			Shop:                model.NullString{NullString: sql.NullString{String: "コストコ", Valid: true}},
+			Amount:              model.NullInt64{NullInt64: sql.NullInt64{Int64: 1000, Valid: true}},
+			PaymentUserID:       model.NullString{NullString: sql.NullString{String: "userID1", Valid: true}},
+			RelatedTransactionData: &model.GroupTransactionData{
+				ID: model.NullInt64{NullInt64: sql.NullInt64{Int64: 3, Valid: true}},
+			},
+		},
+		{
+			GroupShoppingItemID: 6,
+			PurchaseDate:        model.Date{Time: time.Date(2020, 11, 20, 0, 0, 0, 0, time.UTC)},
+			Purchase:            "鶏肉３ｋｇ",
+			Shop:                model.NullString{NullString: sql.NullString{String: "コストコ", Valid: true}},
+			Amount:              model.NullInt64{NullInt64: sql.NullInt64{Int64: 1100, Valid: true}},
+			PaymentUserID:       model.NullString{NullString: sql.NullString{String: "userID2", Valid: true}},
+		},
+		{
+			GroupShoppingItemID: 5,
+			PurchaseDate:        model.Date{Time: time.Date(2020, 11, 5, 0, 0, 0, 0, time.UTC)},
+			Purchase:            "鶏肉3kg",
+			Shop:                model.NullString{NullString: sql.NullString{String: "業務スーパー", Valid: true}},
+			Amount:              model.NullInt64{NullInt64: sql.NullInt64{Int64: 900, Valid: true}},
+			PaymentUserID:       model.NullString{NullString: sql.NullString{String: "userID1", Valid: true}},
+		},
+		{
+			GroupShoppingItemID: 4,
+			PurchaseDate:        model.Date{Time: time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)},
+			Purchase:            "牛乳",
+			Shop:                model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+			Amount:              model.NullInt64{NullInt64: sql.NullInt64{Int64: 200, Valid: true}},
+			PaymentUserID:       model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+		},
+	}, nil
+}
+
+func (m MockGroupShoppingListRepository) GetGroupShoppingItemPriceListByPurchase(groupID int, normalizedPurchase string, limit int) ([]model.GroupShoppingItemPrice, error) {
+	return m.GetGroupShoppingItemPriceList(groupID)
+}
+
 func (m MockGroupShoppingListRepository) PostGroupShoppingItem(groupShoppingItem *model.GroupShoppingItem, groupID int) (sql.Result, error) {
 	return MockSqlResult{}, nil
 }
@@ -568,6 +724,69 @@ func (m MockGroupShoppingListRepository) PutGroupShoppingItem(groupShoppingItem
 	return MockSqlResult{}, nil
 }
 
+func (m MockGroupShoppingListRepository) PutGroupShoppingItemWithTransactionCommand(groupShoppingItem *model.GroupShoppingItem, transactionCommand *model.TransactionCommand) (sql.Result, error) {
+	return MockSqlResult{}, nil
+}
+
+func (m MockGroupShoppingListRepository) ClaimGroupShoppingItem(groupShoppingItemClaim *model.GroupShoppingItemClaim, groupShoppingItemID int, groupID int, claimedDate time.Time) (sql.Result, error) {
+	return MockSqlResult{}, nil
+}
+
+func (m MockGroupShoppingListRepository) UnclaimGroupShoppingItem(claimVersion int, claimedUserID string, groupShoppingItemID int, groupID int) (sql.Result, error) {
+	return MockSqlResult{}, nil
+}
+
+func (m MockGroupShoppingListRepository) GetClaimedGroupShoppingList(groupID int) (model.GroupShoppingList, error) {
+	return model.GroupShoppingList{
+		GroupShoppingList: []model.GroupShoppingItem{
+			{
+				ID:                     2,
+				PostedDate:             time.Date(2020, 12, 18, 14, 0, 0, 0, time.UTC),
+				UpdatedDate:            time.Date(2020, 12, 19, 9, 0, 0, 0, time.UTC),
+				ExpectedPurchaseDate:   model.Date{Time: time.Date(2020, 12, 20, 0, 0, 0, 0, time.UTC)},
+				CompleteFlag:           false,
+				Purchase:               "トイレットペーパー",
+				Shop:                   model.NullString{NullString: sql.NullString{String: "クリエイト", Valid: true}},
+				Amount:                 model.NullInt64{NullInt64: sql.NullInt64{Int64: 300, Valid: true}},
+				BigCategoryID:          3,
+				BigCategoryName:        "",
+				MediumCategoryID:       model.NullInt64{NullInt64: sql.NullInt64{Int64: 13, Valid: true}},
+				MediumCategoryName:     model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+				CustomCategoryID:       model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
+				CustomCategoryName:     model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+				RegularShoppingListID:  model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
+				PaymentUserID:          model.NullString{NullString: sql.NullString{String: "userID1", Valid: true}},
+				ClaimedUserID:          model.NullString{NullString: sql.NullString{String: "userID1", Valid: true}},
+				ClaimedDate:            model.NullTime{NullTime: sql.NullTime{Time: time.Date(2020, 12, 19, 9, 0, 0, 0, time.UTC), Valid: true}},
+				TransactionAutoAdd:     true,
+				RelatedTransactionData: nil,
+			},
+			{
+				ID:                     3,
+				PostedDate:             time.Date(2020, 12, 24, 16, 0, 0, 0, time.UTC),
+				UpdatedDate:            time.Date(2020, 12, 25, 9, 0, 0, 0, time.UTC),
+				ExpectedPurchaseDate:   model.Date{Time: time.Date(2020, 12, 25, 0, 0, 0, 0, time.UTC)},
+				CompleteFlag:           false,
+				Purchase:               "牛乳",
+				Shop:                   model.NullString{NullString: sql.NullString{String: "コストコ", Valid: true}},
+				Amount:                 model.NullInt64{NullInt64: sql.NullInt64{Int64: 200, Valid: true}},
+				BigCategoryID:          2,
+				BigCategoryName:        "",
+				MediumCategoryID:       model.NullInt64{NullInt64: sql.NullInt64{Int64: 6, Valid: true}},
+				MediumCategoryName:     model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+				CustomCategoryID:       model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
+				CustomCategoryName:     model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+				RegularShoppingListID:  model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
+				PaymentUserID:          model.NullString{NullString: sql.NullString{String: "userID1", Valid: true}},
+				ClaimedUserID:          model.NullString{NullString: sql.NullString{String: "userID2", Valid: true}},
+				ClaimedDate:            model.NullTime{NullTime: sql.NullTime{Time: time.Date(2020, 12, 25, 9, 0, 0, 0, time.UTC), Valid: true}},
+				TransactionAutoAdd:     true,
+				RelatedTransactionData: nil,
+			},
+		},
+	}, nil
+}
+
 func (m MockGroupShoppingListRepository) DeleteGroupShoppingItem(groupShoppingItemID int) error {
 	return nil
 }
@@ -576,6 +795,103 @@ func (m MockGroupShoppingListRepository) PutGroupShoppingListCustomCategoryIdToM
 	return nil
 }
 
+func (m MockGroupShoppingListRepository) PutGroupShoppingListCustomCategoryIdToCustomCategoryId(replaceCustomCategoryID int, customCategoryID int) error {
+	return nil
+}
+
+func (m MockGroupShoppingListRepository) GetTransactionRelatedGroupShoppingList(groupID int) (model.GroupShoppingList, error) {
+	return model.GroupShoppingList{
+		GroupShoppingList: []model.GroupShoppingItem{
+			{
+				ID:                   1,
+				PostedDate:           time.Date(2020, 12, 13, 16, 0, 0, 0, time.UTC),
+				UpdatedDate:          time.Date(2020, 12, 15, 16, 0, 0, 0, time.UTC),
+				ExpectedPurchaseDate: model.Date{Time: time.Date(2020, 12, 15, 0, 0, 0, 0, time.UTC)},
+				CompleteFlag:         true,
+				Purchase:             "鶏肉3kg",
+				Shop:                 model.NullString{NullString: sql.NullString{String: "コストコ", Valid: true}},
+				Amount:               model.NullInt64{NullInt64: sql.NullInt64{Int64: 1000, Valid: true}},
+				BigCategoryID:        2,
+				MediumCategoryID:     model.NullInt64{NullInt64: sql.NullInt64{Int64: 6, Valid: true}},
+				CustomCategoryID:     model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
+				PaymentUserID:        model.NullString{NullString: sql.NullString{String: "userID1", Valid: true}},
+				TransactionAutoAdd:   true,
+				RelatedTransactionData: &model.GroupTransactionData{
+					ID: model.NullInt64{NullInt64: sql.NullInt64{Int64: 1, Valid: true}},
+				},
+			},
+			{
+				ID:                     2,
+				PostedDate:             time.Date(2020, 12, 13, 16, 0, 0, 0, time.UTC),
+				UpdatedDate:            time.Date(2020, 12, 15, 16, 0, 0, 0, time.UTC),
+				ExpectedPurchaseDate:   model.Date{Time: time.Date(2020, 12, 15, 0, 0, 0, 0, time.UTC)},
+				CompleteFlag:           true,
+				Purchase:               "鶏肉3kg",
+				Shop:                   model.NullString{NullString: sql.NullString{String: "コストコ", Valid: true}},
+				Amount:                 model.NullInt64{NullInt64: sql.NullInt64{Int64: 1000, Valid: true}},
+				BigCategoryID:          2,
+				MediumCategoryID:       model.NullInt64{NullInt64: sql.NullInt64{Int64: 6, Valid: true}},
+				CustomCategoryID:       model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
+				PaymentUserID:          model.NullString{NullString: sql.NullString{String: "userID1", Valid: true}},
+				TransactionAutoAdd:     true,
+				RelatedTransactionData: nil,
+			},
+			{
+				ID:                   3,
+				PostedDate:           time.Date(2020, 12, 13, 16, 0, 0, 0, time.UTC),
+				UpdatedDate:          time.Date(2020, 12, 15, 16, 0, 0, 0, time.UTC),
+				ExpectedPurchaseDate: model.Date{Time: time.Date(2020, 12, 15, 0, 0, 0, 0, time.UTC)},
+				CompleteFlag:         false,
+				Purchase:             "鶏肉3kg",
+				Shop:                 model.NullString{NullString: sql.NullString{String: "コストコ", Valid: true}},
+				Amount:               model.NullInt64{NullInt64: sql.NullInt64{Int64: 1000, Valid: true}},
+				BigCategoryID:        2,
+				MediumCategoryID:     model.NullInt64{NullInt64: sql.NullInt64{Int64: 6, Valid: true}},
+				CustomCategoryID:     model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
+				PaymentUserID:        model.NullString{NullString: sql.NullString{String: "userID1", Valid: true}},
+				TransactionAutoAdd:   true,
+				RelatedTransactionData: &model.GroupTransactionData{
+					ID: model.NullInt64{NullInt64: sql.NullInt64{Int64: 3, Valid: true}},
+				},
+			},
+			{
+				ID:                   4,
+				PostedDate:           time.Date(2020, 12, 13, 16, 0, 0, 0, time.UTC),
+				UpdatedDate:          time.Date(2020, 12, 15, 16, 0, 0, 0, time.UTC),
+				ExpectedPurchaseDate: model.Date{Time: time.Date(2020, 12, 15, 0, 0, 0, 0, time.UTC)},
+				CompleteFlag:         true,
+				Purchase:             "鶏肉3kg",
+				Shop:                 model.NullString{NullString: sql.NullString{String: "コストコ", Valid: true}},
+				Amount:               model.NullInt64{NullInt64: sql.NullInt64{Int64: 1000, Valid: true}},
+				BigCategoryID:        2,
+				MediumCategoryID:     model.NullInt64{NullInt64: sql.NullInt64{Int64: 6, Valid: true}},
+				CustomCategoryID:     model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
+				PaymentUserID:        model.NullString{NullString: sql.NullString{String: "userID1", Valid: true}},
+				TransactionAutoAdd:   true,
+				RelatedTransactionData: &model.GroupTransactionData{
+					ID: model.NullInt64{NullInt64: sql.NullInt64{Int64: 4, Valid: true}},
+				},
+			},
+			{
+				ID:                     5,
+				PostedDate:             time.Date(2020, 12, 13, 16, 0, 0, 0, time.UTC),
+				UpdatedDate:            time.Date(2020, 12, 15, 16, 0, 0, 0, time.UTC),
+				ExpectedPurchaseDate:   model.Date{Time: time.Date(2020, 12, 15, 0, 0, 0, 0, time.UTC)},
+				CompleteFlag:           true,
+				Purchase:               "鶏肉3kg",
+				Shop:                   model.NullString{NullString: sql.NullString{String: "コストコ", Valid: true}},
+				Amount:                 model.NullInt64{NullInt64: sql.NullInt64{Int64: 1000, Valid: true}},
+				BigCategoryID:          2,
+				MediumCategoryID:       model.NullInt64{NullInt64: sql.NullInt64{Int64: 6, Valid: true}},
+				CustomCategoryID:       model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
+				PaymentUserID:          model.NullString{NullString: sql.NullString{String: "userID1", Valid: true}},
+				TransactionAutoAdd:     true,
+				RelatedTransactionData: nil,
+			},
+		},
+	}, nil
+}
+
 func TestDBHandler_GetDailyGroupShoppingDataByDay(t *testing.T) {
 	accountHostURL := fmt.Sprintf("%s:%d", config.Env.AccountApi.Host, config.Env.AccountApi.Port)
 
@@ -858,7 +1174,7 @@ func TestDBHandler_GetMonthlyGroupShoppingDataByDay(t *testing.T) {
 				},
 			}
 		} else if serverCounter == 1 {
-			atomic.AddInt64(&serverCounter, -1)
+			atomic.AddInt64(&serverCounter, 1)
 
 			mockCategoriesNameList = []MockCategoriesName{
 				{
@@ -872,6 +1188,16 @@ func TestDBHandler_GetMonthlyGroupShoppingDataByDay(t *testing.T) {
 					CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
 				},
 			}
+		} else if serverCounter == 2 {
+			atomic.AddInt64(&serverCounter, -2)
+
+			mockCategoriesNameList = []MockCategoriesName{
+				{
+					BigCategoryName:    model.NullString{NullString: sql.NullString{String: "日用品", Valid: true}},
+					MediumCategoryName: model.NullString{NullString: sql.NullString{String: "消耗品", Valid: true}},
+					CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+				},
+			}
 		}
 
 		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
@@ -963,7 +1289,7 @@ func TestDBHandler_GetMonthlyGroupShoppingDataByDay(t *testing.T) {
 	defer res.Body.Close()
 
 	testutil.AssertResponseHeader(t, res, http.StatusOK)
-	testutil.AssertResponseBody(t, res, &model.GroupShoppingDataByDay{}, &model.GroupShoppingDataByDay{})
+	testutil.AssertResponseBody(t, res, &model.MonthlyGroupShoppingDataByDay{}, &model.MonthlyGroupShoppingDataByDay{})
 }
 
 func TestDBHandler_GetMonthlyGroupShoppingDataByCategory(t *testing.T) {
@@ -1096,12 +1422,274 @@ func TestDBHandler_GetMonthlyGroupShoppingDataByCategory(t *testing.T) {
 	testutil.AssertResponseBody(t, res, &model.GroupShoppingDataByCategory{}, &model.GroupShoppingDataByCategory{})
 }
 
-func TestDBHandler_GetExpiredGroupShoppingList(t *testing.T) {
+func TestDBHandler_GetDailyGroupShoppingDataByShop(t *testing.T) {
 	accountHostURL := fmt.Sprintf("%s:%d", config.Env.AccountApi.Host, config.Env.AccountApi.Port)
 
 	mockGetGroupShoppingItemCategoriesNameList := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		mockCategoriesNameList := []MockCategoriesName{
-			{
+		var mockCategoriesNameList []MockCategoriesName
+
+		if serverCounter == 0 {
+			atomic.AddInt64(&serverCounter, 1)
+
+			mockCategoriesNameList = []MockCategoriesName{
+				{
+					BigCategoryName:    model.NullString{NullString: sql.NullString{String: "食費", Valid: true}},
+					MediumCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+					CustomCategoryName: model.NullString{NullString: sql.NullString{String: "米", Valid: true}},
+				},
+				{
+					BigCategoryName:    model.NullString{NullString: sql.NullString{String: "日用品", Valid: true}},
+					MediumCategoryName: model.NullString{NullString: sql.NullString{String: "消耗品", Valid: true}},
+					CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+				},
+			}
+		} else if serverCounter == 1 {
+			atomic.AddInt64(&serverCounter, -1)
+
+			mockCategoriesNameList = []MockCategoriesName{
+				{
+					BigCategoryName:    model.NullString{NullString: sql.NullString{String: "食費", Valid: true}},
+					MediumCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+					CustomCategoryName: model.NullString{NullString: sql.NullString{String: "米", Valid: true}},
+				},
+				{
+					BigCategoryName:    model.NullString{NullString: sql.NullString{String: "日用品", Valid: true}},
+					MediumCategoryName: model.NullString{NullString: sql.NullString{String: "消耗品", Valid: true}},
+					CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+				},
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(&mockCategoriesNameList); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	})
+
+	mockGetGroupShoppingItemRelatedTransactionDataList := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		groupShoppingItemRelatedTransactionDataList := []*model.GroupTransactionData{
+			{
+				ID:                 model.NullInt64{NullInt64: sql.NullInt64{Int64: 1, Valid: true}},
+				TransactionType:    "expense",
+				PostedDate:         time.Date(2020, 12, 18, 14, 0, 0, 0, time.UTC),
+				UpdatedDate:        time.Date(2020, 12, 18, 14, 0, 0, 0, time.UTC),
+				TransactionDate:    "2020/12/18(金)",
+				Shop:               model.NullString{NullString: sql.NullString{String: "コストコ", Valid: true}},
+				Memo:               model.NullString{NullString: sql.NullString{String: "【買い物リスト】米", Valid: true}},
+				Amount:             4000,
+				PostedUserID:       "userID1",
+				UpdatedUserID:      model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+				PaymentUserID:      "userID1",
+				BigCategoryID:      2,
+				BigCategoryName:    "食費",
+				MediumCategoryID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
+				MediumCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+				CustomCategoryID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 1, Valid: true}},
+				CustomCategoryName: model.NullString{NullString: sql.NullString{String: "米", Valid: true}},
+			},
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(&groupShoppingItemRelatedTransactionDataList); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/groups/{group_id:[0-9]+}/categories/names", mockGetGroupShoppingItemCategoriesNameList).Methods("GET")
+	router.HandleFunc("/groups/{group_id:[0-9]+}/transactions/related-shopping-list", mockGetGroupShoppingItemRelatedTransactionDataList).Methods("GET")
+
+	listener, err := net.Listen("tcp", accountHostURL)
+	if err != nil {
+		t.Fatalf("unexpected error by net.Listen() '%#v'", err)
+	}
+
+	ts := httptest.Server{
+		Listener: listener,
+		Config:   &http.Server{Handler: router},
+	}
+
+	ts.Start()
+	defer ts.Close()
+
+	h := DBHandler{
+		AuthRepo:              MockAuthRepository{},
+		ShoppingListRepo:      MockShoppingListRepository{},
+		GroupShoppingListRepo: MockGroupShoppingListRepository{},
+		TimeManage:            MockTime{},
+	}
+
+	r := httptest.NewRequest("GET", "/groups/1/shopping-list/2020-12-18/shops", nil)
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"group_id": "1",
+		"date":     "2020-12-18",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	dbMu.Lock()
+	defer dbMu.Unlock()
+
+	serverMu.Lock()
+	defer serverMu.Unlock()
+
+	h.GetDailyGroupShoppingDataByShop(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &model.GroupShoppingDataByShop{}, &model.GroupShoppingDataByShop{})
+}
+
+func TestDBHandler_GetMonthlyGroupShoppingDataByShop(t *testing.T) {
+	accountHostURL := fmt.Sprintf("%s:%d", config.Env.AccountApi.Host, config.Env.AccountApi.Port)
+
+	mockGetGroupShoppingItemCategoriesNameList := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var mockCategoriesNameList []MockCategoriesName
+
+		if serverCounter == 0 {
+			atomic.AddInt64(&serverCounter, 1)
+
+			mockCategoriesNameList = []MockCategoriesName{
+				{
+					BigCategoryName:    model.NullString{NullString: sql.NullString{String: "食費", Valid: true}},
+					MediumCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+					CustomCategoryName: model.NullString{NullString: sql.NullString{String: "米", Valid: true}},
+				},
+				{
+					BigCategoryName:    model.NullString{NullString: sql.NullString{String: "日用品", Valid: true}},
+					MediumCategoryName: model.NullString{NullString: sql.NullString{String: "消耗品", Valid: true}},
+					CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+				},
+			}
+		} else if serverCounter == 1 {
+			atomic.AddInt64(&serverCounter, -1)
+
+			mockCategoriesNameList = []MockCategoriesName{
+				{
+					BigCategoryName:    model.NullString{NullString: sql.NullString{String: "食費", Valid: true}},
+					MediumCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+					CustomCategoryName: model.NullString{NullString: sql.NullString{String: "米", Valid: true}},
+				},
+				{
+					BigCategoryName:    model.NullString{NullString: sql.NullString{String: "日用品", Valid: true}},
+					MediumCategoryName: model.NullString{NullString: sql.NullString{String: "消耗品", Valid: true}},
+					CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+				},
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(&mockCategoriesNameList); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	})
+
+	mockGetGroupShoppingItemRelatedTransactionDataList := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		groupShoppingItemRelatedTransactionDataList := []*model.GroupTransactionData{
+			{
+				ID:                 model.NullInt64{NullInt64: sql.NullInt64{Int64: 1, Valid: true}},
+				TransactionType:    "expense",
+				PostedDate:         time.Date(2020, 12, 18, 14, 0, 0, 0, time.UTC),
+				UpdatedDate:        time.Date(2020, 12, 18, 14, 0, 0, 0, time.UTC),
+				TransactionDate:    "2020/12/18(金)",
+				Shop:               model.NullString{NullString: sql.NullString{String: "コストコ", Valid: true}},
+				Memo:               model.NullString{NullString: sql.NullString{String: "【買い物リスト】米", Valid: true}},
+				Amount:             4000,
+				PostedUserID:       "userID1",
+				UpdatedUserID:      model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+				PaymentUserID:      "userID1",
+				BigCategoryID:      2,
+				BigCategoryName:    "食費",
+				MediumCategoryID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
+				MediumCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+				CustomCategoryID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 1, Valid: true}},
+				CustomCategoryName: model.NullString{NullString: sql.NullString{String: "米", Valid: true}},
+			},
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(&groupShoppingItemRelatedTransactionDataList); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/groups/{group_id:[0-9]+}/categories/names", mockGetGroupShoppingItemCategoriesNameList).Methods("GET")
+	router.HandleFunc("/groups/{group_id:[0-9]+}/transactions/related-shopping-list", mockGetGroupShoppingItemRelatedTransactionDataList).Methods("GET")
+
+	listener, err := net.Listen("tcp", accountHostURL)
+	if err != nil {
+		t.Fatalf("unexpected error by net.Listen() '%#v'", err)
+	}
+
+	ts := httptest.Server{
+		Listener: listener,
+		Config:   &http.Server{Handler: router},
+	}
+
+	ts.Start()
+	defer ts.Close()
+
+	h := DBHandler{
+		AuthRepo:              MockAuthRepository{},
+		ShoppingListRepo:      MockShoppingListRepository{},
+		GroupShoppingListRepo: MockGroupShoppingListRepository{},
+		TimeManage:            MockTime{},
+	}
+
+	r := httptest.NewRequest("GET", "/groups/1/shopping-list/2020-12/shops", nil)
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"group_id":   "1",
+		"year_month": "2020-12",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	dbMu.Lock()
+	defer dbMu.Unlock()
+
+	serverMu.Lock()
+	defer serverMu.Unlock()
+
+	h.GetMonthlyGroupShoppingDataByShop(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &model.GroupShoppingDataByShop{}, &model.GroupShoppingDataByShop{})
+}
+
+func TestDBHandler_GetExpiredGroupShoppingList(t *testing.T) {
+	accountHostURL := fmt.Sprintf("%s:%d", config.Env.AccountApi.Host, config.Env.AccountApi.Port)
+
+	mockGetGroupShoppingItemCategoriesNameList := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mockCategoriesNameList := []MockCategoriesName{
+			{
 				BigCategoryName:    model.NullString{NullString: sql.NullString{String: "食費", Valid: true}},
 				MediumCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
 				CustomCategoryName: model.NullString{NullString: sql.NullString{String: "米", Valid: true}},
@@ -1120,14 +1708,680 @@ func TestDBHandler_GetExpiredGroupShoppingList(t *testing.T) {
 
 		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 		w.WriteHeader(http.StatusOK)
-		if err := json.NewEncoder(w).Encode(&mockCategoriesNameList); err != nil {
+		if err := json.NewEncoder(w).Encode(&mockCategoriesNameList); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/groups/{group_id:[0-9]+}/categories/names", mockGetGroupShoppingItemCategoriesNameList).Methods("GET")
+
+	listener, err := net.Listen("tcp", accountHostURL)
+	if err != nil {
+		t.Fatalf("unexpected error by net.Listen() '%#v'", err)
+	}
+
+	ts := httptest.Server{
+		Listener: listener,
+		Config:   &http.Server{Handler: router},
+	}
+
+	ts.Start()
+	defer ts.Close()
+
+	h := DBHandler{
+		AuthRepo:              MockAuthRepository{},
+		GroupShoppingListRepo: MockGroupShoppingListRepository{},
+		TimeManage:            MockTime{},
+	}
+
+	r := httptest.NewRequest("GET", "/groups/1/shopping-list/expired", nil)
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"group_id": "1",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	dbMu.Lock()
+	defer dbMu.Unlock()
+
+	serverMu.Lock()
+	defer serverMu.Unlock()
+
+	h.GetExpiredGroupShoppingList(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &model.ExpiredGroupShoppingList{}, &model.ExpiredGroupShoppingList{})
+}
+
+func TestDBHandler_GetGroupShoppingItemPriceHistoryList(t *testing.T) {
+	accountHostURL := fmt.Sprintf("%s:%d", config.Env.AccountApi.Host, config.Env.AccountApi.Port)
+
+	mockGetGroupShoppingItemRelatedTransactionDataList := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		groupShoppingItemRelatedTransactionDataList := []*model.GroupTransactionData{
+			{
+				ID:                 model.NullInt64{NullInt64: sql.NullInt64{Int64: 3, Valid: true}},
+				TransactionType:    "expense",
+				PostedDate:         time.Date(2020, 12, 10, 14, 0, 0, 0, time.UTC),
+				UpdatedDate:        time.Date(2020, 12, 10, 14, 0, 0, 0, time.UTC),
+				TransactionDate:    "2020/12/10(木)",
+				Shop:               model.NullString{NullString: sql.NullString{String: "コストコ", Valid: true}},
+				Memo:               model.NullString{NullString: sql.NullString{String: "【買い物リスト】鶏肉3kg", Valid: true}},
+				Amount:             950,
+				PostedUserID:       "userID1",
+				UpdatedUserID:      model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+				PaymentUserID:      "userID1",
+				BigCategoryID:      2,
+				BigCategoryName:    "食費",
+				MediumCategoryID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 6, Valid: true}},
+				MediumCategoryName: model.NullString{NullString: sql.NullString{String: "食料品", Valid: true}},
+				CustomCategoryID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
+				CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+			},
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(&groupShoppingItemRelatedTransactionDataList); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/groups/{group_id:[0-9]+}/transactions/related-shopping-list", mockGetGroupShoppingItemRelatedTransactionDataList).Methods("GET")
+
+	listener, err := net.Listen("tcp", accountHostURL)
+	if err != nil {
+		t.Fatalf("unexpected error by net.Listen() '%#v'", err)
+	}
+
+	ts := httptest.Server{
+		Listener: listener,
+		Config:   &http.Server{Handler: router},
+	}
+
+	ts.Start()
+	defer ts.Close()
+
+	h := DBHandler{
+		AuthRepo:              MockAuthRepository{},
+		GroupShoppingListRepo: MockGroupShoppingListRepository{},
+	}
+
+	r := httptest.NewRequest("GET", "/groups/1/shopping-list/prices?purchase=鶏肉", nil)
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"group_id": "1",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.GetGroupShoppingItemPriceHistoryList(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &model.GroupShoppingItemPriceHistoryList{}, &model.GroupShoppingItemPriceHistoryList{})
+}
+
+func TestDBHandler_PostGroupRegularShoppingItem(t *testing.T) {
+	accountHostURL := fmt.Sprintf("%s:%d", config.Env.AccountApi.Host, config.Env.AccountApi.Port)
+
+	mockGetGroupShoppingItemCategoriesName := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mockCategoriesName := MockCategoriesName{
+			BigCategoryName:    model.NullString{NullString: sql.NullString{String: "日用品", Valid: true}},
+			MediumCategoryName: model.NullString{NullString: sql.NullString{String: "消耗品", Valid: true}},
+			CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(&mockCategoriesName); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/groups/{group_id:[0-9]+}/categories/name", mockGetGroupShoppingItemCategoriesName).Methods("GET")
+
+	listener, err := net.Listen("tcp", accountHostURL)
+	if err != nil {
+		t.Fatalf("unexpected error by net.Listen() '%#v'", err)
+	}
+
+	ts := httptest.Server{
+		Listener: listener,
+		Config:   &http.Server{Handler: router},
+	}
+
+	ts.Start()
+	defer ts.Close()
+
+	h := DBHandler{
+		AuthRepo:              MockAuthRepository{},
+		GroupShoppingListRepo: MockGroupShoppingListRepository{},
+		TimeManage:            MockTime{},
+	}
+
+	r := httptest.NewRequest("POST", "/groups/1/shopping-list/regular", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"group_id": "1",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.PostGroupRegularShoppingItem(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusCreated)
+	testutil.AssertResponseBody(t, res,
+		&struct {
+			GroupRegularShoppingItem model.GroupRegularShoppingItem `json:"regular_shopping_item"`
+			model.GroupShoppingList
+		}{},
+		&struct {
+			GroupRegularShoppingItem model.GroupRegularShoppingItem `json:"regular_shopping_item"`
+			model.GroupShoppingList
+		}{})
+}
+
+func TestDBHandler_PutGroupRegularShoppingItem(t *testing.T) {
+	accountHostURL := fmt.Sprintf("%s:%d", config.Env.AccountApi.Host, config.Env.AccountApi.Port)
+
+	mockGetGroupShoppingItemCategoriesName := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mockCategoriesName := MockCategoriesName{
+			BigCategoryName:    model.NullString{NullString: sql.NullString{String: "日用品", Valid: true}},
+			MediumCategoryName: model.NullString{NullString: sql.NullString{String: "消耗品", Valid: true}},
+			CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(&mockCategoriesName); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/groups/{group_id:[0-9]+}/categories/name", mockGetGroupShoppingItemCategoriesName).Methods("GET")
+
+	listener, err := net.Listen("tcp", accountHostURL)
+	if err != nil {
+		t.Fatalf("unexpected error by net.Listen() '%#v'", err)
+	}
+
+	ts := httptest.Server{
+		Listener: listener,
+		Config:   &http.Server{Handler: router},
+	}
+
+	ts.Start()
+	defer ts.Close()
+
+	h := DBHandler{
+		AuthRepo:              MockAuthRepository{},
+		GroupShoppingListRepo: MockGroupShoppingListRepository{},
+		TimeManage:            MockTime{},
+	}
+
+	r := httptest.NewRequest("PUT", "/groups/1/shopping-list/regular/1", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"group_id": "1",
+		"id":       "1",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.PutGroupRegularShoppingItem(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res,
+		&struct {
+			GroupRegularShoppingItem model.GroupRegularShoppingItem `json:"regular_shopping_item"`
+			model.GroupShoppingList
+		}{},
+		&struct {
+			GroupRegularShoppingItem model.GroupRegularShoppingItem `json:"regular_shopping_item"`
+			model.GroupShoppingList
+		}{})
+}
+
+func TestDBHandler_SkipGroupRegularShoppingItem(t *testing.T) {
+	accountHostURL := fmt.Sprintf("%s:%d", config.Env.AccountApi.Host, config.Env.AccountApi.Port)
+
+	mockGetGroupShoppingItemCategoriesName := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mockCategoriesName := MockCategoriesName{
+			BigCategoryName:    model.NullString{NullString: sql.NullString{String: "日用品", Valid: true}},
+			MediumCategoryName: model.NullString{NullString: sql.NullString{String: "消耗品", Valid: true}},
+			CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(&mockCategoriesName); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/groups/{group_id:[0-9]+}/categories/name", mockGetGroupShoppingItemCategoriesName).Methods("GET")
+
+	listener, err := net.Listen("tcp", accountHostURL)
+	if err != nil {
+		t.Fatalf("unexpected error by net.Listen() '%#v'", err)
+	}
+
+	ts := httptest.Server{
+		Listener: listener,
+		Config:   &http.Server{Handler: router},
+	}
+
+	ts.Start()
+	defer ts.Close()
+
+	h := DBHandler{
+		AuthRepo:              MockAuthRepository{},
+		GroupShoppingListRepo: MockGroupShoppingListRepository{},
+		TimeManage:            MockTime{},
+	}
+
+	r := httptest.NewRequest("PUT", "/groups/1/shopping-list/regular/1/skip", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"group_id": "1",
+		"id":       "1",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.SkipGroupRegularShoppingItem(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res,
+		&struct {
+			GroupRegularShoppingItem model.GroupRegularShoppingItem `json:"regular_shopping_item"`
+			model.GroupShoppingList
+		}{},
+		&struct {
+			GroupRegularShoppingItem model.GroupRegularShoppingItem `json:"regular_shopping_item"`
+			model.GroupShoppingList
+		}{})
+}
+
+func TestDBHandler_PauseGroupRegularShoppingItem(t *testing.T) {
+	accountHostURL := fmt.Sprintf("%s:%d", config.Env.AccountApi.Host, config.Env.AccountApi.Port)
+
+	mockGetGroupShoppingItemCategoriesName := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mockCategoriesName := MockCategoriesName{
+			BigCategoryName:    model.NullString{NullString: sql.NullString{String: "日用品", Valid: true}},
+			MediumCategoryName: model.NullString{NullString: sql.NullString{String: "消耗品", Valid: true}},
+			CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(&mockCategoriesName); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/groups/{group_id:[0-9]+}/categories/name", mockGetGroupShoppingItemCategoriesName).Methods("GET")
+
+	listener, err := net.Listen("tcp", accountHostURL)
+	if err != nil {
+		t.Fatalf("unexpected error by net.Listen() '%#v'", err)
+	}
+
+	ts := httptest.Server{
+		Listener: listener,
+		Config:   &http.Server{Handler: router},
+	}
+
+	ts.Start()
+	defer ts.Close()
+
+	h := DBHandler{
+		AuthRepo:              MockAuthRepository{},
+		GroupShoppingListRepo: MockGroupShoppingListRepository{},
+		TimeManage:            MockTime{},
+	}
+
+	r := httptest.NewRequest("PUT", "/groups/1/shopping-list/regular/1/pause", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"group_id": "1",
+		"id":       "1",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.PauseGroupRegularShoppingItem(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res,
+		&struct {
+			GroupRegularShoppingItem model.GroupRegularShoppingItem `json:"regular_shopping_item"`
+			model.GroupShoppingList
+		}{},
+		&struct {
+			GroupRegularShoppingItem model.GroupRegularShoppingItem `json:"regular_shopping_item"`
+			model.GroupShoppingList
+		}{})
+}
+
+func TestDBHandler_DeleteGroupRegularShoppingItem(t *testing.T) {
+	h := DBHandler{
+		AuthRepo:              MockAuthRepository{},
+		GroupShoppingListRepo: MockGroupShoppingListRepository{},
+	}
+
+	r := httptest.NewRequest("DELETE", "/groups/1/shopping-list/regular/1", nil)
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"group_id": "1",
+		"id":       "1",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.DeleteGroupRegularShoppingItem(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &DeleteContentMsg{}, &DeleteContentMsg{})
+}
+
+func TestDBHandler_PostGroupShoppingItem(t *testing.T) {
+	accountHostURL := fmt.Sprintf("%s:%d", config.Env.AccountApi.Host, config.Env.AccountApi.Port)
+
+	mockGetGroupShoppingItemCategoriesName := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mockCategoriesName := MockCategoriesName{
+			BigCategoryName:    model.NullString{NullString: sql.NullString{String: "食費", Valid: true}},
+			MediumCategoryName: model.NullString{NullString: sql.NullString{String: "食料品", Valid: true}},
+			CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(&mockCategoriesName); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	})
+
+	mockGetGroupShoppingItemRelatedTransactionDataList := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		groupShoppingItemRelatedTransactionDataList := []*model.GroupTransactionData{
+			{
+				ID:                 model.NullInt64{NullInt64: sql.NullInt64{Int64: 3, Valid: true}},
+				TransactionType:    "expense",
+				PostedDate:         time.Date(2020, 12, 10, 14, 0, 0, 0, time.UTC),
+				UpdatedDate:        time.Date(2020, 12, 10, 14, 0, 0, 0, time.UTC),
+				TransactionDate:    "2020/12/10(木)",
+				Shop:               model.NullString{NullString: sql.NullString{String: "コストコ", Valid: true}},
+				Memo:               model.NullString{NullString: sql.NullString{String: "【買い物リスト】鶏肉3kg", Valid: true}},
+				Amount:             950,
+				PostedUserID:       "userID1",
+				UpdatedUserID:      model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+				PaymentUserID:      "userID1",
+				BigCategoryID:      2,
+				BigCategoryName:    "食費",
+				MediumCategoryID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 6, Valid: true}},
+				MediumCategoryName: model.NullString{NullString: sql.NullString{String: "食料品", Valid: true}},
+				CustomCategoryID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
+				CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+			},
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(&groupShoppingItemRelatedTransactionDataList); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/groups/{group_id:[0-9]+}/categories/name", mockGetGroupShoppingItemCategoriesName).Methods("GET")
+	router.HandleFunc("/groups/{group_id:[0-9]+}/transactions/related-shopping-list", mockGetGroupShoppingItemRelatedTransactionDataList).Methods("GET")
+
+	listener, err := net.Listen("tcp", accountHostURL)
+	if err != nil {
+		t.Fatalf("unexpected error by net.Listen() '%#v'", err)
+	}
+
+	ts := httptest.Server{
+		Listener: listener,
+		Config:   &http.Server{Handler: router},
+	}
+
+	ts.Start()
+	defer ts.Close()
+
+	h := DBHandler{
+		AuthRepo:              MockAuthRepository{},
+		GroupShoppingListRepo: MockGroupShoppingListRepository{},
+		TimeManage:            MockTime{},
+	}
+
+	r := httptest.NewRequest("POST", "/groups/1/shopping-list", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"group_id": "1",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.PostGroupShoppingItem(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusCreated)
+	testutil.AssertResponseBody(t, res, &model.GroupShoppingItem{}, &model.GroupShoppingItem{})
+}
+
+func TestDBHandler_PostGroupShoppingItem_PriceSuggestionFailure(t *testing.T) {
+	accountHostURL := fmt.Sprintf("%s:%d", config.Env.AccountApi.Host, config.Env.AccountApi.Port)
+
+	mockGetGroupShoppingItemCategoriesName := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mockCategoriesName := MockCategoriesName{
+			BigCategoryName:    model.NullString{NullString: sql.NullString{String: "食費", Valid: true}},
+			MediumCategoryName: model.NullString{NullString: sql.NullString{String: "食料品", Valid: true}},
+			CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(&mockCategoriesName); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	})
+
+	// The related transactions are not served, so the price suggestion fails to be made.
+	router := mux.NewRouter()
+	router.HandleFunc("/groups/{group_id:[0-9]+}/categories/name", mockGetGroupShoppingItemCategoriesName).Methods("GET")
+
+	listener, err := net.Listen("tcp", accountHostURL)
+	if err != nil {
+		t.Fatalf("unexpected error by net.Listen() '%#v'", err)
+	}
+
+	ts := httptest.Server{
+		Listener: listener,
+		Config:   &http.Server{Handler: router},
+	}
+
+	ts.Start()
+	defer ts.Close()
+
+	h := DBHandler{
+		AuthRepo:              MockAuthRepository{},
+		GroupShoppingListRepo: MockGroupShoppingListRepository{},
+		TimeManage:            MockTime{},
+	}
+
+	r := httptest.NewRequest("POST", "/groups/1/shopping-list", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"group_id": "1",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.PostGroupShoppingItem(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusCreated)
+
+	var groupShoppingItem model.GroupShoppingItem
+	if err := json.NewDecoder(res.Body).Decode(&groupShoppingItem); err != nil {
+		t.Fatalf("unexpected error by json.NewDecoder().Decode() '%#v'", err)
+	}
+
+	if groupShoppingItem.PriceSuggestion != nil {
+		t.Errorf("PriceSuggestion = %#v, want nil", groupShoppingItem.PriceSuggestion)
+	}
+}
+
+func TestDBHandler_PutGroupShoppingItem(t *testing.T) {
+	accountHostURL := fmt.Sprintf("%s:%d", config.Env.AccountApi.Host, config.Env.AccountApi.Port)
+
+	mockPostGroupTransaction := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mockGroupTransaction := model.GroupTransactionData{
+			ID:                 model.NullInt64{NullInt64: sql.NullInt64{Int64: 1, Valid: true}},
+			TransactionType:    "expense",
+			PostedDate:         time.Date(2020, 12, 15, 16, 0, 0, 0, time.UTC),
+			UpdatedDate:        time.Date(2020, 12, 15, 16, 0, 0, 0, time.UTC),
+			TransactionDate:    "2020/12/15(火)",
+			Shop:               model.NullString{NullString: sql.NullString{String: "コストコ", Valid: true}},
+			Memo:               model.NullString{NullString: sql.NullString{String: "【買い物リスト】鶏肉3kg", Valid: true}},
+			Amount:             1000,
+			PostedUserID:       "userID1",
+			UpdatedUserID:      model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+			PaymentUserID:      "userID1",
+			BigCategoryID:      2,
+			BigCategoryName:    "食費",
+			MediumCategoryID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 6, Valid: true}},
+			MediumCategoryName: model.NullString{NullString: sql.NullString{String: "食料品", Valid: true}},
+			CustomCategoryID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
+			CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(&mockGroupTransaction); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	})
+
+	mockDeleteGroupTransaction := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	mockGetGroupShoppingItemCategoriesName := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mockCategoriesName := MockCategoriesName{
+			BigCategoryName:    model.NullString{NullString: sql.NullString{String: "食費", Valid: true}},
+			MediumCategoryName: model.NullString{NullString: sql.NullString{String: "食料品", Valid: true}},
+			CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(&mockCategoriesName); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 	})
 
 	router := mux.NewRouter()
-	router.HandleFunc("/groups/{group_id:[0-9]+}/categories/names", mockGetGroupShoppingItemCategoriesNameList).Methods("GET")
+	router.HandleFunc("/groups/{group_id:[0-9]+}/transactions", mockPostGroupTransaction).Methods("POST")
+	router.HandleFunc("/groups/{group_id:[0-9]+}/transactions/{id:[0-9]+}", mockDeleteGroupTransaction).Methods("DELETE")
+	router.HandleFunc("/groups/{group_id:[0-9]+}/categories/name", mockGetGroupShoppingItemCategoriesName).Methods("GET")
 
 	listener, err := net.Listen("tcp", accountHostURL)
 	if err != nil {
@@ -1145,14 +2399,16 @@ func TestDBHandler_GetExpiredGroupShoppingList(t *testing.T) {
 	h := DBHandler{
 		AuthRepo:              MockAuthRepository{},
 		GroupShoppingListRepo: MockGroupShoppingListRepository{},
+		TransactionOutboxRepo: MockTransactionOutboxRepository{},
 		TimeManage:            MockTime{},
 	}
 
-	r := httptest.NewRequest("GET", "/groups/1/shopping-list/expired", nil)
+	r := httptest.NewRequest("PUT", "/groups/1/shopping-list/2", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
 	w := httptest.NewRecorder()
 
 	r = mux.SetURLVars(r, map[string]string{
 		"group_id": "1",
+		"id":       "2",
 	})
 
 	cookie := &http.Cookie{
@@ -1162,41 +2418,42 @@ func TestDBHandler_GetExpiredGroupShoppingList(t *testing.T) {
 
 	r.AddCookie(cookie)
 
-	dbMu.Lock()
-	defer dbMu.Unlock()
-
-	serverMu.Lock()
-	defer serverMu.Unlock()
-
-	h.GetExpiredGroupShoppingList(w, r)
+	h.PutGroupShoppingItem(w, r)
 
 	res := w.Result()
 	defer res.Body.Close()
 
 	testutil.AssertResponseHeader(t, res, http.StatusOK)
-	testutil.AssertResponseBody(t, res, &model.ExpiredGroupShoppingList{}, &model.ExpiredGroupShoppingList{})
+	testutil.AssertResponseBody(t, res, &model.GroupShoppingItem{}, &model.GroupShoppingItem{})
 }
 
-func TestDBHandler_PostGroupRegularShoppingItem(t *testing.T) {
+func TestDBHandler_GetClaimedGroupShoppingList(t *testing.T) {
 	accountHostURL := fmt.Sprintf("%s:%d", config.Env.AccountApi.Host, config.Env.AccountApi.Port)
 
-	mockGetGroupShoppingItemCategoriesName := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		mockCategoriesName := MockCategoriesName{
-			BigCategoryName:    model.NullString{NullString: sql.NullString{String: "日用品", Valid: true}},
-			MediumCategoryName: model.NullString{NullString: sql.NullString{String: "消耗品", Valid: true}},
-			CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+	mockGetGroupShoppingItemCategoriesNameList := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mockCategoriesNameList := []MockCategoriesName{
+			{
+				BigCategoryName:    model.NullString{NullString: sql.NullString{String: "日用品", Valid: true}},
+				MediumCategoryName: model.NullString{NullString: sql.NullString{String: "消耗品", Valid: true}},
+				CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+			},
+			{
+				BigCategoryName:    model.NullString{NullString: sql.NullString{String: "食費", Valid: true}},
+				MediumCategoryName: model.NullString{NullString: sql.NullString{String: "食料品", Valid: true}},
+				CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+			},
 		}
 
 		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 		w.WriteHeader(http.StatusOK)
-		if err := json.NewEncoder(w).Encode(&mockCategoriesName); err != nil {
+		if err := json.NewEncoder(w).Encode(&mockCategoriesNameList); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 	})
 
 	router := mux.NewRouter()
-	router.HandleFunc("/groups/{group_id:[0-9]+}/categories/name", mockGetGroupShoppingItemCategoriesName).Methods("GET")
+	router.HandleFunc("/groups/{group_id:[0-9]+}/categories/names", mockGetGroupShoppingItemCategoriesNameList).Methods("GET")
 
 	listener, err := net.Listen("tcp", accountHostURL)
 	if err != nil {
@@ -1217,7 +2474,7 @@ func TestDBHandler_PostGroupRegularShoppingItem(t *testing.T) {
 		TimeManage:            MockTime{},
 	}
 
-	r := httptest.NewRequest("POST", "/groups/1/shopping-list/regular", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
+	r := httptest.NewRequest("GET", "/groups/1/shopping-list/claimed", nil)
 	w := httptest.NewRecorder()
 
 	r = mux.SetURLVars(r, map[string]string{
@@ -1231,43 +2488,43 @@ func TestDBHandler_PostGroupRegularShoppingItem(t *testing.T) {
 
 	r.AddCookie(cookie)
 
-	h.PostGroupRegularShoppingItem(w, r)
+	dbMu.Lock()
+	defer dbMu.Unlock()
+
+	serverMu.Lock()
+	defer serverMu.Unlock()
+
+	h.GetClaimedGroupShoppingList(w, r)
 
 	res := w.Result()
 	defer res.Body.Close()
 
-	testutil.AssertResponseHeader(t, res, http.StatusCreated)
-	testutil.AssertResponseBody(t, res,
-		&struct {
-			GroupRegularShoppingItem model.GroupRegularShoppingItem `json:"regular_shopping_item"`
-			model.GroupShoppingList
-		}{},
-		&struct {
-			GroupRegularShoppingItem model.GroupRegularShoppingItem `json:"regular_shopping_item"`
-			model.GroupShoppingList
-		}{})
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &model.ClaimedGroupShoppingList{}, &model.ClaimedGroupShoppingList{})
 }
 
-func TestDBHandler_PutGroupRegularShoppingItem(t *testing.T) {
+func TestDBHandler_ClaimGroupShoppingItem(t *testing.T) {
 	accountHostURL := fmt.Sprintf("%s:%d", config.Env.AccountApi.Host, config.Env.AccountApi.Port)
 
-	mockGetGroupShoppingItemCategoriesName := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		mockCategoriesName := MockCategoriesName{
-			BigCategoryName:    model.NullString{NullString: sql.NullString{String: "日用品", Valid: true}},
-			MediumCategoryName: model.NullString{NullString: sql.NullString{String: "消耗品", Valid: true}},
-			CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+	mockGetGroupShoppingItemCategoriesNameList := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mockCategoriesNameList := []MockCategoriesName{
+			{
+				BigCategoryName:    model.NullString{NullString: sql.NullString{String: "食費", Valid: true}},
+				MediumCategoryName: model.NullString{NullString: sql.NullString{String: "食料品", Valid: true}},
+				CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+			},
 		}
 
 		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 		w.WriteHeader(http.StatusOK)
-		if err := json.NewEncoder(w).Encode(&mockCategoriesName); err != nil {
+		if err := json.NewEncoder(w).Encode(&mockCategoriesNameList); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 	})
 
 	router := mux.NewRouter()
-	router.HandleFunc("/groups/{group_id:[0-9]+}/categories/name", mockGetGroupShoppingItemCategoriesName).Methods("GET")
+	router.HandleFunc("/groups/{group_id:[0-9]+}/categories/names", mockGetGroupShoppingItemCategoriesNameList).Methods("GET")
 
 	listener, err := net.Listen("tcp", accountHostURL)
 	if err != nil {
@@ -1288,12 +2545,12 @@ func TestDBHandler_PutGroupRegularShoppingItem(t *testing.T) {
 		TimeManage:            MockTime{},
 	}
 
-	r := httptest.NewRequest("PUT", "/groups/1/shopping-list/regular/1", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
+	r := httptest.NewRequest("PUT", "/groups/1/shopping-list/3/claim", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
 	w := httptest.NewRecorder()
 
 	r = mux.SetURLVars(r, map[string]string{
 		"group_id": "1",
-		"id":       "1",
+		"id":       "3",
 	})
 
 	cookie := &http.Cookie{
@@ -1303,73 +2560,43 @@ func TestDBHandler_PutGroupRegularShoppingItem(t *testing.T) {
 
 	r.AddCookie(cookie)
 
-	h.PutGroupRegularShoppingItem(w, r)
-
-	res := w.Result()
-	defer res.Body.Close()
-
-	testutil.AssertResponseHeader(t, res, http.StatusOK)
-	testutil.AssertResponseBody(t, res,
-		&struct {
-			GroupRegularShoppingItem model.GroupRegularShoppingItem `json:"regular_shopping_item"`
-			model.GroupShoppingList
-		}{},
-		&struct {
-			GroupRegularShoppingItem model.GroupRegularShoppingItem `json:"regular_shopping_item"`
-			model.GroupShoppingList
-		}{})
-}
-
-func TestDBHandler_DeleteGroupRegularShoppingItem(t *testing.T) {
-	h := DBHandler{
-		AuthRepo:              MockAuthRepository{},
-		GroupShoppingListRepo: MockGroupShoppingListRepository{},
-	}
-
-	r := httptest.NewRequest("DELETE", "/groups/1/shopping-list/regular/1", nil)
-	w := httptest.NewRecorder()
-
-	r = mux.SetURLVars(r, map[string]string{
-		"group_id": "1",
-		"id":       "1",
-	})
-
-	cookie := &http.Cookie{
-		Name:  config.Env.Cookie.Name,
-		Value: uuid.New().String(),
-	}
+	dbMu.Lock()
+	defer dbMu.Unlock()
 
-	r.AddCookie(cookie)
+	serverMu.Lock()
+	defer serverMu.Unlock()
 
-	h.DeleteGroupRegularShoppingItem(w, r)
+	h.ClaimGroupShoppingItem(w, r)
 
 	res := w.Result()
 	defer res.Body.Close()
 
 	testutil.AssertResponseHeader(t, res, http.StatusOK)
-	testutil.AssertResponseBody(t, res, &DeleteContentMsg{}, &DeleteContentMsg{})
+	testutil.AssertResponseBody(t, res, &model.GroupShoppingItem{}, &model.GroupShoppingItem{})
 }
 
-func TestDBHandler_PostGroupShoppingItem(t *testing.T) {
+func TestDBHandler_UnclaimGroupShoppingItem(t *testing.T) {
 	accountHostURL := fmt.Sprintf("%s:%d", config.Env.AccountApi.Host, config.Env.AccountApi.Port)
 
-	mockGetGroupShoppingItemCategoriesName := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		mockCategoriesName := MockCategoriesName{
-			BigCategoryName:    model.NullString{NullString: sql.NullString{String: "食費", Valid: true}},
-			MediumCategoryName: model.NullString{NullString: sql.NullString{String: "食料品", Valid: true}},
-			CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+	mockGetGroupShoppingItemCategoriesNameList := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mockCategoriesNameList := []MockCategoriesName{
+			{
+				BigCategoryName:    model.NullString{NullString: sql.NullString{String: "食費", Valid: true}},
+				MediumCategoryName: model.NullString{NullString: sql.NullString{String: "食料品", Valid: true}},
+				CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+			},
 		}
 
 		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 		w.WriteHeader(http.StatusOK)
-		if err := json.NewEncoder(w).Encode(&mockCategoriesName); err != nil {
+		if err := json.NewEncoder(w).Encode(&mockCategoriesNameList); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 	})
 
 	router := mux.NewRouter()
-	router.HandleFunc("/groups/{group_id:[0-9]+}/categories/name", mockGetGroupShoppingItemCategoriesName).Methods("GET")
+	router.HandleFunc("/groups/{group_id:[0-9]+}/categories/names", mockGetGroupShoppingItemCategoriesNameList).Methods("GET")
 
 	listener, err := net.Listen("tcp", accountHostURL)
 	if err != nil {
@@ -1390,11 +2617,12 @@ func TestDBHandler_PostGroupShoppingItem(t *testing.T) {
 		TimeManage:            MockTime{},
 	}
 
-	r := httptest.NewRequest("POST", "/groups/1/shopping-list", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
+	r := httptest.NewRequest("PUT", "/groups/1/shopping-list/1/unclaim", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
 	w := httptest.NewRecorder()
 
 	r = mux.SetURLVars(r, map[string]string{
 		"group_id": "1",
+		"id":       "1",
 	})
 
 	cookie := &http.Cookie{
@@ -1404,95 +2632,61 @@ func TestDBHandler_PostGroupShoppingItem(t *testing.T) {
 
 	r.AddCookie(cookie)
 
-	h.PostGroupShoppingItem(w, r)
+	dbMu.Lock()
+	defer dbMu.Unlock()
+
+	serverMu.Lock()
+	defer serverMu.Unlock()
+
+	h.UnclaimGroupShoppingItem(w, r)
 
 	res := w.Result()
 	defer res.Body.Close()
 
-	testutil.AssertResponseHeader(t, res, http.StatusCreated)
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
 	testutil.AssertResponseBody(t, res, &model.GroupShoppingItem{}, &model.GroupShoppingItem{})
 }
 
-func TestDBHandler_PutGroupShoppingItem(t *testing.T) {
-	accountHostURL := fmt.Sprintf("%s:%d", config.Env.AccountApi.Host, config.Env.AccountApi.Port)
-
-	mockPostGroupTransaction := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		mockGroupTransaction := model.GroupTransactionData{
-			ID:                 model.NullInt64{NullInt64: sql.NullInt64{Int64: 1, Valid: true}},
-			TransactionType:    "expense",
-			PostedDate:         time.Date(2020, 12, 15, 16, 0, 0, 0, time.UTC),
-			UpdatedDate:        time.Date(2020, 12, 15, 16, 0, 0, 0, time.UTC),
-			TransactionDate:    "2020/12/15(火)",
-			Shop:               model.NullString{NullString: sql.NullString{String: "コストコ", Valid: true}},
-			Memo:               model.NullString{NullString: sql.NullString{String: "【買い物リスト】鶏肉3kg", Valid: true}},
-			Amount:             1000,
-			PostedUserID:       "userID1",
-			UpdatedUserID:      model.NullString{NullString: sql.NullString{String: "", Valid: false}},
-			PaymentUserID:      "userID1",
-			BigCategoryID:      2,
-			BigCategoryName:    "食費",
-			MediumCategoryID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 6, Valid: true}},
-			MediumCategoryName: model.NullString{NullString: sql.NullString{String: "食料品", Valid: true}},
-			CustomCategoryID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
-			CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
-		}
-
-		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
-		w.WriteHeader(http.StatusCreated)
-		if err := json.NewEncoder(w).Encode(&mockGroupTransaction); err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			return
-		}
-	})
-
-	mockDeleteGroupTransaction := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-	})
-
-	mockGetGroupShoppingItemCategoriesName := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		mockCategoriesName := MockCategoriesName{
-			BigCategoryName:    model.NullString{NullString: sql.NullString{String: "食費", Valid: true}},
-			MediumCategoryName: model.NullString{NullString: sql.NullString{String: "食料品", Valid: true}},
-			CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
-		}
+type MockNoRowsAffectedSqlResult struct {
+	MockSqlResult
+}
 
-		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
-		w.WriteHeader(http.StatusOK)
-		if err := json.NewEncoder(w).Encode(&mockCategoriesName); err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			return
-		}
-	})
+func (r MockNoRowsAffectedSqlResult) RowsAffected() (int64, error) {
+	return 0, nil
+}
 
-	router := mux.NewRouter()
-	router.HandleFunc("/groups/{group_id:[0-9]+}/transactions", mockPostGroupTransaction).Methods("POST")
-	router.HandleFunc("/groups/{group_id:[0-9]+}/transactions/{id:[0-9]+}", mockDeleteGroupTransaction).Methods("DELETE")
-	router.HandleFunc("/groups/{group_id:[0-9]+}/categories/name", mockGetGroupShoppingItemCategoriesName).Methods("GET")
+type MockUnclaimConflictGroupShoppingListRepository struct {
+	MockGroupShoppingListRepository
+	claimVersion  *int
+	claimedUserID *string
+}
 
-	listener, err := net.Listen("tcp", accountHostURL)
-	if err != nil {
-		t.Fatalf("unexpected error by net.Listen() '%#v'", err)
-	}
+func (m MockUnclaimConflictGroupShoppingListRepository) UnclaimGroupShoppingItem(claimVersion int, claimedUserID string, groupShoppingItemID int, groupID int) (sql.Result, error) {
+	*m.claimVersion = claimVersion
+	*m.claimedUserID = claimedUserID
 
-	ts := httptest.Server{
-		Listener: listener,
-		Config:   &http.Server{Handler: router},
-	}
+	return MockNoRowsAffectedSqlResult{}, nil
+}
 
-	ts.Start()
-	defer ts.Close()
+func TestDBHandler_UnclaimGroupShoppingItem_Conflict(t *testing.T) {
+	var claimVersion int
+	var claimedUserID string
 
 	h := DBHandler{
-		AuthRepo:              MockAuthRepository{},
-		GroupShoppingListRepo: MockGroupShoppingListRepository{},
+		AuthRepo: MockAuthRepository{},
+		GroupShoppingListRepo: MockUnclaimConflictGroupShoppingListRepository{
+			claimVersion:  &claimVersion,
+			claimedUserID: &claimedUserID,
+		},
+		TimeManage: MockTime{},
 	}
 
-	r := httptest.NewRequest("PUT", "/groups/1/shopping-list/2", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
+	r := httptest.NewRequest("PUT", "/groups/1/shopping-list/1/unclaim", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
 	w := httptest.NewRecorder()
 
 	r = mux.SetURLVars(r, map[string]string{
 		"group_id": "1",
-		"id":       "2",
+		"id":       "1",
 	})
 
 	cookie := &http.Cookie{
@@ -1502,13 +2696,18 @@ func TestDBHandler_PutGroupShoppingItem(t *testing.T) {
 
 	r.AddCookie(cookie)
 
-	h.PutGroupShoppingItem(w, r)
+	h.UnclaimGroupShoppingItem(w, r)
 
 	res := w.Result()
 	defer res.Body.Close()
 
-	testutil.AssertResponseHeader(t, res, http.StatusOK)
-	testutil.AssertResponseBody(t, res, &model.GroupShoppingItem{}, &model.GroupShoppingItem{})
+	// Only the caller's own claim at the fetched version can be released.
+	if claimVersion != 1 || claimedUserID != "userID1" {
+		t.Errorf("unexpected unclaim condition: claim_version %d, claimed_user_id %s", claimVersion, claimedUserID)
+	}
+
+	testutil.AssertResponseHeader(t, res, http.StatusConflict)
+	testutil.AssertResponseBody(t, res, &HTTPError{ErrorMessage: &ConflictErrorMsg{}}, &HTTPError{ErrorMessage: &ConflictErrorMsg{}})
 }
 
 func TestDBHandler_DeleteGroupShoppingItem(t *testing.T) {
@@ -1562,3 +2761,25 @@ func TestDBHandler_PutGroupShoppingListCustomCategoryIdToMediumCategoryId(t *tes
 		t.Errorf("differs: (-want +got)\n%s", diff)
 	}
 }
+
+func TestDBHandler_PutGroupShoppingListCustomCategoryIdToMediumCategoryIdWithReplaceCustomCategory(t *testing.T) {
+	h := DBHandler{
+		GroupShoppingListRepo: MockGroupShoppingListRepository{},
+	}
+
+	r := httptest.NewRequest("PUT", "/groups/1/shopping-list/categories", strings.NewReader(testutil.GetRequestJsonFromTestData(t)))
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"group_id": "1",
+	})
+
+	h.PutGroupShoppingListCustomCategoryIdToMediumCategoryId(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	if diff := cmp.Diff(http.StatusOK, res.StatusCode); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
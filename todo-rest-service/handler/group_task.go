@@ -10,6 +10,8 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -22,6 +24,11 @@ import (
 	"github.com/hryze/kakeibo-app-api/todo-rest-service/domain/model"
 )
 
+const (
+	groupTasksScheduleDefaultMonths = 1
+	groupTasksScheduleMaxYears      = 1
+)
+
 func verifyGroupAffiliationOfUsersList(groupID int, groupUsersList model.GroupTasksUsersListReceiver) error {
 	requestURL := fmt.Sprintf(
 		"http://%s:%d/groups/%d/users/verify",
@@ -123,83 +130,191 @@ func generateNextGroupTasksUserID(groupTaskAssignedToUser model.GroupTask, group
 	return nextGroupTasksUserID
 }
 
+// rotateGroupTasksListAssignedToUser moves each task whose cycle has ended to the user in charge of the current cycle,
+// and returns the indexes of the updated tasks.
+func rotateGroupTasksListAssignedToUser(groupTasksListAssignedToUser []model.GroupTask, groupTasksUsersList []model.GroupTasksUser, now time.Time) []int {
+	var updateTaskIndexList []int
+	today := time.Date(now.Year(), now.Month(), now.Day(), 23, 59, 59, 0, time.UTC)
+
+	for i := 0; i < len(groupTasksListAssignedToUser); i++ {
+		baseDate := groupTasksListAssignedToUser[i].BaseDate.Time
+		cycleDays := groupTasksListAssignedToUser[i].Cycle.Int
+		taskEndDate := baseDate.AddDate(0, 0, cycleDays).Add(-1 * time.Second)
+
+		if !today.After(taskEndDate) {
+			continue
+		}
+
+		if groupTasksListAssignedToUser[i].CycleType.String == "none" {
+			groupTasksListAssignedToUser[i].BaseDate.Valid = false
+			groupTasksListAssignedToUser[i].CycleType.Valid = false
+			groupTasksListAssignedToUser[i].Cycle.Valid = false
+			groupTasksListAssignedToUser[i].GroupTasksUserID.Valid = false
+
+			updateTaskIndexList = append(updateTaskIndexList, i)
+
+			continue
+		}
+
+		nextBaseDate, cycleCount := generateNextBaseDate(today, baseDate, cycleDays)
+		nextGroupTasksUserID := generateNextGroupTasksUserID(groupTasksListAssignedToUser[i], groupTasksUsersList, cycleCount)
+
+		groupTasksListAssignedToUser[i].BaseDate.Time = nextBaseDate
+		groupTasksListAssignedToUser[i].GroupTasksUserID.Int = nextGroupTasksUserID
+
+		updateTaskIndexList = append(updateTaskIndexList, i)
+	}
+
+	return updateTaskIndexList
+}
+
+// generateGroupTaskAssignmentList projects the user in charge of the task for each cycle overlapping from and to,
+// by the same rotation as rotateGroupTasksListAssignedToUser, without changing the task itself.
+func generateGroupTaskAssignmentList(groupTaskAssignedToUser model.GroupTask, groupTasksUsersList []model.GroupTasksUser, now time.Time, from time.Time, to time.Time) []model.GroupTaskAssignment {
+	if !groupTaskAssignedToUser.BaseDate.Valid || !groupTaskAssignedToUser.GroupTasksUserID.Valid || groupTaskAssignedToUser.Cycle.Int <= 0 || len(groupTasksUsersList) == 0 {
+		return nil
+	}
+
+	groupTasksList := []model.GroupTask{groupTaskAssignedToUser}
+	rotateGroupTasksListAssignedToUser(groupTasksList, groupTasksUsersList, now)
+
+	groupTask := groupTasksList[0]
+	if !groupTask.BaseDate.Valid {
+		return nil
+	}
+
+	baseDate := time.Date(groupTask.BaseDate.Time.Year(), groupTask.BaseDate.Time.Month(), groupTask.BaseDate.Time.Day(), 0, 0, 0, 0, time.UTC)
+	cycleDays := groupTask.Cycle.Int
+
+	var cycleCount int
+	if from.After(baseDate) {
+		cycleCount = int(from.Sub(baseDate).Hours()/24) / cycleDays
+	}
+
+	var groupTaskAssignmentList []model.GroupTaskAssignment
+	for ; ; cycleCount++ {
+		// The task which is not rotated is only in charge of the current cycle.
+		if groupTask.CycleType.String == "none" && cycleCount > 0 {
+			break
+		}
+
+		startDate := baseDate.AddDate(0, 0, cycleCount*cycleDays)
+		if startDate.After(to) {
+			break
+		}
+
+		endDate := startDate.AddDate(0, 0, cycleDays-1)
+		if endDate.Before(from) {
+			continue
+		}
+
+		groupTaskAssignment := model.GroupTaskAssignment{
+			GroupTasksID:     groupTask.ID,
+			TaskName:         groupTask.TaskName,
+			CycleType:        groupTask.CycleType.String,
+			StartDate:        startDate,
+			EndDate:          endDate,
+			GroupTasksUserID: generateNextGroupTasksUserID(groupTask, groupTasksUsersList, cycleCount),
+		}
+
+		for _, groupTasksUser := range groupTasksUsersList {
+			if groupTasksUser.ID == groupTaskAssignment.GroupTasksUserID {
+				groupTaskAssignment.UserID = groupTasksUser.UserID
+				break
+			}
+		}
+
+		groupTaskAssignmentList = append(groupTaskAssignmentList, groupTaskAssignment)
+	}
+
+	return groupTaskAssignmentList
+}
+
+// generateGroupTasksSchedulePeriod generates the period of the group tasks schedule from the url query,
+// which is the month from today by default, and returns BadRequestErrorMsg when it is invalid.
+func generateGroupTasksSchedulePeriod(urlQuery url.Values, now time.Time) (time.Time, time.Time, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	startDate, err := generateStartDate(urlQuery.Get("from"))
+	if err != nil {
+		return time.Time{}, time.Time{}, &BadRequestErrorMsg{"日付を正しく指定してください。"}
+	}
+
+	if !startDate.Valid {
+		startDate.Time = today
+	}
+
+	endDate, err := generateEndDate(urlQuery.Get("to"))
+	if err != nil {
+		return time.Time{}, time.Time{}, &BadRequestErrorMsg{"日付を正しく指定してください。"}
+	}
+
+	if !endDate.Valid {
+		endDate.Time = startDate.Time.AddDate(0, groupTasksScheduleDefaultMonths, 0).Add(-1 * time.Second)
+	}
+
+	// The cycles before the current one are not projected, since the members in charge may have changed since then.
+	if startDate.Time.Before(today) {
+		return time.Time{}, time.Time{}, &BadRequestErrorMsg{"開始日は今日以降の日付を指定してください。"}
+	}
+
+	if endDate.Time.Before(startDate.Time) {
+		return time.Time{}, time.Time{}, &BadRequestErrorMsg{"終了日は開始日以降の日付を指定してください。"}
+	}
+
+	if !endDate.Time.Before(startDate.Time.AddDate(groupTasksScheduleMaxYears, 0, 0)) {
+		return time.Time{}, time.Time{}, &BadRequestErrorMsg{"取得期間は1年以内で指定してください。"}
+	}
+
+	return startDate.Time, endDate.Time, nil
+}
+
 func (h *DBHandler) GetGroupTasksListForEachUser(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
-	groupTasksUsersList, err := h.GroupTasksRepo.GetGroupTasksUsersList(groupID)
+	groupTasksUsersList, err := h.GroupTasksRepo.GetGroupTasksUsersList(r.Context(), groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	groupTasksListAssignedToUser, err := h.GroupTasksRepo.GetGroupTasksListAssignedToUser(groupID)
+	groupTasksListAssignedToUser, err := h.GroupTasksRepo.GetGroupTasksListAssignedToUser(r.Context(), groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	var updateTaskIndexList []int
-	now := h.TimeManage.Now()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 23, 59, 59, 0, time.UTC)
-
-	for i := 0; i < len(groupTasksListAssignedToUser); i++ {
-		baseDate := groupTasksListAssignedToUser[i].BaseDate.Time
-		cycleDays := groupTasksListAssignedToUser[i].Cycle.Int
-		taskEndDate := baseDate.AddDate(0, 0, cycleDays).Add(-1 * time.Second)
-
-		if !today.After(taskEndDate) {
-			continue
-		}
-
-		if groupTasksListAssignedToUser[i].CycleType.String == "none" {
-			groupTasksListAssignedToUser[i].BaseDate.Valid = false
-			groupTasksListAssignedToUser[i].CycleType.Valid = false
-			groupTasksListAssignedToUser[i].Cycle.Valid = false
-			groupTasksListAssignedToUser[i].GroupTasksUserID.Valid = false
-
-			updateTaskIndexList = append(updateTaskIndexList, i)
-
-			continue
-		}
-
-		nextBaseDate, cycleCount := generateNextBaseDate(today, baseDate, cycleDays)
-		nextGroupTasksUserID := generateNextGroupTasksUserID(groupTasksListAssignedToUser[i], groupTasksUsersList, cycleCount)
-
-		groupTasksListAssignedToUser[i].BaseDate.Time = nextBaseDate
-		groupTasksListAssignedToUser[i].GroupTasksUserID.Int = nextGroupTasksUserID
-
-		updateTaskIndexList = append(updateTaskIndexList, i)
-	}
+	updateTaskIndexList := rotateGroupTasksListAssignedToUser(groupTasksListAssignedToUser, groupTasksUsersList, h.TimeManage.Now())
 
 	if len(updateTaskIndexList) != 0 {
 		if err := h.GroupTasksRepo.PutGroupTasksListAssignedToUser(groupTasksListAssignedToUser, updateTaskIndexList); err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 	}
@@ -222,75 +337,145 @@ func (h *DBHandler) GetGroupTasksListForEachUser(w http.ResponseWriter, r *http.
 	}
 }
 
+// GetGroupTasksSchedule projects the users in charge of the group tasks for each cycle in the period,
+// without writing the rotation back unlike GetGroupTasksListForEachUser.
+func (h *DBHandler) GetGroupTasksSchedule(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		return
+	}
+
+	if err := verifyGroupAffiliation(groupID, userID); err != nil {
+		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
+		if !ok {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		return
+	}
+
+	now := h.TimeManage.Now()
+
+	startDate, endDate, err := generateGroupTasksSchedulePeriod(r.URL.Query(), now)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
+		return
+	}
+
+	groupTasksUsersList, err := h.GroupTasksRepo.GetGroupTasksUsersList(r.Context(), groupID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	groupTasksListAssignedToUser, err := h.GroupTasksRepo.GetGroupTasksListAssignedToUser(r.Context(), groupID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	var groupTaskAssignmentList []model.GroupTaskAssignment
+	for _, groupTaskAssignedToUser := range groupTasksListAssignedToUser {
+		groupTaskAssignmentList = append(groupTaskAssignmentList, generateGroupTaskAssignmentList(groupTaskAssignedToUser, groupTasksUsersList, now, startDate, endDate)...)
+	}
+
+	sort.SliceStable(groupTaskAssignmentList, func(i, j int) bool {
+		return groupTaskAssignmentList[i].StartDate.Before(groupTaskAssignmentList[j].StartDate)
+	})
+
+	groupTasksSchedule := model.NewGroupTasksSchedule(groupTaskAssignmentList)
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&groupTasksSchedule); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
 func (h *DBHandler) PostGroupTasksUsersList(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	var groupTasksUsersListReceiver model.GroupTasksUsersListReceiver
 	if err := json.NewDecoder(r.Body).Decode(&groupTasksUsersListReceiver); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if err := verifyGroupAffiliationOfUsersList(groupID, groupTasksUsersListReceiver); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
-	dbGroupTasksUsersList, err := h.GroupTasksRepo.GetGroupTasksUsersList(groupID)
+	dbGroupTasksUsersList, err := h.GroupTasksRepo.GetGroupTasksUsersList(r.Context(), groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	for _, userID := range groupTasksUsersListReceiver.GroupUsersList {
 		for _, dbUser := range dbGroupTasksUsersList {
 			if userID == dbUser.UserID {
-				errorResponseByJSON(w, NewHTTPError(http.StatusConflict, &ConflictErrorMsg{"選択したユーザーは、既にタスクメンバーに追加されています。"}))
+				errorResponseByJSON(w, r, NewHTTPError(http.StatusConflict, &ConflictErrorMsg{"選択したユーザーは、既にタスクメンバーに追加されています。"}))
 				return
 			}
 		}
 	}
 
 	if err := h.GroupTasksRepo.PostGroupTasksUsersList(groupTasksUsersListReceiver, groupID); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	dbGroupTasksUsersListSender, err := h.GroupTasksRepo.GetGroupTasksUsersList(groupID)
+	dbGroupTasksUsersListSender, err := h.GroupTasksRepo.GetGroupTasksUsersList(r.Context(), groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -316,51 +501,51 @@ func (h *DBHandler) DeleteGroupTasksUsersList(w http.ResponseWriter, r *http.Req
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	var groupTasksUsersListReceiver model.GroupTasksUsersListReceiver
 	if err := json.NewDecoder(r.Body).Decode(&groupTasksUsersListReceiver); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if err := verifyGroupAffiliationOfUsersList(groupID, groupTasksUsersListReceiver); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
-	dbGroupTasksUsersList, err := h.GroupTasksRepo.GetGroupTasksUsersList(groupID)
+	dbGroupTasksUsersList, err := h.GroupTasksRepo.GetGroupTasksUsersList(r.Context(), groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -382,24 +567,24 @@ func (h *DBHandler) DeleteGroupTasksUsersList(w http.ResponseWriter, r *http.Req
 			continue
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"選択したユーザーは、既にタスクメンバーから削除されています。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"選択したユーザーは、既にタスクメンバーから削除されています。"}))
 		return
 	}
 
 	groupTasksIDList, err := h.GroupTasksRepo.GetGroupTasksIDListAssignedToUser(groupTasksUsersIdList, groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if err := h.GroupTasksRepo.DeleteGroupTasksUsersList(groupTasksUsersListReceiver, groupTasksIDList, groupID); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(&DeleteContentMsg{"タスクメンバーを削除しました。"}); err != nil {
+	if err := json.NewEncoder(w).Encode(&DeleteContentMsg{localizeMessage(r.Context(), "タスクメンバーを削除しました。")}); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -409,34 +594,34 @@ func (h *DBHandler) GetGroupTasksList(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	groupTasksList, err := h.GroupTasksRepo.GetGroupTasksList(groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -454,57 +639,57 @@ func (h *DBHandler) PostGroupTask(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	var groupTask model.GroupTask
 	if err := json.NewDecoder(r.Body).Decode(&groupTask); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if err := validateGroupTaskName(&groupTask); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, err))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
 		return
 	}
 
 	result, err := h.GroupTasksRepo.PostGroupTask(groupTask, groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	lastInsertId, err := result.LastInsertId()
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	dbGroupTask, err := h.GroupTasksRepo.GetGroupTask(int(lastInsertId))
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -520,66 +705,66 @@ func (h *DBHandler) PutGroupTask(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	groupTasksID, err := strconv.Atoi(mux.Vars(r)["id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"タスクIDを正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"タスクIDを正しく指定してください。"}))
 		return
 	}
 
 	var groupTask model.GroupTask
 	if err := json.NewDecoder(r.Body).Decode(&groupTask); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if err := validateGroupTaskName(&groupTask); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, err))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
 		return
 	}
 
 	result, err := h.GroupTasksRepo.PutGroupTask(&groupTask, groupTasksID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	} else if rowsAffected == 0 {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"指定されたタスクは存在しません。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"指定されたタスクは存在しません。"}))
 		return
 	}
 
 	dbGroupTask, err := h.GroupTasksRepo.GetGroupTask(groupTasksID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -595,55 +780,55 @@ func (h *DBHandler) DeleteGroupTask(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	groupTasksID, err := strconv.Atoi(mux.Vars(r)["id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"タスクIDを正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"タスクIDを正しく指定してください。"}))
 		return
 	}
 
 	if _, err := h.GroupTasksRepo.GetGroupTask(groupTasksID); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"指定されたタスクは存在しません。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"指定されたタスクは存在しません。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if err := h.GroupTasksRepo.DeleteGroupTask(groupTasksID); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(DeleteContentMsg{"タスクを削除しました。"}); err != nil {
+	if err := json.NewEncoder(w).Encode(DeleteContentMsg{localizeMessage(r.Context(), "タスクを削除しました。")}); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -1,14 +1,18 @@
 package handler
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 
@@ -19,7 +23,7 @@ import (
 
 type MockGroupTasksRepository struct{}
 
-func (m MockGroupTasksRepository) GetGroupTasksUsersList(groupID int) ([]model.GroupTasksUser, error) {
+func (m MockGroupTasksRepository) GetGroupTasksUsersList(ctx context.Context, groupID int) ([]model.GroupTasksUser, error) {
 	if groupID == 1 {
 		return []model.GroupTasksUser{
 			{ID: 1, UserID: "userID1", GroupID: 1, TasksList: make([]model.GroupTask, 0)},
@@ -50,7 +54,7 @@ func (m MockGroupTasksRepository) GetGroupTasksUsersList(groupID int) ([]model.G
 	}, nil
 }
 
-func (m MockGroupTasksRepository) GetGroupTasksListAssignedToUser(groupID int) ([]model.GroupTask, error) {
+func (m MockGroupTasksRepository) GetGroupTasksListAssignedToUser(ctx context.Context, groupID int) ([]model.GroupTask, error) {
 	return []model.GroupTask{
 		{
 			ID:               1,
@@ -214,6 +218,222 @@ func TestDBHandler_GetGroupTasksListForEachUser(t *testing.T) {
 	testutil.AssertResponseBody(t, res, &model.GroupTasksListForEachUser{}, &model.GroupTasksListForEachUser{})
 }
 
+// MockScheduleGroupTasksRepository fails to write the rotation back, which the schedule must not do.
+type MockScheduleGroupTasksRepository struct {
+	MockGroupTasksRepository
+}
+
+func (m MockScheduleGroupTasksRepository) PutGroupTasksListAssignedToUser(groupTasksList []model.GroupTask, updateTaskIndexList []int) error {
+	return errors.New("the rotation must not be written back by the schedule")
+}
+
+func TestDBHandler_GetGroupTasksSchedule(t *testing.T) {
+	h := DBHandler{
+		AuthRepo:       MockAuthRepository{},
+		GroupTasksRepo: MockScheduleGroupTasksRepository{},
+		TimeManage:     MockTime{},
+	}
+
+	r := httptest.NewRequest("GET", "/groups/1/tasks/schedule?from=2020-09-06&to=2020-09-12", nil)
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"group_id": "1",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.GetGroupTasksSchedule(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &model.GroupTasksSchedule{}, &model.GroupTasksSchedule{})
+}
+
+func TestGenerateGroupTasksSchedulePeriod(t *testing.T) {
+	now := MockTime{}.Now()
+
+	tests := []struct {
+		name          string
+		urlQuery      url.Values
+		wantStartDate time.Time
+		wantEndDate   time.Time
+		wantErr       error
+	}{
+		{
+			name:          "default period",
+			urlQuery:      url.Values{},
+			wantStartDate: time.Date(2020, 9, 6, 0, 0, 0, 0, time.UTC),
+			wantEndDate:   time.Date(2020, 10, 5, 23, 59, 59, 0, time.UTC),
+		},
+		{
+			name:          "default end date",
+			urlQuery:      url.Values{"from": {"2020-12-01"}},
+			wantStartDate: time.Date(2020, 12, 1, 0, 0, 0, 0, time.UTC),
+			wantEndDate:   time.Date(2020, 12, 31, 23, 59, 59, 0, time.UTC),
+		},
+		{
+			name:          "specified period",
+			urlQuery:      url.Values{"from": {"2020-09-06"}, "to": {"2021-09-05"}},
+			wantStartDate: time.Date(2020, 9, 6, 0, 0, 0, 0, time.UTC),
+			wantEndDate:   time.Date(2021, 9, 5, 23, 59, 59, 0, time.UTC),
+		},
+		{
+			name:     "invalid date",
+			urlQuery: url.Values{"from": {"2020-9-6"}},
+			wantErr:  &BadRequestErrorMsg{"日付を正しく指定してください。"},
+		},
+		{
+			name:     "start date before today",
+			urlQuery: url.Values{"from": {"2020-09-05"}},
+			wantErr:  &BadRequestErrorMsg{"開始日は今日以降の日付を指定してください。"},
+		},
+		{
+			name:     "end date before start date",
+			urlQuery: url.Values{"from": {"2020-09-10"}, "to": {"2020-09-09"}},
+			wantErr:  &BadRequestErrorMsg{"終了日は開始日以降の日付を指定してください。"},
+		},
+		{
+			name:     "period longer than a year",
+			urlQuery: url.Values{"from": {"2020-09-06"}, "to": {"2021-09-06"}},
+			wantErr:  &BadRequestErrorMsg{"取得期間は1年以内で指定してください。"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			startDate, endDate, err := generateGroupTasksSchedulePeriod(tt.urlQuery, now)
+
+			if diff := cmp.Diff(tt.wantErr, err); len(diff) != 0 {
+				t.Fatalf("differs: (-want +got)\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.wantStartDate, startDate); len(diff) != 0 {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.wantEndDate, endDate); len(diff) != 0 {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateGroupTaskAssignmentList(t *testing.T) {
+	now := MockTime{}.Now()
+
+	groupTasksUsersList := []model.GroupTasksUser{
+		{ID: 1, UserID: "userID1", GroupID: 1},
+		{ID: 2, UserID: "userID2", GroupID: 1},
+		{ID: 3, UserID: "userID3", GroupID: 1},
+	}
+
+	newGroupTask := func(baseDate time.Time, cycleType string, cycle int, groupTasksUserID int) model.GroupTask {
+		return model.GroupTask{
+			ID:               1,
+			BaseDate:         model.NullTime{NullTime: sql.NullTime{Time: baseDate, Valid: true}},
+			CycleType:        model.NullString{NullString: sql.NullString{String: cycleType, Valid: true}},
+			Cycle:            model.NullInt{Int: cycle, Valid: true},
+			TaskName:         "洗濯",
+			GroupID:          1,
+			GroupTasksUserID: model.NullInt{Int: groupTasksUserID, Valid: true},
+		}
+	}
+
+	newGroupTaskAssignment := func(cycleType string, startDate time.Time, endDate time.Time, groupTasksUserID int) model.GroupTaskAssignment {
+		return model.GroupTaskAssignment{
+			GroupTasksID:     1,
+			TaskName:         "洗濯",
+			CycleType:        cycleType,
+			StartDate:        startDate,
+			EndDate:          endDate,
+			GroupTasksUserID: groupTasksUserID,
+			UserID:           groupTasksUsersList[groupTasksUserID-1].UserID,
+		}
+	}
+
+	tests := []struct {
+		name      string
+		groupTask model.GroupTask
+		from      time.Time
+		to        time.Time
+		want      []model.GroupTaskAssignment
+	}{
+		{
+			name:      "every cycle from the rotated base date",
+			groupTask: newGroupTask(time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC), "every", 2, 1),
+			from:      time.Date(2020, 9, 6, 0, 0, 0, 0, time.UTC),
+			to:        time.Date(2020, 9, 12, 23, 59, 59, 0, time.UTC),
+			want: []model.GroupTaskAssignment{
+				newGroupTaskAssignment("every", time.Date(2020, 9, 5, 0, 0, 0, 0, time.UTC), time.Date(2020, 9, 6, 0, 0, 0, 0, time.UTC), 3),
+				newGroupTaskAssignment("every", time.Date(2020, 9, 7, 0, 0, 0, 0, time.UTC), time.Date(2020, 9, 8, 0, 0, 0, 0, time.UTC), 1),
+				newGroupTaskAssignment("every", time.Date(2020, 9, 9, 0, 0, 0, 0, time.UTC), time.Date(2020, 9, 10, 0, 0, 0, 0, time.UTC), 2),
+				newGroupTaskAssignment("every", time.Date(2020, 9, 11, 0, 0, 0, 0, time.UTC), time.Date(2020, 9, 12, 0, 0, 0, 0, time.UTC), 3),
+			},
+		},
+		{
+			name:      "consecutive cycles far from today",
+			groupTask: newGroupTask(time.Date(2020, 9, 6, 0, 0, 0, 0, time.UTC), "consecutive", 7, 2),
+			from:      time.Date(2020, 12, 1, 0, 0, 0, 0, time.UTC),
+			to:        time.Date(2020, 12, 10, 23, 59, 59, 0, time.UTC),
+			want: []model.GroupTaskAssignment{
+				newGroupTaskAssignment("consecutive", time.Date(2020, 11, 29, 0, 0, 0, 0, time.UTC), time.Date(2020, 12, 5, 0, 0, 0, 0, time.UTC), 2),
+				newGroupTaskAssignment("consecutive", time.Date(2020, 12, 6, 0, 0, 0, 0, time.UTC), time.Date(2020, 12, 12, 0, 0, 0, 0, time.UTC), 3),
+			},
+		},
+		{
+			name:      "current cycle of the task not rotated",
+			groupTask: newGroupTask(time.Date(2020, 9, 5, 0, 0, 0, 0, time.UTC), "none", 3, 2),
+			from:      time.Date(2020, 9, 6, 0, 0, 0, 0, time.UTC),
+			to:        time.Date(2020, 9, 30, 23, 59, 59, 0, time.UTC),
+			want: []model.GroupTaskAssignment{
+				newGroupTaskAssignment("none", time.Date(2020, 9, 5, 0, 0, 0, 0, time.UTC), time.Date(2020, 9, 7, 0, 0, 0, 0, time.UTC), 2),
+			},
+		},
+		{
+			name:      "ended cycle of the task not rotated",
+			groupTask: newGroupTask(time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC), "none", 3, 2),
+			from:      time.Date(2020, 9, 6, 0, 0, 0, 0, time.UTC),
+			to:        time.Date(2020, 9, 30, 23, 59, 59, 0, time.UTC),
+			want:      nil,
+		},
+		{
+			name: "task assigned to no one",
+			groupTask: model.GroupTask{
+				ID:       1,
+				TaskName: "洗濯",
+				GroupID:  1,
+			},
+			from: time.Date(2020, 9, 6, 0, 0, 0, 0, time.UTC),
+			to:   time.Date(2020, 9, 30, 23, 59, 59, 0, time.UTC),
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groupTask := tt.groupTask
+
+			got := generateGroupTaskAssignmentList(groupTask, groupTasksUsersList, now, tt.from, tt.to)
+
+			if diff := cmp.Diff(tt.want, got); len(diff) != 0 {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.groupTask, groupTask); len(diff) != 0 {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestDBHandler_PostGroupTasksUsersList(t *testing.T) {
 	h := DBHandler{
 		AuthRepo:       MockAuthRepository{},
@@ -1,12 +1,12 @@
 package handler
 
 import (
-	"bytes"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/url"
 	"strconv"
-	"text/template"
 	"time"
 
 	"github.com/garyburd/redigo/redis"
@@ -15,189 +15,173 @@ import (
 	"github.com/hryze/kakeibo-app-api/todo-rest-service/domain/model"
 )
 
-type GroupTodoSearchQuery struct {
-	DateType     string
-	StartDate    string
-	EndDate      string
-	CompleteFlag string
-	TodoContent  string
-	Sort         string
-	SortType     string
-	Limit        string
-	GroupID      string
-	UsersID      []string
+// NewGroupTodoSearchCriteria generates the criteria of the group todo search from the url query in the same way as NewTodoSearchCriteria.
+func NewGroupTodoSearchCriteria(urlQuery url.Values) (model.GroupTodoSearchCriteria, error) {
+	todoSearchCriteria, err := NewTodoSearchCriteria(urlQuery)
+	if err != nil {
+		return model.GroupTodoSearchCriteria{}, err
+	}
+
+	return model.GroupTodoSearchCriteria{
+		TodoSearchCriteria: todoSearchCriteria,
+		UsersID:            urlQuery["user_id"],
+	}, nil
 }
 
-func NewGroupTodoSearchQuery(urlQuery url.Values, groupID string) (*GroupTodoSearchQuery, error) {
-	startDate, err := generateStartDate(urlQuery.Get("start_date"))
-	if err != nil {
-		return nil, err
+// generateGroupTodoSeries sets the recurrence of the edited group todo in the same way as generateTodoSeries.
+func generateGroupTodoSeries(groupTodo *model.GroupTodo, dbGroupTodo *model.GroupTodo, editScope string) {
+	groupTodo.SeriesID = dbGroupTodo.SeriesID
+
+	if dbGroupTodo.SeriesID.Valid && editScope == model.TodoEditScopeThis {
+		groupTodo.RecurrenceType = dbGroupTodo.RecurrenceType
+		groupTodo.RecurrenceRule = dbGroupTodo.RecurrenceRule
+		groupTodo.RecurrenceInterval = dbGroupTodo.RecurrenceInterval
+		groupTodo.RecurrenceStartDate = dbGroupTodo.RecurrenceStartDate
+		return
 	}
 
-	endDate, err := generateEndDate(urlQuery.Get("end_date"))
-	if err != nil {
-		return nil, err
+	groupTodo.RecurrenceRule, groupTodo.RecurrenceInterval = generateTodoRecurrence(groupTodo.RecurrenceType, groupTodo.RecurrenceRule, groupTodo.RecurrenceInterval)
+	if !groupTodo.RecurrenceType.Valid {
+		groupTodo.RecurrenceStartDate = model.NullTime{}
+		return
 	}
 
-	return &GroupTodoSearchQuery{
-		DateType:     urlQuery.Get("date_type"),
-		StartDate:    startDate,
-		EndDate:      endDate,
-		CompleteFlag: urlQuery.Get("complete_flag"),
-		TodoContent:  urlQuery.Get("todo_content"),
-		Sort:         urlQuery.Get("sort"),
-		SortType:     urlQuery.Get("sort_type"),
-		Limit:        urlQuery.Get("limit"),
-		GroupID:      groupID,
-		UsersID:      urlQuery["user_id"],
-	}, nil
+	if !groupTodo.SeriesID.Valid {
+		groupTodo.SeriesID = model.NullInt64{NullInt64: sql.NullInt64{Int64: int64(dbGroupTodo.ID), Valid: true}}
+	}
+
+	groupTodo.RecurrenceStartDate = model.NullTime{NullTime: sql.NullTime{Time: groupTodo.ImplementationDate.Time, Valid: true}}
 }
 
-func generateGroupTodoSqlQuery(groupTodoSearchQuery *GroupTodoSearchQuery) (string, error) {
-	query := `
-        SELECT
-            id,
-            posted_date,
-            updated_date,
-            implementation_date,
-            due_date,
-            todo_content,
-            complete_flag,
-            user_id
-        FROM
-            group_todo_list
-        WHERE
-            group_id = {{.GroupID}}
-
-        {{ if eq (len .UsersID) 1 }}
-        {{ range $i, $UserID := .UsersID }}
-        AND
-            user_id = "{{ $UserID }}"
-        {{ end }}
-        {{ end }}
-
-        {{ if gt (len .UsersID) 1 }}
-        {{ range $i, $UserID := .UsersID }}
-        {{ if eq $i 0}}
-        AND
-            user_id IN("{{ $UserID }}"
-        {{ end }}
-        {{ if gt $i 0 }}
-        ,"{{ $UserID }}"
-        {{ end }}
-        {{ end }}
-        {{ end }}
-        {{ if gt (len .UsersID) 1 }}
-        )
-        {{ end }}
-
-        {{ with $DateType := .DateType }}
-        AND
-            {{ $DateType }} >= "{{ $.StartDate }}"
-        AND
-            {{ $DateType }} <= "{{ $.EndDate }}"
-        {{ else }}
-        AND
-            implementation_date >= "{{ .StartDate }}"
-        AND
-            implementation_date <= "{{ .EndDate }}"
-        {{ end }}
-
-        {{ with $CompleteFlag := .CompleteFlag }}
-        AND
-            complete_flag = {{ $CompleteFlag }}
-        {{ end }}
-
-        {{ with $TodoContent := .TodoContent }}
-        AND
-            todo_content
-        LIKE
-            "%{{ $TodoContent }}%"
-        {{ end }}
-
-        {{ with $Sort := .Sort}}
-        ORDER BY
-            {{ $Sort }}
-        {{ else }}
-        ORDER BY
-            implementation_date
-        {{ end }}
-
-        {{ with $SortType := .SortType}}
-        {{ $SortType }}, updated_date DESC
-        {{ else }}
-        ASC, updated_date DESC
-        {{ end }}
-
-        {{ with $Limit := .Limit}}
-        LIMIT
-        {{ $Limit }}
-        {{ end }}`
-
-	var buffer bytes.Buffer
-	groupTodoSqlQueryTemplate, err := template.New("GroupTodoSqlQueryTemplate").Parse(query)
-	if err != nil {
-		return "", err
-	}
-
-	if err := groupTodoSqlQueryTemplate.Execute(&buffer, groupTodoSearchQuery); err != nil {
-		return "", err
-	}
-
-	return buffer.String(), nil
+// generateGroupTodoCompletion keeps who completed the group todo and when while the group todo stays completed,
+// and clears them when the group todo is reopened. They are recorded by the handler when the group todo is completed.
+func generateGroupTodoCompletion(groupTodo *model.GroupTodo, dbGroupTodo *model.GroupTodo) {
+	if !groupTodo.CompleteFlag || !dbGroupTodo.CompleteFlag {
+		groupTodo.CompletedUserID = model.NullString{}
+		groupTodo.CompletedDate = model.NullTime{}
+		return
+	}
+
+	groupTodo.CompletedUserID = dbGroupTodo.CompletedUserID
+	groupTodo.CompletedDate = dbGroupTodo.CompletedDate
+}
+
+// verifyGroupTodoAssigneeList verifies that the assignees newly added to the group todo belong to the group.
+// The assignees already assigned are not verified again, so that the group todo can be edited after one of them leaves the group.
+func verifyGroupTodoAssigneeList(groupID int, assigneeList []string, dbAssigneeList []string) error {
+	dbAssignees := make(map[string]bool, len(dbAssigneeList))
+	for _, assigneeUserID := range dbAssigneeList {
+		dbAssignees[assigneeUserID] = true
+	}
+
+	newAssigneeList := make([]string, 0, len(assigneeList))
+	for _, assigneeUserID := range assigneeList {
+		if !dbAssignees[assigneeUserID] {
+			newAssigneeList = append(newAssigneeList, assigneeUserID)
+		}
+	}
+
+	if len(newAssigneeList) == 0 {
+		return nil
+	}
+
+	if err := verifyGroupAffiliationOfUsersList(groupID, model.GroupTasksUsersListReceiver{GroupUsersList: newAssigneeList}); err != nil {
+		if _, ok := err.(*BadRequestErrorMsg); !ok {
+			return err
+		}
+
+		return &BadRequestErrorMsg{"担当者はグループに所属するメンバーから選択してください。"}
+	}
+
+	return nil
+}
+
+// generateAssignedGroupTodoList groups the assigned group todo list sorted by group ID for each group.
+// The group todo list of a group which the user no longer belongs to is left out.
+func generateAssignedGroupTodoList(groupTodoList []model.GroupTodo, userID string) (model.AssignedGroupTodoList, error) {
+	assignedGroupTodoList := model.AssignedGroupTodoList{
+		AssignedGroupTodoList: make([]model.GroupTodoListByGroup, 0),
+	}
+
+	for i := 0; i < len(groupTodoList); {
+		groupID := groupTodoList[i].GroupID
+
+		j := i
+		for j < len(groupTodoList) && groupTodoList[j].GroupID == groupID {
+			j++
+		}
+
+		if err := verifyGroupAffiliation(groupID, userID); err != nil {
+			if _, ok := err.(*BadRequestErrorMsg); !ok {
+				return model.AssignedGroupTodoList{}, err
+			}
+
+			i = j
+			continue
+		}
+
+		assignedGroupTodoList.AssignedGroupTodoList = append(assignedGroupTodoList.AssignedGroupTodoList, model.GroupTodoListByGroup{
+			GroupID:       groupID,
+			GroupTodoList: groupTodoList[i:j],
+		})
+
+		i = j
+	}
+
+	return assignedGroupTodoList, nil
 }
 
 func (h *DBHandler) GetDailyGroupTodoList(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	date, err := time.Parse("2006-01-02", mux.Vars(r)["date"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"日付を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"日付を正しく指定してください。"}))
 		return
 	}
 
-	implementationGroupTodoList, err := h.GroupTodoRepo.GetDailyImplementationGroupTodoList(date, groupID)
+	implementationGroupTodoList, err := h.GroupTodoRepo.GetDailyImplementationGroupTodoList(r.Context(), date, groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	dueGroupTodoList, err := h.GroupTodoRepo.GetDailyDueGroupTodoList(date, groupID)
+	dueGroupTodoList, err := h.GroupTodoRepo.GetDailyDueGroupTodoList(r.Context(), date, groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if len(implementationGroupTodoList) == 0 && len(dueGroupTodoList) == 0 {
 		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 		w.WriteHeader(http.StatusOK)
-		if err := json.NewEncoder(w).Encode(&NoContentMsg{"今日実施予定todo、締切予定todoは登録されていません。"}); err != nil {
+		if err := json.NewEncoder(w).Encode(&NoContentMsg{localizeMessage(r.Context(), "今日実施予定todo、締切予定todoは登録されていません。")}); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
@@ -219,34 +203,34 @@ func (h *DBHandler) GetMonthlyGroupTodoList(w http.ResponseWriter, r *http.Reque
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	firstDay, err := time.Parse("2006-01", mux.Vars(r)["year_month"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
 		return
 	}
 
@@ -254,20 +238,20 @@ func (h *DBHandler) GetMonthlyGroupTodoList(w http.ResponseWriter, r *http.Reque
 
 	implementationGroupTodoList, err := h.GroupTodoRepo.GetMonthlyImplementationGroupTodoList(firstDay, lastDay, groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	dueGroupTodoList, err := h.GroupTodoRepo.GetMonthlyDueGroupTodoList(firstDay, lastDay, groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if len(implementationGroupTodoList) == 0 && len(dueGroupTodoList) == 0 {
 		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 		w.WriteHeader(http.StatusOK)
-		if err := json.NewEncoder(w).Encode(&NoContentMsg{"当月実施予定todoは登録されていません。"}); err != nil {
+		if err := json.NewEncoder(w).Encode(&NoContentMsg{localizeMessage(r.Context(), "当月実施予定todoは登録されていません。")}); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
@@ -289,37 +273,128 @@ func (h *DBHandler) GetExpiredGroupTodoList(w http.ResponseWriter, r *http.Reque
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		return
+	}
+
+	if err := verifyGroupAffiliation(groupID, userID); err != nil {
+		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
+		if !ok {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		return
+	}
+
+	now := h.TimeManage.Now()
+	dueDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+
+	expiredGroupTodoList, err := h.GroupTodoRepo.GetExpiredGroupTodoList(r.Context(), dueDate, groupID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&expiredGroupTodoList); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) GetAssignedGroupTodoList(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	groupTodoList, err := h.GroupTodoRepo.GetAssignedGroupTodoList(userID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	assignedGroupTodoList, err := generateAssignedGroupTodoList(groupTodoList, userID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&assignedGroupTodoList); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) GetExpiredAssignedGroupTodoList(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		return
+	}
+
+	assigneeUserID := mux.Vars(r)["user_id"]
+
+	if err := verifyGroupAffiliationOfUsersList(groupID, model.GroupTasksUsersListReceiver{GroupUsersList: []string{assigneeUserID}}); err != nil {
+		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
+		if !ok {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	now := h.TimeManage.Now()
 	dueDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
 
-	expiredGroupTodoList, err := h.GroupTodoRepo.GetExpiredGroupTodoList(dueDate, groupID)
+	expiredGroupTodoList, err := h.GroupTodoRepo.GetExpiredAssignedGroupTodoList(dueDate, groupID, assigneeUserID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -335,57 +410,75 @@ func (h *DBHandler) PostGroupTodo(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	var groupTodo model.GroupTodo
 	if err := json.NewDecoder(r.Body).Decode(&groupTodo); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if err := validateTodo(&groupTodo); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, err))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
 		return
 	}
 
+	if err := verifyGroupTodoAssigneeList(groupID, groupTodo.AssigneeList, nil); err != nil {
+		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
+		if !ok {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		return
+	}
+
+	groupTodo.SubtaskList = generateTodoSubtaskList(groupTodo.SubtaskList, nil)
+
+	groupTodo.RecurrenceRule, groupTodo.RecurrenceInterval = generateTodoRecurrence(groupTodo.RecurrenceType, groupTodo.RecurrenceRule, groupTodo.RecurrenceInterval)
+	if groupTodo.RecurrenceType.Valid {
+		groupTodo.RecurrenceStartDate = model.NullTime{NullTime: sql.NullTime{Time: groupTodo.ImplementationDate.Time, Valid: true}}
+	}
+
 	result, err := h.GroupTodoRepo.PostGroupTodo(&groupTodo, userID, groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	lastInsertId, err := result.LastInsertId()
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	dbGroupTodo, err := h.GroupTodoRepo.GetGroupTodo(int(lastInsertId))
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -401,56 +494,101 @@ func (h *DBHandler) PutGroupTodo(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	var groupTodo model.GroupTodo
 	if err := json.NewDecoder(r.Body).Decode(&groupTodo); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if err := validateTodo(&groupTodo); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, err))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
 		return
 	}
 
 	groupTodoID, err := strconv.Atoi(mux.Vars(r)["id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"todo ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"todo ID を正しく指定してください。"}))
+		return
+	}
+
+	editScope, err := generateTodoEditScope(r.URL.Query().Get("scope"))
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
+		return
+	}
+
+	dbGroupTodo, err := h.GroupTodoRepo.GetGroupTodo(groupTodoID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"指定されたtodoは存在しません。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if err := verifyGroupTodoAssigneeList(groupID, groupTodo.AssigneeList, dbGroupTodo.AssigneeList); err != nil {
+		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
+		if !ok {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
-	if err := h.GroupTodoRepo.PutGroupTodo(&groupTodo, groupTodoID); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+	generateGroupTodoSeries(&groupTodo, dbGroupTodo, editScope)
+	generateGroupTodoCompletion(&groupTodo, dbGroupTodo)
+	groupTodo.SubtaskList = generateTodoSubtaskList(groupTodo.SubtaskList, dbGroupTodo.SubtaskList)
+
+	var nextGroupTodo *model.GroupTodo
+	if !dbGroupTodo.CompleteFlag && groupTodo.CompleteFlag {
+		now := h.TimeManage.Now()
+		groupTodo.CompletedUserID = model.NullString{NullString: sql.NullString{String: userID, Valid: true}}
+		groupTodo.CompletedDate = model.NullTime{NullTime: sql.NullTime{Time: now, Valid: true}}
+
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+		if next, ok := groupTodo.NextGroupTodo(today); ok {
+			nextGroupTodo = &next
+		}
+	}
+
+	if err := h.GroupTodoRepo.PutGroupTodo(&groupTodo, groupTodoID, editScope, nextGroupTodo); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	dbGroupTodo, err := h.GroupTodoRepo.GetGroupTodo(int(groupTodoID))
+	dbGroupTodo, err = h.GroupTodoRepo.GetGroupTodo(groupTodoID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -466,45 +604,45 @@ func (h *DBHandler) DeleteGroupTodo(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	groupTodoID, err := strconv.Atoi(mux.Vars(r)["id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"todo ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"todo ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := h.GroupTodoRepo.DeleteGroupTodo(groupTodoID); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(&DeleteContentMsg{"todoを削除しました。"}); err != nil {
+	if err := json.NewEncoder(w).Encode(&DeleteContentMsg{localizeMessage(r.Context(), "todoを削除しました。")}); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -514,60 +652,52 @@ func (h *DBHandler) SearchGroupTodoList(w http.ResponseWriter, r *http.Request)
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	strGroupID := mux.Vars(r)["group_id"]
-
-	groupID, err := strconv.Atoi(strGroupID)
+	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := verifyGroupAffiliation(groupID, userID); err != nil {
 		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
 		if !ok {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
 		return
 	}
 
 	if err := r.ParseForm(); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
-		return
-	}
-
-	groupTodoSearchQuery, err := NewGroupTodoSearchQuery(r.Form, strGroupID)
-	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"日付を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	groupTodoSqlQuery, err := generateGroupTodoSqlQuery(groupTodoSearchQuery)
+	groupTodoSearchCriteria, err := NewGroupTodoSearchCriteria(r.Form)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
 		return
 	}
 
-	dbSearchGroupTodoList, err := h.GroupTodoRepo.SearchGroupTodoList(groupTodoSqlQuery)
+	dbSearchGroupTodoList, err := h.GroupTodoRepo.SearchGroupTodoList(groupTodoSearchCriteria, groupID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if len(dbSearchGroupTodoList) == 0 {
 		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 		w.WriteHeader(http.StatusOK)
-		if err := json.NewEncoder(w).Encode(&NoContentMsg{"条件に一致するtodoは見つかりませんでした。"}); err != nil {
+		if err := json.NewEncoder(w).Encode(&NoContentMsg{localizeMessage(r.Context(), "条件に一致するtodoは見つかりませんでした。")}); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
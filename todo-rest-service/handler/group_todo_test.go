@@ -1,13 +1,16 @@
 package handler
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 
@@ -18,13 +21,13 @@ import (
 
 type MockGroupTodoRepository struct{}
 
-func (m MockGroupTodoRepository) GetDailyImplementationGroupTodoList(date time.Time, groupID int) ([]model.GroupTodo, error) {
+func (m MockGroupTodoRepository) GetDailyImplementationGroupTodoList(ctx context.Context, date time.Time, groupID int) ([]model.GroupTodo, error) {
 	return []model.GroupTodo{
-		{ID: 3, PostedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 12, 0, 0, 0, 0, time.UTC)}, TodoContent: "醤油購入", CompleteFlag: false, UserID: "userID1"},
+		{ID: 3, PostedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 12, 0, 0, 0, 0, time.UTC)}, TodoContent: "醤油購入", CompleteFlag: false, UserID: "userID1", Priority: 2, LabelList: []string{"買い物"}, SubtaskList: []model.TodoSubtask{{ID: 1, SubtaskContent: "濃口醤油", CompleteFlag: true}, {ID: 2, SubtaskContent: "薄口醤油", CompleteFlag: false}}, AssigneeList: []string{"userID2"}},
 	}, nil
 }
 
-func (m MockGroupTodoRepository) GetDailyDueGroupTodoList(date time.Time, groupID int) ([]model.GroupTodo, error) {
+func (m MockGroupTodoRepository) GetDailyDueGroupTodoList(ctx context.Context, date time.Time, groupID int) ([]model.GroupTodo, error) {
 	return []model.GroupTodo{
 		{ID: 2, PostedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 9, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, TodoContent: "コストコ鶏肉セール 5パック購入", CompleteFlag: true, UserID: "userID2"},
 	}, nil
@@ -34,7 +37,7 @@ func (m MockGroupTodoRepository) GetMonthlyImplementationGroupTodoList(firstDay
 	return []model.GroupTodo{
 		{ID: 1, PostedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 5, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 5, 0, 0, 0, 0, time.UTC)}, TodoContent: "今月の予算を立てる", CompleteFlag: true, UserID: "userID1"},
 		{ID: 2, PostedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 9, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, TodoContent: "コストコ鶏肉セール 5パック購入", CompleteFlag: true, UserID: "userID2"},
-		{ID: 3, PostedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 12, 0, 0, 0, 0, time.UTC)}, TodoContent: "醤油購入", CompleteFlag: false, UserID: "userID1"},
+		{ID: 3, PostedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 12, 0, 0, 0, 0, time.UTC)}, TodoContent: "醤油購入", CompleteFlag: false, UserID: "userID1", Priority: 2, LabelList: []string{"買い物"}, SubtaskList: []model.TodoSubtask{{ID: 1, SubtaskContent: "濃口醤油", CompleteFlag: true}, {ID: 2, SubtaskContent: "薄口醤油", CompleteFlag: false}}, AssigneeList: []string{"userID2"}},
 	}, nil
 }
 
@@ -42,15 +45,31 @@ func (m MockGroupTodoRepository) GetMonthlyDueGroupTodoList(firstDay time.Time,
 	return []model.GroupTodo{
 		{ID: 1, PostedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 5, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 5, 0, 0, 0, 0, time.UTC)}, TodoContent: "今月の予算を立てる", CompleteFlag: true, UserID: "userID1"},
 		{ID: 2, PostedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 9, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, TodoContent: "コストコ鶏肉セール 5パック購入", CompleteFlag: true, UserID: "userID2"},
-		{ID: 3, PostedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 12, 0, 0, 0, 0, time.UTC)}, TodoContent: "醤油購入", CompleteFlag: false, UserID: "userID1"},
+		{ID: 3, PostedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 12, 0, 0, 0, 0, time.UTC)}, TodoContent: "醤油購入", CompleteFlag: false, UserID: "userID1", Priority: 2, LabelList: []string{"買い物"}, SubtaskList: []model.TodoSubtask{{ID: 1, SubtaskContent: "濃口醤油", CompleteFlag: true}, {ID: 2, SubtaskContent: "薄口醤油", CompleteFlag: false}}, AssigneeList: []string{"userID2"}},
 	}, nil
 }
 
-func (m MockGroupTodoRepository) GetExpiredGroupTodoList(dueDate time.Time, groupID int) (*model.ExpiredGroupTodoList, error) {
+func (m MockGroupTodoRepository) GetExpiredGroupTodoList(ctx context.Context, dueDate time.Time, groupID int) (*model.ExpiredGroupTodoList, error) {
 	return &model.ExpiredGroupTodoList{
 		ExpiredGroupTodoList: []model.GroupTodo{
 			{ID: 1, PostedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 5, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 5, 0, 0, 0, 0, time.UTC)}, TodoContent: "今月の予算を立てる", CompleteFlag: false, UserID: "userID1"},
-			{ID: 3, PostedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 12, 0, 0, 0, 0, time.UTC)}, TodoContent: "醤油購入", CompleteFlag: false, UserID: "userID2"},
+			{ID: 3, PostedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 12, 0, 0, 0, 0, time.UTC)}, TodoContent: "醤油購入", CompleteFlag: false, UserID: "userID2", Priority: 2, LabelList: []string{"買い物"}, SubtaskList: []model.TodoSubtask{{ID: 1, SubtaskContent: "濃口醤油", CompleteFlag: true}, {ID: 2, SubtaskContent: "薄口醤油", CompleteFlag: false}}, AssigneeList: []string{"userID2"}},
+		},
+	}, nil
+}
+
+func (m MockGroupTodoRepository) GetAssignedGroupTodoList(userID string) ([]model.GroupTodo, error) {
+	return []model.GroupTodo{
+		{ID: 3, PostedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 12, 0, 0, 0, 0, time.UTC)}, TodoContent: "醤油購入", CompleteFlag: false, UserID: "userID2", Priority: 2, LabelList: []string{"買い物"}, SubtaskList: []model.TodoSubtask{}, AssigneeList: []string{"userID1"}, GroupID: 1},
+		{ID: 5, PostedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 12, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 15, 0, 0, 0, 0, time.UTC)}, TodoContent: "町内会費支払い", CompleteFlag: false, UserID: "userID1", LabelList: []string{}, SubtaskList: []model.TodoSubtask{}, AssigneeList: []string{"userID1", "userID2"}, GroupID: 1},
+		{ID: 8, PostedDate: time.Date(2020, 9, 6, 10, 12, 30, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 6, 10, 12, 30, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 11, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 11, 0, 0, 0, 0, time.UTC)}, TodoContent: "実家に電話", CompleteFlag: false, UserID: "userID3", LabelList: []string{}, SubtaskList: []model.TodoSubtask{}, AssigneeList: []string{"userID1"}, GroupID: 2},
+	}, nil
+}
+
+func (m MockGroupTodoRepository) GetExpiredAssignedGroupTodoList(dueDate time.Time, groupID int, userID string) (*model.ExpiredGroupTodoList, error) {
+	return &model.ExpiredGroupTodoList{
+		ExpiredGroupTodoList: []model.GroupTodo{
+			{ID: 3, PostedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 12, 0, 0, 0, 0, time.UTC)}, TodoContent: "醤油購入", CompleteFlag: false, UserID: "userID1", Priority: 2, LabelList: []string{"買い物"}, SubtaskList: []model.TodoSubtask{}, AssigneeList: []string{"userID2"}},
 		},
 	}, nil
 }
@@ -65,6 +84,13 @@ func (m MockGroupTodoRepository) GetGroupTodo(groupTodoId int) (*model.GroupTodo
 		TodoContent:        "今月の予算を立てる",
 		CompleteFlag:       false,
 		UserID:             "userID1",
+		Priority:           3,
+		LabelList:          []string{"家計"},
+		SubtaskList: []model.TodoSubtask{
+			{ID: 1, SubtaskContent: "先月の支出を確認", CompleteFlag: true},
+			{ID: 2, SubtaskContent: "食費の予算を決める", CompleteFlag: false},
+		},
+		AssigneeList: []string{"userID1"},
 	}, nil
 }
 
@@ -72,7 +98,7 @@ func (m MockGroupTodoRepository) PostGroupTodo(groupTodo *model.GroupTodo, userI
 	return MockSqlResult{}, nil
 }
 
-func (m MockGroupTodoRepository) PutGroupTodo(groupTodo *model.GroupTodo, groupTodoID int) error {
+func (m MockGroupTodoRepository) PutGroupTodo(groupTodo *model.GroupTodo, groupTodoID int, editScope string, nextGroupTodo *model.GroupTodo) error {
 	return nil
 }
 
@@ -80,7 +106,7 @@ func (m MockGroupTodoRepository) DeleteGroupTodo(groupTodoID int) error {
 	return nil
 }
 
-func (m MockGroupTodoRepository) SearchGroupTodoList(groupTodoSqlQuery string) ([]model.GroupTodo, error) {
+func (m MockGroupTodoRepository) SearchGroupTodoList(groupTodoSearchCriteria model.GroupTodoSearchCriteria, groupID int) ([]model.GroupTodo, error) {
 	return []model.GroupTodo{
 		{ID: 1, PostedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 5, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 5, 0, 0, 0, 0, time.UTC)}, TodoContent: "今月の予算を立てる", CompleteFlag: true, UserID: "userID1"},
 		{ID: 2, PostedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 5, 1, 29, 8, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 9, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, TodoContent: "コストコ鶏肉セール 5パック購入", CompleteFlag: true, UserID: "userID2"},
@@ -177,6 +203,62 @@ func TestDBHandler_GetExpiredGroupTodoList(t *testing.T) {
 	testutil.AssertResponseBody(t, res, &model.ExpiredGroupTodoList{}, &model.ExpiredGroupTodoList{})
 }
 
+func TestDBHandler_GetAssignedGroupTodoList(t *testing.T) {
+	h := DBHandler{
+		AuthRepo:      MockAuthRepository{},
+		GroupTodoRepo: MockGroupTodoRepository{},
+	}
+
+	r := httptest.NewRequest("GET", "/groups/todo-list/assigned", nil)
+	w := httptest.NewRecorder()
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.GetAssignedGroupTodoList(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &model.AssignedGroupTodoList{}, &model.AssignedGroupTodoList{})
+}
+
+func TestDBHandler_GetExpiredAssignedGroupTodoList(t *testing.T) {
+	h := DBHandler{
+		AuthRepo:      MockAuthRepository{},
+		GroupTodoRepo: MockGroupTodoRepository{},
+		TimeManage:    MockTime{},
+	}
+
+	r := httptest.NewRequest("GET", "/groups/1/todo-list/users/userID2/expired", nil)
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"group_id": "1",
+		"user_id":  "userID2",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.GetExpiredAssignedGroupTodoList(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &model.ExpiredGroupTodoList{}, &model.ExpiredGroupTodoList{})
+}
+
 func TestDBHandler_PostGroupTodo(t *testing.T) {
 	h := DBHandler{
 		AuthRepo:      MockAuthRepository{},
@@ -286,6 +368,8 @@ func TestDBHandler_SearchGroupTodoList(t *testing.T) {
 		"start_date":    "2020-07-05T00:00:00.0000",
 		"end_date":      "2020-07-10T00:00:00.0000",
 		"complete_flag": "true",
+		"priority":      "3",
+		"label":         "手続き",
 		"sort":          "due_date",
 	}
 
@@ -310,3 +394,194 @@ func TestDBHandler_SearchGroupTodoList(t *testing.T) {
 	testutil.AssertResponseHeader(t, res, http.StatusOK)
 	testutil.AssertResponseBody(t, res, &model.SearchGroupTodoList{}, &model.SearchGroupTodoList{})
 }
+
+// MockRecurringGroupTodoRepository keeps the group todo list in memory,
+// and spawns the next instances of the recurring group todos in the same way as the MySQL repository.
+type MockRecurringGroupTodoRepository struct {
+	MockGroupTodoRepository
+	groupTodoList []model.GroupTodo
+}
+
+func (m *MockRecurringGroupTodoRepository) GetGroupTodo(groupTodoId int) (*model.GroupTodo, error) {
+	if groupTodoId < 1 || groupTodoId > len(m.groupTodoList) {
+		return nil, sql.ErrNoRows
+	}
+
+	groupTodo := m.groupTodoList[groupTodoId-1]
+
+	return &groupTodo, nil
+}
+
+func (m *MockRecurringGroupTodoRepository) PutGroupTodo(groupTodo *model.GroupTodo, groupTodoID int, editScope string, nextGroupTodo *model.GroupTodo) error {
+	completeFlag := m.groupTodoList[groupTodoID-1].CompleteFlag
+
+	dbGroupTodo := *groupTodo
+	dbGroupTodo.ID = groupTodoID
+	dbGroupTodo.UserID = m.groupTodoList[groupTodoID-1].UserID
+	m.groupTodoList[groupTodoID-1] = dbGroupTodo
+
+	if nextGroupTodo == nil || completeFlag {
+		return nil
+	}
+
+	for _, followingGroupTodo := range m.groupTodoList {
+		if followingGroupTodo.SeriesID == nextGroupTodo.SeriesID && followingGroupTodo.ID > groupTodoID {
+			return nil
+		}
+	}
+
+	spawnedGroupTodo := *nextGroupTodo
+	spawnedGroupTodo.ID = len(m.groupTodoList) + 1
+	spawnedGroupTodo.UserID = dbGroupTodo.UserID
+	m.groupTodoList = append(m.groupTodoList, spawnedGroupTodo)
+
+	return nil
+}
+
+func TestDBHandler_PutGroupTodoWithAfterCompletionRecurrence(t *testing.T) {
+	groupTodoRepo := &MockRecurringGroupTodoRepository{
+		groupTodoList: []model.GroupTodo{
+			{
+				ID:                 1,
+				ImplementationDate: model.Date{Time: time.Date(2020, 7, 25, 0, 0, 0, 0, time.UTC)},
+				DueDate:            model.Date{Time: time.Date(2020, 7, 25, 0, 0, 0, 0, time.UTC)},
+				TodoContent:        "排水口掃除",
+				UserID:             "userID1",
+				RecurrenceType:     model.NullString{NullString: sql.NullString{String: "after_completion", Valid: true}},
+				RecurrenceInterval: model.NullInt{Int: 7, Valid: true},
+				SeriesID:           model.NullInt64{NullInt64: sql.NullInt64{Int64: 1, Valid: true}},
+			},
+		},
+	}
+
+	h := DBHandler{
+		AuthRepo:      MockAuthRepository{},
+		GroupTodoRepo: groupTodoRepo,
+		TimeManage:    &MockClock{now: time.Date(2020, 7, 28, 20, 0, 0, 0, time.UTC)},
+	}
+
+	for i := 0; i < 2; i++ {
+		r := httptest.NewRequest("PUT", "/groups/1/todo-list/1", strings.NewReader(`{"implementation_date": "2020-07-25", "due_date": "2020-07-25", "todo_content": "排水口掃除", "complete_flag": true}`))
+		w := httptest.NewRecorder()
+
+		r = mux.SetURLVars(r, map[string]string{
+			"group_id": "1",
+			"id":       "1",
+		})
+
+		cookie := &http.Cookie{
+			Name:  config.Env.Cookie.Name,
+			Value: uuid.New().String(),
+		}
+
+		r.AddCookie(cookie)
+
+		h.PutGroupTodo(w, r)
+
+		res := w.Result()
+		res.Body.Close()
+
+		testutil.AssertResponseHeader(t, res, http.StatusOK)
+	}
+
+	if len(groupTodoRepo.groupTodoList) != 2 {
+		t.Fatalf("group todo list length differs: want 2, got %d", len(groupTodoRepo.groupTodoList))
+	}
+
+	nextGroupTodo := groupTodoRepo.groupTodoList[1]
+	want := []string{"2020-08-04", "2020-08-04", "排水口掃除", "userID1"}
+	got := []string{nextGroupTodo.ImplementationDate.Format("2006-01-02"), nextGroupTodo.DueDate.Format("2006-01-02"), nextGroupTodo.TodoContent, nextGroupTodo.UserID}
+
+	if diff := cmp.Diff(want, got); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestDBHandler_PutGroupTodoWithCompletionAttribution(t *testing.T) {
+	groupTodoRepo := &MockRecurringGroupTodoRepository{
+		groupTodoList: []model.GroupTodo{
+			{
+				ID:                 1,
+				ImplementationDate: model.Date{Time: time.Date(2020, 7, 25, 0, 0, 0, 0, time.UTC)},
+				DueDate:            model.Date{Time: time.Date(2020, 7, 25, 0, 0, 0, 0, time.UTC)},
+				TodoContent:        "排水口掃除",
+				UserID:             "userID2",
+				AssigneeList:       []string{"userID1", "userID2"},
+			},
+		},
+	}
+
+	clock := &MockClock{}
+
+	h := DBHandler{
+		AuthRepo:      MockAuthRepository{},
+		GroupTodoRepo: groupTodoRepo,
+		TimeManage:    clock,
+	}
+
+	completedDate := time.Date(2020, 7, 28, 20, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name            string
+		now             time.Time
+		completeFlag    bool
+		completedUserID model.NullString
+		completedDate   model.NullTime
+	}{
+		{
+			name:            "complete",
+			now:             completedDate,
+			completeFlag:    true,
+			completedUserID: model.NullString{NullString: sql.NullString{String: "userID1", Valid: true}},
+			completedDate:   model.NullTime{NullTime: sql.NullTime{Time: completedDate, Valid: true}},
+		},
+		{
+			name:            "keep completion",
+			now:             completedDate.AddDate(0, 0, 1),
+			completeFlag:    true,
+			completedUserID: model.NullString{NullString: sql.NullString{String: "userID1", Valid: true}},
+			completedDate:   model.NullTime{NullTime: sql.NullTime{Time: completedDate, Valid: true}},
+		},
+		{
+			name:         "reopen",
+			now:          completedDate.AddDate(0, 0, 2),
+			completeFlag: false,
+		},
+	}
+
+	for _, tt := range testCases {
+		clock.now = tt.now
+
+		requestBody := fmt.Sprintf(`{"implementation_date": "2020-07-25", "due_date": "2020-07-25", "todo_content": "排水口掃除", "complete_flag": %t, "assignee_list": ["userID1", "userID2"]}`, tt.completeFlag)
+		r := httptest.NewRequest("PUT", "/groups/1/todo-list/1", strings.NewReader(requestBody))
+		w := httptest.NewRecorder()
+
+		r = mux.SetURLVars(r, map[string]string{
+			"group_id": "1",
+			"id":       "1",
+		})
+
+		cookie := &http.Cookie{
+			Name:  config.Env.Cookie.Name,
+			Value: uuid.New().String(),
+		}
+
+		r.AddCookie(cookie)
+
+		h.PutGroupTodo(w, r)
+
+		res := w.Result()
+		res.Body.Close()
+
+		testutil.AssertResponseHeader(t, res, http.StatusOK)
+
+		groupTodo := groupTodoRepo.groupTodoList[0]
+		if diff := cmp.Diff(tt.completedUserID, groupTodo.CompletedUserID); len(diff) != 0 {
+			t.Errorf("%s: completed user ID differs: (-want +got)\n%s", tt.name, diff)
+		}
+
+		if diff := cmp.Diff(tt.completedDate, groupTodo.CompletedDate); len(diff) != 0 {
+			t.Errorf("%s: completed date differs: (-want +got)\n%s", tt.name, diff)
+		}
+	}
+}
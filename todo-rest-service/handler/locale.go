@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	japaneseLocale = "ja"
+	englishLocale  = "en"
+	defaultLocale  = japaneseLocale
+)
+
+type localeContextKey struct{}
+
+// LocaleMiddleware chooses the locale of the response from the Accept-Language header, and stores it in the request context
+// so that handlers can localise messages and category names fetched from account-rest-service.
+func LocaleMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		locale := negotiateLocale(r.Header.Get("Accept-Language"))
+
+		w.Header().Set("Content-Language", locale)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), localeContextKey{}, locale)))
+	})
+}
+
+func isSupportedLocale(locale string) bool {
+	return locale == japaneseLocale || locale == englishLocale
+}
+
+// negotiateLocale returns the supported locale with the highest quality value in the Accept-Language header,
+// or the default locale if none of them is supported.
+func negotiateLocale(acceptLanguage string) string {
+	locale := defaultLocale
+	maxQuality := 0.0
+	for _, languageRange := range strings.Split(acceptLanguage, ",") {
+		params := strings.Split(languageRange, ";")
+
+		quality := 1.0
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if !strings.HasPrefix(param, "q=") {
+				continue
+			}
+
+			q, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64)
+			if err != nil {
+				q = 0
+			}
+
+			quality = q
+		}
+
+		language := strings.ToLower(strings.SplitN(strings.TrimSpace(params[0]), "-", 2)[0])
+		if !isSupportedLocale(language) || quality <= maxQuality {
+			continue
+		}
+
+		locale, maxQuality = language, quality
+	}
+
+	return locale
+}
+
+// requestLocale returns the locale stored by LocaleMiddleware, or the default locale if there is none.
+func requestLocale(ctx context.Context) string {
+	if locale, ok := ctx.Value(localeContextKey{}).(string); ok && isSupportedLocale(locale) {
+		return locale
+	}
+
+	return defaultLocale
+}
+
+func localizeMessage(ctx context.Context, message string) string {
+	return translateMessage(requestLocale(ctx), message)
+}
+
+// translateMessage looks up the message catalogue by the Japanese message.
+// Messages which are not in the catalogue are returned as they are.
+func translateMessage(locale string, message string) string {
+	if translatedMessage, ok := messageCatalogue[locale][message]; ok {
+		return translatedMessage
+	}
+
+	return message
+}
+
+// localizeMessageFields translates every settable string reachable from v in place.
+func localizeMessageFields(v reflect.Value, locale string) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			localizeMessageFields(v.Elem(), locale)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			localizeMessageFields(v.Field(i), locale)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			localizeMessageFields(v.Index(i), locale)
+		}
+	case reflect.String:
+		if v.CanSet() {
+			v.SetString(translateMessage(locale, v.String()))
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package handler
+
+// messageCatalogue maps each Japanese message to its translation by locale.
+var messageCatalogue = map[string]map[string]string{
+	englishLocale: {
+		// common
+		"このページを表示するにはログインが必要です。": "You need to log in to view this page.",
+		"指定されたグループに所属していません。":    "You do not belong to the specified group.",
+		"group ID を正しく指定してください。": "Please specify a valid group ID.",
+		"日付を正しく指定してください。":        "Please specify a valid date.",
+		"年月を正しく指定してください。":        "Please specify a valid year and month.",
+
+		// todo
+		"todo ID を正しく指定してください。":             "Please specify a valid todo ID.",
+		"todo実施日を正しく選択してください。":              "Please select a valid implementation date.",
+		"todo期限日を正しく選択してください。":              "Please select a valid due date.",
+		"内容が入力されていません。":                     "No content is entered.",
+		"内容は100文字以内で入力してください":               "Please enter content of 100 characters or less.",
+		"内容の文字列先頭か末尾に空白がないか確認してください。":       "Please check that the content does not start or end with a space.",
+		"todoを削除しました。":                      "The todo has been deleted.",
+		"今日実施予定todo、締切予定todoは登録されていません。":    "There are no todos to do or due today.",
+		"当月実施予定todoは登録されていません。":             "There are no todos to do this month.",
+		"条件に一致するtodoは見つかりませんでした。":           "No todos matched the conditions.",
+		"指定されたtodoは存在しません。":                 "The specified todo does not exist.",
+		"繰り返しの種類を正しく選択してください。":              "Please select a valid recurrence type.",
+		"完了後に繰り返す日数が入力されていません。":             "No number of days to repeat after completion is entered.",
+		"完了後に繰り返す日数は1以上365以下の整数を入力してください。":  "Please enter an integer from 1 to 365 for the number of days to repeat after completion.",
+		"編集範囲を正しく指定してください。":                 "Please specify a valid scope of the edit.",
+		"優先度を正しく選択してください。":                  "Please select a valid priority.",
+		"優先度を正しく指定してください。":                  "Please specify a valid priority.",
+		"日付の種類を正しく指定してください。":                "Please specify a valid date type.",
+		"完了状態を正しく指定してください。":                 "Please specify a valid completion status.",
+		"並び替えの項目を正しく指定してください。":              "Please specify a valid sort item.",
+		"並び順を正しく指定してください。":                  "Please specify a valid sort order.",
+		"取得件数を正しく指定してください。":                 "Please specify a valid number of todos to get.",
+		"ラベルは10個以内で登録してください。":               "Please register 10 labels or less.",
+		"同じラベルが重複して登録されています。":               "The same label is registered more than once.",
+		"ラベルが入力されていません。":                    "No label is entered.",
+		"ラベルは20文字以内で入力してください。":              "Please enter a label of 20 characters or less.",
+		"ラベルの文字列先頭か末尾に空白がないか確認してください。":      "Please check that the label does not start or end with a space.",
+		"サブタスクは30個以内で登録してください。":             "Please register 30 subtasks or less.",
+		"サブタスクの内容が入力されていません。":               "No subtask content is entered.",
+		"サブタスクの内容は100文字以内で入力してください。":        "Please enter subtask content of 100 characters or less.",
+		"サブタスクの内容の文字列先頭か末尾に空白がないか確認してください。": "Please check that the subtask content does not start or end with a space.",
+		"担当者は10人以内で選択してください。":               "Please select 10 assignees or less.",
+		"同じ担当者が重複して選択されています。":               "The same assignee is selected more than once.",
+		"担当者を正しく選択してください。":                  "Please select a valid assignee.",
+
+		// shopping list
+		"ショッピングアイテムIDを正しく指定してください。":      "Please specify a valid shopping item ID.",
+		"定期ショッピングアイテムIDを正しく指定してください。":    "Please specify a valid regular shopping item ID.",
+		"ショッピングアイテムを削除しました。":             "The shopping item has been deleted.",
+		"定期ショッピングアイテムを削除しました。":           "The regular shopping item has been deleted.",
+		"購入予定日が選択されていません。":               "No purchase date is selected.",
+		"購入予定日は今日以降の日付を選択してください。":        "Please select a purchase date of today or later.",
+		"購入周期タイプが選択されていません。":             "No purchase cycle type is selected.",
+		"購入周期タイプを正しく選択してください。":           "Please select a valid purchase cycle type.",
+		"購入周期は1以上の正の整数を入力してください。":        "Please enter a positive integer of 1 or more for the purchase cycle.",
+		"繰り返しルールが入力されていません。":             "No recurrence rule is entered.",
+		"繰り返しルールは255文字以内で入力してください。":      "Please enter a recurrence rule of 255 characters or less.",
+		"繰り返しルールを正しく入力してください。":           "Please enter a valid recurrence rule.",
+		"購入品は50文字以内で入力してください。":           "Please enter a purchase of 50 characters or less.",
+		"購入品の文字列先頭か末尾に空白がないか確認してください。":   "Please check that the purchase does not start or end with a space.",
+		"定期購入品は50文字以内で入力してください。":         "Please enter a regular purchase of 50 characters or less.",
+		"定期購入品の文字列先頭か末尾に空白がないか確認してください。": "Please check that the regular purchase does not start or end with a space.",
+		"店名は20文字以内で入力してください。":            "Please enter a shop name of 20 characters or less.",
+		"店名の文字列先頭か末尾に空白がないか確認してください。":    "Please check that the shop name does not start or end with a space.",
+		"店名は1文字以上20文字以内で入力してください。":       "Please enter a shop name of 1 to 20 characters.",
+		"担当者はグループに所属するメンバーから選択してください。":   "Please select the assignee from the members of the group.",
+		"この買い物リストは他のメンバーが担当しているか、既に更新されています。最新の買い物リストを確認してください。": "This shopping item is claimed by another member or has already been updated. Please check the latest shopping list.",
+		"この定期ショッピングアイテムは既に更新されています。最新の買い物リストを確認してください。":          "This regular shopping item has already been updated. Please check the latest shopping list.",
+		"指定された定期ショッピングアイテムは存在しません。":                              "The specified regular shopping item does not exist.",
+		"繰り返しが終了した定期ショッピングアイテムはスキップできません。":                       "A regular shopping item whose recurrence has ended cannot be skipped.",
+		"スキップする回数と日付は、どちらか一方を指定してください。":                          "Please specify either the number of times or the date to skip, not both.",
+		"スキップする回数または日付を指定してください。":                                "Please specify the number of times or the date to skip.",
+		"スキップする回数は1以上100以下の整数を入力してください。":                         "Please enter an integer from 1 to 100 for the number of times to skip.",
+		"スキップする日付は今日以降の日付を選択してください。":                             "Please select today or a later date as the date to skip.",
+		"スキップする日付は購入予定日から選択してください。":                              "Please select the date to skip from the expected purchase dates.",
+		"休止期間の終了日は今日以降の日付を選択してください。":                             "Please select today or a later date as the end date of the pause.",
+		"休止期間の終了日は1年以内の日付を選択してください。":                             "Please select a date within one year as the end date of the pause.",
+		"金額は1以上の正の整数を入力してください。":                                  "Please enter a positive integer of 1 or more for the amount.",
+		"金額は10億円以下で入力してください。":                                    "Please enter an amount of 1,000,000,000 yen or less.",
+		"数量は1以上の正の整数を入力してください。":                                  "Please enter a positive integer of 1 or more for the quantity.",
+		"数量は1000以下で入力してください。":                                    "Please enter a quantity of 1000 or less.",
+		"単位は10文字以内で入力してください。":                                    "Please enter a unit of 10 characters or less.",
+		"単位の文字列先頭か末尾に空白がないか確認してください。":                            "Please check that the unit does not start or end with a space.",
+		"単位を入力する場合は数量を入力してください。":                                 "Please enter the quantity when entering the unit.",
+		"単価は1以上の正の整数を入力してください。":                                  "Please enter a positive integer of 1 or more for the unit price.",
+		"単価は100万円以下で入力してください。":                                   "Please enter a unit price of 1,000,000 yen or less.",
+		"大カテゴリーが選択されていません。":                                      "No big category is selected.",
+		"大カテゴリーを正しく選択してください。":                                    "Please select a valid big category.",
+		"中カテゴリーを正しく選択してください。":                                    "Please select a valid medium category.",
+		"支払ユーザーを正しく選択してください。":                                    "Please select a valid payment user.",
+
+		// group task
+		"タスクIDを正しく指定してください。":             "Please specify a valid task ID.",
+		"指定されたタスクは存在しません。":               "The specified task does not exist.",
+		"タスク名は1文字以上20文字以内で入力してください。":     "Please enter a task name of 1 to 20 characters.",
+		"タスク名の文字列先頭に空白がないか確認してください。":     "Please check that the task name does not start with a space.",
+		"タスク名の文字列末尾に空白がないか確認してください。":     "Please check that the task name does not end with a space.",
+		"タスクを削除しました。":                    "The task has been deleted.",
+		"タスクメンバーを削除しました。":                "The task member has been deleted.",
+		"選択したユーザーは、既にタスクメンバーに追加されています。":  "The selected users have already been added to the task members.",
+		"選択したユーザーは、既にタスクメンバーから削除されています。": "The selected users have already been removed from the task members.",
+		"こちらのグループには、指定されたユーザーは所属していません。": "The specified users do not belong to this group.",
+		"開始日は今日以降の日付を指定してください。":          "Please specify today or a later date as the start date.",
+		"終了日は開始日以降の日付を指定してください。":         "Please specify the start date or a later date as the end date.",
+		"取得期間は1年以内で指定してください。":            "Please specify a period within one year.",
+
+		// dashboard
+		"データを取得できませんでした。":    "The data could not be fetched.",
+		"データの取得がタイムアウトしました。": "Fetching the data timed out.",
+
+		// calendar feed
+		"カレンダーの購読URLを無効にしました。": "The calendar subscription URL has been revoked.",
+		"カレンダーの購読URLが無効です。":    "The calendar subscription URL is invalid.",
+	},
+}
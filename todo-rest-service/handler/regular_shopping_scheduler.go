@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	regularShoppingSchedulerInterval = 1 * time.Minute
+	regularShoppingSchedulerLockName = "todo-rest-service:regular-shopping-scheduler"
+	regularShoppingSchedulerLockTime = 3 * time.Minute
+)
+
+// RunRegularShoppingScheduler materialises the shopping items of the due regular shopping items until ctx is done.
+// Only the instance holding the scheduler lock runs the schedule, and the others stand by to take over.
+func (h *DBHandler) RunRegularShoppingScheduler(ctx context.Context) {
+	owner := uuid.New().String()
+
+	ticker := time.NewTicker(regularShoppingSchedulerInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := runRegularShoppingSchedule(h, owner); err != nil {
+			log.Println(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			if err := h.SchedulerLockRepo.ReleaseSchedulerLock(regularShoppingSchedulerLockName, owner); err != nil {
+				log.Println(err)
+			}
+
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runRegularShoppingSchedule advances every regular shopping item whose expected purchase date has come,
+// so that the shopping item of the next expected purchase date is always materialised ahead of time.
+func runRegularShoppingSchedule(h *DBHandler, owner string) error {
+	leader, err := h.SchedulerLockRepo.AcquireSchedulerLock(regularShoppingSchedulerLockName, owner, regularShoppingSchedulerLockTime)
+	if err != nil {
+		return err
+	}
+
+	if !leader {
+		return nil
+	}
+
+	now := h.TimeManage.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	userIDList, err := h.ShoppingListRepo.GetDueRegularShoppingListUserIDList(today)
+	if err != nil {
+		return err
+	}
+
+	for _, userID := range userIDList {
+		regularShoppingList, err := h.ShoppingListRepo.GetRegularShoppingList(userID)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+
+		if err := h.ShoppingListRepo.PutRegularShoppingList(regularShoppingList, userID, today); err != nil {
+			log.Println(err)
+		}
+	}
+
+	groupIDList, err := h.GroupShoppingListRepo.GetDueGroupRegularShoppingListGroupIDList(today)
+	if err != nil {
+		return err
+	}
+
+	for _, groupID := range groupIDList {
+		groupRegularShoppingList, err := h.GroupShoppingListRepo.GetGroupRegularShoppingList(groupID)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+
+		if err := h.GroupShoppingListRepo.PutGroupRegularShoppingList(groupRegularShoppingList, groupID, today); err != nil {
+			log.Println(err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,373 @@
+package handler
+
+import (
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hryze/kakeibo-app-api/todo-rest-service/domain/model"
+)
+
+type MockClock struct {
+	now time.Time
+}
+
+func (c *MockClock) Now() time.Time {
+	return c.now
+}
+
+type MockSchedulerLockRepository struct {
+	mu    sync.Mutex
+	owner string
+}
+
+func (m *MockSchedulerLockRepository) AcquireSchedulerLock(name string, owner string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.owner != "" && m.owner != owner {
+		return false, nil
+	}
+
+	m.owner = owner
+
+	return true, nil
+}
+
+func (m *MockSchedulerLockRepository) ReleaseSchedulerLock(name string, owner string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.owner == owner {
+		m.owner = ""
+	}
+
+	return nil
+}
+
+// MockScheduledShoppingListRepository keeps the regular shopping list of a user in memory,
+// and materialises the shopping items by the same model.RegularShoppingMaterialization as the MySQL repository.
+type MockScheduledShoppingListRepository struct {
+	MockShoppingListRepository
+	userID              string
+	regularShoppingList model.RegularShoppingList
+	shoppingList        []model.ShoppingItem
+}
+
+func (m *MockScheduledShoppingListRepository) GetDueRegularShoppingListUserIDList(today time.Time) ([]string, error) {
+	for _, regularShoppingItem := range m.regularShoppingList.RegularShoppingList {
+		if !bool(regularShoppingItem.FinishedFlag) && !today.Before(regularShoppingItem.ExpectedPurchaseDate.Time) {
+			return []string{m.userID}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (m *MockScheduledShoppingListRepository) GetRegularShoppingList(userID string) (model.RegularShoppingList, error) {
+	regularShoppingList := model.RegularShoppingList{
+		RegularShoppingList: make([]model.RegularShoppingItem, len(m.regularShoppingList.RegularShoppingList)),
+	}
+
+	copy(regularShoppingList.RegularShoppingList, m.regularShoppingList.RegularShoppingList)
+
+	return regularShoppingList, nil
+}
+
+func (m *MockScheduledShoppingListRepository) PutRegularShoppingList(regularShoppingList model.RegularShoppingList, userID string, today time.Time) error {
+	for _, regularShoppingItem := range regularShoppingList.RegularShoppingList {
+		if today.Before(regularShoppingItem.ExpectedPurchaseDate.Time) {
+			continue
+		}
+
+		dbRegularShoppingItem := &m.regularShoppingList.RegularShoppingList[regularShoppingItem.ID-1]
+		if !dbRegularShoppingItem.ExpectedPurchaseDate.Equal(regularShoppingItem.ExpectedPurchaseDate.Time) {
+			continue
+		}
+
+		materialization := regularShoppingItem.Materialization(today)
+		for _, expectedPurchaseDate := range materialization.ExpectedPurchaseDateList {
+			m.shoppingList = append(m.shoppingList, model.ShoppingItem{
+				ExpectedPurchaseDate:  model.Date{Time: expectedPurchaseDate},
+				Purchase:              regularShoppingItem.Purchase,
+				RegularShoppingListID: model.NullInt64{NullInt64: sql.NullInt64{Int64: int64(regularShoppingItem.ID), Valid: true}},
+			})
+		}
+
+		dbRegularShoppingItem.ExpectedPurchaseDate = model.Date{Time: materialization.NextExpectedPurchaseDate}
+		dbRegularShoppingItem.FinishedFlag = model.BitBool(materialization.Finished)
+	}
+
+	return nil
+}
+
+func (m *MockScheduledShoppingListRepository) expectedPurchaseDateList(regularShoppingItemID int) []string {
+	expectedPurchaseDateList := make([]string, 0)
+	for _, shoppingItem := range m.shoppingList {
+		if shoppingItem.RegularShoppingListID.Int64 == int64(regularShoppingItemID) {
+			expectedPurchaseDateList = append(expectedPurchaseDateList, shoppingItem.ExpectedPurchaseDate.Format("2006-01-02"))
+		}
+	}
+
+	return expectedPurchaseDateList
+}
+
+// MockScheduledGroupShoppingListRepository keeps the regular shopping list of a group in memory,
+// and materialises the shopping items by the same model.RegularShoppingMaterialization as the MySQL repository.
+type MockScheduledGroupShoppingListRepository struct {
+	MockGroupShoppingListRepository
+	groupID                  int
+	groupRegularShoppingList model.GroupRegularShoppingList
+	groupShoppingList        []model.GroupShoppingItem
+}
+
+func (m *MockScheduledGroupShoppingListRepository) GetDueGroupRegularShoppingListGroupIDList(today time.Time) ([]int, error) {
+	for _, groupRegularShoppingItem := range m.groupRegularShoppingList.GroupRegularShoppingList {
+		if !bool(groupRegularShoppingItem.FinishedFlag) && !today.Before(groupRegularShoppingItem.ExpectedPurchaseDate.Time) {
+			return []int{m.groupID}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (m *MockScheduledGroupShoppingListRepository) GetGroupRegularShoppingList(groupID int) (model.GroupRegularShoppingList, error) {
+	groupRegularShoppingList := model.GroupRegularShoppingList{
+		GroupRegularShoppingList: make([]model.GroupRegularShoppingItem, len(m.groupRegularShoppingList.GroupRegularShoppingList)),
+	}
+
+	copy(groupRegularShoppingList.GroupRegularShoppingList, m.groupRegularShoppingList.GroupRegularShoppingList)
+
+	return groupRegularShoppingList, nil
+}
+
+func (m *MockScheduledGroupShoppingListRepository) PutGroupRegularShoppingList(groupRegularShoppingList model.GroupRegularShoppingList, groupID int, today time.Time) error {
+	for _, groupRegularShoppingItem := range groupRegularShoppingList.GroupRegularShoppingList {
+		if today.Before(groupRegularShoppingItem.ExpectedPurchaseDate.Time) {
+			continue
+		}
+
+		dbGroupRegularShoppingItem := &m.groupRegularShoppingList.GroupRegularShoppingList[groupRegularShoppingItem.ID-1]
+		if !dbGroupRegularShoppingItem.ExpectedPurchaseDate.Equal(groupRegularShoppingItem.ExpectedPurchaseDate.Time) {
+			continue
+		}
+
+		materialization := groupRegularShoppingItem.Materialization(today)
+		for _, expectedPurchaseDate := range materialization.ExpectedPurchaseDateList {
+			m.groupShoppingList = append(m.groupShoppingList, model.GroupShoppingItem{
+				ExpectedPurchaseDate:  model.Date{Time: expectedPurchaseDate},
+				Purchase:              groupRegularShoppingItem.Purchase,
+				RegularShoppingListID: model.NullInt64{NullInt64: sql.NullInt64{Int64: int64(groupRegularShoppingItem.ID), Valid: true}},
+			})
+		}
+
+		dbGroupRegularShoppingItem.ExpectedPurchaseDate = model.Date{Time: materialization.NextExpectedPurchaseDate}
+		dbGroupRegularShoppingItem.FinishedFlag = model.BitBool(materialization.Finished)
+	}
+
+	return nil
+}
+
+func (m *MockScheduledGroupShoppingListRepository) expectedPurchaseDateList(groupRegularShoppingItemID int) []string {
+	expectedPurchaseDateList := make([]string, 0)
+	for _, groupShoppingItem := range m.groupShoppingList {
+		if groupShoppingItem.RegularShoppingListID.Int64 == int64(groupRegularShoppingItemID) {
+			expectedPurchaseDateList = append(expectedPurchaseDateList, groupShoppingItem.ExpectedPurchaseDate.Format("2006-01-02"))
+		}
+	}
+
+	return expectedPurchaseDateList
+}
+
+func newMockScheduledShoppingListRepository() *MockScheduledShoppingListRepository {
+	return &MockScheduledShoppingListRepository{
+		userID: "userID1",
+		regularShoppingList: model.RegularShoppingList{
+			RegularShoppingList: []model.RegularShoppingItem{
+				{
+					ID:                   1,
+					ExpectedPurchaseDate: model.Date{Time: time.Date(2020, 9, 6, 0, 0, 0, 0, time.UTC)},
+					CycleType:            "weekly",
+					Cycle:                model.NullInt{Int: 0, Valid: false},
+					RecurrenceRule:       model.NullString{NullString: sql.NullString{String: "FREQ=WEEKLY", Valid: true}},
+					RecurrenceStartDate:  model.Date{Time: time.Date(2020, 9, 6, 0, 0, 0, 0, time.UTC)},
+					Purchase:             "米",
+				},
+				{
+					ID:                   2,
+					ExpectedPurchaseDate: model.Date{Time: time.Date(2020, 9, 7, 0, 0, 0, 0, time.UTC)},
+					CycleType:            "custom",
+					Cycle:                model.NullInt{Int: 3, Valid: true},
+					RecurrenceRule:       model.NullString{NullString: sql.NullString{String: "FREQ=DAILY;INTERVAL=3", Valid: true}},
+					RecurrenceStartDate:  model.Date{Time: time.Date(2020, 9, 7, 0, 0, 0, 0, time.UTC)},
+					Purchase:             "牛乳",
+				},
+				{
+					ID:                   3,
+					ExpectedPurchaseDate: model.Date{Time: time.Date(2020, 9, 12, 0, 0, 0, 0, time.UTC)},
+					CycleType:            "rrule",
+					Cycle:                model.NullInt{Int: 0, Valid: false},
+					RecurrenceRule:       model.NullString{NullString: sql.NullString{String: "FREQ=MONTHLY;BYDAY=2SA,4SA", Valid: true}},
+					RecurrenceStartDate:  model.Date{Time: time.Date(2020, 9, 12, 0, 0, 0, 0, time.UTC)},
+					Purchase:             "コーヒー豆",
+				},
+			},
+		},
+	}
+}
+
+func newMockScheduledGroupShoppingListRepository() *MockScheduledGroupShoppingListRepository {
+	return &MockScheduledGroupShoppingListRepository{
+		groupID: 1,
+		groupRegularShoppingList: model.GroupRegularShoppingList{
+			GroupRegularShoppingList: []model.GroupRegularShoppingItem{
+				{
+					ID:                   1,
+					ExpectedPurchaseDate: model.Date{Time: time.Date(2020, 9, 10, 0, 0, 0, 0, time.UTC)},
+					CycleType:            "rrule",
+					Cycle:                model.NullInt{Int: 0, Valid: false},
+					RecurrenceRule:       model.NullString{NullString: sql.NullString{String: "FREQ=WEEKLY;BYDAY=TH;COUNT=3", Valid: true}},
+					RecurrenceStartDate:  model.Date{Time: time.Date(2020, 9, 10, 0, 0, 0, 0, time.UTC)},
+					Purchase:             "トイレットペーパー",
+				},
+			},
+		},
+	}
+}
+
+func TestDBHandler_RunRegularShoppingSchedule(t *testing.T) {
+	shoppingListRepo := newMockScheduledShoppingListRepository()
+	groupShoppingListRepo := newMockScheduledGroupShoppingListRepository()
+	clock := &MockClock{}
+
+	h := DBHandler{
+		SchedulerLockRepo:     &MockSchedulerLockRepository{},
+		ShoppingListRepo:      shoppingListRepo,
+		GroupShoppingListRepo: groupShoppingListRepo,
+		TimeManage:            clock,
+	}
+
+	// Three weeks pass, and the scheduler runs every few hours.
+	start := time.Date(2020, 9, 6, 0, 0, 0, 0, time.UTC)
+	for clock.now = start; clock.now.Before(start.AddDate(0, 0, 21)); clock.now = clock.now.Add(6 * time.Hour) {
+		if err := runRegularShoppingSchedule(&h, "owner1"); err != nil {
+			t.Fatalf("unexpected error by runRegularShoppingSchedule() '%#v'", err)
+		}
+	}
+
+	if diff := cmp.Diff([]string{"2020-09-13", "2020-09-20", "2020-09-27"}, shoppingListRepo.expectedPurchaseDateList(1)); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+
+	if diff := cmp.Diff([]string{"2020-09-10", "2020-09-13", "2020-09-16", "2020-09-19", "2020-09-22", "2020-09-25", "2020-09-28"}, shoppingListRepo.expectedPurchaseDateList(2)); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+
+	if diff := cmp.Diff([]string{"2020-09-26", "2020-10-10"}, shoppingListRepo.expectedPurchaseDateList(3)); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+
+	// The recurrence of the group regular shopping item ends after three occurrences.
+	if diff := cmp.Diff([]string{"2020-09-17", "2020-09-24"}, groupShoppingListRepo.expectedPurchaseDateList(1)); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+
+	if !groupShoppingListRepo.groupRegularShoppingList.GroupRegularShoppingList[0].FinishedFlag {
+		t.Errorf("group regular shopping item is not finished")
+	}
+}
+
+func TestDBHandler_RunRegularShoppingScheduleAfterDowntime(t *testing.T) {
+	shoppingListRepo := newMockScheduledShoppingListRepository()
+	groupShoppingListRepo := newMockScheduledGroupShoppingListRepository()
+	clock := &MockClock{}
+
+	h := DBHandler{
+		SchedulerLockRepo:     &MockSchedulerLockRepository{},
+		ShoppingListRepo:      shoppingListRepo,
+		GroupShoppingListRepo: groupShoppingListRepo,
+		TimeManage:            clock,
+	}
+
+	// The scheduler is down for two weeks, and catches up with the missed expected purchase dates at once.
+	for _, now := range []time.Time{
+		time.Date(2020, 9, 5, 12, 0, 0, 0, time.UTC),
+		time.Date(2020, 9, 19, 12, 0, 0, 0, time.UTC),
+	} {
+		clock.now = now
+		if err := runRegularShoppingSchedule(&h, "owner1"); err != nil {
+			t.Fatalf("unexpected error by runRegularShoppingSchedule() '%#v'", err)
+		}
+	}
+
+	if diff := cmp.Diff([]string{"2020-09-13", "2020-09-20"}, shoppingListRepo.expectedPurchaseDateList(1)); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+
+	if diff := cmp.Diff([]string{"2020-09-10", "2020-09-13", "2020-09-16", "2020-09-19", "2020-09-22"}, shoppingListRepo.expectedPurchaseDateList(2)); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+
+	if diff := cmp.Diff([]string{"2020-09-26"}, shoppingListRepo.expectedPurchaseDateList(3)); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+
+	if diff := cmp.Diff([]string{"2020-09-17", "2020-09-24"}, groupShoppingListRepo.expectedPurchaseDateList(1)); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestDBHandler_RunRegularShoppingScheduleWithMultipleInstances(t *testing.T) {
+	shoppingListRepo := newMockScheduledShoppingListRepository()
+	groupShoppingListRepo := newMockScheduledGroupShoppingListRepository()
+	schedulerLockRepo := &MockSchedulerLockRepository{}
+	clock := &MockClock{}
+
+	h := DBHandler{
+		SchedulerLockRepo:     schedulerLockRepo,
+		ShoppingListRepo:      shoppingListRepo,
+		GroupShoppingListRepo: groupShoppingListRepo,
+		TimeManage:            clock,
+	}
+
+	// Two instances run the scheduler for a week, and the second one takes over after the first one releases the lock.
+	start := time.Date(2020, 9, 6, 0, 0, 0, 0, time.UTC)
+	for clock.now = start; clock.now.Before(start.AddDate(0, 0, 14)); clock.now = clock.now.AddDate(0, 0, 1) {
+		if clock.now.Equal(start.AddDate(0, 0, 7)) {
+			if err := schedulerLockRepo.ReleaseSchedulerLock(regularShoppingSchedulerLockName, "owner1"); err != nil {
+				t.Fatalf("unexpected error by ReleaseSchedulerLock() '%#v'", err)
+			}
+		}
+
+		for _, owner := range []string{"owner1", "owner2"} {
+			if clock.now.Before(start.AddDate(0, 0, 7)) || owner == "owner2" {
+				if err := runRegularShoppingSchedule(&h, owner); err != nil {
+					t.Fatalf("unexpected error by runRegularShoppingSchedule() '%#v'", err)
+				}
+			}
+		}
+	}
+
+	if schedulerLockRepo.owner != "owner2" {
+		t.Errorf("scheduler lock owner = %s, want owner2", schedulerLockRepo.owner)
+	}
+
+	if diff := cmp.Diff([]string{"2020-09-13", "2020-09-20"}, shoppingListRepo.expectedPurchaseDateList(1)); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+
+	if diff := cmp.Diff([]string{"2020-09-10", "2020-09-13", "2020-09-16", "2020-09-19", "2020-09-22"}, shoppingListRepo.expectedPurchaseDateList(2)); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+
+	if diff := cmp.Diff([]string{"2020-09-26"}, shoppingListRepo.expectedPurchaseDateList(3)); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+
+	if diff := cmp.Diff([]string{"2020-09-17", "2020-09-24"}, groupShoppingListRepo.expectedPurchaseDateList(1)); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
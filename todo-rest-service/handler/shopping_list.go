@@ -2,8 +2,11 @@ package handler
 
 import (
 	"bytes"
+	"context"
+	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -11,8 +14,11 @@ import (
 	"net"
 	"net/http"
 	"reflect"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/garyburd/redigo/redis"
 	"github.com/go-playground/validator"
@@ -79,6 +85,22 @@ func validateRegularShoppingItem(regularShoppingItem model.RegularShoppingItem)
 		return err
 	}
 
+	if err := validate.RegisterValidation("with_quantity", withQuantityValidation); err != nil {
+		return err
+	}
+
+	if err := validate.RegisterValidation("unit_price_total", unitPriceTotalValidation); err != nil {
+		return err
+	}
+
+	if err := validate.RegisterValidation("with_cycle", withCycleValidation); err != nil {
+		return err
+	}
+
+	if err := validate.RegisterValidation("recurrence_rule", recurrenceRuleValidation); err != nil {
+		return err
+	}
+
 	err := validate.Struct(regularShoppingItem)
 	if err == nil {
 		return nil
@@ -105,9 +127,23 @@ func validateRegularShoppingItem(regularShoppingItem model.RegularShoppingItem)
 				errorMessage = "購入周期タイプが選択されていません。"
 			case "oneof":
 				errorMessage = "購入周期タイプを正しく選択してください。"
+			case "with_cycle":
+				if err.Value() == "custom" {
+					errorMessage = "購入周期は1以上の正の整数を入力してください。"
+				} else {
+					errorMessage = "繰り返しルールが入力されていません。"
+				}
 			}
 		case "Cycle":
 			errorMessage = "購入周期は1以上の正の整数を入力してください。"
+		case "RecurrenceRule":
+			tagName := err.Tag()
+			switch tagName {
+			case "max":
+				errorMessage = "繰り返しルールは255文字以内で入力してください。"
+			case "recurrence_rule":
+				errorMessage = "繰り返しルールを正しく入力してください。"
+			}
 		case "Purchase":
 			tagName := err.Tag()
 			switch tagName {
@@ -125,7 +161,41 @@ func validateRegularShoppingItem(regularShoppingItem model.RegularShoppingItem)
 				errorMessage = "店名の文字列先頭か末尾に空白がないか確認してください。"
 			}
 		case "Amount":
-			errorMessage = "金額は1以上の正の整数を入力してください。"
+			tagName := err.Tag()
+			switch tagName {
+			case "min":
+				errorMessage = "金額は1以上の正の整数を入力してください。"
+			case "max":
+				errorMessage = "金額は10億円以下で入力してください。"
+			case "unit_price_total":
+				errorMessage = "金額は数量と単価の積と一致するように入力してください。"
+			}
+		case "Quantity":
+			tagName := err.Tag()
+			switch tagName {
+			case "min":
+				errorMessage = "数量は1以上の正の整数を入力してください。"
+			case "max":
+				errorMessage = "数量は1000以下で入力してください。"
+			}
+		case "Unit":
+			tagName := err.Tag()
+			switch tagName {
+			case "max":
+				errorMessage = "単位は10文字以内で入力してください。"
+			case "blank":
+				errorMessage = "単位の文字列先頭か末尾に空白がないか確認してください。"
+			case "with_quantity":
+				errorMessage = "単位を入力する場合は数量を入力してください。"
+			}
+		case "UnitPrice":
+			tagName := err.Tag()
+			switch tagName {
+			case "min":
+				errorMessage = "単価は1以上の正の整数を入力してください。"
+			case "max":
+				errorMessage = "単価は100万円以下で入力してください。"
+			}
 		case "BigCategoryID":
 			tagName := err.Tag()
 			switch tagName {
@@ -174,6 +244,14 @@ func validateShoppingItem(shoppingItem model.ShoppingItem) error {
 		return err
 	}
 
+	if err := validate.RegisterValidation("with_quantity", withQuantityValidation); err != nil {
+		return err
+	}
+
+	if err := validate.RegisterValidation("unit_price_total", unitPriceTotalValidation); err != nil {
+		return err
+	}
+
 	err := validate.Struct(shoppingItem)
 	if err == nil {
 		return nil
@@ -210,7 +288,41 @@ func validateShoppingItem(shoppingItem model.ShoppingItem) error {
 				errorMessage = "店名の文字列先頭か末尾に空白がないか確認してください。"
 			}
 		case "Amount":
-			errorMessage = "金額は1以上の正の整数を入力してください。"
+			tagName := err.Tag()
+			switch tagName {
+			case "min":
+				errorMessage = "金額は1以上の正の整数を入力してください。"
+			case "max":
+				errorMessage = "金額は10億円以下で入力してください。"
+			case "unit_price_total":
+				errorMessage = "金額は数量と単価の積と一致するように入力してください。"
+			}
+		case "Quantity":
+			tagName := err.Tag()
+			switch tagName {
+			case "min":
+				errorMessage = "数量は1以上の正の整数を入力してください。"
+			case "max":
+				errorMessage = "数量は1000以下で入力してください。"
+			}
+		case "Unit":
+			tagName := err.Tag()
+			switch tagName {
+			case "max":
+				errorMessage = "単位は10文字以内で入力してください。"
+			case "blank":
+				errorMessage = "単位の文字列先頭か末尾に空白がないか確認してください。"
+			case "with_quantity":
+				errorMessage = "単位を入力する場合は数量を入力してください。"
+			}
+		case "UnitPrice":
+			tagName := err.Tag()
+			switch tagName {
+			case "min":
+				errorMessage = "単価は1以上の正の整数を入力してください。"
+			case "max":
+				errorMessage = "単価は100万円以下で入力してください。"
+			}
 		case "BigCategoryID":
 			tagName := err.Tag()
 			switch tagName {
@@ -300,7 +412,132 @@ func eitherCategoryIDValidation(fl validator.FieldLevel) bool {
 	return false
 }
 
-func getShoppingItemCategoriesName(categoriesID CategoriesID) ([]byte, error) {
+// generateShoppingItemAmount returns the total computed from the quantity and the unit price when the amount is omitted with both given.
+// Otherwise it returns the amount as it is, which unitPriceTotalValidation has already checked against them.
+func generateShoppingItemAmount(amount model.NullInt64, quantity model.NullInt64, unitPrice model.NullInt64) model.NullInt64 {
+	if amount.Valid || !quantity.Valid || !unitPrice.Valid {
+		return amount
+	}
+
+	return model.NullInt64{NullInt64: sql.NullInt64{Int64: quantity.Int64 * unitPrice.Int64, Valid: true}}
+}
+
+// generateShoppingItemMemo returns the memo of the transaction related to the shopping item,
+// e.g. "【買い物リスト】牛乳 2本".
+func generateShoppingItemMemo(purchase string, quantity model.NullInt64, unit model.NullString) string {
+	if !quantity.Valid {
+		return fmt.Sprintf("【買い物リスト】%s", purchase)
+	}
+
+	return fmt.Sprintf("【買い物リスト】%s %d%s", purchase, quantity.Int64, unit.String)
+}
+
+func validateShopSectionOrder(shopSectionOrder *model.ShopSectionOrder) error {
+	if strings.HasPrefix(shopSectionOrder.Shop, " ") || strings.HasPrefix(shopSectionOrder.Shop, "　") || strings.HasSuffix(shopSectionOrder.Shop, " ") || strings.HasSuffix(shopSectionOrder.Shop, "　") {
+		return &BadRequestErrorMsg{"店名の文字列先頭か末尾に空白がないか確認してください。"}
+	}
+
+	if utf8.RuneCountInString(shopSectionOrder.Shop) == 0 || utf8.RuneCountInString(shopSectionOrder.Shop) > 20 {
+		return &BadRequestErrorMsg{"店名は1文字以上20文字以内で入力してください。"}
+	}
+
+	bigCategoryIDSet := make(map[int]bool, len(shopSectionOrder.BigCategoryIDList))
+	for _, bigCategoryID := range shopSectionOrder.BigCategoryIDList {
+		if bigCategoryID < 2 || bigCategoryID > 17 || bigCategoryIDSet[bigCategoryID] {
+			return &BadRequestErrorMsg{"大カテゴリーを正しく選択してください。"}
+		}
+
+		bigCategoryIDSet[bigCategoryID] = true
+	}
+
+	return nil
+}
+
+func withQuantityValidation(fl validator.FieldLevel) bool {
+	switch item := fl.Parent().Interface().(type) {
+	case model.RegularShoppingItem:
+		return item.Quantity.Valid
+	case model.ShoppingItem:
+		return item.Quantity.Valid
+	case model.GroupRegularShoppingItem:
+		return item.Quantity.Valid
+	case model.GroupShoppingItem:
+		return item.Quantity.Valid
+	default:
+		return false
+	}
+}
+
+// unitPriceTotalValidation reports whether the amount equals the quantity times the unit price when both are given,
+// so that an amount sent with them, or left from before they were changed, never disagrees with them.
+func unitPriceTotalValidation(fl validator.FieldLevel) bool {
+	var quantity, unitPrice model.NullInt64
+
+	switch item := fl.Parent().Interface().(type) {
+	case model.RegularShoppingItem:
+		quantity, unitPrice = item.Quantity, item.UnitPrice
+	case model.ShoppingItem:
+		quantity, unitPrice = item.Quantity, item.UnitPrice
+	case model.GroupRegularShoppingItem:
+		quantity, unitPrice = item.Quantity, item.UnitPrice
+	case model.GroupShoppingItem:
+		quantity, unitPrice = item.Quantity, item.UnitPrice
+	default:
+		return false
+	}
+
+	if !quantity.Valid || !unitPrice.Valid {
+		return true
+	}
+
+	return fl.Field().Int() == quantity.Int64*unitPrice.Int64
+}
+
+// withCycleValidation reports whether the cycle or the recurrence rule required by the cycle type is specified.
+func withCycleValidation(fl validator.FieldLevel) bool {
+	var cycle model.NullInt
+	var recurrenceRule model.NullString
+
+	switch item := fl.Parent().Interface().(type) {
+	case model.RegularShoppingItem:
+		cycle, recurrenceRule = item.Cycle, item.RecurrenceRule
+	case model.GroupRegularShoppingItem:
+		cycle, recurrenceRule = item.Cycle, item.RecurrenceRule
+	default:
+		return false
+	}
+
+	switch fl.Field().String() {
+	case "custom":
+		return cycle.Valid
+	case "rrule":
+		return recurrenceRule.Valid && len(recurrenceRule.String) != 0
+	}
+
+	return true
+}
+
+func recurrenceRuleValidation(fl validator.FieldLevel) bool {
+	_, err := model.ParseRecurrenceRule(fl.Field().String())
+
+	return err == nil
+}
+
+// generateRecurrenceRule returns the recurrence rule of the regular shopping item in the canonical form.
+// The cycle types other than rrule are converted into the equivalent recurrence rules,
+// so that every regular shopping item is advanced by its recurrence rule.
+func generateRecurrenceRule(cycleType string, cycle model.NullInt, recurrenceRule model.NullString, start model.Date) model.NullString {
+	rule := model.CycleRecurrenceRule(cycleType, cycle.Int, start.Time)
+	if cycleType == "rrule" {
+		if parsedRecurrenceRule, err := model.ParseRecurrenceRule(recurrenceRule.String); err == nil {
+			rule = parsedRecurrenceRule.String()
+		}
+	}
+
+	return model.NullString{NullString: sql.NullString{String: rule, Valid: true}}
+}
+
+func getShoppingItemCategoriesName(categoriesID CategoriesID, locale string) ([]byte, error) {
 	requestURL := fmt.Sprintf(
 		"http://%s:%d/categories/name",
 		config.Env.AccountApi.Host, config.Env.AccountApi.Port,
@@ -321,6 +558,9 @@ func getShoppingItemCategoriesName(categoriesID CategoriesID) ([]byte, error) {
 	}
 
 	request.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	request.Header.Set("Accept-Language", locale)
+
+	cachedCategoriesNameBytes, isCached := categoriesNameResponses.setIfNoneMatch(request, requestBody)
 
 	client := &http.Client{
 		Transport: &http.Transport{
@@ -347,6 +587,10 @@ func getShoppingItemCategoriesName(categoriesID CategoriesID) ([]byte, error) {
 		response.Body.Close()
 	}()
 
+	if response.StatusCode == http.StatusNotModified && isCached {
+		return cachedCategoriesNameBytes, nil
+	}
+
 	if response.StatusCode == http.StatusInternalServerError {
 		return nil, &InternalServerErrorMsg{"500 Internal Server Error"}
 	}
@@ -356,10 +600,12 @@ func getShoppingItemCategoriesName(categoriesID CategoriesID) ([]byte, error) {
 		return nil, err
 	}
 
+	categoriesNameResponses.set(request, requestBody, response, categoriesNameBytes)
+
 	return categoriesNameBytes, nil
 }
 
-func getShoppingItemCategoriesNameList(categoriesIdList []CategoriesID) ([]byte, error) {
+func getShoppingItemCategoriesNameList(ctx context.Context, categoriesIdList []CategoriesID, locale string) ([]byte, error) {
 	requestURL := fmt.Sprintf(
 		"http://%s:%d/categories/names",
 		config.Env.AccountApi.Host, config.Env.AccountApi.Port,
@@ -370,7 +616,8 @@ func getShoppingItemCategoriesNameList(categoriesIdList []CategoriesID) ([]byte,
 		return nil, err
 	}
 
-	request, err := http.NewRequest(
+	request, err := http.NewRequestWithContext(
+		ctx,
 		"GET",
 		requestURL,
 		bytes.NewBuffer(requestBody),
@@ -380,6 +627,9 @@ func getShoppingItemCategoriesNameList(categoriesIdList []CategoriesID) ([]byte,
 	}
 
 	request.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	request.Header.Set("Accept-Language", locale)
+
+	cachedCategoriesNameBytes, isCached := categoriesNameResponses.setIfNoneMatch(request, requestBody)
 
 	client := &http.Client{
 		Transport: &http.Transport{
@@ -406,6 +656,10 @@ func getShoppingItemCategoriesNameList(categoriesIdList []CategoriesID) ([]byte,
 		response.Body.Close()
 	}()
 
+	if response.StatusCode == http.StatusNotModified && isCached {
+		return cachedCategoriesNameBytes, nil
+	}
+
 	if response.StatusCode == http.StatusInternalServerError {
 		return nil, &InternalServerErrorMsg{"500 Internal Server Error"}
 	}
@@ -415,6 +669,8 @@ func getShoppingItemCategoriesNameList(categoriesIdList []CategoriesID) ([]byte,
 		return nil, err
 	}
 
+	categoriesNameResponses.set(request, requestBody, response, categoriesNameBytes)
+
 	return categoriesNameBytes, nil
 }
 
@@ -477,129 +733,20 @@ func getShoppingItemRelatedTransactionDataList(transactionIdList []int64) ([]*mo
 	return shoppingItemRelatedTransactionDataList, nil
 }
 
-func postRelatedTransaction(shoppingItem model.ShoppingItem, cookie *http.Cookie) (model.ShoppingItem, error) {
-	requestURL := fmt.Sprintf(
-		"http://%s:%d/transactions",
-		config.Env.AccountApi.Host, config.Env.AccountApi.Port,
-	)
-
-	relatedTransaction := RelatedTransaction{
+func generateRelatedTransaction(shoppingItem model.ShoppingItem) RelatedTransaction {
+	return RelatedTransaction{
 		TransactionType:  "expense",
 		TransactionDate:  shoppingItem.ExpectedPurchaseDate.Time,
 		Shop:             shoppingItem.Shop,
-		Memo:             fmt.Sprintf("【買い物リスト】%s", shoppingItem.Purchase),
+		Memo:             generateShoppingItemMemo(shoppingItem.Purchase, shoppingItem.Quantity, shoppingItem.Unit),
 		Amount:           shoppingItem.Amount.Int64,
 		BigCategoryID:    shoppingItem.BigCategoryID,
 		MediumCategoryID: shoppingItem.MediumCategoryID,
 		CustomCategoryID: shoppingItem.CustomCategoryID,
 	}
-
-	requestBody, err := json.Marshal(&relatedTransaction)
-	if err != nil {
-		return shoppingItem, err
-	}
-
-	request, err := http.NewRequest(
-		"POST",
-		requestURL,
-		bytes.NewBuffer(requestBody),
-	)
-	if err != nil {
-		return shoppingItem, err
-	}
-
-	request.AddCookie(cookie)
-	request.Header.Set("Content-Type", "application/json; charset=UTF-8")
-
-	client := &http.Client{
-		Transport: &http.Transport{
-			DialContext: (&net.Dialer{
-				Timeout:   30 * time.Second,
-				KeepAlive: 30 * time.Second,
-			}).DialContext,
-			MaxIdleConns:          500,
-			MaxIdleConnsPerHost:   100,
-			IdleConnTimeout:       90 * time.Second,
-			ResponseHeaderTimeout: 10 * time.Second,
-			ExpectContinueTimeout: 1 * time.Second,
-		},
-		Timeout: 60 * time.Second,
-	}
-
-	response, err := client.Do(request)
-	if err != nil {
-		return shoppingItem, err
-	}
-
-	defer func() {
-		_, _ = io.Copy(ioutil.Discard, response.Body)
-		response.Body.Close()
-	}()
-
-	if err := json.NewDecoder(response.Body).Decode(&shoppingItem.RelatedTransactionData); err != nil {
-		return shoppingItem, err
-	}
-
-	if response.StatusCode == http.StatusInternalServerError {
-		return shoppingItem, &InternalServerErrorMsg{"500 Internal Server Error"}
-	}
-
-	return shoppingItem, nil
-}
-
-func deleteRelatedTransaction(shoppingItem model.ShoppingItem, cookie *http.Cookie) (model.ShoppingItem, error) {
-	requestURL := fmt.Sprintf(
-		"http://%s:%d/transactions/%d",
-		config.Env.AccountApi.Host, config.Env.AccountApi.Port, shoppingItem.RelatedTransactionData.ID.Int64,
-	)
-
-	request, err := http.NewRequest(
-		"DELETE",
-		requestURL,
-		nil,
-	)
-	if err != nil {
-		return shoppingItem, err
-	}
-
-	request.AddCookie(cookie)
-	request.Header.Set("Content-Type", "application/json; charset=UTF-8")
-
-	client := &http.Client{
-		Transport: &http.Transport{
-			DialContext: (&net.Dialer{
-				Timeout:   30 * time.Second,
-				KeepAlive: 30 * time.Second,
-			}).DialContext,
-			MaxIdleConns:          500,
-			MaxIdleConnsPerHost:   100,
-			IdleConnTimeout:       90 * time.Second,
-			ResponseHeaderTimeout: 10 * time.Second,
-			ExpectContinueTimeout: 1 * time.Second,
-		},
-		Timeout: 60 * time.Second,
-	}
-
-	response, err := client.Do(request)
-	if err != nil {
-		return shoppingItem, err
-	}
-
-	defer func() {
-		_, _ = io.Copy(ioutil.Discard, response.Body)
-		response.Body.Close()
-	}()
-
-	if response.StatusCode == http.StatusInternalServerError {
-		return shoppingItem, &InternalServerErrorMsg{"500 Internal Server Error"}
-	}
-
-	shoppingItem.RelatedTransactionData = nil
-
-	return shoppingItem, nil
 }
 
-func generateRegularShoppingList(regularShoppingList model.RegularShoppingList) (model.RegularShoppingList, error) {
+func generateRegularShoppingList(ctx context.Context, regularShoppingList model.RegularShoppingList, locale string) (model.RegularShoppingList, error) {
 	categoriesIdList := make([]CategoriesID, len(regularShoppingList.RegularShoppingList))
 
 	for i, regularShoppingItem := range regularShoppingList.RegularShoppingList {
@@ -609,7 +756,7 @@ func generateRegularShoppingList(regularShoppingList model.RegularShoppingList)
 		}
 	}
 
-	categoriesNameListBytes, err := getShoppingItemCategoriesNameList(categoriesIdList)
+	categoriesNameListBytes, err := getShoppingItemCategoriesNameList(ctx, categoriesIdList, locale)
 	if err != nil {
 		return regularShoppingList, err
 	}
@@ -621,7 +768,7 @@ func generateRegularShoppingList(regularShoppingList model.RegularShoppingList)
 	return regularShoppingList, nil
 }
 
-func generateShoppingList(shoppingList model.ShoppingList) (model.ShoppingList, error) {
+func generateShoppingList(ctx context.Context, shoppingList model.ShoppingList, locale string) (model.ShoppingList, error) {
 	categoriesIdList := make([]CategoriesID, len(shoppingList.ShoppingList))
 	var transactionIdList []int64
 
@@ -636,7 +783,7 @@ func generateShoppingList(shoppingList model.ShoppingList) (model.ShoppingList,
 		}
 	}
 
-	categoriesNameListBytes, err := getShoppingItemCategoriesNameList(categoriesIdList)
+	categoriesNameListBytes, err := getShoppingItemCategoriesNameList(ctx, categoriesIdList, locale)
 	if err != nil {
 		return shoppingList, err
 	}
@@ -663,102 +810,525 @@ func generateShoppingList(shoppingList model.ShoppingList) (model.ShoppingList,
 	return shoppingList, nil
 }
 
-func generateShoppingListByCategories(shoppingList model.ShoppingList) []model.ShoppingListByCategory {
-	shoppingListByCategories := make([]model.ShoppingListByCategory, 0)
-
-	for i, j := 0, 0; i < len(shoppingList.ShoppingList); i++ {
-		firstIndexBigCategoryID := shoppingList.ShoppingList[j].BigCategoryID
-		currentIndexBigCategoryID := shoppingList.ShoppingList[i].BigCategoryID
+// recurringShoppingItem is implemented by both the personal and the group regular shopping items.
+type recurringShoppingItem interface {
+	IsExpectedPurchaseDate(date time.Time) bool
+	UpcomingExpectedPurchaseDateList(date time.Time, count int, until time.Time) []time.Time
+}
 
-		if firstIndexBigCategoryID != currentIndexBigCategoryID {
-			shoppingListByCategory := model.ShoppingListByCategory{
-				BigCategoryName: shoppingList.ShoppingList[j].BigCategoryName,
-				ShoppingList:    append(make([]model.ShoppingItem, 0, i-j), shoppingList.ShoppingList[j:i]...),
-			}
+// generateSkippedDateList returns the dates to skip, which are either the next skip count expected purchase dates or the skip date.
+func generateSkippedDateList(regularShoppingItemSkip model.RegularShoppingItemSkip, regularShoppingItem recurringShoppingItem, expectedPurchaseDate time.Time, today time.Time) ([]time.Time, error) {
+	switch {
+	case regularShoppingItemSkip.SkipCount != 0 && regularShoppingItemSkip.SkipDate.Valid:
+		return nil, &BadRequestErrorMsg{"スキップする回数と日付は、どちらか一方を指定してください。"}
+	case regularShoppingItemSkip.SkipDate.Valid:
+		skipDate := regularShoppingItemSkip.SkipDate.Time
+		skipDate = time.Date(skipDate.Year(), skipDate.Month(), skipDate.Day(), 0, 0, 0, 0, time.UTC)
+
+		if skipDate.Before(today) {
+			return nil, &BadRequestErrorMsg{"スキップする日付は今日以降の日付を選択してください。"}
+		}
 
-			shoppingListByCategories = append(shoppingListByCategories, shoppingListByCategory)
+		if !regularShoppingItem.IsExpectedPurchaseDate(skipDate) {
+			return nil, &BadRequestErrorMsg{"スキップする日付は購入予定日から選択してください。"}
+		}
 
-			j = i
+		return []time.Time{skipDate}, nil
+	case regularShoppingItemSkip.SkipCount != 0:
+		if regularShoppingItemSkip.SkipCount < 1 || regularShoppingItemSkip.SkipCount > 100 {
+			return nil, &BadRequestErrorMsg{"スキップする回数は1以上100以下の整数を入力してください。"}
 		}
 
-		if i == len(shoppingList.ShoppingList)-1 {
-			if firstIndexBigCategoryID == currentIndexBigCategoryID {
-				shoppingListByCategory := model.ShoppingListByCategory{
-					BigCategoryName: shoppingList.ShoppingList[j].BigCategoryName,
-					ShoppingList:    append(make([]model.ShoppingItem, 0, i-j), shoppingList.ShoppingList[j:]...),
-				}
+		return regularShoppingItem.UpcomingExpectedPurchaseDateList(expectedPurchaseDate, regularShoppingItemSkip.SkipCount, time.Time{}), nil
+	}
 
-				shoppingListByCategories = append(shoppingListByCategories, shoppingListByCategory)
-			} else if i == j {
-				shoppingListByCategory := model.ShoppingListByCategory{
-					BigCategoryName: shoppingList.ShoppingList[i].BigCategoryName,
-					ShoppingList:    []model.ShoppingItem{shoppingList.ShoppingList[i]},
-				}
+	return nil, &BadRequestErrorMsg{"スキップする回数または日付を指定してください。"}
+}
 
-				shoppingListByCategories = append(shoppingListByCategories, shoppingListByCategory)
-			}
-		}
+func validatePausedUntil(pausedUntil model.NullTime, today time.Time) error {
+	if !pausedUntil.Valid {
+		return nil
 	}
 
-	return shoppingListByCategories
-}
+	if pausedUntil.Time.Before(today) {
+		return &BadRequestErrorMsg{"休止期間の終了日は今日以降の日付を選択してください。"}
+	}
 
-func (h *DBHandler) GetDailyShoppingDataByDay(w http.ResponseWriter, r *http.Request) {
-	userID, err := verifySessionID(h, w, r)
+	if pausedUntil.Time.After(today.AddDate(1, 0, 0)) {
+		return &BadRequestErrorMsg{"休止期間の終了日は1年以内の日付を選択してください。"}
+	}
+
+	return nil
+}
+
+type UpdatedRegularShoppingData struct {
+	RegularShoppingItem model.RegularShoppingItem `json:"regular_shopping_item"`
+	model.ShoppingList
+}
+
+func generateUpdatedRegularShoppingData(h *DBHandler, regularShoppingItemID int, locale string) (UpdatedRegularShoppingData, error) {
+	regularShoppingItem, err := h.ShoppingListRepo.GetRegularShoppingItem(regularShoppingItemID)
 	if err != nil {
-		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
-			return
+		return UpdatedRegularShoppingData{}, err
+	}
+
+	shoppingList, err := h.ShoppingListRepo.GetShoppingListRelatedToUpdatedRegularShoppingItem(regularShoppingItemID)
+	if err != nil {
+		return UpdatedRegularShoppingData{}, err
+	}
+
+	categoriesID := CategoriesID{
+		MediumCategoryID: regularShoppingItem.MediumCategoryID,
+		CustomCategoryID: regularShoppingItem.CustomCategoryID,
+	}
+
+	categoriesNameBytes, err := getShoppingItemCategoriesName(categoriesID, locale)
+	if err != nil {
+		return UpdatedRegularShoppingData{}, err
+	}
+
+	if err := json.Unmarshal(categoriesNameBytes, &regularShoppingItem); err != nil {
+		return UpdatedRegularShoppingData{}, err
+	}
+
+	for i := 0; i < len(shoppingList.ShoppingList); i++ {
+		if err := json.Unmarshal(categoriesNameBytes, &shoppingList.ShoppingList[i]); err != nil {
+			return UpdatedRegularShoppingData{}, err
 		}
+	}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
-		return
+	return UpdatedRegularShoppingData{
+		RegularShoppingItem: regularShoppingItem,
+		ShoppingList:        shoppingList,
+	}, nil
+}
+
+func generateSkippedShoppingList(ctx context.Context, skippedShoppingList model.SkippedShoppingList, locale string) (model.SkippedShoppingList, error) {
+	categoriesIdList := make([]CategoriesID, len(skippedShoppingList.SkippedShoppingList))
+
+	for i, skippedShoppingItem := range skippedShoppingList.SkippedShoppingList {
+		categoriesIdList[i] = CategoriesID{
+			MediumCategoryID: skippedShoppingItem.MediumCategoryID,
+			CustomCategoryID: skippedShoppingItem.CustomCategoryID,
+		}
 	}
 
-	regularShoppingList, err := h.ShoppingListRepo.GetRegularShoppingList(userID)
+	categoriesNameListBytes, err := getShoppingItemCategoriesNameList(ctx, categoriesIdList, locale)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
-		return
+		return skippedShoppingList, err
 	}
 
-	if len(regularShoppingList.RegularShoppingList) != 0 {
-		now := h.TimeManage.Now()
-		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if err := json.Unmarshal(categoriesNameListBytes, &skippedShoppingList.SkippedShoppingList); err != nil {
+		return skippedShoppingList, err
+	}
 
-		if err = h.ShoppingListRepo.PutRegularShoppingList(regularShoppingList, userID, today); err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
-			return
+	return skippedShoppingList, nil
+}
+
+func generateShoppingListByCategories(shoppingList model.ShoppingList) []model.ShoppingListByCategory {
+	shoppingListByCategories := make([]model.ShoppingListByCategory, 0)
+
+	for i, j := 0, 0; i < len(shoppingList.ShoppingList); i++ {
+		firstIndexBigCategoryID := shoppingList.ShoppingList[j].BigCategoryID
+		currentIndexBigCategoryID := shoppingList.ShoppingList[i].BigCategoryID
+
+		if firstIndexBigCategoryID != currentIndexBigCategoryID {
+			shoppingListByCategory := model.ShoppingListByCategory{
+				BigCategoryName: shoppingList.ShoppingList[j].BigCategoryName,
+				ShoppingList:    append(make([]model.ShoppingItem, 0, i-j), shoppingList.ShoppingList[j:i]...),
+			}
+
+			shoppingListByCategories = append(shoppingListByCategories, shoppingListByCategory)
+
+			j = i
+		}
+
+		if i == len(shoppingList.ShoppingList)-1 {
+			if firstIndexBigCategoryID == currentIndexBigCategoryID {
+				shoppingListByCategory := model.ShoppingListByCategory{
+					BigCategoryName: shoppingList.ShoppingList[j].BigCategoryName,
+					ShoppingList:    append(make([]model.ShoppingItem, 0, i-j), shoppingList.ShoppingList[j:]...),
+				}
+
+				shoppingListByCategories = append(shoppingListByCategories, shoppingListByCategory)
+			} else if i == j {
+				shoppingListByCategory := model.ShoppingListByCategory{
+					BigCategoryName: shoppingList.ShoppingList[i].BigCategoryName,
+					ShoppingList:    []model.ShoppingItem{shoppingList.ShoppingList[i]},
+				}
+
+				shoppingListByCategories = append(shoppingListByCategories, shoppingListByCategory)
+			}
+		}
+	}
+
+	return shoppingListByCategories
+}
+
+// generateShopSectionOrders returns the order of the sections, that is the big categories, for each shop.
+func generateShopSectionOrders(shopSectionList []model.ShopSection) map[string]map[int]int {
+	shopSectionOrders := make(map[string]map[int]int)
+
+	for _, shopSection := range shopSectionList {
+		sectionOrder, ok := shopSectionOrders[shopSection.Shop]
+		if !ok {
+			sectionOrder = make(map[int]int)
+			shopSectionOrders[shopSection.Shop] = sectionOrder
+		}
+
+		sectionOrder[shopSection.BigCategoryID] = len(sectionOrder)
+	}
+
+	return shopSectionOrders
+}
+
+// shopSectionRank places the sections without order after the ordered ones, in order of big category ID.
+func shopSectionRank(sectionOrder map[int]int, bigCategoryID int) int {
+	if order, ok := sectionOrder[bigCategoryID]; ok {
+		return order
+	}
+
+	return len(sectionOrder) + bigCategoryID
+}
+
+func generateShoppingListByShops(shoppingList model.ShoppingList, shopSectionOrders map[string]map[int]int) []model.ShoppingListByShop {
+	shoppingListByShops := make([]model.ShoppingListByShop, 0)
+	noShopShoppingList := make([]model.ShoppingItem, 0)
+
+	for _, shoppingItem := range shoppingList.ShoppingList {
+		if !shoppingItem.Shop.Valid || len(shoppingItem.Shop.String) == 0 {
+			noShopShoppingList = append(noShopShoppingList, shoppingItem)
+			continue
+		}
+
+		if len(shoppingListByShops) == 0 || shoppingListByShops[len(shoppingListByShops)-1].Shop.String != shoppingItem.Shop.String {
+			shoppingListByShops = append(shoppingListByShops, model.ShoppingListByShop{
+				Shop:         shoppingItem.Shop,
+				ShoppingList: make([]model.ShoppingItem, 0),
+			})
 		}
 
-		regularShoppingList, err = h.ShoppingListRepo.GetRegularShoppingList(userID)
+		lastIndex := len(shoppingListByShops) - 1
+		shoppingListByShops[lastIndex].ShoppingList = append(shoppingListByShops[lastIndex].ShoppingList, shoppingItem)
+	}
+
+	// Items with no shop are put together in the last bucket.
+	if len(noShopShoppingList) != 0 {
+		shoppingListByShops = append(shoppingListByShops, model.ShoppingListByShop{
+			ShoppingList: noShopShoppingList,
+		})
+	}
+
+	for _, shoppingListByShop := range shoppingListByShops {
+		sectionOrder := shopSectionOrders[shoppingListByShop.Shop.String]
+		shoppingList := shoppingListByShop.ShoppingList
+
+		sort.SliceStable(shoppingList, func(i, j int) bool {
+			return shopSectionRank(sectionOrder, shoppingList[i].BigCategoryID) < shopSectionRank(sectionOrder, shoppingList[j].BigCategoryID)
+		})
+	}
+
+	return shoppingListByShops
+}
+
+// purchasedPrice is the price paid for a purchased shopping item, from which price histories and suggestions are computed.
+type purchasedPrice struct {
+	purchase string
+	shop     model.NullString
+	amount   int64
+}
+
+// normalizeShoppingItemName folds full-width characters, letter case and spaces of a purchase or a shop name,
+// so that for example "牛乳　１Ｌ" and "牛乳 1l" are regarded as the same item.
+func normalizeShoppingItemName(name string) string {
+	halfWidthName := strings.Map(func(r rune) rune {
+		if r >= '！' && r <= '～' {
+			return r - '！' + '!'
+		}
+
+		return r
+	}, name)
+
+	return strings.ToLower(strings.Join(strings.Fields(halfWidthName), " "))
+}
+
+func purchasedPriceKey(purchasedPrice purchasedPrice) string {
+	return normalizeShoppingItemName(purchasedPrice.purchase) + "\n" + normalizeShoppingItemName(purchasedPrice.shop.String)
+}
+
+// groupPurchasedPrices groups the indexes of the prices by the normalized purchase and shop.
+// The prices are expected to be ordered from the latest, so the first index of each group is the latest price.
+func groupPurchasedPrices(purchasedPriceList []purchasedPrice) [][]int {
+	var indexGroupList [][]int
+	groupIndexes := make(map[string]int)
+
+	for i, purchasedPrice := range purchasedPriceList {
+		key := purchasedPriceKey(purchasedPrice)
+
+		groupIndex, ok := groupIndexes[key]
+		if !ok {
+			groupIndex = len(indexGroupList)
+			groupIndexes[key] = groupIndex
+			indexGroupList = append(indexGroupList, nil)
+		}
+
+		indexGroupList[groupIndex] = append(indexGroupList[groupIndex], i)
+	}
+
+	return indexGroupList
+}
+
+func averagePurchasedPrice(purchasedPriceList []purchasedPrice, indexList []int) int64 {
+	var totalAmount int64
+	for _, i := range indexList {
+		totalAmount += purchasedPriceList[i].amount
+	}
+
+	count := int64(len(indexList))
+
+	return (totalAmount + count/2) / count
+}
+
+func summarizePurchasedPrices(purchasedPriceList []purchasedPrice, indexList []int) (lastPrice int64, averagePrice int64, minPrice int64, maxPrice int64) {
+	lastPrice = purchasedPriceList[indexList[0]].amount
+	minPrice, maxPrice = lastPrice, lastPrice
+
+	for _, i := range indexList {
+		if amount := purchasedPriceList[i].amount; amount < minPrice {
+			minPrice = amount
+		} else if amount > maxPrice {
+			maxPrice = amount
+		}
+	}
+
+	return lastPrice, averagePurchasedPrice(purchasedPriceList, indexList), minPrice, maxPrice
+}
+
+// suggestPrice suggests the last price, the average price and the cheapest shop of the purchase from the price history.
+// The prices are compared as they are, so they are expected to be unit prices where the quantity is known.
+// The cheapest shop is the shop with the lowest average price. It returns nil if the purchase has never been bought.
+func suggestPrice(purchasedPriceList []purchasedPrice, purchase string) *model.PriceSuggestion {
+	normalizedPurchase := normalizeShoppingItemName(purchase)
+
+	var matchedPurchasedPriceList []purchasedPrice
+	for _, purchasedPrice := range purchasedPriceList {
+		if normalizeShoppingItemName(purchasedPrice.purchase) == normalizedPurchase {
+			matchedPurchasedPriceList = append(matchedPurchasedPriceList, purchasedPrice)
+		}
+	}
+
+	if len(matchedPurchasedPriceList) == 0 {
+		return nil
+	}
+
+	allIndexList := make([]int, len(matchedPurchasedPriceList))
+	for i := range allIndexList {
+		allIndexList[i] = i
+	}
+
+	priceSuggestion := model.PriceSuggestion{
+		LastPrice:    matchedPurchasedPriceList[0].amount,
+		LastShop:     matchedPurchasedPriceList[0].shop,
+		AveragePrice: averagePurchasedPrice(matchedPurchasedPriceList, allIndexList),
+	}
+
+	for _, indexList := range groupPurchasedPrices(matchedPurchasedPriceList) {
+		shop := matchedPurchasedPriceList[indexList[0]].shop
+		if !shop.Valid {
+			continue
+		}
+
+		averagePrice := averagePurchasedPrice(matchedPurchasedPriceList, indexList)
+		if !priceSuggestion.CheapestShopAveragePrice.Valid || averagePrice < priceSuggestion.CheapestShopAveragePrice.Int64 {
+			priceSuggestion.CheapestShop = shop
+			priceSuggestion.CheapestShopAveragePrice = model.NullInt64{NullInt64: sql.NullInt64{Int64: averagePrice, Valid: true}}
+		}
+	}
+
+	return &priceSuggestion
+}
+
+// filterPurchasedPrices picks the indexes of the prices whose purchase contains the purchase query
+// and whose shop is the shop query. An empty query matches every price.
+func filterPurchasedPrices(purchasedPriceList []purchasedPrice, purchaseQuery string, shopQuery string) []int {
+	normalizedPurchaseQuery := normalizeShoppingItemName(purchaseQuery)
+	normalizedShopQuery := normalizeShoppingItemName(shopQuery)
+
+	indexList := make([]int, 0, len(purchasedPriceList))
+	for i, purchasedPrice := range purchasedPriceList {
+		if !strings.Contains(normalizeShoppingItemName(purchasedPrice.purchase), normalizedPurchaseQuery) {
+			continue
+		}
+
+		if len(normalizedShopQuery) != 0 && normalizeShoppingItemName(purchasedPrice.shop.String) != normalizedShopQuery {
+			continue
+		}
+
+		indexList = append(indexList, i)
+	}
+
+	return indexList
+}
+
+// generateShoppingItemPriceList replaces the amount of each purchased shopping item with the amount of its related transaction,
+// which records what was actually paid, and drops the items whose price is unknown.
+func generateShoppingItemPriceList(shoppingItemPriceList []model.ShoppingItemPrice) ([]model.ShoppingItemPrice, error) {
+	var transactionIdList []int64
+	for _, shoppingItemPrice := range shoppingItemPriceList {
+		if shoppingItemPrice.RelatedTransactionData != nil {
+			transactionIdList = append(transactionIdList, shoppingItemPrice.RelatedTransactionData.ID.Int64)
+		}
+	}
+
+	if len(transactionIdList) != 0 {
+		shoppingItemRelatedTransactionDataList, err := getShoppingItemRelatedTransactionDataList(transactionIdList)
 		if err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			return shoppingItemPriceList, err
+		}
+
+		for _, shoppingItemRelatedTransactionData := range shoppingItemRelatedTransactionDataList {
+			for i, shoppingItemPrice := range shoppingItemPriceList {
+				if shoppingItemPrice.RelatedTransactionData != nil && shoppingItemRelatedTransactionData.ID.Int64 == shoppingItemPrice.RelatedTransactionData.ID.Int64 {
+					shoppingItemPriceList[i].Amount = model.NullInt64{NullInt64: sql.NullInt64{Int64: int64(shoppingItemRelatedTransactionData.Amount), Valid: true}}
+				}
+			}
+		}
+	}
+
+	pricedShoppingItemPriceList := make([]model.ShoppingItemPrice, 0, len(shoppingItemPriceList))
+	for _, shoppingItemPrice := range shoppingItemPriceList {
+		if shoppingItemPrice.Amount.Valid {
+			pricedShoppingItemPriceList = append(pricedShoppingItemPriceList, shoppingItemPrice)
+		}
+	}
+
+	return pricedShoppingItemPriceList, nil
+}
+
+// maxPriceSuggestionHistory bounds the number of past purchases a price suggestion is made from.
+const maxPriceSuggestionHistory = 50
+
+// unitPurchasedPrice divides the amount by the quantity if it is known,
+// so that purchases of different quantities can be compared.
+func unitPurchasedPrice(amount int64, quantity model.NullInt64) int64 {
+	if !quantity.Valid || quantity.Int64 <= 0 {
+		return amount
+	}
+
+	return (amount + quantity.Int64/2) / quantity.Int64
+}
+
+func (h *DBHandler) suggestShoppingItemPrice(userID string, purchase string) (*model.PriceSuggestion, error) {
+	shoppingItemPriceList, err := h.ShoppingListRepo.GetShoppingItemPriceListByPurchase(userID, normalizeShoppingItemName(purchase), maxPriceSuggestionHistory)
+	if err != nil {
+		return nil, err
+	}
+
+	shoppingItemPriceList, err = generateShoppingItemPriceList(shoppingItemPriceList)
+	if err != nil {
+		return nil, err
+	}
+
+	purchasedPriceList := newPurchasedPriceList(shoppingItemPriceList)
+	for i, shoppingItemPrice := range shoppingItemPriceList {
+		purchasedPriceList[i].amount = unitPurchasedPrice(shoppingItemPrice.Amount.Int64, shoppingItemPrice.Quantity)
+	}
+
+	return suggestPrice(purchasedPriceList, purchase), nil
+}
+
+func newPurchasedPriceList(shoppingItemPriceList []model.ShoppingItemPrice) []purchasedPrice {
+	purchasedPriceList := make([]purchasedPrice, len(shoppingItemPriceList))
+	for i, shoppingItemPrice := range shoppingItemPriceList {
+		purchasedPriceList[i] = purchasedPrice{
+			purchase: shoppingItemPrice.Purchase,
+			shop:     shoppingItemPrice.Shop,
+			amount:   shoppingItemPrice.Amount.Int64,
+		}
+	}
+
+	return purchasedPriceList
+}
+
+func generateShoppingItemPriceHistoryList(shoppingItemPriceList []model.ShoppingItemPrice, purchaseQuery string, shopQuery string) model.ShoppingItemPriceHistoryList {
+	shoppingItemPriceHistoryList := model.ShoppingItemPriceHistoryList{
+		ShoppingItemPriceHistoryList: make([]model.ShoppingItemPriceHistory, 0),
+	}
+
+	purchasedPriceList := newPurchasedPriceList(shoppingItemPriceList)
+
+	var filteredShoppingItemPriceList []model.ShoppingItemPrice
+	var filteredPurchasedPriceList []purchasedPrice
+	for _, i := range filterPurchasedPrices(purchasedPriceList, purchaseQuery, shopQuery) {
+		filteredShoppingItemPriceList = append(filteredShoppingItemPriceList, shoppingItemPriceList[i])
+		filteredPurchasedPriceList = append(filteredPurchasedPriceList, purchasedPriceList[i])
+	}
+
+	for _, indexList := range groupPurchasedPrices(filteredPurchasedPriceList) {
+		lastPrice, averagePrice, minPrice, maxPrice := summarizePurchasedPrices(filteredPurchasedPriceList, indexList)
+
+		shoppingItemPriceHistory := model.ShoppingItemPriceHistory{
+			Purchase:     filteredShoppingItemPriceList[indexList[0]].Purchase,
+			Shop:         filteredShoppingItemPriceList[indexList[0]].Shop,
+			LastPrice:    lastPrice,
+			AveragePrice: averagePrice,
+			MinPrice:     minPrice,
+			MaxPrice:     maxPrice,
+			PriceList:    make([]model.ShoppingItemPrice, len(indexList)),
+		}
+
+		for j, i := range indexList {
+			shoppingItemPriceHistory.PriceList[j] = filteredShoppingItemPriceList[i]
+		}
+
+		shoppingItemPriceHistoryList.ShoppingItemPriceHistoryList = append(shoppingItemPriceHistoryList.ShoppingItemPriceHistoryList, shoppingItemPriceHistory)
+	}
+
+	return shoppingItemPriceHistoryList
+}
+
+func (h *DBHandler) GetDailyShoppingDataByDay(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		regularShoppingList, err = generateRegularShoppingList(regularShoppingList)
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	regularShoppingList, err := h.ShoppingListRepo.GetRegularShoppingList(userID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if len(regularShoppingList.RegularShoppingList) != 0 {
+		regularShoppingList, err = generateRegularShoppingList(r.Context(), regularShoppingList, requestLocale(r.Context()))
 		if err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 	}
 
 	date, err := time.Parse("2006-01-02", mux.Vars(r)["date"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"日付を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"日付を正しく指定してください。"}))
 		return
 	}
 
 	shoppingList, err := h.ShoppingListRepo.GetDailyShoppingListByDay(date, userID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if len(shoppingList.ShoppingList) != 0 {
-		shoppingList, err = generateShoppingList(shoppingList)
+		shoppingList, err = generateShoppingList(r.Context(), shoppingList, requestLocale(r.Context()))
 		if err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 	}
@@ -780,58 +1350,44 @@ func (h *DBHandler) GetDailyShoppingDataByCategory(w http.ResponseWriter, r *htt
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	regularShoppingList, err := h.ShoppingListRepo.GetRegularShoppingList(userID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if len(regularShoppingList.RegularShoppingList) != 0 {
-		now := h.TimeManage.Now()
-		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
-
-		if err = h.ShoppingListRepo.PutRegularShoppingList(regularShoppingList, userID, today); err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
-			return
-		}
-
-		regularShoppingList, err = h.ShoppingListRepo.GetRegularShoppingList(userID)
+		regularShoppingList, err = generateRegularShoppingList(r.Context(), regularShoppingList, requestLocale(r.Context()))
 		if err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
-			return
-		}
-
-		regularShoppingList, err = generateRegularShoppingList(regularShoppingList)
-		if err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 	}
 
 	date, err := time.Parse("2006-01-02", mux.Vars(r)["date"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"日付を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"日付を正しく指定してください。"}))
 		return
 	}
 
 	shoppingList, err := h.ShoppingListRepo.GetDailyShoppingListByCategory(date, userID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if len(shoppingList.ShoppingList) != 0 {
-		shoppingList, err = generateShoppingList(shoppingList)
+		shoppingList, err = generateShoppingList(r.Context(), shoppingList, requestLocale(r.Context()))
 		if err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 	}
@@ -855,149 +1411,274 @@ func (h *DBHandler) GetMonthlyShoppingDataByDay(w http.ResponseWriter, r *http.R
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	regularShoppingList, err := h.ShoppingListRepo.GetRegularShoppingList(userID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if len(regularShoppingList.RegularShoppingList) != 0 {
-		now := h.TimeManage.Now()
-		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		regularShoppingList, err = generateRegularShoppingList(r.Context(), regularShoppingList, requestLocale(r.Context()))
+		if err != nil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+	}
+
+	firstDay, err := time.Parse("2006-01", mux.Vars(r)["year_month"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
+		return
+	}
+
+	lastDay := time.Date(firstDay.Year(), firstDay.Month()+1, 1, 0, 0, 0, 0, firstDay.Location()).Add(-1 * time.Second)
 
-		if err = h.ShoppingListRepo.PutRegularShoppingList(regularShoppingList, userID, today); err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+	shoppingList, err := h.ShoppingListRepo.GetMonthlyShoppingListByDay(firstDay, lastDay, userID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if len(shoppingList.ShoppingList) != 0 {
+		shoppingList, err = generateShoppingList(r.Context(), shoppingList, requestLocale(r.Context()))
+		if err != nil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
+	}
+
+	skippedShoppingList, err := h.ShoppingListRepo.GetMonthlySkippedShoppingList(firstDay, lastDay, userID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
 
-		regularShoppingList, err = h.ShoppingListRepo.GetRegularShoppingList(userID)
+	if len(skippedShoppingList.SkippedShoppingList) != 0 {
+		skippedShoppingList, err = generateSkippedShoppingList(r.Context(), skippedShoppingList, requestLocale(r.Context()))
 		if err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
+	}
 
-		regularShoppingList, err = generateRegularShoppingList(regularShoppingList)
+	shoppingData := model.MonthlyShoppingDataByDay{
+		ShoppingDataByDay: model.ShoppingDataByDay{
+			RegularShoppingList: regularShoppingList,
+			ShoppingList:        shoppingList,
+		},
+		SkippedShoppingList: skippedShoppingList,
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&shoppingData); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) GetMonthlyShoppingDataByCategory(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	regularShoppingList, err := h.ShoppingListRepo.GetRegularShoppingList(userID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if len(regularShoppingList.RegularShoppingList) != 0 {
+		regularShoppingList, err = generateRegularShoppingList(r.Context(), regularShoppingList, requestLocale(r.Context()))
 		if err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 	}
 
 	firstDay, err := time.Parse("2006-01", mux.Vars(r)["year_month"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
 		return
 	}
 
 	lastDay := time.Date(firstDay.Year(), firstDay.Month()+1, 1, 0, 0, 0, 0, firstDay.Location()).Add(-1 * time.Second)
 
-	shoppingList, err := h.ShoppingListRepo.GetMonthlyShoppingListByDay(firstDay, lastDay, userID)
+	shoppingList, err := h.ShoppingListRepo.GetMonthlyShoppingListByCategory(firstDay, lastDay, userID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if len(shoppingList.ShoppingList) != 0 {
-		shoppingList, err = generateShoppingList(shoppingList)
+		shoppingList, err = generateShoppingList(r.Context(), shoppingList, requestLocale(r.Context()))
 		if err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 	}
 
-	shoppingData := model.ShoppingDataByDay{
-		RegularShoppingList: regularShoppingList,
-		ShoppingList:        shoppingList,
+	shoppingListByCategories := generateShoppingListByCategories(shoppingList)
+
+	shoppingDataByCategories := model.ShoppingDataByCategory{
+		RegularShoppingList:    regularShoppingList,
+		ShoppingListByCategory: shoppingListByCategories,
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(&shoppingData); err != nil {
+	if err := json.NewEncoder(w).Encode(&shoppingDataByCategories); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 }
 
-func (h *DBHandler) GetMonthlyShoppingDataByCategory(w http.ResponseWriter, r *http.Request) {
+func (h *DBHandler) GetDailyShoppingDataByShop(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	regularShoppingList, err := h.ShoppingListRepo.GetRegularShoppingList(userID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if len(regularShoppingList.RegularShoppingList) != 0 {
-		now := h.TimeManage.Now()
-		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
-
-		if err = h.ShoppingListRepo.PutRegularShoppingList(regularShoppingList, userID, today); err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		regularShoppingList, err = generateRegularShoppingList(r.Context(), regularShoppingList, requestLocale(r.Context()))
+		if err != nil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
+	}
+
+	date, err := time.Parse("2006-01-02", mux.Vars(r)["date"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"日付を正しく指定してください。"}))
+		return
+	}
+
+	shoppingList, err := h.ShoppingListRepo.GetDailyShoppingListByShop(date, userID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
 
-		regularShoppingList, err = h.ShoppingListRepo.GetRegularShoppingList(userID)
+	if len(shoppingList.ShoppingList) != 0 {
+		shoppingList, err = generateShoppingList(r.Context(), shoppingList, requestLocale(r.Context()))
 		if err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+	}
+
+	shopSectionList, err := h.ShoppingListRepo.GetShopSectionList(userID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	shoppingListByShops := generateShoppingListByShops(shoppingList, generateShopSectionOrders(shopSectionList))
+
+	shoppingDataByShops := model.ShoppingDataByShop{
+		RegularShoppingList: regularShoppingList,
+		ShoppingListByShop:  shoppingListByShops,
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&shoppingDataByShops); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) GetMonthlyShoppingDataByShop(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		regularShoppingList, err = generateRegularShoppingList(regularShoppingList)
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	regularShoppingList, err := h.ShoppingListRepo.GetRegularShoppingList(userID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if len(regularShoppingList.RegularShoppingList) != 0 {
+		regularShoppingList, err = generateRegularShoppingList(r.Context(), regularShoppingList, requestLocale(r.Context()))
 		if err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 	}
 
 	firstDay, err := time.Parse("2006-01", mux.Vars(r)["year_month"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
 		return
 	}
 
 	lastDay := time.Date(firstDay.Year(), firstDay.Month()+1, 1, 0, 0, 0, 0, firstDay.Location()).Add(-1 * time.Second)
 
-	shoppingList, err := h.ShoppingListRepo.GetMonthlyShoppingListByCategory(firstDay, lastDay, userID)
+	shoppingList, err := h.ShoppingListRepo.GetMonthlyShoppingListByShop(firstDay, lastDay, userID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if len(shoppingList.ShoppingList) != 0 {
-		shoppingList, err = generateShoppingList(shoppingList)
+		shoppingList, err = generateShoppingList(r.Context(), shoppingList, requestLocale(r.Context()))
 		if err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 	}
 
-	shoppingListByCategories := generateShoppingListByCategories(shoppingList)
-
-	shoppingDataByCategories := model.ShoppingDataByCategory{
-		RegularShoppingList:    regularShoppingList,
-		ShoppingListByCategory: shoppingListByCategories,
+	shopSectionList, err := h.ShoppingListRepo.GetShopSectionList(userID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	shoppingListByShops := generateShoppingListByShops(shoppingList, generateShopSectionOrders(shopSectionList))
+
+	shoppingDataByShops := model.ShoppingDataByShop{
+		RegularShoppingList: regularShoppingList,
+		ShoppingListByShop:  shoppingListByShops,
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(&shoppingDataByCategories); err != nil {
+	if err := json.NewEncoder(w).Encode(&shoppingDataByShops); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -1007,20 +1688,20 @@ func (h *DBHandler) GetExpiredShoppingList(w http.ResponseWriter, r *http.Reques
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	now := h.TimeManage.Now()
 	dueDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
 
-	expiredShoppingList, err := h.ShoppingListRepo.GetExpiredShoppingList(dueDate, userID)
+	expiredShoppingList, err := h.ShoppingListRepo.GetExpiredShoppingList(r.Context(), dueDate, userID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -1034,14 +1715,14 @@ func (h *DBHandler) GetExpiredShoppingList(w http.ResponseWriter, r *http.Reques
 			}
 		}
 
-		categoriesNameListBytes, err := getShoppingItemCategoriesNameList(categoriesIdList)
+		categoriesNameListBytes, err := getShoppingItemCategoriesNameList(r.Context(), categoriesIdList, requestLocale(r.Context()))
 		if err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
 		if err := json.Unmarshal(categoriesNameListBytes, &expiredShoppingList.ExpiredShoppingList); err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 	}
@@ -1054,21 +1735,55 @@ func (h *DBHandler) GetExpiredShoppingList(w http.ResponseWriter, r *http.Reques
 	}
 }
 
+func (h *DBHandler) GetShoppingItemPriceHistoryList(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	shoppingItemPriceList, err := h.ShoppingListRepo.GetShoppingItemPriceList(userID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	shoppingItemPriceList, err = generateShoppingItemPriceList(shoppingItemPriceList)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	shoppingItemPriceHistoryList := generateShoppingItemPriceHistoryList(shoppingItemPriceList, r.URL.Query().Get("purchase"), r.URL.Query().Get("shop"))
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&shoppingItemPriceHistoryList); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
 func (h *DBHandler) PostShoppingItem(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	var shoppingItem model.ShoppingItem
 	if err := json.NewDecoder(r.Body).Decode(&shoppingItem); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -1078,25 +1793,27 @@ func (h *DBHandler) PostShoppingItem(w http.ResponseWriter, r *http.Request) {
 	shoppingItem.Today = today
 
 	if err := validateShoppingItem(shoppingItem); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, err))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
 		return
 	}
 
+	shoppingItem.Amount = generateShoppingItemAmount(shoppingItem.Amount, shoppingItem.Quantity, shoppingItem.UnitPrice)
+
 	result, err := h.ShoppingListRepo.PostShoppingItem(&shoppingItem, userID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	lastInsertId, err := result.LastInsertId()
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	shoppingItem, err = h.ShoppingListRepo.GetShoppingItem(int(lastInsertId))
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -1105,17 +1822,22 @@ func (h *DBHandler) PostShoppingItem(w http.ResponseWriter, r *http.Request) {
 		CustomCategoryID: shoppingItem.CustomCategoryID,
 	}
 
-	categoriesNameBytes, err := getShoppingItemCategoriesName(categoriesID)
+	categoriesNameBytes, err := getShoppingItemCategoriesName(categoriesID, requestLocale(r.Context()))
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if err := json.Unmarshal(categoriesNameBytes, &shoppingItem); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
+	// The shopping item has already been created, so the price suggestion is left out rather than failing the request.
+	if shoppingItem.PriceSuggestion, err = h.suggestShoppingItemPrice(userID, shoppingItem.Purchase); err != nil {
+		log.Println(err)
+	}
+
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusCreated)
 	if err := json.NewEncoder(w).Encode(&shoppingItem); err != nil {
@@ -1125,68 +1847,93 @@ func (h *DBHandler) PostShoppingItem(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *DBHandler) PutShoppingItem(w http.ResponseWriter, r *http.Request) {
-	_, err := verifySessionID(h, w, r)
+	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	var shoppingItem model.ShoppingItem
 	if err := json.NewDecoder(r.Body).Decode(&shoppingItem); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	shoppingItem.ID, err = strconv.Atoi(mux.Vars(r)["id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"ショッピングアイテムIDを正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"ショッピングアイテムIDを正しく指定してください。"}))
 		return
 	}
 
 	if err := validateShoppingItem(shoppingItem); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, err))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
 		return
 	}
 
-	if shoppingItem.CompleteFlag && shoppingItem.TransactionAutoAdd && shoppingItem.RelatedTransactionData == nil {
-		cookie, err := r.Cookie(config.Env.Cookie.Name)
-		if err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+	shoppingItem.Amount = generateShoppingItemAmount(shoppingItem.Amount, shoppingItem.Quantity, shoppingItem.UnitPrice)
+
+	dbShoppingItem, err := h.ShoppingListRepo.GetShoppingItem(shoppingItem.ID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	// The related transaction is taken from the stored shopping item, since it is linked by the transaction outbox
+	// after the transaction has been posted to account-rest-service.
+	if shoppingItem.RelatedTransactionData == nil || dbShoppingItem.RelatedTransactionData == nil || shoppingItem.RelatedTransactionData.ID != dbShoppingItem.RelatedTransactionData.ID {
+		shoppingItem.RelatedTransactionData = dbShoppingItem.RelatedTransactionData
+	}
+
+	transactionCommand, err := generateTransactionCommand(shoppingItem, dbShoppingItem, userID, h.TimeManage.Now())
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if transactionCommand == nil {
+		if _, err := h.ShoppingListRepo.PutShoppingItem(&shoppingItem); err != nil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
+	} else {
+		if transactionCommand.Command == "delete" {
+			shoppingItem.RelatedTransactionData = nil
+		}
 
-		shoppingItem, err = postRelatedTransaction(shoppingItem, cookie)
+		result, err := h.ShoppingListRepo.PutShoppingItemWithTransactionCommand(&shoppingItem, transactionCommand)
 		if err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
-	} else if !shoppingItem.CompleteFlag && shoppingItem.RelatedTransactionData != nil {
-		cookie, err := r.Cookie(config.Env.Cookie.Name)
+
+		transactionCommandID, err := result.LastInsertId()
 		if err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 
-		shoppingItem, err = deleteRelatedTransaction(shoppingItem, cookie)
+		transactionCommand.ID = int(transactionCommandID)
+
+		// The transaction command is delivered right away if possible, otherwise it is retried by the transaction outbox worker.
+		responseBody, err := deliverTransactionCommand(h, *transactionCommand)
 		if err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
-			return
+			log.Println(err)
+		} else if len(responseBody) != 0 && transactionCommand.Command != "delete" {
+			if err := json.Unmarshal(responseBody, &shoppingItem.RelatedTransactionData); err != nil {
+				errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+				return
+			}
 		}
 	}
 
-	if _, err := h.ShoppingListRepo.PutShoppingItem(&shoppingItem); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
-		return
-	}
-
-	dbShoppingItem, err := h.ShoppingListRepo.GetShoppingItem(shoppingItem.ID)
+	dbShoppingItem, err = h.ShoppingListRepo.GetShoppingItem(shoppingItem.ID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -1198,14 +1945,14 @@ func (h *DBHandler) PutShoppingItem(w http.ResponseWriter, r *http.Request) {
 		CustomCategoryID: shoppingItem.CustomCategoryID,
 	}
 
-	categoriesNameBytes, err := getShoppingItemCategoriesName(categoriesID)
+	categoriesNameBytes, err := getShoppingItemCategoriesName(categoriesID, requestLocale(r.Context()))
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if err := json.Unmarshal(categoriesNameBytes, &shoppingItem); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -1221,28 +1968,28 @@ func (h *DBHandler) DeleteShoppingItem(w http.ResponseWriter, r *http.Request) {
 	_, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	shoppingItemID, err := strconv.Atoi(mux.Vars(r)["id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"ショッピングアイテムIDを正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"ショッピングアイテムIDを正しく指定してください。"}))
 		return
 	}
 
 	if err := h.ShoppingListRepo.DeleteShoppingItem(shoppingItemID); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(&DeleteContentMsg{"ショッピングアイテムを削除しました。"}); err != nil {
+	if err := json.NewEncoder(w).Encode(&DeleteContentMsg{localizeMessage(r.Context(), "ショッピングアイテムを削除しました。")}); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -1252,17 +1999,17 @@ func (h *DBHandler) PostRegularShoppingItem(w http.ResponseWriter, r *http.Reque
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	var regularShoppingItem model.RegularShoppingItem
 	if err := json.NewDecoder(r.Body).Decode(&regularShoppingItem); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -1272,19 +2019,23 @@ func (h *DBHandler) PostRegularShoppingItem(w http.ResponseWriter, r *http.Reque
 	regularShoppingItem.Today = today
 
 	if err := validateRegularShoppingItem(regularShoppingItem); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, err))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
 		return
 	}
 
+	regularShoppingItem.Amount = generateShoppingItemAmount(regularShoppingItem.Amount, regularShoppingItem.Quantity, regularShoppingItem.UnitPrice)
+	regularShoppingItem.RecurrenceRule = generateRecurrenceRule(regularShoppingItem.CycleType, regularShoppingItem.Cycle, regularShoppingItem.RecurrenceRule, regularShoppingItem.ExpectedPurchaseDate)
+	regularShoppingItem.RecurrenceStartDate = regularShoppingItem.ExpectedPurchaseDate
+
 	regularShoppingItemResult, todayShoppingItemResult, laterThanTodayShoppingItemResult, err := h.ShoppingListRepo.PostRegularShoppingItem(&regularShoppingItem, userID, today)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	regularShoppingItemId, err := regularShoppingItemResult.LastInsertId()
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -1292,26 +2043,29 @@ func (h *DBHandler) PostRegularShoppingItem(w http.ResponseWriter, r *http.Reque
 	if todayShoppingItemResult != nil {
 		todayShoppingItemID, err = todayShoppingItemResult.LastInsertId()
 		if err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 	}
 
-	laterThanTodayShoppingItemID, err := laterThanTodayShoppingItemResult.LastInsertId()
-	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
-		return
+	var laterThanTodayShoppingItemID int64
+	if laterThanTodayShoppingItemResult != nil {
+		laterThanTodayShoppingItemID, err = laterThanTodayShoppingItemResult.LastInsertId()
+		if err != nil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
 	}
 
 	regularShoppingItem, err = h.ShoppingListRepo.GetRegularShoppingItem(int(regularShoppingItemId))
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	shoppingList, err := h.ShoppingListRepo.GetShoppingListRelatedToPostedRegularShoppingItem(int(todayShoppingItemID), int(laterThanTodayShoppingItemID))
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -1320,20 +2074,20 @@ func (h *DBHandler) PostRegularShoppingItem(w http.ResponseWriter, r *http.Reque
 		CustomCategoryID: regularShoppingItem.CustomCategoryID,
 	}
 
-	categoriesNameBytes, err := getShoppingItemCategoriesName(categoriesID)
+	categoriesNameBytes, err := getShoppingItemCategoriesName(categoriesID, requestLocale(r.Context()))
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if err := json.Unmarshal(categoriesNameBytes, &regularShoppingItem); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	for i := 0; i < len(shoppingList.ShoppingList); i++ {
 		if err := json.Unmarshal(categoriesNameBytes, &shoppingList.ShoppingList[i]); err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 			return
 		}
 	}
@@ -1358,80 +2112,201 @@ func (h *DBHandler) PutRegularShoppingItem(w http.ResponseWriter, r *http.Reques
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	var regularShoppingItem model.RegularShoppingItem
 	if err := json.NewDecoder(r.Body).Decode(&regularShoppingItem); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	regularShoppingItemID, err := strconv.Atoi(mux.Vars(r)["id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"定期ショッピングアイテムIDを正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"定期ショッピングアイテムIDを正しく指定してください。"}))
 		return
 	}
 
 	if err := validateRegularShoppingItem(regularShoppingItem); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, err))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
 		return
 	}
 
+	regularShoppingItem.Amount = generateShoppingItemAmount(regularShoppingItem.Amount, regularShoppingItem.Quantity, regularShoppingItem.UnitPrice)
+	regularShoppingItem.RecurrenceRule = generateRecurrenceRule(regularShoppingItem.CycleType, regularShoppingItem.Cycle, regularShoppingItem.RecurrenceRule, regularShoppingItem.ExpectedPurchaseDate)
+	regularShoppingItem.RecurrenceStartDate = regularShoppingItem.ExpectedPurchaseDate
+
 	now := h.TimeManage.Now()
 	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
 
 	if err := h.ShoppingListRepo.PutRegularShoppingItem(&regularShoppingItem, regularShoppingItemID, userID, today); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	regularShoppingItem, err = h.ShoppingListRepo.GetRegularShoppingItem(regularShoppingItemID)
+	shoppingData, err := generateUpdatedRegularShoppingData(h, regularShoppingItemID, requestLocale(r.Context()))
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	shoppingList, err := h.ShoppingListRepo.GetShoppingListRelatedToUpdatedRegularShoppingItem(regularShoppingItemID)
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&shoppingData); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) SkipRegularShoppingItem(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	categoriesID := CategoriesID{
-		MediumCategoryID: regularShoppingItem.MediumCategoryID,
-		CustomCategoryID: regularShoppingItem.CustomCategoryID,
+	regularShoppingItemID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"定期ショッピングアイテムIDを正しく指定してください。"}))
+		return
 	}
 
-	categoriesNameBytes, err := getShoppingItemCategoriesName(categoriesID)
+	var regularShoppingItemSkip model.RegularShoppingItemSkip
+	if err := json.NewDecoder(r.Body).Decode(&regularShoppingItemSkip); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	regularShoppingItem, err := h.ShoppingListRepo.GetRegularShoppingItem(regularShoppingItemID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		if errors.Is(err, sql.ErrNoRows) {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"指定された定期ショッピングアイテムは存在しません。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	if err := json.Unmarshal(categoriesNameBytes, &regularShoppingItem); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+	if regularShoppingItem.FinishedFlag {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"繰り返しが終了した定期ショッピングアイテムはスキップできません。"}))
 		return
 	}
 
-	for i := 0; i < len(shoppingList.ShoppingList); i++ {
-		if err := json.Unmarshal(categoriesNameBytes, &shoppingList.ShoppingList[i]); err != nil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+	now := h.TimeManage.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	skippedDateList, err := generateSkippedDateList(regularShoppingItemSkip, regularShoppingItem, regularShoppingItem.ExpectedPurchaseDate.Time, today)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
+		return
+	}
+
+	skipped, err := h.ShoppingListRepo.SkipRegularShoppingItem(&regularShoppingItem, skippedDateList, userID, today)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusNotFound, &NotFoundErrorMsg{"指定された定期ショッピングアイテムは存在しません。"}))
 			return
 		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
 	}
 
-	shoppingData := struct {
-		RegularShoppingItem model.RegularShoppingItem `json:"regular_shopping_item"`
-		model.ShoppingList
-	}{
-		RegularShoppingItem: regularShoppingItem,
-		ShoppingList:        shoppingList,
+	if !skipped {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusConflict, &ConflictErrorMsg{"この定期ショッピングアイテムは既に更新されています。最新の買い物リストを確認してください。"}))
+		return
+	}
+
+	shoppingData, err := generateUpdatedRegularShoppingData(h, regularShoppingItemID, requestLocale(r.Context()))
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&shoppingData); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) PauseRegularShoppingItem(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	regularShoppingItemID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"定期ショッピングアイテムIDを正しく指定してください。"}))
+		return
+	}
+
+	var regularShoppingItemPause model.RegularShoppingItemPause
+	if err := json.NewDecoder(r.Body).Decode(&regularShoppingItemPause); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	regularShoppingItem, err := h.ShoppingListRepo.GetRegularShoppingItem(regularShoppingItemID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"指定された定期ショッピングアイテムは存在しません。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	now := h.TimeManage.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	if err := validatePausedUntil(regularShoppingItemPause.PausedUntil, today); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
+		return
+	}
+
+	regularShoppingItem.PausedUntil = regularShoppingItemPause.PausedUntil
+
+	paused, err := h.ShoppingListRepo.PauseRegularShoppingItem(&regularShoppingItem, userID, today)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusNotFound, &NotFoundErrorMsg{"指定された定期ショッピングアイテムは存在しません。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if !paused {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusConflict, &ConflictErrorMsg{"この定期ショッピングアイテムは既に更新されています。最新の買い物リストを確認してください。"}))
+		return
+	}
+
+	shoppingData, err := generateUpdatedRegularShoppingData(h, regularShoppingItemID, requestLocale(r.Context()))
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
@@ -1446,28 +2321,28 @@ func (h *DBHandler) DeleteRegularShoppingItem(w http.ResponseWriter, r *http.Req
 	_, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	regularShoppingItemID, err := strconv.Atoi(mux.Vars(r)["id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"定期ショッピングアイテムIDを正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"定期ショッピングアイテムIDを正しく指定してください。"}))
 		return
 	}
 
 	if err := h.ShoppingListRepo.DeleteRegularShoppingItem(regularShoppingItemID); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(&DeleteContentMsg{"定期ショッピングアイテムを削除しました。"}); err != nil {
+	if err := json.NewEncoder(w).Encode(&DeleteContentMsg{localizeMessage(r.Context(), "定期ショッピングアイテムを削除しました。")}); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -1475,19 +2350,160 @@ func (h *DBHandler) DeleteRegularShoppingItem(w http.ResponseWriter, r *http.Req
 
 func (h *DBHandler) PutShoppingListCustomCategoryIdToMediumCategoryId(w http.ResponseWriter, r *http.Request) {
 	categoriesID := struct {
-		MediumCategoryID int `json:"medium_category_id"`
-		CustomCategoryID int `json:"custom_category_id"`
+		MediumCategoryID        int             `json:"medium_category_id"`
+		CustomCategoryID        int             `json:"custom_category_id"`
+		ReplaceCustomCategoryID model.NullInt64 `json:"replace_custom_category_id"`
 	}{}
 
 	if err := json.NewDecoder(r.Body).Decode(&categoriesID); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if categoriesID.ReplaceCustomCategoryID.Valid {
+		if err := h.ShoppingListRepo.PutShoppingListCustomCategoryIdToCustomCategoryId(int(categoriesID.ReplaceCustomCategoryID.Int64), categoriesID.CustomCategoryID); err != nil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
 		return
 	}
 
 	if err := h.ShoppingListRepo.PutShoppingListCustomCategoryIdToMediumCategoryId(categoriesID.MediumCategoryID, categoriesID.CustomCategoryID); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *DBHandler) MergeShoppingListCustomCategory(w http.ResponseWriter, r *http.Request) {
+	if err := verifyServiceToken(r); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+		return
+	}
+
+	var customCategoriesMerge model.CustomCategoriesMerge
+	if err := json.NewDecoder(r.Body).Decode(&customCategoriesMerge); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	mergedShoppingItemsID, err := h.ShoppingListRepo.MergeShoppingListCustomCategory(&customCategoriesMerge)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&mergedShoppingItemsID); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) RevertShoppingListCustomCategoryMerge(w http.ResponseWriter, r *http.Request) {
+	if err := verifyServiceToken(r); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+		return
+	}
+
+	var mergedShoppingItemsID model.MergedShoppingItemsID
+	if err := json.NewDecoder(r.Body).Decode(&mergedShoppingItemsID); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if err := h.ShoppingListRepo.RevertShoppingListCustomCategoryMerge(&mergedShoppingItemsID); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *DBHandler) GetShopSectionOrderList(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	shopSectionList, err := h.ShoppingListRepo.GetShopSectionList(userID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	shopSectionOrderList := model.ShopSectionOrderList{
+		ShopSectionOrderList: make([]model.ShopSectionOrder, 0),
+	}
+
+	for _, shopSection := range shopSectionList {
+		lastIndex := len(shopSectionOrderList.ShopSectionOrderList) - 1
+		if lastIndex < 0 || shopSectionOrderList.ShopSectionOrderList[lastIndex].Shop != shopSection.Shop {
+			shopSectionOrderList.ShopSectionOrderList = append(shopSectionOrderList.ShopSectionOrderList, model.ShopSectionOrder{
+				Shop:              shopSection.Shop,
+				BigCategoryIDList: make([]int, 0),
+			})
+
+			lastIndex++
+		}
+
+		shopSectionOrderList.ShopSectionOrderList[lastIndex].BigCategoryIDList = append(shopSectionOrderList.ShopSectionOrderList[lastIndex].BigCategoryIDList, shopSection.BigCategoryID)
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&shopSectionOrderList); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) PutShopSectionOrder(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	var shopSectionOrder model.ShopSectionOrder
+	if err := json.NewDecoder(r.Body).Decode(&shopSectionOrder); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	if shopSectionOrder.BigCategoryIDList == nil {
+		shopSectionOrder.BigCategoryIDList = make([]int, 0)
+	}
+
+	if err := validateShopSectionOrder(&shopSectionOrder); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
+		return
+	}
+
+	if err := h.ShoppingListRepo.PutShopSectionOrder(&shopSectionOrder, userID); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&shopSectionOrder); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
 }
@@ -1,16 +1,16 @@
 package handler
 
 import (
-	"bytes"
+	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 	"net/url"
 	"reflect"
 	"strconv"
 	"strings"
-	"text/template"
 	"time"
 
 	"github.com/garyburd/redigo/redis"
@@ -24,18 +24,6 @@ type Todos interface {
 	ShowTodo() (string, error)
 }
 
-type TodoSearchQuery struct {
-	DateType     string
-	StartDate    string
-	EndDate      string
-	CompleteFlag string
-	TodoContent  string
-	Sort         string
-	SortType     string
-	Limit        string
-	UserID       string
-}
-
 type NoContentMsg struct {
 	Message string `json:"message"`
 }
@@ -55,7 +43,7 @@ func (e *TodoValidationErrorMsg) Error() string {
 
 func validateTodo(todos Todos) error {
 	validate := validator.New()
-	validate.RegisterCustomTypeFunc(validateValuer, model.Date{})
+	validate.RegisterCustomTypeFunc(validateValuer, model.Date{}, model.NullString{}, model.NullInt{})
 	if err := validate.RegisterValidation("date", dateValidation); err != nil {
 		return err
 	}
@@ -64,6 +52,14 @@ func validateTodo(todos Todos) error {
 		return err
 	}
 
+	if err := validate.RegisterValidation("with_recurrence", withRecurrenceValidation); err != nil {
+		return err
+	}
+
+	if err := validate.RegisterValidation("recurrence_rule", recurrenceRuleValidation); err != nil {
+		return err
+	}
+
 	err := validate.Struct(todos)
 	if err == nil {
 		return nil
@@ -74,6 +70,14 @@ func validateTodo(todos Todos) error {
 		var errorMessage string
 
 		fieldName := err.Field()
+		if strings.HasPrefix(fieldName, "LabelList[") {
+			fieldName = "Label"
+		}
+
+		if strings.HasPrefix(fieldName, "AssigneeList[") {
+			fieldName = "Assignee"
+		}
+
 		switch fieldName {
 		case "ImplementationDate":
 			errorMessage = "todo実施日を正しく選択してください。"
@@ -89,6 +93,70 @@ func validateTodo(todos Todos) error {
 			case "blank":
 				errorMessage = "内容の文字列先頭か末尾に空白がないか確認してください。"
 			}
+		case "RecurrenceType":
+			tagName := err.Tag()
+			switch tagName {
+			case "oneof":
+				errorMessage = "繰り返しの種類を正しく選択してください。"
+			case "with_recurrence":
+				if err.Value() == "schedule" {
+					errorMessage = "繰り返しルールが入力されていません。"
+				} else {
+					errorMessage = "完了後に繰り返す日数が入力されていません。"
+				}
+			}
+		case "RecurrenceRule":
+			tagName := err.Tag()
+			switch tagName {
+			case "max":
+				errorMessage = "繰り返しルールは255文字以内で入力してください。"
+			case "recurrence_rule":
+				errorMessage = "繰り返しルールを正しく入力してください。"
+			}
+		case "RecurrenceInterval":
+			errorMessage = "完了後に繰り返す日数は1以上365以下の整数を入力してください。"
+		case "Priority":
+			errorMessage = "優先度を正しく選択してください。"
+		case "LabelList":
+			tagName := err.Tag()
+			switch tagName {
+			case "max":
+				errorMessage = "ラベルは10個以内で登録してください。"
+			case "unique":
+				errorMessage = "同じラベルが重複して登録されています。"
+			}
+		case "Label":
+			tagName := err.Tag()
+			switch tagName {
+			case "required":
+				errorMessage = "ラベルが入力されていません。"
+			case "max":
+				errorMessage = "ラベルは20文字以内で入力してください。"
+			case "blank":
+				errorMessage = "ラベルの文字列先頭か末尾に空白がないか確認してください。"
+			}
+		case "SubtaskList":
+			errorMessage = "サブタスクは30個以内で登録してください。"
+		case "SubtaskContent":
+			tagName := err.Tag()
+			switch tagName {
+			case "required":
+				errorMessage = "サブタスクの内容が入力されていません。"
+			case "max":
+				errorMessage = "サブタスクの内容は100文字以内で入力してください。"
+			case "blank":
+				errorMessage = "サブタスクの内容の文字列先頭か末尾に空白がないか確認してください。"
+			}
+		case "AssigneeList":
+			tagName := err.Tag()
+			switch tagName {
+			case "max":
+				errorMessage = "担当者は10人以内で選択してください。"
+			case "unique":
+				errorMessage = "同じ担当者が重複して選択されています。"
+			}
+		case "Assignee":
+			errorMessage = "担当者を正しく選択してください。"
 		}
 
 		todoValidationErrorMsg.Message = append(todoValidationErrorMsg.Message, errorMessage)
@@ -132,6 +200,103 @@ func dateValidation(fl validator.FieldLevel) bool {
 	return true
 }
 
+// withRecurrenceValidation reports whether the recurrence rule or the recurrence interval required by the recurrence type is specified.
+func withRecurrenceValidation(fl validator.FieldLevel) bool {
+	var recurrenceRule model.NullString
+	var recurrenceInterval model.NullInt
+
+	switch todo := reflect.Indirect(fl.Parent()).Interface().(type) {
+	case model.Todo:
+		recurrenceRule, recurrenceInterval = todo.RecurrenceRule, todo.RecurrenceInterval
+	case model.GroupTodo:
+		recurrenceRule, recurrenceInterval = todo.RecurrenceRule, todo.RecurrenceInterval
+	default:
+		return false
+	}
+
+	switch fl.Field().String() {
+	case "schedule":
+		return recurrenceRule.Valid && len(recurrenceRule.String) != 0
+	case "after_completion":
+		return recurrenceInterval.Valid
+	}
+
+	return true
+}
+
+// generateTodoRecurrence returns the recurrence rule and the recurrence interval used by the recurrence type,
+// leaving out the other one. The recurrence rule is converted into the canonical form.
+func generateTodoRecurrence(recurrenceType model.NullString, recurrenceRule model.NullString, recurrenceInterval model.NullInt) (model.NullString, model.NullInt) {
+	switch recurrenceType.String {
+	case "schedule":
+		if parsedRecurrenceRule, err := model.ParseRecurrenceRule(recurrenceRule.String); err == nil {
+			recurrenceRule = model.NullString{NullString: sql.NullString{String: parsedRecurrenceRule.String(), Valid: true}}
+		}
+
+		return recurrenceRule, model.NullInt{}
+	case "after_completion":
+		return model.NullString{}, recurrenceInterval
+	}
+
+	return model.NullString{}, model.NullInt{}
+}
+
+// generateTodoEditScope returns the scope of the edit of a recurring todo, which is this todo only by default.
+func generateTodoEditScope(scope string) (string, error) {
+	switch scope {
+	case "", model.TodoEditScopeThis:
+		return model.TodoEditScopeThis, nil
+	case model.TodoEditScopeFollowing:
+		return model.TodoEditScopeFollowing, nil
+	}
+
+	return "", &BadRequestErrorMsg{"編集範囲を正しく指定してください。"}
+}
+
+// generateTodoSeries sets the recurrence of the edited todo.
+// Editing only this todo of a series keeps the recurrence of the series, while editing this and the following todos,
+// or a todo which does not belong to any series, restarts the recurrence from the edited implementation date.
+func generateTodoSeries(todo *model.Todo, dbTodo *model.Todo, editScope string) {
+	todo.SeriesID = dbTodo.SeriesID
+
+	if dbTodo.SeriesID.Valid && editScope == model.TodoEditScopeThis {
+		todo.RecurrenceType = dbTodo.RecurrenceType
+		todo.RecurrenceRule = dbTodo.RecurrenceRule
+		todo.RecurrenceInterval = dbTodo.RecurrenceInterval
+		todo.RecurrenceStartDate = dbTodo.RecurrenceStartDate
+		return
+	}
+
+	todo.RecurrenceRule, todo.RecurrenceInterval = generateTodoRecurrence(todo.RecurrenceType, todo.RecurrenceRule, todo.RecurrenceInterval)
+	if !todo.RecurrenceType.Valid {
+		todo.RecurrenceStartDate = model.NullTime{}
+		return
+	}
+
+	if !todo.SeriesID.Valid {
+		todo.SeriesID = model.NullInt64{NullInt64: sql.NullInt64{Int64: int64(dbTodo.ID), Valid: true}}
+	}
+
+	todo.RecurrenceStartDate = model.NullTime{NullTime: sql.NullTime{Time: todo.ImplementationDate.Time, Valid: true}}
+}
+
+// generateTodoSubtaskList returns the subtasks of the edited todo.
+// A subtask keeps its ID only when it is one of the stored subtasks of the todo, otherwise it is registered as a new subtask.
+func generateTodoSubtaskList(subtaskList []model.TodoSubtask, dbSubtaskList []model.TodoSubtask) []model.TodoSubtask {
+	dbSubtaskIDs := make(map[int]bool, len(dbSubtaskList))
+	for _, dbSubtask := range dbSubtaskList {
+		dbSubtaskIDs[dbSubtask.ID] = true
+	}
+
+	for i := range subtaskList {
+		if !dbSubtaskIDs[subtaskList[i].ID] {
+			subtaskList[i].ID = 0
+		}
+	}
+
+	return subtaskList
+}
+
 func blankValidation(fl validator.FieldLevel) bool {
 	text := fl.Field().String()
 
@@ -142,163 +307,148 @@ func blankValidation(fl validator.FieldLevel) bool {
 	return true
 }
 
-func NewTodoSearchQuery(urlQuery url.Values, userID string) (*TodoSearchQuery, error) {
+// maxTodoSearchLimit is the largest number of todos a search can return at once.
+const maxTodoSearchLimit = 100
+
+// NewTodoSearchCriteria generates the criteria of the todo search from the url query,
+// and returns BadRequestErrorMsg when any of the conditions is invalid.
+func NewTodoSearchCriteria(urlQuery url.Values) (model.TodoSearchCriteria, error) {
 	startDate, err := generateStartDate(urlQuery.Get("start_date"))
 	if err != nil {
-		return nil, err
+		return model.TodoSearchCriteria{}, &BadRequestErrorMsg{"日付を正しく指定してください。"}
 	}
 
 	endDate, err := generateEndDate(urlQuery.Get("end_date"))
 	if err != nil {
-		return nil, err
-	}
-
-	return &TodoSearchQuery{
-		DateType:     urlQuery.Get("date_type"),
-		StartDate:    startDate,
-		EndDate:      endDate,
-		CompleteFlag: urlQuery.Get("complete_flag"),
-		TodoContent:  urlQuery.Get("todo_content"),
-		Sort:         urlQuery.Get("sort"),
-		SortType:     urlQuery.Get("sort_type"),
-		Limit:        urlQuery.Get("limit"),
-		UserID:       userID,
-	}, nil
-}
+		return model.TodoSearchCriteria{}, &BadRequestErrorMsg{"日付を正しく指定してください。"}
+	}
 
-func generateStartDate(date string) (string, error) {
-	if len(date) == 0 {
-		return "", nil
+	todoSearchCriteria := model.TodoSearchCriteria{
+		DateType:    model.TodoSearchDateType(urlQuery.Get("date_type")),
+		StartDate:   startDate,
+		EndDate:     endDate,
+		TodoContent: urlQuery.Get("todo_content"),
+		Label:       urlQuery.Get("label"),
+		Sort:        model.TodoSearchSort(urlQuery.Get("sort")),
+		SortType:    model.TodoSearchSortType(strings.ToLower(urlQuery.Get("sort_type"))),
 	}
 
-	startDate, err := time.Parse("2006-01-02", date[:10])
-	if err != nil {
-		return "", err
+	if len(todoSearchCriteria.DateType) != 0 && !todoSearchCriteria.DateType.IsValid() {
+		return model.TodoSearchCriteria{}, &BadRequestErrorMsg{"日付の種類を正しく指定してください。"}
+	}
+
+	if completeFlag := urlQuery.Get("complete_flag"); len(completeFlag) != 0 {
+		b, err := strconv.ParseBool(completeFlag)
+		if err != nil {
+			return model.TodoSearchCriteria{}, &BadRequestErrorMsg{"完了状態を正しく指定してください。"}
+		}
+
+		todoSearchCriteria.CompleteFlag = sql.NullBool{Bool: b, Valid: true}
+	}
+
+	if priority := urlQuery.Get("priority"); len(priority) != 0 {
+		p, err := strconv.Atoi(priority)
+		if err != nil || p < 0 || p > 3 {
+			return model.TodoSearchCriteria{}, &BadRequestErrorMsg{"優先度を正しく指定してください。"}
+		}
+
+		todoSearchCriteria.Priority = model.NullInt{Int: p, Valid: true}
+	}
+
+	if len(todoSearchCriteria.Sort) != 0 && !todoSearchCriteria.Sort.IsValid() {
+		return model.TodoSearchCriteria{}, &BadRequestErrorMsg{"並び替えの項目を正しく指定してください。"}
+	}
+
+	if len(todoSearchCriteria.SortType) != 0 && !todoSearchCriteria.SortType.IsValid() {
+		return model.TodoSearchCriteria{}, &BadRequestErrorMsg{"並び順を正しく指定してください。"}
+	}
+
+	if limit := urlQuery.Get("limit"); len(limit) != 0 {
+		l, err := strconv.Atoi(limit)
+		if err != nil || l < 1 {
+			return model.TodoSearchCriteria{}, &BadRequestErrorMsg{"取得件数を正しく指定してください。"}
+		}
+
+		if l > maxTodoSearchLimit {
+			return model.TodoSearchCriteria{}, &BadRequestErrorMsg{"取得件数は100件以下で指定してください。"}
+		}
+
+		todoSearchCriteria.Limit = model.NullInt{Int: l, Valid: true}
+	}
+
+	return todoSearchCriteria, nil
+}
+
+func parseSearchDate(date string) (time.Time, error) {
+	if len(date) < len("2006-01-02") {
+		return time.Time{}, errors.New("invalid date")
 	}
 
-	return startDate.String(), nil
+	return time.Parse("2006-01-02", date[:10])
 }
 
-func generateEndDate(date string) (string, error) {
+func generateStartDate(date string) (model.NullTime, error) {
 	if len(date) == 0 {
-		return "", nil
+		return model.NullTime{}, nil
 	}
 
-	parseDate, err := time.Parse("2006-01-02", date[:10])
+	startDate, err := parseSearchDate(date)
 	if err != nil {
-		return "", err
+		return model.NullTime{}, err
 	}
 
-	endDate := time.Date(parseDate.Year(), parseDate.Month(), parseDate.Day()+1, 0, 0, 0, 0, parseDate.Location()).Add(-1 * time.Second)
-
-	return endDate.String(), nil
+	return model.NullTime{NullTime: sql.NullTime{Time: startDate, Valid: true}}, nil
 }
 
-func generateTodoSqlQuery(todoSearchQuery *TodoSearchQuery) (string, error) {
-	query := `
-        SELECT
-            id,
-            posted_date,
-            updated_date,
-            implementation_date,
-            due_date,
-            todo_content,
-            complete_flag
-        FROM
-            todo_list
-        WHERE
-            user_id = "{{ .UserID }}"
-
-        {{ with $DateType := .DateType }}
-        AND
-            {{ $DateType }} >= "{{ $.StartDate }}"
-        AND
-            {{ $DateType }} <= "{{ $.EndDate }}"
-        {{ else }}
-        AND
-            implementation_date >= "{{ .StartDate }}"
-        AND
-            implementation_date <= "{{ .EndDate }}"
-        {{ end }}
-
-        {{ with $CompleteFlag := .CompleteFlag }}
-        AND
-            complete_flag = {{ $CompleteFlag }}
-        {{ end }}
-
-        {{ with $TodoContent := .TodoContent }}
-        AND
-            todo_content
-        LIKE
-            "%{{ $TodoContent }}%"
-        {{ end }}
-
-        {{ with $Sort := .Sort}}
-        ORDER BY
-            {{ $Sort }}
-        {{ else }}
-        ORDER BY
-            implementation_date
-        {{ end }}
-
-        {{ with $SortType := .SortType}}
-        {{ $SortType }}, updated_date DESC
-        {{ else }}
-        ASC, updated_date DESC
-        {{ end }}
-
-        {{ with $Limit := .Limit}}
-        LIMIT
-        {{ $Limit }}
-        {{ end }}`
-
-	var buffer bytes.Buffer
-	todoSqlQueryTemplate, err := template.New("TodoSqlQueryTemplate").Parse(query)
-	if err != nil {
-		return "", err
+func generateEndDate(date string) (model.NullTime, error) {
+	if len(date) == 0 {
+		return model.NullTime{}, nil
 	}
 
-	if err := todoSqlQueryTemplate.Execute(&buffer, todoSearchQuery); err != nil {
-		return "", err
+	parseDate, err := parseSearchDate(date)
+	if err != nil {
+		return model.NullTime{}, err
 	}
 
-	return buffer.String(), nil
+	endDate := time.Date(parseDate.Year(), parseDate.Month(), parseDate.Day()+1, 0, 0, 0, 0, parseDate.Location()).Add(-1 * time.Second)
+
+	return model.NullTime{NullTime: sql.NullTime{Time: endDate, Valid: true}}, nil
 }
 
 func (h *DBHandler) GetDailyTodoList(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	date, err := time.Parse("2006-01-02", mux.Vars(r)["date"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"日付を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"日付を正しく指定してください。"}))
 		return
 	}
 
-	implementationTodoList, err := h.TodoRepo.GetDailyImplementationTodoList(date, userID)
+	implementationTodoList, err := h.TodoRepo.GetDailyImplementationTodoList(r.Context(), date, userID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	dueTodoList, err := h.TodoRepo.GetDailyDueTodoList(date, userID)
+	dueTodoList, err := h.TodoRepo.GetDailyDueTodoList(r.Context(), date, userID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if len(implementationTodoList) == 0 && len(dueTodoList) == 0 {
 		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 		w.WriteHeader(http.StatusOK)
-		if err := json.NewEncoder(w).Encode(&NoContentMsg{"今日実施予定todo、締切予定todoは登録されていません。"}); err != nil {
+		if err := json.NewEncoder(w).Encode(&NoContentMsg{localizeMessage(r.Context(), "今日実施予定todo、締切予定todoは登録されていません。")}); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
@@ -320,17 +470,17 @@ func (h *DBHandler) GetMonthlyTodoList(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	firstDay, err := time.Parse("2006-01", mux.Vars(r)["year_month"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"年月を正しく指定してください。"}))
 		return
 	}
 
@@ -338,20 +488,20 @@ func (h *DBHandler) GetMonthlyTodoList(w http.ResponseWriter, r *http.Request) {
 
 	implementationTodoList, err := h.TodoRepo.GetMonthlyImplementationTodoList(firstDay, lastDay, userID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	dueTodoList, err := h.TodoRepo.GetMonthlyDueTodoList(firstDay, lastDay, userID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if len(implementationTodoList) == 0 && len(dueTodoList) == 0 {
 		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 		w.WriteHeader(http.StatusOK)
-		if err := json.NewEncoder(w).Encode(&NoContentMsg{"当月実施予定todoは登録されていません。"}); err != nil {
+		if err := json.NewEncoder(w).Encode(&NoContentMsg{localizeMessage(r.Context(), "当月実施予定todoは登録されていません。")}); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
@@ -373,20 +523,20 @@ func (h *DBHandler) GetExpiredTodoList(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	now := h.TimeManage.Now()
 	dueDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
 
-	expiredTodoList, err := h.TodoRepo.GetExpiredTodoList(dueDate, userID)
+	expiredTodoList, err := h.TodoRepo.GetExpiredTodoList(r.Context(), dueDate, userID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -402,40 +552,47 @@ func (h *DBHandler) PostTodo(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	var todo model.Todo
 	if err := json.NewDecoder(r.Body).Decode(&todo); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if err := validateTodo(&todo); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, err))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
 		return
 	}
 
+	todo.SubtaskList = generateTodoSubtaskList(todo.SubtaskList, nil)
+
+	todo.RecurrenceRule, todo.RecurrenceInterval = generateTodoRecurrence(todo.RecurrenceType, todo.RecurrenceRule, todo.RecurrenceInterval)
+	if todo.RecurrenceType.Valid {
+		todo.RecurrenceStartDate = model.NullTime{NullTime: sql.NullTime{Time: todo.ImplementationDate.Time, Valid: true}}
+	}
+
 	result, err := h.TodoRepo.PostTodo(&todo, userID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	lastInsertId, err := result.LastInsertId()
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	dbTodo, err := h.TodoRepo.GetTodo(int(lastInsertId))
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -451,39 +608,69 @@ func (h *DBHandler) PutTodo(w http.ResponseWriter, r *http.Request) {
 	_, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	var todo model.Todo
 	if err := json.NewDecoder(r.Body).Decode(&todo); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if err := validateTodo(&todo); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, err))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
 		return
 	}
 
 	todoID, err := strconv.Atoi(mux.Vars(r)["id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"todo ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"todo ID を正しく指定してください。"}))
 		return
 	}
 
-	if err := h.TodoRepo.PutTodo(&todo, todoID); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+	editScope, err := generateTodoEditScope(r.URL.Query().Get("scope"))
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
 		return
 	}
 
 	dbTodo, err := h.TodoRepo.GetTodo(todoID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		if errors.Is(err, sql.ErrNoRows) {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"指定されたtodoは存在しません。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	generateTodoSeries(&todo, dbTodo, editScope)
+	todo.SubtaskList = generateTodoSubtaskList(todo.SubtaskList, dbTodo.SubtaskList)
+
+	var nextTodo *model.Todo
+	if !dbTodo.CompleteFlag && todo.CompleteFlag {
+		now := h.TimeManage.Now()
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+		if next, ok := todo.NextTodo(today); ok {
+			nextTodo = &next
+		}
+	}
+
+	if err := h.TodoRepo.PutTodo(&todo, todoID, editScope, nextTodo); err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	dbTodo, err = h.TodoRepo.GetTodo(todoID)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
@@ -499,28 +686,28 @@ func (h *DBHandler) DeleteTodo(w http.ResponseWriter, r *http.Request) {
 	_, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	todoID, err := strconv.Atoi(mux.Vars(r)["id"])
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"todo ID を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"todo ID を正しく指定してください。"}))
 		return
 	}
 
 	if err := h.TodoRepo.DeleteTodo(todoID); err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(&DeleteContentMsg{"todoを削除しました。"}); err != nil {
+	if err := json.NewEncoder(w).Encode(&DeleteContentMsg{localizeMessage(r.Context(), "todoを削除しました。")}); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -530,38 +717,30 @@ func (h *DBHandler) SearchTodoList(w http.ResponseWriter, r *http.Request) {
 	userID, err := verifySessionID(h, w, r)
 	if err != nil {
 		if err == http.ErrNoCookie || err == redis.ErrNil {
-			errorResponseByJSON(w, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
 			return
 		}
 
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
-		return
-	}
-
-	urlQuery := r.URL.Query()
-
-	todoSearchQuery, err := NewTodoSearchQuery(urlQuery, userID)
-	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"日付を正しく指定してください。"}))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
-	todoSqlQuery, err := generateTodoSqlQuery(todoSearchQuery)
+	todoSearchCriteria, err := NewTodoSearchCriteria(r.URL.Query())
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, err))
 		return
 	}
 
-	dbSearchTodoList, err := h.TodoRepo.SearchTodoList(todoSqlQuery)
+	dbSearchTodoList, err := h.TodoRepo.SearchTodoList(todoSearchCriteria, userID)
 	if err != nil {
-		errorResponseByJSON(w, NewHTTPError(http.StatusInternalServerError, nil))
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
 		return
 	}
 
 	if len(dbSearchTodoList) == 0 {
 		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 		w.WriteHeader(http.StatusOK)
-		if err := json.NewEncoder(w).Encode(&NoContentMsg{"条件に一致するtodoは見つかりませんでした。"}); err != nil {
+		if err := json.NewEncoder(w).Encode(&NoContentMsg{localizeMessage(r.Context(), "条件に一致するtodoは見つかりませんでした。")}); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
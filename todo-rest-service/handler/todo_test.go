@@ -1,13 +1,17 @@
 package handler
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 
@@ -18,17 +22,17 @@ import (
 
 type MockTodoRepository struct{}
 
-func (m MockTodoRepository) GetDailyImplementationTodoList(date time.Time, userID string) ([]model.Todo, error) {
+func (m MockTodoRepository) GetDailyImplementationTodoList(ctx context.Context, date time.Time, userID string) ([]model.Todo, error) {
 	return []model.Todo{
-		{ID: 3, PostedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, TodoContent: "電車定期券更新", CompleteFlag: true},
-		{ID: 4, PostedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 12, 0, 0, 0, 0, time.UTC)}, TodoContent: "醤油購入", CompleteFlag: false},
+		{ID: 3, PostedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, TodoContent: "電車定期券更新", CompleteFlag: true, Priority: 3, LabelList: []string{"手続き", "交通費"}},
+		{ID: 4, PostedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 12, 0, 0, 0, 0, time.UTC)}, TodoContent: "醤油購入", CompleteFlag: false, Priority: 2, LabelList: []string{"買い物"}, SubtaskList: []model.TodoSubtask{{ID: 1, SubtaskContent: "濃口醤油", CompleteFlag: true}, {ID: 2, SubtaskContent: "薄口醤油", CompleteFlag: false}}},
 	}, nil
 }
 
-func (m MockTodoRepository) GetDailyDueTodoList(date time.Time, userID string) ([]model.Todo, error) {
+func (m MockTodoRepository) GetDailyDueTodoList(ctx context.Context, date time.Time, userID string) ([]model.Todo, error) {
 	return []model.Todo{
 		{ID: 2, PostedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 9, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, TodoContent: "コストコ鶏肉セール 5パック購入", CompleteFlag: true},
-		{ID: 3, PostedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, TodoContent: "電車定期券更新", CompleteFlag: true},
+		{ID: 3, PostedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, TodoContent: "電車定期券更新", CompleteFlag: true, Priority: 3, LabelList: []string{"手続き", "交通費"}},
 	}, nil
 }
 
@@ -36,8 +40,8 @@ func (m MockTodoRepository) GetMonthlyImplementationTodoList(firstDay time.Time,
 	return []model.Todo{
 		{ID: 1, PostedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 5, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 5, 0, 0, 0, 0, time.UTC)}, TodoContent: "今月の予算を立てる", CompleteFlag: true},
 		{ID: 2, PostedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 9, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, TodoContent: "コストコ鶏肉セール 5パック購入", CompleteFlag: true},
-		{ID: 3, PostedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, TodoContent: "電車定期券更新", CompleteFlag: true},
-		{ID: 4, PostedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 12, 0, 0, 0, 0, time.UTC)}, TodoContent: "醤油購入", CompleteFlag: false},
+		{ID: 3, PostedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, TodoContent: "電車定期券更新", CompleteFlag: true, Priority: 3, LabelList: []string{"手続き", "交通費"}},
+		{ID: 4, PostedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 12, 0, 0, 0, 0, time.UTC)}, TodoContent: "醤油購入", CompleteFlag: false, Priority: 2, LabelList: []string{"買い物"}, SubtaskList: []model.TodoSubtask{{ID: 1, SubtaskContent: "濃口醤油", CompleteFlag: true}, {ID: 2, SubtaskContent: "薄口醤油", CompleteFlag: false}}},
 	}, nil
 }
 
@@ -45,16 +49,16 @@ func (m MockTodoRepository) GetMonthlyDueTodoList(firstDay time.Time, lastDay ti
 	return []model.Todo{
 		{ID: 1, PostedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 5, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 5, 0, 0, 0, 0, time.UTC)}, TodoContent: "今月の予算を立てる", CompleteFlag: true},
 		{ID: 2, PostedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 9, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, TodoContent: "コストコ鶏肉セール 5パック購入", CompleteFlag: true},
-		{ID: 3, PostedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, TodoContent: "電車定期券更新", CompleteFlag: true},
-		{ID: 4, PostedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 12, 0, 0, 0, 0, time.UTC)}, TodoContent: "醤油購入", CompleteFlag: false},
+		{ID: 3, PostedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, TodoContent: "電車定期券更新", CompleteFlag: true, Priority: 3, LabelList: []string{"手続き", "交通費"}},
+		{ID: 4, PostedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 12, 0, 0, 0, 0, time.UTC)}, TodoContent: "醤油購入", CompleteFlag: false, Priority: 2, LabelList: []string{"買い物"}, SubtaskList: []model.TodoSubtask{{ID: 1, SubtaskContent: "濃口醤油", CompleteFlag: true}, {ID: 2, SubtaskContent: "薄口醤油", CompleteFlag: false}}},
 	}, nil
 }
 
-func (m MockTodoRepository) GetExpiredTodoList(dueDate time.Time, userID string) (*model.ExpiredTodoList, error) {
+func (m MockTodoRepository) GetExpiredTodoList(ctx context.Context, dueDate time.Time, userID string) (*model.ExpiredTodoList, error) {
 	return &model.ExpiredTodoList{
 		ExpiredTodoList: []model.Todo{
 			{ID: 1, PostedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 5, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 5, 0, 0, 0, 0, time.UTC)}, TodoContent: "今月の予算を立てる", CompleteFlag: false},
-			{ID: 4, PostedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 12, 0, 0, 0, 0, time.UTC)}, TodoContent: "醤油購入", CompleteFlag: false},
+			{ID: 4, PostedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 12, 0, 0, 0, 0, time.UTC)}, TodoContent: "醤油購入", CompleteFlag: false, Priority: 2, LabelList: []string{"買い物"}, SubtaskList: []model.TodoSubtask{{ID: 1, SubtaskContent: "濃口醤油", CompleteFlag: true}, {ID: 2, SubtaskContent: "薄口醤油", CompleteFlag: false}}},
 		},
 	}, nil
 }
@@ -68,6 +72,12 @@ func (m MockTodoRepository) GetTodo(todoId int) (*model.Todo, error) {
 		DueDate:            model.Date{Time: time.Date(2020, 7, 30, 0, 0, 0, 0, time.UTC)},
 		TodoContent:        "食器用洗剤2つ購入",
 		CompleteFlag:       false,
+		Priority:           1,
+		LabelList:          []string{"買い物", "日用品"},
+		SubtaskList: []model.TodoSubtask{
+			{ID: 1, SubtaskContent: "ドラッグストアの特売日を確認", CompleteFlag: true},
+			{ID: 2, SubtaskContent: "詰め替え用を購入", CompleteFlag: false},
+		},
 	}, nil
 }
 
@@ -75,7 +85,7 @@ func (m MockTodoRepository) PostTodo(todo *model.Todo, userID string) (sql.Resul
 	return MockSqlResult{}, nil
 }
 
-func (m MockTodoRepository) PutTodo(todo *model.Todo, todoID int) error {
+func (m MockTodoRepository) PutTodo(todo *model.Todo, todoID int, editScope string, nextTodo *model.Todo) error {
 	return nil
 }
 
@@ -83,11 +93,11 @@ func (m MockTodoRepository) DeleteTodo(todoID int) error {
 	return nil
 }
 
-func (m MockTodoRepository) SearchTodoList(todoSqlQuery string) ([]model.Todo, error) {
+func (m MockTodoRepository) SearchTodoList(todoSearchCriteria model.TodoSearchCriteria, userID string) ([]model.Todo, error) {
 	return []model.Todo{
 		{ID: 1, PostedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 5, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 5, 0, 0, 0, 0, time.UTC)}, TodoContent: "今月の予算を立てる", CompleteFlag: true},
 		{ID: 2, PostedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 9, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, TodoContent: "コストコ鶏肉セール 5パック購入", CompleteFlag: true},
-		{ID: 3, PostedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, TodoContent: "電車定期券更新", CompleteFlag: true},
+		{ID: 3, PostedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), UpdatedDate: time.Date(2020, 9, 4, 17, 11, 0, 0, time.UTC), ImplementationDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, DueDate: model.Date{Time: time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)}, TodoContent: "電車定期券更新", CompleteFlag: true, Priority: 3, LabelList: []string{"手続き", "交通費"}},
 	}, nil
 }
 
@@ -258,6 +268,37 @@ func TestDBHandler_DeleteTodo(t *testing.T) {
 	testutil.AssertResponseBody(t, res, &DeleteContentMsg{}, &DeleteContentMsg{})
 }
 
+func TestDBHandler_DeleteTodoInEnglish(t *testing.T) {
+	h := DBHandler{
+		AuthRepo: MockAuthRepository{},
+		TodoRepo: MockTodoRepository{},
+	}
+
+	r := httptest.NewRequest("DELETE", "/todo-list/1", nil)
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"id": "1",
+	})
+
+	r.Header.Set("Accept-Language", "en")
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	LocaleMiddleware(http.HandlerFunc(h.DeleteTodo)).ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &DeleteContentMsg{}, &DeleteContentMsg{})
+}
+
 func TestDBHandler_SearchTodoList(t *testing.T) {
 	h := DBHandler{
 		AuthRepo: MockAuthRepository{},
@@ -274,6 +315,8 @@ func TestDBHandler_SearchTodoList(t *testing.T) {
 		"start_date":    "2020-07-05T00:00:00.0000",
 		"end_date":      "2020-07-30T00:00:00.0000",
 		"complete_flag": "true",
+		"priority":      "3",
+		"label":         "手続き",
 		"sort":          "due_date",
 	}
 
@@ -298,3 +341,283 @@ func TestDBHandler_SearchTodoList(t *testing.T) {
 	testutil.AssertResponseHeader(t, res, http.StatusOK)
 	testutil.AssertResponseBody(t, res, &model.SearchTodoList{}, &model.SearchTodoList{})
 }
+
+func TestNewTodoSearchCriteria(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    model.TodoSearchCriteria
+		wantErr string
+	}{
+		{
+			name:  "all conditions",
+			query: "date_type=due_date&start_date=2020-07-05T00:00:00.0000&end_date=2020-07-30T00:00:00.0000&complete_flag=true&todo_content=予算&priority=3&label=手続き&sort=priority&sort_type=DESC&limit=10",
+			want: model.TodoSearchCriteria{
+				DateType:     model.TodoSearchDateTypeDue,
+				StartDate:    model.NullTime{NullTime: sql.NullTime{Time: time.Date(2020, 7, 5, 0, 0, 0, 0, time.UTC), Valid: true}},
+				EndDate:      model.NullTime{NullTime: sql.NullTime{Time: time.Date(2020, 7, 30, 23, 59, 59, 0, time.UTC), Valid: true}},
+				CompleteFlag: sql.NullBool{Bool: true, Valid: true},
+				TodoContent:  "予算",
+				Priority:     model.NullInt{Int: 3, Valid: true},
+				Label:        "手続き",
+				Sort:         model.TodoSearchSortPriority,
+				SortType:     model.TodoSearchSortTypeDesc,
+				Limit:        model.NullInt{Int: 10, Valid: true},
+			},
+		},
+		{name: "no conditions", query: "", want: model.TodoSearchCriteria{}},
+		{name: "short date", query: "start_date=2020", wantErr: "日付を正しく指定してください。"},
+		{name: "hostile date type", query: url.Values{"date_type": {`due_date >= "" OR 1=1 --`}}.Encode(), wantErr: "日付の種類を正しく指定してください。"},
+		{name: "hostile complete flag", query: url.Values{"complete_flag": {"1 OR 1=1"}}.Encode(), wantErr: "完了状態を正しく指定してください。"},
+		{name: "hostile priority", query: url.Values{"priority": {"3 OR 1=1"}}.Encode(), wantErr: "優先度を正しく指定してください。"},
+		{name: "hostile sort", query: url.Values{"sort": {"(SELECT SLEEP(10))"}}.Encode(), wantErr: "並び替えの項目を正しく指定してください。"},
+		{name: "hostile sort type", query: url.Values{"sort_type": {"ASC; DROP TABLE todo_list"}}.Encode(), wantErr: "並び順を正しく指定してください。"},
+		{name: "hostile limit", query: url.Values{"limit": {"1 UNION SELECT * FROM todo_list"}}.Encode(), wantErr: "取得件数を正しく指定してください。"},
+		{name: "zero limit", query: "limit=0", wantErr: "取得件数を正しく指定してください。"},
+		{name: "largest limit", query: "limit=100", want: model.TodoSearchCriteria{Limit: model.NullInt{Int: 100, Valid: true}}},
+		{name: "too large limit", query: "limit=101", wantErr: "取得件数は100件以下で指定してください。"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			urlQuery, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("unexpected error by url.ParseQuery() '%#v'", err)
+			}
+
+			got, err := NewTodoSearchCriteria(urlQuery)
+			if len(tt.wantErr) != 0 {
+				if err == nil {
+					t.Fatalf("NewTodoSearchCriteria() error = nil, want %q", tt.wantErr)
+				}
+
+				if diff := cmp.Diff(tt.wantErr, err.Error()); len(diff) != 0 {
+					t.Errorf("differs: (-want +got)\n%s", diff)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error by NewTodoSearchCriteria() '%#v'", err)
+			}
+
+			if diff := cmp.Diff(tt.want, got); len(diff) != 0 {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+// MockRecurringTodoRepository keeps the todo list in memory,
+// and spawns the next instances of the recurring todos in the same way as the MySQL repository.
+type MockRecurringTodoRepository struct {
+	MockTodoRepository
+	todoList []model.Todo
+}
+
+func (m *MockRecurringTodoRepository) GetTodo(todoId int) (*model.Todo, error) {
+	if todoId < 1 || todoId > len(m.todoList) {
+		return nil, sql.ErrNoRows
+	}
+
+	todo := m.todoList[todoId-1]
+
+	return &todo, nil
+}
+
+func (m *MockRecurringTodoRepository) PutTodo(todo *model.Todo, todoID int, editScope string, nextTodo *model.Todo) error {
+	completeFlag := m.todoList[todoID-1].CompleteFlag
+
+	if editScope == model.TodoEditScopeFollowing && todo.SeriesID.Valid {
+		for i := range m.todoList {
+			followingTodo := &m.todoList[i]
+			if followingTodo.SeriesID != todo.SeriesID || followingTodo.ID <= todoID || followingTodo.CompleteFlag {
+				continue
+			}
+
+			followingTodo.TodoContent = todo.TodoContent
+			followingTodo.RecurrenceType = todo.RecurrenceType
+			followingTodo.RecurrenceRule = todo.RecurrenceRule
+			followingTodo.RecurrenceInterval = todo.RecurrenceInterval
+			followingTodo.RecurrenceStartDate = todo.RecurrenceStartDate
+		}
+	}
+
+	dbTodo := *todo
+	dbTodo.ID = todoID
+	m.todoList[todoID-1] = dbTodo
+
+	if nextTodo == nil || completeFlag {
+		return nil
+	}
+
+	for _, followingTodo := range m.todoList {
+		if followingTodo.SeriesID == nextTodo.SeriesID && followingTodo.ID > todoID {
+			return nil
+		}
+	}
+
+	spawnedTodo := *nextTodo
+	spawnedTodo.ID = len(m.todoList) + 1
+	m.todoList = append(m.todoList, spawnedTodo)
+
+	return nil
+}
+
+// todoSummaryList returns the implementation date, the due date, the content and the completion of the todos.
+func (m *MockRecurringTodoRepository) todoSummaryList() []string {
+	todoSummaryList := make([]string, 0, len(m.todoList))
+	for _, todo := range m.todoList {
+		todoSummaryList = append(todoSummaryList, fmt.Sprintf("%s %s %s %t %s", todo.ImplementationDate.Format("2006-01-02"), todo.DueDate.Format("2006-01-02"), todo.TodoContent, todo.CompleteFlag, todo.RecurrenceRule.String))
+	}
+
+	return todoSummaryList
+}
+
+func putRecurringTodo(t *testing.T, h *DBHandler, todoID string, scope string, body string) {
+	t.Helper()
+
+	r := httptest.NewRequest("PUT", "/todo-list/"+todoID+"?scope="+scope, strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"id": todoID,
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.PutTodo(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+}
+
+func newMockRecurringTodo(id int, implementationDate time.Time, dueDate time.Time, recurrenceRule string) model.Todo {
+	return model.Todo{
+		ID:                  id,
+		ImplementationDate:  model.Date{Time: implementationDate},
+		DueDate:             model.Date{Time: dueDate},
+		TodoContent:         "換気扇掃除",
+		RecurrenceType:      model.NullString{NullString: sql.NullString{String: "schedule", Valid: true}},
+		RecurrenceRule:      model.NullString{NullString: sql.NullString{String: recurrenceRule, Valid: true}},
+		RecurrenceStartDate: model.NullTime{NullTime: sql.NullTime{Time: time.Date(2020, 7, 25, 0, 0, 0, 0, time.UTC), Valid: true}},
+		SeriesID:            model.NullInt64{NullInt64: sql.NullInt64{Int64: 1, Valid: true}},
+	}
+}
+
+func TestDBHandler_PutTodoWithScheduledRecurrence(t *testing.T) {
+	todoRepo := &MockRecurringTodoRepository{
+		todoList: []model.Todo{
+			newMockRecurringTodo(1, time.Date(2020, 7, 25, 0, 0, 0, 0, time.UTC), time.Date(2020, 7, 30, 0, 0, 0, 0, time.UTC), "FREQ=MONTHLY"),
+		},
+	}
+	clock := &MockClock{now: time.Date(2020, 7, 26, 9, 0, 0, 0, time.UTC)}
+
+	h := DBHandler{
+		AuthRepo:   MockAuthRepository{},
+		TodoRepo:   todoRepo,
+		TimeManage: clock,
+	}
+
+	// The recurrence is kept even though the request does not contain it.
+	putRecurringTodo(t, &h, "1", "", `{"implementation_date": "2020-07-25", "due_date": "2020-07-30", "todo_content": "換気扇掃除", "complete_flag": true}`)
+
+	clock.now = time.Date(2020, 8, 20, 9, 0, 0, 0, time.UTC)
+	putRecurringTodo(t, &h, "2", "", `{"implementation_date": "2020-08-25", "due_date": "2020-08-30", "todo_content": "換気扇掃除", "complete_flag": true}`)
+
+	// Reopening and completing the todo again does not spawn another instance.
+	putRecurringTodo(t, &h, "2", "", `{"implementation_date": "2020-08-25", "due_date": "2020-08-30", "todo_content": "換気扇掃除", "complete_flag": false}`)
+	putRecurringTodo(t, &h, "2", "", `{"implementation_date": "2020-08-25", "due_date": "2020-08-30", "todo_content": "換気扇掃除", "complete_flag": true}`)
+
+	want := []string{
+		"2020-07-25 2020-07-30 換気扇掃除 true FREQ=MONTHLY",
+		"2020-08-25 2020-08-30 換気扇掃除 true FREQ=MONTHLY",
+		"2020-09-25 2020-09-30 換気扇掃除 false FREQ=MONTHLY",
+	}
+
+	if diff := cmp.Diff(want, todoRepo.todoSummaryList()); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestDBHandler_PutTodoWithAfterCompletionRecurrence(t *testing.T) {
+	todoRepo := &MockRecurringTodoRepository{
+		todoList: []model.Todo{
+			{
+				ID:                 1,
+				ImplementationDate: model.Date{Time: time.Date(2020, 7, 25, 0, 0, 0, 0, time.UTC)},
+				DueDate:            model.Date{Time: time.Date(2020, 7, 25, 0, 0, 0, 0, time.UTC)},
+				TodoContent:        "浴室乾燥機フィルター掃除",
+			},
+		},
+	}
+	clock := &MockClock{now: time.Date(2020, 7, 25, 9, 0, 0, 0, time.UTC)}
+
+	h := DBHandler{
+		AuthRepo:   MockAuthRepository{},
+		TodoRepo:   todoRepo,
+		TimeManage: clock,
+	}
+
+	putRecurringTodo(t, &h, "1", "", `{"implementation_date": "2020-07-25", "due_date": "2020-07-27", "todo_content": "浴室乾燥機フィルター掃除", "complete_flag": false, "recurrence_type": "after_completion", "recurrence_interval": 14}`)
+
+	// The next instance is scheduled 14 days after the todo is actually completed.
+	clock.now = time.Date(2020, 8, 1, 21, 0, 0, 0, time.UTC)
+	putRecurringTodo(t, &h, "1", "", `{"implementation_date": "2020-07-25", "due_date": "2020-07-27", "todo_content": "浴室乾燥機フィルター掃除", "complete_flag": true}`)
+
+	want := []string{
+		"2020-07-25 2020-07-27 浴室乾燥機フィルター掃除 true ",
+		"2020-08-15 2020-08-17 浴室乾燥機フィルター掃除 false ",
+	}
+
+	if diff := cmp.Diff(want, todoRepo.todoSummaryList()); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+
+	if todoRepo.todoList[1].SeriesID != todoRepo.todoList[0].SeriesID || todoRepo.todoList[0].SeriesID.Int64 != 1 {
+		t.Errorf("series ID differs: %v, %v", todoRepo.todoList[0].SeriesID, todoRepo.todoList[1].SeriesID)
+	}
+}
+
+func TestDBHandler_PutTodoWithFollowingScope(t *testing.T) {
+	todoRepo := &MockRecurringTodoRepository{
+		todoList: []model.Todo{
+			newMockRecurringTodo(1, time.Date(2020, 7, 25, 0, 0, 0, 0, time.UTC), time.Date(2020, 7, 30, 0, 0, 0, 0, time.UTC), "FREQ=MONTHLY"),
+			newMockRecurringTodo(2, time.Date(2020, 8, 25, 0, 0, 0, 0, time.UTC), time.Date(2020, 8, 30, 0, 0, 0, 0, time.UTC), "FREQ=MONTHLY"),
+			newMockRecurringTodo(3, time.Date(2020, 9, 25, 0, 0, 0, 0, time.UTC), time.Date(2020, 9, 30, 0, 0, 0, 0, time.UTC), "FREQ=MONTHLY"),
+		},
+	}
+	todoRepo.todoList[0].CompleteFlag = true
+
+	h := DBHandler{
+		AuthRepo:   MockAuthRepository{},
+		TodoRepo:   todoRepo,
+		TimeManage: &MockClock{now: time.Date(2020, 8, 1, 9, 0, 0, 0, time.UTC)},
+	}
+
+	putRecurringTodo(t, &h, "2", model.TodoEditScopeFollowing, `{"implementation_date": "2020-08-25", "due_date": "2020-08-30", "todo_content": "レンジフード掃除", "complete_flag": false, "recurrence_type": "schedule", "recurrence_rule": "INTERVAL=2;FREQ=MONTHLY"}`)
+	putRecurringTodo(t, &h, "3", model.TodoEditScopeThis, `{"implementation_date": "2020-09-26", "due_date": "2020-09-30", "todo_content": "レンジフード掃除(フィルター交換)", "complete_flag": false}`)
+
+	want := []string{
+		"2020-07-25 2020-07-30 換気扇掃除 true FREQ=MONTHLY",
+		"2020-08-25 2020-08-30 レンジフード掃除 false FREQ=MONTHLY;INTERVAL=2",
+		"2020-09-26 2020-09-30 レンジフード掃除(フィルター交換) false FREQ=MONTHLY;INTERVAL=2",
+	}
+
+	if diff := cmp.Diff(want, todoRepo.todoSummaryList()); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+
+	// The recurrence of the series restarts from the todo edited with the following scope.
+	if got := todoRepo.todoList[2].RecurrenceStartDate.Time; !got.Equal(time.Date(2020, 8, 25, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("recurrence start date differs: %v", got)
+	}
+}
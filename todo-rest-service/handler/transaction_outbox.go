@@ -0,0 +1,768 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/gorilla/mux"
+
+	"github.com/hryze/kakeibo-app-api/todo-rest-service/config"
+	"github.com/hryze/kakeibo-app-api/todo-rest-service/domain/model"
+)
+
+const (
+	transactionOutboxInterval      = 10 * time.Second
+	transactionOutboxBatchSize     = 100
+	transactionOutboxLeaseTime     = 1 * time.Minute
+	transactionOutboxRetryInterval = 10 * time.Second
+	transactionOutboxMaxRetryDelay = 1 * time.Hour
+	transactionOutboxMaxAttempts   = 10
+)
+
+// RunTransactionOutboxWorker delivers the pending transaction commands to account-rest-service until ctx is done.
+func (h *DBHandler) RunTransactionOutboxWorker(ctx context.Context) {
+	ticker := time.NewTicker(transactionOutboxInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := deliverPendingTransactionCommands(h); err != nil {
+				log.Println(err)
+			}
+		}
+	}
+}
+
+func deliverPendingTransactionCommands(h *DBHandler) error {
+	transactionCommandList, err := h.TransactionOutboxRepo.GetPendingTransactionCommandList(h.TimeManage.Now(), transactionOutboxBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, transactionCommand := range transactionCommandList {
+		if _, err := deliverTransactionCommand(h, transactionCommand); err != nil {
+			log.Println(err)
+		}
+	}
+
+	return nil
+}
+
+// deliverTransactionCommand sends the transaction command to account-rest-service and returns the response body.
+// The response body is empty when the transaction command is being delivered by another worker,
+// or waits for an earlier command for the same shopping item.
+func deliverTransactionCommand(h *DBHandler, transactionCommand model.TransactionCommand) ([]byte, error) {
+	now := h.TimeManage.Now().Truncate(time.Second)
+
+	leased, err := h.TransactionOutboxRepo.LeaseTransactionCommand(&transactionCommand, now.Add(transactionOutboxLeaseTime))
+	if err != nil {
+		return nil, err
+	}
+
+	if !leased {
+		return nil, nil
+	}
+
+	transactionCommand.Attempts++
+
+	responseBody, statusCode, err := requestTransactionCommand(transactionCommand)
+	if err == nil && (statusCode == http.StatusOK || statusCode == http.StatusCreated) {
+		if transactionCommand.Command == "create" {
+			var transactionData model.TransactionData
+			if err := json.Unmarshal(responseBody, &transactionData); err != nil {
+				return nil, err
+			}
+
+			transactionCommand.TransactionID = transactionData.ID
+		}
+
+		if err := h.TransactionOutboxRepo.DeliverTransactionCommand(&transactionCommand); err != nil {
+			return nil, err
+		}
+
+		return responseBody, nil
+	}
+
+	if err == nil {
+		err = fmt.Errorf("transaction command %d: %s", transactionCommand.ID, http.StatusText(statusCode))
+	}
+
+	lastError := err.Error()
+	if len(lastError) > 255 {
+		lastError = lastError[:255]
+	}
+
+	transactionCommand.LastError = model.NullString{NullString: sql.NullString{String: lastError, Valid: true}}
+
+	// The transaction command rejected by account-rest-service is not retried, and is left to the reconciliation.
+	// 401 Unauthorized is retried, since it is caused by the service token, e.g. while the token is being rotated.
+	retryable := statusCode == 0 || statusCode == http.StatusUnauthorized || statusCode >= http.StatusInternalServerError
+	if retryable && transactionCommand.Attempts < transactionOutboxMaxAttempts {
+		transactionCommand.NextAttemptDate = now.Add(transactionCommandRetryDelay(transactionCommand.Attempts))
+	} else {
+		transactionCommand.Status = "failed"
+	}
+
+	if err := h.TransactionOutboxRepo.PutTransactionCommand(&transactionCommand); err != nil {
+		return nil, err
+	}
+
+	return nil, err
+}
+
+func transactionCommandRetryDelay(attempts int) time.Duration {
+	retryDelay := transactionOutboxRetryInterval
+	for i := 1; i < attempts; i++ {
+		retryDelay *= 2
+		if retryDelay >= transactionOutboxMaxRetryDelay {
+			return transactionOutboxMaxRetryDelay
+		}
+	}
+
+	return retryDelay
+}
+
+func requestTransactionCommand(transactionCommand model.TransactionCommand) ([]byte, int, error) {
+	requestURL := fmt.Sprintf(
+		"http://%s:%d/transactions",
+		config.Env.AccountApi.Host, config.Env.AccountApi.Port,
+	)
+
+	if transactionCommand.GroupID.Valid {
+		requestURL = fmt.Sprintf(
+			"http://%s:%d/groups/%d/transactions",
+			config.Env.AccountApi.Host, config.Env.AccountApi.Port, transactionCommand.GroupID.Int64,
+		)
+	}
+
+	method := "POST"
+	switch transactionCommand.Command {
+	case "update":
+		method = "PUT"
+		requestURL = fmt.Sprintf("%s/%d", requestURL, transactionCommand.TransactionID.Int64)
+	case "delete":
+		method = "DELETE"
+		requestURL = fmt.Sprintf("%s/%d", requestURL, transactionCommand.TransactionID.Int64)
+	}
+
+	request, err := http.NewRequest(
+		method,
+		requestURL,
+		bytes.NewBufferString(transactionCommand.Payload.String),
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// The transaction command is delivered on behalf of the user who issued it, since the user's session may have expired by then.
+	request.Header.Set("Authorization", "Bearer "+config.Env.ServiceAuth.Token)
+	request.Header.Set("X-User-ID", transactionCommand.UserID)
+	request.Header.Set("Content-Type", "application/json; charset=UTF-8")
+
+	if transactionCommand.Command == "create" {
+		request.Header.Set("Idempotency-Key", transactionCommand.IdempotencyKey)
+	}
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: (&net.Dialer{
+				Timeout:   30 * time.Second,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			MaxIdleConns:          500,
+			MaxIdleConnsPerHost:   100,
+			IdleConnTimeout:       90 * time.Second,
+			ResponseHeaderTimeout: 10 * time.Second,
+			ExpectContinueTimeout: 1 * time.Second,
+		},
+		Timeout: 60 * time.Second,
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer func() {
+		_, _ = io.Copy(ioutil.Discard, response.Body)
+		response.Body.Close()
+	}()
+
+	responseBody, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, response.StatusCode, err
+	}
+
+	return responseBody, response.StatusCode, nil
+}
+
+func newTransactionCommand(command string, shoppingItemID int, userID string, now time.Time) *model.TransactionCommand {
+	return &model.TransactionCommand{
+		Command:         command,
+		UserID:          userID,
+		ShoppingItemID:  shoppingItemID,
+		IdempotencyKey:  fmt.Sprintf("shopping-list-%d", shoppingItemID),
+		Status:          "pending",
+		NextAttemptDate: now.Truncate(time.Second),
+	}
+}
+
+func newGroupTransactionCommand(command string, groupShoppingItemID int, groupID int, userID string, now time.Time) *model.TransactionCommand {
+	transactionCommand := newTransactionCommand(command, groupShoppingItemID, userID, now)
+	transactionCommand.GroupID = model.NullInt64{NullInt64: sql.NullInt64{Int64: int64(groupID), Valid: true}}
+	transactionCommand.IdempotencyKey = fmt.Sprintf("group-shopping-list-%d", groupShoppingItemID)
+
+	return transactionCommand
+}
+
+// generateTransactionCommand issues the transaction command which brings the related transaction in line with the shopping item,
+// and returns nil when the related transaction is already in line with it.
+func generateTransactionCommand(shoppingItem model.ShoppingItem, dbShoppingItem model.ShoppingItem, userID string, now time.Time) (*model.TransactionCommand, error) {
+	payload, err := json.Marshal(generateRelatedTransaction(shoppingItem))
+	if err != nil {
+		return nil, err
+	}
+
+	var transactionCommand *model.TransactionCommand
+	switch {
+	case bool(shoppingItem.CompleteFlag) && bool(shoppingItem.TransactionAutoAdd) && shoppingItem.RelatedTransactionData == nil:
+		transactionCommand = newTransactionCommand("create", shoppingItem.ID, userID, now)
+	case !bool(shoppingItem.CompleteFlag) && shoppingItem.RelatedTransactionData != nil:
+		transactionCommand = newTransactionCommand("delete", shoppingItem.ID, userID, now)
+		transactionCommand.TransactionID = shoppingItem.RelatedTransactionData.ID
+
+		return transactionCommand, nil
+	case bool(shoppingItem.CompleteFlag) && bool(shoppingItem.TransactionAutoAdd) && shoppingItem.RelatedTransactionData != nil:
+		dbPayload, err := json.Marshal(generateRelatedTransaction(dbShoppingItem))
+		if err != nil {
+			return nil, err
+		}
+
+		if bytes.Equal(payload, dbPayload) {
+			return nil, nil
+		}
+
+		transactionCommand = newTransactionCommand("update", shoppingItem.ID, userID, now)
+		transactionCommand.TransactionID = shoppingItem.RelatedTransactionData.ID
+	default:
+		return nil, nil
+	}
+
+	transactionCommand.Payload = model.NullString{NullString: sql.NullString{String: string(payload), Valid: true}}
+
+	return transactionCommand, nil
+}
+
+func generateGroupTransactionCommand(groupShoppingItem model.GroupShoppingItem, dbGroupShoppingItem model.GroupShoppingItem, groupID int, userID string, now time.Time) (*model.TransactionCommand, error) {
+	payload, err := json.Marshal(generateGroupRelatedTransaction(groupShoppingItem))
+	if err != nil {
+		return nil, err
+	}
+
+	var transactionCommand *model.TransactionCommand
+	switch {
+	case bool(groupShoppingItem.CompleteFlag) && bool(groupShoppingItem.TransactionAutoAdd) && groupShoppingItem.RelatedTransactionData == nil:
+		transactionCommand = newGroupTransactionCommand("create", groupShoppingItem.ID, groupID, userID, now)
+	case !bool(groupShoppingItem.CompleteFlag) && groupShoppingItem.RelatedTransactionData != nil:
+		transactionCommand = newGroupTransactionCommand("delete", groupShoppingItem.ID, groupID, userID, now)
+		transactionCommand.TransactionID = groupShoppingItem.RelatedTransactionData.ID
+
+		return transactionCommand, nil
+	case bool(groupShoppingItem.CompleteFlag) && bool(groupShoppingItem.TransactionAutoAdd) && groupShoppingItem.RelatedTransactionData != nil:
+		dbPayload, err := json.Marshal(generateGroupRelatedTransaction(dbGroupShoppingItem))
+		if err != nil {
+			return nil, err
+		}
+
+		if bytes.Equal(payload, dbPayload) {
+			return nil, nil
+		}
+
+		transactionCommand = newGroupTransactionCommand("update", groupShoppingItem.ID, groupID, userID, now)
+		transactionCommand.TransactionID = groupShoppingItem.RelatedTransactionData.ID
+	default:
+		return nil, nil
+	}
+
+	transactionCommand.Payload = model.NullString{NullString: sql.NullString{String: string(payload), Valid: true}}
+
+	return transactionCommand, nil
+}
+
+// repairFailedTransactionCommand puts the failed transaction command back to pending when it is still needed, and cancels it otherwise.
+// A failed create command is always cancelled, since the missing transaction is detected from the shopping item itself.
+func repairFailedTransactionCommand(transactionCommand model.TransactionCommand, linkedTransactionID model.NullInt64, payload model.NullString, now time.Time) model.TransactionCommand {
+	linked := linkedTransactionID.Valid && linkedTransactionID.Int64 == transactionCommand.TransactionID.Int64
+
+	switch {
+	case transactionCommand.Command == "delete" && !linked, transactionCommand.Command == "update" && linked:
+		if transactionCommand.Command == "update" {
+			transactionCommand.Payload = payload
+		}
+
+		transactionCommand.Status = "pending"
+		transactionCommand.Attempts = 0
+		transactionCommand.NextAttemptDate = now.Truncate(time.Second)
+		transactionCommand.LastError = model.NullString{}
+	default:
+		transactionCommand.Status = "cancelled"
+	}
+
+	return transactionCommand
+}
+
+func reconcileShoppingListTransactions(h *DBHandler, userID string, repair bool) (model.TransactionReconciliation, error) {
+	transactionReconciliation := model.TransactionReconciliation{
+		TransactionMismatchList: make([]model.TransactionMismatch, 0),
+	}
+
+	shoppingList, err := h.ShoppingListRepo.GetTransactionRelatedShoppingList(userID)
+	if err != nil {
+		return transactionReconciliation, err
+	}
+
+	transactionCommandList, err := h.TransactionOutboxRepo.GetUndeliveredTransactionCommandList(userID)
+	if err != nil {
+		return transactionReconciliation, err
+	}
+
+	// The shopping item whose transaction command is pending is in the middle of the sync, and is not reconciled.
+	pendingShoppingItemIDs := make(map[int]bool)
+	for _, transactionCommand := range transactionCommandList {
+		if transactionCommand.Status == "pending" {
+			pendingShoppingItemIDs[transactionCommand.ShoppingItemID] = true
+		}
+	}
+
+	var transactionIdList []int64
+	for _, shoppingItem := range shoppingList.ShoppingList {
+		if shoppingItem.RelatedTransactionData != nil {
+			transactionIdList = append(transactionIdList, shoppingItem.RelatedTransactionData.ID.Int64)
+		}
+	}
+
+	existingTransactionIDs := make(map[int64]bool)
+	if len(transactionIdList) != 0 {
+		shoppingItemRelatedTransactionDataList, err := getShoppingItemRelatedTransactionDataList(transactionIdList)
+		if err != nil {
+			return transactionReconciliation, err
+		}
+
+		for _, shoppingItemRelatedTransactionData := range shoppingItemRelatedTransactionDataList {
+			existingTransactionIDs[shoppingItemRelatedTransactionData.ID.Int64] = true
+		}
+	}
+
+	now := h.TimeManage.Now()
+	shoppingItemsByID := make(map[int]model.ShoppingItem)
+	for _, shoppingItem := range shoppingList.ShoppingList {
+		shoppingItemsByID[shoppingItem.ID] = shoppingItem
+
+		if pendingShoppingItemIDs[shoppingItem.ID] {
+			continue
+		}
+
+		transactionMismatch := model.TransactionMismatch{
+			ShoppingItemID: shoppingItem.ID,
+			Repaired:       repair,
+		}
+
+		var transactionCommand *model.TransactionCommand
+		switch {
+		case shoppingItem.RelatedTransactionData != nil && !existingTransactionIDs[shoppingItem.RelatedTransactionData.ID.Int64]:
+			transactionMismatch.Reason = "lost_transaction"
+			transactionMismatch.TransactionID = shoppingItem.RelatedTransactionData.ID
+			shoppingItem.RelatedTransactionData = nil
+
+			if shoppingItem.CompleteFlag && shoppingItem.TransactionAutoAdd {
+				transactionCommand, err = generateTransactionCommand(shoppingItem, shoppingItem, userID, now)
+				if err != nil {
+					return transactionReconciliation, err
+				}
+			}
+		case !bool(shoppingItem.CompleteFlag) && shoppingItem.RelatedTransactionData != nil:
+			transactionMismatch.Reason = "unexpected_transaction"
+			transactionMismatch.TransactionID = shoppingItem.RelatedTransactionData.ID
+
+			transactionCommand, err = generateTransactionCommand(shoppingItem, shoppingItem, userID, now)
+			if err != nil {
+				return transactionReconciliation, err
+			}
+
+			shoppingItem.RelatedTransactionData = nil
+		case bool(shoppingItem.CompleteFlag) && bool(shoppingItem.TransactionAutoAdd) && shoppingItem.RelatedTransactionData == nil:
+			transactionMismatch.Reason = "missing_transaction"
+
+			transactionCommand, err = generateTransactionCommand(shoppingItem, shoppingItem, userID, now)
+			if err != nil {
+				return transactionReconciliation, err
+			}
+		default:
+			continue
+		}
+
+		transactionReconciliation.TransactionMismatchList = append(transactionReconciliation.TransactionMismatchList, transactionMismatch)
+
+		if !repair {
+			continue
+		}
+
+		switch {
+		case transactionMismatch.Reason == "missing_transaction":
+			if _, err := h.TransactionOutboxRepo.PostTransactionCommand(transactionCommand); err != nil {
+				return transactionReconciliation, err
+			}
+		case transactionCommand != nil:
+			if _, err := h.ShoppingListRepo.PutShoppingItemWithTransactionCommand(&shoppingItem, transactionCommand); err != nil {
+				return transactionReconciliation, err
+			}
+		default:
+			if _, err := h.ShoppingListRepo.PutShoppingItem(&shoppingItem); err != nil {
+				return transactionReconciliation, err
+			}
+		}
+	}
+
+	for _, transactionCommand := range transactionCommandList {
+		if transactionCommand.Status != "failed" || pendingShoppingItemIDs[transactionCommand.ShoppingItemID] {
+			continue
+		}
+
+		transactionReconciliation.TransactionMismatchList = append(transactionReconciliation.TransactionMismatchList, model.TransactionMismatch{
+			ShoppingItemID: transactionCommand.ShoppingItemID,
+			TransactionID:  transactionCommand.TransactionID,
+			Reason:         "failed_delivery",
+			Repaired:       repair,
+		})
+
+		if !repair {
+			continue
+		}
+
+		var linkedTransactionID model.NullInt64
+		var payload model.NullString
+		if shoppingItem, ok := shoppingItemsByID[transactionCommand.ShoppingItemID]; ok {
+			if shoppingItem.RelatedTransactionData != nil {
+				linkedTransactionID = shoppingItem.RelatedTransactionData.ID
+			}
+
+			relatedTransaction, err := json.Marshal(generateRelatedTransaction(shoppingItem))
+			if err != nil {
+				return transactionReconciliation, err
+			}
+
+			payload = model.NullString{NullString: sql.NullString{String: string(relatedTransaction), Valid: true}}
+		}
+
+		repairedTransactionCommand := repairFailedTransactionCommand(transactionCommand, linkedTransactionID, payload, now)
+		if err := h.TransactionOutboxRepo.PutTransactionCommand(&repairedTransactionCommand); err != nil {
+			return transactionReconciliation, err
+		}
+	}
+
+	return transactionReconciliation, nil
+}
+
+func reconcileGroupShoppingListTransactions(h *DBHandler, groupID int, userID string, repair bool) (model.TransactionReconciliation, error) {
+	transactionReconciliation := model.TransactionReconciliation{
+		TransactionMismatchList: make([]model.TransactionMismatch, 0),
+	}
+
+	groupShoppingList, err := h.GroupShoppingListRepo.GetTransactionRelatedGroupShoppingList(groupID)
+	if err != nil {
+		return transactionReconciliation, err
+	}
+
+	transactionCommandList, err := h.TransactionOutboxRepo.GetUndeliveredGroupTransactionCommandList(groupID)
+	if err != nil {
+		return transactionReconciliation, err
+	}
+
+	// The shopping item whose transaction command is pending is in the middle of the sync, and is not reconciled.
+	pendingShoppingItemIDs := make(map[int]bool)
+	for _, transactionCommand := range transactionCommandList {
+		if transactionCommand.Status == "pending" {
+			pendingShoppingItemIDs[transactionCommand.ShoppingItemID] = true
+		}
+	}
+
+	var transactionIdList []int64
+	for _, groupShoppingItem := range groupShoppingList.GroupShoppingList {
+		if groupShoppingItem.RelatedTransactionData != nil {
+			transactionIdList = append(transactionIdList, groupShoppingItem.RelatedTransactionData.ID.Int64)
+		}
+	}
+
+	existingTransactionIDs := make(map[int64]bool)
+	if len(transactionIdList) != 0 {
+		groupShoppingItemRelatedTransactionDataList, err := getGroupShoppingItemRelatedTransactionDataList(transactionIdList, groupID)
+		if err != nil {
+			return transactionReconciliation, err
+		}
+
+		for _, groupShoppingItemRelatedTransactionData := range groupShoppingItemRelatedTransactionDataList {
+			existingTransactionIDs[groupShoppingItemRelatedTransactionData.ID.Int64] = true
+		}
+	}
+
+	now := h.TimeManage.Now()
+	groupShoppingItemsByID := make(map[int]model.GroupShoppingItem)
+	for _, groupShoppingItem := range groupShoppingList.GroupShoppingList {
+		groupShoppingItemsByID[groupShoppingItem.ID] = groupShoppingItem
+
+		if pendingShoppingItemIDs[groupShoppingItem.ID] {
+			continue
+		}
+
+		transactionMismatch := model.TransactionMismatch{
+			ShoppingItemID: groupShoppingItem.ID,
+			Repaired:       repair,
+		}
+
+		var transactionCommand *model.TransactionCommand
+		switch {
+		case groupShoppingItem.RelatedTransactionData != nil && !existingTransactionIDs[groupShoppingItem.RelatedTransactionData.ID.Int64]:
+			transactionMismatch.Reason = "lost_transaction"
+			transactionMismatch.TransactionID = groupShoppingItem.RelatedTransactionData.ID
+			groupShoppingItem.RelatedTransactionData = nil
+
+			if groupShoppingItem.CompleteFlag && groupShoppingItem.TransactionAutoAdd {
+				transactionCommand, err = generateGroupTransactionCommand(groupShoppingItem, groupShoppingItem, groupID, userID, now)
+				if err != nil {
+					return transactionReconciliation, err
+				}
+			}
+		case !bool(groupShoppingItem.CompleteFlag) && groupShoppingItem.RelatedTransactionData != nil:
+			transactionMismatch.Reason = "unexpected_transaction"
+			transactionMismatch.TransactionID = groupShoppingItem.RelatedTransactionData.ID
+
+			transactionCommand, err = generateGroupTransactionCommand(groupShoppingItem, groupShoppingItem, groupID, userID, now)
+			if err != nil {
+				return transactionReconciliation, err
+			}
+
+			groupShoppingItem.RelatedTransactionData = nil
+		case bool(groupShoppingItem.CompleteFlag) && bool(groupShoppingItem.TransactionAutoAdd) && groupShoppingItem.RelatedTransactionData == nil:
+			transactionMismatch.Reason = "missing_transaction"
+
+			transactionCommand, err = generateGroupTransactionCommand(groupShoppingItem, groupShoppingItem, groupID, userID, now)
+			if err != nil {
+				return transactionReconciliation, err
+			}
+		default:
+			continue
+		}
+
+		transactionReconciliation.TransactionMismatchList = append(transactionReconciliation.TransactionMismatchList, transactionMismatch)
+
+		if !repair {
+			continue
+		}
+
+		switch {
+		case transactionMismatch.Reason == "missing_transaction":
+			if _, err := h.TransactionOutboxRepo.PostTransactionCommand(transactionCommand); err != nil {
+				return transactionReconciliation, err
+			}
+		case transactionCommand != nil:
+			if _, err := h.GroupShoppingListRepo.PutGroupShoppingItemWithTransactionCommand(&groupShoppingItem, transactionCommand); err != nil {
+				return transactionReconciliation, err
+			}
+		default:
+			if _, err := h.GroupShoppingListRepo.PutGroupShoppingItem(&groupShoppingItem); err != nil {
+				return transactionReconciliation, err
+			}
+		}
+	}
+
+	for _, transactionCommand := range transactionCommandList {
+		if transactionCommand.Status != "failed" || pendingShoppingItemIDs[transactionCommand.ShoppingItemID] {
+			continue
+		}
+
+		transactionReconciliation.TransactionMismatchList = append(transactionReconciliation.TransactionMismatchList, model.TransactionMismatch{
+			ShoppingItemID: transactionCommand.ShoppingItemID,
+			TransactionID:  transactionCommand.TransactionID,
+			Reason:         "failed_delivery",
+			Repaired:       repair,
+		})
+
+		if !repair {
+			continue
+		}
+
+		var linkedTransactionID model.NullInt64
+		var payload model.NullString
+		if groupShoppingItem, ok := groupShoppingItemsByID[transactionCommand.ShoppingItemID]; ok {
+			if groupShoppingItem.RelatedTransactionData != nil {
+				linkedTransactionID = groupShoppingItem.RelatedTransactionData.ID
+			}
+
+			groupRelatedTransaction, err := json.Marshal(generateGroupRelatedTransaction(groupShoppingItem))
+			if err != nil {
+				return transactionReconciliation, err
+			}
+
+			payload = model.NullString{NullString: sql.NullString{String: string(groupRelatedTransaction), Valid: true}}
+		}
+
+		repairedTransactionCommand := repairFailedTransactionCommand(transactionCommand, linkedTransactionID, payload, now)
+		if err := h.TransactionOutboxRepo.PutTransactionCommand(&repairedTransactionCommand); err != nil {
+			return transactionReconciliation, err
+		}
+	}
+
+	return transactionReconciliation, nil
+}
+
+func (h *DBHandler) GetShoppingListTransactionReconciliation(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	transactionReconciliation, err := reconcileShoppingListTransactions(h, userID, false)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&transactionReconciliation); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) PostShoppingListTransactionReconciliation(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	transactionReconciliation, err := reconcileShoppingListTransactions(h, userID, true)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&transactionReconciliation); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) GetGroupShoppingListTransactionReconciliation(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		return
+	}
+
+	if err := verifyGroupAffiliation(groupID, userID); err != nil {
+		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
+		if !ok {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		return
+	}
+
+	transactionReconciliation, err := reconcileGroupShoppingListTransactions(h, groupID, userID, false)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&transactionReconciliation); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *DBHandler) PostGroupShoppingListTransactionReconciliation(w http.ResponseWriter, r *http.Request) {
+	userID, err := verifySessionID(h, w, r)
+	if err != nil {
+		if err == http.ErrNoCookie || err == redis.ErrNil {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusUnauthorized, &AuthenticationErrorMsg{"このページを表示するにはログインが必要です。"}))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	groupID, err := strconv.Atoi(mux.Vars(r)["group_id"])
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, &BadRequestErrorMsg{"group ID を正しく指定してください。"}))
+		return
+	}
+
+	if err := verifyGroupAffiliation(groupID, userID); err != nil {
+		badRequestErrorMsg, ok := err.(*BadRequestErrorMsg)
+		if !ok {
+			errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+			return
+		}
+
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusBadRequest, badRequestErrorMsg))
+		return
+	}
+
+	transactionReconciliation, err := reconcileGroupShoppingListTransactions(h, groupID, userID, true)
+	if err != nil {
+		errorResponseByJSON(w, r, NewHTTPError(http.StatusInternalServerError, nil))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(&transactionReconciliation); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,924 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/hryze/kakeibo-app-api/todo-rest-service/config"
+	"github.com/hryze/kakeibo-app-api/todo-rest-service/domain/model"
+	"github.com/hryze/kakeibo-app-api/todo-rest-service/testutil"
+)
+
+type MockTransactionOutboxRepository struct{}
+
+func (m MockTransactionOutboxRepository) PostTransactionCommand(transactionCommand *model.TransactionCommand) (sql.Result, error) {
+	return MockSqlResult{}, nil
+}
+
+func (m MockTransactionOutboxRepository) GetPendingTransactionCommandList(now time.Time, limit int) ([]model.TransactionCommand, error) {
+	return nil, nil
+}
+
+func (m MockTransactionOutboxRepository) GetUndeliveredTransactionCommandList(userID string) ([]model.TransactionCommand, error) {
+	return []model.TransactionCommand{
+		{
+			ID:              1,
+			Command:         "create",
+			UserID:          "userID1",
+			GroupID:         model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
+			ShoppingItemID:  5,
+			TransactionID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
+			IdempotencyKey:  "shopping-list-5",
+			Payload:         model.NullString{NullString: sql.NullString{String: "{}", Valid: true}},
+			Status:          "pending",
+			Attempts:        1,
+			NextAttemptDate: time.Date(2020, 9, 6, 0, 0, 10, 0, time.UTC),
+			LastError:       model.NullString{NullString: sql.NullString{String: "status code 503", Valid: true}},
+		},
+		{
+			ID:              2,
+			Command:         "delete",
+			UserID:          "userID1",
+			GroupID:         model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
+			ShoppingItemID:  6,
+			TransactionID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 6, Valid: true}},
+			IdempotencyKey:  "shopping-list-6",
+			Payload:         model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+			Status:          "failed",
+			Attempts:        1,
+			NextAttemptDate: time.Date(2020, 9, 5, 0, 0, 0, 0, time.UTC),
+			LastError:       model.NullString{NullString: sql.NullString{String: "status code 401", Valid: true}},
+		},
+	}, nil
+}
+
+func (m MockTransactionOutboxRepository) GetUndeliveredGroupTransactionCommandList(groupID int) ([]model.TransactionCommand, error) {
+	return []model.TransactionCommand{
+		{
+			ID:              1,
+			Command:         "create",
+			UserID:          "userID1",
+			GroupID:         model.NullInt64{NullInt64: sql.NullInt64{Int64: 1, Valid: true}},
+			ShoppingItemID:  5,
+			TransactionID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
+			IdempotencyKey:  "group-shopping-list-5",
+			Payload:         model.NullString{NullString: sql.NullString{String: "{}", Valid: true}},
+			Status:          "pending",
+			Attempts:        1,
+			NextAttemptDate: time.Date(2020, 9, 6, 0, 0, 10, 0, time.UTC),
+			LastError:       model.NullString{NullString: sql.NullString{String: "status code 503", Valid: true}},
+		},
+		{
+			ID:              2,
+			Command:         "delete",
+			UserID:          "userID1",
+			GroupID:         model.NullInt64{NullInt64: sql.NullInt64{Int64: 1, Valid: true}},
+			ShoppingItemID:  6,
+			TransactionID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 6, Valid: true}},
+			IdempotencyKey:  "group-shopping-list-6",
+			Payload:         model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+			Status:          "failed",
+			Attempts:        1,
+			NextAttemptDate: time.Date(2020, 9, 5, 0, 0, 0, 0, time.UTC),
+			LastError:       model.NullString{NullString: sql.NullString{String: "status code 401", Valid: true}},
+		},
+	}, nil
+}
+
+func (m MockTransactionOutboxRepository) LeaseTransactionCommand(transactionCommand *model.TransactionCommand, leaseDate time.Time) (bool, error) {
+	return true, nil
+}
+
+func (m MockTransactionOutboxRepository) DeliverTransactionCommand(transactionCommand *model.TransactionCommand) error {
+	return nil
+}
+
+func (m MockTransactionOutboxRepository) PutTransactionCommand(transactionCommand *model.TransactionCommand) error {
+	return nil
+}
+
+func TestDBHandler_GetShoppingListTransactionReconciliation(t *testing.T) {
+	accountHostURL := fmt.Sprintf("%s:%d", config.Env.AccountApi.Host, config.Env.AccountApi.Port)
+
+	mockGetShoppingItemRelatedTransactionDataList := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		shoppingItemRelatedTransactionDataList := []*model.TransactionData{
+			{
+				ID:                 model.NullInt64{NullInt64: sql.NullInt64{Int64: 1, Valid: true}},
+				TransactionType:    "expense",
+				PostedDate:         time.Date(2020, 12, 15, 16, 0, 0, 0, time.UTC),
+				UpdatedDate:        time.Date(2020, 12, 15, 16, 0, 0, 0, time.UTC),
+				TransactionDate:    "2020/12/15(火)",
+				Shop:               model.NullString{NullString: sql.NullString{String: "コストコ", Valid: true}},
+				Memo:               model.NullString{NullString: sql.NullString{String: "【買い物リスト】鶏肉3kg", Valid: true}},
+				Amount:             1000,
+				BigCategoryID:      2,
+				BigCategoryName:    "食費",
+				MediumCategoryID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 6, Valid: true}},
+				MediumCategoryName: model.NullString{NullString: sql.NullString{String: "食料品", Valid: true}},
+				CustomCategoryID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
+				CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+			},
+			{
+				ID:                 model.NullInt64{NullInt64: sql.NullInt64{Int64: 3, Valid: true}},
+				TransactionType:    "expense",
+				PostedDate:         time.Date(2020, 12, 15, 16, 0, 0, 0, time.UTC),
+				UpdatedDate:        time.Date(2020, 12, 15, 16, 0, 0, 0, time.UTC),
+				TransactionDate:    "2020/12/15(火)",
+				Shop:               model.NullString{NullString: sql.NullString{String: "コストコ", Valid: true}},
+				Memo:               model.NullString{NullString: sql.NullString{String: "【買い物リスト】鶏肉3kg", Valid: true}},
+				Amount:             1000,
+				BigCategoryID:      2,
+				BigCategoryName:    "食費",
+				MediumCategoryID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 6, Valid: true}},
+				MediumCategoryName: model.NullString{NullString: sql.NullString{String: "食料品", Valid: true}},
+				CustomCategoryID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
+				CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+			},
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(&shoppingItemRelatedTransactionDataList); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/transactions/related-shopping-list", mockGetShoppingItemRelatedTransactionDataList).Methods("GET")
+
+	listener, err := net.Listen("tcp", accountHostURL)
+	if err != nil {
+		t.Fatalf("unexpected error by net.Listen() '%#v'", err)
+	}
+
+	ts := httptest.Server{
+		Listener: listener,
+		Config:   &http.Server{Handler: router},
+	}
+
+	ts.Start()
+	defer ts.Close()
+
+	h := DBHandler{
+		AuthRepo:              MockAuthRepository{},
+		ShoppingListRepo:      MockShoppingListRepository{},
+		TransactionOutboxRepo: MockTransactionOutboxRepository{},
+		TimeManage:            MockTime{},
+	}
+
+	r := httptest.NewRequest("GET", "/shopping-list/transactions/reconciliation", nil)
+	w := httptest.NewRecorder()
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.GetShoppingListTransactionReconciliation(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &model.TransactionReconciliation{}, &model.TransactionReconciliation{})
+}
+
+func TestDBHandler_PostShoppingListTransactionReconciliation(t *testing.T) {
+	accountHostURL := fmt.Sprintf("%s:%d", config.Env.AccountApi.Host, config.Env.AccountApi.Port)
+
+	mockGetShoppingItemRelatedTransactionDataList := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		shoppingItemRelatedTransactionDataList := []*model.TransactionData{
+			{
+				ID:                 model.NullInt64{NullInt64: sql.NullInt64{Int64: 1, Valid: true}},
+				TransactionType:    "expense",
+				PostedDate:         time.Date(2020, 12, 15, 16, 0, 0, 0, time.UTC),
+				UpdatedDate:        time.Date(2020, 12, 15, 16, 0, 0, 0, time.UTC),
+				TransactionDate:    "2020/12/15(火)",
+				Shop:               model.NullString{NullString: sql.NullString{String: "コストコ", Valid: true}},
+				Memo:               model.NullString{NullString: sql.NullString{String: "【買い物リスト】鶏肉3kg", Valid: true}},
+				Amount:             1000,
+				BigCategoryID:      2,
+				BigCategoryName:    "食費",
+				MediumCategoryID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 6, Valid: true}},
+				MediumCategoryName: model.NullString{NullString: sql.NullString{String: "食料品", Valid: true}},
+				CustomCategoryID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
+				CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+			},
+			{
+				ID:                 model.NullInt64{NullInt64: sql.NullInt64{Int64: 3, Valid: true}},
+				TransactionType:    "expense",
+				PostedDate:         time.Date(2020, 12, 15, 16, 0, 0, 0, time.UTC),
+				UpdatedDate:        time.Date(2020, 12, 15, 16, 0, 0, 0, time.UTC),
+				TransactionDate:    "2020/12/15(火)",
+				Shop:               model.NullString{NullString: sql.NullString{String: "コストコ", Valid: true}},
+				Memo:               model.NullString{NullString: sql.NullString{String: "【買い物リスト】鶏肉3kg", Valid: true}},
+				Amount:             1000,
+				BigCategoryID:      2,
+				BigCategoryName:    "食費",
+				MediumCategoryID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 6, Valid: true}},
+				MediumCategoryName: model.NullString{NullString: sql.NullString{String: "食料品", Valid: true}},
+				CustomCategoryID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
+				CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+			},
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(&shoppingItemRelatedTransactionDataList); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/transactions/related-shopping-list", mockGetShoppingItemRelatedTransactionDataList).Methods("GET")
+
+	listener, err := net.Listen("tcp", accountHostURL)
+	if err != nil {
+		t.Fatalf("unexpected error by net.Listen() '%#v'", err)
+	}
+
+	ts := httptest.Server{
+		Listener: listener,
+		Config:   &http.Server{Handler: router},
+	}
+
+	ts.Start()
+	defer ts.Close()
+
+	h := DBHandler{
+		AuthRepo:              MockAuthRepository{},
+		ShoppingListRepo:      MockShoppingListRepository{},
+		TransactionOutboxRepo: MockTransactionOutboxRepository{},
+		TimeManage:            MockTime{},
+	}
+
+	r := httptest.NewRequest("POST", "/shopping-list/transactions/reconciliation", nil)
+	w := httptest.NewRecorder()
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.PostShoppingListTransactionReconciliation(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &model.TransactionReconciliation{}, &model.TransactionReconciliation{})
+}
+
+func TestDBHandler_GetGroupShoppingListTransactionReconciliation(t *testing.T) {
+	accountHostURL := fmt.Sprintf("%s:%d", config.Env.AccountApi.Host, config.Env.AccountApi.Port)
+
+	mockGetGroupShoppingItemRelatedTransactionDataList := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		groupShoppingItemRelatedTransactionDataList := []*model.GroupTransactionData{
+			{
+				ID:                 model.NullInt64{NullInt64: sql.NullInt64{Int64: 1, Valid: true}},
+				TransactionType:    "expense",
+				PostedDate:         time.Date(2020, 12, 15, 16, 0, 0, 0, time.UTC),
+				UpdatedDate:        time.Date(2020, 12, 15, 16, 0, 0, 0, time.UTC),
+				TransactionDate:    "2020/12/15(火)",
+				Shop:               model.NullString{NullString: sql.NullString{String: "コストコ", Valid: true}},
+				Memo:               model.NullString{NullString: sql.NullString{String: "【買い物リスト】鶏肉3kg", Valid: true}},
+				Amount:             1000,
+				PostedUserID:       "userID1",
+				UpdatedUserID:      model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+				PaymentUserID:      "userID1",
+				BigCategoryID:      2,
+				BigCategoryName:    "食費",
+				MediumCategoryID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 6, Valid: true}},
+				MediumCategoryName: model.NullString{NullString: sql.NullString{String: "食料品", Valid: true}},
+				CustomCategoryID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
+				CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+			},
+			{
+				ID:                 model.NullInt64{NullInt64: sql.NullInt64{Int64: 3, Valid: true}},
+				TransactionType:    "expense",
+				PostedDate:         time.Date(2020, 12, 15, 16, 0, 0, 0, time.UTC),
+				UpdatedDate:        time.Date(2020, 12, 15, 16, 0, 0, 0, time.UTC),
+				TransactionDate:    "2020/12/15(火)",
+				Shop:               model.NullString{NullString: sql.NullString{String: "コストコ", Valid: true}},
+				Memo:               model.NullString{NullString: sql.NullString{String: "【買い物リスト】鶏肉3kg", Valid: true}},
+				Amount:             1000,
+				PostedUserID:       "userID1",
+				UpdatedUserID:      model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+				PaymentUserID:      "userID1",
+				BigCategoryID:      2,
+				BigCategoryName:    "食費",
+				MediumCategoryID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 6, Valid: true}},
+				MediumCategoryName: model.NullString{NullString: sql.NullString{String: "食料品", Valid: true}},
+				CustomCategoryID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
+				CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+			},
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(&groupShoppingItemRelatedTransactionDataList); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/groups/{group_id:[0-9]+}/transactions/related-shopping-list", mockGetGroupShoppingItemRelatedTransactionDataList).Methods("GET")
+
+	listener, err := net.Listen("tcp", accountHostURL)
+	if err != nil {
+		t.Fatalf("unexpected error by net.Listen() '%#v'", err)
+	}
+
+	ts := httptest.Server{
+		Listener: listener,
+		Config:   &http.Server{Handler: router},
+	}
+
+	ts.Start()
+	defer ts.Close()
+
+	h := DBHandler{
+		AuthRepo:              MockAuthRepository{},
+		GroupShoppingListRepo: MockGroupShoppingListRepository{},
+		TransactionOutboxRepo: MockTransactionOutboxRepository{},
+		TimeManage:            MockTime{},
+	}
+
+	r := httptest.NewRequest("GET", "/groups/1/shopping-list/transactions/reconciliation", nil)
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"group_id": "1",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.GetGroupShoppingListTransactionReconciliation(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &model.TransactionReconciliation{}, &model.TransactionReconciliation{})
+}
+
+func TestDBHandler_PostGroupShoppingListTransactionReconciliation(t *testing.T) {
+	accountHostURL := fmt.Sprintf("%s:%d", config.Env.AccountApi.Host, config.Env.AccountApi.Port)
+
+	mockGetGroupShoppingItemRelatedTransactionDataList := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		groupShoppingItemRelatedTransactionDataList := []*model.GroupTransactionData{
+			{
+				ID:                 model.NullInt64{NullInt64: sql.NullInt64{Int64: 1, Valid: true}},
+				TransactionType:    "expense",
+				PostedDate:         time.Date(2020, 12, 15, 16, 0, 0, 0, time.UTC),
+				UpdatedDate:        time.Date(2020, 12, 15, 16, 0, 0, 0, time.UTC),
+				TransactionDate:    "2020/12/15(火)",
+				Shop:               model.NullString{NullString: sql.NullString{String: "コストコ", Valid: true}},
+				Memo:               model.NullString{NullString: sql.NullString{String: "【買い物リスト】鶏肉3kg", Valid: true}},
+				Amount:             1000,
+				PostedUserID:       "userID1",
+				UpdatedUserID:      model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+				PaymentUserID:      "userID1",
+				BigCategoryID:      2,
+				BigCategoryName:    "食費",
+				MediumCategoryID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 6, Valid: true}},
+				MediumCategoryName: model.NullString{NullString: sql.NullString{String: "食料品", Valid: true}},
+				CustomCategoryID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
+				CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+			},
+			{
+				ID:                 model.NullInt64{NullInt64: sql.NullInt64{Int64: 3, Valid: true}},
+				TransactionType:    "expense",
+				PostedDate:         time.Date(2020, 12, 15, 16, 0, 0, 0, time.UTC),
+				UpdatedDate:        time.Date(2020, 12, 15, 16, 0, 0, 0, time.UTC),
+				TransactionDate:    "2020/12/15(火)",
+				Shop:               model.NullString{NullString: sql.NullString{String: "コストコ", Valid: true}},
+				Memo:               model.NullString{NullString: sql.NullString{String: "【買い物リスト】鶏肉3kg", Valid: true}},
+				Amount:             1000,
+				PostedUserID:       "userID1",
+				UpdatedUserID:      model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+				PaymentUserID:      "userID1",
+				BigCategoryID:      2,
+				BigCategoryName:    "食費",
+				MediumCategoryID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 6, Valid: true}},
+				MediumCategoryName: model.NullString{NullString: sql.NullString{String: "食料品", Valid: true}},
+				CustomCategoryID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}},
+				CustomCategoryName: model.NullString{NullString: sql.NullString{String: "", Valid: false}},
+			},
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(&groupShoppingItemRelatedTransactionDataList); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/groups/{group_id:[0-9]+}/transactions/related-shopping-list", mockGetGroupShoppingItemRelatedTransactionDataList).Methods("GET")
+
+	listener, err := net.Listen("tcp", accountHostURL)
+	if err != nil {
+		t.Fatalf("unexpected error by net.Listen() '%#v'", err)
+	}
+
+	ts := httptest.Server{
+		Listener: listener,
+		Config:   &http.Server{Handler: router},
+	}
+
+	ts.Start()
+	defer ts.Close()
+
+	h := DBHandler{
+		AuthRepo:              MockAuthRepository{},
+		GroupShoppingListRepo: MockGroupShoppingListRepository{},
+		TransactionOutboxRepo: MockTransactionOutboxRepository{},
+		TimeManage:            MockTime{},
+	}
+
+	r := httptest.NewRequest("POST", "/groups/1/shopping-list/transactions/reconciliation", nil)
+	w := httptest.NewRecorder()
+
+	r = mux.SetURLVars(r, map[string]string{
+		"group_id": "1",
+	})
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	h.PostGroupShoppingListTransactionReconciliation(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, &model.TransactionReconciliation{}, &model.TransactionReconciliation{})
+}
+
+type transactionCommandRequest struct {
+	Method         string
+	Path           string
+	Authorization  string
+	UserID         string
+	IdempotencyKey string
+	Cookie         bool
+}
+
+type MockDeliveryTransactionOutboxRepository struct {
+	MockTransactionOutboxRepository
+	leased                      bool
+	deliveredTransactionCommand *[]model.TransactionCommand
+	putTransactionCommand       *[]model.TransactionCommand
+}
+
+func (m MockDeliveryTransactionOutboxRepository) LeaseTransactionCommand(transactionCommand *model.TransactionCommand, leaseDate time.Time) (bool, error) {
+	return m.leased, nil
+}
+
+func (m MockDeliveryTransactionOutboxRepository) DeliverTransactionCommand(transactionCommand *model.TransactionCommand) error {
+	*m.deliveredTransactionCommand = append(*m.deliveredTransactionCommand, *transactionCommand)
+
+	return nil
+}
+
+func (m MockDeliveryTransactionOutboxRepository) PutTransactionCommand(transactionCommand *model.TransactionCommand) error {
+	*m.putTransactionCommand = append(*m.putTransactionCommand, *transactionCommand)
+
+	return nil
+}
+
+func startMockTransactionCommandServer(t *testing.T, handler http.HandlerFunc) *httptest.Server {
+	accountHostURL := fmt.Sprintf("%s:%d", config.Env.AccountApi.Host, config.Env.AccountApi.Port)
+
+	listener, err := net.Listen("tcp", accountHostURL)
+	if err != nil {
+		t.Fatalf("unexpected error by net.Listen() '%#v'", err)
+	}
+
+	ts := &httptest.Server{
+		Listener: listener,
+		Config:   &http.Server{Handler: handler},
+	}
+
+	ts.Start()
+
+	return ts
+}
+
+func newTransactionCommandRequest(r *http.Request) transactionCommandRequest {
+	_, err := r.Cookie(config.Env.Cookie.Name)
+
+	return transactionCommandRequest{
+		Method:         r.Method,
+		Path:           r.URL.Path,
+		Authorization:  r.Header.Get("Authorization"),
+		UserID:         r.Header.Get("X-User-ID"),
+		IdempotencyKey: r.Header.Get("Idempotency-Key"),
+		Cookie:         err == nil,
+	}
+}
+
+func TestTransactionCommandRetryDelay(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempts int
+		want     time.Duration
+	}{
+		{name: "first attempt", attempts: 1, want: 10 * time.Second},
+		{name: "second attempt", attempts: 2, want: 20 * time.Second},
+		{name: "third attempt", attempts: 3, want: 40 * time.Second},
+		{name: "ninth attempt", attempts: 9, want: 2560 * time.Second},
+		{name: "capped at the max retry delay", attempts: 10, want: time.Hour},
+		{name: "stays at the max retry delay", attempts: 30, want: time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := transactionCommandRetryDelay(tt.attempts)
+
+			if diff := cmp.Diff(tt.want, got); len(diff) != 0 {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDeliverTransactionCommand(t *testing.T) {
+	now := MockTime{}.Now()
+	authorization := "Bearer " + config.Env.ServiceAuth.Token
+
+	createCommand := model.TransactionCommand{
+		ID:              1,
+		Command:         "create",
+		UserID:          "userID1",
+		ShoppingItemID:  5,
+		IdempotencyKey:  "shopping-list-5",
+		Payload:         model.NullString{NullString: sql.NullString{String: `{"transaction_type":"expense"}`, Valid: true}},
+		Status:          "pending",
+		NextAttemptDate: now,
+	}
+
+	updateGroupCommand := model.TransactionCommand{
+		ID:              2,
+		Command:         "update",
+		UserID:          "userID2",
+		GroupID:         model.NullInt64{NullInt64: sql.NullInt64{Int64: 1, Valid: true}},
+		ShoppingItemID:  6,
+		TransactionID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 6, Valid: true}},
+		IdempotencyKey:  "group-shopping-list-6",
+		Payload:         model.NullString{NullString: sql.NullString{String: `{"transaction_type":"expense"}`, Valid: true}},
+		Status:          "pending",
+		NextAttemptDate: now,
+	}
+
+	deleteCommand := model.TransactionCommand{
+		ID:              3,
+		Command:         "delete",
+		UserID:          "userID1",
+		ShoppingItemID:  7,
+		TransactionID:   model.NullInt64{NullInt64: sql.NullInt64{Int64: 7, Valid: true}},
+		IdempotencyKey:  "shopping-list-7",
+		Status:          "pending",
+		NextAttemptDate: now,
+	}
+
+	withAttempts := func(transactionCommand model.TransactionCommand, attempts int) model.TransactionCommand {
+		transactionCommand.Attempts = attempts
+
+		return transactionCommand
+	}
+
+	tests := []struct {
+		name                            string
+		transactionCommand              model.TransactionCommand
+		leased                          bool
+		statusCode                      int
+		responseBody                    string
+		wantRequestList                 []transactionCommandRequest
+		wantDeliveredTransactionCommand []model.TransactionCommand
+		wantPutTransactionCommand       []model.TransactionCommand
+		wantErr                         bool
+	}{
+		{
+			name:               "create command is delivered with the service token and the stored user ID",
+			transactionCommand: createCommand,
+			leased:             true,
+			statusCode:         http.StatusCreated,
+			responseBody:       `{"id":10}`,
+			wantRequestList: []transactionCommandRequest{
+				{Method: "POST", Path: "/transactions", Authorization: authorization, UserID: "userID1", IdempotencyKey: "shopping-list-5"},
+			},
+			wantDeliveredTransactionCommand: []model.TransactionCommand{
+				func() model.TransactionCommand {
+					transactionCommand := withAttempts(createCommand, 1)
+					transactionCommand.TransactionID = model.NullInt64{NullInt64: sql.NullInt64{Int64: 10, Valid: true}}
+
+					return transactionCommand
+				}(),
+			},
+		},
+		{
+			name:               "group update command is delivered",
+			transactionCommand: updateGroupCommand,
+			leased:             true,
+			statusCode:         http.StatusOK,
+			responseBody:       `{"id":6}`,
+			wantRequestList: []transactionCommandRequest{
+				{Method: "PUT", Path: "/groups/1/transactions/6", Authorization: authorization, UserID: "userID2"},
+			},
+			wantDeliveredTransactionCommand: []model.TransactionCommand{withAttempts(updateGroupCommand, 1)},
+		},
+		{
+			name:               "delete command is delivered",
+			transactionCommand: deleteCommand,
+			leased:             true,
+			statusCode:         http.StatusOK,
+			responseBody:       `{"message":"取引を削除しました。"}`,
+			wantRequestList: []transactionCommandRequest{
+				{Method: "DELETE", Path: "/transactions/7", Authorization: authorization, UserID: "userID1"},
+			},
+			wantDeliveredTransactionCommand: []model.TransactionCommand{withAttempts(deleteCommand, 1)},
+		},
+		{
+			name:               "server error is retried with backoff",
+			transactionCommand: withAttempts(createCommand, 2),
+			leased:             true,
+			statusCode:         http.StatusServiceUnavailable,
+			wantRequestList: []transactionCommandRequest{
+				{Method: "POST", Path: "/transactions", Authorization: authorization, UserID: "userID1", IdempotencyKey: "shopping-list-5"},
+			},
+			wantPutTransactionCommand: []model.TransactionCommand{
+				func() model.TransactionCommand {
+					transactionCommand := withAttempts(createCommand, 3)
+					transactionCommand.NextAttemptDate = now.Add(40 * time.Second)
+					transactionCommand.LastError = model.NullString{NullString: sql.NullString{String: "transaction command 1: Service Unavailable", Valid: true}}
+
+					return transactionCommand
+				}(),
+			},
+			wantErr: true,
+		},
+		{
+			name:               "unauthorized is retried",
+			transactionCommand: deleteCommand,
+			leased:             true,
+			statusCode:         http.StatusUnauthorized,
+			wantRequestList: []transactionCommandRequest{
+				{Method: "DELETE", Path: "/transactions/7", Authorization: authorization, UserID: "userID1"},
+			},
+			wantPutTransactionCommand: []model.TransactionCommand{
+				func() model.TransactionCommand {
+					transactionCommand := withAttempts(deleteCommand, 1)
+					transactionCommand.NextAttemptDate = now.Add(10 * time.Second)
+					transactionCommand.LastError = model.NullString{NullString: sql.NullString{String: "transaction command 3: Unauthorized", Valid: true}}
+
+					return transactionCommand
+				}(),
+			},
+			wantErr: true,
+		},
+		{
+			name:               "server error on the last attempt fails the command",
+			transactionCommand: withAttempts(createCommand, 9),
+			leased:             true,
+			statusCode:         http.StatusInternalServerError,
+			wantRequestList: []transactionCommandRequest{
+				{Method: "POST", Path: "/transactions", Authorization: authorization, UserID: "userID1", IdempotencyKey: "shopping-list-5"},
+			},
+			wantPutTransactionCommand: []model.TransactionCommand{
+				func() model.TransactionCommand {
+					transactionCommand := withAttempts(createCommand, 10)
+					transactionCommand.Status = "failed"
+					transactionCommand.LastError = model.NullString{NullString: sql.NullString{String: "transaction command 1: Internal Server Error", Valid: true}}
+
+					return transactionCommand
+				}(),
+			},
+			wantErr: true,
+		},
+		{
+			name:               "client error fails the command without retry",
+			transactionCommand: updateGroupCommand,
+			leased:             true,
+			statusCode:         http.StatusBadRequest,
+			wantRequestList: []transactionCommandRequest{
+				{Method: "PUT", Path: "/groups/1/transactions/6", Authorization: authorization, UserID: "userID2"},
+			},
+			wantPutTransactionCommand: []model.TransactionCommand{
+				func() model.TransactionCommand {
+					transactionCommand := withAttempts(updateGroupCommand, 1)
+					transactionCommand.Status = "failed"
+					transactionCommand.LastError = model.NullString{NullString: sql.NullString{String: "transaction command 2: Bad Request", Valid: true}}
+
+					return transactionCommand
+				}(),
+			},
+			wantErr: true,
+		},
+		{
+			name:               "command leased by another worker is not sent",
+			transactionCommand: createCommand,
+			leased:             false,
+			statusCode:         http.StatusCreated,
+			responseBody:       `{"id":10}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requestList []transactionCommandRequest
+			ts := startMockTransactionCommandServer(t, func(w http.ResponseWriter, r *http.Request) {
+				requestList = append(requestList, newTransactionCommandRequest(r))
+
+				w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(tt.responseBody))
+			})
+			defer ts.Close()
+
+			var deliveredTransactionCommand, putTransactionCommand []model.TransactionCommand
+			h := DBHandler{
+				TransactionOutboxRepo: MockDeliveryTransactionOutboxRepository{
+					leased:                      tt.leased,
+					deliveredTransactionCommand: &deliveredTransactionCommand,
+					putTransactionCommand:       &putTransactionCommand,
+				},
+				TimeManage: MockTime{},
+			}
+
+			_, err := deliverTransactionCommand(&h, tt.transactionCommand)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("unexpected error: %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.wantRequestList, requestList); len(diff) != 0 {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.wantDeliveredTransactionCommand, deliveredTransactionCommand); len(diff) != 0 {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.wantPutTransactionCommand, putTransactionCommand); len(diff) != 0 {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDeliverTransactionCommand_IdempotentRedelivery(t *testing.T) {
+	// account-rest-service posts the transaction, but the response is lost on the first delivery.
+	transactionIDs := make(map[string]int)
+	var requestCount int
+	ts := startMockTransactionCommandServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+
+		idempotencyKey := r.Header.Get("Idempotency-Key")
+		statusCode := http.StatusOK
+		if _, ok := transactionIDs[idempotencyKey]; !ok {
+			transactionIDs[idempotencyKey] = len(transactionIDs) + 10
+			statusCode = http.StatusCreated
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		if requestCount == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		w.WriteHeader(statusCode)
+		_, _ = fmt.Fprintf(w, `{"id":%d}`, transactionIDs[idempotencyKey])
+	})
+	defer ts.Close()
+
+	var deliveredTransactionCommand, putTransactionCommand []model.TransactionCommand
+	h := DBHandler{
+		TransactionOutboxRepo: MockDeliveryTransactionOutboxRepository{
+			leased:                      true,
+			deliveredTransactionCommand: &deliveredTransactionCommand,
+			putTransactionCommand:       &putTransactionCommand,
+		},
+		TimeManage: MockTime{},
+	}
+
+	transactionCommand := model.TransactionCommand{
+		ID:              1,
+		Command:         "create",
+		UserID:          "userID1",
+		ShoppingItemID:  5,
+		IdempotencyKey:  "shopping-list-5",
+		Payload:         model.NullString{NullString: sql.NullString{String: `{"transaction_type":"expense"}`, Valid: true}},
+		Status:          "pending",
+		NextAttemptDate: MockTime{}.Now(),
+	}
+
+	if _, err := deliverTransactionCommand(&h, transactionCommand); err == nil {
+		t.Fatal("expected the first delivery to fail")
+	}
+
+	if len(putTransactionCommand) != 1 || putTransactionCommand[0].Status != "pending" {
+		t.Fatalf("expected the command to be kept pending for the retry, got %+v", putTransactionCommand)
+	}
+
+	if _, err := deliverTransactionCommand(&h, putTransactionCommand[0]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(transactionIDs) != 1 {
+		t.Errorf("expected a single transaction to be posted, got %d", len(transactionIDs))
+	}
+
+	want := []model.TransactionCommand{
+		func() model.TransactionCommand {
+			transactionCommand := putTransactionCommand[0]
+			transactionCommand.Attempts = 2
+			transactionCommand.TransactionID = model.NullInt64{NullInt64: sql.NullInt64{Int64: 10, Valid: true}}
+
+			return transactionCommand
+		}(),
+	}
+
+	if diff := cmp.Diff(want, deliveredTransactionCommand); len(diff) != 0 {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+type MockPendingTransactionOutboxRepository struct {
+	MockDeliveryTransactionOutboxRepository
+	pendingTransactionCommandList []model.TransactionCommand
+}
+
+func (m MockPendingTransactionOutboxRepository) GetPendingTransactionCommandList(now time.Time, limit int) ([]model.TransactionCommand, error) {
+	return m.pendingTransactionCommandList, nil
+}
+
+func TestDeliverPendingTransactionCommands(t *testing.T) {
+	// The failure of a transaction command does not stop the delivery of the following ones.
+	ts := startMockTransactionCommandServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		if r.Header.Get("X-User-ID") == "userID1" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":6}`))
+	})
+	defer ts.Close()
+
+	var deliveredTransactionCommand, putTransactionCommand []model.TransactionCommand
+	h := DBHandler{
+		TransactionOutboxRepo: MockPendingTransactionOutboxRepository{
+			MockDeliveryTransactionOutboxRepository: MockDeliveryTransactionOutboxRepository{
+				leased:                      true,
+				deliveredTransactionCommand: &deliveredTransactionCommand,
+				putTransactionCommand:       &putTransactionCommand,
+			},
+			pendingTransactionCommandList: []model.TransactionCommand{
+				{ID: 1, Command: "delete", UserID: "userID1", ShoppingItemID: 5, TransactionID: model.NullInt64{NullInt64: sql.NullInt64{Int64: 5, Valid: true}}, Status: "pending"},
+				{ID: 2, Command: "delete", UserID: "userID2", ShoppingItemID: 6, TransactionID: model.NullInt64{NullInt64: sql.NullInt64{Int64: 6, Valid: true}}, Status: "pending"},
+			},
+		},
+		TimeManage: MockTime{},
+	}
+
+	if err := deliverPendingTransactionCommands(&h); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(putTransactionCommand) != 1 || putTransactionCommand[0].ID != 1 || putTransactionCommand[0].Status != "failed" {
+		t.Errorf("expected the command 1 to fail, got %+v", putTransactionCommand)
+	}
+
+	if len(deliveredTransactionCommand) != 1 || deliveredTransactionCommand[0].ID != 2 {
+		t.Errorf("expected the command 2 to be delivered, got %+v", deliveredTransactionCommand)
+	}
+}
@@ -0,0 +1,101 @@
+package infrastructure
+
+import (
+	"database/sql"
+
+	"github.com/hryze/kakeibo-app-api/todo-rest-service/domain/model"
+)
+
+type CalendarFeedRepository struct {
+	*MySQLHandler
+}
+
+func NewCalendarFeedRepository(mysqlHandler *MySQLHandler) *CalendarFeedRepository {
+	return &CalendarFeedRepository{mysqlHandler}
+}
+
+func (r *CalendarFeedRepository) GetCalendarFeedToken(tokenHash string) (*model.CalendarFeedToken, error) {
+	query := `
+        SELECT
+            id,
+            user_id,
+            group_id,
+            token_hash,
+            created_date
+        FROM
+            calendar_feed_tokens
+        WHERE
+            token_hash = ?`
+
+	var calendarFeedToken model.CalendarFeedToken
+	if err := r.MySQLHandler.conn.QueryRowx(query, tokenHash).StructScan(&calendarFeedToken); err != nil {
+		return nil, err
+	}
+
+	return &calendarFeedToken, nil
+}
+
+// PutCalendarFeedToken replaces the token of the feed of the user, or of the group when the group ID is valid,
+// so that the feed URL issued before is revoked.
+func (r *CalendarFeedRepository) PutCalendarFeedToken(calendarFeedToken *model.CalendarFeedToken) error {
+	deleteQuery := `
+        DELETE
+        FROM
+            calendar_feed_tokens
+        WHERE
+            user_id = ?
+        AND
+            group_id <=> ?`
+
+	insertQuery := `
+        INSERT INTO calendar_feed_tokens
+            (user_id, group_id, token_hash)
+        VALUES
+            (?,?,?)`
+
+	tx, err := r.MySQLHandler.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	transactions := func(tx *sql.Tx) error {
+		if _, err := tx.Exec(deleteQuery, calendarFeedToken.UserID, calendarFeedToken.GroupID); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(insertQuery, calendarFeedToken.UserID, calendarFeedToken.GroupID, calendarFeedToken.TokenHash); err != nil {
+			return err
+		}
+
+		return nil
+	}
+
+	if err := transactions(tx); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return err
+		}
+
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *CalendarFeedRepository) DeleteCalendarFeedToken(userID string, groupID model.NullInt) error {
+	query := `
+        DELETE
+        FROM
+            calendar_feed_tokens
+        WHERE
+            user_id = ?
+        AND
+            group_id <=> ?`
+
+	_, err := r.MySQLHandler.conn.Exec(query, userID, groupID)
+
+	return err
+}
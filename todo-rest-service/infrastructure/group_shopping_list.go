@@ -1,6 +1,7 @@
 package infrastructure
 
 import (
+	"context"
 	"database/sql"
 	"time"
 
@@ -24,14 +25,21 @@ func (r *GroupShoppingListRepository) GetGroupRegularShoppingList(groupID int) (
             expected_purchase_date,
             cycle_type,
             cycle,
+            recurrence_rule,
+            recurrence_start_date,
             purchase,
             shop,
             amount,
+            quantity,
+            unit,
+            unit_price,
             big_category_id,
             medium_category_id,
             custom_category_id,
             payment_user_id,
-            transaction_auto_add
+            transaction_auto_add,
+            finished_flag,
+            paused_until
         FROM
             group_regular_shopping_list
         WHERE
@@ -60,9 +68,54 @@ func (r *GroupShoppingListRepository) GetGroupRegularShoppingList(groupID int) (
 		return groupRegularShoppingList, err
 	}
 
+	if err := r.setGroupRegularShoppingListSkippedDateList(groupRegularShoppingList.GroupRegularShoppingList, groupID); err != nil {
+		return groupRegularShoppingList, err
+	}
+
 	return groupRegularShoppingList, nil
 }
 
+func (r *GroupShoppingListRepository) setGroupRegularShoppingListSkippedDateList(groupRegularShoppingList []model.GroupRegularShoppingItem, groupID int) error {
+	query := `
+        SELECT
+            group_regular_shopping_skips.regular_shopping_list_id,
+            group_regular_shopping_skips.skipped_date
+        FROM
+            group_regular_shopping_skips
+        INNER JOIN
+            group_regular_shopping_list
+        ON
+            group_regular_shopping_skips.regular_shopping_list_id = group_regular_shopping_list.id
+        WHERE
+            group_regular_shopping_list.group_id = ?`
+
+	rows, err := r.MySQLHandler.conn.Queryx(query, groupID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var groupRegularShoppingItemID int
+		var skippedDate model.Date
+		if err := rows.Scan(&groupRegularShoppingItemID, &skippedDate); err != nil {
+			return err
+		}
+
+		for i := range groupRegularShoppingList {
+			if groupRegularShoppingList[i].ID == groupRegularShoppingItemID {
+				groupRegularShoppingList[i].SkippedDateList = append(groupRegularShoppingList[i].SkippedDateList, skippedDate)
+			}
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (r *GroupShoppingListRepository) GetGroupRegularShoppingItem(groupRegularShoppingItemID int) (model.GroupRegularShoppingItem, error) {
 	query := `
         SELECT
@@ -72,24 +125,58 @@ func (r *GroupShoppingListRepository) GetGroupRegularShoppingItem(groupRegularSh
             expected_purchase_date,
             cycle_type,
             cycle,
+            recurrence_rule,
+            recurrence_start_date,
             purchase,
             shop,
             amount,
+            quantity,
+            unit,
+            unit_price,
             big_category_id,
             medium_category_id,
             custom_category_id,
             payment_user_id,
-            transaction_auto_add
+            transaction_auto_add,
+            finished_flag,
+            paused_until
         FROM
             group_regular_shopping_list
         WHERE
             id = ?`
 
+	skippedDateListQuery := `
+        SELECT
+            skipped_date
+        FROM
+            group_regular_shopping_skips
+        WHERE
+            regular_shopping_list_id = ?`
+
 	var groupRegularShoppingItem model.GroupRegularShoppingItem
 	if err := r.MySQLHandler.conn.QueryRowx(query, groupRegularShoppingItemID).StructScan(&groupRegularShoppingItem); err != nil {
 		return groupRegularShoppingItem, err
 	}
 
+	rows, err := r.MySQLHandler.conn.Queryx(skippedDateListQuery, groupRegularShoppingItemID)
+	if err != nil {
+		return groupRegularShoppingItem, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var skippedDate model.Date
+		if err := rows.Scan(&skippedDate); err != nil {
+			return groupRegularShoppingItem, err
+		}
+
+		groupRegularShoppingItem.SkippedDateList = append(groupRegularShoppingItem.SkippedDateList, skippedDate)
+	}
+
+	if err := rows.Err(); err != nil {
+		return groupRegularShoppingItem, err
+	}
+
 	return groupRegularShoppingItem, nil
 }
 
@@ -104,11 +191,17 @@ func (r *GroupShoppingListRepository) GetGroupShoppingListRelatedToPostedGroupRe
             purchase,
             shop,
             amount,
+            quantity,
+            unit,
+            unit_price,
             big_category_id,
             medium_category_id,
             custom_category_id,
             regular_shopping_list_id,
             payment_user_id,
+            claimed_user_id,
+            claimed_date,
+            claim_version,
             transaction_auto_add,
             transaction_id
         FROM
@@ -157,18 +250,24 @@ func (r *GroupShoppingListRepository) PostGroupRegularShoppingItem(groupRegularS
             expected_purchase_date,
             cycle_type,
             cycle,
+            recurrence_rule,
+            recurrence_start_date,
             purchase,
             shop,
             amount,
+            quantity,
+            unit,
+            unit_price,
             big_category_id,
             medium_category_id,
             custom_category_id,
             payment_user_id,
             group_id,
-            transaction_auto_add
+            transaction_auto_add,
+            finished_flag
         )
         VALUES
-            (?,?,?,?,?,?,?,?,?,?,?,?)`
+            (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`
 
 	groupShoppingItemQuery := `
         INSERT INTO group_shopping_list
@@ -177,6 +276,9 @@ func (r *GroupShoppingListRepository) PostGroupRegularShoppingItem(groupRegularS
             purchase,
             shop,
             amount,
+            quantity,
+            unit,
+            unit_price,
             big_category_id,
             medium_category_id,
             custom_category_id,
@@ -186,7 +288,7 @@ func (r *GroupShoppingListRepository) PostGroupRegularShoppingItem(groupRegularS
             transaction_auto_add
         )
         VALUES
-            (?,?,?,?,?,?,?,?,?,?,?)`
+            (?,?,?,?,?,?,?,?,?,?,?,?,?,?)`
 
 	tx, err := r.MySQLHandler.conn.Begin()
 	if err != nil {
@@ -197,15 +299,13 @@ func (r *GroupShoppingListRepository) PostGroupRegularShoppingItem(groupRegularS
 		var groupRegularShoppingItemResult, todayGroupShoppingItemResult, laterThanTodayGroupShoppingItemResult sql.Result
 		nextExpectedPurchaseDate := groupRegularShoppingItem.ExpectedPurchaseDate.Time
 
+		var finished bool
 		if today.Equal(groupRegularShoppingItem.ExpectedPurchaseDate.Time) {
-			if groupRegularShoppingItem.CycleType == "daily" {
-				nextExpectedPurchaseDate = nextExpectedPurchaseDate.AddDate(0, 0, 1)
-			} else if groupRegularShoppingItem.CycleType == "weekly" {
-				nextExpectedPurchaseDate = nextExpectedPurchaseDate.AddDate(0, 0, 7)
-			} else if groupRegularShoppingItem.CycleType == "monthly" {
-				nextExpectedPurchaseDate = nextExpectedPurchaseDate.AddDate(0, 1, 0)
-			} else if groupRegularShoppingItem.CycleType == "custom" {
-				nextExpectedPurchaseDate = nextExpectedPurchaseDate.AddDate(0, 0, groupRegularShoppingItem.Cycle.Int)
+			expectedPurchaseDate, ok := groupRegularShoppingItem.NextExpectedPurchaseDate(nextExpectedPurchaseDate)
+			if ok {
+				nextExpectedPurchaseDate = expectedPurchaseDate
+			} else {
+				finished = true
 			}
 		}
 
@@ -214,15 +314,21 @@ func (r *GroupShoppingListRepository) PostGroupRegularShoppingItem(groupRegularS
 			nextExpectedPurchaseDate,
 			groupRegularShoppingItem.CycleType,
 			groupRegularShoppingItem.Cycle,
+			groupRegularShoppingItem.RecurrenceRule,
+			groupRegularShoppingItem.RecurrenceStartDate,
 			groupRegularShoppingItem.Purchase,
 			groupRegularShoppingItem.Shop,
 			groupRegularShoppingItem.Amount,
+			groupRegularShoppingItem.Quantity,
+			groupRegularShoppingItem.Unit,
+			groupRegularShoppingItem.UnitPrice,
 			groupRegularShoppingItem.BigCategoryID,
 			groupRegularShoppingItem.MediumCategoryID,
 			groupRegularShoppingItem.CustomCategoryID,
 			groupRegularShoppingItem.PaymentUserID,
 			groupID,
 			groupRegularShoppingItem.TransactionAutoAdd,
+			finished,
 		)
 		if err != nil {
 			return nil, nil, nil, err
@@ -233,22 +339,27 @@ func (r *GroupShoppingListRepository) PostGroupRegularShoppingItem(groupRegularS
 			return nil, nil, nil, err
 		}
 
-		laterThanTodayGroupShoppingItemResult, err = tx.Exec(
-			groupShoppingItemQuery,
-			nextExpectedPurchaseDate,
-			groupRegularShoppingItem.Purchase,
-			groupRegularShoppingItem.Shop,
-			groupRegularShoppingItem.Amount,
-			groupRegularShoppingItem.BigCategoryID,
-			groupRegularShoppingItem.MediumCategoryID,
-			groupRegularShoppingItem.CustomCategoryID,
-			groupRegularShoppingItemId,
-			groupRegularShoppingItem.PaymentUserID,
-			groupID,
-			groupRegularShoppingItem.TransactionAutoAdd,
-		)
-		if err != nil {
-			return nil, nil, nil, err
+		if !finished {
+			laterThanTodayGroupShoppingItemResult, err = tx.Exec(
+				groupShoppingItemQuery,
+				nextExpectedPurchaseDate,
+				groupRegularShoppingItem.Purchase,
+				groupRegularShoppingItem.Shop,
+				groupRegularShoppingItem.Amount,
+				groupRegularShoppingItem.Quantity,
+				groupRegularShoppingItem.Unit,
+				groupRegularShoppingItem.UnitPrice,
+				groupRegularShoppingItem.BigCategoryID,
+				groupRegularShoppingItem.MediumCategoryID,
+				groupRegularShoppingItem.CustomCategoryID,
+				groupRegularShoppingItemId,
+				groupRegularShoppingItem.PaymentUserID,
+				groupID,
+				groupRegularShoppingItem.TransactionAutoAdd,
+			)
+			if err != nil {
+				return nil, nil, nil, err
+			}
 		}
 
 		if today.Equal(groupRegularShoppingItem.ExpectedPurchaseDate.Time) {
@@ -258,6 +369,9 @@ func (r *GroupShoppingListRepository) PostGroupRegularShoppingItem(groupRegularS
 				groupRegularShoppingItem.Purchase,
 				groupRegularShoppingItem.Shop,
 				groupRegularShoppingItem.Amount,
+				groupRegularShoppingItem.Quantity,
+				groupRegularShoppingItem.Unit,
+				groupRegularShoppingItem.UnitPrice,
 				groupRegularShoppingItem.BigCategoryID,
 				groupRegularShoppingItem.MediumCategoryID,
 				groupRegularShoppingItem.CustomCategoryID,
@@ -301,11 +415,17 @@ func (r *GroupShoppingListRepository) GetGroupShoppingListRelatedToUpdatedGroupR
             purchase,
             shop,
             amount,
+            quantity,
+            unit,
+            unit_price,
             big_category_id,
             medium_category_id,
             custom_category_id,
             regular_shopping_list_id,
             payment_user_id,
+            claimed_user_id,
+            claimed_date,
+            claim_version,
             transaction_auto_add,
             transaction_id
         FROM
@@ -358,17 +478,33 @@ func (r *GroupShoppingListRepository) PutGroupRegularShoppingItem(groupRegularSh
             expected_purchase_date = ?,
             cycle_type = ?,
             cycle = ?,
+            recurrence_rule = ?,
+            recurrence_start_date = ?,
             purchase = ?,
             shop = ?,
             amount = ?,
+            quantity = ?,
+            unit = ?,
+            unit_price = ?,
             big_category_id = ?,
             medium_category_id = ?,
             custom_category_id = ?,
             payment_user_id = ?,
-            transaction_auto_add = ?
+            transaction_auto_add = ?,
+            finished_flag = ?,
+            paused_until = NULL
         WHERE
             id = ?`
 
+	deleteSkippedDateQuery := `
+        DELETE
+        FROM
+            group_regular_shopping_skips
+        WHERE
+            regular_shopping_list_id = ?
+        AND
+            skipped_date >= ?`
+
 	insertGroupShoppingItemQuery := `
         INSERT INTO group_shopping_list
         (
@@ -376,6 +512,9 @@ func (r *GroupShoppingListRepository) PutGroupRegularShoppingItem(groupRegularSh
             purchase,
             shop,
             amount,
+            quantity,
+            unit,
+            unit_price,
             big_category_id,
             medium_category_id,
             custom_category_id,
@@ -385,7 +524,7 @@ func (r *GroupShoppingListRepository) PutGroupRegularShoppingItem(groupRegularSh
             transaction_auto_add
         )
         VALUES
-            (?,?,?,?,?,?,?,?,?,?,?)`
+            (?,?,?,?,?,?,?,?,?,?,?,?,?,?)`
 
 	tx, err := r.MySQLHandler.conn.Begin()
 	if err != nil {
@@ -400,12 +539,25 @@ func (r *GroupShoppingListRepository) PutGroupRegularShoppingItem(groupRegularSh
 			return err
 		}
 
+		// Editing restarts the recurrence from the edited expected purchase date,
+		// so the skips and the pause made for the previous recurrence are cancelled.
+		if _, err := tx.Exec(
+			deleteSkippedDateQuery,
+			groupRegularShoppingItemID,
+			today,
+		); err != nil {
+			return err
+		}
+
 		if _, err = tx.Exec(
 			insertGroupShoppingItemQuery,
 			groupRegularShoppingItem.ExpectedPurchaseDate,
 			groupRegularShoppingItem.Purchase,
 			groupRegularShoppingItem.Shop,
 			groupRegularShoppingItem.Amount,
+			groupRegularShoppingItem.Quantity,
+			groupRegularShoppingItem.Unit,
+			groupRegularShoppingItem.UnitPrice,
 			groupRegularShoppingItem.BigCategoryID,
 			groupRegularShoppingItem.MediumCategoryID,
 			groupRegularShoppingItem.CustomCategoryID,
@@ -419,23 +571,25 @@ func (r *GroupShoppingListRepository) PutGroupRegularShoppingItem(groupRegularSh
 
 		nextExpectedPurchaseDate := groupRegularShoppingItem.ExpectedPurchaseDate.Time
 
+		var finished bool
 		for !today.Before(nextExpectedPurchaseDate) {
-			if groupRegularShoppingItem.CycleType == "daily" {
-				nextExpectedPurchaseDate = nextExpectedPurchaseDate.AddDate(0, 0, 1)
-			} else if groupRegularShoppingItem.CycleType == "weekly" {
-				nextExpectedPurchaseDate = nextExpectedPurchaseDate.AddDate(0, 0, 7)
-			} else if groupRegularShoppingItem.CycleType == "monthly" {
-				nextExpectedPurchaseDate = nextExpectedPurchaseDate.AddDate(0, 1, 0)
-			} else if groupRegularShoppingItem.CycleType == "custom" {
-				nextExpectedPurchaseDate = nextExpectedPurchaseDate.AddDate(0, 0, groupRegularShoppingItem.Cycle.Int)
+			expectedPurchaseDate, ok := groupRegularShoppingItem.NextExpectedPurchaseDate(nextExpectedPurchaseDate)
+			if !ok {
+				finished = true
+				break
 			}
 
+			nextExpectedPurchaseDate = expectedPurchaseDate
+
 			if _, err = tx.Exec(
 				insertGroupShoppingItemQuery,
 				nextExpectedPurchaseDate,
 				groupRegularShoppingItem.Purchase,
 				groupRegularShoppingItem.Shop,
 				groupRegularShoppingItem.Amount,
+				groupRegularShoppingItem.Quantity,
+				groupRegularShoppingItem.Unit,
+				groupRegularShoppingItem.UnitPrice,
 				groupRegularShoppingItem.BigCategoryID,
 				groupRegularShoppingItem.MediumCategoryID,
 				groupRegularShoppingItem.CustomCategoryID,
@@ -453,14 +607,20 @@ func (r *GroupShoppingListRepository) PutGroupRegularShoppingItem(groupRegularSh
 			nextExpectedPurchaseDate,
 			groupRegularShoppingItem.CycleType,
 			groupRegularShoppingItem.Cycle,
+			groupRegularShoppingItem.RecurrenceRule,
+			groupRegularShoppingItem.RecurrenceStartDate,
 			groupRegularShoppingItem.Purchase,
 			groupRegularShoppingItem.Shop,
 			groupRegularShoppingItem.Amount,
+			groupRegularShoppingItem.Quantity,
+			groupRegularShoppingItem.Unit,
+			groupRegularShoppingItem.UnitPrice,
 			groupRegularShoppingItem.BigCategoryID,
 			groupRegularShoppingItem.MediumCategoryID,
 			groupRegularShoppingItem.CustomCategoryID,
 			groupRegularShoppingItem.PaymentUserID,
 			groupRegularShoppingItem.TransactionAutoAdd,
+			finished,
 			groupRegularShoppingItemID,
 		); err != nil {
 			return err
@@ -489,9 +649,12 @@ func (r *GroupShoppingListRepository) PutGroupRegularShoppingList(groupRegularSh
         UPDATE
             group_regular_shopping_list
         SET
-            expected_purchase_date = ?
+            expected_purchase_date = ?,
+            finished_flag = ?
         WHERE
-            id = ?`
+            id = ?
+        AND
+            expected_purchase_date = ?`
 
 	insertGroupShoppingItemQuery := `
         INSERT INTO 
@@ -501,6 +664,9 @@ func (r *GroupShoppingListRepository) PutGroupRegularShoppingList(groupRegularSh
             purchase,
             shop,
             amount,
+            quantity,
+            unit,
+            unit_price,
             big_category_id,
             medium_category_id,
             custom_category_id,
@@ -511,7 +677,7 @@ func (r *GroupShoppingListRepository) PutGroupRegularShoppingList(groupRegularSh
         )
         VALUES
         (
-            ?,?,?,?,?,?,?,?,?,?,?
+            ?,?,?,?,?,?,?,?,?,?,?,?,?,?
         )`
 
 	tx, err := r.MySQLHandler.conn.Begin()
@@ -521,25 +687,44 @@ func (r *GroupShoppingListRepository) PutGroupRegularShoppingList(groupRegularSh
 
 	transactions := func(tx *sql.Tx) error {
 		for _, groupRegularShoppingItem := range groupRegularShoppingList.GroupRegularShoppingList {
-			nextExpectedPurchaseDate := groupRegularShoppingItem.ExpectedPurchaseDate.Time
-
-			for !today.Before(nextExpectedPurchaseDate) {
-				if groupRegularShoppingItem.CycleType == "daily" {
-					nextExpectedPurchaseDate = nextExpectedPurchaseDate.AddDate(0, 0, 1)
-				} else if groupRegularShoppingItem.CycleType == "weekly" {
-					nextExpectedPurchaseDate = nextExpectedPurchaseDate.AddDate(0, 0, 7)
-				} else if groupRegularShoppingItem.CycleType == "monthly" {
-					nextExpectedPurchaseDate = nextExpectedPurchaseDate.AddDate(0, 1, 0)
-				} else if groupRegularShoppingItem.CycleType == "custom" {
-					nextExpectedPurchaseDate = nextExpectedPurchaseDate.AddDate(0, 0, groupRegularShoppingItem.Cycle.Int)
-				}
+			if today.Before(groupRegularShoppingItem.ExpectedPurchaseDate.Time) {
+				continue
+			}
+
+			materialization := groupRegularShoppingItem.Materialization(today)
+
+			// The regular shopping item is advanced only from the expected purchase date it was read with,
+			// so that the shopping items are not inserted twice when it has been advanced in the meantime.
+			result, err := tx.Exec(
+				updateGroupRegularShoppingItemQuery,
+				materialization.NextExpectedPurchaseDate,
+				materialization.Finished,
+				groupRegularShoppingItem.ID,
+				groupRegularShoppingItem.ExpectedPurchaseDate.Time,
+			)
+			if err != nil {
+				return err
+			}
+
+			rowsAffected, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+
+			if rowsAffected == 0 {
+				continue
+			}
 
-				if _, err = tx.Exec(
+			for _, expectedPurchaseDate := range materialization.ExpectedPurchaseDateList {
+				if _, err := tx.Exec(
 					insertGroupShoppingItemQuery,
-					nextExpectedPurchaseDate,
+					expectedPurchaseDate,
 					groupRegularShoppingItem.Purchase,
 					groupRegularShoppingItem.Shop,
 					groupRegularShoppingItem.Amount,
+					groupRegularShoppingItem.Quantity,
+					groupRegularShoppingItem.Unit,
+					groupRegularShoppingItem.UnitPrice,
 					groupRegularShoppingItem.BigCategoryID,
 					groupRegularShoppingItem.MediumCategoryID,
 					groupRegularShoppingItem.CustomCategoryID,
@@ -551,16 +736,6 @@ func (r *GroupShoppingListRepository) PutGroupRegularShoppingList(groupRegularSh
 					return err
 				}
 			}
-
-			if !today.Before(groupRegularShoppingItem.ExpectedPurchaseDate.Time) {
-				if _, err := tx.Exec(
-					updateGroupRegularShoppingItemQuery,
-					nextExpectedPurchaseDate,
-					groupRegularShoppingItem.ID,
-				); err != nil {
-					return err
-				}
-			}
 		}
 
 		return nil
@@ -581,132 +756,465 @@ func (r *GroupShoppingListRepository) PutGroupRegularShoppingList(groupRegularSh
 	return nil
 }
 
-func (r *GroupShoppingListRepository) DeleteGroupRegularShoppingItem(groupRegularShoppingItemID int) error {
-	deleteGroupShoppingItemQuery := `
-        DELETE
+func (r *GroupShoppingListRepository) GetDueGroupRegularShoppingListGroupIDList(today time.Time) ([]int, error) {
+	query := `
+        SELECT DISTINCT
+            group_id
         FROM
-            group_shopping_list
+            group_regular_shopping_list
         WHERE
-            regular_shopping_list_id = ?
+            expected_purchase_date <= ?
         AND
-            complete_flag = false`
+            finished_flag = false`
 
-	deleteGroupRegularShoppingItemQuery := `
+	rows, err := r.MySQLHandler.conn.Queryx(query, today)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var groupIDList []int
+	for rows.Next() {
+		var groupID int
+		if err := rows.Scan(&groupID); err != nil {
+			return nil, err
+		}
+
+		groupIDList = append(groupIDList, groupID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return groupIDList, nil
+}
+
+func (r *GroupShoppingListRepository) SkipGroupRegularShoppingItem(groupRegularShoppingItem *model.GroupRegularShoppingItem, skippedDateList []time.Time, groupID int, today time.Time) (bool, error) {
+	tx, err := r.MySQLHandler.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	transactions := func(tx *sql.Tx) (bool, error) {
+		locked, err := lockGroupRegularShoppingItem(tx, groupRegularShoppingItem, groupID)
+		if err != nil || !locked {
+			return false, err
+		}
+
+		if err := skipGroupRegularShoppingItemDateList(tx, groupRegularShoppingItem, skippedDateList, "skip", groupID, today); err != nil {
+			return false, err
+		}
+
+		return true, nil
+	}
+
+	skipped, err := transactions(tx)
+	if err != nil || !skipped {
+		if err := tx.Rollback(); err != nil {
+			return false, err
+		}
+
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *GroupShoppingListRepository) PauseGroupRegularShoppingItem(groupRegularShoppingItem *model.GroupRegularShoppingItem, groupID int, today time.Time) (bool, error) {
+	deletePausedDateQuery := `
         DELETE
         FROM
+            group_regular_shopping_skips
+        WHERE
+            regular_shopping_list_id = ?
+        AND
+            skip_reason = 'pause'
+        AND
+            skipped_date >= ?`
+
+	updatePausedUntilQuery := `
+        UPDATE
             group_regular_shopping_list
+        SET
+            paused_until = ?
         WHERE
             id = ?`
 
 	tx, err := r.MySQLHandler.conn.Begin()
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	transactions := func(tx *sql.Tx) error {
-		if _, err := tx.Exec(deleteGroupShoppingItemQuery, groupRegularShoppingItemID); err != nil {
-			return err
+	transactions := func(tx *sql.Tx) (bool, error) {
+		locked, err := lockGroupRegularShoppingItem(tx, groupRegularShoppingItem, groupID)
+		if err != nil || !locked {
+			return false, err
 		}
 
-		if _, err := tx.Exec(deleteGroupRegularShoppingItemQuery, groupRegularShoppingItemID); err != nil {
-			return err
+		// The previous pause is replaced, so the dates it has skipped from today on are brought back first.
+		if _, err := tx.Exec(deletePausedDateQuery, groupRegularShoppingItem.ID, today); err != nil {
+			return false, err
 		}
 
-		return nil
+		if _, err := tx.Exec(updatePausedUntilQuery, groupRegularShoppingItem.PausedUntil, groupRegularShoppingItem.ID); err != nil {
+			return false, err
+		}
+
+		if groupRegularShoppingItem.SkippedDateList, err = selectGroupRegularShoppingItemSkippedDateList(tx, groupRegularShoppingItem.ID); err != nil {
+			return false, err
+		}
+
+		var pausedDateList []time.Time
+		if groupRegularShoppingItem.PausedUntil.Valid {
+			if expectedPurchaseDate, ok := groupRegularShoppingItem.RescheduledExpectedPurchaseDate(today); ok {
+				pausedDateList = groupRegularShoppingItem.UpcomingExpectedPurchaseDateList(expectedPurchaseDate, 0, groupRegularShoppingItem.PausedUntil.Time)
+			}
+		}
+
+		if err := skipGroupRegularShoppingItemDateList(tx, groupRegularShoppingItem, pausedDateList, "pause", groupID, today); err != nil {
+			return false, err
+		}
+
+		return true, nil
 	}
 
-	if err := transactions(tx); err != nil {
+	paused, err := transactions(tx)
+	if err != nil || !paused {
 		if err := tx.Rollback(); err != nil {
-			return err
+			return false, err
 		}
 
-		return err
+		return false, err
 	}
 
 	if err := tx.Commit(); err != nil {
-		return err
+		return false, err
 	}
 
-	return nil
+	return true, nil
 }
 
-func (r *GroupShoppingListRepository) GetDailyGroupShoppingListByDay(date time.Time, groupID int) (model.GroupShoppingList, error) {
+// lockGroupRegularShoppingItem locks the group regular shopping item of the group, and reports whether it is still on the expected purchase date it was read with,
+// so that the skips are not applied to the occurrences the scheduler has advanced over in the meantime.
+// It returns sql.ErrNoRows when the item does not exist or belongs to another group.
+func lockGroupRegularShoppingItem(tx *sql.Tx, groupRegularShoppingItem *model.GroupRegularShoppingItem, groupID int) (bool, error) {
 	query := `
         SELECT
-            id,
-            posted_date,
-            updated_date,
-            expected_purchase_date,
-            complete_flag,
-            purchase,
-            shop,
-            amount,
-            big_category_id,
-            medium_category_id,
-            custom_category_id,
-            regular_shopping_list_id,
-            payment_user_id,
-            transaction_auto_add,
-            transaction_id
+            expected_purchase_date
         FROM
-            group_shopping_list
+            group_regular_shopping_list
         WHERE
-            group_id = ?
+            id = ?
         AND
-            expected_purchase_date = ?`
+            group_id = ?
+        FOR UPDATE`
 
-	groupShoppingList := model.GroupShoppingList{
-		GroupShoppingList: make([]model.GroupShoppingItem, 0),
+	var expectedPurchaseDate model.Date
+	if err := tx.QueryRow(query, groupRegularShoppingItem.ID, groupID).Scan(&expectedPurchaseDate); err != nil {
+		return false, err
 	}
 
-	rows, err := r.MySQLHandler.conn.Queryx(query, groupID, date)
+	return expectedPurchaseDate.Time.Equal(groupRegularShoppingItem.ExpectedPurchaseDate.Time), nil
+}
+
+func selectGroupRegularShoppingItemSkippedDateList(tx *sql.Tx, groupRegularShoppingItemID int) ([]model.Date, error) {
+	query := `
+        SELECT
+            skipped_date
+        FROM
+            group_regular_shopping_skips
+        WHERE
+            regular_shopping_list_id = ?`
+
+	rows, err := tx.Query(query, groupRegularShoppingItemID)
 	if err != nil {
-		return groupShoppingList, err
+		return nil, err
 	}
 	defer rows.Close()
 
+	var skippedDateList []model.Date
 	for rows.Next() {
-		var groupShoppingItem model.GroupShoppingItem
-		if err := rows.StructScan(&groupShoppingItem); err != nil {
-			return groupShoppingList, err
+		var skippedDate model.Date
+		if err := rows.Scan(&skippedDate); err != nil {
+			return nil, err
 		}
 
-		groupShoppingList.GroupShoppingList = append(groupShoppingList.GroupShoppingList, groupShoppingItem)
+		skippedDateList = append(skippedDateList, skippedDate)
 	}
 
 	if err := rows.Err(); err != nil {
-		return groupShoppingList, err
+		return nil, err
 	}
 
-	return groupShoppingList, nil
+	return skippedDateList, nil
 }
 
-func (r *GroupShoppingListRepository) GetDailyGroupShoppingListByCategory(date time.Time, groupID int) (model.GroupShoppingList, error) {
-	query := `
-        SELECT
-            id,
-            posted_date,
-            updated_date,
-            expected_purchase_date,
-            complete_flag,
-            purchase,
-            shop,
-            amount,
-            big_category_id,
-            medium_category_id,
-            custom_category_id,
+// skipGroupRegularShoppingItemDateList records the skipped dates, removes their shopping items which have not been completed,
+// and moves the regular shopping item and its shopping item to the first expected purchase date which is not skipped.
+func skipGroupRegularShoppingItemDateList(tx *sql.Tx, groupRegularShoppingItem *model.GroupRegularShoppingItem, skippedDateList []time.Time, skipReason string, groupID int, today time.Time) error {
+	insertSkippedDateQuery := `
+        INSERT IGNORE INTO group_regular_shopping_skips
+        (
             regular_shopping_list_id,
-            payment_user_id,
-            transaction_auto_add,
-            transaction_id
-        FROM
-            group_shopping_list
-        WHERE
-            group_id = ?
+            skipped_date,
+            skip_reason
+        )
+        VALUES
+            (?,?,?)`
+
+	deleteGroupShoppingItemQuery := `
+        DELETE
+        FROM
+            group_shopping_list
+        WHERE
+            regular_shopping_list_id = ?
         AND
             expected_purchase_date = ?
+        AND
+            complete_flag = false`
+
+	updateGroupRegularShoppingItemQuery := `
+        UPDATE
+            group_regular_shopping_list
+        SET
+            expected_purchase_date = ?,
+            finished_flag = ?
+        WHERE
+            id = ?`
+
+	insertGroupShoppingItemQuery := `
+        INSERT INTO 
+            group_shopping_list
+        (
+            expected_purchase_date,
+            purchase,
+            shop,
+            amount,
+            quantity,
+            unit,
+            unit_price,
+            big_category_id,
+            medium_category_id,
+            custom_category_id,
+            regular_shopping_list_id,
+            payment_user_id,
+            group_id,
+            transaction_auto_add
+        )
+        VALUES
+        (
+            ?,?,?,?,?,?,?,?,?,?,?,?,?,?
+        )`
+
+	// The shopping item of the current expected purchase date is kept, unless it is skipped or the recurrence had finished.
+	materialized := !bool(groupRegularShoppingItem.FinishedFlag)
+
+	for _, skippedDate := range skippedDateList {
+		if _, err := tx.Exec(insertSkippedDateQuery, groupRegularShoppingItem.ID, skippedDate, skipReason); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(deleteGroupShoppingItemQuery, groupRegularShoppingItem.ID, skippedDate); err != nil {
+			return err
+		}
+
+		if skippedDate.Equal(groupRegularShoppingItem.ExpectedPurchaseDate.Time) {
+			materialized = false
+		}
+	}
+
+	var err error
+	if groupRegularShoppingItem.SkippedDateList, err = selectGroupRegularShoppingItemSkippedDateList(tx, groupRegularShoppingItem.ID); err != nil {
+		return err
+	}
+
+	nextExpectedPurchaseDate, ok := groupRegularShoppingItem.RescheduledExpectedPurchaseDate(today)
+	if !ok {
+		nextExpectedPurchaseDate = groupRegularShoppingItem.ExpectedPurchaseDate.Time
+	}
+
+	if _, err := tx.Exec(
+		updateGroupRegularShoppingItemQuery,
+		nextExpectedPurchaseDate,
+		!ok,
+		groupRegularShoppingItem.ID,
+	); err != nil {
+		return err
+	}
+
+	if !ok || (materialized && nextExpectedPurchaseDate.Equal(groupRegularShoppingItem.ExpectedPurchaseDate.Time)) {
+		return nil
+	}
+
+	if _, err := tx.Exec(deleteGroupShoppingItemQuery, groupRegularShoppingItem.ID, groupRegularShoppingItem.ExpectedPurchaseDate.Time); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(
+		insertGroupShoppingItemQuery,
+		nextExpectedPurchaseDate,
+		groupRegularShoppingItem.Purchase,
+		groupRegularShoppingItem.Shop,
+		groupRegularShoppingItem.Amount,
+		groupRegularShoppingItem.Quantity,
+		groupRegularShoppingItem.Unit,
+		groupRegularShoppingItem.UnitPrice,
+		groupRegularShoppingItem.BigCategoryID,
+		groupRegularShoppingItem.MediumCategoryID,
+		groupRegularShoppingItem.CustomCategoryID,
+		groupRegularShoppingItem.ID,
+		groupRegularShoppingItem.PaymentUserID,
+		groupID,
+		groupRegularShoppingItem.TransactionAutoAdd,
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *GroupShoppingListRepository) GetMonthlyGroupSkippedShoppingList(firstDay time.Time, lastDay time.Time, groupID int) (model.GroupSkippedShoppingList, error) {
+	query := `
+        SELECT
+            group_regular_shopping_skips.id,
+            group_regular_shopping_skips.skipped_date,
+            group_regular_shopping_skips.skip_reason,
+            group_regular_shopping_list.purchase,
+            group_regular_shopping_list.shop,
+            group_regular_shopping_list.amount,
+            group_regular_shopping_list.quantity,
+            group_regular_shopping_list.unit,
+            group_regular_shopping_list.unit_price,
+            group_regular_shopping_list.big_category_id,
+            group_regular_shopping_list.medium_category_id,
+            group_regular_shopping_list.custom_category_id,
+            group_regular_shopping_list.payment_user_id,
+            group_regular_shopping_skips.regular_shopping_list_id
+        FROM
+            group_regular_shopping_skips
+        INNER JOIN
+            group_regular_shopping_list
+        ON
+            group_regular_shopping_skips.regular_shopping_list_id = group_regular_shopping_list.id
+        WHERE
+            group_regular_shopping_list.group_id = ?
+        AND
+            group_regular_shopping_skips.skipped_date >= ?
+        AND
+            group_regular_shopping_skips.skipped_date <= ?
         ORDER BY
-            big_category_id`
+            group_regular_shopping_skips.skipped_date, group_regular_shopping_skips.id`
+
+	groupSkippedShoppingList := model.GroupSkippedShoppingList{
+		GroupSkippedShoppingList: make([]model.GroupSkippedShoppingItem, 0),
+	}
+
+	rows, err := r.MySQLHandler.conn.Queryx(query, groupID, firstDay, lastDay)
+	if err != nil {
+		return groupSkippedShoppingList, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var groupSkippedShoppingItem model.GroupSkippedShoppingItem
+		if err := rows.StructScan(&groupSkippedShoppingItem); err != nil {
+			return groupSkippedShoppingList, err
+		}
+
+		groupSkippedShoppingList.GroupSkippedShoppingList = append(groupSkippedShoppingList.GroupSkippedShoppingList, groupSkippedShoppingItem)
+	}
+
+	if err := rows.Err(); err != nil {
+		return groupSkippedShoppingList, err
+	}
+
+	return groupSkippedShoppingList, nil
+}
+
+func (r *GroupShoppingListRepository) DeleteGroupRegularShoppingItem(groupRegularShoppingItemID int) error {
+	deleteGroupShoppingItemQuery := `
+        DELETE
+        FROM
+            group_shopping_list
+        WHERE
+            regular_shopping_list_id = ?
+        AND
+            complete_flag = false`
+
+	deleteGroupRegularShoppingItemQuery := `
+        DELETE
+        FROM
+            group_regular_shopping_list
+        WHERE
+            id = ?`
+
+	tx, err := r.MySQLHandler.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	transactions := func(tx *sql.Tx) error {
+		if _, err := tx.Exec(deleteGroupShoppingItemQuery, groupRegularShoppingItemID); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(deleteGroupRegularShoppingItemQuery, groupRegularShoppingItemID); err != nil {
+			return err
+		}
+
+		return nil
+	}
+
+	if err := transactions(tx); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return err
+		}
+
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *GroupShoppingListRepository) GetDailyGroupShoppingListByDay(date time.Time, groupID int) (model.GroupShoppingList, error) {
+	query := `
+        SELECT
+            id,
+            posted_date,
+            updated_date,
+            expected_purchase_date,
+            complete_flag,
+            purchase,
+            shop,
+            amount,
+            quantity,
+            unit,
+            unit_price,
+            big_category_id,
+            medium_category_id,
+            custom_category_id,
+            regular_shopping_list_id,
+            payment_user_id,
+            claimed_user_id,
+            claimed_date,
+            claim_version,
+            transaction_auto_add,
+            transaction_id
+        FROM
+            group_shopping_list
+        WHERE
+            group_id = ?
+        AND
+            expected_purchase_date = ?`
 
 	groupShoppingList := model.GroupShoppingList{
 		GroupShoppingList: make([]model.GroupShoppingItem, 0),
@@ -734,7 +1242,7 @@ func (r *GroupShoppingListRepository) GetDailyGroupShoppingListByCategory(date t
 	return groupShoppingList, nil
 }
 
-func (r *GroupShoppingListRepository) GetMonthlyGroupShoppingListByDay(firstDay time.Time, lastDay time.Time, groupID int) (model.GroupShoppingList, error) {
+func (r *GroupShoppingListRepository) GetDailyGroupShoppingListByCategory(date time.Time, groupID int) (model.GroupShoppingList, error) {
 	query := `
         SELECT
             id,
@@ -745,11 +1253,17 @@ func (r *GroupShoppingListRepository) GetMonthlyGroupShoppingListByDay(firstDay
             purchase,
             shop,
             amount,
+            quantity,
+            unit,
+            unit_price,
             big_category_id,
             medium_category_id,
             custom_category_id,
             regular_shopping_list_id,
             payment_user_id,
+            claimed_user_id,
+            claimed_date,
+            claim_version,
             transaction_auto_add,
             transaction_id
         FROM
@@ -757,17 +1271,15 @@ func (r *GroupShoppingListRepository) GetMonthlyGroupShoppingListByDay(firstDay
         WHERE
             group_id = ?
         AND
-            expected_purchase_date >= ?
-        AND
-            expected_purchase_date <= ?
+            expected_purchase_date = ?
         ORDER BY
-            expected_purchase_date`
+            big_category_id`
 
 	groupShoppingList := model.GroupShoppingList{
 		GroupShoppingList: make([]model.GroupShoppingItem, 0),
 	}
 
-	rows, err := r.MySQLHandler.conn.Queryx(query, groupID, firstDay, lastDay)
+	rows, err := r.MySQLHandler.conn.Queryx(query, groupID, date)
 	if err != nil {
 		return groupShoppingList, err
 	}
@@ -789,7 +1301,7 @@ func (r *GroupShoppingListRepository) GetMonthlyGroupShoppingListByDay(firstDay
 	return groupShoppingList, nil
 }
 
-func (r *GroupShoppingListRepository) GetMonthlyGroupShoppingListByCategory(firstDay time.Time, lastDay time.Time, groupID int) (model.GroupShoppingList, error) {
+func (r *GroupShoppingListRepository) GetMonthlyGroupShoppingListByDay(firstDay time.Time, lastDay time.Time, groupID int) (model.GroupShoppingList, error) {
 	query := `
         SELECT
             id,
@@ -800,11 +1312,17 @@ func (r *GroupShoppingListRepository) GetMonthlyGroupShoppingListByCategory(firs
             purchase,
             shop,
             amount,
+            quantity,
+            unit,
+            unit_price,
             big_category_id,
             medium_category_id,
             custom_category_id,
             regular_shopping_list_id,
             payment_user_id,
+            claimed_user_id,
+            claimed_date,
+            claim_version,
             transaction_auto_add,
             transaction_id
         FROM
@@ -816,7 +1334,7 @@ func (r *GroupShoppingListRepository) GetMonthlyGroupShoppingListByCategory(firs
         AND
             expected_purchase_date <= ?
         ORDER BY
-            big_category_id, expected_purchase_date`
+            expected_purchase_date`
 
 	groupShoppingList := model.GroupShoppingList{
 		GroupShoppingList: make([]model.GroupShoppingItem, 0),
@@ -844,7 +1362,7 @@ func (r *GroupShoppingListRepository) GetMonthlyGroupShoppingListByCategory(firs
 	return groupShoppingList, nil
 }
 
-func (r *GroupShoppingListRepository) GetExpiredGroupShoppingList(dueDate time.Time, groupID int) (model.ExpiredGroupShoppingList, error) {
+func (r *GroupShoppingListRepository) GetMonthlyGroupShoppingListByCategory(firstDay time.Time, lastDay time.Time, groupID int) (model.GroupShoppingList, error) {
 	query := `
         SELECT
             id,
@@ -855,11 +1373,17 @@ func (r *GroupShoppingListRepository) GetExpiredGroupShoppingList(dueDate time.T
             purchase,
             shop,
             amount,
+            quantity,
+            unit,
+            unit_price,
             big_category_id,
             medium_category_id,
             custom_category_id,
             regular_shopping_list_id,
             payment_user_id,
+            claimed_user_id,
+            claimed_date,
+            claim_version,
             transaction_auto_add,
             transaction_id
         FROM
@@ -867,39 +1391,39 @@ func (r *GroupShoppingListRepository) GetExpiredGroupShoppingList(dueDate time.T
         WHERE
             group_id = ?
         AND
-            complete_flag = false
+            expected_purchase_date >= ?
         AND
             expected_purchase_date <= ?
         ORDER BY
-            expected_purchase_date`
+            big_category_id, expected_purchase_date`
 
-	expiredGroupShoppingList := model.ExpiredGroupShoppingList{
-		ExpiredGroupShoppingList: make([]model.GroupShoppingItem, 0),
+	groupShoppingList := model.GroupShoppingList{
+		GroupShoppingList: make([]model.GroupShoppingItem, 0),
 	}
 
-	rows, err := r.MySQLHandler.conn.Queryx(query, groupID, dueDate)
+	rows, err := r.MySQLHandler.conn.Queryx(query, groupID, firstDay, lastDay)
 	if err != nil {
-		return expiredGroupShoppingList, err
+		return groupShoppingList, err
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var expiredGroupShoppingItem model.GroupShoppingItem
-		if err := rows.StructScan(&expiredGroupShoppingItem); err != nil {
-			return expiredGroupShoppingList, err
+		var groupShoppingItem model.GroupShoppingItem
+		if err := rows.StructScan(&groupShoppingItem); err != nil {
+			return groupShoppingList, err
 		}
 
-		expiredGroupShoppingList.ExpiredGroupShoppingList = append(expiredGroupShoppingList.ExpiredGroupShoppingList, expiredGroupShoppingItem)
+		groupShoppingList.GroupShoppingList = append(groupShoppingList.GroupShoppingList, groupShoppingItem)
 	}
 
 	if err := rows.Err(); err != nil {
-		return expiredGroupShoppingList, err
+		return groupShoppingList, err
 	}
 
-	return expiredGroupShoppingList, nil
+	return groupShoppingList, nil
 }
 
-func (r *GroupShoppingListRepository) GetGroupShoppingItem(groupShoppingItemID int) (model.GroupShoppingItem, error) {
+func (r *GroupShoppingListRepository) GetDailyGroupShoppingListByShop(date time.Time, groupID int) (model.GroupShoppingList, error) {
 	query := `
         SELECT
             id,
@@ -910,50 +1434,339 @@ func (r *GroupShoppingListRepository) GetGroupShoppingItem(groupShoppingItemID i
             purchase,
             shop,
             amount,
+            quantity,
+            unit,
+            unit_price,
             big_category_id,
             medium_category_id,
             custom_category_id,
             regular_shopping_list_id,
             payment_user_id,
+            claimed_user_id,
+            claimed_date,
+            claim_version,
             transaction_auto_add,
             transaction_id
         FROM
             group_shopping_list
         WHERE
-            id = ?`
+            group_id = ?
+        AND
+            expected_purchase_date = ?
+        ORDER BY
+            shop IS NULL, shop, id`
 
-	var groupShoppingItem model.GroupShoppingItem
-	if err := r.MySQLHandler.conn.QueryRowx(query, groupShoppingItemID).StructScan(&groupShoppingItem); err != nil {
-		return groupShoppingItem, err
+	groupShoppingList := model.GroupShoppingList{
+		GroupShoppingList: make([]model.GroupShoppingItem, 0),
 	}
 
-	return groupShoppingItem, nil
+	rows, err := r.MySQLHandler.conn.Queryx(query, groupID, date)
+	if err != nil {
+		return groupShoppingList, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var groupShoppingItem model.GroupShoppingItem
+		if err := rows.StructScan(&groupShoppingItem); err != nil {
+			return groupShoppingList, err
+		}
+
+		groupShoppingList.GroupShoppingList = append(groupShoppingList.GroupShoppingList, groupShoppingItem)
+	}
+
+	if err := rows.Err(); err != nil {
+		return groupShoppingList, err
+	}
+
+	return groupShoppingList, nil
 }
 
-func (r *GroupShoppingListRepository) PostGroupShoppingItem(groupShoppingItem *model.GroupShoppingItem, groupID int) (sql.Result, error) {
+func (r *GroupShoppingListRepository) GetMonthlyGroupShoppingListByShop(firstDay time.Time, lastDay time.Time, groupID int) (model.GroupShoppingList, error) {
 	query := `
-        INSERT INTO group_shopping_list
-        (
+        SELECT
+            id,
+            posted_date,
+            updated_date,
             expected_purchase_date,
+            complete_flag,
             purchase,
             shop,
             amount,
+            quantity,
+            unit,
+            unit_price,
             big_category_id,
             medium_category_id,
             custom_category_id,
+            regular_shopping_list_id,
             payment_user_id,
-            group_id,
-            transaction_auto_add
-        )
-        VALUES
-            (?,?,?,?,?,?,?,?,?,?)`
-
-	result, err := r.MySQLHandler.conn.Exec(
-		query,
+            claimed_user_id,
+            claimed_date,
+            claim_version,
+            transaction_auto_add,
+            transaction_id
+        FROM
+            group_shopping_list
+        WHERE
+            group_id = ?
+        AND
+            expected_purchase_date >= ?
+        AND
+            expected_purchase_date <= ?
+        ORDER BY
+            shop IS NULL, shop, expected_purchase_date, id`
+
+	groupShoppingList := model.GroupShoppingList{
+		GroupShoppingList: make([]model.GroupShoppingItem, 0),
+	}
+
+	rows, err := r.MySQLHandler.conn.Queryx(query, groupID, firstDay, lastDay)
+	if err != nil {
+		return groupShoppingList, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var groupShoppingItem model.GroupShoppingItem
+		if err := rows.StructScan(&groupShoppingItem); err != nil {
+			return groupShoppingList, err
+		}
+
+		groupShoppingList.GroupShoppingList = append(groupShoppingList.GroupShoppingList, groupShoppingItem)
+	}
+
+	if err := rows.Err(); err != nil {
+		return groupShoppingList, err
+	}
+
+	return groupShoppingList, nil
+}
+
+func (r *GroupShoppingListRepository) GetExpiredGroupShoppingList(ctx context.Context, dueDate time.Time, groupID int) (model.ExpiredGroupShoppingList, error) {
+	query := `
+        SELECT
+            id,
+            posted_date,
+            updated_date,
+            expected_purchase_date,
+            complete_flag,
+            purchase,
+            shop,
+            amount,
+            quantity,
+            unit,
+            unit_price,
+            big_category_id,
+            medium_category_id,
+            custom_category_id,
+            regular_shopping_list_id,
+            payment_user_id,
+            claimed_user_id,
+            claimed_date,
+            claim_version,
+            transaction_auto_add,
+            transaction_id
+        FROM
+            group_shopping_list
+        WHERE
+            group_id = ?
+        AND
+            complete_flag = false
+        AND
+            expected_purchase_date <= ?
+        ORDER BY
+            expected_purchase_date`
+
+	expiredGroupShoppingList := model.ExpiredGroupShoppingList{
+		ExpiredGroupShoppingList: make([]model.GroupShoppingItem, 0),
+	}
+
+	rows, err := r.MySQLHandler.conn.QueryxContext(ctx, query, groupID, dueDate)
+	if err != nil {
+		return expiredGroupShoppingList, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var expiredGroupShoppingItem model.GroupShoppingItem
+		if err := rows.StructScan(&expiredGroupShoppingItem); err != nil {
+			return expiredGroupShoppingList, err
+		}
+
+		expiredGroupShoppingList.ExpiredGroupShoppingList = append(expiredGroupShoppingList.ExpiredGroupShoppingList, expiredGroupShoppingItem)
+	}
+
+	if err := rows.Err(); err != nil {
+		return expiredGroupShoppingList, err
+	}
+
+	return expiredGroupShoppingList, nil
+}
+
+func (r *GroupShoppingListRepository) GetGroupShoppingItem(groupShoppingItemID int) (model.GroupShoppingItem, error) {
+	query := `
+        SELECT
+            id,
+            posted_date,
+            updated_date,
+            expected_purchase_date,
+            complete_flag,
+            purchase,
+            shop,
+            amount,
+            quantity,
+            unit,
+            unit_price,
+            big_category_id,
+            medium_category_id,
+            custom_category_id,
+            regular_shopping_list_id,
+            payment_user_id,
+            claimed_user_id,
+            claimed_date,
+            claim_version,
+            transaction_auto_add,
+            transaction_id
+        FROM
+            group_shopping_list
+        WHERE
+            id = ?`
+
+	var groupShoppingItem model.GroupShoppingItem
+	if err := r.MySQLHandler.conn.QueryRowx(query, groupShoppingItemID).StructScan(&groupShoppingItem); err != nil {
+		return groupShoppingItem, err
+	}
+
+	return groupShoppingItem, nil
+}
+
+func (r *GroupShoppingListRepository) GetGroupShoppingItemPriceList(groupID int) ([]model.GroupShoppingItemPrice, error) {
+	query := `
+        SELECT
+            id,
+            expected_purchase_date,
+            purchase,
+            shop,
+            amount,
+            payment_user_id,
+            quantity,
+            transaction_id
+        FROM
+            group_shopping_list
+        WHERE
+            group_id = ?
+        AND
+            complete_flag = true
+        AND
+            (amount IS NOT NULL OR transaction_id IS NOT NULL)
+        ORDER BY
+            expected_purchase_date DESC, id DESC`
+
+	groupShoppingItemPriceList := make([]model.GroupShoppingItemPrice, 0)
+
+	rows, err := r.MySQLHandler.conn.Queryx(query, groupID)
+	if err != nil {
+		return groupShoppingItemPriceList, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var groupShoppingItemPrice model.GroupShoppingItemPrice
+		if err := rows.StructScan(&groupShoppingItemPrice); err != nil {
+			return groupShoppingItemPriceList, err
+		}
+
+		groupShoppingItemPriceList = append(groupShoppingItemPriceList, groupShoppingItemPrice)
+	}
+
+	if err := rows.Err(); err != nil {
+		return groupShoppingItemPriceList, err
+	}
+
+	return groupShoppingItemPriceList, nil
+}
+
+// GetGroupShoppingItemPriceListByPurchase gets the latest prices of the purchase in the same way as GetShoppingItemPriceListByPurchase.
+func (r *GroupShoppingListRepository) GetGroupShoppingItemPriceListByPurchase(groupID int, normalizedPurchase string, limit int) ([]model.GroupShoppingItemPrice, error) {
+	query := `
+        SELECT
+            id,
+            expected_purchase_date,
+            purchase,
+            shop,
+            amount,
+            payment_user_id,
+            quantity,
+            transaction_id
+        FROM
+            group_shopping_list
+        WHERE
+            group_id = ?
+        AND
+            complete_flag = true
+        AND
+            (amount IS NOT NULL OR transaction_id IS NOT NULL)
+        AND
+            REGEXP_REPLACE(REGEXP_REPLACE(purchase, '^[[:space:]]+|[[:space:]]+$', ''), '[[:space:]]+', ' ') COLLATE utf8mb4_0900_ai_ci = ?
+        ORDER BY
+            expected_purchase_date DESC, id DESC
+        LIMIT ?`
+
+	groupShoppingItemPriceList := make([]model.GroupShoppingItemPrice, 0)
+
+	rows, err := r.MySQLHandler.conn.Queryx(query, groupID, normalizedPurchase, limit)
+	if err != nil {
+		return groupShoppingItemPriceList, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var groupShoppingItemPrice model.GroupShoppingItemPrice
+		if err := rows.StructScan(&groupShoppingItemPrice); err != nil {
+			return groupShoppingItemPriceList, err
+		}
+
+		groupShoppingItemPriceList = append(groupShoppingItemPriceList, groupShoppingItemPrice)
+	}
+
+	if err := rows.Err(); err != nil {
+		return groupShoppingItemPriceList, err
+	}
+
+	return groupShoppingItemPriceList, nil
+}
+
+func (r *GroupShoppingListRepository) PostGroupShoppingItem(groupShoppingItem *model.GroupShoppingItem, groupID int) (sql.Result, error) {
+	query := `
+        INSERT INTO group_shopping_list
+        (
+            expected_purchase_date,
+            purchase,
+            shop,
+            amount,
+            quantity,
+            unit,
+            unit_price,
+            big_category_id,
+            medium_category_id,
+            custom_category_id,
+            payment_user_id,
+            group_id,
+            transaction_auto_add
+        )
+        VALUES
+            (?,?,?,?,?,?,?,?,?,?,?,?,?)`
+
+	result, err := r.MySQLHandler.conn.Exec(
+		query,
 		groupShoppingItem.ExpectedPurchaseDate,
 		groupShoppingItem.Purchase,
 		groupShoppingItem.Shop,
 		groupShoppingItem.Amount,
+		groupShoppingItem.Quantity,
+		groupShoppingItem.Unit,
+		groupShoppingItem.UnitPrice,
 		groupShoppingItem.BigCategoryID,
 		groupShoppingItem.MediumCategoryID,
 		groupShoppingItem.CustomCategoryID,
@@ -975,6 +1788,9 @@ func (r *GroupShoppingListRepository) PutGroupShoppingItem(groupShoppingItem *mo
             purchase = ?,
             shop = ?,
             amount = ?,
+            quantity = ?,
+            unit = ?,
+            unit_price = ?,
             big_category_id = ?,
             medium_category_id = ?,
             custom_category_id = ?,
@@ -999,6 +1815,9 @@ func (r *GroupShoppingListRepository) PutGroupShoppingItem(groupShoppingItem *mo
 		groupShoppingItem.Purchase,
 		groupShoppingItem.Shop,
 		groupShoppingItem.Amount,
+		groupShoppingItem.Quantity,
+		groupShoppingItem.Unit,
+		groupShoppingItem.UnitPrice,
 		groupShoppingItem.BigCategoryID,
 		groupShoppingItem.MediumCategoryID,
 		groupShoppingItem.CustomCategoryID,
@@ -1011,6 +1830,200 @@ func (r *GroupShoppingListRepository) PutGroupShoppingItem(groupShoppingItem *mo
 	return result, err
 }
 
+func (r *GroupShoppingListRepository) PutGroupShoppingItemWithTransactionCommand(groupShoppingItem *model.GroupShoppingItem, transactionCommand *model.TransactionCommand) (sql.Result, error) {
+	query := `
+        UPDATE
+            group_shopping_list
+        SET 
+            expected_purchase_date = ?,
+            complete_flag = ?,
+            purchase = ?,
+            shop = ?,
+            amount = ?,
+            quantity = ?,
+            unit = ?,
+            unit_price = ?,
+            big_category_id = ?,
+            medium_category_id = ?,
+            custom_category_id = ?,
+            payment_user_id = ?,
+            transaction_auto_add = ?,
+            transaction_id = ?
+        WHERE
+            id = ?`
+
+	relatedTransactionID := func(relatedTransactionData *model.GroupTransactionData) *int64 {
+		if relatedTransactionData != nil {
+			return &relatedTransactionData.ID.Int64
+		}
+
+		return nil
+	}
+
+	tx, err := r.MySQLHandler.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	var transactionCommandResult sql.Result
+	transactions := func(tx *sql.Tx) error {
+		if _, err := tx.Exec(
+			query,
+			groupShoppingItem.ExpectedPurchaseDate,
+			groupShoppingItem.CompleteFlag,
+			groupShoppingItem.Purchase,
+			groupShoppingItem.Shop,
+			groupShoppingItem.Amount,
+			groupShoppingItem.Quantity,
+			groupShoppingItem.Unit,
+			groupShoppingItem.UnitPrice,
+			groupShoppingItem.BigCategoryID,
+			groupShoppingItem.MediumCategoryID,
+			groupShoppingItem.CustomCategoryID,
+			groupShoppingItem.PaymentUserID,
+			groupShoppingItem.TransactionAutoAdd,
+			relatedTransactionID(groupShoppingItem.RelatedTransactionData),
+			groupShoppingItem.ID,
+		); err != nil {
+			return err
+		}
+
+		transactionCommandResult, err = postTransactionCommand(tx, transactionCommand)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+
+	if err := transactions(tx); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return nil, err
+		}
+
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return transactionCommandResult, nil
+}
+
+func (r *GroupShoppingListRepository) ClaimGroupShoppingItem(groupShoppingItemClaim *model.GroupShoppingItemClaim, groupShoppingItemID int, groupID int, claimedDate time.Time) (sql.Result, error) {
+	query := `
+        UPDATE
+            group_shopping_list
+        SET 
+            claimed_user_id = ?,
+            claimed_date = ?,
+            claim_version = claim_version + 1
+        WHERE
+            id = ?
+        AND
+            group_id = ?
+        AND
+            claim_version = ?
+        AND
+            (claimed_user_id IS NULL OR claimed_user_id = ?)`
+
+	result, err := r.MySQLHandler.conn.Exec(
+		query,
+		groupShoppingItemClaim.ClaimedUserID,
+		claimedDate,
+		groupShoppingItemID,
+		groupID,
+		groupShoppingItemClaim.ClaimVersion,
+		groupShoppingItemClaim.ClaimedUserID,
+	)
+
+	return result, err
+}
+
+func (r *GroupShoppingListRepository) UnclaimGroupShoppingItem(claimVersion int, claimedUserID string, groupShoppingItemID int, groupID int) (sql.Result, error) {
+	query := `
+        UPDATE
+            group_shopping_list
+        SET 
+            claimed_user_id = NULL,
+            claimed_date = NULL,
+            claim_version = claim_version + 1
+        WHERE
+            id = ?
+        AND
+            group_id = ?
+        AND
+            claim_version = ?
+        AND
+            claimed_user_id = ?`
+
+	result, err := r.MySQLHandler.conn.Exec(query, groupShoppingItemID, groupID, claimVersion, claimedUserID)
+
+	return result, err
+}
+
+func (r *GroupShoppingListRepository) GetClaimedGroupShoppingList(groupID int) (model.GroupShoppingList, error) {
+	query := `
+        SELECT
+            id,
+            posted_date,
+            updated_date,
+            expected_purchase_date,
+            complete_flag,
+            purchase,
+            shop,
+            amount,
+            quantity,
+            unit,
+            unit_price,
+            big_category_id,
+            medium_category_id,
+            custom_category_id,
+            regular_shopping_list_id,
+            payment_user_id,
+            claimed_user_id,
+            claimed_date,
+            claim_version,
+            transaction_auto_add,
+            transaction_id
+        FROM
+            group_shopping_list
+        WHERE
+            group_id = ?
+        AND
+            complete_flag = false
+        AND
+            claimed_user_id IS NOT NULL
+        ORDER BY
+            claimed_user_id, expected_purchase_date, id`
+
+	groupShoppingList := model.GroupShoppingList{
+		GroupShoppingList: make([]model.GroupShoppingItem, 0),
+	}
+
+	rows, err := r.MySQLHandler.conn.Queryx(query, groupID)
+	if err != nil {
+		return groupShoppingList, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var groupShoppingItem model.GroupShoppingItem
+		if err := rows.StructScan(&groupShoppingItem); err != nil {
+			return groupShoppingList, err
+		}
+
+		groupShoppingList.GroupShoppingList = append(groupShoppingList.GroupShoppingList, groupShoppingItem)
+	}
+
+	if err := rows.Err(); err != nil {
+		return groupShoppingList, err
+	}
+
+	return groupShoppingList, nil
+}
+
 func (r *GroupShoppingListRepository) DeleteGroupShoppingItem(groupShoppingItemID int) error {
 	query := `
         DELETE
@@ -1082,3 +2095,119 @@ func (r *GroupShoppingListRepository) PutGroupShoppingListCustomCategoryIdToMedi
 
 	return nil
 }
+
+func (r *GroupShoppingListRepository) PutGroupShoppingListCustomCategoryIdToCustomCategoryId(replaceCustomCategoryID int, customCategoryID int) error {
+	updateGroupShoppingListQuery := `
+        UPDATE
+            group_shopping_list
+        SET 
+            custom_category_id = ?
+        WHERE
+            custom_category_id = ?`
+
+	updateGroupRegularShoppingListQuery := `
+        UPDATE
+            group_regular_shopping_list
+        SET 
+            custom_category_id = ?
+        WHERE
+            custom_category_id = ?`
+
+	tx, err := r.MySQLHandler.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	transactions := func(tx *sql.Tx) error {
+		if _, err := tx.Exec(
+			updateGroupShoppingListQuery,
+			replaceCustomCategoryID,
+			customCategoryID,
+		); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(
+			updateGroupRegularShoppingListQuery,
+			replaceCustomCategoryID,
+			customCategoryID,
+		); err != nil {
+			return err
+		}
+
+		return nil
+	}
+
+	if err := transactions(tx); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return err
+		}
+
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *GroupShoppingListRepository) GetTransactionRelatedGroupShoppingList(groupID int) (model.GroupShoppingList, error) {
+	query := `
+        SELECT
+            id,
+            posted_date,
+            updated_date,
+            expected_purchase_date,
+            complete_flag,
+            purchase,
+            shop,
+            amount,
+            quantity,
+            unit,
+            unit_price,
+            big_category_id,
+            medium_category_id,
+            custom_category_id,
+            regular_shopping_list_id,
+            payment_user_id,
+            claimed_user_id,
+            claimed_date,
+            claim_version,
+            transaction_auto_add,
+            transaction_id
+        FROM
+            group_shopping_list
+        WHERE
+            group_id = ?
+        AND
+            ((complete_flag = true AND transaction_auto_add = true) OR transaction_id IS NOT NULL)
+        ORDER BY
+            id`
+
+	groupShoppingList := model.GroupShoppingList{
+		GroupShoppingList: make([]model.GroupShoppingItem, 0),
+	}
+
+	rows, err := r.MySQLHandler.conn.Queryx(query, groupID)
+	if err != nil {
+		return groupShoppingList, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var groupShoppingItem model.GroupShoppingItem
+		if err := rows.StructScan(&groupShoppingItem); err != nil {
+			return groupShoppingList, err
+		}
+
+		groupShoppingList.GroupShoppingList = append(groupShoppingList.GroupShoppingList, groupShoppingItem)
+	}
+
+	if err := rows.Err(); err != nil {
+		return groupShoppingList, err
+	}
+
+	return groupShoppingList, nil
+}
@@ -1,6 +1,7 @@
 package infrastructure
 
 import (
+	"context"
 	"database/sql"
 	"strings"
 
@@ -15,7 +16,7 @@ func NewGroupTasksRepository(mysqlHandler *MySQLHandler) *GroupTasksRepository {
 	return &GroupTasksRepository{mysqlHandler}
 }
 
-func (r *GroupTasksRepository) GetGroupTasksUsersList(groupID int) ([]model.GroupTasksUser, error) {
+func (r *GroupTasksRepository) GetGroupTasksUsersList(ctx context.Context, groupID int) ([]model.GroupTasksUser, error) {
 	query := `
         SELECT
             id,
@@ -28,7 +29,7 @@ func (r *GroupTasksRepository) GetGroupTasksUsersList(groupID int) ([]model.Grou
         ORDER BY
             id`
 
-	rows, err := r.MySQLHandler.conn.Queryx(query, groupID)
+	rows, err := r.MySQLHandler.conn.QueryxContext(ctx, query, groupID)
 	if err != nil {
 		return nil, err
 	}
@@ -51,7 +52,7 @@ func (r *GroupTasksRepository) GetGroupTasksUsersList(groupID int) ([]model.Grou
 	return groupTasksUsersList, nil
 }
 
-func (r *GroupTasksRepository) GetGroupTasksListAssignedToUser(groupID int) ([]model.GroupTask, error) {
+func (r *GroupTasksRepository) GetGroupTasksListAssignedToUser(ctx context.Context, groupID int) ([]model.GroupTask, error) {
 	query := `
         SELECT
             id,
@@ -70,7 +71,7 @@ func (r *GroupTasksRepository) GetGroupTasksListAssignedToUser(groupID int) ([]m
         ORDER BY
             id`
 
-	rows, err := r.MySQLHandler.conn.Queryx(query, groupID)
+	rows, err := r.MySQLHandler.conn.QueryxContext(ctx, query, groupID)
 	if err != nil {
 		return nil, err
 	}
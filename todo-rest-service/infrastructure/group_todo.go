@@ -1,9 +1,12 @@
 package infrastructure
 
 import (
+	"context"
 	"database/sql"
 	"time"
 
+	"github.com/jmoiron/sqlx"
+
 	"github.com/hryze/kakeibo-app-api/todo-rest-service/domain/model"
 )
 
@@ -15,7 +18,7 @@ func NewGroupTodoRepository(mysqlHandler *MySQLHandler) *GroupTodoRepository {
 	return &GroupTodoRepository{mysqlHandler}
 }
 
-func (r *GroupTodoRepository) GetDailyImplementationGroupTodoList(date time.Time, groupID int) ([]model.GroupTodo, error) {
+func (r *GroupTodoRepository) GetDailyImplementationGroupTodoList(ctx context.Context, date time.Time, groupID int) ([]model.GroupTodo, error) {
 	query := `
         SELECT
             id,
@@ -25,7 +28,15 @@ func (r *GroupTodoRepository) GetDailyImplementationGroupTodoList(date time.Time
             due_date,
             todo_content,
             complete_flag,
-            user_id
+            user_id,
+            recurrence_type,
+            recurrence_rule,
+            recurrence_interval,
+            recurrence_start_date,
+            series_id,
+            priority,
+            completed_user_id,
+            completed_date
         FROM
             group_todo_list
         WHERE
@@ -33,7 +44,7 @@ func (r *GroupTodoRepository) GetDailyImplementationGroupTodoList(date time.Time
         AND
             implementation_date = ?`
 
-	rows, err := r.MySQLHandler.conn.Queryx(query, groupID, date)
+	rows, err := r.MySQLHandler.conn.QueryxContext(ctx, query, groupID, date)
 	if err != nil {
 		return nil, err
 	}
@@ -53,10 +64,14 @@ func (r *GroupTodoRepository) GetDailyImplementationGroupTodoList(date time.Time
 		return nil, err
 	}
 
+	if err := r.setGroupTodoListDetails(ctx, implementationGroupTodoList); err != nil {
+		return nil, err
+	}
+
 	return implementationGroupTodoList, nil
 }
 
-func (r *GroupTodoRepository) GetDailyDueGroupTodoList(date time.Time, groupID int) ([]model.GroupTodo, error) {
+func (r *GroupTodoRepository) GetDailyDueGroupTodoList(ctx context.Context, date time.Time, groupID int) ([]model.GroupTodo, error) {
 	query := `
         SELECT
             id,
@@ -66,7 +81,15 @@ func (r *GroupTodoRepository) GetDailyDueGroupTodoList(date time.Time, groupID i
             due_date,
             todo_content,
             complete_flag,
-            user_id
+            user_id,
+            recurrence_type,
+            recurrence_rule,
+            recurrence_interval,
+            recurrence_start_date,
+            series_id,
+            priority,
+            completed_user_id,
+            completed_date
         FROM
             group_todo_list
         WHERE
@@ -74,7 +97,7 @@ func (r *GroupTodoRepository) GetDailyDueGroupTodoList(date time.Time, groupID i
         AND
             due_date = ?`
 
-	rows, err := r.MySQLHandler.conn.Queryx(query, groupID, date)
+	rows, err := r.MySQLHandler.conn.QueryxContext(ctx, query, groupID, date)
 	if err != nil {
 		return nil, err
 	}
@@ -94,6 +117,10 @@ func (r *GroupTodoRepository) GetDailyDueGroupTodoList(date time.Time, groupID i
 		return nil, err
 	}
 
+	if err := r.setGroupTodoListDetails(ctx, dueGroupTodoList); err != nil {
+		return nil, err
+	}
+
 	return dueGroupTodoList, nil
 }
 
@@ -107,7 +134,15 @@ func (r *GroupTodoRepository) GetMonthlyImplementationGroupTodoList(firstDay tim
             due_date,
             todo_content,
             complete_flag,
-            user_id
+            user_id,
+            recurrence_type,
+            recurrence_rule,
+            recurrence_interval,
+            recurrence_start_date,
+            series_id,
+            priority,
+            completed_user_id,
+            completed_date
         FROM
             group_todo_list
         WHERE
@@ -139,6 +174,10 @@ func (r *GroupTodoRepository) GetMonthlyImplementationGroupTodoList(firstDay tim
 		return nil, err
 	}
 
+	if err := r.setGroupTodoListDetails(context.Background(), implementationGroupTodoList); err != nil {
+		return nil, err
+	}
+
 	return implementationGroupTodoList, nil
 }
 
@@ -152,7 +191,15 @@ func (r *GroupTodoRepository) GetMonthlyDueGroupTodoList(firstDay time.Time, las
             due_date,
             todo_content,
             complete_flag,
-            user_id
+            user_id,
+            recurrence_type,
+            recurrence_rule,
+            recurrence_interval,
+            recurrence_start_date,
+            series_id,
+            priority,
+            completed_user_id,
+            completed_date
         FROM
             group_todo_list
         WHERE
@@ -184,10 +231,14 @@ func (r *GroupTodoRepository) GetMonthlyDueGroupTodoList(firstDay time.Time, las
 		return nil, err
 	}
 
+	if err := r.setGroupTodoListDetails(context.Background(), dueGroupTodoList); err != nil {
+		return nil, err
+	}
+
 	return dueGroupTodoList, nil
 }
 
-func (r *GroupTodoRepository) GetExpiredGroupTodoList(dueDate time.Time, groupID int) (*model.ExpiredGroupTodoList, error) {
+func (r *GroupTodoRepository) GetExpiredGroupTodoList(ctx context.Context, dueDate time.Time, groupID int) (*model.ExpiredGroupTodoList, error) {
 	query := `
         SELECT
             id,
@@ -197,7 +248,139 @@ func (r *GroupTodoRepository) GetExpiredGroupTodoList(dueDate time.Time, groupID
             due_date,
             todo_content,
             complete_flag,
-            user_id
+            user_id,
+            recurrence_type,
+            recurrence_rule,
+            recurrence_interval,
+            recurrence_start_date,
+            series_id,
+            priority,
+            completed_user_id,
+            completed_date
+        FROM
+            group_todo_list
+        WHERE
+            group_id = ?
+        AND
+            complete_flag = b'0'
+        AND
+            due_date <= ?
+        ORDER BY
+            due_date`
+
+	rows, err := r.MySQLHandler.conn.QueryxContext(ctx, query, groupID, dueDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	expiredGroupTodoList := model.ExpiredGroupTodoList{
+		ExpiredGroupTodoList: make([]model.GroupTodo, 0),
+	}
+	for rows.Next() {
+		var expiredGroupTodo model.GroupTodo
+		if err := rows.StructScan(&expiredGroupTodo); err != nil {
+			return nil, err
+		}
+
+		expiredGroupTodoList.ExpiredGroupTodoList = append(expiredGroupTodoList.ExpiredGroupTodoList, expiredGroupTodo)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.setGroupTodoListDetails(ctx, expiredGroupTodoList.ExpiredGroupTodoList); err != nil {
+		return nil, err
+	}
+
+	return &expiredGroupTodoList, nil
+}
+
+func (r *GroupTodoRepository) GetAssignedGroupTodoList(userID string) ([]model.GroupTodo, error) {
+	query := `
+        SELECT
+            id,
+            posted_date,
+            updated_date,
+            implementation_date,
+            due_date,
+            todo_content,
+            complete_flag,
+            user_id,
+            recurrence_type,
+            recurrence_rule,
+            recurrence_interval,
+            recurrence_start_date,
+            series_id,
+            priority,
+            completed_user_id,
+            completed_date,
+            group_id
+        FROM
+            group_todo_list
+        WHERE
+            complete_flag = b'0'
+        AND
+            EXISTS (
+                SELECT
+                    id
+                FROM
+                    group_todo_assignees
+                WHERE
+                    group_todo_assignees.todo_id = group_todo_list.id
+                AND
+                    group_todo_assignees.user_id = ?
+            )
+        ORDER BY
+            group_id, due_date, implementation_date`
+
+	rows, err := r.MySQLHandler.conn.Queryx(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	assignedGroupTodoList := make([]model.GroupTodo, 0)
+	for rows.Next() {
+		var assignedGroupTodo model.GroupTodo
+		if err := rows.StructScan(&assignedGroupTodo); err != nil {
+			return nil, err
+		}
+
+		assignedGroupTodoList = append(assignedGroupTodoList, assignedGroupTodo)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.setGroupTodoListDetails(context.Background(), assignedGroupTodoList); err != nil {
+		return nil, err
+	}
+
+	return assignedGroupTodoList, nil
+}
+
+func (r *GroupTodoRepository) GetExpiredAssignedGroupTodoList(dueDate time.Time, groupID int, userID string) (*model.ExpiredGroupTodoList, error) {
+	query := `
+        SELECT
+            id,
+            posted_date,
+            updated_date,
+            implementation_date,
+            due_date,
+            todo_content,
+            complete_flag,
+            user_id,
+            recurrence_type,
+            recurrence_rule,
+            recurrence_interval,
+            recurrence_start_date,
+            series_id,
+            priority,
+            completed_user_id,
+            completed_date
         FROM
             group_todo_list
         WHERE
@@ -206,10 +389,21 @@ func (r *GroupTodoRepository) GetExpiredGroupTodoList(dueDate time.Time, groupID
             complete_flag = b'0'
         AND
             due_date <= ?
+        AND
+            EXISTS (
+                SELECT
+                    id
+                FROM
+                    group_todo_assignees
+                WHERE
+                    group_todo_assignees.todo_id = group_todo_list.id
+                AND
+                    group_todo_assignees.user_id = ?
+            )
         ORDER BY
             due_date`
 
-	rows, err := r.MySQLHandler.conn.Queryx(query, groupID, dueDate)
+	rows, err := r.MySQLHandler.conn.Queryx(query, groupID, dueDate, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -231,6 +425,10 @@ func (r *GroupTodoRepository) GetExpiredGroupTodoList(dueDate time.Time, groupID
 		return nil, err
 	}
 
+	if err := r.setGroupTodoListDetails(context.Background(), expiredGroupTodoList.ExpiredGroupTodoList); err != nil {
+		return nil, err
+	}
+
 	return &expiredGroupTodoList, nil
 }
 
@@ -244,7 +442,15 @@ func (r *GroupTodoRepository) GetGroupTodo(groupTodoId int) (*model.GroupTodo, e
             due_date,
             todo_content,
             complete_flag,
-            user_id
+            user_id,
+            recurrence_type,
+            recurrence_rule,
+            recurrence_interval,
+            recurrence_start_date,
+            series_id,
+            priority,
+            completed_user_id,
+            completed_date
         FROM
             group_todo_list
         WHERE
@@ -255,36 +461,211 @@ func (r *GroupTodoRepository) GetGroupTodo(groupTodoId int) (*model.GroupTodo, e
 		return nil, err
 	}
 
-	return &groupTodo, nil
+	groupTodoList := []model.GroupTodo{groupTodo}
+	if err := r.setGroupTodoListDetails(context.Background(), groupTodoList); err != nil {
+		return nil, err
+	}
+
+	return &groupTodoList[0], nil
 }
 
 func (r *GroupTodoRepository) PostGroupTodo(groupTodo *model.GroupTodo, userID string, groupID int) (sql.Result, error) {
 	query := `
         INSERT INTO group_todo_list
-            (implementation_date, due_date, todo_content, user_id, group_id)
+            (implementation_date, due_date, todo_content, recurrence_type, recurrence_rule, recurrence_interval, recurrence_start_date, priority, user_id, group_id)
         VALUES
-            (?,?,?,?,?)`
+            (?,?,?,?,?,?,?,?,?,?)`
 
-	result, err := r.MySQLHandler.conn.Exec(query, groupTodo.ImplementationDate, groupTodo.DueDate, groupTodo.TodoContent, userID, groupID)
+	updateSeriesIDQuery := `
+        UPDATE
+            group_todo_list
+        SET
+            series_id = id
+        WHERE
+            id = ?`
+
+	tx, err := r.MySQLHandler.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := func(tx *sql.Tx) (sql.Result, error) {
+		result, err := tx.Exec(query, groupTodo.ImplementationDate, groupTodo.DueDate, groupTodo.TodoContent, groupTodo.RecurrenceType, groupTodo.RecurrenceRule, groupTodo.RecurrenceInterval, groupTodo.RecurrenceStartDate, groupTodo.Priority, userID, groupID)
+		if err != nil {
+			return nil, err
+		}
+
+		lastInsertId, err := result.LastInsertId()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := insertGroupTodoDetails(tx, int(lastInsertId), groupTodo.LabelList, groupTodo.SubtaskList, groupTodo.AssigneeList); err != nil {
+			return nil, err
+		}
+
+		if !groupTodo.RecurrenceType.Valid {
+			return result, nil
+		}
+
+		if _, err := tx.Exec(updateSeriesIDQuery, lastInsertId); err != nil {
+			return nil, err
+		}
+
+		return result, nil
+	}
+
+	result, err := transactions(tx)
+	if err != nil {
+		if err := tx.Rollback(); err != nil {
+			return nil, err
+		}
 
-	return result, err
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
 }
 
-func (r *GroupTodoRepository) PutGroupTodo(groupTodo *model.GroupTodo, groupTodoID int) error {
-	query := `
+func (r *GroupTodoRepository) PutGroupTodo(groupTodo *model.GroupTodo, groupTodoID int, editScope string, nextGroupTodo *model.GroupTodo) error {
+	lockGroupTodoQuery := `
+        SELECT
+            complete_flag
+        FROM
+            group_todo_list
+        WHERE
+            id = ?
+        FOR UPDATE`
+
+	updateFollowingGroupTodoListQuery := `
+        UPDATE
+            group_todo_list
+        SET
+            todo_content = ?,
+            recurrence_type = ?,
+            recurrence_rule = ?,
+            recurrence_interval = ?,
+            recurrence_start_date = ?,
+            priority = ?
+        WHERE
+            series_id = ?
+        AND
+            id > ?
+        AND
+            complete_flag = b'0'`
+
+	updateGroupTodoQuery := `
         UPDATE
             group_todo_list
         SET 
             implementation_date = ?,
             due_date = ?,
             todo_content = ?,
-            complete_flag = ?
+            complete_flag = ?,
+            recurrence_type = ?,
+            recurrence_rule = ?,
+            recurrence_interval = ?,
+            recurrence_start_date = ?,
+            series_id = ?,
+            priority = ?,
+            completed_user_id = ?,
+            completed_date = ?
         WHERE
             id = ?`
 
-	_, err := r.MySQLHandler.conn.Exec(query, groupTodo.ImplementationDate, groupTodo.DueDate, groupTodo.TodoContent, groupTodo.CompleteFlag, groupTodoID)
+	existsNextGroupTodoQuery := `
+        SELECT
+            EXISTS (
+                SELECT
+                    id
+                FROM
+                    group_todo_list
+                WHERE
+                    series_id = ?
+                AND
+                    id > ?
+            )`
+
+	insertNextGroupTodoQuery := `
+        INSERT INTO group_todo_list
+            (implementation_date, due_date, todo_content, recurrence_type, recurrence_rule, recurrence_interval, recurrence_start_date, series_id, priority, user_id, group_id)
+        SELECT
+            ?,?,?,?,?,?,?,?,?,user_id,group_id
+        FROM
+            group_todo_list
+        WHERE
+            id = ?`
 
-	return err
+	tx, err := r.MySQLHandler.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	transactions := func(tx *sql.Tx) error {
+		var completeFlag model.BitBool
+		if err := tx.QueryRow(lockGroupTodoQuery, groupTodoID).Scan(&completeFlag); err != nil {
+			return err
+		}
+
+		if editScope == model.TodoEditScopeFollowing && groupTodo.SeriesID.Valid {
+			if _, err := tx.Exec(updateFollowingGroupTodoListQuery, groupTodo.TodoContent, groupTodo.RecurrenceType, groupTodo.RecurrenceRule, groupTodo.RecurrenceInterval, groupTodo.RecurrenceStartDate, groupTodo.Priority, groupTodo.SeriesID, groupTodoID); err != nil {
+				return err
+			}
+		}
+
+		if _, err := tx.Exec(updateGroupTodoQuery, groupTodo.ImplementationDate, groupTodo.DueDate, groupTodo.TodoContent, groupTodo.CompleteFlag, groupTodo.RecurrenceType, groupTodo.RecurrenceRule, groupTodo.RecurrenceInterval, groupTodo.RecurrenceStartDate, groupTodo.SeriesID, groupTodo.Priority, groupTodo.CompletedUserID, groupTodo.CompletedDate, groupTodoID); err != nil {
+			return err
+		}
+
+		if err := replaceGroupTodoDetails(tx, groupTodoID, groupTodo.LabelList, groupTodo.SubtaskList, groupTodo.AssigneeList); err != nil {
+			return err
+		}
+
+		// The next instance is spawned only once per instance, even when the instance is completed again after being reopened,
+		// or completed by concurrent requests.
+		if nextGroupTodo == nil || completeFlag {
+			return nil
+		}
+
+		var existsNextGroupTodo bool
+		if err := tx.QueryRow(existsNextGroupTodoQuery, nextGroupTodo.SeriesID, groupTodoID).Scan(&existsNextGroupTodo); err != nil {
+			return err
+		}
+
+		if existsNextGroupTodo {
+			return nil
+		}
+
+		result, err := tx.Exec(insertNextGroupTodoQuery, nextGroupTodo.ImplementationDate, nextGroupTodo.DueDate, nextGroupTodo.TodoContent, nextGroupTodo.RecurrenceType, nextGroupTodo.RecurrenceRule, nextGroupTodo.RecurrenceInterval, nextGroupTodo.RecurrenceStartDate, nextGroupTodo.SeriesID, nextGroupTodo.Priority, groupTodoID)
+		if err != nil {
+			return err
+		}
+
+		nextGroupTodoID, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+
+		return insertGroupTodoDetails(tx, int(nextGroupTodoID), nextGroupTodo.LabelList, nextGroupTodo.SubtaskList, nextGroupTodo.AssigneeList)
+	}
+
+	if err := transactions(tx); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return err
+		}
+
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
 }
 
 func (r *GroupTodoRepository) DeleteGroupTodo(groupTodoID int) error {
@@ -300,8 +681,10 @@ func (r *GroupTodoRepository) DeleteGroupTodo(groupTodoID int) error {
 	return err
 }
 
-func (r *GroupTodoRepository) SearchGroupTodoList(groupTodoSqlQuery string) ([]model.GroupTodo, error) {
-	rows, err := r.MySQLHandler.conn.Queryx(groupTodoSqlQuery)
+func (r *GroupTodoRepository) SearchGroupTodoList(groupTodoSearchCriteria model.GroupTodoSearchCriteria, groupID int) ([]model.GroupTodo, error) {
+	query, args := generateGroupTodoSearchQuery(groupTodoSearchCriteria, groupID)
+
+	rows, err := r.MySQLHandler.conn.Queryx(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -321,5 +704,218 @@ func (r *GroupTodoRepository) SearchGroupTodoList(groupTodoSqlQuery string) ([]m
 		return nil, err
 	}
 
+	if err := r.setGroupTodoListDetails(context.Background(), searchGroupTodoList); err != nil {
+		return nil, err
+	}
+
 	return searchGroupTodoList, nil
 }
+
+// setGroupTodoListDetails sets the labels, the subtasks and the assignees to each group todo of groupTodoList.
+func (r *GroupTodoRepository) setGroupTodoListDetails(ctx context.Context, groupTodoList []model.GroupTodo) error {
+	if len(groupTodoList) == 0 {
+		return nil
+	}
+
+	labelListQuery := `
+        SELECT
+            todo_id,
+            label_name
+        FROM
+            group_todo_labels
+        WHERE
+            todo_id IN (?)
+        ORDER BY
+            id`
+
+	subtaskListQuery := `
+        SELECT
+            id,
+            todo_id,
+            subtask_content,
+            complete_flag
+        FROM
+            group_todo_subtasks
+        WHERE
+            todo_id IN (?)
+        ORDER BY
+            subtask_order`
+
+	assigneeListQuery := `
+        SELECT
+            todo_id,
+            user_id
+        FROM
+            group_todo_assignees
+        WHERE
+            todo_id IN (?)
+        ORDER BY
+            id`
+
+	groupTodoIndexes := make(map[int]int, len(groupTodoList))
+	groupTodoIDList := make([]int, 0, len(groupTodoList))
+	for i := range groupTodoList {
+		groupTodoList[i].LabelList = make([]string, 0)
+		groupTodoList[i].SubtaskList = make([]model.TodoSubtask, 0)
+		groupTodoList[i].AssigneeList = make([]string, 0)
+		groupTodoIndexes[groupTodoList[i].ID] = i
+		groupTodoIDList = append(groupTodoIDList, groupTodoList[i].ID)
+	}
+
+	query, args, err := sqlx.In(labelListQuery, groupTodoIDList)
+	if err != nil {
+		return err
+	}
+
+	labelRows, err := r.MySQLHandler.conn.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer labelRows.Close()
+
+	for labelRows.Next() {
+		var groupTodoID int
+		var labelName string
+		if err := labelRows.Scan(&groupTodoID, &labelName); err != nil {
+			return err
+		}
+
+		groupTodo := &groupTodoList[groupTodoIndexes[groupTodoID]]
+		groupTodo.LabelList = append(groupTodo.LabelList, labelName)
+	}
+
+	if err := labelRows.Err(); err != nil {
+		return err
+	}
+
+	query, args, err = sqlx.In(subtaskListQuery, groupTodoIDList)
+	if err != nil {
+		return err
+	}
+
+	subtaskRows, err := r.MySQLHandler.conn.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer subtaskRows.Close()
+
+	for subtaskRows.Next() {
+		var groupTodoID int
+		var subtask model.TodoSubtask
+		if err := subtaskRows.Scan(&subtask.ID, &groupTodoID, &subtask.SubtaskContent, &subtask.CompleteFlag); err != nil {
+			return err
+		}
+
+		groupTodo := &groupTodoList[groupTodoIndexes[groupTodoID]]
+		groupTodo.SubtaskList = append(groupTodo.SubtaskList, subtask)
+	}
+
+	if err := subtaskRows.Err(); err != nil {
+		return err
+	}
+
+	query, args, err = sqlx.In(assigneeListQuery, groupTodoIDList)
+	if err != nil {
+		return err
+	}
+
+	assigneeRows, err := r.MySQLHandler.conn.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer assigneeRows.Close()
+
+	for assigneeRows.Next() {
+		var groupTodoID int
+		var assigneeUserID string
+		if err := assigneeRows.Scan(&groupTodoID, &assigneeUserID); err != nil {
+			return err
+		}
+
+		groupTodo := &groupTodoList[groupTodoIndexes[groupTodoID]]
+		groupTodo.AssigneeList = append(groupTodo.AssigneeList, assigneeUserID)
+	}
+
+	return assigneeRows.Err()
+}
+
+// insertGroupTodoDetails inserts the labels, the subtasks and the assignees of the group todo in the order of the lists.
+func insertGroupTodoDetails(tx *sql.Tx, groupTodoID int, labelList []string, subtaskList []model.TodoSubtask, assigneeList []string) error {
+	insertLabelQuery := `
+        INSERT INTO group_todo_labels
+            (todo_id, label_name)
+        VALUES
+            (?,?)`
+
+	insertSubtaskQuery := `
+        INSERT INTO group_todo_subtasks
+            (id, todo_id, subtask_content, complete_flag, subtask_order)
+        VALUES
+            (?,?,?,?,?)`
+
+	insertAssigneeQuery := `
+        INSERT INTO group_todo_assignees
+            (todo_id, user_id)
+        VALUES
+            (?,?)`
+
+	for _, labelName := range labelList {
+		if _, err := tx.Exec(insertLabelQuery, groupTodoID, labelName); err != nil {
+			return err
+		}
+	}
+
+	for i, subtask := range subtaskList {
+		// A new subtask is numbered by AUTO_INCREMENT, while an existing subtask keeps its ID.
+		subtaskID := sql.NullInt64{Int64: int64(subtask.ID), Valid: subtask.ID != 0}
+		if _, err := tx.Exec(insertSubtaskQuery, subtaskID, groupTodoID, subtask.SubtaskContent, subtask.CompleteFlag, i+1); err != nil {
+			return err
+		}
+	}
+
+	for _, assigneeUserID := range assigneeList {
+		if _, err := tx.Exec(insertAssigneeQuery, groupTodoID, assigneeUserID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// replaceGroupTodoDetails replaces the labels, the subtasks and the assignees of the group todo.
+func replaceGroupTodoDetails(tx *sql.Tx, groupTodoID int, labelList []string, subtaskList []model.TodoSubtask, assigneeList []string) error {
+	deleteLabelListQuery := `
+        DELETE
+        FROM
+            group_todo_labels
+        WHERE
+            todo_id = ?`
+
+	deleteSubtaskListQuery := `
+        DELETE
+        FROM
+            group_todo_subtasks
+        WHERE
+            todo_id = ?`
+
+	deleteAssigneeListQuery := `
+        DELETE
+        FROM
+            group_todo_assignees
+        WHERE
+            todo_id = ?`
+
+	if _, err := tx.Exec(deleteLabelListQuery, groupTodoID); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(deleteSubtaskListQuery, groupTodoID); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(deleteAssigneeListQuery, groupTodoID); err != nil {
+		return err
+	}
+
+	return insertGroupTodoDetails(tx, groupTodoID, labelList, subtaskList, assigneeList)
+}
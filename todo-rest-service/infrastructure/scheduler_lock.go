@@ -0,0 +1,59 @@
+package infrastructure
+
+import (
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+)
+
+type SchedulerLockRepository struct {
+	*RedisHandler
+}
+
+func NewSchedulerLockRepository(redisHandler *RedisHandler) *SchedulerLockRepository {
+	return &SchedulerLockRepository{redisHandler}
+}
+
+// acquireSchedulerLockScript takes the lock when nobody holds it, and extends the lock when the owner already holds it.
+var acquireSchedulerLockScript = redis.NewScript(1, `
+    if redis.call("GET", KEYS[1]) == ARGV[1] then
+        return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+    end
+
+    if redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+        return 1
+    end
+
+    return 0`)
+
+// releaseSchedulerLockScript releases the lock only when the owner holds it,
+// so that the lock taken over by another instance after the expiry is not released.
+var releaseSchedulerLockScript = redis.NewScript(1, `
+    if redis.call("GET", KEYS[1]) == ARGV[1] then
+        return redis.call("DEL", KEYS[1])
+    end
+
+    return 0`)
+
+func (r *SchedulerLockRepository) AcquireSchedulerLock(name string, owner string, ttl time.Duration) (bool, error) {
+	conn := r.RedisHandler.pool.Get()
+	defer conn.Close()
+
+	acquired, err := redis.Bool(acquireSchedulerLockScript.Do(conn, name, owner, ttl.Milliseconds()))
+	if err != nil {
+		return false, err
+	}
+
+	return acquired, nil
+}
+
+func (r *SchedulerLockRepository) ReleaseSchedulerLock(name string, owner string) error {
+	conn := r.RedisHandler.pool.Get()
+	defer conn.Close()
+
+	if _, err := releaseSchedulerLockScript.Do(conn, name, owner); err != nil {
+		return err
+	}
+
+	return nil
+}
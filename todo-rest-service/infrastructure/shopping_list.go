@@ -1,7 +1,9 @@
 package infrastructure
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -25,13 +27,20 @@ func (r *ShoppingListRepository) GetRegularShoppingList(userID string) (model.Re
             expected_purchase_date,
             cycle_type,
             cycle,
+            recurrence_rule,
+            recurrence_start_date,
             purchase,
             shop,
             amount,
+            quantity,
+            unit,
+            unit_price,
             big_category_id,
             medium_category_id,
             custom_category_id,
-            transaction_auto_add
+            transaction_auto_add,
+            finished_flag,
+            paused_until
         FROM
             regular_shopping_list
         WHERE
@@ -60,9 +69,54 @@ func (r *ShoppingListRepository) GetRegularShoppingList(userID string) (model.Re
 		return regularShoppingList, err
 	}
 
+	if err := r.setRegularShoppingListSkippedDateList(regularShoppingList.RegularShoppingList, userID); err != nil {
+		return regularShoppingList, err
+	}
+
 	return regularShoppingList, nil
 }
 
+func (r *ShoppingListRepository) setRegularShoppingListSkippedDateList(regularShoppingList []model.RegularShoppingItem, userID string) error {
+	query := `
+        SELECT
+            regular_shopping_skips.regular_shopping_list_id,
+            regular_shopping_skips.skipped_date
+        FROM
+            regular_shopping_skips
+        INNER JOIN
+            regular_shopping_list
+        ON
+            regular_shopping_skips.regular_shopping_list_id = regular_shopping_list.id
+        WHERE
+            regular_shopping_list.user_id = ?`
+
+	rows, err := r.MySQLHandler.conn.Queryx(query, userID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var regularShoppingItemID int
+		var skippedDate model.Date
+		if err := rows.Scan(&regularShoppingItemID, &skippedDate); err != nil {
+			return err
+		}
+
+		for i := range regularShoppingList {
+			if regularShoppingList[i].ID == regularShoppingItemID {
+				regularShoppingList[i].SkippedDateList = append(regularShoppingList[i].SkippedDateList, skippedDate)
+			}
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (r *ShoppingListRepository) GetRegularShoppingItem(regularShoppingItemID int) (model.RegularShoppingItem, error) {
 	query := `
         SELECT
@@ -72,23 +126,57 @@ func (r *ShoppingListRepository) GetRegularShoppingItem(regularShoppingItemID in
             expected_purchase_date,
             cycle_type,
             cycle,
+            recurrence_rule,
+            recurrence_start_date,
             purchase,
             shop,
             amount,
+            quantity,
+            unit,
+            unit_price,
             big_category_id,
             medium_category_id,
             custom_category_id,
-            transaction_auto_add
+            transaction_auto_add,
+            finished_flag,
+            paused_until
         FROM
             regular_shopping_list
         WHERE
             id = ?`
 
+	skippedDateListQuery := `
+        SELECT
+            skipped_date
+        FROM
+            regular_shopping_skips
+        WHERE
+            regular_shopping_list_id = ?`
+
 	var regularShoppingItem model.RegularShoppingItem
 	if err := r.MySQLHandler.conn.QueryRowx(query, regularShoppingItemID).StructScan(&regularShoppingItem); err != nil {
 		return regularShoppingItem, err
 	}
 
+	rows, err := r.MySQLHandler.conn.Queryx(skippedDateListQuery, regularShoppingItemID)
+	if err != nil {
+		return regularShoppingItem, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var skippedDate model.Date
+		if err := rows.Scan(&skippedDate); err != nil {
+			return regularShoppingItem, err
+		}
+
+		regularShoppingItem.SkippedDateList = append(regularShoppingItem.SkippedDateList, skippedDate)
+	}
+
+	if err := rows.Err(); err != nil {
+		return regularShoppingItem, err
+	}
+
 	return regularShoppingItem, nil
 }
 
@@ -103,6 +191,9 @@ func (r *ShoppingListRepository) GetShoppingListRelatedToPostedRegularShoppingIt
             purchase,
             shop,
             amount,
+            quantity,
+            unit,
+            unit_price,
             big_category_id,
             medium_category_id,
             custom_category_id,
@@ -155,17 +246,23 @@ func (r *ShoppingListRepository) PostRegularShoppingItem(regularShoppingItem *mo
             expected_purchase_date,
             cycle_type,
             cycle,
+            recurrence_rule,
+            recurrence_start_date,
             purchase,
             shop,
             amount,
+            quantity,
+            unit,
+            unit_price,
             big_category_id,
             medium_category_id,
             custom_category_id,
             user_id,
-            transaction_auto_add
+            transaction_auto_add,
+            finished_flag
         )
         VALUES
-            (?,?,?,?,?,?,?,?,?,?,?)`
+            (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`
 
 	shoppingItemQuery := `
         INSERT INTO shopping_list
@@ -174,6 +271,9 @@ func (r *ShoppingListRepository) PostRegularShoppingItem(regularShoppingItem *mo
             purchase,
             shop,
             amount,
+            quantity,
+            unit,
+            unit_price,
             big_category_id,
             medium_category_id,
             custom_category_id,
@@ -182,7 +282,7 @@ func (r *ShoppingListRepository) PostRegularShoppingItem(regularShoppingItem *mo
             transaction_auto_add
         )
         VALUES
-            (?,?,?,?,?,?,?,?,?,?)`
+            (?,?,?,?,?,?,?,?,?,?,?,?,?)`
 
 	tx, err := r.MySQLHandler.conn.Begin()
 	if err != nil {
@@ -193,15 +293,13 @@ func (r *ShoppingListRepository) PostRegularShoppingItem(regularShoppingItem *mo
 		var regularShoppingItemResult, todayShoppingItemResult, laterThanTodayShoppingItemResult sql.Result
 		nextExpectedPurchaseDate := regularShoppingItem.ExpectedPurchaseDate.Time
 
+		var finished bool
 		if today.Equal(regularShoppingItem.ExpectedPurchaseDate.Time) {
-			if regularShoppingItem.CycleType == "daily" {
-				nextExpectedPurchaseDate = nextExpectedPurchaseDate.AddDate(0, 0, 1)
-			} else if regularShoppingItem.CycleType == "weekly" {
-				nextExpectedPurchaseDate = nextExpectedPurchaseDate.AddDate(0, 0, 7)
-			} else if regularShoppingItem.CycleType == "monthly" {
-				nextExpectedPurchaseDate = nextExpectedPurchaseDate.AddDate(0, 1, 0)
-			} else if regularShoppingItem.CycleType == "custom" {
-				nextExpectedPurchaseDate = nextExpectedPurchaseDate.AddDate(0, 0, regularShoppingItem.Cycle.Int)
+			expectedPurchaseDate, ok := regularShoppingItem.NextExpectedPurchaseDate(nextExpectedPurchaseDate)
+			if ok {
+				nextExpectedPurchaseDate = expectedPurchaseDate
+			} else {
+				finished = true
 			}
 		}
 
@@ -210,14 +308,20 @@ func (r *ShoppingListRepository) PostRegularShoppingItem(regularShoppingItem *mo
 			nextExpectedPurchaseDate,
 			regularShoppingItem.CycleType,
 			regularShoppingItem.Cycle,
+			regularShoppingItem.RecurrenceRule,
+			regularShoppingItem.RecurrenceStartDate,
 			regularShoppingItem.Purchase,
 			regularShoppingItem.Shop,
 			regularShoppingItem.Amount,
+			regularShoppingItem.Quantity,
+			regularShoppingItem.Unit,
+			regularShoppingItem.UnitPrice,
 			regularShoppingItem.BigCategoryID,
 			regularShoppingItem.MediumCategoryID,
 			regularShoppingItem.CustomCategoryID,
 			userID,
 			regularShoppingItem.TransactionAutoAdd,
+			finished,
 		)
 		if err != nil {
 			return nil, nil, nil, err
@@ -228,21 +332,26 @@ func (r *ShoppingListRepository) PostRegularShoppingItem(regularShoppingItem *mo
 			return nil, nil, nil, err
 		}
 
-		laterThanTodayShoppingItemResult, err = tx.Exec(
-			shoppingItemQuery,
-			nextExpectedPurchaseDate,
-			regularShoppingItem.Purchase,
-			regularShoppingItem.Shop,
-			regularShoppingItem.Amount,
-			regularShoppingItem.BigCategoryID,
-			regularShoppingItem.MediumCategoryID,
-			regularShoppingItem.CustomCategoryID,
-			regularShoppingItemId,
-			userID,
-			regularShoppingItem.TransactionAutoAdd,
-		)
-		if err != nil {
-			return nil, nil, nil, err
+		if !finished {
+			laterThanTodayShoppingItemResult, err = tx.Exec(
+				shoppingItemQuery,
+				nextExpectedPurchaseDate,
+				regularShoppingItem.Purchase,
+				regularShoppingItem.Shop,
+				regularShoppingItem.Amount,
+				regularShoppingItem.Quantity,
+				regularShoppingItem.Unit,
+				regularShoppingItem.UnitPrice,
+				regularShoppingItem.BigCategoryID,
+				regularShoppingItem.MediumCategoryID,
+				regularShoppingItem.CustomCategoryID,
+				regularShoppingItemId,
+				userID,
+				regularShoppingItem.TransactionAutoAdd,
+			)
+			if err != nil {
+				return nil, nil, nil, err
+			}
 		}
 
 		if today.Equal(regularShoppingItem.ExpectedPurchaseDate.Time) {
@@ -252,6 +361,9 @@ func (r *ShoppingListRepository) PostRegularShoppingItem(regularShoppingItem *mo
 				regularShoppingItem.Purchase,
 				regularShoppingItem.Shop,
 				regularShoppingItem.Amount,
+				regularShoppingItem.Quantity,
+				regularShoppingItem.Unit,
+				regularShoppingItem.UnitPrice,
 				regularShoppingItem.BigCategoryID,
 				regularShoppingItem.MediumCategoryID,
 				regularShoppingItem.CustomCategoryID,
@@ -294,6 +406,9 @@ func (r *ShoppingListRepository) GetShoppingListRelatedToUpdatedRegularShoppingI
             purchase,
             shop,
             amount,
+            quantity,
+            unit,
+            unit_price,
             big_category_id,
             medium_category_id,
             custom_category_id,
@@ -350,16 +465,32 @@ func (r *ShoppingListRepository) PutRegularShoppingItem(regularShoppingItem *mod
             expected_purchase_date = ?,
             cycle_type = ?,
             cycle = ?,
+            recurrence_rule = ?,
+            recurrence_start_date = ?,
             purchase = ?,
             shop = ?,
             amount = ?,
+            quantity = ?,
+            unit = ?,
+            unit_price = ?,
             big_category_id = ?,
             medium_category_id = ?,
             custom_category_id = ?,
-            transaction_auto_add = ?
+            transaction_auto_add = ?,
+            finished_flag = ?,
+            paused_until = NULL
         WHERE
             id = ?`
 
+	deleteSkippedDateQuery := `
+        DELETE
+        FROM
+            regular_shopping_skips
+        WHERE
+            regular_shopping_list_id = ?
+        AND
+            skipped_date >= ?`
+
 	insertShoppingItemQuery := `
         INSERT INTO 
             shopping_list
@@ -368,6 +499,9 @@ func (r *ShoppingListRepository) PutRegularShoppingItem(regularShoppingItem *mod
             purchase,
             shop,
             amount,
+            quantity,
+            unit,
+            unit_price,
             big_category_id,
             medium_category_id,
             custom_category_id,
@@ -377,7 +511,7 @@ func (r *ShoppingListRepository) PutRegularShoppingItem(regularShoppingItem *mod
         )
         VALUES
         (
-            ?,?,?,?,?,?,?,?,?,?
+            ?,?,?,?,?,?,?,?,?,?,?,?,?
         )`
 
 	tx, err := r.MySQLHandler.conn.Begin()
@@ -393,12 +527,25 @@ func (r *ShoppingListRepository) PutRegularShoppingItem(regularShoppingItem *mod
 			return err
 		}
 
+		// Editing restarts the recurrence from the edited expected purchase date,
+		// so the skips and the pause made for the previous recurrence are cancelled.
+		if _, err := tx.Exec(
+			deleteSkippedDateQuery,
+			regularShoppingItemID,
+			today,
+		); err != nil {
+			return err
+		}
+
 		if _, err = tx.Exec(
 			insertShoppingItemQuery,
 			regularShoppingItem.ExpectedPurchaseDate,
 			regularShoppingItem.Purchase,
 			regularShoppingItem.Shop,
 			regularShoppingItem.Amount,
+			regularShoppingItem.Quantity,
+			regularShoppingItem.Unit,
+			regularShoppingItem.UnitPrice,
 			regularShoppingItem.BigCategoryID,
 			regularShoppingItem.MediumCategoryID,
 			regularShoppingItem.CustomCategoryID,
@@ -411,23 +558,25 @@ func (r *ShoppingListRepository) PutRegularShoppingItem(regularShoppingItem *mod
 
 		nextExpectedPurchaseDate := regularShoppingItem.ExpectedPurchaseDate.Time
 
+		var finished bool
 		for !today.Before(nextExpectedPurchaseDate) {
-			if regularShoppingItem.CycleType == "daily" {
-				nextExpectedPurchaseDate = nextExpectedPurchaseDate.AddDate(0, 0, 1)
-			} else if regularShoppingItem.CycleType == "weekly" {
-				nextExpectedPurchaseDate = nextExpectedPurchaseDate.AddDate(0, 0, 7)
-			} else if regularShoppingItem.CycleType == "monthly" {
-				nextExpectedPurchaseDate = nextExpectedPurchaseDate.AddDate(0, 1, 0)
-			} else if regularShoppingItem.CycleType == "custom" {
-				nextExpectedPurchaseDate = nextExpectedPurchaseDate.AddDate(0, 0, regularShoppingItem.Cycle.Int)
+			expectedPurchaseDate, ok := regularShoppingItem.NextExpectedPurchaseDate(nextExpectedPurchaseDate)
+			if !ok {
+				finished = true
+				break
 			}
 
+			nextExpectedPurchaseDate = expectedPurchaseDate
+
 			if _, err = tx.Exec(
 				insertShoppingItemQuery,
 				nextExpectedPurchaseDate,
 				regularShoppingItem.Purchase,
 				regularShoppingItem.Shop,
 				regularShoppingItem.Amount,
+				regularShoppingItem.Quantity,
+				regularShoppingItem.Unit,
+				regularShoppingItem.UnitPrice,
 				regularShoppingItem.BigCategoryID,
 				regularShoppingItem.MediumCategoryID,
 				regularShoppingItem.CustomCategoryID,
@@ -445,13 +594,19 @@ func (r *ShoppingListRepository) PutRegularShoppingItem(regularShoppingItem *mod
 			nextExpectedPurchaseDate,
 			regularShoppingItem.CycleType,
 			regularShoppingItem.Cycle,
+			regularShoppingItem.RecurrenceRule,
+			regularShoppingItem.RecurrenceStartDate,
 			regularShoppingItem.Purchase,
 			regularShoppingItem.Shop,
 			regularShoppingItem.Amount,
+			regularShoppingItem.Quantity,
+			regularShoppingItem.Unit,
+			regularShoppingItem.UnitPrice,
 			regularShoppingItem.BigCategoryID,
 			regularShoppingItem.MediumCategoryID,
 			regularShoppingItem.CustomCategoryID,
 			regularShoppingItem.TransactionAutoAdd,
+			finished,
 			regularShoppingItemID,
 		); err != nil {
 			return err
@@ -480,9 +635,12 @@ func (r *ShoppingListRepository) PutRegularShoppingList(regularShoppingList mode
         UPDATE
             regular_shopping_list
         SET
-            expected_purchase_date = ?
+            expected_purchase_date = ?,
+            finished_flag = ?
         WHERE
-            id = ?`
+            id = ?
+        AND
+            expected_purchase_date = ?`
 
 	insertShoppingItemQuery := `
         INSERT INTO 
@@ -492,6 +650,9 @@ func (r *ShoppingListRepository) PutRegularShoppingList(regularShoppingList mode
             purchase,
             shop,
             amount,
+            quantity,
+            unit,
+            unit_price,
             big_category_id,
             medium_category_id,
             custom_category_id,
@@ -501,7 +662,7 @@ func (r *ShoppingListRepository) PutRegularShoppingList(regularShoppingList mode
         )
         VALUES
         (
-            ?,?,?,?,?,?,?,?,?,?
+            ?,?,?,?,?,?,?,?,?,?,?,?,?
         )`
 
 	tx, err := r.MySQLHandler.conn.Begin()
@@ -511,25 +672,44 @@ func (r *ShoppingListRepository) PutRegularShoppingList(regularShoppingList mode
 
 	transactions := func(tx *sql.Tx) error {
 		for _, regularShoppingItem := range regularShoppingList.RegularShoppingList {
-			nextExpectedPurchaseDate := regularShoppingItem.ExpectedPurchaseDate.Time
-
-			for !today.Before(nextExpectedPurchaseDate) {
-				if regularShoppingItem.CycleType == "daily" {
-					nextExpectedPurchaseDate = nextExpectedPurchaseDate.AddDate(0, 0, 1)
-				} else if regularShoppingItem.CycleType == "weekly" {
-					nextExpectedPurchaseDate = nextExpectedPurchaseDate.AddDate(0, 0, 7)
-				} else if regularShoppingItem.CycleType == "monthly" {
-					nextExpectedPurchaseDate = nextExpectedPurchaseDate.AddDate(0, 1, 0)
-				} else if regularShoppingItem.CycleType == "custom" {
-					nextExpectedPurchaseDate = nextExpectedPurchaseDate.AddDate(0, 0, regularShoppingItem.Cycle.Int)
-				}
+			if today.Before(regularShoppingItem.ExpectedPurchaseDate.Time) {
+				continue
+			}
+
+			materialization := regularShoppingItem.Materialization(today)
+
+			// The regular shopping item is advanced only from the expected purchase date it was read with,
+			// so that the shopping items are not inserted twice when it has been advanced in the meantime.
+			result, err := tx.Exec(
+				updateRegularShoppingItemQuery,
+				materialization.NextExpectedPurchaseDate,
+				materialization.Finished,
+				regularShoppingItem.ID,
+				regularShoppingItem.ExpectedPurchaseDate.Time,
+			)
+			if err != nil {
+				return err
+			}
+
+			rowsAffected, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
 
-				if _, err = tx.Exec(
+			if rowsAffected == 0 {
+				continue
+			}
+
+			for _, expectedPurchaseDate := range materialization.ExpectedPurchaseDateList {
+				if _, err := tx.Exec(
 					insertShoppingItemQuery,
-					nextExpectedPurchaseDate,
+					expectedPurchaseDate,
 					regularShoppingItem.Purchase,
 					regularShoppingItem.Shop,
 					regularShoppingItem.Amount,
+					regularShoppingItem.Quantity,
+					regularShoppingItem.Unit,
+					regularShoppingItem.UnitPrice,
 					regularShoppingItem.BigCategoryID,
 					regularShoppingItem.MediumCategoryID,
 					regularShoppingItem.CustomCategoryID,
@@ -540,16 +720,6 @@ func (r *ShoppingListRepository) PutRegularShoppingList(regularShoppingList mode
 					return err
 				}
 			}
-
-			if !today.Before(regularShoppingItem.ExpectedPurchaseDate.Time) {
-				if _, err := tx.Exec(
-					updateRegularShoppingItemQuery,
-					nextExpectedPurchaseDate,
-					regularShoppingItem.ID,
-				); err != nil {
-					return err
-				}
-			}
 		}
 
 		return nil
@@ -570,130 +740,458 @@ func (r *ShoppingListRepository) PutRegularShoppingList(regularShoppingList mode
 	return nil
 }
 
-func (r *ShoppingListRepository) DeleteRegularShoppingItem(regularShoppingItemID int) error {
-	deleteShoppingItemQuery := `
-        DELETE
+func (r *ShoppingListRepository) GetDueRegularShoppingListUserIDList(today time.Time) ([]string, error) {
+	query := `
+        SELECT DISTINCT
+            user_id
         FROM
-            shopping_list
+            regular_shopping_list
         WHERE
-            regular_shopping_list_id = ?
+            expected_purchase_date <= ?
         AND
-            complete_flag = false`
+            finished_flag = false`
 
-	deleteRegularShoppingItemQuery := `
+	rows, err := r.MySQLHandler.conn.Queryx(query, today)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var userIDList []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+
+		userIDList = append(userIDList, userID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return userIDList, nil
+}
+
+func (r *ShoppingListRepository) SkipRegularShoppingItem(regularShoppingItem *model.RegularShoppingItem, skippedDateList []time.Time, userID string, today time.Time) (bool, error) {
+	tx, err := r.MySQLHandler.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	transactions := func(tx *sql.Tx) (bool, error) {
+		locked, err := lockRegularShoppingItem(tx, regularShoppingItem, userID)
+		if err != nil || !locked {
+			return false, err
+		}
+
+		if err := skipRegularShoppingItemDateList(tx, regularShoppingItem, skippedDateList, "skip", userID, today); err != nil {
+			return false, err
+		}
+
+		return true, nil
+	}
+
+	skipped, err := transactions(tx)
+	if err != nil || !skipped {
+		if err := tx.Rollback(); err != nil {
+			return false, err
+		}
+
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *ShoppingListRepository) PauseRegularShoppingItem(regularShoppingItem *model.RegularShoppingItem, userID string, today time.Time) (bool, error) {
+	deletePausedDateQuery := `
         DELETE
         FROM
+            regular_shopping_skips
+        WHERE
+            regular_shopping_list_id = ?
+        AND
+            skip_reason = 'pause'
+        AND
+            skipped_date >= ?`
+
+	updatePausedUntilQuery := `
+        UPDATE
             regular_shopping_list
+        SET
+            paused_until = ?
         WHERE
             id = ?`
 
 	tx, err := r.MySQLHandler.conn.Begin()
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	transactions := func(tx *sql.Tx) error {
-		if _, err := tx.Exec(deleteShoppingItemQuery, regularShoppingItemID); err != nil {
-			return err
+	transactions := func(tx *sql.Tx) (bool, error) {
+		locked, err := lockRegularShoppingItem(tx, regularShoppingItem, userID)
+		if err != nil || !locked {
+			return false, err
 		}
 
-		if _, err := tx.Exec(deleteRegularShoppingItemQuery, regularShoppingItemID); err != nil {
-			return err
+		// The previous pause is replaced, so the dates it has skipped from today on are brought back first.
+		if _, err := tx.Exec(deletePausedDateQuery, regularShoppingItem.ID, today); err != nil {
+			return false, err
 		}
 
-		return nil
+		if _, err := tx.Exec(updatePausedUntilQuery, regularShoppingItem.PausedUntil, regularShoppingItem.ID); err != nil {
+			return false, err
+		}
+
+		if regularShoppingItem.SkippedDateList, err = selectRegularShoppingItemSkippedDateList(tx, regularShoppingItem.ID); err != nil {
+			return false, err
+		}
+
+		var pausedDateList []time.Time
+		if regularShoppingItem.PausedUntil.Valid {
+			if expectedPurchaseDate, ok := regularShoppingItem.RescheduledExpectedPurchaseDate(today); ok {
+				pausedDateList = regularShoppingItem.UpcomingExpectedPurchaseDateList(expectedPurchaseDate, 0, regularShoppingItem.PausedUntil.Time)
+			}
+		}
+
+		if err := skipRegularShoppingItemDateList(tx, regularShoppingItem, pausedDateList, "pause", userID, today); err != nil {
+			return false, err
+		}
+
+		return true, nil
 	}
 
-	if err := transactions(tx); err != nil {
+	paused, err := transactions(tx)
+	if err != nil || !paused {
 		if err := tx.Rollback(); err != nil {
-			return err
+			return false, err
 		}
 
-		return err
+		return false, err
 	}
 
 	if err := tx.Commit(); err != nil {
-		return err
+		return false, err
 	}
 
-	return nil
+	return true, nil
 }
 
-func (r *ShoppingListRepository) GetDailyShoppingListByDay(date time.Time, userID string) (model.ShoppingList, error) {
+// lockRegularShoppingItem locks the regular shopping item of the user, and reports whether it is still on the expected purchase date it was read with,
+// so that the skips are not applied to the occurrences the scheduler has advanced over in the meantime.
+// It returns sql.ErrNoRows when the item does not exist or belongs to another user.
+func lockRegularShoppingItem(tx *sql.Tx, regularShoppingItem *model.RegularShoppingItem, userID string) (bool, error) {
 	query := `
         SELECT
-            id,
-            posted_date,
-            updated_date,
-            expected_purchase_date,
-            complete_flag,
-            purchase,
-            shop,
-            amount,
-            big_category_id,
-            medium_category_id,
-            custom_category_id,
-            regular_shopping_list_id,
-            transaction_auto_add,
-            transaction_id
+            expected_purchase_date
         FROM
-            shopping_list
+            regular_shopping_list
         WHERE
-            user_id = ?
+            id = ?
         AND
-            expected_purchase_date = ?`
+            user_id = ?
+        FOR UPDATE`
 
-	shoppingList := model.ShoppingList{
-		ShoppingList: make([]model.ShoppingItem, 0),
+	var expectedPurchaseDate model.Date
+	if err := tx.QueryRow(query, regularShoppingItem.ID, userID).Scan(&expectedPurchaseDate); err != nil {
+		return false, err
 	}
 
-	rows, err := r.MySQLHandler.conn.Queryx(query, userID, date)
+	return expectedPurchaseDate.Time.Equal(regularShoppingItem.ExpectedPurchaseDate.Time), nil
+}
+
+func selectRegularShoppingItemSkippedDateList(tx *sql.Tx, regularShoppingItemID int) ([]model.Date, error) {
+	query := `
+        SELECT
+            skipped_date
+        FROM
+            regular_shopping_skips
+        WHERE
+            regular_shopping_list_id = ?`
+
+	rows, err := tx.Query(query, regularShoppingItemID)
 	if err != nil {
-		return shoppingList, err
+		return nil, err
 	}
 	defer rows.Close()
 
+	var skippedDateList []model.Date
 	for rows.Next() {
-		var shoppingItem model.ShoppingItem
-		if err := rows.StructScan(&shoppingItem); err != nil {
-			return shoppingList, err
+		var skippedDate model.Date
+		if err := rows.Scan(&skippedDate); err != nil {
+			return nil, err
 		}
 
-		shoppingList.ShoppingList = append(shoppingList.ShoppingList, shoppingItem)
+		skippedDateList = append(skippedDateList, skippedDate)
 	}
 
 	if err := rows.Err(); err != nil {
-		return shoppingList, err
+		return nil, err
 	}
 
-	return shoppingList, nil
+	return skippedDateList, nil
 }
 
-func (r *ShoppingListRepository) GetDailyShoppingListByCategory(date time.Time, userID string) (model.ShoppingList, error) {
-	query := `
-        SELECT
-            id,
-            posted_date,
-            updated_date,
-            expected_purchase_date,
-            complete_flag,
-            purchase,
-            shop,
-            amount,
-            big_category_id,
-            medium_category_id,
-            custom_category_id,
+// skipRegularShoppingItemDateList records the skipped dates, removes their shopping items which have not been completed,
+// and moves the regular shopping item and its shopping item to the first expected purchase date which is not skipped.
+func skipRegularShoppingItemDateList(tx *sql.Tx, regularShoppingItem *model.RegularShoppingItem, skippedDateList []time.Time, skipReason string, userID string, today time.Time) error {
+	insertSkippedDateQuery := `
+        INSERT IGNORE INTO regular_shopping_skips
+        (
             regular_shopping_list_id,
-            transaction_auto_add,
-            transaction_id
+            skipped_date,
+            skip_reason
+        )
+        VALUES
+            (?,?,?)`
+
+	deleteShoppingItemQuery := `
+        DELETE
         FROM
             shopping_list
         WHERE
-            user_id = ?
+            regular_shopping_list_id = ?
         AND
             expected_purchase_date = ?
-        ORDER BY
-            big_category_id`
+        AND
+            complete_flag = false`
+
+	updateRegularShoppingItemQuery := `
+        UPDATE
+            regular_shopping_list
+        SET
+            expected_purchase_date = ?,
+            finished_flag = ?
+        WHERE
+            id = ?`
+
+	insertShoppingItemQuery := `
+        INSERT INTO 
+            shopping_list
+        (
+            expected_purchase_date,
+            purchase,
+            shop,
+            amount,
+            quantity,
+            unit,
+            unit_price,
+            big_category_id,
+            medium_category_id,
+            custom_category_id,
+            regular_shopping_list_id,
+            user_id,
+            transaction_auto_add
+        )
+        VALUES
+        (
+            ?,?,?,?,?,?,?,?,?,?,?,?,?
+        )`
+
+	// The shopping item of the current expected purchase date is kept, unless it is skipped or the recurrence had finished.
+	materialized := !bool(regularShoppingItem.FinishedFlag)
+
+	for _, skippedDate := range skippedDateList {
+		if _, err := tx.Exec(insertSkippedDateQuery, regularShoppingItem.ID, skippedDate, skipReason); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(deleteShoppingItemQuery, regularShoppingItem.ID, skippedDate); err != nil {
+			return err
+		}
+
+		if skippedDate.Equal(regularShoppingItem.ExpectedPurchaseDate.Time) {
+			materialized = false
+		}
+	}
+
+	var err error
+	if regularShoppingItem.SkippedDateList, err = selectRegularShoppingItemSkippedDateList(tx, regularShoppingItem.ID); err != nil {
+		return err
+	}
+
+	nextExpectedPurchaseDate, ok := regularShoppingItem.RescheduledExpectedPurchaseDate(today)
+	if !ok {
+		nextExpectedPurchaseDate = regularShoppingItem.ExpectedPurchaseDate.Time
+	}
+
+	if _, err := tx.Exec(
+		updateRegularShoppingItemQuery,
+		nextExpectedPurchaseDate,
+		!ok,
+		regularShoppingItem.ID,
+	); err != nil {
+		return err
+	}
+
+	if !ok || (materialized && nextExpectedPurchaseDate.Equal(regularShoppingItem.ExpectedPurchaseDate.Time)) {
+		return nil
+	}
+
+	if _, err := tx.Exec(deleteShoppingItemQuery, regularShoppingItem.ID, regularShoppingItem.ExpectedPurchaseDate.Time); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(
+		insertShoppingItemQuery,
+		nextExpectedPurchaseDate,
+		regularShoppingItem.Purchase,
+		regularShoppingItem.Shop,
+		regularShoppingItem.Amount,
+		regularShoppingItem.Quantity,
+		regularShoppingItem.Unit,
+		regularShoppingItem.UnitPrice,
+		regularShoppingItem.BigCategoryID,
+		regularShoppingItem.MediumCategoryID,
+		regularShoppingItem.CustomCategoryID,
+		regularShoppingItem.ID,
+		userID,
+		regularShoppingItem.TransactionAutoAdd,
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ShoppingListRepository) GetMonthlySkippedShoppingList(firstDay time.Time, lastDay time.Time, userID string) (model.SkippedShoppingList, error) {
+	query := `
+        SELECT
+            regular_shopping_skips.id,
+            regular_shopping_skips.skipped_date,
+            regular_shopping_skips.skip_reason,
+            regular_shopping_list.purchase,
+            regular_shopping_list.shop,
+            regular_shopping_list.amount,
+            regular_shopping_list.quantity,
+            regular_shopping_list.unit,
+            regular_shopping_list.unit_price,
+            regular_shopping_list.big_category_id,
+            regular_shopping_list.medium_category_id,
+            regular_shopping_list.custom_category_id,
+            regular_shopping_skips.regular_shopping_list_id
+        FROM
+            regular_shopping_skips
+        INNER JOIN
+            regular_shopping_list
+        ON
+            regular_shopping_skips.regular_shopping_list_id = regular_shopping_list.id
+        WHERE
+            regular_shopping_list.user_id = ?
+        AND
+            regular_shopping_skips.skipped_date >= ?
+        AND
+            regular_shopping_skips.skipped_date <= ?
+        ORDER BY
+            regular_shopping_skips.skipped_date, regular_shopping_skips.id`
+
+	skippedShoppingList := model.SkippedShoppingList{
+		SkippedShoppingList: make([]model.SkippedShoppingItem, 0),
+	}
+
+	rows, err := r.MySQLHandler.conn.Queryx(query, userID, firstDay, lastDay)
+	if err != nil {
+		return skippedShoppingList, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var skippedShoppingItem model.SkippedShoppingItem
+		if err := rows.StructScan(&skippedShoppingItem); err != nil {
+			return skippedShoppingList, err
+		}
+
+		skippedShoppingList.SkippedShoppingList = append(skippedShoppingList.SkippedShoppingList, skippedShoppingItem)
+	}
+
+	if err := rows.Err(); err != nil {
+		return skippedShoppingList, err
+	}
+
+	return skippedShoppingList, nil
+}
+
+func (r *ShoppingListRepository) DeleteRegularShoppingItem(regularShoppingItemID int) error {
+	deleteShoppingItemQuery := `
+        DELETE
+        FROM
+            shopping_list
+        WHERE
+            regular_shopping_list_id = ?
+        AND
+            complete_flag = false`
+
+	deleteRegularShoppingItemQuery := `
+        DELETE
+        FROM
+            regular_shopping_list
+        WHERE
+            id = ?`
+
+	tx, err := r.MySQLHandler.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	transactions := func(tx *sql.Tx) error {
+		if _, err := tx.Exec(deleteShoppingItemQuery, regularShoppingItemID); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(deleteRegularShoppingItemQuery, regularShoppingItemID); err != nil {
+			return err
+		}
+
+		return nil
+	}
+
+	if err := transactions(tx); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return err
+		}
+
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ShoppingListRepository) GetDailyShoppingListByDay(date time.Time, userID string) (model.ShoppingList, error) {
+	query := `
+        SELECT
+            id,
+            posted_date,
+            updated_date,
+            expected_purchase_date,
+            complete_flag,
+            purchase,
+            shop,
+            amount,
+            quantity,
+            unit,
+            unit_price,
+            big_category_id,
+            medium_category_id,
+            custom_category_id,
+            regular_shopping_list_id,
+            transaction_auto_add,
+            transaction_id
+        FROM
+            shopping_list
+        WHERE
+            user_id = ?
+        AND
+            expected_purchase_date = ?`
 
 	shoppingList := model.ShoppingList{
 		ShoppingList: make([]model.ShoppingItem, 0),
@@ -721,7 +1219,7 @@ func (r *ShoppingListRepository) GetDailyShoppingListByCategory(date time.Time,
 	return shoppingList, nil
 }
 
-func (r *ShoppingListRepository) GetMonthlyShoppingListByDay(firstDay time.Time, lastDay time.Time, userID string) (model.ShoppingList, error) {
+func (r *ShoppingListRepository) GetDailyShoppingListByCategory(date time.Time, userID string) (model.ShoppingList, error) {
 	query := `
         SELECT
             id,
@@ -732,6 +1230,9 @@ func (r *ShoppingListRepository) GetMonthlyShoppingListByDay(firstDay time.Time,
             purchase,
             shop,
             amount,
+            quantity,
+            unit,
+            unit_price,
             big_category_id,
             medium_category_id,
             custom_category_id,
@@ -743,17 +1244,15 @@ func (r *ShoppingListRepository) GetMonthlyShoppingListByDay(firstDay time.Time,
         WHERE
             user_id = ?
         AND
-            expected_purchase_date >= ?
-        AND
-            expected_purchase_date <= ?
+            expected_purchase_date = ?
         ORDER BY
-            expected_purchase_date`
+            big_category_id`
 
 	shoppingList := model.ShoppingList{
 		ShoppingList: make([]model.ShoppingItem, 0),
 	}
 
-	rows, err := r.MySQLHandler.conn.Queryx(query, userID, firstDay, lastDay)
+	rows, err := r.MySQLHandler.conn.Queryx(query, userID, date)
 	if err != nil {
 		return shoppingList, err
 	}
@@ -775,7 +1274,7 @@ func (r *ShoppingListRepository) GetMonthlyShoppingListByDay(firstDay time.Time,
 	return shoppingList, nil
 }
 
-func (r *ShoppingListRepository) GetMonthlyShoppingListByCategory(firstDay time.Time, lastDay time.Time, userID string) (model.ShoppingList, error) {
+func (r *ShoppingListRepository) GetMonthlyShoppingListByDay(firstDay time.Time, lastDay time.Time, userID string) (model.ShoppingList, error) {
 	query := `
         SELECT
             id,
@@ -786,6 +1285,9 @@ func (r *ShoppingListRepository) GetMonthlyShoppingListByCategory(firstDay time.
             purchase,
             shop,
             amount,
+            quantity,
+            unit,
+            unit_price,
             big_category_id,
             medium_category_id,
             custom_category_id,
@@ -801,7 +1303,7 @@ func (r *ShoppingListRepository) GetMonthlyShoppingListByCategory(firstDay time.
         AND
             expected_purchase_date <= ?
         ORDER BY
-            big_category_id, expected_purchase_date`
+            expected_purchase_date`
 
 	shoppingList := model.ShoppingList{
 		ShoppingList: make([]model.ShoppingItem, 0),
@@ -829,7 +1331,7 @@ func (r *ShoppingListRepository) GetMonthlyShoppingListByCategory(firstDay time.
 	return shoppingList, nil
 }
 
-func (r *ShoppingListRepository) GetExpiredShoppingList(dueDate time.Time, userID string) (model.ExpiredShoppingList, error) {
+func (r *ShoppingListRepository) GetMonthlyShoppingListByCategory(firstDay time.Time, lastDay time.Time, userID string) (model.ShoppingList, error) {
 	query := `
         SELECT
             id,
@@ -840,6 +1342,9 @@ func (r *ShoppingListRepository) GetExpiredShoppingList(dueDate time.Time, userI
             purchase,
             shop,
             amount,
+            quantity,
+            unit,
+            unit_price,
             big_category_id,
             medium_category_id,
             custom_category_id,
@@ -851,39 +1356,39 @@ func (r *ShoppingListRepository) GetExpiredShoppingList(dueDate time.Time, userI
         WHERE
             user_id = ?
         AND
-            complete_flag = false
+            expected_purchase_date >= ?
         AND
             expected_purchase_date <= ?
         ORDER BY
-            expected_purchase_date`
+            big_category_id, expected_purchase_date`
 
-	expiredShoppingList := model.ExpiredShoppingList{
-		ExpiredShoppingList: make([]model.ShoppingItem, 0),
+	shoppingList := model.ShoppingList{
+		ShoppingList: make([]model.ShoppingItem, 0),
 	}
 
-	rows, err := r.MySQLHandler.conn.Queryx(query, userID, dueDate)
+	rows, err := r.MySQLHandler.conn.Queryx(query, userID, firstDay, lastDay)
 	if err != nil {
-		return expiredShoppingList, err
+		return shoppingList, err
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var expiredShoppingItem model.ShoppingItem
-		if err := rows.StructScan(&expiredShoppingItem); err != nil {
-			return expiredShoppingList, err
+		var shoppingItem model.ShoppingItem
+		if err := rows.StructScan(&shoppingItem); err != nil {
+			return shoppingList, err
 		}
 
-		expiredShoppingList.ExpiredShoppingList = append(expiredShoppingList.ExpiredShoppingList, expiredShoppingItem)
+		shoppingList.ShoppingList = append(shoppingList.ShoppingList, shoppingItem)
 	}
 
 	if err := rows.Err(); err != nil {
-		return expiredShoppingList, err
+		return shoppingList, err
 	}
 
-	return expiredShoppingList, nil
+	return shoppingList, nil
 }
 
-func (r *ShoppingListRepository) GetShoppingItem(shoppingItemID int) (model.ShoppingItem, error) {
+func (r *ShoppingListRepository) GetDailyShoppingListByShop(date time.Time, userID string) (model.ShoppingList, error) {
 	query := `
         SELECT
             id,
@@ -894,6 +1399,9 @@ func (r *ShoppingListRepository) GetShoppingItem(shoppingItemID int) (model.Shop
             purchase,
             shop,
             amount,
+            quantity,
+            unit,
+            unit_price,
             big_category_id,
             medium_category_id,
             custom_category_id,
@@ -903,39 +1411,310 @@ func (r *ShoppingListRepository) GetShoppingItem(shoppingItemID int) (model.Shop
         FROM
             shopping_list
         WHERE
-            id = ?`
+            user_id = ?
+        AND
+            expected_purchase_date = ?
+        ORDER BY
+            shop IS NULL, shop, id`
 
-	var shoppingItem model.ShoppingItem
-	if err := r.MySQLHandler.conn.QueryRowx(query, shoppingItemID).StructScan(&shoppingItem); err != nil {
-		return shoppingItem, err
+	shoppingList := model.ShoppingList{
+		ShoppingList: make([]model.ShoppingItem, 0),
 	}
 
-	return shoppingItem, nil
+	rows, err := r.MySQLHandler.conn.Queryx(query, userID, date)
+	if err != nil {
+		return shoppingList, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var shoppingItem model.ShoppingItem
+		if err := rows.StructScan(&shoppingItem); err != nil {
+			return shoppingList, err
+		}
+
+		shoppingList.ShoppingList = append(shoppingList.ShoppingList, shoppingItem)
+	}
+
+	if err := rows.Err(); err != nil {
+		return shoppingList, err
+	}
+
+	return shoppingList, nil
 }
 
-func (r *ShoppingListRepository) PostShoppingItem(shoppingItem *model.ShoppingItem, userID string) (sql.Result, error) {
+func (r *ShoppingListRepository) GetMonthlyShoppingListByShop(firstDay time.Time, lastDay time.Time, userID string) (model.ShoppingList, error) {
 	query := `
-        INSERT INTO shopping_list
-        (
+        SELECT
+            id,
+            posted_date,
+            updated_date,
             expected_purchase_date,
+            complete_flag,
             purchase,
             shop,
             amount,
+            quantity,
+            unit,
+            unit_price,
             big_category_id,
             medium_category_id,
             custom_category_id,
-            user_id,
-            transaction_auto_add
-        )
-        VALUES
-            (?,?,?,?,?,?,?,?,?)`
+            regular_shopping_list_id,
+            transaction_auto_add,
+            transaction_id
+        FROM
+            shopping_list
+        WHERE
+            user_id = ?
+        AND
+            expected_purchase_date >= ?
+        AND
+            expected_purchase_date <= ?
+        ORDER BY
+            shop IS NULL, shop, expected_purchase_date, id`
 
-	result, err := r.MySQLHandler.conn.Exec(
-		query,
+	shoppingList := model.ShoppingList{
+		ShoppingList: make([]model.ShoppingItem, 0),
+	}
+
+	rows, err := r.MySQLHandler.conn.Queryx(query, userID, firstDay, lastDay)
+	if err != nil {
+		return shoppingList, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var shoppingItem model.ShoppingItem
+		if err := rows.StructScan(&shoppingItem); err != nil {
+			return shoppingList, err
+		}
+
+		shoppingList.ShoppingList = append(shoppingList.ShoppingList, shoppingItem)
+	}
+
+	if err := rows.Err(); err != nil {
+		return shoppingList, err
+	}
+
+	return shoppingList, nil
+}
+
+func (r *ShoppingListRepository) GetExpiredShoppingList(ctx context.Context, dueDate time.Time, userID string) (model.ExpiredShoppingList, error) {
+	query := `
+        SELECT
+            id,
+            posted_date,
+            updated_date,
+            expected_purchase_date,
+            complete_flag,
+            purchase,
+            shop,
+            amount,
+            quantity,
+            unit,
+            unit_price,
+            big_category_id,
+            medium_category_id,
+            custom_category_id,
+            regular_shopping_list_id,
+            transaction_auto_add,
+            transaction_id
+        FROM
+            shopping_list
+        WHERE
+            user_id = ?
+        AND
+            complete_flag = false
+        AND
+            expected_purchase_date <= ?
+        ORDER BY
+            expected_purchase_date`
+
+	expiredShoppingList := model.ExpiredShoppingList{
+		ExpiredShoppingList: make([]model.ShoppingItem, 0),
+	}
+
+	rows, err := r.MySQLHandler.conn.QueryxContext(ctx, query, userID, dueDate)
+	if err != nil {
+		return expiredShoppingList, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var expiredShoppingItem model.ShoppingItem
+		if err := rows.StructScan(&expiredShoppingItem); err != nil {
+			return expiredShoppingList, err
+		}
+
+		expiredShoppingList.ExpiredShoppingList = append(expiredShoppingList.ExpiredShoppingList, expiredShoppingItem)
+	}
+
+	if err := rows.Err(); err != nil {
+		return expiredShoppingList, err
+	}
+
+	return expiredShoppingList, nil
+}
+
+func (r *ShoppingListRepository) GetShoppingItem(shoppingItemID int) (model.ShoppingItem, error) {
+	query := `
+        SELECT
+            id,
+            posted_date,
+            updated_date,
+            expected_purchase_date,
+            complete_flag,
+            purchase,
+            shop,
+            amount,
+            quantity,
+            unit,
+            unit_price,
+            big_category_id,
+            medium_category_id,
+            custom_category_id,
+            regular_shopping_list_id,
+            transaction_auto_add,
+            transaction_id
+        FROM
+            shopping_list
+        WHERE
+            id = ?`
+
+	var shoppingItem model.ShoppingItem
+	if err := r.MySQLHandler.conn.QueryRowx(query, shoppingItemID).StructScan(&shoppingItem); err != nil {
+		return shoppingItem, err
+	}
+
+	return shoppingItem, nil
+}
+
+func (r *ShoppingListRepository) GetShoppingItemPriceList(userID string) ([]model.ShoppingItemPrice, error) {
+	query := `
+        SELECT
+            id,
+            expected_purchase_date,
+            purchase,
+            shop,
+            amount,
+            quantity,
+            transaction_id
+        FROM
+            shopping_list
+        WHERE
+            user_id = ?
+        AND
+            complete_flag = true
+        AND
+            (amount IS NOT NULL OR transaction_id IS NOT NULL)
+        ORDER BY
+            expected_purchase_date DESC, id DESC`
+
+	shoppingItemPriceList := make([]model.ShoppingItemPrice, 0)
+
+	rows, err := r.MySQLHandler.conn.Queryx(query, userID)
+	if err != nil {
+		return shoppingItemPriceList, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var shoppingItemPrice model.ShoppingItemPrice
+		if err := rows.StructScan(&shoppingItemPrice); err != nil {
+			return shoppingItemPriceList, err
+		}
+
+		shoppingItemPriceList = append(shoppingItemPriceList, shoppingItemPrice)
+	}
+
+	if err := rows.Err(); err != nil {
+		return shoppingItemPriceList, err
+	}
+
+	return shoppingItemPriceList, nil
+}
+
+// GetShoppingItemPriceListByPurchase gets at most limit latest prices of the purchase.
+// normalizedPurchase is expected to have its spaces collapsed and its width and case folded, and is matched
+// by collapsing the spaces of each purchase and comparing them with a width and case insensitive collation.
+func (r *ShoppingListRepository) GetShoppingItemPriceListByPurchase(userID string, normalizedPurchase string, limit int) ([]model.ShoppingItemPrice, error) {
+	query := `
+        SELECT
+            id,
+            expected_purchase_date,
+            purchase,
+            shop,
+            amount,
+            quantity,
+            transaction_id
+        FROM
+            shopping_list
+        WHERE
+            user_id = ?
+        AND
+            complete_flag = true
+        AND
+            (amount IS NOT NULL OR transaction_id IS NOT NULL)
+        AND
+            REGEXP_REPLACE(REGEXP_REPLACE(purchase, '^[[:space:]]+|[[:space:]]+$', ''), '[[:space:]]+', ' ') COLLATE utf8mb4_0900_ai_ci = ?
+        ORDER BY
+            expected_purchase_date DESC, id DESC
+        LIMIT ?`
+
+	shoppingItemPriceList := make([]model.ShoppingItemPrice, 0)
+
+	rows, err := r.MySQLHandler.conn.Queryx(query, userID, normalizedPurchase, limit)
+	if err != nil {
+		return shoppingItemPriceList, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var shoppingItemPrice model.ShoppingItemPrice
+		if err := rows.StructScan(&shoppingItemPrice); err != nil {
+			return shoppingItemPriceList, err
+		}
+
+		shoppingItemPriceList = append(shoppingItemPriceList, shoppingItemPrice)
+	}
+
+	if err := rows.Err(); err != nil {
+		return shoppingItemPriceList, err
+	}
+
+	return shoppingItemPriceList, nil
+}
+
+func (r *ShoppingListRepository) PostShoppingItem(shoppingItem *model.ShoppingItem, userID string) (sql.Result, error) {
+	query := `
+        INSERT INTO shopping_list
+        (
+            expected_purchase_date,
+            purchase,
+            shop,
+            amount,
+            quantity,
+            unit,
+            unit_price,
+            big_category_id,
+            medium_category_id,
+            custom_category_id,
+            user_id,
+            transaction_auto_add
+        )
+        VALUES
+            (?,?,?,?,?,?,?,?,?,?,?,?)`
+
+	result, err := r.MySQLHandler.conn.Exec(
+		query,
 		shoppingItem.ExpectedPurchaseDate,
 		shoppingItem.Purchase,
 		shoppingItem.Shop,
 		shoppingItem.Amount,
+		shoppingItem.Quantity,
+		shoppingItem.Unit,
+		shoppingItem.UnitPrice,
 		shoppingItem.BigCategoryID,
 		shoppingItem.MediumCategoryID,
 		shoppingItem.CustomCategoryID,
@@ -956,6 +1735,9 @@ func (r *ShoppingListRepository) PutShoppingItem(shoppingItem *model.ShoppingIte
             purchase = ?,
             shop = ?,
             amount = ?,
+            quantity = ?,
+            unit = ?,
+            unit_price = ?,
             big_category_id = ?,
             medium_category_id = ?,
             custom_category_id = ?,
@@ -980,6 +1762,9 @@ func (r *ShoppingListRepository) PutShoppingItem(shoppingItem *model.ShoppingIte
 		shoppingItem.Purchase,
 		shoppingItem.Shop,
 		shoppingItem.Amount,
+		shoppingItem.Quantity,
+		shoppingItem.Unit,
+		shoppingItem.UnitPrice,
 		shoppingItem.BigCategoryID,
 		shoppingItem.MediumCategoryID,
 		shoppingItem.CustomCategoryID,
@@ -992,6 +1777,87 @@ func (r *ShoppingListRepository) PutShoppingItem(shoppingItem *model.ShoppingIte
 	return result, err
 }
 
+func (r *ShoppingListRepository) PutShoppingItemWithTransactionCommand(shoppingItem *model.ShoppingItem, transactionCommand *model.TransactionCommand) (sql.Result, error) {
+	query := `
+        UPDATE
+            shopping_list
+        SET 
+            expected_purchase_date = ?,
+            complete_flag = ?,
+            purchase = ?,
+            shop = ?,
+            amount = ?,
+            quantity = ?,
+            unit = ?,
+            unit_price = ?,
+            big_category_id = ?,
+            medium_category_id = ?,
+            custom_category_id = ?,
+            regular_shopping_list_id = ?,
+            transaction_auto_add = ?,
+            transaction_id = ?
+        WHERE
+            id = ?`
+
+	relatedTransactionID := func(relatedTransactionData *model.TransactionData) *int64 {
+		if relatedTransactionData != nil {
+			return &relatedTransactionData.ID.Int64
+		}
+
+		return nil
+	}
+
+	tx, err := r.MySQLHandler.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	var transactionCommandResult sql.Result
+	transactions := func(tx *sql.Tx) error {
+		if _, err := tx.Exec(
+			query,
+			shoppingItem.ExpectedPurchaseDate,
+			shoppingItem.CompleteFlag,
+			shoppingItem.Purchase,
+			shoppingItem.Shop,
+			shoppingItem.Amount,
+			shoppingItem.Quantity,
+			shoppingItem.Unit,
+			shoppingItem.UnitPrice,
+			shoppingItem.BigCategoryID,
+			shoppingItem.MediumCategoryID,
+			shoppingItem.CustomCategoryID,
+			shoppingItem.RegularShoppingListID,
+			shoppingItem.TransactionAutoAdd,
+			relatedTransactionID(shoppingItem.RelatedTransactionData),
+			shoppingItem.ID,
+		); err != nil {
+			return err
+		}
+
+		transactionCommandResult, err = postTransactionCommand(tx, transactionCommand)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+
+	if err := transactions(tx); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return nil, err
+		}
+
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return transactionCommandResult, nil
+}
+
 func (r *ShoppingListRepository) DeleteShoppingItem(shoppingItemID int) error {
 	query := `
         DELETE
@@ -1063,3 +1929,442 @@ func (r *ShoppingListRepository) PutShoppingListCustomCategoryIdToMediumCategory
 
 	return nil
 }
+
+func (r *ShoppingListRepository) PutShoppingListCustomCategoryIdToCustomCategoryId(replaceCustomCategoryID int, customCategoryID int) error {
+	updateShoppingListQuery := `
+        UPDATE
+            shopping_list
+        SET 
+            custom_category_id = ?
+        WHERE
+            custom_category_id = ?`
+
+	updateRegularShoppingListQuery := `
+        UPDATE
+            regular_shopping_list
+        SET 
+            custom_category_id = ?
+        WHERE
+            custom_category_id = ?`
+
+	tx, err := r.MySQLHandler.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	transactions := func(tx *sql.Tx) error {
+		if _, err := tx.Exec(
+			updateShoppingListQuery,
+			replaceCustomCategoryID,
+			customCategoryID,
+		); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(
+			updateRegularShoppingListQuery,
+			replaceCustomCategoryID,
+			customCategoryID,
+		); err != nil {
+			return err
+		}
+
+		return nil
+	}
+
+	if err := transactions(tx); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return err
+		}
+
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ShoppingListRepository) MergeShoppingListCustomCategory(customCategoriesMerge *model.CustomCategoriesMerge) (*model.MergedShoppingItemsID, error) {
+	selectCustomCategoryMergeQuery := `
+        SELECT
+            target_custom_category_id,
+            shopping_item_id_list,
+            regular_shopping_item_id_list
+        FROM
+            shopping_list_custom_category_merges
+        WHERE
+            source_custom_category_id = ?
+        FOR UPDATE`
+
+	selectShoppingListQuery := `
+        SELECT
+            id
+        FROM
+            shopping_list
+        WHERE
+            custom_category_id = ?
+        FOR UPDATE`
+
+	selectRegularShoppingListQuery := `
+        SELECT
+            id
+        FROM
+            regular_shopping_list
+        WHERE
+            custom_category_id = ?
+        FOR UPDATE`
+
+	updateShoppingListQuery := `
+        UPDATE
+            shopping_list
+        SET 
+            custom_category_id = ?
+        WHERE
+            custom_category_id = ?`
+
+	updateRegularShoppingListQuery := `
+        UPDATE
+            regular_shopping_list
+        SET 
+            custom_category_id = ?
+        WHERE
+            custom_category_id = ?`
+
+	upsertCustomCategoryMergeQuery := `
+        INSERT INTO shopping_list_custom_category_merges
+            (source_custom_category_id, target_custom_category_id, shopping_item_id_list, regular_shopping_item_id_list)
+        VALUES
+            (?,?,?,?)
+        ON DUPLICATE KEY UPDATE
+            target_custom_category_id = VALUES(target_custom_category_id),
+            shopping_item_id_list = VALUES(shopping_item_id_list),
+            regular_shopping_item_id_list = VALUES(regular_shopping_item_id_list),
+            merged_date = CURRENT_TIMESTAMP`
+
+	mergedShoppingItemsID := model.MergedShoppingItemsID{
+		ShoppingItemIDList:        make([]int, 0),
+		RegularShoppingItemIDList: make([]int, 0),
+	}
+
+	selectIDList := func(tx *sql.Tx, query string) ([]int, error) {
+		rows, err := tx.Query(query, customCategoriesMerge.SourceCustomCategoryID)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		idList := make([]int, 0)
+		for rows.Next() {
+			var id int
+			if err := rows.Scan(&id); err != nil {
+				return nil, err
+			}
+
+			idList = append(idList, id)
+		}
+
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+
+		return idList, nil
+	}
+
+	tx, err := r.MySQLHandler.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := func(tx *sql.Tx) error {
+		// A retried merge finds the shopping items already moved by the previous attempt under the target category,
+		// so the recorded ID lists are carried over to keep the response the same as the first attempt's.
+		var targetCustomCategoryID int
+		var shoppingItemIDList, regularShoppingItemIDList []byte
+		if err := tx.QueryRow(selectCustomCategoryMergeQuery, customCategoriesMerge.SourceCustomCategoryID).Scan(
+			&targetCustomCategoryID,
+			&shoppingItemIDList,
+			&regularShoppingItemIDList,
+		); err != nil && err != sql.ErrNoRows {
+			return err
+		} else if err == nil && targetCustomCategoryID == customCategoriesMerge.TargetCustomCategoryID {
+			if err := json.Unmarshal(shoppingItemIDList, &mergedShoppingItemsID.ShoppingItemIDList); err != nil {
+				return err
+			}
+
+			if err := json.Unmarshal(regularShoppingItemIDList, &mergedShoppingItemsID.RegularShoppingItemIDList); err != nil {
+				return err
+			}
+		}
+
+		idList, err := selectIDList(tx, selectShoppingListQuery)
+		if err != nil {
+			return err
+		}
+
+		mergedShoppingItemsID.ShoppingItemIDList = append(mergedShoppingItemsID.ShoppingItemIDList, idList...)
+
+		regularIDList, err := selectIDList(tx, selectRegularShoppingListQuery)
+		if err != nil {
+			return err
+		}
+
+		mergedShoppingItemsID.RegularShoppingItemIDList = append(mergedShoppingItemsID.RegularShoppingItemIDList, regularIDList...)
+
+		if _, err := tx.Exec(
+			updateShoppingListQuery,
+			customCategoriesMerge.TargetCustomCategoryID,
+			customCategoriesMerge.SourceCustomCategoryID,
+		); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(
+			updateRegularShoppingListQuery,
+			customCategoriesMerge.TargetCustomCategoryID,
+			customCategoriesMerge.SourceCustomCategoryID,
+		); err != nil {
+			return err
+		}
+
+		if shoppingItemIDList, err = json.Marshal(mergedShoppingItemsID.ShoppingItemIDList); err != nil {
+			return err
+		}
+
+		if regularShoppingItemIDList, err = json.Marshal(mergedShoppingItemsID.RegularShoppingItemIDList); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(
+			upsertCustomCategoryMergeQuery,
+			customCategoriesMerge.SourceCustomCategoryID,
+			customCategoriesMerge.TargetCustomCategoryID,
+			shoppingItemIDList,
+			regularShoppingItemIDList,
+		); err != nil {
+			return err
+		}
+
+		return nil
+	}
+
+	if err := transactions(tx); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return nil, err
+		}
+
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &mergedShoppingItemsID, nil
+}
+
+func (r *ShoppingListRepository) RevertShoppingListCustomCategoryMerge(mergedShoppingItemsID *model.MergedShoppingItemsID) error {
+	updateShoppingItemQuery := `
+        UPDATE
+            shopping_list
+        SET 
+            custom_category_id = ?
+        WHERE
+            id = ?`
+
+	updateRegularShoppingItemQuery := `
+        UPDATE
+            regular_shopping_list
+        SET 
+            custom_category_id = ?
+        WHERE
+            id = ?`
+
+	deleteCustomCategoryMergeQuery := `
+        DELETE
+        FROM
+            shopping_list_custom_category_merges
+        WHERE
+            source_custom_category_id = ?`
+
+	tx, err := r.MySQLHandler.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	transactions := func(tx *sql.Tx) error {
+		if _, err := tx.Exec(deleteCustomCategoryMergeQuery, mergedShoppingItemsID.SourceCustomCategoryID); err != nil {
+			return err
+		}
+
+		for _, shoppingItemID := range mergedShoppingItemsID.ShoppingItemIDList {
+			if _, err := tx.Exec(updateShoppingItemQuery, mergedShoppingItemsID.SourceCustomCategoryID, shoppingItemID); err != nil {
+				return err
+			}
+		}
+
+		for _, regularShoppingItemID := range mergedShoppingItemsID.RegularShoppingItemIDList {
+			if _, err := tx.Exec(updateRegularShoppingItemQuery, mergedShoppingItemsID.SourceCustomCategoryID, regularShoppingItemID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if err := transactions(tx); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return err
+		}
+
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ShoppingListRepository) GetShopSectionList(userID string) ([]model.ShopSection, error) {
+	query := `
+        SELECT
+            shop,
+            big_category_id
+        FROM
+            shop_sections
+        WHERE
+            user_id = ?
+        ORDER BY
+            shop, section_order`
+
+	rows, err := r.MySQLHandler.conn.Queryx(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	shopSectionList := make([]model.ShopSection, 0)
+	for rows.Next() {
+		var shopSection model.ShopSection
+		if err := rows.StructScan(&shopSection); err != nil {
+			return nil, err
+		}
+
+		shopSectionList = append(shopSectionList, shopSection)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return shopSectionList, nil
+}
+
+func (r *ShoppingListRepository) PutShopSectionOrder(shopSectionOrder *model.ShopSectionOrder, userID string) error {
+	deleteQuery := `
+        DELETE
+        FROM
+            shop_sections
+        WHERE
+            user_id = ?
+        AND
+            shop = ?`
+
+	insertQuery := `
+        INSERT INTO shop_sections
+            (user_id, shop, big_category_id, section_order)
+        VALUES
+            (?,?,?,?)`
+
+	tx, err := r.MySQLHandler.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	transactions := func(tx *sql.Tx) error {
+		if _, err := tx.Exec(deleteQuery, userID, shopSectionOrder.Shop); err != nil {
+			return err
+		}
+
+		for i, bigCategoryID := range shopSectionOrder.BigCategoryIDList {
+			if _, err := tx.Exec(insertQuery, userID, shopSectionOrder.Shop, bigCategoryID, i); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if err := transactions(tx); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return err
+		}
+
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ShoppingListRepository) GetTransactionRelatedShoppingList(userID string) (model.ShoppingList, error) {
+	query := `
+        SELECT
+            id,
+            posted_date,
+            updated_date,
+            expected_purchase_date,
+            complete_flag,
+            purchase,
+            shop,
+            amount,
+            quantity,
+            unit,
+            unit_price,
+            big_category_id,
+            medium_category_id,
+            custom_category_id,
+            regular_shopping_list_id,
+            transaction_auto_add,
+            transaction_id
+        FROM
+            shopping_list
+        WHERE
+            user_id = ?
+        AND
+            ((complete_flag = true AND transaction_auto_add = true) OR transaction_id IS NOT NULL)
+        ORDER BY
+            id`
+
+	shoppingList := model.ShoppingList{
+		ShoppingList: make([]model.ShoppingItem, 0),
+	}
+
+	rows, err := r.MySQLHandler.conn.Queryx(query, userID)
+	if err != nil {
+		return shoppingList, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var shoppingItem model.ShoppingItem
+		if err := rows.StructScan(&shoppingItem); err != nil {
+			return shoppingList, err
+		}
+
+		shoppingList.ShoppingList = append(shoppingList.ShoppingList, shoppingItem)
+	}
+
+	if err := rows.Err(); err != nil {
+		return shoppingList, err
+	}
+
+	return shoppingList, nil
+}
@@ -1,9 +1,12 @@
 package infrastructure
 
 import (
+	"context"
 	"database/sql"
 	"time"
 
+	"github.com/jmoiron/sqlx"
+
 	"github.com/hryze/kakeibo-app-api/todo-rest-service/domain/model"
 )
 
@@ -15,7 +18,7 @@ func NewTodoRepository(mysqlHandler *MySQLHandler) *TodoRepository {
 	return &TodoRepository{mysqlHandler}
 }
 
-func (r *TodoRepository) GetDailyImplementationTodoList(date time.Time, userID string) ([]model.Todo, error) {
+func (r *TodoRepository) GetDailyImplementationTodoList(ctx context.Context, date time.Time, userID string) ([]model.Todo, error) {
 	query := `
         SELECT
             id,
@@ -24,7 +27,13 @@ func (r *TodoRepository) GetDailyImplementationTodoList(date time.Time, userID s
             implementation_date,
             due_date,
             todo_content,
-            complete_flag
+            complete_flag,
+            recurrence_type,
+            recurrence_rule,
+            recurrence_interval,
+            recurrence_start_date,
+            series_id,
+            priority
         FROM
             todo_list
         WHERE
@@ -32,7 +41,7 @@ func (r *TodoRepository) GetDailyImplementationTodoList(date time.Time, userID s
         AND
             implementation_date = ?`
 
-	rows, err := r.MySQLHandler.conn.Queryx(query, userID, date)
+	rows, err := r.MySQLHandler.conn.QueryxContext(ctx, query, userID, date)
 	if err != nil {
 		return nil, err
 	}
@@ -52,10 +61,14 @@ func (r *TodoRepository) GetDailyImplementationTodoList(date time.Time, userID s
 		return nil, err
 	}
 
+	if err := r.setTodoListDetails(ctx, implementationTodoList); err != nil {
+		return nil, err
+	}
+
 	return implementationTodoList, nil
 }
 
-func (r *TodoRepository) GetDailyDueTodoList(date time.Time, userID string) ([]model.Todo, error) {
+func (r *TodoRepository) GetDailyDueTodoList(ctx context.Context, date time.Time, userID string) ([]model.Todo, error) {
 	query := `
         SELECT
             id,
@@ -64,7 +77,13 @@ func (r *TodoRepository) GetDailyDueTodoList(date time.Time, userID string) ([]m
             implementation_date,
             due_date,
             todo_content,
-            complete_flag
+            complete_flag,
+            recurrence_type,
+            recurrence_rule,
+            recurrence_interval,
+            recurrence_start_date,
+            series_id,
+            priority
         FROM
             todo_list
         WHERE
@@ -72,7 +91,7 @@ func (r *TodoRepository) GetDailyDueTodoList(date time.Time, userID string) ([]m
         AND
             due_date = ?`
 
-	rows, err := r.MySQLHandler.conn.Queryx(query, userID, date)
+	rows, err := r.MySQLHandler.conn.QueryxContext(ctx, query, userID, date)
 	if err != nil {
 		return nil, err
 	}
@@ -92,6 +111,10 @@ func (r *TodoRepository) GetDailyDueTodoList(date time.Time, userID string) ([]m
 		return nil, err
 	}
 
+	if err := r.setTodoListDetails(ctx, dueTodoList); err != nil {
+		return nil, err
+	}
+
 	return dueTodoList, nil
 }
 
@@ -104,7 +127,13 @@ func (r *TodoRepository) GetMonthlyImplementationTodoList(firstDay time.Time, la
             implementation_date,
             due_date,
             todo_content,
-            complete_flag
+            complete_flag,
+            recurrence_type,
+            recurrence_rule,
+            recurrence_interval,
+            recurrence_start_date,
+            series_id,
+            priority
         FROM
             todo_list
         WHERE
@@ -136,6 +165,10 @@ func (r *TodoRepository) GetMonthlyImplementationTodoList(firstDay time.Time, la
 		return nil, err
 	}
 
+	if err := r.setTodoListDetails(context.Background(), implementationTodoList); err != nil {
+		return nil, err
+	}
+
 	return implementationTodoList, nil
 }
 
@@ -148,7 +181,13 @@ func (r *TodoRepository) GetMonthlyDueTodoList(firstDay time.Time, lastDay time.
             implementation_date,
             due_date,
             todo_content,
-            complete_flag
+            complete_flag,
+            recurrence_type,
+            recurrence_rule,
+            recurrence_interval,
+            recurrence_start_date,
+            series_id,
+            priority
         FROM
             todo_list
         WHERE
@@ -180,10 +219,14 @@ func (r *TodoRepository) GetMonthlyDueTodoList(firstDay time.Time, lastDay time.
 		return nil, err
 	}
 
+	if err := r.setTodoListDetails(context.Background(), dueTodoList); err != nil {
+		return nil, err
+	}
+
 	return dueTodoList, nil
 }
 
-func (r *TodoRepository) GetExpiredTodoList(dueDate time.Time, userID string) (*model.ExpiredTodoList, error) {
+func (r *TodoRepository) GetExpiredTodoList(ctx context.Context, dueDate time.Time, userID string) (*model.ExpiredTodoList, error) {
 	query := `
         SELECT
             id,
@@ -192,7 +235,13 @@ func (r *TodoRepository) GetExpiredTodoList(dueDate time.Time, userID string) (*
             implementation_date,
             due_date,
             todo_content,
-            complete_flag
+            complete_flag,
+            recurrence_type,
+            recurrence_rule,
+            recurrence_interval,
+            recurrence_start_date,
+            series_id,
+            priority
         FROM
             todo_list
         WHERE
@@ -204,7 +253,7 @@ func (r *TodoRepository) GetExpiredTodoList(dueDate time.Time, userID string) (*
         ORDER BY
             due_date`
 
-	rows, err := r.MySQLHandler.conn.Queryx(query, userID, dueDate)
+	rows, err := r.MySQLHandler.conn.QueryxContext(ctx, query, userID, dueDate)
 	if err != nil {
 		return nil, err
 	}
@@ -226,6 +275,10 @@ func (r *TodoRepository) GetExpiredTodoList(dueDate time.Time, userID string) (*
 		return nil, err
 	}
 
+	if err := r.setTodoListDetails(ctx, expiredTodoList.ExpiredTodoList); err != nil {
+		return nil, err
+	}
+
 	return &expiredTodoList, nil
 }
 
@@ -238,7 +291,13 @@ func (r *TodoRepository) GetTodo(todoId int) (*model.Todo, error) {
             implementation_date,
             due_date,
             todo_content,
-            complete_flag
+            complete_flag,
+            recurrence_type,
+            recurrence_rule,
+            recurrence_interval,
+            recurrence_start_date,
+            series_id,
+            priority
         FROM
             todo_list
         WHERE
@@ -249,36 +308,209 @@ func (r *TodoRepository) GetTodo(todoId int) (*model.Todo, error) {
 		return nil, err
 	}
 
-	return &todo, nil
+	todoList := []model.Todo{todo}
+	if err := r.setTodoListDetails(context.Background(), todoList); err != nil {
+		return nil, err
+	}
+
+	return &todoList[0], nil
 }
 
 func (r *TodoRepository) PostTodo(todo *model.Todo, userID string) (sql.Result, error) {
 	query := `
         INSERT INTO todo_list
-            (implementation_date, due_date, todo_content, user_id)
+            (implementation_date, due_date, todo_content, recurrence_type, recurrence_rule, recurrence_interval, recurrence_start_date, priority, user_id)
         VALUES
-            (?,?,?,?)`
+            (?,?,?,?,?,?,?,?,?)`
+
+	updateSeriesIDQuery := `
+        UPDATE
+            todo_list
+        SET
+            series_id = id
+        WHERE
+            id = ?`
+
+	tx, err := r.MySQLHandler.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := func(tx *sql.Tx) (sql.Result, error) {
+		result, err := tx.Exec(query, todo.ImplementationDate, todo.DueDate, todo.TodoContent, todo.RecurrenceType, todo.RecurrenceRule, todo.RecurrenceInterval, todo.RecurrenceStartDate, todo.Priority, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		lastInsertId, err := result.LastInsertId()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := insertTodoDetails(tx, int(lastInsertId), todo.LabelList, todo.SubtaskList); err != nil {
+			return nil, err
+		}
+
+		if !todo.RecurrenceType.Valid {
+			return result, nil
+		}
 
-	result, err := r.MySQLHandler.conn.Exec(query, todo.ImplementationDate, todo.DueDate, todo.TodoContent, userID)
+		if _, err := tx.Exec(updateSeriesIDQuery, lastInsertId); err != nil {
+			return nil, err
+		}
+
+		return result, nil
+	}
+
+	result, err := transactions(tx)
+	if err != nil {
+		if err := tx.Rollback(); err != nil {
+			return nil, err
+		}
+
+		return nil, err
+	}
 
-	return result, err
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
 }
 
-func (r *TodoRepository) PutTodo(todo *model.Todo, todoID int) error {
-	query := `
+func (r *TodoRepository) PutTodo(todo *model.Todo, todoID int, editScope string, nextTodo *model.Todo) error {
+	lockTodoQuery := `
+        SELECT
+            complete_flag
+        FROM
+            todo_list
+        WHERE
+            id = ?
+        FOR UPDATE`
+
+	updateFollowingTodoListQuery := `
+        UPDATE
+            todo_list
+        SET
+            todo_content = ?,
+            recurrence_type = ?,
+            recurrence_rule = ?,
+            recurrence_interval = ?,
+            recurrence_start_date = ?,
+            priority = ?
+        WHERE
+            series_id = ?
+        AND
+            id > ?
+        AND
+            complete_flag = b'0'`
+
+	updateTodoQuery := `
         UPDATE
             todo_list
         SET 
             implementation_date = ?,
             due_date = ?,
             todo_content = ?,
-            complete_flag = ?
+            complete_flag = ?,
+            recurrence_type = ?,
+            recurrence_rule = ?,
+            recurrence_interval = ?,
+            recurrence_start_date = ?,
+            series_id = ?,
+            priority = ?
+        WHERE
+            id = ?`
+
+	existsNextTodoQuery := `
+        SELECT
+            EXISTS (
+                SELECT
+                    id
+                FROM
+                    todo_list
+                WHERE
+                    series_id = ?
+                AND
+                    id > ?
+            )`
+
+	insertNextTodoQuery := `
+        INSERT INTO todo_list
+            (implementation_date, due_date, todo_content, recurrence_type, recurrence_rule, recurrence_interval, recurrence_start_date, series_id, priority, user_id)
+        SELECT
+            ?,?,?,?,?,?,?,?,?,user_id
+        FROM
+            todo_list
         WHERE
             id = ?`
 
-	_, err := r.MySQLHandler.conn.Exec(query, todo.ImplementationDate, todo.DueDate, todo.TodoContent, todo.CompleteFlag, todoID)
+	tx, err := r.MySQLHandler.conn.Begin()
+	if err != nil {
+		return err
+	}
 
-	return err
+	transactions := func(tx *sql.Tx) error {
+		var completeFlag model.BitBool
+		if err := tx.QueryRow(lockTodoQuery, todoID).Scan(&completeFlag); err != nil {
+			return err
+		}
+
+		if editScope == model.TodoEditScopeFollowing && todo.SeriesID.Valid {
+			if _, err := tx.Exec(updateFollowingTodoListQuery, todo.TodoContent, todo.RecurrenceType, todo.RecurrenceRule, todo.RecurrenceInterval, todo.RecurrenceStartDate, todo.Priority, todo.SeriesID, todoID); err != nil {
+				return err
+			}
+		}
+
+		if _, err := tx.Exec(updateTodoQuery, todo.ImplementationDate, todo.DueDate, todo.TodoContent, todo.CompleteFlag, todo.RecurrenceType, todo.RecurrenceRule, todo.RecurrenceInterval, todo.RecurrenceStartDate, todo.SeriesID, todo.Priority, todoID); err != nil {
+			return err
+		}
+
+		if err := replaceTodoDetails(tx, todoID, todo.LabelList, todo.SubtaskList); err != nil {
+			return err
+		}
+
+		// The next instance is spawned only once per instance, even when the instance is completed again after being reopened,
+		// or completed by concurrent requests.
+		if nextTodo == nil || completeFlag {
+			return nil
+		}
+
+		var existsNextTodo bool
+		if err := tx.QueryRow(existsNextTodoQuery, nextTodo.SeriesID, todoID).Scan(&existsNextTodo); err != nil {
+			return err
+		}
+
+		if existsNextTodo {
+			return nil
+		}
+
+		result, err := tx.Exec(insertNextTodoQuery, nextTodo.ImplementationDate, nextTodo.DueDate, nextTodo.TodoContent, nextTodo.RecurrenceType, nextTodo.RecurrenceRule, nextTodo.RecurrenceInterval, nextTodo.RecurrenceStartDate, nextTodo.SeriesID, nextTodo.Priority, todoID)
+		if err != nil {
+			return err
+		}
+
+		nextTodoID, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+
+		return insertTodoDetails(tx, int(nextTodoID), nextTodo.LabelList, nextTodo.SubtaskList)
+	}
+
+	if err := transactions(tx); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return err
+		}
+
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
 }
 
 func (r *TodoRepository) DeleteTodo(todoID int) error {
@@ -294,8 +526,10 @@ func (r *TodoRepository) DeleteTodo(todoID int) error {
 	return err
 }
 
-func (r *TodoRepository) SearchTodoList(todoSqlQuery string) ([]model.Todo, error) {
-	rows, err := r.MySQLHandler.conn.Queryx(todoSqlQuery)
+func (r *TodoRepository) SearchTodoList(todoSearchCriteria model.TodoSearchCriteria, userID string) ([]model.Todo, error) {
+	query, args := generateTodoSearchQuery(todoSearchCriteria, userID)
+
+	rows, err := r.MySQLHandler.conn.Queryx(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -315,5 +549,157 @@ func (r *TodoRepository) SearchTodoList(todoSqlQuery string) ([]model.Todo, erro
 		return nil, err
 	}
 
+	if err := r.setTodoListDetails(context.Background(), searchTodoList); err != nil {
+		return nil, err
+	}
+
 	return searchTodoList, nil
 }
+
+// setTodoListDetails sets the labels and the subtasks to each todo of todoList.
+func (r *TodoRepository) setTodoListDetails(ctx context.Context, todoList []model.Todo) error {
+	if len(todoList) == 0 {
+		return nil
+	}
+
+	labelListQuery := `
+        SELECT
+            todo_id,
+            label_name
+        FROM
+            todo_labels
+        WHERE
+            todo_id IN (?)
+        ORDER BY
+            id`
+
+	subtaskListQuery := `
+        SELECT
+            id,
+            todo_id,
+            subtask_content,
+            complete_flag
+        FROM
+            todo_subtasks
+        WHERE
+            todo_id IN (?)
+        ORDER BY
+            subtask_order`
+
+	todoIndexes := make(map[int]int, len(todoList))
+	todoIDList := make([]int, 0, len(todoList))
+	for i := range todoList {
+		todoList[i].LabelList = make([]string, 0)
+		todoList[i].SubtaskList = make([]model.TodoSubtask, 0)
+		todoIndexes[todoList[i].ID] = i
+		todoIDList = append(todoIDList, todoList[i].ID)
+	}
+
+	query, args, err := sqlx.In(labelListQuery, todoIDList)
+	if err != nil {
+		return err
+	}
+
+	labelRows, err := r.MySQLHandler.conn.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer labelRows.Close()
+
+	for labelRows.Next() {
+		var todoID int
+		var labelName string
+		if err := labelRows.Scan(&todoID, &labelName); err != nil {
+			return err
+		}
+
+		todo := &todoList[todoIndexes[todoID]]
+		todo.LabelList = append(todo.LabelList, labelName)
+	}
+
+	if err := labelRows.Err(); err != nil {
+		return err
+	}
+
+	query, args, err = sqlx.In(subtaskListQuery, todoIDList)
+	if err != nil {
+		return err
+	}
+
+	subtaskRows, err := r.MySQLHandler.conn.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer subtaskRows.Close()
+
+	for subtaskRows.Next() {
+		var todoID int
+		var subtask model.TodoSubtask
+		if err := subtaskRows.Scan(&subtask.ID, &todoID, &subtask.SubtaskContent, &subtask.CompleteFlag); err != nil {
+			return err
+		}
+
+		todo := &todoList[todoIndexes[todoID]]
+		todo.SubtaskList = append(todo.SubtaskList, subtask)
+	}
+
+	return subtaskRows.Err()
+}
+
+// insertTodoDetails inserts the labels and the subtasks of the todo in the order of the lists.
+func insertTodoDetails(tx *sql.Tx, todoID int, labelList []string, subtaskList []model.TodoSubtask) error {
+	insertLabelQuery := `
+        INSERT INTO todo_labels
+            (todo_id, label_name)
+        VALUES
+            (?,?)`
+
+	insertSubtaskQuery := `
+        INSERT INTO todo_subtasks
+            (id, todo_id, subtask_content, complete_flag, subtask_order)
+        VALUES
+            (?,?,?,?,?)`
+
+	for _, labelName := range labelList {
+		if _, err := tx.Exec(insertLabelQuery, todoID, labelName); err != nil {
+			return err
+		}
+	}
+
+	for i, subtask := range subtaskList {
+		// A new subtask is numbered by AUTO_INCREMENT, while an existing subtask keeps its ID.
+		subtaskID := sql.NullInt64{Int64: int64(subtask.ID), Valid: subtask.ID != 0}
+		if _, err := tx.Exec(insertSubtaskQuery, subtaskID, todoID, subtask.SubtaskContent, subtask.CompleteFlag, i+1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// replaceTodoDetails replaces the labels and the subtasks of the todo.
+func replaceTodoDetails(tx *sql.Tx, todoID int, labelList []string, subtaskList []model.TodoSubtask) error {
+	deleteLabelListQuery := `
+        DELETE
+        FROM
+            todo_labels
+        WHERE
+            todo_id = ?`
+
+	deleteSubtaskListQuery := `
+        DELETE
+        FROM
+            todo_subtasks
+        WHERE
+            todo_id = ?`
+
+	if _, err := tx.Exec(deleteLabelListQuery, todoID); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(deleteSubtaskListQuery, todoID); err != nil {
+		return err
+	}
+
+	return insertTodoDetails(tx, todoID, labelList, subtaskList)
+}
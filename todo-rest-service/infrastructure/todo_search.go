@@ -0,0 +1,198 @@
+package infrastructure
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hryze/kakeibo-app-api/todo-rest-service/domain/model"
+)
+
+// todoSearchTable is the tables of the todo list searched by todoSearchQueryBuilder.
+type todoSearchTable struct {
+	todoTable  string
+	labelTable string
+}
+
+var (
+	todoListSearchTable      = todoSearchTable{todoTable: "todo_list", labelTable: "todo_labels"}
+	groupTodoListSearchTable = todoSearchTable{todoTable: "group_todo_list", labelTable: "group_todo_labels"}
+)
+
+// todoSearchDateColumns and todoSearchSortColumns are the allow-lists of the columns used in the todo search,
+// so that any value given by the user is never written in the query.
+var todoSearchDateColumns = map[model.TodoSearchDateType]string{
+	model.TodoSearchDateTypeImplementation: "implementation_date",
+	model.TodoSearchDateTypeDue:            "due_date",
+}
+
+var todoSearchSortColumns = map[model.TodoSearchSort]string{
+	model.TodoSearchSortImplementationDate: "implementation_date",
+	model.TodoSearchSortDueDate:            "due_date",
+	model.TodoSearchSortPostedDate:         "posted_date",
+	model.TodoSearchSortUpdatedDate:        "updated_date",
+	model.TodoSearchSortTodoContent:        "todo_content",
+	model.TodoSearchSortCompleteFlag:       "complete_flag",
+	model.TodoSearchSortPriority:           "priority",
+}
+
+var todoSearchSortTypes = map[model.TodoSearchSortType]string{
+	model.TodoSearchSortTypeAsc:  "ASC",
+	model.TodoSearchSortTypeDesc: "DESC",
+}
+
+// todoSearchLikeReplacer escapes the wildcards in the todo content with the default escape character of LIKE.
+var todoSearchLikeReplacer = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// todoSearchQueryBuilder builds the conditions of the todo search with the placeholders,
+// and keeps the values to be bound to them in args.
+type todoSearchQueryBuilder struct {
+	table      todoSearchTable
+	conditions []string
+	args       []interface{}
+}
+
+func newTodoSearchQueryBuilder(table todoSearchTable) *todoSearchQueryBuilder {
+	return &todoSearchQueryBuilder{
+		table: table,
+	}
+}
+
+func (b *todoSearchQueryBuilder) where(condition string, args ...interface{}) {
+	b.conditions = append(b.conditions, condition)
+	b.args = append(b.args, args...)
+}
+
+func (b *todoSearchQueryBuilder) whereIn(column string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+
+	placeholders := make([]string, len(values))
+	args := make([]interface{}, len(values))
+	for i, value := range values {
+		placeholders[i] = "?"
+		args[i] = value
+	}
+
+	b.where(fmt.Sprintf("%s IN (%s)", column, strings.Join(placeholders, ", ")), args...)
+}
+
+func (b *todoSearchQueryBuilder) whereCriteria(criteria model.TodoSearchCriteria) {
+	dateColumn, ok := todoSearchDateColumns[criteria.DateType]
+	if !ok {
+		dateColumn = todoSearchDateColumns[model.TodoSearchDateTypeImplementation]
+	}
+
+	if criteria.StartDate.Valid {
+		b.where(dateColumn+" >= ?", criteria.StartDate.Time)
+	}
+
+	if criteria.EndDate.Valid {
+		b.where(dateColumn+" <= ?", criteria.EndDate.Time)
+	}
+
+	if criteria.CompleteFlag.Valid {
+		b.where("complete_flag = ?", criteria.CompleteFlag.Bool)
+	}
+
+	if len(criteria.TodoContent) != 0 {
+		b.where("todo_content LIKE ?", "%"+todoSearchLikeReplacer.Replace(criteria.TodoContent)+"%")
+	}
+
+	if criteria.Priority.Valid {
+		b.where("priority = ?", criteria.Priority.Int)
+	}
+
+	if len(criteria.Label) != 0 {
+		b.where(fmt.Sprintf(
+			"EXISTS (SELECT id FROM %[1]s WHERE %[1]s.todo_id = %[2]s.id AND label_name = ?)",
+			b.table.labelTable, b.table.todoTable,
+		), criteria.Label)
+	}
+}
+
+func (b *todoSearchQueryBuilder) orderBy(criteria model.TodoSearchCriteria) string {
+	sortColumn, ok := todoSearchSortColumns[criteria.Sort]
+	if !ok {
+		sortColumn = todoSearchSortColumns[model.TodoSearchSortImplementationDate]
+	}
+
+	if criteria.Sort == model.TodoSearchSortLabel {
+		sortColumn = fmt.Sprintf(
+			"(SELECT MIN(label_name) FROM %[1]s WHERE %[1]s.todo_id = %[2]s.id)",
+			b.table.labelTable, b.table.todoTable,
+		)
+	}
+
+	sortType, ok := todoSearchSortTypes[criteria.SortType]
+	if !ok {
+		sortType = todoSearchSortTypes[model.TodoSearchSortTypeAsc]
+	}
+
+	return fmt.Sprintf("ORDER BY %s %s, updated_date DESC", sortColumn, sortType)
+}
+
+func (b *todoSearchQueryBuilder) build(columns string, criteria model.TodoSearchCriteria) (string, []interface{}) {
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE %s %s",
+		columns, b.table.todoTable, strings.Join(b.conditions, " AND "), b.orderBy(criteria),
+	)
+
+	args := b.args
+	if criteria.Limit.Valid {
+		query += " LIMIT ?"
+		args = append(args, criteria.Limit.Int)
+	}
+
+	return query, args
+}
+
+func generateTodoSearchQuery(criteria model.TodoSearchCriteria, userID string) (string, []interface{}) {
+	columns := `
+            id,
+            posted_date,
+            updated_date,
+            implementation_date,
+            due_date,
+            todo_content,
+            complete_flag,
+            recurrence_type,
+            recurrence_rule,
+            recurrence_interval,
+            recurrence_start_date,
+            series_id,
+            priority`
+
+	b := newTodoSearchQueryBuilder(todoListSearchTable)
+	b.where("user_id = ?", userID)
+	b.whereCriteria(criteria)
+
+	return b.build(columns, criteria)
+}
+
+func generateGroupTodoSearchQuery(criteria model.GroupTodoSearchCriteria, groupID int) (string, []interface{}) {
+	columns := `
+            id,
+            posted_date,
+            updated_date,
+            implementation_date,
+            due_date,
+            todo_content,
+            complete_flag,
+            user_id,
+            recurrence_type,
+            recurrence_rule,
+            recurrence_interval,
+            recurrence_start_date,
+            series_id,
+            priority,
+            completed_user_id,
+            completed_date`
+
+	b := newTodoSearchQueryBuilder(groupTodoListSearchTable)
+	b.where("group_id = ?", groupID)
+	b.whereIn("user_id", criteria.UsersID)
+	b.whereCriteria(criteria.TodoSearchCriteria)
+
+	return b.build(columns, criteria.TodoSearchCriteria)
+}
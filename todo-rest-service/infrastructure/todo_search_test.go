@@ -0,0 +1,148 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hryze/kakeibo-app-api/todo-rest-service/domain/model"
+)
+
+// todoSearchQueryConditions returns the query after the FROM clause, with the whitespaces collapsed.
+func todoSearchQueryConditions(t *testing.T, query string) string {
+	t.Helper()
+
+	i := strings.Index(query, " FROM ")
+	if i == -1 {
+		t.Fatalf("FROM clause is not found in %q", query)
+	}
+
+	return strings.Join(strings.Fields(query[i:]), " ")
+}
+
+func TestGenerateTodoSearchQuery(t *testing.T) {
+	startDate := time.Date(2020, 7, 5, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2020, 7, 30, 23, 59, 59, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		criteria model.TodoSearchCriteria
+		want     string
+		wantArgs []interface{}
+	}{
+		{
+			name:     "default ordering",
+			criteria: model.TodoSearchCriteria{},
+			want:     "FROM todo_list WHERE user_id = ? ORDER BY implementation_date ASC, updated_date DESC",
+			wantArgs: []interface{}{"userID1"},
+		},
+		{
+			name: "all conditions",
+			criteria: model.TodoSearchCriteria{
+				DateType:     model.TodoSearchDateTypeDue,
+				StartDate:    model.NullTime{NullTime: sql.NullTime{Time: startDate, Valid: true}},
+				EndDate:      model.NullTime{NullTime: sql.NullTime{Time: endDate, Valid: true}},
+				CompleteFlag: sql.NullBool{Bool: true, Valid: true},
+				TodoContent:  "予算",
+				Priority:     model.NullInt{Int: 3, Valid: true},
+				Label:        "手続き",
+				Sort:         model.TodoSearchSortPriority,
+				SortType:     model.TodoSearchSortTypeDesc,
+				Limit:        model.NullInt{Int: 10, Valid: true},
+			},
+			want: "FROM todo_list WHERE user_id = ? AND due_date >= ? AND due_date <= ? AND complete_flag = ? AND todo_content LIKE ? AND priority = ?" +
+				" AND EXISTS (SELECT id FROM todo_labels WHERE todo_labels.todo_id = todo_list.id AND label_name = ?)" +
+				" ORDER BY priority DESC, updated_date DESC LIMIT ?",
+			wantArgs: []interface{}{"userID1", startDate, endDate, true, "%予算%", 3, "手続き", 10},
+		},
+		{
+			name:     "sort by label",
+			criteria: model.TodoSearchCriteria{Sort: model.TodoSearchSortLabel},
+			want:     "FROM todo_list WHERE user_id = ? ORDER BY (SELECT MIN(label_name) FROM todo_labels WHERE todo_labels.todo_id = todo_list.id) ASC, updated_date DESC",
+			wantArgs: []interface{}{"userID1"},
+		},
+		{
+			name: "hostile todo content and label",
+			criteria: model.TodoSearchCriteria{
+				TodoContent: `100%_off" OR "1"="1`,
+				Label:       `x') OR 1=1; DROP TABLE todo_list; --`,
+			},
+			want: "FROM todo_list WHERE user_id = ? AND todo_content LIKE ?" +
+				" AND EXISTS (SELECT id FROM todo_labels WHERE todo_labels.todo_id = todo_list.id AND label_name = ?)" +
+				" ORDER BY implementation_date ASC, updated_date DESC",
+			wantArgs: []interface{}{"userID1", `%100\%\_off" OR "1"="1%`, `x') OR 1=1; DROP TABLE todo_list; --`},
+		},
+		{
+			name: "hostile date type, sort and sort type",
+			criteria: model.TodoSearchCriteria{
+				DateType:  "implementation_date; DROP TABLE todo_list; --",
+				StartDate: model.NullTime{NullTime: sql.NullTime{Time: startDate, Valid: true}},
+				Sort:      "(SELECT SLEEP(10))",
+				SortType:  "ASC, (SELECT SLEEP(10))",
+			},
+			want:     "FROM todo_list WHERE user_id = ? AND implementation_date >= ? ORDER BY implementation_date ASC, updated_date DESC",
+			wantArgs: []interface{}{"userID1", startDate},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args := generateTodoSearchQuery(tt.criteria, "userID1")
+
+			if diff := cmp.Diff(tt.want, todoSearchQueryConditions(t, query)); len(diff) != 0 {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.wantArgs, args); len(diff) != 0 {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateGroupTodoSearchQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		criteria model.GroupTodoSearchCriteria
+		want     string
+		wantArgs []interface{}
+	}{
+		{
+			name:     "default ordering",
+			criteria: model.GroupTodoSearchCriteria{},
+			want:     "FROM group_todo_list WHERE group_id = ? ORDER BY implementation_date ASC, updated_date DESC",
+			wantArgs: []interface{}{1},
+		},
+		{
+			name: "hostile users ID and label",
+			criteria: model.GroupTodoSearchCriteria{
+				TodoSearchCriteria: model.TodoSearchCriteria{
+					Label: `" OR ""="`,
+					Sort:  model.TodoSearchSortLabel,
+				},
+				UsersID: []string{"userID1", `userID2") OR ("1"="1`},
+			},
+			want: "FROM group_todo_list WHERE group_id = ? AND user_id IN (?, ?)" +
+				" AND EXISTS (SELECT id FROM group_todo_labels WHERE group_todo_labels.todo_id = group_todo_list.id AND label_name = ?)" +
+				" ORDER BY (SELECT MIN(label_name) FROM group_todo_labels WHERE group_todo_labels.todo_id = group_todo_list.id) ASC, updated_date DESC",
+			wantArgs: []interface{}{1, "userID1", `userID2") OR ("1"="1`, `" OR ""="`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args := generateGroupTodoSearchQuery(tt.criteria, 1)
+
+			if diff := cmp.Diff(tt.want, todoSearchQueryConditions(t, query)); len(diff) != 0 {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.wantArgs, args); len(diff) != 0 {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,412 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/hryze/kakeibo-app-api/todo-rest-service/domain/model"
+)
+
+type TransactionOutboxRepository struct {
+	*MySQLHandler
+}
+
+func NewTransactionOutboxRepository(mysqlHandler *MySQLHandler) *TransactionOutboxRepository {
+	return &TransactionOutboxRepository{mysqlHandler}
+}
+
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// postTransactionCommand is shared with the shopping list repositories,
+// which insert a transaction command in the same transaction as the shopping item.
+func postTransactionCommand(conn execer, transactionCommand *model.TransactionCommand) (sql.Result, error) {
+	query := `
+        INSERT INTO transaction_outbox
+            (command, user_id, group_id, shopping_item_id, transaction_id, idempotency_key, payload, status, attempts, next_attempt_date)
+        VALUES
+            (?,?,?,?,?,?,?,?,?,?)`
+
+	result, err := conn.Exec(
+		query,
+		transactionCommand.Command,
+		transactionCommand.UserID,
+		transactionCommand.GroupID,
+		transactionCommand.ShoppingItemID,
+		transactionCommand.TransactionID,
+		transactionCommand.IdempotencyKey,
+		transactionCommand.Payload,
+		transactionCommand.Status,
+		transactionCommand.Attempts,
+		transactionCommand.NextAttemptDate,
+	)
+
+	return result, err
+}
+
+func (r *TransactionOutboxRepository) PostTransactionCommand(transactionCommand *model.TransactionCommand) (sql.Result, error) {
+	return postTransactionCommand(r.MySQLHandler.conn, transactionCommand)
+}
+
+func (r *TransactionOutboxRepository) GetPendingTransactionCommandList(now time.Time, limit int) ([]model.TransactionCommand, error) {
+	query := `
+        SELECT
+            id,
+            command,
+            user_id,
+            group_id,
+            shopping_item_id,
+            transaction_id,
+            idempotency_key,
+            payload,
+            status,
+            attempts,
+            next_attempt_date,
+            last_error
+        FROM
+            transaction_outbox
+        WHERE
+            status = 'pending'
+        AND
+            next_attempt_date <= ?
+        ORDER BY
+            id
+        LIMIT ?`
+
+	rows, err := r.MySQLHandler.conn.Queryx(query, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transactionCommandList []model.TransactionCommand
+	for rows.Next() {
+		var transactionCommand model.TransactionCommand
+		if err := rows.StructScan(&transactionCommand); err != nil {
+			return nil, err
+		}
+
+		transactionCommandList = append(transactionCommandList, transactionCommand)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return transactionCommandList, nil
+}
+
+func (r *TransactionOutboxRepository) GetUndeliveredTransactionCommandList(userID string) ([]model.TransactionCommand, error) {
+	query := `
+        SELECT
+            id,
+            command,
+            user_id,
+            group_id,
+            shopping_item_id,
+            transaction_id,
+            idempotency_key,
+            payload,
+            status,
+            attempts,
+            next_attempt_date,
+            last_error
+        FROM
+            transaction_outbox
+        WHERE
+            user_id = ?
+        AND
+            group_id IS NULL
+        AND
+            status IN ('pending', 'failed')
+        ORDER BY
+            id`
+
+	rows, err := r.MySQLHandler.conn.Queryx(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transactionCommandList []model.TransactionCommand
+	for rows.Next() {
+		var transactionCommand model.TransactionCommand
+		if err := rows.StructScan(&transactionCommand); err != nil {
+			return nil, err
+		}
+
+		transactionCommandList = append(transactionCommandList, transactionCommand)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return transactionCommandList, nil
+}
+
+func (r *TransactionOutboxRepository) GetUndeliveredGroupTransactionCommandList(groupID int) ([]model.TransactionCommand, error) {
+	query := `
+        SELECT
+            id,
+            command,
+            user_id,
+            group_id,
+            shopping_item_id,
+            transaction_id,
+            idempotency_key,
+            payload,
+            status,
+            attempts,
+            next_attempt_date,
+            last_error
+        FROM
+            transaction_outbox
+        WHERE
+            group_id = ?
+        AND
+            status IN ('pending', 'failed')
+        ORDER BY
+            id`
+
+	rows, err := r.MySQLHandler.conn.Queryx(query, groupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transactionCommandList []model.TransactionCommand
+	for rows.Next() {
+		var transactionCommand model.TransactionCommand
+		if err := rows.StructScan(&transactionCommand); err != nil {
+			return nil, err
+		}
+
+		transactionCommandList = append(transactionCommandList, transactionCommand)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return transactionCommandList, nil
+}
+
+// LeaseTransactionCommand holds off the other deliveries of the transaction command until leaseDate.
+// The transaction command is not leased while an earlier command for the same shopping item is pending,
+// so that the commands for a shopping item are delivered in the order they were issued.
+func (r *TransactionOutboxRepository) LeaseTransactionCommand(transactionCommand *model.TransactionCommand, leaseDate time.Time) (bool, error) {
+	precedingQuery := `
+        SELECT
+            COUNT(id)
+        FROM
+            transaction_outbox
+        WHERE
+            shopping_item_id = ?
+        AND
+            group_id <=> ?
+        AND
+            status = 'pending'
+        AND
+            id < ?
+        FOR UPDATE`
+
+	leaseQuery := `
+        UPDATE
+            transaction_outbox
+        SET
+            next_attempt_date = ?
+        WHERE
+            id = ?
+        AND
+            status = 'pending'
+        AND
+            next_attempt_date = ?`
+
+	tx, err := r.MySQLHandler.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	var leased bool
+	transactions := func(tx *sql.Tx) error {
+		var precedingCount int
+		if err := tx.QueryRow(precedingQuery, transactionCommand.ShoppingItemID, transactionCommand.GroupID, transactionCommand.ID).Scan(&precedingCount); err != nil {
+			return err
+		}
+
+		if precedingCount != 0 {
+			return nil
+		}
+
+		result, err := tx.Exec(leaseQuery, leaseDate, transactionCommand.ID, transactionCommand.NextAttemptDate)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		leased = rowsAffected != 0
+
+		return nil
+	}
+
+	if err := transactions(tx); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return false, err
+		}
+
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+
+	if leased {
+		transactionCommand.NextAttemptDate = leaseDate
+	}
+
+	return leased, nil
+}
+
+// DeliverTransactionCommand marks the transaction command as delivered, and links the posted transaction to the shopping item.
+// When the shopping item has been uncompleted or linked to another transaction in the meantime,
+// a delete command is issued instead so that the posted transaction is not left behind.
+func (r *TransactionOutboxRepository) DeliverTransactionCommand(transactionCommand *model.TransactionCommand) error {
+	shoppingListTable := "shopping_list"
+	if transactionCommand.GroupID.Valid {
+		shoppingListTable = "group_shopping_list"
+	}
+
+	deliverQuery := `
+        UPDATE
+            transaction_outbox
+        SET
+            status = 'delivered',
+            transaction_id = ?,
+            attempts = ?,
+            last_error = NULL
+        WHERE
+            id = ?`
+
+	shoppingItemQuery := fmt.Sprintf(`
+        SELECT
+            complete_flag,
+            transaction_id
+        FROM
+            %s
+        WHERE
+            id = ?
+        FOR UPDATE`, shoppingListTable)
+
+	linkQuery := fmt.Sprintf(`
+        UPDATE
+            %s
+        SET
+            transaction_id = ?
+        WHERE
+            id = ?`, shoppingListTable)
+
+	tx, err := r.MySQLHandler.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	transactions := func(tx *sql.Tx) error {
+		if _, err := tx.Exec(deliverQuery, transactionCommand.TransactionID, transactionCommand.Attempts, transactionCommand.ID); err != nil {
+			return err
+		}
+
+		if transactionCommand.Command != "create" {
+			return nil
+		}
+
+		var completeFlag model.BitBool
+		var transactionID model.NullInt64
+		if err := tx.QueryRow(shoppingItemQuery, transactionCommand.ShoppingItemID).Scan(&completeFlag, &transactionID); err != nil {
+			// The transaction posted for a deleted shopping item is kept, as well as the transaction linked to it.
+			if err == sql.ErrNoRows {
+				return nil
+			}
+
+			return err
+		}
+
+		if transactionID.Valid && transactionID.Int64 == transactionCommand.TransactionID.Int64 {
+			return nil
+		}
+
+		if bool(completeFlag) && !transactionID.Valid {
+			if _, err := tx.Exec(linkQuery, transactionCommand.TransactionID, transactionCommand.ShoppingItemID); err != nil {
+				return err
+			}
+
+			return nil
+		}
+
+		deleteCommand := model.TransactionCommand{
+			Command:         "delete",
+			UserID:          transactionCommand.UserID,
+			GroupID:         transactionCommand.GroupID,
+			ShoppingItemID:  transactionCommand.ShoppingItemID,
+			TransactionID:   transactionCommand.TransactionID,
+			IdempotencyKey:  transactionCommand.IdempotencyKey,
+			Status:          "pending",
+			NextAttemptDate: transactionCommand.NextAttemptDate,
+		}
+
+		if _, err := postTransactionCommand(tx, &deleteCommand); err != nil {
+			return err
+		}
+
+		return nil
+	}
+
+	if err := transactions(tx); err != nil {
+		if err := tx.Rollback(); err != nil {
+			return err
+		}
+
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *TransactionOutboxRepository) PutTransactionCommand(transactionCommand *model.TransactionCommand) error {
+	query := `
+        UPDATE
+            transaction_outbox
+        SET
+            transaction_id = ?,
+            payload = ?,
+            status = ?,
+            attempts = ?,
+            next_attempt_date = ?,
+            last_error = ?
+        WHERE
+            id = ?`
+
+	_, err := r.MySQLHandler.conn.Exec(
+		query,
+		transactionCommand.TransactionID,
+		transactionCommand.Payload,
+		transactionCommand.Status,
+		transactionCommand.Attempts,
+		transactionCommand.NextAttemptDate,
+		transactionCommand.LastError,
+		transactionCommand.ID,
+	)
+
+	return err
+}
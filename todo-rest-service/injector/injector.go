@@ -32,11 +32,14 @@ func InjectDBHandler() *handler.DBHandler {
 	return &handler.DBHandler{
 		HealthRepo:            infrastructure.NewHealthRepository(InjectRedis(), InjectMySQL()),
 		AuthRepo:              infrastructure.NewAuthRepository(InjectRedis()),
+		SchedulerLockRepo:     infrastructure.NewSchedulerLockRepository(InjectRedis()),
 		TodoRepo:              infrastructure.NewTodoRepository(InjectMySQL()),
 		ShoppingListRepo:      infrastructure.NewShoppingListRepository(InjectMySQL()),
 		GroupTodoRepo:         infrastructure.NewGroupTodoRepository(InjectMySQL()),
 		GroupShoppingListRepo: infrastructure.NewGroupShoppingListRepository(InjectMySQL()),
 		GroupTasksRepo:        infrastructure.NewGroupTasksRepository(InjectMySQL()),
+		TransactionOutboxRepo: infrastructure.NewTransactionOutboxRepository(InjectMySQL()),
+		CalendarFeedRepo:      infrastructure.NewCalendarFeedRepository(InjectMySQL()),
 		TimeManage:            handler.NewRealTime(),
 	}
 }
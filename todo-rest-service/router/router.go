@@ -14,6 +14,7 @@ import (
 	"github.com/rs/cors"
 
 	"github.com/hryze/kakeibo-app-api/todo-rest-service/config"
+	"github.com/hryze/kakeibo-app-api/todo-rest-service/handler"
 	"github.com/hryze/kakeibo-app-api/todo-rest-service/injector"
 )
 
@@ -21,7 +22,12 @@ func Run() error {
 	h := injector.InjectDBHandler()
 
 	router := mux.NewRouter()
+	router.Use(handler.LocaleMiddleware)
 	router.HandleFunc("/readyz", h.Readyz).Methods("GET")
+	router.HandleFunc("/dashboard", h.GetDashboard).Methods("GET")
+	router.HandleFunc("/calendar/{token:[0-9a-f]{64}}.ics", h.GetCalendarFeed).Methods("GET")
+	router.HandleFunc("/calendar/token", h.PostCalendarFeedToken).Methods("POST")
+	router.HandleFunc("/calendar/token", h.DeleteCalendarFeedToken).Methods("DELETE")
 	router.HandleFunc("/todo-list/{date:[0-9]{4}-[0-9]{2}-[0-9]{2}}", h.GetDailyTodoList).Methods("GET")
 	router.HandleFunc("/todo-list/{year_month:[0-9]{4}-[0-9]{2}}", h.GetMonthlyTodoList).Methods("GET")
 	router.HandleFunc("/todo-list/expired", h.GetExpiredTodoList).Methods("GET")
@@ -33,17 +39,30 @@ func Run() error {
 	router.HandleFunc("/shopping-list/{date:[0-9]{4}-[0-9]{2}-[0-9]{2}}/categories", h.GetDailyShoppingDataByCategory).Methods("GET")
 	router.HandleFunc("/shopping-list/{year_month:[0-9]{4}-[0-9]{2}}/daily", h.GetMonthlyShoppingDataByDay).Methods("GET")
 	router.HandleFunc("/shopping-list/{year_month:[0-9]{4}-[0-9]{2}}/categories", h.GetMonthlyShoppingDataByCategory).Methods("GET")
+	router.HandleFunc("/shopping-list/{date:[0-9]{4}-[0-9]{2}-[0-9]{2}}/shops", h.GetDailyShoppingDataByShop).Methods("GET")
+	router.HandleFunc("/shopping-list/{year_month:[0-9]{4}-[0-9]{2}}/shops", h.GetMonthlyShoppingDataByShop).Methods("GET")
+	router.HandleFunc("/shopping-list/transactions/reconciliation", h.GetShoppingListTransactionReconciliation).Methods("GET")
+	router.HandleFunc("/shopping-list/transactions/reconciliation", h.PostShoppingListTransactionReconciliation).Methods("POST")
+	router.HandleFunc("/shopping-list/shops/sections", h.GetShopSectionOrderList).Methods("GET")
+	router.HandleFunc("/shopping-list/shops/sections", h.PutShopSectionOrder).Methods("PUT")
 	router.HandleFunc("/shopping-list/expired", h.GetExpiredShoppingList).Methods("GET")
+	router.HandleFunc("/shopping-list/prices", h.GetShoppingItemPriceHistoryList).Methods("GET")
 	router.HandleFunc("/shopping-list/regular", h.PostRegularShoppingItem).Methods("POST")
 	router.HandleFunc("/shopping-list/regular/{id:[0-9]+}", h.PutRegularShoppingItem).Methods("PUT")
 	router.HandleFunc("/shopping-list/regular/{id:[0-9]+}", h.DeleteRegularShoppingItem).Methods("DELETE")
+	router.HandleFunc("/shopping-list/regular/{id:[0-9]+}/skip", h.SkipRegularShoppingItem).Methods("PUT")
+	router.HandleFunc("/shopping-list/regular/{id:[0-9]+}/pause", h.PauseRegularShoppingItem).Methods("PUT")
 	router.HandleFunc("/shopping-list", h.PostShoppingItem).Methods("POST")
 	router.HandleFunc("/shopping-list/{id:[0-9]+}", h.PutShoppingItem).Methods("PUT")
 	router.HandleFunc("/shopping-list/{id:[0-9]+}", h.DeleteShoppingItem).Methods("DELETE")
 	router.HandleFunc("/shopping-list/categories", h.PutShoppingListCustomCategoryIdToMediumCategoryId).Methods("PUT")
+	router.HandleFunc("/shopping-list/categories/merge", h.MergeShoppingListCustomCategory).Methods("PUT")
+	router.HandleFunc("/shopping-list/categories/merge/revert", h.RevertShoppingListCustomCategoryMerge).Methods("PUT")
+	router.HandleFunc("/groups/todo-list/assigned", h.GetAssignedGroupTodoList).Methods("GET")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/todo-list/{date:[0-9]{4}-[0-9]{2}-[0-9]{2}}", h.GetDailyGroupTodoList).Methods("GET")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/todo-list/{year_month:[0-9]{4}-[0-9]{2}}", h.GetMonthlyGroupTodoList).Methods("GET")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/todo-list/expired", h.GetExpiredGroupTodoList).Methods("GET")
+	router.HandleFunc("/groups/{group_id:[0-9]+}/todo-list/users/{user_id:[\\S]{1,10}}/expired", h.GetExpiredAssignedGroupTodoList).Methods("GET")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/todo-list", h.PostGroupTodo).Methods("POST")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/todo-list/{id:[0-9]+}", h.PutGroupTodo).Methods("PUT")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/todo-list/{id:[0-9]+}", h.DeleteGroupTodo).Methods("DELETE")
@@ -52,17 +71,30 @@ func Run() error {
 	router.HandleFunc("/groups/{group_id:[0-9]+}/shopping-list/{date:[0-9]{4}-[0-9]{2}-[0-9]{2}}/categories", h.GetDailyGroupShoppingDataByCategory).Methods("GET")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/shopping-list/{year_month:[0-9]{4}-[0-9]{2}}/daily", h.GetMonthlyGroupShoppingDataByDay).Methods("GET")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/shopping-list/{year_month:[0-9]{4}-[0-9]{2}}/categories", h.GetMonthlyGroupShoppingDataByCategory).Methods("GET")
+	router.HandleFunc("/groups/{group_id:[0-9]+}/shopping-list/{date:[0-9]{4}-[0-9]{2}-[0-9]{2}}/shops", h.GetDailyGroupShoppingDataByShop).Methods("GET")
+	router.HandleFunc("/groups/{group_id:[0-9]+}/shopping-list/{year_month:[0-9]{4}-[0-9]{2}}/shops", h.GetMonthlyGroupShoppingDataByShop).Methods("GET")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/shopping-list/expired", h.GetExpiredGroupShoppingList).Methods("GET")
+	router.HandleFunc("/groups/{group_id:[0-9]+}/shopping-list/claimed", h.GetClaimedGroupShoppingList).Methods("GET")
+	router.HandleFunc("/groups/{group_id:[0-9]+}/shopping-list/transactions/reconciliation", h.GetGroupShoppingListTransactionReconciliation).Methods("GET")
+	router.HandleFunc("/groups/{group_id:[0-9]+}/shopping-list/transactions/reconciliation", h.PostGroupShoppingListTransactionReconciliation).Methods("POST")
+	router.HandleFunc("/groups/{group_id:[0-9]+}/shopping-list/prices", h.GetGroupShoppingItemPriceHistoryList).Methods("GET")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/shopping-list/regular", h.PostGroupRegularShoppingItem).Methods("POST")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/shopping-list/regular/{id:[0-9]+}", h.PutGroupRegularShoppingItem).Methods("PUT")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/shopping-list/regular/{id:[0-9]+}", h.DeleteGroupRegularShoppingItem).Methods("DELETE")
+	router.HandleFunc("/groups/{group_id:[0-9]+}/shopping-list/regular/{id:[0-9]+}/skip", h.SkipGroupRegularShoppingItem).Methods("PUT")
+	router.HandleFunc("/groups/{group_id:[0-9]+}/shopping-list/regular/{id:[0-9]+}/pause", h.PauseGroupRegularShoppingItem).Methods("PUT")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/shopping-list", h.PostGroupShoppingItem).Methods("POST")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/shopping-list/{id:[0-9]+}", h.PutGroupShoppingItem).Methods("PUT")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/shopping-list/{id:[0-9]+}", h.DeleteGroupShoppingItem).Methods("DELETE")
+	router.HandleFunc("/groups/{group_id:[0-9]+}/shopping-list/{id:[0-9]+}/claim", h.ClaimGroupShoppingItem).Methods("PUT")
+	router.HandleFunc("/groups/{group_id:[0-9]+}/shopping-list/{id:[0-9]+}/unclaim", h.UnclaimGroupShoppingItem).Methods("PUT")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/shopping-list/categories", h.PutGroupShoppingListCustomCategoryIdToMediumCategoryId).Methods("PUT")
+	router.HandleFunc("/groups/{group_id:[0-9]+}/calendar/token", h.PostGroupCalendarFeedToken).Methods("POST")
+	router.HandleFunc("/groups/{group_id:[0-9]+}/calendar/token", h.DeleteGroupCalendarFeedToken).Methods("DELETE")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/tasks/users", h.GetGroupTasksListForEachUser).Methods("GET")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/tasks/users", h.PostGroupTasksUsersList).Methods("POST")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/tasks/users", h.DeleteGroupTasksUsersList).Methods("DELETE")
+	router.HandleFunc("/groups/{group_id:[0-9]+}/tasks/schedule", h.GetGroupTasksSchedule).Methods("GET")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/tasks", h.GetGroupTasksList).Methods("GET")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/tasks", h.PostGroupTask).Methods("POST")
 	router.HandleFunc("/groups/{group_id:[0-9]+}/tasks/{id:[0-9]+}", h.PutGroupTask).Methods("PUT")
@@ -80,6 +112,12 @@ func Run() error {
 		Handler: corsWrapper.Handler(router),
 	}
 
+	workerCtx, stopWorker := context.WithCancel(context.Background())
+	defer stopWorker()
+
+	go h.RunTransactionOutboxWorker(workerCtx)
+	go h.RunRegularShoppingScheduler(workerCtx)
+
 	errorCh := make(chan error, 1)
 	go func() {
 		if err := srv.ListenAndServe(); err != http.ErrServerClosed {
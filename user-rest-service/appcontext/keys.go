@@ -5,4 +5,5 @@ type contextKey string
 const (
 	userIDKey   contextKey = "USER_ID"
 	appErrorKey contextKey = "APP_ERROR"
+	localeKey   contextKey = "LOCALE"
 )
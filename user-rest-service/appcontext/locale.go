@@ -0,0 +1,13 @@
+package appcontext
+
+import "context"
+
+func SetLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey, locale)
+}
+
+func GetLocale(ctx context.Context) (string, bool) {
+	locale, ok := ctx.Value(localeKey).(string)
+
+	return locale, ok
+}
@@ -23,14 +23,14 @@ func NewAuthMiddlewareFunc(sessionStore sessionstore.SessionStore) func(http.Han
 
 			cookie, err := r.Cookie(config.Env.Cookie.Name)
 			if xerrors.Is(err, http.ErrNoCookie) {
-				presenter.ErrorJSON(w, apierrors.NewAuthenticationError(apierrors.NewErrorString("このページを表示するにはログインが必要です")))
+				presenter.ErrorJSON(w, r, apierrors.NewAuthenticationError(apierrors.NewErrorString("このページを表示するにはログインが必要です")))
 				return
 			}
 
 			sessionID := cookie.Value
 			userID, err := sessionStore.FetchUserByUserID(sessionID)
 			if err != nil {
-				presenter.ErrorJSON(w, err)
+				presenter.ErrorJSON(w, r, err)
 				return
 			}
 
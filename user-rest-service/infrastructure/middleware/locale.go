@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hryze/kakeibo-app-api/user-rest-service/appcontext"
+	"github.com/hryze/kakeibo-app-api/user-rest-service/interfaces/presenter"
+)
+
+func NewLocaleMiddlewareFunc() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			locale := negotiateLocale(r.Header.Get("Accept-Language"))
+
+			w.Header().Set("Content-Language", locale)
+			next.ServeHTTP(w, r.WithContext(appcontext.SetLocale(r.Context(), locale)))
+		})
+	}
+}
+
+// negotiateLocale returns the supported locale with the highest quality value in the Accept-Language header,
+// or the default locale if none of them is supported.
+func negotiateLocale(acceptLanguage string) string {
+	locale := presenter.DefaultLocale
+	maxQuality := 0.0
+	for _, languageRange := range strings.Split(acceptLanguage, ",") {
+		params := strings.Split(languageRange, ";")
+
+		quality := 1.0
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if !strings.HasPrefix(param, "q=") {
+				continue
+			}
+
+			q, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64)
+			if err != nil {
+				q = 0
+			}
+
+			quality = q
+		}
+
+		language := strings.ToLower(strings.SplitN(strings.TrimSpace(params[0]), "-", 2)[0])
+		if !presenter.IsSupportedLocale(language) || quality <= maxQuality {
+			continue
+		}
+
+		locale, maxQuality = language, quality
+	}
+
+	return locale
+}
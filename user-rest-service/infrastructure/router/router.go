@@ -60,6 +60,7 @@ func Run() error {
 	router := mux.NewRouter()
 
 	// Register middlewares.
+	router.Use(middleware.NewLocaleMiddlewareFunc())
 	router.Use(middleware.NewAuthMiddlewareFunc(sessionStore))
 	router.Use(middleware.NewLoggingMiddlewareFunc())
 
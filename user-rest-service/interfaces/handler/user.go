@@ -27,29 +27,29 @@ func NewUserHandler(userUsecase usecase.UserUsecase) *userHandler {
 func (h *userHandler) SignUp(w http.ResponseWriter, r *http.Request) {
 	var in input.SignUpUser
 	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
-		presenter.ErrorJSON(w, apierrors.NewBadRequestError(apierrors.NewErrorString("正しいデータを入力してください")))
+		presenter.ErrorJSON(w, r, apierrors.NewBadRequestError(apierrors.NewErrorString("正しいデータを入力してください")))
 		return
 	}
 
 	out, err := h.userUsecase.SignUp(&in)
 	if err != nil {
-		presenter.ErrorJSON(w, err)
+		presenter.ErrorJSON(w, r, err)
 		return
 	}
 
-	presenter.JSON(w, http.StatusCreated, out)
+	presenter.JSON(w, r, http.StatusCreated, out)
 }
 
 func (h *userHandler) Login(w http.ResponseWriter, r *http.Request) {
 	var in input.LoginUser
 	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
-		presenter.ErrorJSON(w, apierrors.NewBadRequestError(apierrors.NewErrorString("正しいデータを入力してください")))
+		presenter.ErrorJSON(w, r, apierrors.NewBadRequestError(apierrors.NewErrorString("正しいデータを入力してください")))
 		return
 	}
 
 	out, err := h.userUsecase.Login(&in)
 	if err != nil {
-		presenter.ErrorJSON(w, err)
+		presenter.ErrorJSON(w, r, err)
 		return
 	}
 
@@ -62,41 +62,41 @@ func (h *userHandler) Login(w http.ResponseWriter, r *http.Request) {
 		HttpOnly: true,
 	})
 
-	presenter.JSON(w, http.StatusCreated, out)
+	presenter.JSON(w, r, http.StatusCreated, out)
 }
 
 func (h *userHandler) Logout(w http.ResponseWriter, r *http.Request) {
 	cookie, err := r.Cookie(config.Env.Cookie.Name)
 	if xerrors.Is(err, http.ErrNoCookie) {
-		presenter.ErrorJSON(w, apierrors.NewBadRequestError(apierrors.NewErrorString("ログアウト済みです")))
+		presenter.ErrorJSON(w, r, apierrors.NewBadRequestError(apierrors.NewErrorString("ログアウト済みです")))
 		return
 	}
 
 	cookieInfo := &input.CookieInfo{SessionID: cookie.Value}
 
 	if err := h.userUsecase.Logout(cookieInfo); err != nil {
-		presenter.ErrorJSON(w, err)
+		presenter.ErrorJSON(w, r, err)
 		return
 	}
 
 	cookie.MaxAge = -1
 	http.SetCookie(w, cookie)
 
-	presenter.JSON(w, http.StatusOK, presenter.NewSuccessString("ログアウトしました"))
+	presenter.JSON(w, r, http.StatusOK, presenter.NewSuccessString("ログアウトしました"))
 }
 
 func (h *userHandler) FetchLoginUser(w http.ResponseWriter, r *http.Request) {
 	authenticatedUser, err := getUserIDForContext(r)
 	if err != nil {
-		presenter.ErrorJSON(w, err)
+		presenter.ErrorJSON(w, r, err)
 		return
 	}
 
 	out, err := h.userUsecase.FetchLoginUser(authenticatedUser)
 	if err != nil {
-		presenter.ErrorJSON(w, err)
+		presenter.ErrorJSON(w, r, err)
 		return
 	}
 
-	presenter.JSON(w, http.StatusOK, out)
+	presenter.JSON(w, r, http.StatusOK, out)
 }
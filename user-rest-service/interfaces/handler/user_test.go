@@ -10,6 +10,7 @@ import (
 
 	"github.com/hryze/kakeibo-app-api/user-rest-service/appcontext"
 	"github.com/hryze/kakeibo-app-api/user-rest-service/config"
+	"github.com/hryze/kakeibo-app-api/user-rest-service/infrastructure/middleware"
 	"github.com/hryze/kakeibo-app-api/user-rest-service/interfaces/presenter"
 	"github.com/hryze/kakeibo-app-api/user-rest-service/testutil"
 	"github.com/hryze/kakeibo-app-api/user-rest-service/usecase/input"
@@ -103,6 +104,31 @@ func Test_userHandler_Logout(t *testing.T) {
 	testutil.AssertDeleteResponseCookie(t, res)
 }
 
+func Test_userHandler_LogoutInEnglish(t *testing.T) {
+	h := NewUserHandler(&mockUserUsecase{})
+
+	r := httptest.NewRequest(http.MethodDelete, "/logout", nil)
+	w := httptest.NewRecorder()
+
+	r.Header.Set("Accept-Language", "en-US,en;q=0.9,ja;q=0.8")
+
+	cookie := &http.Cookie{
+		Name:  config.Env.Cookie.Name,
+		Value: uuid.New().String(),
+	}
+
+	r.AddCookie(cookie)
+
+	middleware.NewLocaleMiddlewareFunc()(http.HandlerFunc(h.Logout)).ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	testutil.AssertResponseHeader(t, res, http.StatusOK)
+	testutil.AssertResponseBody(t, res, presenter.NewSuccessString(""), presenter.NewSuccessString(""))
+	testutil.AssertDeleteResponseCookie(t, res)
+}
+
 func Test_userHandler_FetchLoginUser(t *testing.T) {
 	h := NewUserHandler(&mockUserUsecase{})
 
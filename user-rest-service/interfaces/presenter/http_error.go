@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"log"
 	"net/http"
+	"reflect"
 
 	"github.com/hryze/kakeibo-app-api/user-rest-service/apperrors"
 
@@ -55,8 +56,11 @@ func newHTTPError(err error) *httpError {
 	}
 }
 
-func ErrorJSON(w http.ResponseWriter, err error) {
+func ErrorJSON(w http.ResponseWriter, r *http.Request, err error) {
 	httpError := newHTTPError(err)
+	if locale := requestLocale(r); locale != DefaultLocale {
+		localizeMessageFields(reflect.ValueOf(httpError), locale)
+	}
 
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(httpError.StatusCode)
@@ -65,7 +69,7 @@ func ErrorJSON(w http.ResponseWriter, err error) {
 	}
 }
 
-func ErrorJSONV2(w http.ResponseWriter, err error) {
+func ErrorJSONV2(w http.ResponseWriter, r *http.Request, err error) {
 	appErr := apperrors.AsAppError(err)
 
 	httpErr := &httpError{
@@ -73,6 +77,10 @@ func ErrorJSONV2(w http.ResponseWriter, err error) {
 		ErrorMessage: appErr.InfoMessage(),
 	}
 
+	if locale := requestLocale(r); locale != DefaultLocale {
+		localizeMessageFields(reflect.ValueOf(httpErr), locale)
+	}
+
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(httpErr.StatusCode)
 	if err := json.NewEncoder(w).Encode(httpErr); err != nil {
@@ -13,7 +13,11 @@ func NewSuccessString(message string) *successString {
 	return &successString{Message: message}
 }
 
-func JSON(w http.ResponseWriter, statusCode int, data interface{}) {
+func JSON(w http.ResponseWriter, r *http.Request, statusCode int, data interface{}) {
+	if message, ok := data.(*successString); ok {
+		message.Message = translateMessage(requestLocale(r), message.Message)
+	}
+
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(statusCode)
 	if err := json.NewEncoder(w).Encode(data); err != nil {
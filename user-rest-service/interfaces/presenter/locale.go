@@ -0,0 +1,60 @@
+package presenter
+
+import (
+	"net/http"
+	"reflect"
+
+	"github.com/hryze/kakeibo-app-api/user-rest-service/appcontext"
+)
+
+const (
+	JapaneseLocale = "ja"
+	EnglishLocale  = "en"
+	DefaultLocale  = JapaneseLocale
+)
+
+func IsSupportedLocale(locale string) bool {
+	return locale == JapaneseLocale || locale == EnglishLocale
+}
+
+// requestLocale returns the locale stored in the request context by the locale middleware,
+// or the default locale if there is none.
+func requestLocale(r *http.Request) string {
+	if locale, ok := appcontext.GetLocale(r.Context()); ok && IsSupportedLocale(locale) {
+		return locale
+	}
+
+	return DefaultLocale
+}
+
+// translateMessage looks up the message catalogue by the Japanese message.
+// Messages which are not in the catalogue are returned as they are.
+func translateMessage(locale string, message string) string {
+	if translatedMessage, ok := messageCatalogue[locale][message]; ok {
+		return translatedMessage
+	}
+
+	return message
+}
+
+// localizeMessageFields translates every settable string reachable from v in place.
+func localizeMessageFields(v reflect.Value, locale string) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			localizeMessageFields(v.Elem(), locale)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			localizeMessageFields(v.Field(i), locale)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			localizeMessageFields(v.Index(i), locale)
+		}
+	case reflect.String:
+		if v.CanSet() {
+			v.SetString(translateMessage(locale, v.String()))
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package presenter
+
+// messageCatalogue maps each Japanese message to its translation by locale.
+var messageCatalogue = map[string]map[string]string{
+	EnglishLocale: {
+		// common
+		"このページを表示するにはログインが必要です": "You need to log in to view this page",
+		"正しいデータを入力してください":       "Please enter valid data",
+
+		// user
+		"名前を正しく入力してください":       "Please enter a valid name",
+		"メールアドレスを正しく入力してください":  "Please enter a valid email address",
+		"パスワードを正しく入力してください":    "Please enter a valid password",
+		"ユーザーIDを正しく入力してください":   "Please enter a valid user ID",
+		"名前が正しくありません":          "The name is incorrect",
+		"メールアドレスが正しくありません":     "The email address is incorrect",
+		"パスワードが正しくありません":       "The password is incorrect",
+		"ユーザーIDが正しくありません":      "The user ID is incorrect",
+		"このユーザーIDは既に利用されています":  "This user ID is already in use",
+		"このメールアドレスは既に利用されています": "This email address is already in use",
+		"該当するユーザーが見つかりませんでした":  "No matching user was found",
+		"認証に失敗しました":            "Authentication failed",
+		"ログアウトしました":            "You have logged out",
+		"ログアウト済みです":            "You have already logged out",
+
+		// group
+		"グループIDを正しく指定してください":            "Please specify a valid group ID",
+		"グループIDは1以上の整数で指定してください":        "Please specify an integer of 1 or more for the group ID",
+		"グループ名は1文字以上、20文字以内で入力してください":   "Please enter a group name of 1 to 20 characters",
+		"文字列先頭に空白がないか確認してください":          "Please check that it does not start with a space",
+		"文字列末尾に空白がないか確認してください":          "Please check that it does not end with a space",
+		"指定されたグループは存在しません":              "The specified group does not exist",
+		"指定されたグループには、ユーザーは所属していません":     "The user does not belong to the specified group",
+		"こちらのグループには、指定されたユーザーは所属していません": "The specified users do not belong to this group",
+		"こちらのグループには参加していません":            "You have not joined this group",
+		"こちらのグループには招待されていません":           "You have not been invited to this group",
+		"こちらのユーザーは既にグループに参加しています":       "This user has already joined the group",
+		"こちらのユーザーは既にグループに招待しています":       "This user has already been invited to the group",
+		"ユーザーが存在しません":                   "The user does not exist",
+		"グループを退会しました":                   "You have left the group",
+		"グループ招待を拒否しました":                 "You have declined the group invitation",
+	},
+}